@@ -0,0 +1,196 @@
+package main
+
+import (
+	aga "business/internal/agency/application"
+	"business/internal/agency/domain"
+	"business/tools/scheduler"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"go.uber.org/dig"
+)
+
+// runAgencies は営業会社（差出人のドメイン）の台帳と取り込みのルールを管理します
+// サブコマンド: list / show / set / refresh。set の block は次の取り込みから解析せず、mute は通知・ダイジェストに載せません。
+// refresh は --every を指定した場合は常駐して一定間隔で実行します（Ctrl+C で終了）。
+func runAgencies(ctx context.Context, container *dig.Container, args []string) {
+	if len(args) == 0 {
+		printAgenciesUsage()
+		return
+	}
+
+	fs := flag.NewFlagSet("agencies "+args[0], flag.ContinueOnError)
+	query := fs.String("q", "", "list: ドメイン・表示名の部分一致")
+	rule := fs.String("rule", "", "list: ルールで絞り込む / set: ルール（none / block / mute / prioritize）")
+	sort := fs.String("sort", domain.SortEmails, "list: 並び順（emails / projects / good_rate / duplicate_rate / last_received）")
+	limit := fs.Int("limit", domain.DefaultListLimit, "list: 表示する営業会社の数")
+	name := fs.String("name", "", "set: 表示名")
+	note := fs.String("note", "", "set: メモ")
+	full := fs.Bool("full", false, "refresh: すべての営業会社を集計し直す")
+	every := fs.Duration("every", 0, "refresh: 指定した間隔で繰り返し実行する（例: 1h）")
+	if err := fs.Parse(args[1:]); err != nil {
+		return
+	}
+	rest := fs.Args()
+	// set は指定したフラグだけを変更する
+	given := map[string]bool{}
+	fs.Visit(func(f *flag.Flag) { given[f.Name] = true })
+
+	if args[0] == "refresh" {
+		runAgencyRefresh(ctx, container, *full, *every)
+		return
+	}
+
+	var agencies []domain.Agency
+	var senders []domain.Sender
+	var innerErr error
+	err := container.Invoke(func(agu *aga.UseCase) {
+		switch args[0] {
+		case "list":
+			agencies, innerErr = agu.List(domain.Filter{Query: *query, Rule: domain.Rule(*rule), Sort: *sort, Limit: *limit})
+		case "show", "set":
+			if len(rest) != 1 {
+				innerErr = fmt.Errorf("営業会社のドメインまたはメールアドレスを1つ指定してください")
+				return
+			}
+			var a domain.Agency
+			if args[0] == "show" {
+				a, senders, innerErr = agu.Get(rest[0])
+			} else {
+				s := domain.Settings{Domain: rest[0]}
+				if given["name"] {
+					s.Name = name
+				}
+				if given["rule"] {
+					r := domain.Rule(*rule)
+					s.Rule = &r
+				}
+				if given["note"] {
+					s.Note = note
+				}
+				a, innerErr = agu.Update(s)
+			}
+			agencies = []domain.Agency{a}
+		default:
+			innerErr = fmt.Errorf("不明なサブコマンドです: %s", args[0])
+		}
+	})
+	if innerErr != nil {
+		fmt.Printf("営業会社エラー: %v \n", innerErr)
+		if args[0] != "list" {
+			printAgenciesUsage()
+		}
+		return
+	}
+	if err != nil {
+		fmt.Printf("依存性注入に失敗しました。:%v \n", err)
+		return
+	}
+
+	if len(agencies) == 0 {
+		fmt.Println("営業会社はありません。（agencies refresh で集計を作成してください）")
+		return
+	}
+	if args[0] != "list" {
+		printAgency(agencies[0], senders)
+		return
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ドメイン\t表示名\tルール\tメール\t案件\t平均単価\t重複率\tいいね率\t応募率\t最終受信")
+	for _, a := range agencies {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%d\t%s\t%.1f%%\t%.1f%%\t%.1f%%\t%s\n",
+			a.Domain, a.Name, a.Rule, a.Emails, a.Projects, formatAveragePrice(a.AveragePrice),
+			a.DuplicateRate*100, a.GoodRate*100, a.ApplyRate*100, formatReceivedAt(a.LastReceivedAt))
+	}
+	_ = w.Flush()
+}
+
+// printAgency は営業会社の集計と差出人を表示します
+func printAgency(a domain.Agency, senders []domain.Sender) {
+	fmt.Printf("%s %s（ルール: %s）\n", a.Domain, a.Name, a.Rule)
+	if a.Note != "" {
+		fmt.Printf("  メモ: %s\n", a.Note)
+	}
+	fmt.Printf("  メール: %d通（案件 %d / 人材 %d、案件の割合 %.1f%%）、差出人 %d人、受信 %s 〜 %s\n",
+		a.Emails, a.ProjectEmails, a.CandidateEmails, a.ProjectShare*100, a.Senders,
+		formatReceivedAt(a.FirstReceivedAt), formatReceivedAt(a.LastReceivedAt))
+	fmt.Printf("  案件: %d件（単価の記載 %d件、平均単価 %s）\n", a.Projects, a.PricedProjects, formatAveragePrice(a.AveragePrice))
+	fmt.Printf("  重複 %d件（%.1f%%）、いいね %d件（%.1f%%）、応募 %d件（%.1f%%）、面談 %d件、決定 %d件\n",
+		a.DuplicateProjects, a.DuplicateRate*100, a.GoodProjects, a.GoodRate*100,
+		a.AppliedProjects, a.ApplyRate*100, a.InterviewProjects, a.WonProjects)
+	fmt.Printf("  連絡: %d日、追っての連絡・返信 %d通、募集終了 %d件、対応 %d件（受信から平均 %s）\n",
+		a.ActiveDays, a.ReplyEmails, a.ClosedProjects, a.RespondedProjects, formatResponseHours(a.AverageResponseHours))
+	for _, s := range senders {
+		fmt.Printf("    %s %s %d通（最終受信 %s）\n", s.Email, s.Name, s.Emails, s.LastReceivedAt.Format("2006-01-02 15:04"))
+	}
+}
+
+func formatAveragePrice(price *int) string {
+	if price == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%d円", *price)
+}
+
+func formatResponseHours(hours *int) string {
+	if hours == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%d時間", *hours)
+}
+
+func formatReceivedAt(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.Format("2006-01-02")
+}
+
+// runAgencyRefresh は前回の更新以降にメール・案件が保存・更新された営業会社の集計を更新します
+func runAgencyRefresh(ctx context.Context, container *dig.Container, full bool, every time.Duration) {
+	job := func(ctx context.Context) error {
+		var result domain.RefreshResult
+		var innerErr error
+		err := container.Invoke(func(agu *aga.UseCase) {
+			result, innerErr = agu.Refresh(full)
+		})
+		if innerErr != nil {
+			return innerErr
+		}
+		if err != nil {
+			return fmt.Errorf("依存性注入に失敗しました。:%w", err)
+		}
+
+		fmt.Printf("%s 営業会社%d社の集計を更新しました。\n", time.Now().Format("2006-01-02 15:04:05"), result.Agencies)
+		return nil
+	}
+	onError := func(err error) {
+		fmt.Printf("営業会社の集計エラー: %v \n", err)
+	}
+
+	if every <= 0 {
+		if err := job(ctx); err != nil {
+			onError(err)
+		}
+		return
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	fmt.Printf("%s ごとに営業会社の集計を更新します。（Ctrl+C で終了）\n", every)
+	scheduler.Every(ctx, every, job, onError)
+}
+
+func printAgenciesUsage() {
+	fmt.Println("使用方法:")
+	fmt.Println("  go run main.go agencies list [--q 部分一致] [--rule block] [--sort good_rate] [--limit 50] # 営業会社を品質の指標付きで表示")
+	fmt.Println("  go run main.go agencies show <ドメインまたはメールアドレス> # 営業会社の集計と差出人を表示")
+	fmt.Println("  go run main.go agencies set [--rule none|block|mute|prioritize] [--name 表示名] [--note メモ] <ドメイン> # 表示名・ルール・メモを設定")
+	fmt.Println("  go run main.go agencies refresh [--full] [--every 1h]      # 営業会社の集計を更新（--full ですべて集計し直す）")
+}
@@ -0,0 +1,217 @@
+package main
+
+import (
+	aa "business/internal/alert/application"
+	"business/internal/alert/domain"
+	"business/tools/scheduler"
+	"context"
+	"flag"
+	"fmt"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"go.uber.org/dig"
+)
+
+// runAlerts は保存した検索条件と新着案件の通知を管理します
+// サブコマンド: list / save / delete / run / notifications。save は同じ利用者に同じ名前の検索条件があれば置き換えます。
+// run は --every を指定した場合は常駐して一定間隔で実行します（Ctrl+C で終了）。
+func runAlerts(ctx context.Context, container *dig.Container, args []string) {
+	if len(args) == 0 {
+		printAlertsUsage()
+		return
+	}
+
+	fs := flag.NewFlagSet("alerts "+args[0], flag.ContinueOnError)
+	user := fs.String("user", "", "list / save / notifications: 利用者（save は必須）")
+	name := fs.String("name", "", "save: 検索条件の名前（必須）")
+	languages := fs.String("languages", "", "save: 言語（カンマ区切り）")
+	match := fs.String("match", domain.MatchAny, "save: 言語の一致条件（any / all）")
+	frameworks := fs.String("frameworks", "", "save: フレームワーク（カンマ区切り。いずれかに一致）")
+	priceMin := fs.Int("price-min", 0, "save: 単価の下限（税別の月額、円）")
+	priceMax := fs.Int("price-max", 0, "save: 単価の上限（税別の月額、円）")
+	remote := fs.String("remote", "", "save: リモート区分（カンマ区切り。いずれかに一致）")
+	prefectures := fs.String("prefectures", "", "save: 勤務地の都道府県（カンマ区切り。略称も可）")
+	disabled := fs.Bool("disabled", false, "save: 通知を止める")
+	status := fs.String("status", "", "notifications: 送信状況（pending / sent / failed）")
+	limit := fs.Int("limit", domain.DefaultNotificationLimit, "notifications: 表示する通知数")
+	every := fs.Duration("every", 0, "run: 指定した間隔で繰り返し実行する（例: 10m）")
+	if err := fs.Parse(args[1:]); err != nil {
+		return
+	}
+	rest := fs.Args()
+
+	if args[0] == "run" {
+		runAlertJob(ctx, container, *every)
+		return
+	}
+
+	var searches []domain.SavedSearch
+	var notifications []domain.Notification
+	var innerErr error
+	err := container.Invoke(func(au *aa.UseCase) {
+		switch args[0] {
+		case "list":
+			searches, innerErr = au.ListSearches(*user)
+		case "save":
+			s := domain.SavedSearch{
+				UserID:        *user,
+				Name:          *name,
+				Languages:     splitList(*languages),
+				LanguageMatch: *match,
+				Frameworks:    splitList(*frameworks),
+				RemoteTypes:   splitList(*remote),
+				Prefectures:   splitList(*prefectures),
+				Enabled:       !*disabled,
+			}
+			if *priceMin > 0 {
+				s.PriceMin = priceMin
+			}
+			if *priceMax > 0 {
+				s.PriceMax = priceMax
+			}
+			var existing []domain.SavedSearch
+			if existing, innerErr = au.ListSearches(strings.TrimSpace(*user)); innerErr != nil {
+				return
+			}
+			for _, e := range existing {
+				if e.Name == strings.TrimSpace(*name) {
+					s.ID = e.ID
+				}
+			}
+			if s.ID != 0 {
+				s, innerErr = au.UpdateSearch(s)
+			} else {
+				s, innerErr = au.CreateSearch(s)
+			}
+			searches = []domain.SavedSearch{s}
+		case "delete":
+			id, err := strconv.ParseUint(fs.Arg(0), 10, 64)
+			if len(rest) != 1 || err != nil || id == 0 {
+				innerErr = fmt.Errorf("検索条件IDを1つ指定してください")
+				return
+			}
+			innerErr = au.DeleteSearch(uint(id))
+		case "notifications":
+			f := domain.NotificationFilter{UserID: *user, Status: domain.NotificationStatus(*status), Limit: *limit}
+			notifications, innerErr = au.ListNotifications(f)
+		default:
+			innerErr = fmt.Errorf("不明なサブコマンドです: %s", args[0])
+		}
+	})
+	if innerErr != nil {
+		fmt.Printf("検索条件エラー: %v \n", innerErr)
+		if args[0] != "list" {
+			printAlertsUsage()
+		}
+		return
+	}
+	if err != nil {
+		fmt.Printf("依存性注入に失敗しました。:%v \n", err)
+		return
+	}
+
+	switch args[0] {
+	case "delete":
+		fmt.Printf("検索条件 %s を削除しました。\n", rest[0])
+	case "notifications":
+		if len(notifications) == 0 {
+			fmt.Println("通知はありません。")
+			return
+		}
+		for _, n := range notifications {
+			fmt.Printf("#%d %s %-7s %s 宛 %s\n", n.ID, n.CreatedAt.Format("2006-01-02 15:04"), n.Status, n.UserID, n.Title)
+			if n.LastError != "" {
+				fmt.Printf("      送信エラー（%d回）: %s\n", n.Attempts, n.LastError)
+			}
+		}
+	default:
+		if len(searches) == 0 {
+			fmt.Println("検索条件はありません。")
+			return
+		}
+		for _, s := range searches {
+			printSavedSearch(s)
+		}
+	}
+}
+
+// runAlertJob は前回以降に保存した案件を検索条件と照合して通知を作成し、未送信の通知を送信します
+func runAlertJob(ctx context.Context, container *dig.Container, every time.Duration) {
+	job := func(ctx context.Context) error {
+		var result domain.RunResult
+		var innerErr error
+		err := container.Invoke(func(au *aa.UseCase) {
+			result, innerErr = au.Run(ctx, time.Now())
+		})
+		if innerErr != nil {
+			return innerErr
+		}
+		if err != nil {
+			return fmt.Errorf("依存性注入に失敗しました。:%w", err)
+		}
+
+		fmt.Printf("%s 新着案件%d件を検索条件%d件と照合し、通知を%d件作成、%d件送信しました。（送信失敗%d件）\n",
+			time.Now().Format("2006-01-02 15:04:05"), result.Evaluated, result.Searches, result.Created, result.Sent, result.Failed)
+		return nil
+	}
+	onError := func(err error) {
+		fmt.Printf("新着案件の通知エラー: %v \n", err)
+	}
+
+	if every <= 0 {
+		if err := job(ctx); err != nil {
+			onError(err)
+		}
+		return
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	fmt.Printf("%s ごとに新着案件の通知を実行します。（Ctrl+C で終了）\n", every)
+	scheduler.Every(ctx, every, job, onError)
+}
+
+// printSavedSearch は検索条件を表示します
+func printSavedSearch(s domain.SavedSearch) {
+	state := "通知する"
+	if !s.Enabled {
+		state = "停止中"
+	}
+	fmt.Printf("#%d %s %s（%s）\n", s.ID, s.UserID, s.Name, state)
+	if len(s.Languages) > 0 {
+		fmt.Printf("      言語: %s（%s）\n", strings.Join(s.Languages, ", "), s.LanguageMatch)
+	}
+	if len(s.Frameworks) > 0 {
+		fmt.Printf("      フレームワーク: %s\n", strings.Join(s.Frameworks, ", "))
+	}
+	if s.PriceMin != nil || s.PriceMax != nil {
+		fmt.Printf("      単価: %s〜%s\n", formatYen(s.PriceMin), formatYen(s.PriceMax))
+	}
+	if len(s.RemoteTypes) > 0 {
+		fmt.Printf("      リモート: %s\n", strings.Join(s.RemoteTypes, ", "))
+	}
+	if len(s.Prefectures) > 0 {
+		fmt.Printf("      勤務地: %s\n", strings.Join(s.Prefectures, ", "))
+	}
+}
+
+// formatYen は単価を表示します（未指定は空）
+func formatYen(v *int) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.Itoa(*v) + "円"
+}
+
+func printAlertsUsage() {
+	fmt.Println("使用方法:")
+	fmt.Println("  go run main.go alerts list [--user 利用者]                 # 検索条件一覧")
+	fmt.Println("  go run main.go alerts save --user a@example.com --name Go案件 --languages Go,Python [--match all] [--frameworks Gin] [--price-min 600000] [--price-max 900000] [--remote フルリモート] [--prefectures 東京] [--disabled] # 登録・更新")
+	fmt.Println("  go run main.go alerts delete <検索条件ID>                  # 検索条件を通知ごと削除")
+	fmt.Println("  go run main.go alerts run [--every 10m]                    # 新着案件を照合して通知を作成・送信")
+	fmt.Println("  go run main.go alerts notifications [--user 利用者] [--status failed] [--limit 50] # 通知一覧")
+}
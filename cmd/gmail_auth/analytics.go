@@ -0,0 +1,155 @@
+package main
+
+import (
+	ana "business/internal/analytics/application"
+	"business/internal/analytics/domain"
+	"business/tools/scheduler"
+	"context"
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"go.uber.org/dig"
+)
+
+// runAnalytics は案件の市場動向（スキルの需要・単価・リモート比率の推移）を集計・表示します
+// サブコマンド: refresh / skills / prices / remote / rising。集計は取り込みのたびに差分を更新するため、refresh は辞書の見直し後などに使います。
+// refresh は --every を指定した場合は常駐して一定間隔で実行します（Ctrl+C で終了）。
+func runAnalytics(ctx context.Context, container *dig.Container, args []string) {
+	if len(args) == 0 {
+		printAnalyticsUsage()
+		return
+	}
+
+	fs := flag.NewFlagSet("analytics "+args[0], flag.ContinueOnError)
+	full := fs.Bool("full", false, "refresh: すべての週の集計を作り直す")
+	every := fs.Duration("every", 0, "refresh: 指定した間隔で繰り返し実行する（例: 1h）")
+	kind := fs.String("kind", domain.KindLanguage, "スキルの種類（language / framework / position）")
+	skills := fs.String("skills", "", "スキル名（カンマ区切り。未指定は案件数の多い順）")
+	weeks := fs.Int("weeks", domain.DefaultWeeks, "表示する週の数")
+	until := fs.String("until", "", "この日を含む週まで表示する（YYYY-MM-DD。未指定は今週）")
+	limit := fs.Int("limit", domain.DefaultLimit, "スキルを指定しない場合に表示するスキル数")
+	window := fs.Int("window", domain.DefaultWindow, "rising: 比べる週の数（直近の週とその前の週）")
+	minCount := fs.Int("min-count", domain.DefaultMinCount, "rising: 直近の案件数の下限")
+	asCSV := fs.Bool("csv", false, "CSV で出力する")
+	if err := fs.Parse(args[1:]); err != nil {
+		return
+	}
+
+	if args[0] == "refresh" {
+		runAnalyticsRefresh(ctx, container, *full, *every)
+		return
+	}
+
+	f := domain.Filter{Kind: *kind, Names: splitList(*skills), Weeks: *weeks, Limit: *limit, Window: *window, MinCount: *minCount}
+	if *until != "" {
+		t, err := time.ParseInLocation("2006-01-02", *until, time.Local)
+		if err != nil {
+			fmt.Println("--until は YYYY-MM-DD で指定してください")
+			return
+		}
+		f.Until = t
+	}
+
+	var records [][]string
+	var innerErr error
+	err := container.Invoke(func(anu *ana.UseCase) {
+		now := time.Now()
+		switch args[0] {
+		case "skills":
+			var series []domain.SkillSeries
+			series, innerErr = anu.SkillTrends(f, now)
+			records = domain.SkillRecords(series)
+		case "prices":
+			var series []domain.PriceSeries
+			series, innerErr = anu.PriceTrends(f, now)
+			records = domain.PriceRecords(series)
+		case "remote":
+			var points []domain.RemotePoint
+			points, innerErr = anu.RemoteTrends(f, now)
+			records = domain.RemoteRecords(points)
+		case "rising":
+			var rising []domain.RisingSkill
+			rising, innerErr = anu.RisingSkills(f, now)
+			records = domain.RisingRecords(rising)
+		default:
+			innerErr = fmt.Errorf("不明なサブコマンドです: %s", args[0])
+		}
+	})
+	if innerErr != nil {
+		fmt.Printf("市場動向の集計エラー: %v \n", innerErr)
+		printAnalyticsUsage()
+		return
+	}
+	if err != nil {
+		fmt.Printf("依存性注入に失敗しました。:%v \n", err)
+		return
+	}
+
+	if *asCSV {
+		if err := csv.NewWriter(os.Stdout).WriteAll(records); err != nil {
+			fmt.Printf("CSV の書き出しエラー: %v \n", err)
+		}
+		return
+	}
+	if len(records) <= 1 {
+		fmt.Println("集計はありません。（analytics refresh で集計を作成してください）")
+		return
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, r := range records {
+		fmt.Fprintln(w, strings.Join(r, "\t"))
+	}
+	_ = w.Flush()
+}
+
+// runAnalyticsRefresh は前回の更新以降に保存した案件の週の集計を作り直します
+func runAnalyticsRefresh(ctx context.Context, container *dig.Container, full bool, every time.Duration) {
+	job := func(ctx context.Context) error {
+		var result domain.RefreshResult
+		var innerErr error
+		err := container.Invoke(func(anu *ana.UseCase) {
+			result, innerErr = anu.Refresh(full)
+		})
+		if innerErr != nil {
+			return innerErr
+		}
+		if err != nil {
+			return fmt.Errorf("依存性注入に失敗しました。:%w", err)
+		}
+
+		fmt.Printf("%s 市場動向の集計を%d週分更新しました。（案件%d件、集計済みの案件ID %d）\n",
+			time.Now().Format("2006-01-02 15:04:05"), result.Weeks, result.Projects, result.LastProjectID)
+		return nil
+	}
+	onError := func(err error) {
+		fmt.Printf("市場動向の集計エラー: %v \n", err)
+	}
+
+	if every <= 0 {
+		if err := job(ctx); err != nil {
+			onError(err)
+		}
+		return
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	fmt.Printf("%s ごとに市場動向の集計を更新します。（Ctrl+C で終了）\n", every)
+	scheduler.Every(ctx, every, job, onError)
+}
+
+func printAnalyticsUsage() {
+	fmt.Println("使用方法:")
+	fmt.Println("  go run main.go analytics refresh [--full] [--every 1h]     # 週ごとの集計を更新（--full ですべて作り直す）")
+	fmt.Println("  go run main.go analytics skills [--kind language] [--skills Go,Java] [--weeks 12] [--until 2025-06-30] [--limit 10] [--csv] # スキル別の案件数の推移")
+	fmt.Println("  go run main.go analytics prices [--kind language] [--skills Go] [--weeks 12] [--csv] # 全体とスキル別の単価（中央値・パーセンタイル）の推移")
+	fmt.Println("  go run main.go analytics remote [--weeks 12] [--csv]       # リモート比率の推移")
+	fmt.Println("  go run main.go analytics rising [--kind framework] [--window 4] [--min-count 3] [--limit 10] [--csv] # 案件数が伸びているスキル")
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	ba "business/internal/batch/application"
+	"business/internal/batch/domain"
+	cd "business/internal/common/domain"
+	ga "business/internal/gmail/application"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.uber.org/dig"
+)
+
+// runBatchSubmit は指定ラベルのメールをBatch APIに登録します
+func runBatchSubmit(ctx context.Context, container *dig.Container, args []string) {
+	if len(args) < 2 {
+		fmt.Println("エラー: ラベルパスと何日前から取得するかを指定してください")
+		fmt.Println("使用例: go run main.go batch-submit 営業/案件 -30")
+		return
+	}
+	sinceDaysAgo, err := strconv.Atoi(args[1])
+	if err != nil {
+		fmt.Printf("引数の日付調整値の数値変換に失敗しました。引数を確認してください。: %v \n", err)
+		return
+	}
+
+	var messages []cd.BasicMessage
+	var innerErr error
+	err = container.Invoke(func(ga *ga.GmailUseCase) {
+		messages, innerErr = ga.GetMessages(ctx, args[0], sinceDaysAgo)
+	})
+	if innerErr != nil {
+		fmt.Printf("gメール取得処理失敗: %v \n", innerErr)
+		return
+	}
+	if err != nil {
+		fmt.Printf("gメール取得処理失敗: %v \n", err)
+		return
+	}
+	if len(messages) == 0 {
+		fmt.Println("未解析のメールがありません。")
+		return
+	}
+
+	var batch domain.Batch
+	err = container.Invoke(func(ba *ba.UseCase) {
+		batch, innerErr = ba.Submit(ctx, messages)
+	})
+	if innerErr != nil {
+		fmt.Printf("バッチ登録エラー: %v \n", innerErr)
+		return
+	}
+	if err != nil {
+		fmt.Printf("依存性注入に失敗しました。:%v \n", err)
+		return
+	}
+
+	fmt.Printf("バッチを登録しました。バッチID: %s メール: %d件 リクエスト: %d件\n", batch.BatchID, len(messages), batch.RequestCount)
+	fmt.Println("完了後に batch-collect で解析結果を取り込んでください。")
+}
+
+// runBatchStatus は取り込み前のバッチの状態を表示します
+func runBatchStatus(ctx context.Context, container *dig.Container) {
+	var batches []domain.Batch
+	var innerErr error
+	err := container.Invoke(func(ba *ba.UseCase) {
+		batches, innerErr = ba.Refresh(ctx)
+	})
+	if innerErr != nil {
+		fmt.Printf("バッチ状態の取得エラー: %v \n", innerErr)
+		return
+	}
+	if err != nil {
+		fmt.Printf("依存性注入に失敗しました。:%v \n", err)
+		return
+	}
+
+	if len(batches) == 0 {
+		fmt.Println("取り込み前のバッチはありません。")
+		return
+	}
+	for _, b := range batches {
+		fmt.Printf("%s 状態: %s 完了: %d/%d 失敗: %d 登録日時: %s\n",
+			b.BatchID, b.Status, b.CompletedCount, b.RequestCount, b.FailedCount, b.CreatedAt.Format("2006-01-02 15:04"))
+	}
+}
+
+// runBatchCollect は完了したバッチの解析結果を取り込みます
+func runBatchCollect(ctx context.Context, container *dig.Container) {
+	var summary domain.CollectSummary
+	var innerErr error
+	err := container.Invoke(func(ba *ba.UseCase) {
+		summary, innerErr = ba.Collect(ctx)
+	})
+	if innerErr != nil {
+		fmt.Printf("バッチ取り込みエラー: %v \n", innerErr)
+		return
+	}
+	if err != nil {
+		fmt.Printf("依存性注入に失敗しました。:%v \n", err)
+		return
+	}
+
+	fmt.Printf("取り込んだバッチ: %d件\n", summary.Batches)
+	fmt.Printf("保存したメール: %d件（案件/人材: %d件）\n", summary.Saved, summary.Projects)
+	if len(summary.Failed) > 0 {
+		fmt.Printf("失敗したメール: %s\n", strings.Join(summary.Failed, ","))
+	}
+}
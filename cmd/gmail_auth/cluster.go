@@ -0,0 +1,125 @@
+package main
+
+import (
+	da "business/internal/dictionary/application"
+	"business/internal/dictionary/domain"
+	"business/tools/scheduler"
+	"context"
+	"flag"
+	"fmt"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"go.uber.org/dig"
+)
+
+// runClusterKeywords は前回の実行以降に作成されたキーワードグループの統合提案を作成します
+// --every を指定した場合は常駐して一定間隔で実行します（Ctrl+C で終了）。
+func runClusterKeywords(ctx context.Context, container *dig.Container, args []string) {
+	fs := flag.NewFlagSet("cluster-keywords", flag.ContinueOnError)
+	threshold := fs.Float64("threshold", da.DefaultClusterThreshold, "統合を提案する類似度の下限（0〜1）")
+	useLLM := fs.Bool("llm", false, "類似度が下限に満たないキーワードをLLMで判定する")
+	every := fs.Duration("every", 0, "指定した間隔で繰り返し実行する（例: 1h）")
+	if err := fs.Parse(args); err != nil {
+		return
+	}
+	opts := da.ClusterOptions{Threshold: *threshold, UseLLM: *useLLM}
+
+	job := func(ctx context.Context) error {
+		var run domain.ClusteringRun
+		var innerErr error
+		err := container.Invoke(func(cu *da.ClusterUseCase) {
+			run, innerErr = cu.ClusterKeywords(ctx, opts)
+		})
+		if innerErr != nil {
+			return innerErr
+		}
+		if err != nil {
+			return fmt.Errorf("依存性注入に失敗しました。:%w", err)
+		}
+
+		for _, p := range run.Proposals {
+			fmt.Printf("#%d %s(#%d) → %s(#%d) 類似度: %.2f [%s] %s\n", p.ID, p.SourceName, p.SourceGroupID, p.TargetName, p.TargetGroupID, p.Score, p.Method, p.Reason)
+		}
+		fmt.Printf("%s %d件のキーワードグループを確認し、%d件の統合提案を作成しました。\n", time.Now().Format("2006-01-02 15:04:05"), run.Checked, len(run.Proposals))
+		return nil
+	}
+	onError := func(err error) {
+		fmt.Printf("キーワードクラスタリングエラー: %v \n", err)
+	}
+
+	if *every <= 0 {
+		if err := job(ctx); err != nil {
+			onError(err)
+		}
+		return
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	fmt.Printf("%s ごとにキーワードのクラスタリングを実行します。（Ctrl+C で終了）\n", *every)
+	scheduler.Every(ctx, *every, job, onError)
+}
+
+// runKeywordProposals はキーワードグループの統合提案（レビューキュー）を表示・承認・却下します
+// サブコマンド: list [--status pending] / accept <提案ID>... / reject <提案ID>...
+func runKeywordProposals(container *dig.Container, args []string) {
+	if len(args) == 0 {
+		args = []string{"list"}
+	}
+
+	fs := flag.NewFlagSet("keyword-proposals "+args[0], flag.ContinueOnError)
+	status := fs.String("status", string(domain.ProposalPending), "list: 状態（pending / accepted / rejected。空文字ですべて）")
+	limit := fs.Int("limit", 0, "list: 表示件数（0で制限なし）")
+	if err := fs.Parse(args[1:]); err != nil {
+		return
+	}
+
+	var proposals []domain.Proposal
+	var innerErr error
+	err := container.Invoke(func(cu *da.ClusterUseCase) {
+		switch args[0] {
+		case "list":
+			proposals, innerErr = cu.ListProposals(domain.ProposalStatus(*status), *limit)
+		case "accept", "reject":
+			if fs.NArg() == 0 {
+				innerErr = fmt.Errorf("提案IDを指定してください")
+				return
+			}
+			for _, arg := range fs.Args() {
+				id, err := strconv.ParseUint(arg, 10, 64)
+				if err != nil || id == 0 {
+					innerErr = fmt.Errorf("提案IDは1以上の整数で指定してください: %s", arg)
+					return
+				}
+				var p domain.Proposal
+				if args[0] == "accept" {
+					p, innerErr = cu.AcceptProposal(uint(id), da.ActorCLI)
+				} else {
+					p, innerErr = cu.RejectProposal(uint(id), da.ActorCLI)
+				}
+				if innerErr != nil {
+					return
+				}
+				proposals = append(proposals, p)
+			}
+		default:
+			innerErr = fmt.Errorf("不明なサブコマンドです: %s（list / accept / reject）", args[0])
+		}
+	})
+	// 複数件の承認・却下で途中まで成功した場合も、成功した分は表示する
+	for _, p := range proposals {
+		fmt.Printf("#%d [%s] %s(#%d) → %s(#%d) 類似度: %.2f [%s] %s\n", p.ID, p.Status, p.SourceName, p.SourceGroupID, p.TargetName, p.TargetGroupID, p.Score, p.Method, p.Reason)
+	}
+	if innerErr != nil {
+		fmt.Printf("統合提案エラー: %v \n", innerErr)
+		return
+	}
+	if err != nil {
+		fmt.Printf("依存性注入に失敗しました。:%v \n", err)
+		return
+	}
+	fmt.Printf("%d件\n", len(proposals))
+}
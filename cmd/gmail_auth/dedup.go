@@ -0,0 +1,61 @@
+package main
+
+import (
+	da "business/internal/dedup/application"
+	"business/internal/dedup/domain"
+	"business/tools/scheduler"
+	"context"
+	"flag"
+	"fmt"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"go.uber.org/dig"
+)
+
+// runDedupProjects は複数の営業会社から届いた同じ案件を重複グループにまとめます
+// --every を指定した場合は常駐して一定間隔で実行します（Ctrl+C で終了）。
+func runDedupProjects(ctx context.Context, container *dig.Container, args []string) {
+	fs := flag.NewFlagSet("dedup-projects", flag.ContinueOnError)
+	threshold := fs.Float64("threshold", domain.DefaultThreshold, "同じ案件とみなす類似度の下限（0〜1）")
+	days := fs.Int("days", domain.DefaultWindowDays, "受信日がこの日数以内の案件を対象にする")
+	every := fs.Duration("every", 0, "指定した間隔で繰り返し実行する（例: 1h）")
+	if err := fs.Parse(args); err != nil {
+		return
+	}
+	opts := domain.DedupOptions{Threshold: *threshold, WindowDays: *days}
+
+	job := func(ctx context.Context) error {
+		var result domain.DedupResult
+		var innerErr error
+		err := container.Invoke(func(du *da.UseCase) {
+			result, innerErr = du.DedupProjects(opts)
+		})
+		if innerErr != nil {
+			return innerErr
+		}
+		if err != nil {
+			return fmt.Errorf("依存性注入に失敗しました。:%w", err)
+		}
+
+		fmt.Printf("%s %d件の案件（候補 %d組）を比較し、%d件を%d個の重複グループにまとめました。\n",
+			time.Now().Format("2006-01-02 15:04:05"), result.Projects, result.Candidates, result.Duplicates, result.Clusters)
+		return nil
+	}
+	onError := func(err error) {
+		fmt.Printf("重複検出エラー: %v \n", err)
+	}
+
+	if *every <= 0 {
+		if err := job(ctx); err != nil {
+			onError(err)
+		}
+		return
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	fmt.Printf("%s ごとに案件の重複検出を実行します。（Ctrl+C で終了）\n", *every)
+	scheduler.Every(ctx, *every, job, onError)
+}
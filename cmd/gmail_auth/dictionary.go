@@ -0,0 +1,186 @@
+package main
+
+import (
+	da "business/internal/dictionary/application"
+	"business/internal/dictionary/domain"
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.uber.org/dig"
+)
+
+// runNormalizeKeywords は表記ゆれで分かれたキーワードグループを統合します
+// --dry-run を指定した場合は統合内容のみ表示します。
+func runNormalizeKeywords(container *dig.Container, args []string) {
+	fs := flag.NewFlagSet("normalize-keywords", flag.ContinueOnError)
+	dryRun := fs.Bool("dry-run", false, "統合内容のみ表示し、DBは変更しない")
+	if err := fs.Parse(args); err != nil {
+		return
+	}
+
+	var merges []domain.Merge
+	var innerErr error
+	err := container.Invoke(func(du *da.UseCase) {
+		merges, innerErr = du.NormalizeKeywords(*dryRun)
+	})
+	if innerErr != nil {
+		fmt.Printf("キーワード正規化エラー: %v \n", innerErr)
+		return
+	}
+	if err != nil {
+		fmt.Printf("依存性注入に失敗しました。:%v \n", err)
+		return
+	}
+
+	if len(merges) == 0 {
+		fmt.Println("統合するキーワードグループはありません。")
+		return
+	}
+	for _, m := range merges {
+		merged := make([]string, 0, len(m.Merged))
+		for _, g := range m.Merged {
+			merged = append(merged, fmt.Sprintf("%s(#%d)", g.Name, g.ID))
+		}
+		fmt.Printf("%s(#%d) ← %s", m.Name, m.Survivor.ID, strings.Join(merged, ", "))
+		if m.Renamed() {
+			fmt.Printf(" [名前変更: %s]", m.Survivor.Name)
+		}
+		fmt.Println()
+	}
+	if *dryRun {
+		fmt.Printf("%d件のキーワードグループを統合できます。（--dry-run のため変更していません）\n", len(merges))
+		return
+	}
+	fmt.Printf("%d件のキーワードグループを統合しました。\n", len(merges))
+}
+
+// runDictionary は用語辞書（キーワード・ポジション・業務種別のグループ）を管理します
+// サブコマンド: list / merge / split / add-alias / remove-alias / rename / log
+func runDictionary(container *dig.Container, args []string) {
+	if len(args) == 0 {
+		printDictionaryUsage()
+		return
+	}
+
+	fs := flag.NewFlagSet("dictionary "+args[0], flag.ContinueOnError)
+	kind := fs.String("kind", string(domain.KindKeyword), "種類（keyword / position / work_type）")
+	q := fs.String("q", "", "list: 正規名・別名の部分一致で絞り込み")
+	name := fs.String("name", "", "split: 新しいグループの正規名")
+	limit := fs.Int("limit", da.DefaultAuditLogLimit, "log: 表示件数")
+	if err := fs.Parse(args[1:]); err != nil {
+		return
+	}
+	k := domain.Kind(*kind)
+	rest := fs.Args()
+
+	var groups []domain.Group
+	var logs []domain.AuditLog
+	var innerErr error
+	err := container.Invoke(func(du *da.UseCase) {
+		switch args[0] {
+		case "list":
+			groups, innerErr = du.ListGroups(k, *q)
+		case "merge":
+			var ids []uint
+			if ids, innerErr = parseGroupIDs(rest, 2); innerErr == nil {
+				groups, innerErr = oneGroup(du.MergeGroups(k, ids[0], ids[1], da.ActorCLI))
+			}
+		case "split":
+			var ids []uint
+			if ids, innerErr = parseGroupIDs(rest, 1); innerErr == nil {
+				groups, innerErr = oneGroup(du.SplitGroup(k, ids[0], *name, rest[1:], da.ActorCLI))
+			}
+		case "add-alias", "remove-alias", "rename":
+			var ids []uint
+			if ids, innerErr = parseGroupIDs(rest, 1); innerErr != nil {
+				return
+			}
+			if len(rest) != 2 {
+				innerErr = fmt.Errorf("グループIDと名前（別名または新しい正規名）を1つずつ指定してください")
+				return
+			}
+			switch args[0] {
+			case "add-alias":
+				groups, innerErr = oneGroup(du.AddAlias(k, ids[0], rest[1], da.ActorCLI))
+			case "remove-alias":
+				groups, innerErr = oneGroup(du.RemoveAlias(k, ids[0], rest[1], da.ActorCLI))
+			default:
+				groups, innerErr = oneGroup(du.RenameGroup(k, ids[0], rest[1], da.ActorCLI))
+			}
+		case "log":
+			// --kind を指定しない場合はすべての種類を表示
+			logKind := domain.Kind("")
+			fs.Visit(func(f *flag.Flag) {
+				if f.Name == "kind" {
+					logKind = k
+				}
+			})
+			logs, innerErr = du.AuditLogs(logKind, *limit)
+		default:
+			innerErr = fmt.Errorf("不明なサブコマンドです: %s", args[0])
+		}
+	})
+	if innerErr != nil {
+		fmt.Printf("用語辞書エラー: %v \n", innerErr)
+		if args[0] != "list" && args[0] != "log" {
+			printDictionaryUsage()
+		}
+		return
+	}
+	if err != nil {
+		fmt.Printf("依存性注入に失敗しました。:%v \n", err)
+		return
+	}
+
+	if args[0] == "log" {
+		for _, l := range logs {
+			fmt.Printf("%s [%s] %s %s (by %s)\n", l.CreatedAt.Format("2006-01-02 15:04:05"), l.Kind, l.Action, l.Detail, l.Actor)
+		}
+		fmt.Printf("%d件\n", len(logs))
+		return
+	}
+	for _, g := range groups {
+		fmt.Printf("#%d %s 案件数: %d 別名: %s\n", g.ID, g.Name, g.Usage, strings.Join(g.Aliases, ", "))
+	}
+	if args[0] == "list" {
+		fmt.Printf("%d件\n", len(groups))
+	}
+}
+
+// parseGroupIDs は引数の先頭 n 個をグループIDとして返します
+func parseGroupIDs(args []string, n int) ([]uint, error) {
+	if len(args) < n {
+		return nil, fmt.Errorf("グループIDを%d個指定してください", n)
+	}
+	ids := make([]uint, 0, n)
+	for _, arg := range args[:n] {
+		id, err := strconv.ParseUint(arg, 10, 64)
+		if err != nil || id == 0 {
+			return nil, fmt.Errorf("グループIDは1以上の整数で指定してください: %s", arg)
+		}
+		ids = append(ids, uint(id))
+	}
+	return ids, nil
+}
+
+// oneGroup は操作結果のグループを表示用のスライスにします
+func oneGroup(g domain.Group, err error) ([]domain.Group, error) {
+	if err != nil {
+		return nil, err
+	}
+	return []domain.Group{g}, nil
+}
+
+// printDictionaryUsage は dictionary コマンドの使い方を表示します
+func printDictionaryUsage() {
+	fmt.Println("使用方法: go run main.go dictionary <サブコマンド> [--kind keyword|position|work_type] [引数]")
+	fmt.Println("  list [--q 文字列]                      # グループを案件数の多い順に一覧表示")
+	fmt.Println("  merge <統合元ID> <統合先ID>             # 統合元のグループを統合先に統合")
+	fmt.Println("  split --name <正規名> <ID> <別名>...     # 別名を切り出して新しいグループを作成")
+	fmt.Println("  add-alias <ID> <別名>                  # 別名を追加")
+	fmt.Println("  remove-alias <ID> <別名>               # 別名を削除")
+	fmt.Println("  rename <ID> <正規名>                   # 正規名を変更（変更前の名前は別名に残す）")
+	fmt.Println("  log [--limit 件数]                     # 操作履歴を表示（--kind 未指定ですべての種類）")
+}
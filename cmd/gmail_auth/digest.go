@@ -0,0 +1,119 @@
+package main
+
+import (
+	dga "business/internal/digest/application"
+	"business/internal/digest/domain"
+	"business/tools/scheduler"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"go.uber.org/dig"
+)
+
+// runDigest は新着案件のダイジェスト（スキル別・単価帯別・営業会社別の件数と、エンジニア別の注目案件）を作成します
+// --since を指定しない場合は --period の期間（daily: 前日、weekly: 直近7日）を集計します。
+// --send を指定した場合はメール・Webhook に送信し、--every を指定した場合は常駐して一定間隔で作成します（Ctrl+C で終了）。
+func runDigest(ctx context.Context, container *dig.Container, args []string) {
+	fs := flag.NewFlagSet("digest", flag.ContinueOnError)
+	since := fs.String("since", "", "集計の開始日時（2025-06-01 / 2025-06-01 09:00 / 24h）")
+	until := fs.String("until", "", "集計の終了日時（--since と同じ形式。未指定で現在）")
+	period := fs.String("period", string(domain.PeriodDaily), "--since を指定しない場合の集計期間（daily / weekly）")
+	format := fs.String("format", string(domain.FormatMarkdown), "出力の形式（markdown / html / text）")
+	output := fs.String("output", "", "出力先のファイル（未指定で標準出力）")
+	top := fs.Int("top", domain.DefaultTopMatches, "エンジニアごとに載せる適合度の高い案件数（0で載せない）")
+	minScore := fs.Float64("min-score", domain.DefaultMinScore, "載せる案件の適合度の下限")
+	send := fs.Bool("send", false, "メール・Webhook に送信する（NOTIFY_SMTP_* / NOTIFY_WEBHOOK_*）")
+	every := fs.Duration("every", 0, "指定した間隔で繰り返し作成する（例: 24h）")
+	if err := fs.Parse(args); err != nil {
+		return
+	}
+	f, err := domain.ParseFormat(*format)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	if *top == 0 {
+		*top = -1
+	}
+
+	job := func(ctx context.Context) error {
+		opts, err := digestOptions(*since, *until, domain.Period(*period), time.Now())
+		if err != nil {
+			return err
+		}
+		opts.TopMatches, opts.MinScore = *top, *minScore
+
+		var report domain.Report
+		var body string
+		var innerErr error
+		err = container.Invoke(func(du *dga.UseCase) {
+			if report, innerErr = du.Generate(opts, time.Now()); innerErr != nil {
+				return
+			}
+			if body, innerErr = domain.Render(report, f); innerErr != nil {
+				return
+			}
+			if *send {
+				innerErr = du.Send(ctx, report)
+			}
+		})
+		if innerErr != nil {
+			return innerErr
+		}
+		if err != nil {
+			return fmt.Errorf("依存性注入に失敗しました。:%w", err)
+		}
+
+		if *output != "" {
+			if err := os.WriteFile(*output, []byte(body), 0o644); err != nil {
+				return fmt.Errorf("ダイジェストの書き出しエラー: %w", err)
+			}
+			fmt.Printf("%s を %s に書き出しました。（新着案件%d件）\n", report.Title, *output, report.Total)
+		} else if !*send || *every <= 0 {
+			fmt.Println(body)
+		}
+		if *send {
+			fmt.Printf("%s %s を送信しました。（新着案件%d件）\n", time.Now().Format("2006-01-02 15:04:05"), report.Title, report.Total)
+		}
+		return nil
+	}
+	onError := func(err error) {
+		fmt.Printf("ダイジェストの作成エラー: %v \n", err)
+	}
+
+	if *every <= 0 {
+		if err := job(ctx); err != nil {
+			onError(err)
+		}
+		return
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	fmt.Printf("%s ごとにダイジェストを作成します。（Ctrl+C で終了）\n", *every)
+	scheduler.Every(ctx, *every, job, onError)
+}
+
+// digestOptions は実行時刻を基準に集計期間を決めます
+// 常駐して実行する場合も、実行のたびに "24h" や --period の期間を計算し直します。
+func digestOptions(since, until string, period domain.Period, now time.Time) (domain.Options, error) {
+	var opts domain.Options
+	var err error
+	if since == "" {
+		opts.Since, opts.Until, err = period.Range(now)
+		return opts, err
+	}
+	if opts.Since, err = domain.ParseTime(since, now); err != nil {
+		return opts, err
+	}
+	opts.Until = now
+	if until != "" {
+		opts.Until, err = domain.ParseTime(until, now)
+	}
+	return opts, err
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	la "business/internal/lifecycle/application"
+	"business/internal/lifecycle/domain"
+	"business/tools/scheduler"
+	"context"
+	"flag"
+	"fmt"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"go.uber.org/dig"
+)
+
+// runLifecycle は募集終了の連絡を検出し、案件の募集状況を判定して古い案件をアーカイブします
+// --every を指定した場合は常駐して一定間隔で実行します（Ctrl+C で終了）。
+func runLifecycle(ctx context.Context, container *dig.Container, args []string) {
+	d := domain.DefaultRules()
+	fs := flag.NewFlagSet("lifecycle", flag.ContinueOnError)
+	graceDays := fs.Int("grace-days", d.ExpireGraceDays, "入場時期の範囲の終了からこの日数を過ぎたら期限切れにする")
+	maxOpenDays := fs.Int("max-open-days", d.MaxOpenDays, "即日・随時・入場時期不明の案件は受信からこの日数を過ぎたら期限切れにする")
+	archiveDays := fs.Int("archive-days", d.ArchiveAfterDays, "募集終了・期限切れになってからこの日数を過ぎたらアーカイブする")
+	closureDays := fs.Int("closure-days", d.ClosureWindowDays, "募集終了の連絡を探す受信日の範囲（日数）")
+	every := fs.Duration("every", 0, "指定した間隔で繰り返し実行する（例: 6h）")
+	if err := fs.Parse(args); err != nil {
+		return
+	}
+	rules := domain.Rules{ExpireGraceDays: *graceDays, MaxOpenDays: *maxOpenDays, ArchiveAfterDays: *archiveDays, ClosureWindowDays: *closureDays}
+
+	job := func(ctx context.Context) error {
+		var result domain.LifecycleResult
+		var innerErr error
+		err := container.Invoke(func(lu *la.UseCase) {
+			result, innerErr = lu.RunLifecycle(rules, time.Now())
+		})
+		if innerErr != nil {
+			return innerErr
+		}
+		if err != nil {
+			return fmt.Errorf("依存性注入に失敗しました。:%w", err)
+		}
+
+		fmt.Printf("%s 募集終了の連絡%d件で%d件を募集終了にし、%d件中%d件の募集状況を更新、%d件をアーカイブしました。\n",
+			time.Now().Format("2006-01-02 15:04:05"), result.Notices, result.Closed, result.Checked, result.Changed, result.Archived)
+		return nil
+	}
+	onError := func(err error) {
+		fmt.Printf("募集状況の判定エラー: %v \n", err)
+	}
+
+	if *every <= 0 {
+		if err := job(ctx); err != nil {
+			onError(err)
+		}
+		return
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	fmt.Printf("%s ごとに案件の募集状況の判定を実行します。（Ctrl+C で終了）\n", *every)
+	scheduler.Every(ctx, *every, job, onError)
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	ea "business/internal/emailstore/application"
+	"business/internal/emailstore/domain"
+	"flag"
+	"fmt"
+
+	"go.uber.org/dig"
+)
+
+// runBackfillLocations は保存済みの勤務場所を都道府県・市区町村・最寄り駅の拠点に変換します
+func runBackfillLocations(container *dig.Container, args []string) {
+	fs := flag.NewFlagSet("backfill-locations", flag.ContinueOnError)
+	batch := fs.Int("batch", domain.DefaultLocationBatchSize, "1トランザクションで変換する案件数")
+	if err := fs.Parse(args); err != nil {
+		return
+	}
+
+	var result domain.LocationBackfillResult
+	var innerErr error
+	err := container.Invoke(func(lu *ea.LocationUseCase) {
+		result, innerErr = lu.BackfillLocations(*batch)
+	})
+	if innerErr != nil {
+		fmt.Printf("勤務地の変換エラー: %v （%d件変換済み）\n", innerErr, result.Projects)
+		return
+	}
+	if err != nil {
+		fmt.Printf("依存性注入に失敗しました。:%v \n", err)
+		return
+	}
+
+	fmt.Printf("%d件の案件の勤務地を変換しました。（拠点: %d件、辞書で解釈できなかった勤務場所: %d件）\n", result.Projects, result.Locations, result.Unresolved)
+}
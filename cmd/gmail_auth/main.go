@@ -130,16 +130,124 @@ func main() {
 		}
 
 		fmt.Printf("DBへの保存処理を開始します。")
-		for _, email := range analysisResults {
-			err = container.Invoke(func(ea *ea.UseCase) {
-				err = ea.SaveEmailAnalysisResult(email)
-				if err != nil {
-					fmt.Printf("メール保存エラー: %v \n", err)
-					return
-				}
-			})
+		var saved int
+		var saveInnerErr error
+		err = container.Invoke(func(ea *ea.UseCase) {
+			saved, saveInnerErr = ea.SaveEmailAnalysisResults(analysisResults)
+		})
+		if saveInnerErr != nil {
+			fmt.Printf("メール保存エラー: %v （%d通保存済み）\n", saveInnerErr, saved)
+			return
+		}
+		if err != nil {
+			fmt.Printf("メール保存エラー: %v \n", err)
+			return
 		}
-		fmt.Printf("DBへの保存処理が完了しました。 \n")
+		fmt.Printf("DBへの保存処理が完了しました。（%d通） \n", saved)
+
+	case "reanalyze":
+		// 保存済みメールを再解析
+		runReanalyze(ctx, container, os.Args[2:])
+
+	case "revisions":
+		// 解析リビジョン一覧を表示
+		runRevisions(container, os.Args[2:])
+
+	case "promote-revision":
+		// 指定した解析リビジョンを採用
+		runPromoteRevision(container, os.Args[2:])
+
+	case "revert-revision":
+		// ひとつ前の解析リビジョンに戻す
+		runRevertRevision(container, os.Args[2:])
+
+	case "batch-submit":
+		// Batch APIに解析リクエストを登録
+		runBatchSubmit(ctx, container, os.Args[2:])
+
+	case "batch-status":
+		// バッチの状態を表示
+		runBatchStatus(ctx, container)
+
+	case "batch-collect":
+		// 完了したバッチの解析結果を取り込み
+		runBatchCollect(ctx, container)
+
+	case "mark":
+		// メールをまとめて仕分け
+		runMark(container, os.Args[2:])
+
+	case "project-status":
+		// 案件の応募状況をまとめて更新
+		runProjectStatus(container, os.Args[2:])
+
+	case "status-history":
+		// 案件の応募状況の変更履歴を表示
+		runStatusHistory(container, os.Args[2:])
+
+	case "normalize-keywords":
+		// 表記ゆれで分かれたキーワードグループを統合
+		runNormalizeKeywords(container, os.Args[2:])
+
+	case "dictionary":
+		// 用語辞書（キーワード・ポジション・業務種別のグループ）を管理
+		runDictionary(container, os.Args[2:])
+
+	case "cluster-keywords":
+		// 新しいキーワードグループの統合提案を作成
+		runClusterKeywords(ctx, container, os.Args[2:])
+
+	case "keyword-proposals":
+		// キーワードグループの統合提案を表示・承認・却下
+		runKeywordProposals(container, os.Args[2:])
+
+	case "backfill-periods":
+		// 保存済みの入場時期・終了時期を日付の範囲に変換
+		runBackfillPeriods(container, os.Args[2:])
+
+	case "backfill-prices":
+		// 保存済みの単価を解釈し、税別の月額に換算
+		runBackfillPrices(container, os.Args[2:])
+
+	case "backfill-locations":
+		// 保存済みの勤務場所を都道府県・市区町村・最寄り駅に正規化
+		runBackfillLocations(container, os.Args[2:])
+
+	case "dedup-projects":
+		// 複数の営業会社から届いた同じ案件を重複グループにまとめる
+		runDedupProjects(ctx, container, os.Args[2:])
+
+	case "lifecycle":
+		// 募集終了の連絡を検出し、案件の募集状況を判定して古い案件をアーカイブ
+		runLifecycle(ctx, container, os.Args[2:])
+
+	case "engineers":
+		// エンジニアのスキルプロフィールを管理
+		runEngineers(container, os.Args[2:])
+
+	case "match":
+		// エンジニアのプロフィールに合う案件を適合度の高い順に表示
+		runMatch(container, os.Args[2:])
+
+	case "candidates":
+		// 案件に合う保存済みの人材メールを適合度の高い順に表示
+		runCandidates(container, os.Args[2:])
+
+	case "alerts":
+		// 保存した検索条件と新着案件の通知を管理
+		runAlerts(ctx, container, os.Args[2:])
+
+	case "digest":
+		// 新着案件のダイジェストを作成・送信
+		runDigest(ctx, container, os.Args[2:])
+
+	case "analytics":
+		// スキルの需要・単価・リモート比率の推移を集計・表示
+		runAnalytics(ctx, container, os.Args[2:])
+
+	case "agencies":
+		// 営業会社の台帳と取り込みのルール（ブロック・ミュート・優先）を管理
+		runAgencies(ctx, container, os.Args[2:])
 
 	default:
 		printUsage()
@@ -154,6 +262,9 @@ func getDependencies(osw *oswrapper.OsWrapper) (*dig.Container, error) {
 	}
 	apiKey := osw.GetEnv("OPENAI_API_KEY")
 	oa := openai.New(apiKey)
+	if baseURL := osw.GetEnv("OPENAI_BASE_URL"); baseURL != "" {
+		oa = openai.NewWithBaseURL(apiKey, baseURL)
+	}
 
 	gs := gmailService.New()
 	gc := gmail.New()
@@ -168,12 +279,49 @@ func printUsage() {
 	fmt.Println("使用方法:")
 	fmt.Println("  go run main.go gmail-auth                    # Gmail認証を実行")
 	fmt.Println("  go run main.go gmail-messages-by-label <ラベル> <日付調整> # 指定ラベルのメッセージを取得")
+	fmt.Println("  go run main.go reanalyze [--from] [--to] [--category] [--version] [--ids] [--limit] [--promote] # 保存済みメールを再解析")
+	fmt.Println("  go run main.go revisions <GメールID>          # 解析リビジョン一覧を表示")
+	fmt.Println("  go run main.go promote-revision <GメールID> <リビジョン> # 指定した解析リビジョンを採用")
+	fmt.Println("  go run main.go revert-revision <GメールID>    # ひとつ前の解析リビジョンに戻す")
+	fmt.Println("  go run main.go batch-submit <ラベル> <日付調整> # Batch APIで一括解析を登録")
+	fmt.Println("  go run main.go batch-status                  # 取り込み前のバッチの状態を表示")
+	fmt.Println("  go run main.go batch-collect                 # 完了したバッチの解析結果を保存")
+	fmt.Println("  go run main.go mark [--read] [--good] [--bad] [--note] <GメールID>... # メールをまとめて仕分け")
+	fmt.Println("  go run main.go project-status --status 応募済 [--note メモ] <案件ID>... # 案件の応募状況をまとめて更新")
+	fmt.Println("  go run main.go status-history <案件ID>        # 案件の応募状況の変更履歴を表示")
+	fmt.Println("  go run main.go normalize-keywords [--dry-run] # 表記ゆれで分かれたキーワードグループを統合")
+	fmt.Println("  go run main.go dictionary <list|merge|split|add-alias|remove-alias|rename|log> [--kind 種類] # 用語辞書を管理")
+	fmt.Println("  go run main.go cluster-keywords [--threshold 0.8] [--llm] [--every 1h] # 新しいキーワードグループの統合提案を作成")
+	fmt.Println("  go run main.go keyword-proposals <list|accept|reject> [--status pending] [提案ID...] # 統合提案をレビュー")
+	fmt.Println("  go run main.go backfill-periods [--batch 500] # 保存済みの入場時期・終了時期を日付の範囲に変換")
+	fmt.Println("  go run main.go backfill-prices [--batch 500] # 保存済みの単価を解釈し、税別の月額に換算")
+	fmt.Println("  go run main.go backfill-locations [--batch 500] # 保存済みの勤務場所を都道府県・市区町村・最寄り駅に正規化")
+	fmt.Println("  go run main.go dedup-projects [--threshold 0.7] [--days 60] [--every 1h] # 複数の営業会社から届いた同じ案件をまとめる")
+	fmt.Println("  go run main.go lifecycle [--grace-days 7] [--max-open-days 45] [--archive-days 14] [--closure-days 60] [--every 6h] # 案件の募集状況を判定し、古い案件をアーカイブする")
+	fmt.Println("  go run main.go engineers <list|show|save|delete> [--name] [--skills Go:5,AWS:2] # エンジニアのスキルプロフィールを管理")
+	fmt.Println("  go run main.go match <プロフィールIDまたは名前> [--days 14] [--limit 20] [--min-score 0] # プロフィールに合う案件を採点の内訳付きで表示")
+	fmt.Println("  go run main.go candidates <案件ID> [--days 30] [--limit 20] [--min-score 0] # 案件に合う人材メールを採点の内訳付きで表示")
+	fmt.Println("  go run main.go alerts <list|save|delete|run|notifications> [--user 利用者] [--every 10m] # 保存した検索条件と新着案件の通知を管理")
+	fmt.Println("  go run main.go digest [--since 2025-06-01|24h] [--period daily|weekly] [--format markdown|html|text] [--send] [--every 24h] # 新着案件のダイジェストを作成・送信")
+	fmt.Println("  go run main.go analytics <refresh|skills|prices|remote|rising> [--kind language] [--skills Go] [--weeks 12] [--csv] # スキルの需要・単価・リモート比率の推移を表示")
+	fmt.Println("  go run main.go agencies <list|show|set|refresh> [--rule block] [--sort good_rate] [--full] # 営業会社の品質の指標と取り込みのルールを管理")
 	fmt.Println("")
 	fmt.Println("例:")
 	fmt.Println("  使用例: 前日から取得する場合")
 	fmt.Println("    go run main.go gmail-messages-by-label 営業/案件 -1")
 	fmt.Println("  使用例: 当日分を取得する場合")
 	fmt.Println("    go run main.go gmail-messages-by-label 営業/案件 0")
+	fmt.Println("  使用例: 6月受信分を新しいプロンプトで再解析し、結果を採用する場合")
+	fmt.Println("    go run main.go reanalyze --from 2025-06-01 --to 2025-07-01 --promote")
+	fmt.Println("  使用例: 2通を既読にし、そのうち案件120を応募済にする場合")
+	fmt.Println("    go run main.go mark --read true 18c1234567890abc 18c1234567890abd")
+	fmt.Println("    go run main.go project-status --status 応募済 120")
+	fmt.Println("  使用例: 毎日、前日分のダイジェストをメールで送る場合")
+	fmt.Println("    go run main.go digest --period daily --send --every 24h")
+	fmt.Println("  使用例: フレームワークの直近12週の案件数を CSV に書き出す場合")
+	fmt.Println("    go run main.go analytics skills --kind framework --csv > frameworks.csv")
+	fmt.Println("  使用例: 営業会社のメールを次の取り込みから解析しないようにする場合")
+	fmt.Println("    go run main.go agencies set --rule block --note 重複ばかり example-agency.co.jp")
 	fmt.Println("")
 	fmt.Println("必要なファイル:")
 	fmt.Println("  client-secret.json - Google Cloud ConsoleからダウンロードしたOAuth2認証情報")
@@ -182,6 +330,12 @@ func printUsage() {
 	fmt.Println("  LABEL              - Gメールの取得対象となるラベル")
 	fmt.Println("  CLIENT_SECRET_PATH - client-secret.jsonファイルのパス(オプション)")
 	fmt.Println("  OPENAI_API_KEY     - openAi API秘密鍵")
+	fmt.Println("  ANALYSIS_VERSION   - 解析バージョン（プロンプト変更時に更新）")
+	fmt.Println("  OPENAI_BASE_URL    - OpenAI APIの接続先(オプション。検証用サーバーを使う場合)")
+	fmt.Println("  EMAILS_PER_TRANSACTION - 1トランザクションで保存するメール数(オプション。既定値50)")
+	fmt.Println("  KEYWORD_ALIASES_PATH - キーワードの別名ルールファイル(オプション。既定値 /data/dictionary/keyword_aliases.txt)")
+	fmt.Println("  NOTIFY_SLACK_WEBHOOK_URL / NOTIFY_TEAMS_WEBHOOK_URL / NOTIFY_DISCORD_WEBHOOK_URL / NOTIFY_WEBHOOK_URL / NOTIFY_SMTP_ADDR - 新着案件の通知の送信先(オプション。未指定で標準出力)")
+	fmt.Println("  NOTIFY_SMTP_* / NOTIFY_WEBHOOK_URL - ダイジェスト(digest --send)の送信先(オプション。チャットには送らない)")
 	fmt.Println("")
 	fmt.Println("注意:")
 	fmt.Println("  - 初回実行時はブラウザで認証が必要です")
@@ -0,0 +1,278 @@
+package main
+
+import (
+	ma "business/internal/matching/application"
+	"business/internal/matching/domain"
+	"errors"
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/dig"
+)
+
+// runEngineers はエンジニアのスキルプロフィールを管理します
+// サブコマンド: list / show / save / delete。save は同じ名前のプロフィールがあれば置き換えます。
+func runEngineers(container *dig.Container, args []string) {
+	if len(args) == 0 {
+		printEngineersUsage()
+		return
+	}
+
+	fs := flag.NewFlagSet("engineers "+args[0], flag.ContinueOnError)
+	name := fs.String("name", "", "save: 氏名・イニシャルなど（必須）")
+	skills := fs.String("skills", "", "save: スキルと経験年数（例: Go:5,AWS:2.5）")
+	price := fs.Int("price", 0, "save: 希望単価（税別の月額、円）")
+	remote := fs.String("remote", string(domain.RemoteAny), "save: リモートの希望（full / partial / onsite / any）")
+	available := fs.String("available", "", "save: 参画可能日（YYYY-MM-DD。省略は即日）")
+	prefectures := fs.String("prefectures", "", "save: 希望する勤務地の都道府県（カンマ区切り。略称も可）")
+	note := fs.String("note", "", "save: メモ")
+	if err := fs.Parse(args[1:]); err != nil {
+		return
+	}
+	rest := fs.Args()
+
+	var profiles []domain.Profile
+	var innerErr error
+	err := container.Invoke(func(mu *ma.UseCase) {
+		switch args[0] {
+		case "list":
+			profiles, innerErr = mu.ListProfiles()
+		case "show":
+			if len(rest) != 1 {
+				innerErr = fmt.Errorf("プロフィールIDまたは名前を1つ指定してください")
+				return
+			}
+			var p domain.Profile
+			p, innerErr = findProfile(mu, rest[0])
+			profiles = []domain.Profile{p}
+		case "save":
+			var p domain.Profile
+			if p, innerErr = parseProfileFlags(*name, *skills, *price, *remote, *available, *prefectures, *note); innerErr != nil {
+				return
+			}
+			existing, err := mu.GetProfileByName(p.Name)
+			switch {
+			case err == nil:
+				p.ID = existing.ID
+				p, innerErr = mu.UpdateProfile(p)
+			case errors.Is(err, domain.ErrProfileNotFound):
+				p, innerErr = mu.CreateProfile(p)
+			default:
+				innerErr = err
+			}
+			profiles = []domain.Profile{p}
+		case "delete":
+			if len(rest) != 1 {
+				innerErr = fmt.Errorf("プロフィールIDまたは名前を1つ指定してください")
+				return
+			}
+			var p domain.Profile
+			if p, innerErr = findProfile(mu, rest[0]); innerErr == nil {
+				innerErr = mu.DeleteProfile(p.ID)
+			}
+		default:
+			innerErr = fmt.Errorf("不明なサブコマンドです: %s", args[0])
+		}
+	})
+	if innerErr != nil {
+		fmt.Printf("プロフィールエラー: %v \n", innerErr)
+		if args[0] != "list" {
+			printEngineersUsage()
+		}
+		return
+	}
+	if err != nil {
+		fmt.Printf("依存性注入に失敗しました。:%v \n", err)
+		return
+	}
+
+	if args[0] == "delete" {
+		fmt.Printf("プロフィール %s を削除しました。\n", rest[0])
+		return
+	}
+	if len(profiles) == 0 {
+		fmt.Println("プロフィールはありません。")
+		return
+	}
+	for _, p := range profiles {
+		printProfile(p)
+	}
+}
+
+// runMatch はエンジニアのプロフィールに対する案件の適合度を採点し、高い順に内訳付きで表示します
+func runMatch(container *dig.Container, args []string) {
+	fs := flag.NewFlagSet("match", flag.ContinueOnError)
+	days := fs.Int("days", domain.DefaultMatchDays, "受信日がこの日数以内の案件を対象にする")
+	limit := fs.Int("limit", domain.DefaultMatchLimit, "表示する案件数")
+	minScore := fs.Float64("min-score", 0, "適合度（0〜100）がこの値未満の案件は表示しない")
+	includeClosed := fs.Bool("include-closed", false, "募集終了・期限切れの案件も対象にする")
+	if err := fs.Parse(args); err != nil {
+		return
+	}
+	if fs.NArg() != 1 {
+		fmt.Println("使用方法: go run main.go match <プロフィールIDまたは名前> [--days 14] [--limit 20] [--min-score 0] [--include-closed]")
+		return
+	}
+	opts := domain.MatchOptions{Days: *days, Limit: *limit, MinScore: *minScore, IncludeClosed: *includeClosed}
+
+	var result domain.MatchResult
+	var innerErr error
+	err := container.Invoke(func(mu *ma.UseCase) {
+		var p domain.Profile
+		if p, innerErr = findProfile(mu, fs.Arg(0)); innerErr != nil {
+			return
+		}
+		result, innerErr = mu.MatchProjects(p.ID, opts, time.Now())
+	})
+	if innerErr != nil {
+		fmt.Printf("案件マッチングエラー: %v \n", innerErr)
+		return
+	}
+	if err != nil {
+		fmt.Printf("依存性注入に失敗しました。:%v \n", err)
+		return
+	}
+
+	fmt.Printf("%s さんに合う案件（直近%d日の%d件を採点）\n", result.Profile.Name, opts.Days, result.Checked)
+	if len(result.Items) == 0 {
+		fmt.Println("該当する案件はありません。")
+		return
+	}
+	for i, m := range result.Items {
+		title := m.ProjectTitle
+		if title == "" {
+			title = m.Subject
+		}
+		fmt.Printf("%2d. %5.1f点 %s [%s] %s %s\n", i+1, m.Score, title, m.GmailID, m.SenderEmail, m.ReceivedDate.Format("2006-01-02"))
+		for _, c := range m.Components {
+			fmt.Printf("      %-6s %4.1f/%2.0f %s\n", c.Name, c.Score, c.Max, c.Reason)
+		}
+	}
+}
+
+// runCandidates は案件に対する保存済みの人材メールの適合度を採点し、高い順に内訳付きで表示します
+func runCandidates(container *dig.Container, args []string) {
+	fs := flag.NewFlagSet("candidates", flag.ContinueOnError)
+	days := fs.Int("days", domain.DefaultCandidateDays, "受信日がこの日数以内の人材メールを対象にする")
+	limit := fs.Int("limit", domain.DefaultMatchLimit, "表示する人材数")
+	minScore := fs.Float64("min-score", 0, "適合度（0〜100）がこの値未満の人材は表示しない")
+	if err := fs.Parse(args); err != nil {
+		return
+	}
+	id, err := strconv.ParseUint(fs.Arg(0), 10, 64)
+	if fs.NArg() != 1 || err != nil || id == 0 {
+		fmt.Println("使用方法: go run main.go candidates <案件ID> [--days 30] [--limit 20] [--min-score 0]")
+		return
+	}
+	opts := domain.MatchOptions{Days: *days, Limit: *limit, MinScore: *minScore}
+
+	var result domain.CandidateResult
+	var innerErr error
+	err = container.Invoke(func(mu *ma.UseCase) {
+		result, innerErr = mu.MatchCandidates(uint(id), opts, time.Now())
+	})
+	if innerErr != nil {
+		fmt.Printf("人材マッチングエラー: %v \n", innerErr)
+		return
+	}
+	if err != nil {
+		fmt.Printf("依存性注入に失敗しました。:%v \n", err)
+		return
+	}
+
+	title := result.ProjectTitle
+	if title == "" {
+		title = result.Subject
+	}
+	fmt.Printf("案件 #%d %s に合う人材（直近%d日の%d件を採点）\n", result.ProjectID, title, opts.Days, result.Checked)
+	if len(result.Items) == 0 {
+		fmt.Println("該当する人材はありません。")
+		return
+	}
+	for i, m := range result.Items {
+		name := m.CandidateName
+		if name == "" {
+			name = m.Subject
+		}
+		fmt.Printf("%2d. %5.1f点 %s [%s] %s %s\n", i+1, m.Score, name, m.GmailID, m.SenderEmail, m.ReceivedDate.Format("2006-01-02"))
+		fmt.Printf("      スキル: %s\n", strings.Join(m.Skills, ", "))
+		for _, c := range m.Components {
+			fmt.Printf("      %-6s %4.1f/%2.0f %s\n", c.Name, c.Score, c.Max, c.Reason)
+		}
+	}
+}
+
+// findProfile はプロフィールIDまたは名前でプロフィールを返します
+func findProfile(mu *ma.UseCase, ref string) (domain.Profile, error) {
+	if id, err := strconv.ParseUint(ref, 10, 64); err == nil {
+		return mu.GetProfile(uint(id))
+	}
+	return mu.GetProfileByName(ref)
+}
+
+// parseProfileFlags は engineers save のオプションをプロフィールに変換します
+func parseProfileFlags(name, skills string, price int, remote, available, prefectures, note string) (domain.Profile, error) {
+	p := domain.Profile{Name: name, RemotePreference: domain.RemotePreference(remote), Note: note}
+	if price > 0 {
+		p.DesiredPrice = &price
+	}
+	if available != "" {
+		t, err := time.ParseInLocation("2006-01-02", available, time.Local)
+		if err != nil {
+			return p, fmt.Errorf("--available の形式が不正です。YYYY-MM-DD で指定してください")
+		}
+		p.AvailableFrom = &t
+	}
+	for _, item := range splitList(skills) {
+		skill := domain.Skill{Name: item}
+		if i := strings.LastIndex(item, ":"); i >= 0 {
+			years, err := strconv.ParseFloat(item[i+1:], 64)
+			if err != nil {
+				return p, fmt.Errorf("--skills の経験年数が不正です: %s", item)
+			}
+			skill = domain.Skill{Name: item[:i], Years: years}
+		}
+		p.Skills = append(p.Skills, skill)
+	}
+	p.PreferredPrefectures = splitList(prefectures)
+	return p, nil
+}
+
+// splitList はカンマ区切りの値を空白と空文字を除いて返します
+func splitList(s string) []string {
+	var items []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			items = append(items, v)
+		}
+	}
+	return items
+}
+
+func printProfile(p domain.Profile) {
+	skills := make([]string, 0, len(p.Skills))
+	for _, s := range p.Skills {
+		skills = append(skills, fmt.Sprintf("%s(%s年)", s.Name, strconv.FormatFloat(s.Years, 'f', -1, 64)))
+	}
+	price := "未設定"
+	if p.DesiredPrice != nil {
+		price = fmt.Sprintf("%d円", *p.DesiredPrice)
+	}
+	available := "即日"
+	if p.AvailableFrom != nil {
+		available = p.AvailableFrom.Format("2006-01-02")
+	}
+	fmt.Printf("#%d %s 希望単価:%s リモート:%s 参画可能日:%s 勤務地:%s\n", p.ID, p.Name, price, p.RemotePreference.Label(), available, strings.Join(p.PreferredPrefectures, ","))
+	fmt.Printf("    スキル: %s\n", strings.Join(skills, ", "))
+}
+
+func printEngineersUsage() {
+	fmt.Println("使用方法:")
+	fmt.Println("  go run main.go engineers list                           # プロフィール一覧")
+	fmt.Println("  go run main.go engineers show <プロフィールIDまたは名前>    # プロフィールを表示")
+	fmt.Println("  go run main.go engineers save --name 山田 --skills Go:5,AWS:2 [--price 700000] [--remote full] [--available 2025-08-01] [--prefectures 東京,神奈川] # 登録・更新")
+	fmt.Println("  go run main.go engineers delete <プロフィールIDまたは名前>  # プロフィールを削除")
+}
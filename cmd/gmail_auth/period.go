@@ -0,0 +1,38 @@
+package main
+
+import (
+	ea "business/internal/emailstore/application"
+	"business/internal/emailstore/domain"
+	"flag"
+	"fmt"
+
+	"go.uber.org/dig"
+)
+
+// runBackfillPeriods は保存済みの入場時期・終了時期を受信日を基準に日付の範囲へ変換します
+func runBackfillPeriods(container *dig.Container, args []string) {
+	fs := flag.NewFlagSet("backfill-periods", flag.ContinueOnError)
+	batch := fs.Int("batch", domain.DefaultPeriodBatchSize, "1トランザクションで変換する案件数")
+	if err := fs.Parse(args); err != nil {
+		return
+	}
+
+	var result domain.PeriodBackfillResult
+	var innerErr error
+	err := container.Invoke(func(pu *ea.PeriodUseCase) {
+		result, innerErr = pu.BackfillPeriods(*batch)
+	})
+	if innerErr != nil {
+		fmt.Printf("入場時期・終了時期の変換エラー: %v （%d件変換済み）\n", innerErr, result.Projects)
+		return
+	}
+	if err != nil {
+		fmt.Printf("依存性注入に失敗しました。:%v \n", err)
+		return
+	}
+
+	fmt.Printf("%d件の案件（入場時期%d件）を変換しました。\n", result.Projects, result.EntryTimings)
+	if result.UnparsedStart > 0 || result.UnparsedEnd > 0 {
+		fmt.Printf("解釈できなかった値: 入場時期%d件、終了時期%d件（範囲は空で保存しています）\n", result.UnparsedStart, result.UnparsedEnd)
+	}
+}
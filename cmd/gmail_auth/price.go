@@ -0,0 +1,35 @@
+package main
+
+import (
+	ea "business/internal/emailstore/application"
+	"business/internal/emailstore/domain"
+	"flag"
+	"fmt"
+
+	"go.uber.org/dig"
+)
+
+// runBackfillPrices は保存済みの単価を単位・税・精算幅付きで解釈し、税別の月額に換算します
+func runBackfillPrices(container *dig.Container, args []string) {
+	fs := flag.NewFlagSet("backfill-prices", flag.ContinueOnError)
+	batch := fs.Int("batch", domain.DefaultPriceBatchSize, "1トランザクションで変換する案件数")
+	if err := fs.Parse(args); err != nil {
+		return
+	}
+
+	var result domain.PriceBackfillResult
+	var innerErr error
+	err := container.Invoke(func(pu *ea.PriceUseCase) {
+		result, innerErr = pu.BackfillPrices(*batch)
+	})
+	if innerErr != nil {
+		fmt.Printf("単価の変換エラー: %v （%d件変換済み）\n", innerErr, result.Projects)
+		return
+	}
+	if err != nil {
+		fmt.Printf("依存性注入に失敗しました。:%v \n", err)
+		return
+	}
+
+	fmt.Printf("%d件の案件の単価を変換しました。（月額に換算: %d件、単価の記載なし: %d件）\n", result.Projects, result.Monthly, result.Empty)
+}
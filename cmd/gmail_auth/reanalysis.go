@@ -0,0 +1,162 @@
+package main
+
+import (
+	ra "business/internal/reanalysis/application"
+	"business/internal/reanalysis/domain"
+	"context"
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/dig"
+)
+
+// runReanalyze は保存済みメールを条件指定で再解析します
+func runReanalyze(ctx context.Context, container *dig.Container, args []string) {
+	fs := flag.NewFlagSet("reanalyze", flag.ContinueOnError)
+	from := fs.String("from", "", "受信日FROM（YYYY-MM-DD）")
+	to := fs.String("to", "", "受信日TO（YYYY-MM-DD。この日を含まない）")
+	category := fs.String("category", "", "メール区分（案件 / 人材）")
+	version := fs.String("version", "", "指定した解析バージョンで解析されたメールのみ対象")
+	ids := fs.String("ids", "", "対象のGメールID（カンマ区切り）")
+	limit := fs.Int("limit", 0, "最大件数（0の場合は無制限）")
+	promote := fs.Bool("promote", false, "再解析結果を即時に採用する")
+	if err := fs.Parse(args); err != nil {
+		return
+	}
+
+	cond := domain.Condition{
+		Category:        *category,
+		AnalysisVersion: *version,
+		Limit:           *limit,
+		Promote:         *promote,
+	}
+	if *from != "" {
+		t, err := time.ParseInLocation("2006-01-02", *from, time.Local)
+		if err != nil {
+			fmt.Printf("--from の形式が不正です。YYYY-MM-DD で指定してください。: %v \n", err)
+			return
+		}
+		cond.From = &t
+	}
+	if *to != "" {
+		t, err := time.ParseInLocation("2006-01-02", *to, time.Local)
+		if err != nil {
+			fmt.Printf("--to の形式が不正です。YYYY-MM-DD で指定してください。: %v \n", err)
+			return
+		}
+		cond.To = &t
+	}
+	if *ids != "" {
+		cond.GmailIDs = strings.Split(*ids, ",")
+	}
+
+	var summary domain.Summary
+	var innerErr error
+	err := container.Invoke(func(ra *ra.UseCase) {
+		summary, innerErr = ra.Reanalyze(ctx, cond)
+	})
+	if innerErr != nil {
+		fmt.Printf("再解析エラー: %v \n", innerErr)
+		return
+	}
+	if err != nil {
+		fmt.Printf("依存性注入に失敗しました。:%v \n", err)
+		return
+	}
+
+	fmt.Printf("再解析対象: %d件\n", summary.Targets)
+	for _, r := range summary.Revisions {
+		fmt.Printf("  %s リビジョン%d (解析バージョン: %s, 案件/人材: %d件)\n", r.GmailID, r.Revision, r.AnalysisVersion, len(r.Results))
+	}
+	fmt.Printf("採用: %d件\n", summary.Promoted)
+	if len(summary.Skipped) > 0 {
+		fmt.Printf("解析結果なし: %s\n", strings.Join(summary.Skipped, ","))
+	}
+}
+
+// runRevisions はGメールIDの解析リビジョン一覧を表示します
+func runRevisions(container *dig.Container, args []string) {
+	if len(args) < 1 {
+		fmt.Println("エラー: GメールIDを指定してください")
+		fmt.Println("使用例: go run main.go revisions 18c1234567890abc")
+		return
+	}
+
+	var revisions []domain.Revision
+	var innerErr error
+	err := container.Invoke(func(ra *ra.UseCase) {
+		revisions, innerErr = ra.ListRevisions(args[0])
+	})
+	if innerErr != nil {
+		fmt.Printf("リビジョン取得エラー: %v \n", innerErr)
+		return
+	}
+	if err != nil {
+		fmt.Printf("依存性注入に失敗しました。:%v \n", err)
+		return
+	}
+
+	for _, r := range revisions {
+		active := ""
+		if r.IsActive {
+			active = " (採用中)"
+		}
+		fmt.Printf("リビジョン%d 解析バージョン: %s 作成日時: %s 件数: %d%s\n",
+			r.Revision, r.AnalysisVersion, r.CreatedAt.Format("2006-01-02 15:04"), len(r.Results), active)
+	}
+}
+
+// runPromoteRevision は指定した解析リビジョンを採用します
+func runPromoteRevision(container *dig.Container, args []string) {
+	if len(args) < 2 {
+		fmt.Println("エラー: GメールIDとリビジョンを指定してください")
+		fmt.Println("使用例: go run main.go promote-revision 18c1234567890abc 2")
+		return
+	}
+	revision, err := strconv.ParseUint(args[1], 10, 32)
+	if err != nil {
+		fmt.Printf("リビジョンの数値変換に失敗しました。引数を確認してください。: %v \n", err)
+		return
+	}
+
+	var innerErr error
+	err = container.Invoke(func(ra *ra.UseCase) {
+		innerErr = ra.PromoteRevision(args[0], uint(revision))
+	})
+	if innerErr != nil {
+		fmt.Printf("リビジョン採用エラー: %v \n", innerErr)
+		return
+	}
+	if err != nil {
+		fmt.Printf("依存性注入に失敗しました。:%v \n", err)
+		return
+	}
+	fmt.Printf("%s のリビジョン%dを採用しました。\n", args[0], revision)
+}
+
+// runRevertRevision は採用中のひとつ前の解析リビジョンに戻します
+func runRevertRevision(container *dig.Container, args []string) {
+	if len(args) < 1 {
+		fmt.Println("エラー: GメールIDを指定してください")
+		fmt.Println("使用例: go run main.go revert-revision 18c1234567890abc")
+		return
+	}
+
+	var revision domain.Revision
+	var innerErr error
+	err := container.Invoke(func(ra *ra.UseCase) {
+		revision, innerErr = ra.RevertRevision(args[0])
+	})
+	if innerErr != nil {
+		fmt.Printf("リビジョン差し戻しエラー: %v \n", innerErr)
+		return
+	}
+	if err != nil {
+		fmt.Printf("依存性注入に失敗しました。:%v \n", err)
+		return
+	}
+	fmt.Printf("%s をリビジョン%dに戻しました。\n", args[0], revision.Revision)
+}
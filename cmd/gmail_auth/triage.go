@@ -0,0 +1,168 @@
+package main
+
+import (
+	ea "business/internal/emailstore/application"
+	"business/internal/emailstore/domain"
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.uber.org/dig"
+)
+
+// runMark は指定したメールをまとめて仕分け（既読・いいね・びみょう・メモ）します
+// 指定したフラグの項目のみ更新します。応募状況は案件ごとに project-status で更新します。
+func runMark(container *dig.Container, args []string) {
+	fs := flag.NewFlagSet("mark", flag.ContinueOnError)
+	read := fs.String("read", "", "既読（true / false）")
+	good := fs.String("good", "", "いいね（true / false）")
+	bad := fs.String("bad", "", "びみょう（true / false）")
+	note := fs.String("note", "", "メモ（空文字でメモを消去）")
+	ids := fs.String("ids", "", "対象のGメールID（カンマ区切り。引数でも指定可）")
+	if err := fs.Parse(args); err != nil {
+		return
+	}
+
+	t := domain.Triage{}
+	var parseErr error
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "read":
+			t.IsRead, parseErr = parseFlagBool(f.Name, *read, parseErr)
+		case "good":
+			t.IsGood, parseErr = parseFlagBool(f.Name, *good, parseErr)
+		case "bad":
+			t.IsBad, parseErr = parseFlagBool(f.Name, *bad, parseErr)
+		case "note":
+			t.Note = note
+		}
+	})
+	if parseErr != nil {
+		fmt.Println(parseErr)
+		return
+	}
+
+	gmailIDs := fs.Args()
+	if *ids != "" {
+		gmailIDs = append(gmailIDs, strings.Split(*ids, ",")...)
+	}
+	if len(gmailIDs) == 0 {
+		fmt.Println("エラー: GメールIDを指定してください")
+		fmt.Println("使用例: go run main.go mark --read true --good true 18c1234567890abc 18c1234567890abd")
+		return
+	}
+
+	var states []domain.TriageState
+	var innerErr error
+	err := container.Invoke(func(tu *ea.TriageUseCase) {
+		states, innerErr = tu.Triage(gmailIDs, t)
+	})
+	if innerErr != nil {
+		fmt.Printf("仕分けエラー: %v \n", innerErr)
+		return
+	}
+	if err != nil {
+		fmt.Printf("依存性注入に失敗しました。:%v \n", err)
+		return
+	}
+
+	for _, s := range states {
+		fmt.Printf("%s 既読: %t いいね: %t びみょう: %t メモ: %s\n", s.GmailID, s.IsRead, s.IsGood, s.IsBad, s.Note)
+	}
+	fmt.Printf("%d件を更新しました。\n", len(states))
+}
+
+// runProjectStatus は指定した案件（案件ID。1通に載った案件ごと）の応募状況をまとめて更新します
+func runProjectStatus(container *dig.Container, args []string) {
+	fs := flag.NewFlagSet("project-status", flag.ContinueOnError)
+	status := fs.String("status", "", "応募状況（未対応 / 応募済 / 面談 / 見送り / 決定）")
+	note := fs.String("note", "", "変更履歴に残すメモ")
+	if err := fs.Parse(args); err != nil {
+		return
+	}
+
+	projectIDs, err := parseProjectIDs(fs.Args())
+	if err != nil || len(projectIDs) == 0 {
+		fmt.Println("エラー: 案件IDを1以上の整数で指定してください")
+		fmt.Println("使用例: go run main.go project-status --status 応募済 --note 単価交渉可 120 121")
+		return
+	}
+
+	var statuses []domain.ProjectStatus
+	var innerErr error
+	err = container.Invoke(func(tu *ea.TriageUseCase) {
+		statuses, innerErr = tu.SetStatus(projectIDs, domain.StatusUpdate{Status: domain.ApplicationStatus(*status), Note: *note})
+	})
+	if innerErr != nil {
+		fmt.Printf("応募状況更新エラー: %v \n", innerErr)
+		return
+	}
+	if err != nil {
+		fmt.Printf("依存性注入に失敗しました。:%v \n", err)
+		return
+	}
+
+	for _, s := range statuses {
+		fmt.Printf("案件%d（%s） 応募状況: %s\n", s.ProjectID, s.GmailID, s.Status)
+	}
+	fmt.Printf("%d件を更新しました。\n", len(statuses))
+}
+
+// runStatusHistory は案件の応募状況の変更履歴を表示します
+func runStatusHistory(container *dig.Container, args []string) {
+	projectIDs, err := parseProjectIDs(args)
+	if err != nil || len(projectIDs) != 1 {
+		fmt.Println("エラー: 案件IDを1つ指定してください")
+		fmt.Println("使用例: go run main.go status-history 120")
+		return
+	}
+
+	var history []domain.StatusChange
+	var innerErr error
+	err = container.Invoke(func(tu *ea.TriageUseCase) {
+		history, innerErr = tu.StatusHistory(projectIDs[0])
+	})
+	if innerErr != nil {
+		fmt.Printf("応募状況履歴取得エラー: %v \n", innerErr)
+		return
+	}
+	if err != nil {
+		fmt.Printf("依存性注入に失敗しました。:%v \n", err)
+		return
+	}
+
+	if len(history) == 0 {
+		fmt.Println("応募状況の変更履歴はありません。")
+		return
+	}
+	for _, h := range history {
+		fmt.Printf("%s %s → %s %s\n", h.ChangedAt.Format("2006-01-02 15:04"), h.From, h.To, h.Note)
+	}
+}
+
+// parseProjectIDs は引数の案件ID（email_projects.id）を変換します
+func parseProjectIDs(args []string) ([]uint, error) {
+	ids := make([]uint, 0, len(args))
+	for _, arg := range args {
+		id, err := strconv.ParseUint(arg, 10, 64)
+		if err != nil || id == 0 {
+			return nil, fmt.Errorf("案件IDは1以上の整数で指定してください: %s", arg)
+		}
+		ids = append(ids, uint(id))
+	}
+	return ids, nil
+}
+
+// parseFlagBool は true / false を指定するフラグの値を変換します
+// 先行するフラグでエラーがある場合はそのエラーを引き継ぎます。
+func parseFlagBool(name, value string, prev error) (*bool, error) {
+	if prev != nil {
+		return nil, prev
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return nil, fmt.Errorf("--%s は true / false で指定してください。: %v", name, err)
+	}
+	return &b, nil
+}
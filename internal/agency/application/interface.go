@@ -0,0 +1,29 @@
+// Package application は営業会社の台帳と取り込みのルールの機能のアプリケーション層を提供します。
+// このファイルは営業会社の台帳のユースケースインターフェースを定義します。
+package application
+
+import (
+	"business/internal/agency/domain"
+	cd "business/internal/common/domain"
+)
+
+// UseCaseInterface は営業会社の台帳のユースケースインターフェースです
+type UseCaseInterface interface {
+	// Refresh は前回の更新以降にメール・案件が保存・更新された営業会社の集計を更新します（full の場合はすべて）
+	Refresh(full bool) (domain.RefreshResult, error)
+
+	// AfterSave はメール解析結果の保存後に営業会社の集計を更新します
+	AfterSave() error
+
+	// List は条件に一致する営業会社を返します
+	List(f domain.Filter) ([]domain.Agency, error)
+
+	// Get は営業会社と差出人を返します（ドメインのほかメールアドレスでも指定できます）
+	Get(value string) (domain.Agency, []domain.Sender, error)
+
+	// Update は営業会社の表示名・ルール・メモを保存します
+	Update(s domain.Settings) (domain.Agency, error)
+
+	// FilterMessages は取り込むメールに営業会社のルールを適用します
+	FilterMessages(messages []cd.BasicMessage) ([]cd.BasicMessage, error)
+}
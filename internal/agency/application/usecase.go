@@ -0,0 +1,135 @@
+// Package application は営業会社の台帳と取り込みのルールの機能のアプリケーション層を提供します。
+// このファイルは営業会社の集計の更新・設定と、取り込むメールへのルールの適用のユースケースを実装します。
+package application
+
+import (
+	"business/internal/agency/domain"
+	r "business/internal/agency/infrastructure"
+	cd "business/internal/common/domain"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+)
+
+// UseCase は営業会社の台帳のユースケースの具象です
+type UseCase struct {
+	r r.RepositoryInterface
+}
+
+// New は営業会社の台帳のユースケースを作成します
+func New(r r.RepositoryInterface) *UseCase {
+	return &UseCase{
+		r: r,
+	}
+}
+
+// Refresh は前回の更新以降にメール・案件が保存・更新された営業会社の集計を更新します
+// 営業会社ごとにすべてのメール・案件から集計し直すため、いいね・応募状況の変更も反映されます。
+// full の場合と未実行の場合はすべての営業会社を集計します。
+func (u *UseCase) Refresh(full bool) (domain.RefreshResult, error) {
+	startedAt := time.Now()
+	result := domain.RefreshResult{Full: full}
+
+	var since *time.Time
+	if !full {
+		last, err := u.r.LastMetricsUpdatedAt()
+		if err != nil {
+			return result, err
+		}
+		since = last
+		result.Full = last == nil
+	}
+	domains, err := u.r.ListDomains(since)
+	if err != nil {
+		return result, err
+	}
+	metrics, err := u.r.AggregateMetrics(domains)
+	if err != nil {
+		return result, err
+	}
+	// 集計中に保存されたメールを次の更新で拾えるよう、開始日時を更新日時にする
+	if err := u.r.SaveMetrics(metrics, startedAt); err != nil {
+		return result, err
+	}
+	result.Agencies = len(metrics)
+	return result, nil
+}
+
+// AfterSave はメール解析結果の保存後に営業会社の集計を更新します
+func (u *UseCase) AfterSave() error {
+	if _, err := u.Refresh(false); err != nil {
+		return fmt.Errorf("営業会社の集計エラー: %w", err)
+	}
+	return nil
+}
+
+// List は条件に一致する営業会社を返します
+func (u *UseCase) List(f domain.Filter) ([]domain.Agency, error) {
+	f, err := f.Normalize()
+	if err != nil {
+		return nil, err
+	}
+	return u.r.List(f)
+}
+
+// Get は営業会社と差出人を返します（ドメインのほかメールアドレスでも指定できます）
+func (u *UseCase) Get(value string) (domain.Agency, []domain.Sender, error) {
+	d, err := domain.NormalizeDomain(value)
+	if err != nil {
+		return domain.Agency{}, nil, err
+	}
+	agency, err := u.r.Find(d)
+	if err != nil {
+		return domain.Agency{}, nil, err
+	}
+	senders, err := u.r.ListSenders(d)
+	if err != nil {
+		return domain.Agency{}, nil, err
+	}
+	return agency, senders, nil
+}
+
+// Update は営業会社の表示名・ルール・メモを保存します
+// メールが届く前の営業会社も登録でき、ブロックした営業会社のメールは次の取り込みから解析しません。
+func (u *UseCase) Update(s domain.Settings) (domain.Agency, error) {
+	d, err := domain.NormalizeDomain(s.Domain)
+	if err != nil {
+		return domain.Agency{}, err
+	}
+	s.Domain = d
+	if s.Rule != nil {
+		rule, err := domain.ParseRule(string(*s.Rule))
+		if err != nil {
+			return domain.Agency{}, err
+		}
+		s.Rule = &rule
+	}
+	if s.Name != nil {
+		name := strings.TrimSpace(*s.Name)
+		s.Name = &name
+	}
+	if s.Name == nil && s.Rule == nil && s.Note == nil {
+		return domain.Agency{}, fmt.Errorf("%w: 表示名・ルール・メモのいずれかを指定してください", domain.ErrInvalidAgency)
+	}
+	return u.r.SaveSettings(s)
+}
+
+// FilterMessages は取り込むメールに営業会社のルールを適用します
+// ブロックしている営業会社のメールを除き、優先する営業会社のメールを先頭に並べ替えます。
+func (u *UseCase) FilterMessages(messages []cd.BasicMessage) ([]cd.BasicMessage, error) {
+	if len(messages) == 0 {
+		return messages, nil
+	}
+	domains := lo.Map(messages, func(m cd.BasicMessage, _ int) string { return domain.AgencyOf(m.From) })
+	rules, err := u.r.Rules(domains)
+	if err != nil {
+		return nil, err
+	}
+	kept, blocked := domain.ApplyRules(messages, rules)
+	for _, m := range blocked {
+		fmt.Printf("GメールID: %v は営業会社 %s をブロックしているため解析をスキップしました。 \n", m.ID, domain.AgencyOf(m.From))
+	}
+	return kept, nil
+}
@@ -0,0 +1,174 @@
+package application
+
+import (
+	"business/internal/agency/domain"
+	cd "business/internal/common/domain"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockRepository は営業会社の台帳のリポジトリのモックです
+type MockRepository struct {
+	mock.Mock
+}
+
+func (m *MockRepository) LastMetricsUpdatedAt() (*time.Time, error) {
+	args := m.Called()
+	return args.Get(0).(*time.Time), args.Error(1)
+}
+
+func (m *MockRepository) ListDomains(since *time.Time) ([]string, error) {
+	args := m.Called(since)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockRepository) AggregateMetrics(domains []string) (map[string]domain.Metrics, error) {
+	args := m.Called(domains)
+	return args.Get(0).(map[string]domain.Metrics), args.Error(1)
+}
+
+func (m *MockRepository) SaveMetrics(metrics map[string]domain.Metrics, now time.Time) error {
+	args := m.Called(metrics, now)
+	return args.Error(0)
+}
+
+func (m *MockRepository) List(f domain.Filter) ([]domain.Agency, error) {
+	args := m.Called(f)
+	return args.Get(0).([]domain.Agency), args.Error(1)
+}
+
+func (m *MockRepository) Find(agencyDomain string) (domain.Agency, error) {
+	args := m.Called(agencyDomain)
+	return args.Get(0).(domain.Agency), args.Error(1)
+}
+
+func (m *MockRepository) SaveSettings(s domain.Settings) (domain.Agency, error) {
+	args := m.Called(s)
+	return args.Get(0).(domain.Agency), args.Error(1)
+}
+
+func (m *MockRepository) Rules(domains []string) (map[string]domain.Rule, error) {
+	args := m.Called(domains)
+	return args.Get(0).(map[string]domain.Rule), args.Error(1)
+}
+
+func (m *MockRepository) ListSenders(agencyDomain string) ([]domain.Sender, error) {
+	args := m.Called(agencyDomain)
+	return args.Get(0).([]domain.Sender), args.Error(1)
+}
+
+func TestUseCase_Refresh(t *testing.T) {
+	metrics := map[string]domain.Metrics{"agency.example.jp": {Emails: 3}}
+
+	t.Run("前回の更新以降に更新した営業会社だけを集計すること", func(t *testing.T) {
+		last := time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC)
+		repo := new(MockRepository)
+		repo.On("LastMetricsUpdatedAt").Return(&last, nil)
+		repo.On("ListDomains", &last).Return([]string{"agency.example.jp"}, nil)
+		repo.On("AggregateMetrics", []string{"agency.example.jp"}).Return(metrics, nil)
+		repo.On("SaveMetrics", metrics, mock.Anything).Return(nil)
+
+		result, err := New(repo).Refresh(false)
+		require.NoError(t, err)
+		assert.Equal(t, domain.RefreshResult{Agencies: 1}, result)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("未実行の場合と full の場合はすべて集計すること", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("LastMetricsUpdatedAt").Return((*time.Time)(nil), nil)
+		repo.On("ListDomains", (*time.Time)(nil)).Return([]string{"agency.example.jp"}, nil)
+		repo.On("AggregateMetrics", []string{"agency.example.jp"}).Return(metrics, nil)
+		repo.On("SaveMetrics", metrics, mock.Anything).Return(nil)
+
+		result, err := New(repo).Refresh(false)
+		require.NoError(t, err)
+		assert.True(t, result.Full)
+
+		result, err = New(repo).Refresh(true)
+		require.NoError(t, err)
+		assert.True(t, result.Full)
+		repo.AssertNumberOfCalls(t, "LastMetricsUpdatedAt", 1)
+	})
+
+	t.Run("集計に失敗した場合はエラーを返すこと", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("LastMetricsUpdatedAt").Return((*time.Time)(nil), nil)
+		repo.On("ListDomains", (*time.Time)(nil)).Return([]string{}, nil)
+		repo.On("AggregateMetrics", []string{}).Return(map[string]domain.Metrics{}, errors.New("db error"))
+
+		err := New(repo).AfterSave()
+		assert.ErrorContains(t, err, "db error")
+		repo.AssertNotCalled(t, "SaveMetrics", mock.Anything, mock.Anything)
+	})
+}
+
+func TestUseCase_Update(t *testing.T) {
+	repo := new(MockRepository)
+	block := domain.Rule("Block")
+	name := " A社 "
+	repo.On("SaveSettings", mock.MatchedBy(func(s domain.Settings) bool {
+		return s.Domain == "agency.example.jp" && *s.Rule == domain.RuleBlock && *s.Name == "A社" && s.Note == nil
+	})).Return(domain.Agency{Domain: "agency.example.jp", Rule: domain.RuleBlock}, nil)
+	u := New(repo)
+
+	// メールアドレスで指定してもドメインに揃えること
+	got, err := u.Update(domain.Settings{Domain: "sales@Agency.example.jp", Rule: &block, Name: &name})
+	require.NoError(t, err)
+	assert.Equal(t, domain.RuleBlock, got.Rule)
+
+	invalid := domain.Rule("ignore")
+	for _, s := range []domain.Settings{
+		{Domain: "localhost", Rule: &block},
+		{Domain: "agency.example.jp", Rule: &invalid},
+		{Domain: "agency.example.jp"},
+	} {
+		_, err := u.Update(s)
+		assert.ErrorIs(t, err, domain.ErrInvalidAgency)
+	}
+	repo.AssertNumberOfCalls(t, "SaveSettings", 1)
+}
+
+func TestUseCase_Get(t *testing.T) {
+	repo := new(MockRepository)
+	repo.On("Find", "agency.example.jp").Return(domain.Agency{Domain: "agency.example.jp"}, nil)
+	repo.On("ListSenders", "agency.example.jp").Return([]domain.Sender{{Email: "a@agency.example.jp", Emails: 2}}, nil)
+	repo.On("Find", "unknown.example.jp").Return(domain.Agency{}, domain.ErrAgencyNotFound)
+	u := New(repo)
+
+	agency, senders, err := u.Get("Agency.example.jp")
+	require.NoError(t, err)
+	assert.Equal(t, "agency.example.jp", agency.Domain)
+	assert.Len(t, senders, 1)
+
+	_, _, err = u.Get("unknown.example.jp")
+	assert.ErrorIs(t, err, domain.ErrAgencyNotFound)
+}
+
+func TestUseCase_FilterMessages(t *testing.T) {
+	repo := new(MockRepository)
+	repo.On("Rules", []string{"a.example.jp", "blocked.example.jp", "priority.example.jp"}).
+		Return(map[string]domain.Rule{"blocked.example.jp": domain.RuleBlock, "priority.example.jp": domain.RulePrioritize}, nil)
+	u := New(repo)
+
+	got, err := u.FilterMessages([]cd.BasicMessage{
+		{ID: "1", From: "a@a.example.jp"},
+		{ID: "2", From: "b@blocked.example.jp"},
+		{ID: "3", From: "営業 <c@priority.example.jp>"},
+	})
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, "3", got[0].ID)
+	assert.Equal(t, "1", got[1].ID)
+
+	// メールが無い場合はルールを参照しないこと
+	got, err = u.FilterMessages(nil)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+	repo.AssertNumberOfCalls(t, "Rules", 1)
+}
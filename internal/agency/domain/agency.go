@@ -0,0 +1,197 @@
+// Package domain は営業会社（差出人のドメイン）の台帳と、取り込みのルールのドメインモデルを提供します。
+// このファイルは営業会社・ルール・品質の指標と一覧の条件を定義します。
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultListLimit = 50  // 一覧の既定の件数
+	MaxListLimit     = 500 // 一覧の件数の上限
+)
+
+var (
+	// ErrInvalidAgency は営業会社の指定・ルール・一覧の条件が不正な場合のエラーです
+	ErrInvalidAgency = errors.New("営業会社の指定が不正です")
+	// ErrAgencyNotFound は営業会社が登録されていない場合のエラーです
+	ErrAgencyNotFound = errors.New("営業会社が見つかりません")
+)
+
+// Rule は営業会社のメールの取り込みのルールです
+type Rule string
+
+const (
+	RuleNone       Rule = "none"       // 指定なし
+	RuleBlock      Rule = "block"      // 解析・保存しない（保存済みの案件も通知・ダイジェストに載せない）
+	RuleMute       Rule = "mute"       // 解析・保存するが、新着案件の通知・ダイジェストに載せない
+	RulePrioritize Rule = "prioritize" // ほかの営業会社より先に解析する
+)
+
+// ParseRule はルールの名前を解釈します（空は指定なし）
+func ParseRule(name string) (Rule, error) {
+	switch r := Rule(strings.ToLower(strings.TrimSpace(name))); r {
+	case "":
+		return RuleNone, nil
+	case RuleNone, RuleBlock, RuleMute, RulePrioritize:
+		return r, nil
+	}
+	return "", fmt.Errorf("%w: rule は none / block / mute / prioritize で指定してください: %s", ErrInvalidAgency, name)
+}
+
+// Hidden は新着案件の通知・ダイジェストに載せないルールかを返します
+func (r Rule) Hidden() bool {
+	return r == RuleBlock || r == RuleMute
+}
+
+// AgencyOf は差出人のメールアドレスから営業会社のドメインを取り出します（"山田 <a@Agency.jp>" → "agency.jp"）
+func AgencyOf(sender string) string {
+	if start, end := strings.Index(sender, "<"), strings.LastIndex(sender, ">"); start >= 0 && end > start {
+		sender = sender[start+1 : end]
+	}
+	sender = strings.TrimSpace(sender)
+	if i := strings.LastIndex(sender, "@"); i >= 0 {
+		sender = sender[i+1:]
+	}
+	return strings.ToLower(sender)
+}
+
+// NormalizeDomain は営業会社の指定をドメインに揃えます（メールアドレスも受け付けます）
+func NormalizeDomain(value string) (string, error) {
+	d := AgencyOf(value)
+	if d == "" || strings.ContainsAny(d, " \t/") || !strings.Contains(d, ".") {
+		return "", fmt.Errorf("%w: ドメインまたはメールアドレスで指定してください: %s", ErrInvalidAgency, value)
+	}
+	return d, nil
+}
+
+// Metrics は営業会社から届いたメール・案件の集計です
+type Metrics struct {
+	Emails            int        `json:"emails"`             // メール数
+	ProjectEmails     int        `json:"project_emails"`     // 案件メール数
+	CandidateEmails   int        `json:"candidate_emails"`   // 人材メール数
+	Senders           int        `json:"senders"`            // 差出人のメールアドレスの数
+	Projects          int        `json:"projects"`           // 案件数（1通に複数の案件が載る場合はそれぞれ）
+	PricedProjects    int        `json:"priced_projects"`    // 単価の記載がある案件数
+	AveragePrice      *int       `json:"average_price"`      // 税別の月額の平均（下限と上限がある案件は中間）
+	DuplicateProjects int        `json:"duplicate_projects"` // ほかの営業会社からも届いた案件数（重複グループの営業会社が2社以上）
+	GoodProjects      int        `json:"good_projects"`      // いいねを付けた案件数
+	AppliedProjects   int        `json:"applied_projects"`   // 応募した案件数（応募済・面談・決定）
+	InterviewProjects int        `json:"interview_projects"` // 面談に進んだ案件数（面談・決定）
+	WonProjects       int        `json:"won_projects"`       // 決定した案件数
+	FirstReceivedAt   *time.Time `json:"first_received_at"`  // 最初に受信した日時
+	LastReceivedAt    *time.Time `json:"last_received_at"`   // 最後に受信した日時
+
+	// 連絡・対応の状況
+	ActiveDays           int  `json:"active_days"`            // メールが届いた日数
+	ReplyEmails          int  `json:"reply_emails"`           // 同じスレッドの2通目以降のメール数（追っての連絡・返信）
+	ClosedProjects       int  `json:"closed_projects"`        // 募集終了の連絡があった案件数
+	RespondedProjects    int  `json:"responded_projects"`     // 応募状況を変更した案件数
+	AverageResponseHours *int `json:"average_response_hours"` // 受信から最初に応募状況を変更するまでの平均時間（時間）
+}
+
+// Rates は集計から求めた営業会社の品質の指標です（0〜1。分母が0の場合は0）
+type Rates struct {
+	ProjectShare  float64 `json:"project_share"`  // 案件メール・人材メールのうち案件メールの割合
+	DuplicateRate float64 `json:"duplicate_rate"` // 案件のうちほかの営業会社からも届いた割合
+	GoodRate      float64 `json:"good_rate"`      // 案件のうちいいねを付けた割合
+	ApplyRate     float64 `json:"apply_rate"`     // 案件のうち応募した割合
+}
+
+// Rates は集計から品質の指標を求めます
+func (m Metrics) Rates() Rates {
+	ratio := func(n, d int) float64 {
+		if d == 0 {
+			return 0
+		}
+		return float64(int(float64(n)/float64(d)*1000+0.5)) / 1000
+	}
+	return Rates{
+		ProjectShare:  ratio(m.ProjectEmails, m.ProjectEmails+m.CandidateEmails),
+		DuplicateRate: ratio(m.DuplicateProjects, m.Projects),
+		GoodRate:      ratio(m.GoodProjects, m.Projects),
+		ApplyRate:     ratio(m.AppliedProjects, m.Projects),
+	}
+}
+
+// Agency は営業会社（差出人のドメイン）です
+type Agency struct {
+	ID     uint   `json:"id"`
+	Domain string `json:"domain"`
+	Name   string `json:"name"` // 表示名（未設定は空）
+	Rule   Rule   `json:"rule"`
+	Note   string `json:"note"`
+	Metrics
+	Rates
+	MetricsUpdatedAt *time.Time `json:"metrics_updated_at"` // 集計を更新した日時（メールが届く前にルールを登録した場合は nil）
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+// Settings は利用者が設定する営業会社の表示名・ルール・メモです（nil の項目は変更しません）
+type Settings struct {
+	Domain string
+	Name   *string
+	Rule   *Rule
+	Note   *string
+}
+
+// Sender は営業会社の差出人です
+type Sender struct {
+	Email          string    `json:"email"`
+	Name           string    `json:"name"` // 最後に受信したメールの差出人名
+	Emails         int       `json:"emails"`
+	LastReceivedAt time.Time `json:"last_received_at"`
+}
+
+// 一覧の並び順
+const (
+	SortEmails       = "emails"         // メール数の多い順（既定）
+	SortProjects     = "projects"       // 案件数の多い順
+	SortGoodRate     = "good_rate"      // いいねの割合の高い順
+	SortDuplicate    = "duplicate_rate" // 重複の割合の高い順
+	SortLastReceived = "last_received"  // 最後に受信した日時の新しい順
+)
+
+// Filter は営業会社の一覧の条件です
+type Filter struct {
+	Query string // ドメイン・表示名の部分一致
+	Rule  Rule   // ルールで絞り込む（空はすべて）
+	Sort  string
+	Limit int
+}
+
+// Normalize は一覧の条件を検証し、既定値を補います
+func (f Filter) Normalize() (Filter, error) {
+	f.Query = strings.TrimSpace(f.Query)
+	if f.Rule != "" {
+		rule, err := ParseRule(string(f.Rule))
+		if err != nil {
+			return f, err
+		}
+		f.Rule = rule
+	}
+	switch f.Sort {
+	case "":
+		f.Sort = SortEmails
+	case SortEmails, SortProjects, SortGoodRate, SortDuplicate, SortLastReceived:
+	default:
+		return f, fmt.Errorf("%w: sort は %s / %s / %s / %s / %s で指定してください: %s",
+			ErrInvalidAgency, SortEmails, SortProjects, SortGoodRate, SortDuplicate, SortLastReceived, f.Sort)
+	}
+	switch {
+	case f.Limit == 0:
+		f.Limit = DefaultListLimit
+	case f.Limit < 0 || f.Limit > MaxListLimit:
+		return f, fmt.Errorf("%w: limit は1〜%dで指定してください", ErrInvalidAgency, MaxListLimit)
+	}
+	return f, nil
+}
+
+// RefreshResult は集計の更新結果です
+type RefreshResult struct {
+	Full     bool `json:"full"`     // すべての営業会社を集計し直した
+	Agencies int  `json:"agencies"` // 集計した営業会社の数
+}
@@ -0,0 +1,86 @@
+package domain
+
+import (
+	cd "business/internal/common/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAgencyOf(t *testing.T) {
+	assert.Equal(t, "agency.example.jp", AgencyOf("a@Agency.Example.jp"))
+	assert.Equal(t, "agency.example.jp", AgencyOf("山田 太郎 <yamada@agency.example.jp>"))
+	assert.Equal(t, "agency.example.jp", AgencyOf(" agency.example.jp "))
+
+	d, err := NormalizeDomain("sales@Agency.example.jp")
+	require.NoError(t, err)
+	assert.Equal(t, "agency.example.jp", d)
+	for _, v := range []string{"", "localhost", "a b.jp", "a@"} {
+		_, err := NormalizeDomain(v)
+		assert.ErrorIs(t, err, ErrInvalidAgency, v)
+	}
+}
+
+func TestParseRule(t *testing.T) {
+	rule, err := ParseRule(" Block ")
+	require.NoError(t, err)
+	assert.Equal(t, RuleBlock, rule)
+	rule, err = ParseRule("")
+	require.NoError(t, err)
+	assert.Equal(t, RuleNone, rule)
+	_, err = ParseRule("ignore")
+	assert.ErrorIs(t, err, ErrInvalidAgency)
+
+	assert.True(t, RuleBlock.Hidden())
+	assert.True(t, RuleMute.Hidden())
+	assert.False(t, RulePrioritize.Hidden())
+}
+
+func TestMetrics_Rates(t *testing.T) {
+	m := Metrics{ProjectEmails: 6, CandidateEmails: 2, Projects: 9, DuplicateProjects: 3, GoodProjects: 1, AppliedProjects: 2}
+	assert.Equal(t, Rates{ProjectShare: 0.75, DuplicateRate: 0.333, GoodRate: 0.111, ApplyRate: 0.222}, m.Rates())
+	assert.Equal(t, Rates{}, Metrics{}.Rates())
+}
+
+func TestFilter_Normalize(t *testing.T) {
+	f, err := Filter{Query: " agency ", Rule: "MUTE"}.Normalize()
+	require.NoError(t, err)
+	assert.Equal(t, Filter{Query: "agency", Rule: RuleMute, Sort: SortEmails, Limit: DefaultListLimit}, f)
+
+	for _, f := range []Filter{{Rule: "ignore"}, {Sort: "name"}, {Limit: MaxListLimit + 1}, {Limit: -1}} {
+		_, err := f.Normalize()
+		assert.ErrorIs(t, err, ErrInvalidAgency)
+	}
+}
+
+func TestApplyRules(t *testing.T) {
+	messages := []cd.BasicMessage{
+		{ID: "1", From: "a@normal.example.jp"},
+		{ID: "2", From: "営業 <b@Blocked.example.jp>"},
+		{ID: "3", From: "c@muted.example.jp"},
+		{ID: "4", From: "d@priority.example.jp"},
+		{ID: "5", From: "e@normal.example.jp"},
+	}
+	rules := map[string]Rule{
+		"blocked.example.jp":  RuleBlock,
+		"muted.example.jp":    RuleMute,
+		"priority.example.jp": RulePrioritize,
+	}
+
+	// ブロックした営業会社のメールを除き、優先する営業会社のメールを先頭にすること（ミュートは取り込む）
+	kept, blocked := ApplyRules(messages, rules)
+	ids := func(ms []cd.BasicMessage) []string {
+		var s []string
+		for _, m := range ms {
+			s = append(s, m.ID)
+		}
+		return s
+	}
+	assert.Equal(t, []string{"4", "1", "3", "5"}, ids(kept))
+	assert.Equal(t, []string{"2"}, ids(blocked))
+
+	kept, blocked = ApplyRules(messages, nil)
+	assert.Len(t, kept, 5)
+	assert.Empty(t, blocked)
+}
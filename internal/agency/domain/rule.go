@@ -0,0 +1,25 @@
+// Package domain は営業会社（差出人のドメイン）の台帳と、取り込みのルールのドメインモデルを提供します。
+// このファイルは取り込むメールへのルールの適用を定義します。
+package domain
+
+import (
+	cd "business/internal/common/domain"
+	"sort"
+)
+
+// ApplyRules は取り込むメールに営業会社のルールを適用します
+// ブロックしている営業会社のメールを除き、優先する営業会社のメールを先頭に並べ替えます（それ以外の順番は変えません）。
+// rules は営業会社のドメインをキーにしたルールで、含まれないドメインは指定なしとみなします。
+func ApplyRules(messages []cd.BasicMessage, rules map[string]Rule) (kept, blocked []cd.BasicMessage) {
+	for _, m := range messages {
+		if rules[AgencyOf(m.From)] == RuleBlock {
+			blocked = append(blocked, m)
+			continue
+		}
+		kept = append(kept, m)
+	}
+	sort.SliceStable(kept, func(i, j int) bool {
+		return rules[AgencyOf(kept[i].From)] == RulePrioritize && rules[AgencyOf(kept[j].From)] != RulePrioritize
+	})
+	return kept, blocked
+}
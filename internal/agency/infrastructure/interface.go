@@ -0,0 +1,38 @@
+// Package infrastructure は営業会社の台帳と取り込みのルールの機能のインフラストラクチャ層を提供します。
+// このファイルは営業会社の台帳で使用するリポジトリのインターフェースを定義します。
+package infrastructure
+
+import (
+	"business/internal/agency/domain"
+	"time"
+)
+
+// RepositoryInterface は営業会社の台帳のリポジトリインターフェースです
+type RepositoryInterface interface {
+	// LastMetricsUpdatedAt は集計を最後に更新した日時を返します（未実行の場合は nil）
+	LastMetricsUpdatedAt() (*time.Time, error)
+
+	// ListDomains は since 以降に保存・更新したメール・案件の差出人のドメインを返します（since が nil の場合はすべて）
+	ListDomains(since *time.Time) ([]string, error)
+
+	// AggregateMetrics はドメインごとにメール・案件を集計します
+	AggregateMetrics(domains []string) (map[string]domain.Metrics, error)
+
+	// SaveMetrics は営業会社の集計を保存します（登録されていない営業会社は作成します）
+	SaveMetrics(metrics map[string]domain.Metrics, now time.Time) error
+
+	// List は条件に一致する営業会社を返します
+	List(f domain.Filter) ([]domain.Agency, error)
+
+	// Find は営業会社を返します
+	Find(agencyDomain string) (domain.Agency, error)
+
+	// SaveSettings は営業会社の表示名・ルール・メモを保存します（登録されていない営業会社は作成します）
+	SaveSettings(s domain.Settings) (domain.Agency, error)
+
+	// Rules はドメインのうちルールを指定した営業会社のルールを返します
+	Rules(domains []string) (map[string]domain.Rule, error)
+
+	// ListSenders は営業会社の差出人をメール数の多い順に返します
+	ListSenders(agencyDomain string) ([]domain.Sender, error)
+}
@@ -0,0 +1,74 @@
+// Package infrastructure は営業会社の台帳と取り込みのルールの機能のインフラストラクチャ層を提供します。
+// このファイルは台帳で参照・更新するテーブルのモデルとクエリの結果の行を定義します。
+package infrastructure
+
+import (
+	"time"
+)
+
+// Agency は営業会社（差出人のドメイン）を表すモデルです
+type Agency struct {
+	ID                   uint   `gorm:"primaryKey;autoIncrement"`
+	Domain               string `gorm:"size:255;not null;uniqueIndex"`
+	Name                 string `gorm:"size:255;not null;default:''"`
+	Rule                 string `gorm:"size:20;not null;default:'none'"`
+	Note                 *string
+	Emails               int  `gorm:"not null;default:0"`
+	ProjectEmails        int  `gorm:"not null;default:0"`
+	CandidateEmails      int  `gorm:"not null;default:0"`
+	Senders              int  `gorm:"not null;default:0"`
+	Projects             int  `gorm:"not null;default:0"`
+	PricedProjects       int  `gorm:"not null;default:0"`
+	AveragePrice         *int `gorm:"type:int"`
+	DuplicateProjects    int  `gorm:"not null;default:0"`
+	GoodProjects         int  `gorm:"not null;default:0"`
+	AppliedProjects      int  `gorm:"not null;default:0"`
+	InterviewProjects    int  `gorm:"not null;default:0"`
+	WonProjects          int  `gorm:"not null;default:0"`
+	FirstReceivedAt      *time.Time
+	LastReceivedAt       *time.Time
+	ActiveDays           int        `gorm:"not null;default:0"`
+	ReplyEmails          int        `gorm:"not null;default:0"`
+	ClosedProjects       int        `gorm:"not null;default:0"`
+	RespondedProjects    int        `gorm:"not null;default:0"`
+	AverageResponseHours *int       `gorm:"type:int"`
+	MetricsUpdatedAt     *time.Time `gorm:"index"`
+	CreatedAt            time.Time
+	UpdatedAt            time.Time
+}
+
+// emailMetricsRow はドメインごとのメールの集計の列です
+type emailMetricsRow struct {
+	AgencyDomain    string
+	Emails          int
+	ProjectEmails   int
+	CandidateEmails int
+	Senders         int
+	FirstReceivedAt *time.Time
+	LastReceivedAt  *time.Time
+	ActiveDays      int
+	ReplyEmails     int
+}
+
+// projectMetricsRow はドメインごとの案件の集計の列です
+type projectMetricsRow struct {
+	AgencyDomain         string
+	Projects             int
+	PricedProjects       int
+	AveragePrice         *float64
+	DuplicateProjects    int
+	GoodProjects         int
+	AppliedProjects      int
+	InterviewProjects    int
+	WonProjects          int
+	ClosedProjects       int
+	RespondedProjects    int
+	AverageResponseHours *float64
+}
+
+// senderRow は営業会社のメールの差出人の列です
+type senderRow struct {
+	SenderEmail  string
+	SenderName   string
+	ReceivedDate time.Time
+}
@@ -0,0 +1,391 @@
+// Package infrastructure は営業会社の台帳と取り込みのルールの機能のインフラストラクチャ層を提供します。
+// このファイルは差出人のドメインごとのメール・案件の集計と、営業会社の設定の保存・参照を実装します。
+package infrastructure
+
+import (
+	"business/internal/agency/domain"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	queryChunkSize = 500 // IN 句にまとめるドメインの件数
+
+	// agencyExpr は差出人のメールアドレスから営業会社のドメインを取り出す式です（domain.AgencyOf と同じ）
+	agencyExpr = "LOWER(SUBSTRING_INDEX(e.sender_email, '@', -1))"
+	// priceExpr は案件の税別の月額の代表値（下限と上限がある場合は中間）を求める式です
+	priceExpr = `CASE
+		WHEN ep.monthly_price_from > 0 AND ep.monthly_price_to > 0 THEN (ep.monthly_price_from + ep.monthly_price_to) / 2
+		WHEN ep.monthly_price_from > 0 THEN ep.monthly_price_from
+		WHEN ep.monthly_price_to > 0 THEN ep.monthly_price_to
+	END`
+)
+
+// metricsColumns は集計の更新で上書きする列です（表示名・ルール・メモは上書きしない）
+var metricsColumns = []string{
+	"emails", "project_emails", "candidate_emails", "senders", "projects", "priced_projects", "average_price",
+	"duplicate_projects", "good_projects", "applied_projects", "interview_projects", "won_projects",
+	"first_received_at", "last_received_at", "active_days", "reply_emails", "closed_projects", "responded_projects",
+	"average_response_hours", "metrics_updated_at", "updated_at",
+}
+
+// Repository は営業会社の台帳のリポジトリ実装です
+type Repository struct {
+	db *gorm.DB
+}
+
+// New は営業会社の台帳のリポジトリを作成します
+func New(db *gorm.DB) *Repository {
+	return &Repository{
+		db: db,
+	}
+}
+
+// LastMetricsUpdatedAt は集計を最後に更新した日時を返します（未実行の場合は nil）
+func (r *Repository) LastMetricsUpdatedAt() (*time.Time, error) {
+	var last *time.Time
+	if err := r.db.Model(&Agency{}).Select("MAX(metrics_updated_at)").Scan(&last).Error; err != nil {
+		return nil, fmt.Errorf("営業会社取得エラー: %w", err)
+	}
+	return last, nil
+}
+
+// ListDomains は since 以降に保存・更新したメール・案件の差出人のドメインを返します（since が nil の場合はすべて）
+// 案件の応募状況や重複グループの更新もメール・案件の集計に影響するため、案件・重複グループの更新日時も見ます。
+func (r *Repository) ListDomains(since *time.Time) ([]string, error) {
+	queries := []*gorm.DB{r.db.Table("emails e")}
+	if since != nil {
+		queries = []*gorm.DB{
+			r.db.Table("emails e").Where("e.updated_at >= ?", *since),
+			r.db.Table("email_projects ep").
+				Joins("JOIN emails e ON e.id = ep.email_id").
+				Where("ep.updated_at >= ?", *since),
+			r.db.Table("project_clusters c").
+				Joins("JOIN project_cluster_members m ON m.cluster_id = c.id").
+				Joins("JOIN email_projects ep ON ep.id = m.email_project_id").
+				Joins("JOIN emails e ON e.id = ep.email_id").
+				Where("c.updated_at >= ?", *since),
+		}
+	}
+
+	var domains []string
+	for _, query := range queries {
+		var found []string
+		if err := query.Where("e.sender_email LIKE ?", "%@%").Distinct().Pluck(agencyExpr, &found).Error; err != nil {
+			return nil, fmt.Errorf("差出人のドメイン取得エラー: %w", err)
+		}
+		domains = append(domains, found...)
+	}
+	domains = lo.Uniq(lo.Compact(domains))
+	sort.Strings(domains)
+	return domains, nil
+}
+
+// AggregateMetrics はドメインごとにメール・案件を集計します
+// 案件は1通に複数の案件が載る場合はそれぞれで数え、いいねはメールに付けたものを案件ごとに数えます。
+func (r *Repository) AggregateMetrics(domains []string) (map[string]domain.Metrics, error) {
+	metrics := make(map[string]domain.Metrics, len(domains))
+	for _, chunk := range lo.Chunk(domains, queryChunkSize) {
+		var emails []emailMetricsRow
+		err := r.db.Table("emails e").
+			Select(agencyExpr+` AS agency_domain, COUNT(*) AS emails,
+				SUM(CASE WHEN e.category = '案件' THEN 1 ELSE 0 END) AS project_emails,
+				SUM(CASE WHEN e.category = '人材' THEN 1 ELSE 0 END) AS candidate_emails,
+				COUNT(DISTINCT LOWER(e.sender_email)) AS senders,
+				MIN(e.received_date) AS first_received_at, MAX(e.received_date) AS last_received_at,
+				COUNT(DISTINCT DATE(e.received_date)) AS active_days,
+				SUM(CASE WHEN e.thread_id <> '' THEN 1 ELSE 0 END) - COUNT(DISTINCT NULLIF(e.thread_id, '')) AS reply_emails`).
+			Where(agencyExpr+" IN ?", chunk).
+			Group("agency_domain").
+			Scan(&emails).Error
+		if err != nil {
+			return nil, fmt.Errorf("メールの集計エラー: %w", err)
+		}
+		for _, row := range emails {
+			metrics[row.AgencyDomain] = domain.Metrics{
+				Emails:          row.Emails,
+				ProjectEmails:   row.ProjectEmails,
+				CandidateEmails: row.CandidateEmails,
+				Senders:         row.Senders,
+				FirstReceivedAt: row.FirstReceivedAt,
+				LastReceivedAt:  row.LastReceivedAt,
+				ActiveDays:      row.ActiveDays,
+				ReplyEmails:     row.ReplyEmails,
+			}
+		}
+
+		var projects []projectMetricsRow
+		err = r.db.Table("email_projects ep").
+			Select(agencyExpr+` AS agency_domain, COUNT(*) AS projects,
+				COUNT(`+priceExpr+`) AS priced_projects, AVG(`+priceExpr+`) AS average_price,
+				SUM(CASE WHEN c.agency_count > 1 THEN 1 ELSE 0 END) AS duplicate_projects,
+				SUM(CASE WHEN e.is_good THEN 1 ELSE 0 END) AS good_projects,
+				SUM(CASE WHEN ep.application_status IN ('応募済', '面談', '決定') THEN 1 ELSE 0 END) AS applied_projects,
+				SUM(CASE WHEN ep.application_status IN ('面談', '決定') THEN 1 ELSE 0 END) AS interview_projects,
+				SUM(CASE WHEN ep.application_status = '決定' THEN 1 ELSE 0 END) AS won_projects,
+				SUM(CASE WHEN ep.lifecycle_status = 'closed' THEN 1 ELSE 0 END) AS closed_projects,
+				COUNT(h.first_changed_at) AS responded_projects,
+				AVG(TIMESTAMPDIFF(MINUTE, e.received_date, h.first_changed_at)) / 60 AS average_response_hours`).
+			Joins("JOIN emails e ON e.id = ep.email_id").
+			Joins(`LEFT JOIN (SELECT email_project_id, MIN(created_at) AS first_changed_at
+				FROM application_status_histories GROUP BY email_project_id) h ON h.email_project_id = ep.id`).
+			Joins("LEFT JOIN project_cluster_members m ON m.email_project_id = ep.id").
+			Joins("LEFT JOIN project_clusters c ON c.id = m.cluster_id").
+			Where(agencyExpr+" IN ?", chunk).
+			Group("agency_domain").
+			Scan(&projects).Error
+		if err != nil {
+			return nil, fmt.Errorf("案件の集計エラー: %w", err)
+		}
+		for _, row := range projects {
+			m := metrics[row.AgencyDomain]
+			m.Projects = row.Projects
+			m.PricedProjects = row.PricedProjects
+			if row.AveragePrice != nil {
+				avg := int(math.Round(*row.AveragePrice))
+				m.AveragePrice = &avg
+			}
+			m.DuplicateProjects = row.DuplicateProjects
+			m.GoodProjects = row.GoodProjects
+			m.AppliedProjects = row.AppliedProjects
+			m.InterviewProjects = row.InterviewProjects
+			m.WonProjects = row.WonProjects
+			m.ClosedProjects = row.ClosedProjects
+			m.RespondedProjects = row.RespondedProjects
+			if row.AverageResponseHours != nil {
+				hours := int(math.Round(*row.AverageResponseHours))
+				m.AverageResponseHours = &hours
+			}
+			metrics[row.AgencyDomain] = m
+		}
+	}
+	return metrics, nil
+}
+
+// SaveMetrics は営業会社の集計を保存します
+// 登録されていない営業会社は作成し、登録済みの営業会社は集計の列だけを更新します（表示名・ルール・メモは変えない）。
+func (r *Repository) SaveMetrics(metrics map[string]domain.Metrics, now time.Time) error {
+	if len(metrics) == 0 {
+		return nil
+	}
+	rows := make([]Agency, 0, len(metrics))
+	for d, m := range metrics {
+		rows = append(rows, Agency{
+			Domain:               d,
+			Rule:                 string(domain.RuleNone),
+			Emails:               m.Emails,
+			ProjectEmails:        m.ProjectEmails,
+			CandidateEmails:      m.CandidateEmails,
+			Senders:              m.Senders,
+			Projects:             m.Projects,
+			PricedProjects:       m.PricedProjects,
+			AveragePrice:         m.AveragePrice,
+			DuplicateProjects:    m.DuplicateProjects,
+			GoodProjects:         m.GoodProjects,
+			AppliedProjects:      m.AppliedProjects,
+			InterviewProjects:    m.InterviewProjects,
+			WonProjects:          m.WonProjects,
+			FirstReceivedAt:      m.FirstReceivedAt,
+			LastReceivedAt:       m.LastReceivedAt,
+			ActiveDays:           m.ActiveDays,
+			ReplyEmails:          m.ReplyEmails,
+			ClosedProjects:       m.ClosedProjects,
+			RespondedProjects:    m.RespondedProjects,
+			AverageResponseHours: m.AverageResponseHours,
+			MetricsUpdatedAt:     &now,
+		})
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].Domain < rows[j].Domain })
+
+	err := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "domain"}},
+		DoUpdates: clause.AssignmentColumns(metricsColumns),
+	}).CreateInBatches(&rows, queryChunkSize).Error
+	if err != nil {
+		return fmt.Errorf("営業会社の集計保存エラー: %w", err)
+	}
+	return nil
+}
+
+// List は条件に一致する営業会社を返します
+func (r *Repository) List(f domain.Filter) ([]domain.Agency, error) {
+	query := r.db.Model(&Agency{})
+	if f.Rule != "" {
+		query = query.Where("rule = ?", string(f.Rule))
+	}
+	if f.Query != "" {
+		like := "%" + escapeLike(f.Query) + "%"
+		query = query.Where("domain LIKE ? OR name LIKE ?", like, like)
+	}
+	switch f.Sort {
+	case domain.SortProjects:
+		query = query.Order("projects DESC")
+	case domain.SortGoodRate:
+		query = query.Order("good_projects / NULLIF(projects, 0) DESC").Order("projects DESC")
+	case domain.SortDuplicate:
+		query = query.Order("duplicate_projects / NULLIF(projects, 0) DESC").Order("projects DESC")
+	case domain.SortLastReceived:
+		query = query.Order("last_received_at IS NULL").Order("last_received_at DESC")
+	default:
+		query = query.Order("emails DESC")
+	}
+
+	var rows []Agency
+	if err := query.Order("domain").Limit(f.Limit).Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("営業会社取得エラー: %w", err)
+	}
+	return lo.Map(rows, func(row Agency, _ int) domain.Agency { return toDomain(row) }), nil
+}
+
+// Find は営業会社を返します
+// 登録されていない場合は domain.ErrAgencyNotFound を返します。
+func (r *Repository) Find(agencyDomain string) (domain.Agency, error) {
+	return find(r.db, agencyDomain)
+}
+
+// SaveSettings は営業会社の表示名・ルール・メモを保存します
+// メールが届く前にブロックできるよう、登録されていない営業会社は作成します。
+func (r *Repository) SaveSettings(s domain.Settings) (domain.Agency, error) {
+	var saved domain.Agency
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var row Agency
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("domain = ?", s.Domain).Take(&row).Error
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			row = Agency{Domain: s.Domain, Rule: string(domain.RuleNone)}
+		case err != nil:
+			return fmt.Errorf("営業会社取得エラー: %w", err)
+		}
+		if s.Name != nil {
+			row.Name = *s.Name
+		}
+		if s.Rule != nil {
+			row.Rule = string(*s.Rule)
+		}
+		if s.Note != nil {
+			row.Note = s.Note
+			if *s.Note == "" {
+				row.Note = nil
+			}
+		}
+		if err := tx.Save(&row).Error; err != nil {
+			return fmt.Errorf("営業会社保存エラー: %w", err)
+		}
+		saved = toDomain(row)
+		return nil
+	})
+	return saved, err
+}
+
+// Rules はドメインのうちルールを指定した営業会社のルールを返します
+func (r *Repository) Rules(domains []string) (map[string]domain.Rule, error) {
+	rules := map[string]domain.Rule{}
+	for _, chunk := range lo.Chunk(lo.Uniq(domains), queryChunkSize) {
+		var rows []Agency
+		err := r.db.Select("domain, rule").
+			Where("domain IN ? AND rule <> ?", chunk, string(domain.RuleNone)).
+			Find(&rows).Error
+		if err != nil {
+			return nil, fmt.Errorf("営業会社のルール取得エラー: %w", err)
+		}
+		for _, row := range rows {
+			rules[row.Domain] = domain.Rule(row.Rule)
+		}
+	}
+	return rules, nil
+}
+
+// ListSenders は営業会社の差出人をメール数の多い順に返します
+// 差出人名は最後に受信したメールのものです。
+func (r *Repository) ListSenders(agencyDomain string) ([]domain.Sender, error) {
+	var rows []senderRow
+	err := r.db.Table("emails e").
+		Select("e.sender_email, e.sender_name, e.received_date").
+		Where(agencyExpr+" = ?", agencyDomain).
+		Order("e.received_date DESC").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("差出人取得エラー: %w", err)
+	}
+
+	var senders []domain.Sender
+	index := map[string]int{}
+	for _, row := range rows {
+		email := strings.ToLower(row.SenderEmail)
+		i, ok := index[email]
+		if !ok {
+			i = len(senders)
+			index[email] = i
+			senders = append(senders, domain.Sender{Email: email, Name: row.SenderName, LastReceivedAt: row.ReceivedDate})
+		}
+		senders[i].Emails++
+	}
+	sort.SliceStable(senders, func(i, j int) bool { return senders[i].Emails > senders[j].Emails })
+	return senders, nil
+}
+
+func find(db *gorm.DB, agencyDomain string) (domain.Agency, error) {
+	var row Agency
+	err := db.Where("domain = ?", agencyDomain).Take(&row).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domain.Agency{}, fmt.Errorf("%w: %s", domain.ErrAgencyNotFound, agencyDomain)
+	}
+	if err != nil {
+		return domain.Agency{}, fmt.Errorf("営業会社取得エラー: %w", err)
+	}
+	return toDomain(row), nil
+}
+
+func toDomain(row Agency) domain.Agency {
+	m := domain.Metrics{
+		Emails:               row.Emails,
+		ProjectEmails:        row.ProjectEmails,
+		CandidateEmails:      row.CandidateEmails,
+		Senders:              row.Senders,
+		Projects:             row.Projects,
+		PricedProjects:       row.PricedProjects,
+		AveragePrice:         row.AveragePrice,
+		DuplicateProjects:    row.DuplicateProjects,
+		GoodProjects:         row.GoodProjects,
+		AppliedProjects:      row.AppliedProjects,
+		InterviewProjects:    row.InterviewProjects,
+		WonProjects:          row.WonProjects,
+		FirstReceivedAt:      row.FirstReceivedAt,
+		LastReceivedAt:       row.LastReceivedAt,
+		ActiveDays:           row.ActiveDays,
+		ReplyEmails:          row.ReplyEmails,
+		ClosedProjects:       row.ClosedProjects,
+		RespondedProjects:    row.RespondedProjects,
+		AverageResponseHours: row.AverageResponseHours,
+	}
+	note := ""
+	if row.Note != nil {
+		note = *row.Note
+	}
+	return domain.Agency{
+		ID:               row.ID,
+		Domain:           row.Domain,
+		Name:             row.Name,
+		Rule:             domain.Rule(row.Rule),
+		Note:             note,
+		Metrics:          m,
+		Rates:            m.Rates(),
+		MetricsUpdatedAt: row.MetricsUpdatedAt,
+		UpdatedAt:        row.UpdatedAt,
+	}
+}
+
+// likeEscaper は LIKE の検索語のワイルドカードをエスケープします
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+func escapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
@@ -0,0 +1,156 @@
+package infrastructure
+
+import (
+	"business/internal/agency/domain"
+	"business/tools/migrations/model"
+	"business/tools/mysql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_Metrics(t *testing.T) {
+	t.Parallel()
+	// テスト用DBの準備
+	db, cleanup, err := mysql.CreateNewTestDB()
+	require.NoError(t, err)
+	defer cleanup()
+
+	err = db.DB.AutoMigrate(
+		model.Email{},
+		model.EmailProject{},
+		model.ProjectCluster{},
+		model.ProjectClusterMember{},
+		model.ApplicationStatusHistory{},
+		model.Agency{},
+	)
+	require.NoError(t, err)
+
+	received := time.Date(2025, 6, 2, 9, 0, 0, 0, time.Local)
+	emails := []model.Email{
+		{GmailID: "gmail-1", ThreadID: "thread-1", SenderName: "山田", SenderEmail: "yamada@Agency-A.example.jp", ReceivedDate: received, Category: "案件", IsGood: true},
+		{GmailID: "gmail-2", ThreadID: "thread-1", SenderName: "鈴木", SenderEmail: "suzuki@agency-a.example.jp", ReceivedDate: received.AddDate(0, 0, 1), Category: "人材"},
+		{GmailID: "gmail-3", SenderName: "佐藤", SenderEmail: "sato@agency-b.example.jp", ReceivedDate: received, Category: "案件"},
+	}
+	require.NoError(t, db.DB.Create(&emails).Error)
+	from, to := 600000, 800000
+	projects := []model.EmailProject{
+		{EmailID: emails[0].ID, ProjectKey: "p1", MonthlyPriceFrom: &from, MonthlyPriceTo: &to, ApplicationStatus: "面談"},
+		{EmailID: emails[0].ID, ProjectKey: "p2", MonthlyPriceTo: &from, ApplicationStatus: "未対応", LifecycleStatus: "closed"},
+		{EmailID: emails[2].ID, ProjectKey: "p3", ApplicationStatus: "未対応"},
+	}
+	require.NoError(t, db.DB.Create(&projects).Error)
+	cluster := model.ProjectCluster{RepresentativeProjectID: projects[0].ID, Size: 2, AgencyCount: 2, FirstReceivedAt: received, LastReceivedAt: received}
+	require.NoError(t, db.DB.Create(&cluster).Error)
+	members := []model.ProjectClusterMember{{ClusterID: cluster.ID, EmailProjectID: projects[0].ID}, {ClusterID: cluster.ID, EmailProjectID: projects[2].ID}}
+	require.NoError(t, db.DB.Create(&members).Error)
+	histories := []model.ApplicationStatusHistory{
+		{EmailProjectID: projects[0].ID, FromStatus: "未対応", ToStatus: "応募済", CreatedAt: received.Add(3 * time.Hour)},
+		{EmailProjectID: projects[0].ID, FromStatus: "応募済", ToStatus: "面談", CreatedAt: received.AddDate(0, 0, 2)},
+	}
+	require.NoError(t, db.DB.Create(&histories).Error)
+
+	repo := New(db.DB)
+
+	// ドメインは小文字にまとめること
+	domains, err := repo.ListDomains(nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"agency-a.example.jp", "agency-b.example.jp"}, domains)
+
+	metrics, err := repo.AggregateMetrics(domains)
+	require.NoError(t, err)
+	a := metrics["agency-a.example.jp"]
+	assert.Equal(t, 2, a.Emails)
+	assert.Equal(t, 1, a.ProjectEmails)
+	assert.Equal(t, 1, a.CandidateEmails)
+	assert.Equal(t, 2, a.Senders)
+	assert.Equal(t, 2, a.Projects)
+	assert.Equal(t, 2, a.PricedProjects)
+	assert.Equal(t, 650000, *a.AveragePrice)
+	assert.Equal(t, 1, a.DuplicateProjects)
+	assert.Equal(t, 2, a.GoodProjects)
+	assert.Equal(t, 1, a.AppliedProjects)
+	assert.Equal(t, 1, a.InterviewProjects)
+	assert.Zero(t, a.WonProjects)
+	assert.True(t, received.Equal(*a.FirstReceivedAt))
+	assert.Equal(t, 2, a.ActiveDays)
+	assert.Equal(t, 1, a.ReplyEmails)
+	assert.Equal(t, 1, a.ClosedProjects)
+	assert.Equal(t, 1, a.RespondedProjects)
+	assert.Equal(t, 3, *a.AverageResponseHours)
+	assert.Nil(t, metrics["agency-b.example.jp"].AverageResponseHours)
+	assert.Nil(t, metrics["agency-b.example.jp"].AveragePrice)
+
+	// 集計の保存で設定を上書きしないこと
+	rule := domain.RuleMute
+	_, err = repo.SaveSettings(domain.Settings{Domain: "agency-a.example.jp", Rule: &rule})
+	require.NoError(t, err)
+	now := time.Now().Truncate(time.Second)
+	require.NoError(t, repo.SaveMetrics(metrics, now))
+	got, err := repo.Find("agency-a.example.jp")
+	require.NoError(t, err)
+	assert.Equal(t, domain.RuleMute, got.Rule)
+	assert.Equal(t, 2, got.Projects)
+	assert.Equal(t, 0.5, got.DuplicateRate)
+
+	last, err := repo.LastMetricsUpdatedAt()
+	require.NoError(t, err)
+	assert.True(t, now.Equal(*last))
+	// 集計の更新以降に更新したメールのドメインだけを返すこと
+	require.NoError(t, db.DB.Model(&model.Email{}).Where("id = ?", emails[2].ID).Update("updated_at", now.Add(time.Minute)).Error)
+	domains, err = repo.ListDomains(last)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"agency-b.example.jp"}, domains)
+
+	senders, err := repo.ListSenders("agency-a.example.jp")
+	require.NoError(t, err)
+	require.Len(t, senders, 2)
+	assert.Equal(t, "yamada@agency-a.example.jp", senders[0].Email)
+	assert.Equal(t, "山田", senders[0].Name)
+}
+
+func TestRepository_Settings(t *testing.T) {
+	t.Parallel()
+	// テスト用DBの準備
+	db, cleanup, err := mysql.CreateNewTestDB()
+	require.NoError(t, err)
+	defer cleanup()
+
+	require.NoError(t, db.DB.AutoMigrate(model.Agency{}))
+	repo := New(db.DB)
+
+	_, err = repo.Find("blocked.example.jp")
+	assert.ErrorIs(t, err, domain.ErrAgencyNotFound)
+
+	// メールが届く前でもルールを登録できること
+	block, prioritize := domain.RuleBlock, domain.RulePrioritize
+	name, note := "ブロック社", "重複ばかり"
+	saved, err := repo.SaveSettings(domain.Settings{Domain: "blocked.example.jp", Name: &name, Rule: &block, Note: &note})
+	require.NoError(t, err)
+	assert.Equal(t, domain.RuleBlock, saved.Rule)
+	assert.Nil(t, saved.MetricsUpdatedAt)
+	_, err = repo.SaveSettings(domain.Settings{Domain: "priority.example.jp", Rule: &prioritize})
+	require.NoError(t, err)
+
+	// 指定しない項目は変えないこと
+	saved, err = repo.SaveSettings(domain.Settings{Domain: "blocked.example.jp", Rule: &prioritize})
+	require.NoError(t, err)
+	assert.Equal(t, "ブロック社", saved.Name)
+	assert.Equal(t, "重複ばかり", saved.Note)
+	_, err = repo.SaveSettings(domain.Settings{Domain: "blocked.example.jp", Rule: &block})
+	require.NoError(t, err)
+
+	rules, err := repo.Rules([]string{"blocked.example.jp", "priority.example.jp", "normal.example.jp"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]domain.Rule{"blocked.example.jp": domain.RuleBlock, "priority.example.jp": domain.RulePrioritize}, rules)
+
+	list, err := repo.List(domain.Filter{Rule: domain.RuleBlock, Sort: domain.SortEmails, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, "blocked.example.jp", list[0].Domain)
+	list, err = repo.List(domain.Filter{Query: "ブロック", Sort: domain.SortLastReceived, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+}
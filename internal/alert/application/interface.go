@@ -0,0 +1,36 @@
+// Package application は保存した検索条件と新着案件の通知機能のアプリケーション層を提供します。
+// このファイルは通知のユースケースインターフェースを定義します。
+package application
+
+import (
+	"business/internal/alert/domain"
+	"context"
+	"time"
+)
+
+// UseCaseInterface は保存した検索条件と新着案件の通知のユースケースインターフェースです
+type UseCaseInterface interface {
+	// ListSearches は利用者の検索条件をID順に返します（userID が空の場合はすべて）
+	ListSearches(userID string) ([]domain.SavedSearch, error)
+
+	// GetSearch は検索条件を返します
+	GetSearch(id uint) (domain.SavedSearch, error)
+
+	// CreateSearch は検索条件を検証して保存します
+	CreateSearch(s domain.SavedSearch) (domain.SavedSearch, error)
+
+	// UpdateSearch は検索条件を検証して更新します
+	UpdateSearch(s domain.SavedSearch) (domain.SavedSearch, error)
+
+	// DeleteSearch は検索条件を通知ごと削除します
+	DeleteSearch(id uint) error
+
+	// Run は前回の評価以降に保存した案件を検索条件と照合して通知を作成し、未送信の通知を送信します
+	Run(ctx context.Context, now time.Time) (domain.RunResult, error)
+
+	// AfterSave はメール解析結果の保存後に新着案件を評価します
+	AfterSave() error
+
+	// ListNotifications は条件に一致する通知を新しい順に返します
+	ListNotifications(f domain.NotificationFilter) ([]domain.Notification, error)
+}
@@ -0,0 +1,15 @@
+// Package application は保存した検索条件と新着案件の通知機能のアプリケーション層を提供します。
+// このファイルは通知の送信先のインターフェースを定義します。
+package application
+
+import (
+	"business/internal/alert/domain"
+	"context"
+)
+
+// Notifier は通知の送信先です
+// 送信に失敗した場合はエラーを返し、通知は domain.MaxAttempts 回まで次の実行で再送されます。
+type Notifier interface {
+	// Notify は通知を送信します
+	Notify(ctx context.Context, n domain.Notification) error
+}
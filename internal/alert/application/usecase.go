@@ -0,0 +1,229 @@
+// Package application は保存した検索条件と新着案件の通知機能のアプリケーション層を提供します。
+// このファイルは検索条件の管理と、新着案件の評価・通知の送信のユースケースを実装します。
+package application
+
+import (
+	"business/internal/alert/domain"
+	r "business/internal/alert/infrastructure"
+	"business/tools/keyword"
+	"business/tools/location"
+	"business/tools/oswrapper"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/samber/lo"
+)
+
+// UseCase は保存した検索条件と新着案件の通知のユースケースの具象です
+type UseCase struct {
+	r  r.RepositoryInterface
+	n  Notifier
+	os oswrapper.OsWapperInterface
+}
+
+// New は保存した検索条件と新着案件の通知のユースケースを作成します
+func New(r r.RepositoryInterface, n Notifier, os oswrapper.OsWapperInterface) *UseCase {
+	return &UseCase{
+		r:  r,
+		n:  n,
+		os: os,
+	}
+}
+
+// ListSearches は利用者の検索条件をID順に返します（userID が空の場合はすべて）
+func (u *UseCase) ListSearches(userID string) ([]domain.SavedSearch, error) {
+	return u.r.ListSearches(userID)
+}
+
+// GetSearch は検索条件を返します
+func (u *UseCase) GetSearch(id uint) (domain.SavedSearch, error) {
+	return u.r.FindSearch(id)
+}
+
+// CreateSearch は検索条件を検証して保存します
+// 保存した時点より後に保存した案件が通知の対象になります。
+func (u *UseCase) CreateSearch(s domain.SavedSearch) (domain.SavedSearch, error) {
+	s, err := normalizeSearch(s)
+	if err != nil {
+		return domain.SavedSearch{}, err
+	}
+	return u.r.CreateSearch(s)
+}
+
+// UpdateSearch は検索条件を検証して更新します
+func (u *UseCase) UpdateSearch(s domain.SavedSearch) (domain.SavedSearch, error) {
+	s, err := normalizeSearch(s)
+	if err != nil {
+		return domain.SavedSearch{}, err
+	}
+	return u.r.UpdateSearch(s)
+}
+
+// DeleteSearch は検索条件を通知ごと削除します
+func (u *UseCase) DeleteSearch(id uint) error {
+	return u.r.DeleteSearch(id)
+}
+
+// Run は前回の評価以降に保存した案件を検索条件と照合して通知を作成し、未送信の通知を送信します
+// 案件は domain.DefaultBatchSize 件ずつ評価し、評価済みの案件IDを実行履歴に記録して次の実行ではその後の案件から評価します。
+// 同じ案件を重ねて評価しても、同じ検索条件・案件の通知は1件だけ作成します。
+// 送信に失敗した通知は送信失敗として記録し、domain.MaxAttempts 回まで次の実行で再送します。
+func (u *UseCase) Run(ctx context.Context, now time.Time) (domain.RunResult, error) {
+	startedAt := time.Now()
+	result := domain.RunResult{}
+
+	searches, err := u.r.ListEnabledSearches()
+	if err != nil {
+		return result, err
+	}
+	result.Searches = len(searches)
+	last, err := u.r.LastEvaluatedProjectID()
+	if err != nil {
+		return result, err
+	}
+	result.LastProjectID = last
+
+	for {
+		projects, err := u.r.ListNewProjects(result.LastProjectID, domain.DefaultBatchSize)
+		if err != nil {
+			return result, err
+		}
+		if len(projects) == 0 {
+			break
+		}
+		notifications, err := u.evaluate(searches, projects)
+		if err != nil {
+			return result, err
+		}
+		created, err := u.r.SaveNotifications(notifications)
+		if err != nil {
+			return result, err
+		}
+		result.Evaluated += len(projects)
+		result.Created += created
+		result.LastProjectID = projects[len(projects)-1].ProjectID
+		if len(projects) < domain.DefaultBatchSize {
+			break
+		}
+	}
+	if result.Evaluated > 0 {
+		if err := u.r.SaveRun(result.LastProjectID, result.Evaluated, result.Created, startedAt, time.Now()); err != nil {
+			return result, err
+		}
+	}
+
+	pending, err := u.r.ListPendingNotifications(domain.DefaultBatchSize)
+	if err != nil {
+		return result, err
+	}
+	for _, n := range pending {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		sendErr := u.n.Notify(ctx, n)
+		if err := u.r.SaveDelivery(n.ID, sendErr, now); err != nil {
+			return result, err
+		}
+		if sendErr != nil {
+			fmt.Printf("通知送信エラー #%d: %v\n", n.ID, sendErr)
+			result.Failed++
+			continue
+		}
+		result.Sent++
+	}
+
+	return result, nil
+}
+
+// AfterSave はメール解析結果の保存後に新着案件を評価します
+func (u *UseCase) AfterSave() error {
+	result, err := u.Run(context.Background(), time.Now())
+	if err != nil {
+		return fmt.Errorf("新着案件の通知エラー: %w", err)
+	}
+	if result.Created > 0 || result.Failed > 0 {
+		fmt.Printf("新着案件の通知: 評価 %d件 / 作成 %d件 / 送信 %d件 / 失敗 %d件\n", result.Evaluated, result.Created, result.Sent, result.Failed)
+	}
+	return nil
+}
+
+// ListNotifications は条件に一致する通知を新しい順に返します
+func (u *UseCase) ListNotifications(f domain.NotificationFilter) ([]domain.Notification, error) {
+	f, err := f.Normalize()
+	if err != nil {
+		return nil, err
+	}
+	return u.r.ListNotifications(f)
+}
+
+// evaluate は案件を検索条件と照合し、一致した組み合わせの通知を返します
+// 言語・フレームワークは用語辞書のキーワードグループと別名ルールの正規名に解決し、別名も一致とみなします。
+func (u *UseCase) evaluate(searches []domain.SavedSearch, projects []domain.Project) ([]domain.Notification, error) {
+	if len(searches) == 0 {
+		return nil, nil
+	}
+	n, err := keyword.Load(u.os)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, s := range searches {
+		names = append(names, s.Keywords()...)
+	}
+	for _, p := range projects {
+		for _, kw := range append(append([]domain.Keyword{}, p.Languages...), p.Frameworks...) {
+			names = append(names, kw.Name)
+		}
+	}
+	names = lo.Uniq(names)
+	// 用語辞書に別名が無い名前（"JS" など）も、別名ルールの正規名のグループに解決する
+	canonical := lo.Map(names, func(name string, _ int) string { return n.Normalize(name) })
+	resolved, err := u.r.ResolveKeywordGroups(lo.Uniq(append(append([]string{}, names...), canonical...)))
+	if err != nil {
+		return nil, err
+	}
+	groups := make(map[string]uint, len(names))
+	for i, name := range names {
+		if id, ok := resolved[keyword.Key(name)]; ok {
+			groups[keyword.Key(name)] = id
+		} else if id, ok := resolved[keyword.Key(canonical[i])]; ok {
+			groups[keyword.Key(name)] = id
+		}
+	}
+
+	var notifications []domain.Notification
+	for _, p := range projects {
+		for i := range p.Languages {
+			p.Languages[i].GroupID = groups[keyword.Key(p.Languages[i].Name)]
+		}
+		for i := range p.Frameworks {
+			p.Frameworks[i].GroupID = groups[keyword.Key(p.Frameworks[i].Name)]
+		}
+		for _, s := range searches {
+			if s.Matches(p, groups, n) {
+				notifications = append(notifications, domain.NewNotification(s, p))
+			}
+		}
+	}
+	return notifications, nil
+}
+
+// normalizeSearch は検索条件を検証し、都道府県を正式名にします（"東京"、"都内" などの略称も可）
+func normalizeSearch(s domain.SavedSearch) (domain.SavedSearch, error) {
+	s, err := s.Validate()
+	if err != nil {
+		return s, err
+	}
+	prefectures := make([]string, 0, len(s.Prefectures))
+	for _, name := range s.Prefectures {
+		pref, ok := location.NormalizePrefecture(name)
+		if !ok {
+			return s, fmt.Errorf("%w: 都道府県 %q を解釈できません", domain.ErrInvalidSearch, name)
+		}
+		prefectures = append(prefectures, pref)
+	}
+	s.Prefectures = prefectures
+	return s, nil
+}
@@ -0,0 +1,238 @@
+package application
+
+import (
+	"business/internal/alert/domain"
+	"business/tools/keyword"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockRepository は保存した検索条件と新着案件の通知のリポジトリのモックです
+type MockRepository struct {
+	mock.Mock
+}
+
+func (m *MockRepository) ListSearches(userID string) ([]domain.SavedSearch, error) {
+	args := m.Called(userID)
+	return args.Get(0).([]domain.SavedSearch), args.Error(1)
+}
+
+func (m *MockRepository) ListEnabledSearches() ([]domain.SavedSearch, error) {
+	args := m.Called()
+	return args.Get(0).([]domain.SavedSearch), args.Error(1)
+}
+
+func (m *MockRepository) FindSearch(id uint) (domain.SavedSearch, error) {
+	args := m.Called(id)
+	return args.Get(0).(domain.SavedSearch), args.Error(1)
+}
+
+func (m *MockRepository) CreateSearch(s domain.SavedSearch) (domain.SavedSearch, error) {
+	args := m.Called(s)
+	return args.Get(0).(domain.SavedSearch), args.Error(1)
+}
+
+func (m *MockRepository) UpdateSearch(s domain.SavedSearch) (domain.SavedSearch, error) {
+	args := m.Called(s)
+	return args.Get(0).(domain.SavedSearch), args.Error(1)
+}
+
+func (m *MockRepository) DeleteSearch(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockRepository) ResolveKeywordGroups(names []string) (map[string]uint, error) {
+	args := m.Called(names)
+	return args.Get(0).(map[string]uint), args.Error(1)
+}
+
+func (m *MockRepository) LastEvaluatedProjectID() (uint, error) {
+	args := m.Called()
+	return args.Get(0).(uint), args.Error(1)
+}
+
+func (m *MockRepository) ListNewProjects(afterID uint, limit int) ([]domain.Project, error) {
+	args := m.Called(afterID, limit)
+	return args.Get(0).([]domain.Project), args.Error(1)
+}
+
+func (m *MockRepository) SaveNotifications(notifications []domain.Notification) (int, error) {
+	args := m.Called(notifications)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockRepository) SaveRun(lastProjectID uint, evaluated, created int, startedAt, finishedAt time.Time) error {
+	args := m.Called(lastProjectID, evaluated, created, startedAt, finishedAt)
+	return args.Error(0)
+}
+
+func (m *MockRepository) ListPendingNotifications(limit int) ([]domain.Notification, error) {
+	args := m.Called(limit)
+	return args.Get(0).([]domain.Notification), args.Error(1)
+}
+
+func (m *MockRepository) SaveDelivery(id uint, sendErr error, now time.Time) error {
+	args := m.Called(id, sendErr, now)
+	return args.Error(0)
+}
+
+func (m *MockRepository) ListNotifications(f domain.NotificationFilter) ([]domain.Notification, error) {
+	args := m.Called(f)
+	return args.Get(0).([]domain.Notification), args.Error(1)
+}
+
+// MockNotifier は通知の送信先のモックです
+type MockNotifier struct {
+	mock.Mock
+}
+
+func (m *MockNotifier) Notify(ctx context.Context, n domain.Notification) error {
+	args := m.Called(n.ID)
+	return args.Error(0)
+}
+
+// mockOsWrapper は別名ルールファイルを読み込む oswrapper のモックです
+type mockOsWrapper struct {
+	files map[string]string
+}
+
+func (m *mockOsWrapper) ReadFile(path string) (string, error) {
+	if text, ok := m.files[path]; ok {
+		return text, nil
+	}
+	return "", errors.New("file not found")
+}
+
+func (m *mockOsWrapper) GetEnv(key string) string {
+	return ""
+}
+
+// newOsWrapper は別名ルールファイルを既定の配置場所に置いた oswrapper を作成します
+func newOsWrapper(rules string) *mockOsWrapper {
+	return &mockOsWrapper{files: map[string]string{keyword.DefaultRulesPath: rules}}
+}
+
+func TestRun(t *testing.T) {
+	repo := new(MockRepository)
+	notifier := new(MockNotifier)
+	usecase := New(repo, notifier, newOsWrapper(""))
+
+	now := time.Date(2025, 7, 1, 9, 0, 0, 0, time.UTC)
+	created := now.Add(-24 * time.Hour)
+	searches := []domain.SavedSearch{
+		{ID: 1, UserID: "a@example.com", Name: "Go", Languages: []string{"Go"}, LanguageMatch: domain.MatchAny, CreatedAt: created},
+		{ID: 2, UserID: "b@example.com", Name: "東京", Prefectures: []string{"東京都"}, CreatedAt: created},
+	}
+	projects := []domain.Project{
+		{ProjectID: 11, GmailID: "gmail-golang", ProjectKey: "k1", CreatedAt: now, Languages: []domain.Keyword{{Name: "Golang"}}},
+		{ProjectID: 12, GmailID: "gmail-php", ProjectKey: "k2", CreatedAt: now, Languages: []domain.Keyword{{Name: "PHP"}}, Prefectures: []string{"東京都"}},
+	}
+	repo.On("ListEnabledSearches").Return(searches, nil)
+	repo.On("LastEvaluatedProjectID").Return(uint(10), nil)
+	repo.On("ListNewProjects", uint(10), domain.DefaultBatchSize).Return(projects, nil)
+	repo.On("ResolveKeywordGroups", []string{"Go", "Golang", "PHP"}).Return(map[string]uint{"go": 1, "golang": 1, "php": 2}, nil)
+
+	var saved []domain.Notification
+	repo.On("SaveNotifications", mock.Anything).Run(func(args mock.Arguments) {
+		saved = args.Get(0).([]domain.Notification)
+	}).Return(2, nil)
+	repo.On("SaveRun", uint(12), 2, 2, mock.Anything, mock.Anything).Return(nil)
+	repo.On("ListPendingNotifications", domain.DefaultBatchSize).Return([]domain.Notification{{ID: 100}, {ID: 101}}, nil)
+	sendErr := errors.New("webhook error")
+	notifier.On("Notify", uint(100)).Return(nil)
+	notifier.On("Notify", uint(101)).Return(sendErr)
+	repo.On("SaveDelivery", uint(100), nil, now).Return(nil)
+	repo.On("SaveDelivery", uint(101), sendErr, now).Return(nil)
+
+	result, err := usecase.Run(context.Background(), now)
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.RunResult{Evaluated: 2, Searches: 2, Created: 2, Sent: 1, Failed: 1, LastProjectID: 12}, result)
+	require.Len(t, saved, 2)
+	assert.Equal(t, uint(1), saved[0].SavedSearchID)
+	assert.Equal(t, uint(11), saved[0].EmailProjectID)
+	assert.Equal(t, uint(2), saved[1].SavedSearchID)
+	assert.Equal(t, uint(12), saved[1].EmailProjectID)
+	repo.AssertExpectations(t)
+	notifier.AssertExpectations(t)
+}
+
+func TestRun_AliasRules(t *testing.T) {
+	repo := new(MockRepository)
+	notifier := new(MockNotifier)
+	usecase := New(repo, notifier, newOsWrapper("JavaScript: JS"))
+
+	now := time.Date(2025, 7, 1, 9, 0, 0, 0, time.UTC)
+	searches := []domain.SavedSearch{
+		{ID: 1, UserID: "a@example.com", Name: "JS", Languages: []string{"JS"}, LanguageMatch: domain.MatchAny, CreatedAt: now.Add(-time.Hour)},
+	}
+	projects := []domain.Project{
+		{ProjectID: 11, GmailID: "gmail-js", ProjectKey: "k1", CreatedAt: now, Languages: []domain.Keyword{{Name: "JavaScript"}}},
+	}
+	repo.On("ListEnabledSearches").Return(searches, nil)
+	repo.On("LastEvaluatedProjectID").Return(uint(10), nil)
+	repo.On("ListNewProjects", uint(10), domain.DefaultBatchSize).Return(projects, nil)
+	// 用語辞書に別名が無くても、別名ルールの正規名で一致する
+	repo.On("ResolveKeywordGroups", []string{"JS", "JavaScript"}).Return(map[string]uint{}, nil)
+	var saved []domain.Notification
+	repo.On("SaveNotifications", mock.Anything).Run(func(args mock.Arguments) {
+		saved = args.Get(0).([]domain.Notification)
+	}).Return(1, nil)
+	repo.On("SaveRun", uint(11), 1, 1, mock.Anything, mock.Anything).Return(nil)
+	repo.On("ListPendingNotifications", domain.DefaultBatchSize).Return([]domain.Notification{}, nil)
+
+	_, err := usecase.Run(context.Background(), now)
+
+	require.NoError(t, err)
+	require.Len(t, saved, 1)
+	assert.Equal(t, uint(11), saved[0].EmailProjectID)
+	repo.AssertExpectations(t)
+}
+
+func TestRun_NoNewProjects(t *testing.T) {
+	repo := new(MockRepository)
+	usecase := New(repo, new(MockNotifier), newOsWrapper(""))
+
+	repo.On("ListEnabledSearches").Return([]domain.SavedSearch{}, nil)
+	repo.On("LastEvaluatedProjectID").Return(uint(5), nil)
+	repo.On("ListNewProjects", uint(5), domain.DefaultBatchSize).Return([]domain.Project{}, nil)
+	repo.On("ListPendingNotifications", domain.DefaultBatchSize).Return([]domain.Notification{}, nil)
+
+	result, err := usecase.Run(context.Background(), time.Now())
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.RunResult{LastProjectID: 5}, result)
+	repo.AssertNotCalled(t, "SaveRun", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	repo.AssertExpectations(t)
+}
+
+func TestCreateSearch_NormalizesPrefectures(t *testing.T) {
+	repo := new(MockRepository)
+	usecase := New(repo, new(MockNotifier), newOsWrapper(""))
+
+	want := domain.SavedSearch{UserID: "a@example.com", Name: "東京", LanguageMatch: domain.MatchAny,
+		Languages: []string{}, Frameworks: []string{}, RemoteTypes: []string{}, Prefectures: []string{"東京都"}}
+	repo.On("CreateSearch", want).Return(want, nil)
+
+	_, err := usecase.CreateSearch(domain.SavedSearch{UserID: "a@example.com", Name: "東京", Prefectures: []string{"東京"}})
+	require.NoError(t, err)
+
+	_, err = usecase.CreateSearch(domain.SavedSearch{UserID: "a@example.com", Name: "不明", Prefectures: []string{"どこか"}})
+	assert.True(t, errors.Is(err, domain.ErrInvalidSearch))
+	repo.AssertExpectations(t)
+}
+
+func TestListNotifications_InvalidFilter(t *testing.T) {
+	usecase := New(new(MockRepository), new(MockNotifier), newOsWrapper(""))
+
+	_, err := usecase.ListNotifications(domain.NotificationFilter{Status: "unknown"})
+
+	assert.True(t, errors.Is(err, domain.ErrInvalidSearch))
+}
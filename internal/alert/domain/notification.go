@@ -0,0 +1,159 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// MaxAttempts は送信に失敗した通知を再送する回数の上限です
+	MaxAttempts = 3
+	// DefaultBatchSize は1回に評価する新着案件の件数です
+	DefaultBatchSize = 500
+	// DefaultNotificationLimit は既定で返す通知の件数です
+	DefaultNotificationLimit = 50
+	// MaxNotificationLimit は返す通知の件数の上限です
+	MaxNotificationLimit = 200
+)
+
+// NotificationStatus は通知の送信状況です
+type NotificationStatus string
+
+const (
+	StatusPending NotificationStatus = "pending" // 未送信
+	StatusSent    NotificationStatus = "sent"    // 送信済み
+	StatusFailed  NotificationStatus = "failed"  // 送信失敗（MaxAttempts 回まで再送）
+)
+
+// IsValid は送信状況が定義済みの値かどうかを返します
+func (s NotificationStatus) IsValid() bool {
+	switch s {
+	case StatusPending, StatusSent, StatusFailed:
+		return true
+	}
+	return false
+}
+
+// Notification は検索条件に一致した新着案件の通知です
+type Notification struct {
+	ID             uint               `json:"id"`
+	SavedSearchID  uint               `json:"saved_search_id"`
+	SearchName     string             `json:"search_name"`
+	UserID         string             `json:"user_id"`
+	EmailProjectID uint               `json:"email_project_id"`
+	GmailID        string             `json:"gmail_id"`
+	ProjectKey     string             `json:"project_key"`
+	Title          string             `json:"title"`
+	Message        string             `json:"message"`
+	Status         NotificationStatus `json:"status"`
+	Attempts       int                `json:"attempts"`
+	LastError      string             `json:"last_error"`
+	SentAt         *time.Time         `json:"sent_at"`
+	CreatedAt      time.Time          `json:"created_at"`
+	Project        *Project           `json:"-"` // 送信時に参照する案件の内容（案件が削除済みの場合は nil）
+}
+
+// NewNotification は検索条件に一致した案件の通知を作成します
+func NewNotification(s SavedSearch, p Project) Notification {
+	title := p.ProjectTitle
+	if title == "" {
+		title = p.Subject
+	}
+	lines := []string{
+		"案件: " + title,
+		"差出人: " + p.SenderEmail,
+		"受信日: " + p.ReceivedDate.Format("2006-01-02 15:04"),
+	}
+	if names := keywordNames(p.Languages, p.Frameworks); names != "" {
+		lines = append(lines, "技術: "+names)
+	}
+	if price := formatPrice(p.MonthlyPriceFrom, p.MonthlyPriceTo); price != "" {
+		lines = append(lines, "単価: "+price)
+	}
+	if p.RemoteType != "" {
+		lines = append(lines, "リモート: "+p.RemoteType)
+	}
+	if len(p.Prefectures) > 0 {
+		lines = append(lines, "勤務地: "+strings.Join(p.Prefectures, ", "))
+	}
+	lines = append(lines, "GメールID: "+p.GmailID)
+
+	return Notification{
+		SavedSearchID:  s.ID,
+		SearchName:     s.Name,
+		UserID:         s.UserID,
+		EmailProjectID: p.ProjectID,
+		GmailID:        p.GmailID,
+		ProjectKey:     p.ProjectKey,
+		Title:          truncate(fmt.Sprintf("[%s] %s", s.Name, title), 255),
+		Message:        strings.Join(lines, "\n"),
+		Status:         StatusPending,
+	}
+}
+
+// RunResult は新着案件の評価と通知の送信の結果です
+type RunResult struct {
+	Evaluated     int  `json:"evaluated"`       // 評価した案件数
+	Searches      int  `json:"searches"`        // 評価に使った検索条件の数
+	Created       int  `json:"created"`         // 作成した通知の数
+	Sent          int  `json:"sent"`            // 送信した通知の数
+	Failed        int  `json:"failed"`          // 送信に失敗した通知の数
+	LastProjectID uint `json:"last_project_id"` // 評価済みの案件IDの最大値
+}
+
+// NotificationFilter は通知の一覧の条件です
+type NotificationFilter struct {
+	UserID string
+	Status NotificationStatus
+	Limit  int
+}
+
+// Normalize は未指定の項目に既定値を設定し、条件を検証します
+func (f NotificationFilter) Normalize() (NotificationFilter, error) {
+	if f.Limit == 0 {
+		f.Limit = DefaultNotificationLimit
+	}
+	if f.Limit < 0 || f.Limit > MaxNotificationLimit {
+		return f, fmt.Errorf("%w: limit は1〜%dで指定してください", ErrInvalidSearch, MaxNotificationLimit)
+	}
+	if f.Status != "" && !f.Status.IsValid() {
+		return f, fmt.Errorf("%w: status は pending / sent / failed のいずれかを指定してください", ErrInvalidSearch)
+	}
+	return f, nil
+}
+
+func keywordNames(groups ...[]Keyword) string {
+	var names []string
+	for _, keywords := range groups {
+		for _, kw := range keywords {
+			names = append(names, kw.Name)
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+// formatPrice は税別の月額の単価の範囲を万円の表記にします
+func formatPrice(from, to *int) string {
+	man := func(yen int) string {
+		return strconv.FormatFloat(float64(yen)/10000, 'f', -1, 64) + "万円"
+	}
+	switch {
+	case from != nil && to != nil && *from != *to:
+		return man(*from) + "〜" + man(*to)
+	case to != nil:
+		return man(*to)
+	case from != nil:
+		return man(*from) + "〜"
+	}
+	return ""
+}
+
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n])
+}
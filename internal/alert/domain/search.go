@@ -0,0 +1,197 @@
+// Package domain は保存した検索条件と新着案件の通知機能のドメイン層を提供します。
+// このファイルは保存した検索条件と、案件が条件に一致するかどうかの判定を定義します。
+package domain
+
+import (
+	"business/tools/keyword"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// 複数指定した言語の一致条件
+const (
+	MatchAny = "any" // いずれかに一致
+	MatchAll = "all" // すべてに一致
+)
+
+var (
+	// ErrInvalidSearch は検索条件の内容が不正な場合のエラーです
+	ErrInvalidSearch = errors.New("検索条件の内容が不正です")
+
+	// ErrSearchNotFound は検索条件が見つからない場合のエラーです
+	ErrSearchNotFound = errors.New("検索条件が見つかりません")
+
+	// ErrConflict は同じ利用者に同じ名前の検索条件がある場合のエラーです
+	ErrConflict = errors.New("同じ名前の検索条件が既に登録されています")
+)
+
+// SavedSearch は利用者ごとに保存した検索条件です
+// 指定した項目はすべて満たす必要があり、未指定の項目は絞り込みません。
+type SavedSearch struct {
+	ID            uint      `json:"id"`
+	UserID        string    `json:"user_id"`        // 利用者（メールアドレスなど）
+	Name          string    `json:"name"`           // 検索条件の名前（利用者ごとに一意）
+	Languages     []string  `json:"languages"`      // 言語（表記ゆれはキーワードグループで照合）
+	LanguageMatch string    `json:"language_match"` // any / all
+	Frameworks    []string  `json:"frameworks"`     // フレームワーク（いずれかに一致）
+	PriceMin      *int      `json:"price_min"`      // 単価の下限（税別の月額に換算した単価TOがこの値以上）
+	PriceMax      *int      `json:"price_max"`      // 単価の上限（税別の月額に換算した単価FROMがこの値以下）
+	RemoteTypes   []string  `json:"remote_types"`   // リモート区分（いずれかに一致）
+	Prefectures   []string  `json:"prefectures"`    // 勤務地の都道府県（いずれかに一致）
+	Enabled       bool      `json:"enabled"`        // 通知するか
+	CreatedAt     time.Time `json:"created_at"`     // これ以降に保存した案件が通知の対象
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// Validate は検索条件の前後の空白と空の値を除き、内容を検証します
+func (s SavedSearch) Validate() (SavedSearch, error) {
+	s.UserID = strings.TrimSpace(s.UserID)
+	s.Name = strings.TrimSpace(s.Name)
+	if s.UserID == "" {
+		return s, fmt.Errorf("%w: 利用者は必須です", ErrInvalidSearch)
+	}
+	if s.Name == "" {
+		return s, fmt.Errorf("%w: 名前は必須です", ErrInvalidSearch)
+	}
+	if s.LanguageMatch == "" {
+		s.LanguageMatch = MatchAny
+	}
+	if s.LanguageMatch != MatchAny && s.LanguageMatch != MatchAll {
+		return s, fmt.Errorf("%w: language_match は any / all のいずれかを指定してください", ErrInvalidSearch)
+	}
+	if (s.PriceMin != nil && *s.PriceMin < 0) || (s.PriceMax != nil && *s.PriceMax < 0) {
+		return s, fmt.Errorf("%w: 単価は0以上で指定してください", ErrInvalidSearch)
+	}
+	if s.PriceMin != nil && s.PriceMax != nil && *s.PriceMin > *s.PriceMax {
+		return s, fmt.Errorf("%w: price_min は price_max 以下で指定してください", ErrInvalidSearch)
+	}
+
+	s.Languages = compact(s.Languages)
+	s.Frameworks = compact(s.Frameworks)
+	s.RemoteTypes = compact(s.RemoteTypes)
+	s.Prefectures = compact(s.Prefectures)
+	if len(s.Languages) == 0 && len(s.Frameworks) == 0 && s.PriceMin == nil && s.PriceMax == nil &&
+		len(s.RemoteTypes) == 0 && len(s.Prefectures) == 0 {
+		return s, fmt.Errorf("%w: 言語・フレームワーク・単価・リモート区分・都道府県のいずれかを指定してください", ErrInvalidSearch)
+	}
+	return s, nil
+}
+
+// Keywords は照合に使う言語・フレームワークの名前を返します
+func (s SavedSearch) Keywords() []string {
+	return append(append([]string{}, s.Languages...), s.Frameworks...)
+}
+
+// Keyword は案件に記載された言語・フレームワークです
+type Keyword struct {
+	Name    string
+	GroupID uint // 照合用のキーワードグループID（解決できない場合は0）
+}
+
+// Project は通知の判定の対象にする新着案件です
+type Project struct {
+	ProjectID        uint
+	GmailID          string
+	ProjectKey       string
+	Subject          string
+	ProjectTitle     string
+	SenderEmail      string
+	ReceivedDate     time.Time
+	CreatedAt        time.Time // 案件を保存した日時
+	Languages        []Keyword
+	Frameworks       []Keyword
+	MonthlyPriceFrom *int
+	MonthlyPriceTo   *int
+	RemoteType       string
+	Prefectures      []string // 勤務地の都道府県
+}
+
+// Matches は案件が検索条件に一致するかどうかを返します
+// groups は言語・フレームワークの名前の keyword.Key ごとのキーワードグループIDで、同じグループの別名（"Golang" と "Go" など）も一致とみなします。
+// n の別名ルールで同じ正規名になる名前（"JS" と "JavaScript" など）も一致とみなします。
+// 検索条件より前に保存した案件は一致しません。
+func (s SavedSearch) Matches(p Project, groups map[string]uint, n *keyword.Normalizer) bool {
+	if p.CreatedAt.Before(s.CreatedAt) {
+		return false
+	}
+	if len(s.Languages) > 0 {
+		hit := 0
+		for _, name := range s.Languages {
+			if containsKeyword(p.Languages, name, groups, n) {
+				hit++
+			}
+		}
+		if hit == 0 || (s.LanguageMatch == MatchAll && hit < len(s.Languages)) {
+			return false
+		}
+	}
+	if len(s.Frameworks) > 0 {
+		ok := false
+		for _, name := range s.Frameworks {
+			if containsKeyword(p.Frameworks, name, groups, n) {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			return false
+		}
+	}
+	if s.PriceMin != nil && (p.MonthlyPriceTo == nil || *p.MonthlyPriceTo < *s.PriceMin) {
+		return false
+	}
+	if s.PriceMax != nil && (p.MonthlyPriceFrom == nil || *p.MonthlyPriceFrom > *s.PriceMax) {
+		return false
+	}
+	if len(s.RemoteTypes) > 0 && !contains(s.RemoteTypes, p.RemoteType) {
+		return false
+	}
+	if len(s.Prefectures) > 0 && !overlaps(s.Prefectures, p.Prefectures) {
+		return false
+	}
+	return true
+}
+
+// containsKeyword は案件の言語・フレームワークに名前が一致するものがあるかどうかを返します
+func containsKeyword(keywords []Keyword, name string, groups map[string]uint, n *keyword.Normalizer) bool {
+	key := n.Key(name)
+	groupID := groups[keyword.Key(name)]
+	for _, kw := range keywords {
+		if n.Key(kw.Name) == key || (groupID != 0 && kw.GroupID == groupID) {
+			return true
+		}
+	}
+	return false
+}
+
+// compact は前後の空白を除き、空の値と重複を除いて返します
+func compact(values []string) []string {
+	result := []string{}
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if v != "" && !contains(result, v) {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+func contains(values []string, v string) bool {
+	for _, x := range values {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
+
+func overlaps(a, b []string) bool {
+	for _, x := range a {
+		if contains(b, x) {
+			return true
+		}
+	}
+	return false
+}
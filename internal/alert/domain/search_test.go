@@ -0,0 +1,156 @@
+package domain
+
+import (
+	"business/tools/keyword"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func TestSavedSearch_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		search  SavedSearch
+		wantErr bool
+	}{
+		{name: "言語のみ", search: SavedSearch{UserID: "a@example.com", Name: "Go", Languages: []string{"Go"}}},
+		{name: "都道府県のみ", search: SavedSearch{UserID: "a@example.com", Name: "東京", Prefectures: []string{"東京都"}}},
+		{name: "利用者なし", search: SavedSearch{Name: "Go", Languages: []string{"Go"}}, wantErr: true},
+		{name: "名前なし", search: SavedSearch{UserID: "a@example.com", Languages: []string{"Go"}}, wantErr: true},
+		{name: "条件なし", search: SavedSearch{UserID: "a@example.com", Name: "Go", Languages: []string{" ", ""}}, wantErr: true},
+		{name: "一致条件が不正", search: SavedSearch{UserID: "a@example.com", Name: "Go", Languages: []string{"Go"}, LanguageMatch: "some"}, wantErr: true},
+		{name: "単価が負", search: SavedSearch{UserID: "a@example.com", Name: "Go", PriceMin: intPtr(-1)}, wantErr: true},
+		{name: "単価の上下が逆", search: SavedSearch{UserID: "a@example.com", Name: "Go", PriceMin: intPtr(800000), PriceMax: intPtr(600000)}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.search.Validate()
+			if tt.wantErr {
+				assert.True(t, errors.Is(err, ErrInvalidSearch))
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestSavedSearch_Validate_Normalizes(t *testing.T) {
+	s, err := SavedSearch{UserID: " a@example.com ", Name: " Go案件 ", Languages: []string{" Go", "Go", ""}}.Validate()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "a@example.com", s.UserID)
+	assert.Equal(t, "Go案件", s.Name)
+	assert.Equal(t, []string{"Go"}, s.Languages)
+	assert.Equal(t, MatchAny, s.LanguageMatch)
+}
+
+func TestSavedSearch_Matches(t *testing.T) {
+	created := time.Date(2025, 6, 1, 0, 0, 0, 0, time.Local)
+	project := Project{
+		CreatedAt:        created.Add(time.Hour),
+		Languages:        []Keyword{{Name: "Golang", GroupID: 1}, {Name: "SQL", GroupID: 3}},
+		Frameworks:       []Keyword{{Name: "Gin", GroupID: 5}},
+		MonthlyPriceFrom: intPtr(600000),
+		MonthlyPriceTo:   intPtr(800000),
+		RemoteType:       "フルリモート",
+		Prefectures:      []string{"東京都"},
+	}
+	groups := map[string]uint{"go": 1, "python": 2, "sql": 3, "gin": 5, "echo": 6}
+
+	tests := []struct {
+		name   string
+		search SavedSearch
+		want   bool
+	}{
+		{name: "別名の言語に一致", search: SavedSearch{Languages: []string{"Go"}}, want: true},
+		{name: "全角の言語名", search: SavedSearch{Languages: []string{"ＳＱＬ"}}, want: true},
+		{name: "いずれかに一致", search: SavedSearch{Languages: []string{"Python", "Go"}, LanguageMatch: MatchAny}, want: true},
+		{name: "すべてに一致しない", search: SavedSearch{Languages: []string{"Python", "Go"}, LanguageMatch: MatchAll}, want: false},
+		{name: "すべてに一致", search: SavedSearch{Languages: []string{"SQL", "Go"}, LanguageMatch: MatchAll}, want: true},
+		{name: "フレームワークが不一致", search: SavedSearch{Frameworks: []string{"Echo"}}, want: false},
+		{name: "単価の下限を満たす", search: SavedSearch{PriceMin: intPtr(800000)}, want: true},
+		{name: "単価の下限を満たさない", search: SavedSearch{PriceMin: intPtr(800001)}, want: false},
+		{name: "単価の上限を満たす", search: SavedSearch{PriceMax: intPtr(600000)}, want: true},
+		{name: "単価の上限を満たさない", search: SavedSearch{PriceMax: intPtr(599999)}, want: false},
+		{name: "リモート区分が不一致", search: SavedSearch{RemoteTypes: []string{"リモート不可"}}, want: false},
+		{name: "都道府県に一致", search: SavedSearch{Prefectures: []string{"神奈川県", "東京都"}}, want: true},
+		{name: "都道府県が不一致", search: SavedSearch{Prefectures: []string{"大阪府"}}, want: false},
+		{name: "検索条件より前の案件", search: SavedSearch{Languages: []string{"Go"}, CreatedAt: created.Add(2 * time.Hour)}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.search.CreatedAt.IsZero() {
+				tt.search.CreatedAt = created
+			}
+			assert.Equal(t, tt.want, tt.search.Matches(project, groups, keyword.New(keyword.Rules{})))
+		})
+	}
+}
+
+func TestSavedSearch_Matches_AliasRules(t *testing.T) {
+	rules, err := keyword.ParseRules("JavaScript: JS\nGo: Golang")
+	if err != nil {
+		t.Fatal(err)
+	}
+	n := keyword.New(rules)
+	project := Project{Languages: []Keyword{{Name: "JavaScript"}, {Name: "Golang"}}}
+
+	assert.True(t, SavedSearch{Languages: []string{"JS"}}.Matches(project, nil, n))
+	assert.True(t, SavedSearch{Languages: []string{"go"}}.Matches(project, nil, n))
+	assert.False(t, SavedSearch{Languages: []string{"JS"}}.Matches(project, nil, keyword.New(keyword.Rules{})))
+}
+
+func TestSavedSearch_Matches_UnknownPrice(t *testing.T) {
+	s := SavedSearch{PriceMin: intPtr(500000)}
+
+	assert.False(t, s.Matches(Project{}, nil, keyword.New(keyword.Rules{})))
+}
+
+func TestNewNotification(t *testing.T) {
+	s := SavedSearch{ID: 3, UserID: "a@example.com", Name: "Go案件"}
+	p := Project{
+		ProjectID:      10,
+		GmailID:        "gmail-1",
+		ProjectKey:     "key-1",
+		Subject:        "【案件】Go開発",
+		SenderEmail:    "sales@example.com",
+		ReceivedDate:   time.Date(2025, 6, 2, 9, 30, 0, 0, time.Local),
+		Languages:      []Keyword{{Name: "Go"}},
+		Frameworks:     []Keyword{{Name: "Gin"}},
+		MonthlyPriceTo: intPtr(750000),
+		RemoteType:     "フルリモート",
+		Prefectures:    []string{"東京都"},
+	}
+
+	n := NewNotification(s, p)
+
+	assert.Equal(t, uint(3), n.SavedSearchID)
+	assert.Equal(t, "a@example.com", n.UserID)
+	assert.Equal(t, uint(10), n.EmailProjectID)
+	assert.Equal(t, "[Go案件] 【案件】Go開発", n.Title)
+	assert.Equal(t, StatusPending, n.Status)
+	assert.Contains(t, n.Message, "技術: Go, Gin")
+	assert.Contains(t, n.Message, "単価: 75万円")
+	assert.Contains(t, n.Message, "勤務地: 東京都")
+	assert.Contains(t, n.Message, "受信日: 2025-06-02 09:30")
+	assert.Equal(t, 255, len([]rune(NewNotification(s, Project{Subject: strings.Repeat("あ", 300)}).Title)))
+}
+
+func TestNotificationFilter_Normalize(t *testing.T) {
+	f, err := NotificationFilter{}.Normalize()
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultNotificationLimit, f.Limit)
+
+	_, err = NotificationFilter{Limit: MaxNotificationLimit + 1}.Normalize()
+	assert.True(t, errors.Is(err, ErrInvalidSearch))
+
+	_, err = NotificationFilter{Status: "unknown"}.Normalize()
+	assert.True(t, errors.Is(err, ErrInvalidSearch))
+}
@@ -0,0 +1,54 @@
+// Package infrastructure は保存した検索条件と新着案件の通知機能のインフラストラクチャ層を提供します。
+// このファイルは通知で使用するリポジトリのインターフェースを定義します。
+package infrastructure
+
+import (
+	"business/internal/alert/domain"
+	"time"
+)
+
+// RepositoryInterface は保存した検索条件と新着案件の通知のリポジトリインターフェースです
+type RepositoryInterface interface {
+	// ListSearches は利用者の検索条件をID順に返します（userID が空の場合はすべて）
+	ListSearches(userID string) ([]domain.SavedSearch, error)
+
+	// ListEnabledSearches は通知する検索条件をID順に返します
+	ListEnabledSearches() ([]domain.SavedSearch, error)
+
+	// FindSearch は検索条件を返します
+	FindSearch(id uint) (domain.SavedSearch, error)
+
+	// CreateSearch は検索条件を保存します
+	CreateSearch(s domain.SavedSearch) (domain.SavedSearch, error)
+
+	// UpdateSearch は検索条件を更新します
+	UpdateSearch(s domain.SavedSearch) (domain.SavedSearch, error)
+
+	// DeleteSearch は検索条件を通知ごと削除します
+	DeleteSearch(id uint) error
+
+	// ResolveKeywordGroups は言語・フレームワークの名前に対応するキーワードグループのIDを、keyword.Key をキーにして返します
+	ResolveKeywordGroups(names []string) (map[string]uint, error)
+
+	// LastEvaluatedProjectID は評価済みの案件IDの最大値を返します（未実行の場合は0）
+	LastEvaluatedProjectID() (uint, error)
+
+	// ListNewProjects は afterID より後のアーカイブしていない案件を、勤務地の都道府県付きでID順に limit 件まで返します
+	// ミュート・ブロックした営業会社（agencies.rule）の案件は返しません。
+	ListNewProjects(afterID uint, limit int) ([]domain.Project, error)
+
+	// SaveNotifications は通知を保存し、作成した件数を返します（同じ検索条件・案件の通知は作成しません）
+	SaveNotifications(notifications []domain.Notification) (int, error)
+
+	// SaveRun は評価の実行履歴を保存します
+	SaveRun(lastProjectID uint, evaluated, created int, startedAt, finishedAt time.Time) error
+
+	// ListPendingNotifications は未送信と、再送回数が上限に達していない送信失敗の通知を、案件の内容付きでID順に limit 件まで返します
+	ListPendingNotifications(limit int) ([]domain.Notification, error)
+
+	// SaveDelivery は通知の送信結果を保存します（sendErr が nil の場合は送信済み）
+	SaveDelivery(id uint, sendErr error, now time.Time) error
+
+	// ListNotifications は条件に一致する通知を新しい順に返します
+	ListNotifications(f domain.NotificationFilter) ([]domain.Notification, error)
+}
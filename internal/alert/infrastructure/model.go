@@ -0,0 +1,93 @@
+// Package infrastructure は保存した検索条件と新着案件の通知機能のインフラストラクチャ層を提供します。
+// このファイルは通知で参照・更新するテーブルのモデルを定義します。
+package infrastructure
+
+import (
+	"time"
+)
+
+// SavedSearch は利用者ごとに保存した検索条件を表すモデルです
+type SavedSearch struct {
+	ID            uint   `gorm:"primaryKey;autoIncrement"`
+	UserID        string `gorm:"size:100;not null;uniqueIndex:idx_saved_search_user_name"`
+	Name          string `gorm:"size:100;not null;uniqueIndex:idx_saved_search_user_name"`
+	Languages     string `gorm:"size:255;not null;default:''"`
+	LanguageMatch string `gorm:"size:10;not null;default:'any'"`
+	Frameworks    string `gorm:"size:255;not null;default:''"`
+	PriceMin      *int   `gorm:"type:int"`
+	PriceMax      *int   `gorm:"type:int"`
+	RemoteTypes   string `gorm:"size:255;not null;default:''"`
+	Prefectures   string `gorm:"size:255;not null;default:''"`
+	Enabled       bool   `gorm:"not null"`
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// Notification は検索条件に一致した新着案件の通知を表すモデルです
+type Notification struct {
+	ID             uint   `gorm:"primaryKey;autoIncrement"`
+	SavedSearchID  uint   `gorm:"not null;uniqueIndex:idx_notification_search_email_project,priority:1"`
+	UserID         string `gorm:"size:100;not null;index"`
+	EmailProjectID uint   `gorm:"not null;uniqueIndex:idx_notification_search_email_project,priority:2"`
+	GmailID        string `gorm:"size:255;not null"`
+	ProjectKey     string `gorm:"size:40;not null"`
+	Title          string `gorm:"size:255;not null;default:''"`
+	Message        string `gorm:"type:text"`
+	Status         string `gorm:"size:20;not null;default:'pending';index"`
+	Attempts       int    `gorm:"not null;default:0"`
+	LastError      string `gorm:"type:text"`
+	SentAt         *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// AlertRun は新着案件の通知の評価の実行履歴を表すモデルです
+type AlertRun struct {
+	ID            uint `gorm:"primaryKey;autoIncrement"`
+	LastProjectID uint `gorm:"not null"`
+	Evaluated     int  `gorm:"not null"`
+	Created       int  `gorm:"not null"`
+	StartedAt     time.Time
+	FinishedAt    time.Time
+}
+
+// projectRow は通知の判定の対象として参照する案件の列です
+type projectRow struct {
+	ProjectID        uint
+	GmailID          string
+	ProjectKey       string
+	Subject          string
+	ProjectTitle     *string
+	SenderEmail      string
+	ReceivedDate     time.Time
+	CreatedAt        time.Time
+	Languages        *string
+	Frameworks       *string
+	MonthlyPriceFrom *int
+	MonthlyPriceTo   *int
+	RemoteType       *string
+}
+
+// locationRow は案件の勤務地の都道府県の列です
+type locationRow struct {
+	EmailProjectID uint
+	Prefecture     string
+}
+
+// notificationRow は通知と検索条件の名前の列です
+type notificationRow struct {
+	Notification
+	SearchName string
+}
+
+func (SavedSearch) TableName() string {
+	return "saved_searches"
+}
+
+func (Notification) TableName() string {
+	return "notifications"
+}
+
+func (AlertRun) TableName() string {
+	return "alert_runs"
+}
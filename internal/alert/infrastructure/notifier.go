@@ -0,0 +1,83 @@
+// Package infrastructure は保存した検索条件と新着案件の通知機能のインフラストラクチャ層を提供します。
+// このファイルは通知を標準出力に書き出す送信先と、tools/notifier の送信先に送信する送信先を実装します。
+package infrastructure
+
+import (
+	"business/internal/alert/domain"
+	"business/tools/notifier"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// ConsoleNotifier は通知を標準出力に書き出す送信先です（送信先を設定していない場合の既定）
+type ConsoleNotifier struct {
+	w io.Writer
+}
+
+// NewConsoleNotifier は通知を標準出力に書き出す送信先を作成します
+func NewConsoleNotifier() *ConsoleNotifier {
+	return &ConsoleNotifier{
+		w: os.Stdout,
+	}
+}
+
+// Notify は通知を書き出します
+func (c *ConsoleNotifier) Notify(ctx context.Context, n domain.Notification) error {
+	_, err := fmt.Fprintf(c.w, "【通知】%s 宛: %s\n%s\n\n", n.UserID, n.Title, n.Message)
+	return err
+}
+
+// ChannelNotifier は通知を案件カードにして Slack・Teams・Discord・Webhook・メールなどの送信先に送信する送信先です
+type ChannelNotifier struct {
+	ch notifier.Channel
+}
+
+// NewChannelNotifier は通知を送信先に送信する送信先を作成します
+func NewChannelNotifier(ch notifier.Channel) *ChannelNotifier {
+	return &ChannelNotifier{
+		ch: ch,
+	}
+}
+
+// Notify は通知を送信します
+func (c *ChannelNotifier) Notify(ctx context.Context, n domain.Notification) error {
+	return c.ch.Send(ctx, toMessage(n))
+}
+
+// toMessage は通知を案件カード1件のメッセージにします
+// 案件が削除済みの場合は、保存した通知の本文をカードの補足に載せます。
+func toMessage(n domain.Notification) notifier.Message {
+	m := notifier.Message{
+		Title: n.Title,
+		Text:  fmt.Sprintf("%s さんの検索条件「%s」に一致する新着案件があります。", n.UserID, n.SearchName),
+	}
+	p := n.Project
+	if p == nil {
+		m.Cards = []notifier.Card{{Title: n.Title, URL: notifier.GmailURL(n.GmailID), Note: n.Message}}
+		return m
+	}
+
+	title := p.ProjectTitle
+	if title == "" {
+		title = p.Subject
+	}
+	var skills []string
+	for _, kw := range append(append([]domain.Keyword{}, p.Languages...), p.Frameworks...) {
+		skills = append(skills, kw.Name)
+	}
+	m.Cards = []notifier.Card{{
+		Title:      title,
+		URL:        notifier.GmailURL(p.GmailID),
+		PriceFrom:  p.MonthlyPriceFrom,
+		PriceTo:    p.MonthlyPriceTo,
+		Skills:     skills,
+		Location:   strings.Join(p.Prefectures, ", "),
+		Remote:     p.RemoteType,
+		Sender:     p.SenderEmail,
+		ReceivedAt: p.ReceivedDate,
+	}}
+	return m
+}
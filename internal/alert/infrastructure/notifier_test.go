@@ -0,0 +1,75 @@
+package infrastructure
+
+import (
+	"business/internal/alert/domain"
+	"business/tools/notifier"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// captureChannel は送信したメッセージを記録するテスト用の送信先です
+type captureChannel struct {
+	sent []notifier.Message
+}
+
+func (c *captureChannel) Name() string { return "capture" }
+
+func (c *captureChannel) Send(ctx context.Context, m notifier.Message) error {
+	c.sent = append(c.sent, m)
+	return nil
+}
+
+func TestChannelNotifier_Notify(t *testing.T) {
+	ch := &captureChannel{}
+	price := 750000
+	received := time.Date(2025, 6, 2, 9, 30, 0, 0, time.UTC)
+	n := domain.Notification{
+		UserID:     "a@example.com",
+		SearchName: "Go案件",
+		GmailID:    "gmail-1",
+		Title:      "[Go案件] Go開発",
+		Project: &domain.Project{
+			GmailID:        "gmail-1",
+			Subject:        "【案件】Go開発",
+			SenderEmail:    "sales@example.com",
+			ReceivedDate:   received,
+			Languages:      []domain.Keyword{{Name: "Go"}},
+			Frameworks:     []domain.Keyword{{Name: "Gin"}},
+			MonthlyPriceTo: &price,
+			RemoteType:     "フルリモート",
+			Prefectures:    []string{"東京都", "神奈川県"},
+		},
+	}
+
+	require.NoError(t, NewChannelNotifier(ch).Notify(context.Background(), n))
+
+	require.Len(t, ch.sent, 1)
+	m := ch.sent[0]
+	assert.Equal(t, "[Go案件] Go開発", m.Title)
+	assert.Contains(t, m.Text, "検索条件「Go案件」")
+	require.Len(t, m.Cards, 1)
+	assert.Equal(t, notifier.Card{
+		Title:      "【案件】Go開発",
+		URL:        notifier.GmailURL("gmail-1"),
+		PriceTo:    &price,
+		Skills:     []string{"Go", "Gin"},
+		Location:   "東京都, 神奈川県",
+		Remote:     "フルリモート",
+		Sender:     "sales@example.com",
+		ReceivedAt: received,
+	}, m.Cards[0])
+}
+
+func TestChannelNotifier_Notify_DeletedProject(t *testing.T) {
+	ch := &captureChannel{}
+	n := domain.Notification{GmailID: "gmail-1", Title: "[Go案件] Go開発", Message: "案件: Go開発"}
+
+	require.NoError(t, NewChannelNotifier(ch).Notify(context.Background(), n))
+
+	require.Len(t, ch.sent, 1)
+	assert.Equal(t, []notifier.Card{{Title: "[Go案件] Go開発", URL: notifier.GmailURL("gmail-1"), Note: "案件: Go開発"}}, ch.sent[0].Cards)
+}
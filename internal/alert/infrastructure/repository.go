@@ -0,0 +1,474 @@
+// Package infrastructure は保存した検索条件と新着案件の通知機能のインフラストラクチャ層を提供します。
+// このファイルは検索条件・通知の保存と、通知の判定の対象にする新着案件の参照を実装します。
+package infrastructure
+
+import (
+	"business/internal/alert/domain"
+	"business/tools/keyword"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// queryChunkSize は IN 句にまとめる値の件数です
+const queryChunkSize = 1000
+
+// insertBatchSize は CreateInBatches で1回のINSERTにまとめる行数です
+const insertBatchSize = 200
+
+// Repository は保存した検索条件と新着案件の通知のリポジトリ実装です
+type Repository struct {
+	db *gorm.DB
+}
+
+// New は保存した検索条件と新着案件の通知のリポジトリを作成します
+func New(db *gorm.DB) *Repository {
+	return &Repository{
+		db: db,
+	}
+}
+
+// ListSearches は利用者の検索条件をID順に返します（userID が空の場合はすべて）
+func (r *Repository) ListSearches(userID string) ([]domain.SavedSearch, error) {
+	query := r.db.Order("id")
+	if userID != "" {
+		query = query.Where("user_id = ?", userID)
+	}
+	return r.listSearches(query)
+}
+
+// ListEnabledSearches は通知する検索条件をID順に返します
+func (r *Repository) ListEnabledSearches() ([]domain.SavedSearch, error) {
+	return r.listSearches(r.db.Where("enabled = ?", true).Order("id"))
+}
+
+// FindSearch は検索条件を返します
+// 検索条件が無い場合は domain.ErrSearchNotFound を返します。
+func (r *Repository) FindSearch(id uint) (domain.SavedSearch, error) {
+	return findSearch(r.db, id)
+}
+
+// CreateSearch は検索条件を保存します
+// 同じ利用者に同じ名前の検索条件がある場合は domain.ErrConflict を返します。
+func (r *Repository) CreateSearch(s domain.SavedSearch) (domain.SavedSearch, error) {
+	var id uint
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := checkNameConflict(tx, s.UserID, s.Name, 0); err != nil {
+			return err
+		}
+		row := toModel(s)
+		if err := tx.Create(&row).Error; err != nil {
+			return fmt.Errorf("検索条件保存エラー: %w", err)
+		}
+		id = row.ID
+		return nil
+	})
+	if err != nil {
+		return domain.SavedSearch{}, err
+	}
+	return r.FindSearch(id)
+}
+
+// UpdateSearch は検索条件を更新します
+// 検索条件が無い場合は domain.ErrSearchNotFound、同じ利用者に同じ名前の別の検索条件がある場合は domain.ErrConflict を返します。
+func (r *Repository) UpdateSearch(s domain.SavedSearch) (domain.SavedSearch, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if _, err := findSearch(tx.Clauses(clause.Locking{Strength: "UPDATE"}), s.ID); err != nil {
+			return err
+		}
+		if err := checkNameConflict(tx, s.UserID, s.Name, s.ID); err != nil {
+			return err
+		}
+		row := toModel(s)
+		err := tx.Model(&SavedSearch{}).Where("id = ?", s.ID).
+			Select("user_id", "name", "languages", "language_match", "frameworks", "price_min", "price_max", "remote_types", "prefectures", "enabled").
+			Updates(&row).Error
+		if err != nil {
+			return fmt.Errorf("検索条件更新エラー: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return domain.SavedSearch{}, err
+	}
+	return r.FindSearch(s.ID)
+}
+
+// DeleteSearch は検索条件を通知ごと削除します
+// 検索条件が無い場合は domain.ErrSearchNotFound を返します。
+func (r *Repository) DeleteSearch(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("saved_search_id = ?", id).Delete(&Notification{}).Error; err != nil {
+			return fmt.Errorf("通知削除エラー: %w", err)
+		}
+		result := tx.Delete(&SavedSearch{}, id)
+		if result.Error != nil {
+			return fmt.Errorf("検索条件削除エラー: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("%w: #%d", domain.ErrSearchNotFound, id)
+		}
+		return nil
+	})
+}
+
+// ResolveKeywordGroups は言語・フレームワークの名前に対応するキーワードグループのIDを、keyword.Key をキーにして返します
+// グループの正規名、別名（KeyWord）の順に照合し、同じ名前のグループが複数ある場合はIDの小さいものを使います。
+func (r *Repository) ResolveKeywordGroups(names []string) (map[string]uint, error) {
+	groupIDs := make(map[string]uint, len(names))
+	names = lo.Uniq(lo.Compact(names))
+	for _, chunk := range lo.Chunk(names, queryChunkSize) {
+		var groups []struct {
+			KeywordGroupID uint
+			Name           string
+		}
+		err := r.db.Table("keyword_groups").
+			Select("keyword_group_id, name").
+			Where("name IN ?", chunk).
+			Order("keyword_group_id").
+			Scan(&groups).Error
+		if err != nil {
+			return nil, fmt.Errorf("キーワードグループ取得エラー: %w", err)
+		}
+		for _, g := range groups {
+			if _, ok := groupIDs[keyword.Key(g.Name)]; !ok {
+				groupIDs[keyword.Key(g.Name)] = g.KeywordGroupID
+			}
+		}
+
+		var aliases []struct {
+			KeywordGroupID uint
+			Word           string
+		}
+		err = r.db.Table("key_words AS w").
+			Select("l.keyword_group_id, w.word").
+			Joins("JOIN keyword_group_word_links l ON l.key_word_id = w.id").
+			Where("w.word IN ?", chunk).
+			Order("l.keyword_group_id").
+			Scan(&aliases).Error
+		if err != nil {
+			return nil, fmt.Errorf("キーワード取得エラー: %w", err)
+		}
+		for _, a := range aliases {
+			if _, ok := groupIDs[keyword.Key(a.Word)]; !ok {
+				groupIDs[keyword.Key(a.Word)] = a.KeywordGroupID
+			}
+		}
+	}
+	return groupIDs, nil
+}
+
+// LastEvaluatedProjectID は評価済みの案件IDの最大値を返します（未実行の場合は0）
+func (r *Repository) LastEvaluatedProjectID() (uint, error) {
+	var last *uint
+	if err := r.db.Model(&AlertRun{}).Select("MAX(last_project_id)").Scan(&last).Error; err != nil {
+		return 0, fmt.Errorf("実行履歴取得エラー: %w", err)
+	}
+	if last == nil {
+		return 0, nil
+	}
+	return *last, nil
+}
+
+// ListNewProjects は afterID より後のアーカイブしていない案件を、勤務地の都道府県付きでID順に limit 件まで返します
+// ミュート・ブロックした営業会社（agencies.rule）の案件は通知しないため返しません。
+func (r *Repository) ListNewProjects(afterID uint, limit int) ([]domain.Project, error) {
+	return r.listProjects(r.projectQuery().
+		Where("ep.id > ? AND ep.archived_at IS NULL", afterID).
+		Where(`NOT EXISTS (SELECT 1 FROM agencies ag
+			WHERE ag.domain = LOWER(SUBSTRING_INDEX(e.sender_email, '@', -1)) AND ag.rule IN ?)`, []string{"mute", "block"}).
+		Order("ep.id").
+		Limit(limit))
+}
+
+// SaveNotifications は通知を保存し、作成した件数を返します
+// 同じ検索条件・GメールID・案件キーの通知が既にある場合は作成しません（評価が重複して実行されても二重に通知しない）。
+func (r *Repository) SaveNotifications(notifications []domain.Notification) (int, error) {
+	if len(notifications) == 0 {
+		return 0, nil
+	}
+	rows := make([]Notification, 0, len(notifications))
+	for _, n := range notifications {
+		rows = append(rows, Notification{
+			SavedSearchID:  n.SavedSearchID,
+			UserID:         n.UserID,
+			EmailProjectID: n.EmailProjectID,
+			GmailID:        n.GmailID,
+			ProjectKey:     n.ProjectKey,
+			Title:          n.Title,
+			Message:        n.Message,
+			Status:         string(domain.StatusPending),
+		})
+	}
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(&rows, insertBatchSize)
+	if result.Error != nil {
+		return 0, fmt.Errorf("通知保存エラー: %w", result.Error)
+	}
+	return int(result.RowsAffected), nil
+}
+
+// SaveRun は評価の実行履歴を保存します
+func (r *Repository) SaveRun(lastProjectID uint, evaluated, created int, startedAt, finishedAt time.Time) error {
+	run := AlertRun{LastProjectID: lastProjectID, Evaluated: evaluated, Created: created, StartedAt: startedAt, FinishedAt: finishedAt}
+	if err := r.db.Create(&run).Error; err != nil {
+		return fmt.Errorf("実行履歴保存エラー: %w", err)
+	}
+	return nil
+}
+
+// ListPendingNotifications は未送信と、再送回数が上限に達していない送信失敗の通知を、案件の内容付きでID順に limit 件まで返します
+func (r *Repository) ListPendingNotifications(limit int) ([]domain.Notification, error) {
+	query := r.notificationQuery().
+		Where("n.status = ? OR (n.status = ? AND n.attempts < ?)", domain.StatusPending, domain.StatusFailed, domain.MaxAttempts).
+		Order("n.id").
+		Limit(limit)
+	notifications, err := scanNotifications(query)
+	if err != nil || len(notifications) == 0 {
+		return notifications, err
+	}
+
+	// 送信する案件カードに使う案件の内容を付ける
+	ids := make([]uint, 0, len(notifications))
+	for _, n := range notifications {
+		ids = append(ids, n.EmailProjectID)
+	}
+	projects, err := r.listProjects(r.projectQuery().Where("ep.id IN ?", lo.Uniq(ids)))
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]*domain.Project, len(projects))
+	for i := range projects {
+		byID[projects[i].ProjectID] = &projects[i]
+	}
+	for i := range notifications {
+		notifications[i].Project = byID[notifications[i].EmailProjectID]
+	}
+	return notifications, nil
+}
+
+// SaveDelivery は通知の送信結果を保存します（sendErr が nil の場合は送信済み）
+func (r *Repository) SaveDelivery(id uint, sendErr error, now time.Time) error {
+	updates := map[string]interface{}{
+		"status":     string(domain.StatusSent),
+		"attempts":   gorm.Expr("attempts + 1"),
+		"last_error": "",
+		"sent_at":    now,
+	}
+	if sendErr != nil {
+		updates["status"] = string(domain.StatusFailed)
+		updates["last_error"] = sendErr.Error()
+		updates["sent_at"] = nil
+	}
+	if err := r.db.Model(&Notification{}).Where("id = ?", id).Updates(updates).Error; err != nil {
+		return fmt.Errorf("送信結果保存エラー: %w", err)
+	}
+	return nil
+}
+
+// ListNotifications は条件に一致する通知を新しい順に返します
+func (r *Repository) ListNotifications(f domain.NotificationFilter) ([]domain.Notification, error) {
+	query := r.notificationQuery()
+	if f.UserID != "" {
+		query = query.Where("n.user_id = ?", f.UserID)
+	}
+	if f.Status != "" {
+		query = query.Where("n.status = ?", f.Status)
+	}
+	return scanNotifications(query.Order("n.id DESC").Limit(f.Limit))
+}
+
+// listSearches はクエリに一致する検索条件を返します
+func (r *Repository) listSearches(query *gorm.DB) ([]domain.SavedSearch, error) {
+	var rows []SavedSearch
+	if err := query.Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("検索条件取得エラー: %w", err)
+	}
+	searches := make([]domain.SavedSearch, 0, len(rows))
+	for _, row := range rows {
+		searches = append(searches, toDomain(row))
+	}
+	return searches, nil
+}
+
+// projectQuery は案件をメールの件名・差出人・受信日付きで選ぶクエリを返します
+func (r *Repository) projectQuery() *gorm.DB {
+	return r.db.Table("email_projects ep").
+		Select(`ep.id AS project_id, e.gmail_id, ep.project_key, e.subject, ep.project_title, e.sender_email, e.received_date,
+			ep.created_at, ep.languages, ep.frameworks, ep.monthly_price_from, ep.monthly_price_to, ep.remote_type`).
+		Joins("JOIN emails e ON e.id = ep.email_id")
+}
+
+// listProjects はクエリに一致する案件を、勤務地の都道府県付きで返します
+func (r *Repository) listProjects(query *gorm.DB) ([]domain.Project, error) {
+	var rows []projectRow
+	if err := query.Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("案件取得エラー: %w", err)
+	}
+
+	projects := make([]domain.Project, 0, len(rows))
+	index := make(map[uint]int, len(rows))
+	for _, row := range rows {
+		index[row.ProjectID] = len(projects)
+		projects = append(projects, domain.Project{
+			ProjectID:        row.ProjectID,
+			GmailID:          row.GmailID,
+			ProjectKey:       row.ProjectKey,
+			Subject:          row.Subject,
+			ProjectTitle:     derefString(row.ProjectTitle),
+			SenderEmail:      row.SenderEmail,
+			ReceivedDate:     row.ReceivedDate,
+			CreatedAt:        row.CreatedAt,
+			Languages:        keywords(row.Languages),
+			Frameworks:       keywords(row.Frameworks),
+			MonthlyPriceFrom: row.MonthlyPriceFrom,
+			MonthlyPriceTo:   row.MonthlyPriceTo,
+			RemoteType:       derefString(row.RemoteType),
+		})
+	}
+	if len(projects) == 0 {
+		return projects, nil
+	}
+
+	var locations []locationRow
+	err := r.db.Table("project_locations").
+		Select("email_project_id, prefecture").
+		Where("email_project_id IN ? AND prefecture <> ''", lo.Keys(index)).
+		Order("email_project_id, site_no").
+		Scan(&locations).Error
+	if err != nil {
+		return nil, fmt.Errorf("勤務地取得エラー: %w", err)
+	}
+	for _, l := range locations {
+		p := &projects[index[l.EmailProjectID]]
+		if !lo.Contains(p.Prefectures, l.Prefecture) {
+			p.Prefectures = append(p.Prefectures, l.Prefecture)
+		}
+	}
+	return projects, nil
+}
+
+// notificationQuery は通知を検索条件の名前付きで選ぶクエリを返します
+func (r *Repository) notificationQuery() *gorm.DB {
+	return r.db.Table("notifications n").
+		Select("n.*, COALESCE(s.name, '') AS search_name").
+		Joins("LEFT JOIN saved_searches s ON s.id = n.saved_search_id")
+}
+
+func scanNotifications(query *gorm.DB) ([]domain.Notification, error) {
+	var rows []notificationRow
+	if err := query.Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("通知取得エラー: %w", err)
+	}
+	notifications := make([]domain.Notification, 0, len(rows))
+	for _, row := range rows {
+		notifications = append(notifications, domain.Notification{
+			ID:             row.ID,
+			SavedSearchID:  row.SavedSearchID,
+			SearchName:     row.SearchName,
+			UserID:         row.UserID,
+			EmailProjectID: row.EmailProjectID,
+			GmailID:        row.GmailID,
+			ProjectKey:     row.ProjectKey,
+			Title:          row.Title,
+			Message:        row.Message,
+			Status:         domain.NotificationStatus(row.Status),
+			Attempts:       row.Attempts,
+			LastError:      row.LastError,
+			SentAt:         row.SentAt,
+			CreatedAt:      row.CreatedAt,
+		})
+	}
+	return notifications, nil
+}
+
+// findSearch はIDの検索条件を返します
+func findSearch(db *gorm.DB, id uint) (domain.SavedSearch, error) {
+	var row SavedSearch
+	err := db.Where("id = ?", id).First(&row).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domain.SavedSearch{}, fmt.Errorf("%w: #%d", domain.ErrSearchNotFound, id)
+	}
+	if err != nil {
+		return domain.SavedSearch{}, fmt.Errorf("検索条件取得エラー: %w", err)
+	}
+	return toDomain(row), nil
+}
+
+// checkNameConflict は exceptID 以外に同じ利用者・名前の検索条件があれば domain.ErrConflict を返します
+func checkNameConflict(tx *gorm.DB, userID, name string, exceptID uint) error {
+	var count int64
+	err := tx.Model(&SavedSearch{}).Where("user_id = ? AND name = ? AND id <> ?", userID, name, exceptID).Count(&count).Error
+	if err != nil {
+		return fmt.Errorf("検索条件取得エラー: %w", err)
+	}
+	if count > 0 {
+		return fmt.Errorf("%w: %s", domain.ErrConflict, name)
+	}
+	return nil
+}
+
+func toModel(s domain.SavedSearch) SavedSearch {
+	return SavedSearch{
+		ID:            s.ID,
+		UserID:        s.UserID,
+		Name:          s.Name,
+		Languages:     strings.Join(s.Languages, ","),
+		LanguageMatch: s.LanguageMatch,
+		Frameworks:    strings.Join(s.Frameworks, ","),
+		PriceMin:      s.PriceMin,
+		PriceMax:      s.PriceMax,
+		RemoteTypes:   strings.Join(s.RemoteTypes, ","),
+		Prefectures:   strings.Join(s.Prefectures, ","),
+		Enabled:       s.Enabled,
+	}
+}
+
+func toDomain(row SavedSearch) domain.SavedSearch {
+	return domain.SavedSearch{
+		ID:            row.ID,
+		UserID:        row.UserID,
+		Name:          row.Name,
+		Languages:     splitList(row.Languages),
+		LanguageMatch: row.LanguageMatch,
+		Frameworks:    splitList(row.Frameworks),
+		PriceMin:      row.PriceMin,
+		PriceMax:      row.PriceMax,
+		RemoteTypes:   splitList(row.RemoteTypes),
+		Prefectures:   splitList(row.Prefectures),
+		Enabled:       row.Enabled,
+		CreatedAt:     row.CreatedAt,
+		UpdatedAt:     row.UpdatedAt,
+	}
+}
+
+// splitList はカンマ区切りの値を空白と空文字を除いて返します
+func splitList(s string) []string {
+	values := []string{}
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// keywords はカンマ区切りの言語・フレームワークを返します
+func keywords(s *string) []domain.Keyword {
+	var result []domain.Keyword
+	for _, name := range splitList(derefString(s)) {
+		result = append(result, domain.Keyword{Name: name})
+	}
+	return result
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,212 @@
+package infrastructure
+
+import (
+	"business/internal/alert/domain"
+	"business/tools/migrations/model"
+	"business/tools/mysql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_Searches(t *testing.T) {
+	t.Parallel()
+	// テスト用DBの準備
+	db, cleanup, err := mysql.CreateNewTestDB()
+	require.NoError(t, err)
+	defer cleanup()
+
+	err = db.DB.AutoMigrate(
+		model.SavedSearch{},
+		model.Notification{},
+	)
+	require.NoError(t, err)
+
+	repo := New(db.DB)
+	priceMin := 600000
+
+	// リストをカンマ区切りで保存し、同じ形で返すこと
+	created, err := repo.CreateSearch(domain.SavedSearch{
+		UserID:        "a@example.com",
+		Name:          "Go案件",
+		Languages:     []string{"Go", "Python"},
+		LanguageMatch: domain.MatchAll,
+		Prefectures:   []string{"東京都"},
+		PriceMin:      &priceMin,
+		Enabled:       true,
+	})
+	require.NoError(t, err)
+	assert.NotZero(t, created.ID)
+	assert.Equal(t, []string{"Go", "Python"}, created.Languages)
+	assert.Equal(t, []string{}, created.Frameworks)
+	assert.Equal(t, 600000, *created.PriceMin)
+	assert.True(t, created.Enabled)
+
+	// 同じ利用者の同じ名前は競合し、別の利用者は登録できること
+	_, err = repo.CreateSearch(domain.SavedSearch{UserID: "a@example.com", Name: "Go案件", LanguageMatch: domain.MatchAny})
+	assert.ErrorIs(t, err, domain.ErrConflict)
+	other, err := repo.CreateSearch(domain.SavedSearch{UserID: "b@example.com", Name: "Go案件", LanguageMatch: domain.MatchAny, Enabled: true})
+	require.NoError(t, err)
+
+	// 更新で通知を止め、未設定の項目を消せること
+	created.Enabled = false
+	created.PriceMin = nil
+	created.Languages = nil
+	updated, err := repo.UpdateSearch(created)
+	require.NoError(t, err)
+	assert.False(t, updated.Enabled)
+	assert.Nil(t, updated.PriceMin)
+	assert.Empty(t, updated.Languages)
+
+	searches, err := repo.ListSearches("a@example.com")
+	require.NoError(t, err)
+	require.Len(t, searches, 1)
+	enabled, err := repo.ListEnabledSearches()
+	require.NoError(t, err)
+	require.Len(t, enabled, 1)
+	assert.Equal(t, other.ID, enabled[0].ID)
+
+	// 削除後は見つからないこと
+	require.NoError(t, repo.DeleteSearch(created.ID))
+	_, err = repo.FindSearch(created.ID)
+	assert.ErrorIs(t, err, domain.ErrSearchNotFound)
+	assert.ErrorIs(t, repo.DeleteSearch(created.ID), domain.ErrSearchNotFound)
+	_, err = repo.UpdateSearch(created)
+	assert.ErrorIs(t, err, domain.ErrSearchNotFound)
+}
+
+func TestRepository_ListNewProjects(t *testing.T) {
+	t.Parallel()
+	// テスト用DBの準備
+	db, cleanup, err := mysql.CreateNewTestDB()
+	require.NoError(t, err)
+	defer cleanup()
+
+	err = db.DB.AutoMigrate(
+		model.Email{},
+		model.EmailProject{},
+		model.ProjectLocation{},
+		model.AlertRun{},
+		model.Agency{},
+	)
+	require.NoError(t, err)
+
+	now := time.Now().Truncate(time.Second)
+	email := model.Email{GmailID: "gmail-1", Subject: "Go案件", SenderEmail: "a@agency.example.com", ReceivedDate: now, Category: "案件"}
+	require.NoError(t, db.DB.Create(&email).Error)
+	langs := "Go,SQL"
+	archivedAt := now
+	projects := []model.EmailProject{
+		{EmailID: email.ID, ProjectKey: "p1", Languages: &langs},
+		{EmailID: email.ID, ProjectKey: "p2", ArchivedAt: &archivedAt},
+		{EmailID: email.ID, ProjectKey: "p3"},
+	}
+	require.NoError(t, db.DB.Create(&projects).Error)
+	locations := []model.ProjectLocation{
+		{EmailProjectID: projects[0].ID, SiteNo: 0, Prefecture: "東京都"},
+		{EmailProjectID: projects[0].ID, SiteNo: 1, Prefecture: "東京都"},
+		{EmailProjectID: projects[0].ID, SiteNo: 2, Prefecture: "神奈川県"},
+	}
+	require.NoError(t, db.DB.Create(&locations).Error)
+
+	repo := New(db.DB)
+
+	// 未実行の場合は0から評価すること
+	last, err := repo.LastEvaluatedProjectID()
+	require.NoError(t, err)
+	assert.Zero(t, last)
+
+	// アーカイブした案件を除き、勤務地の都道府県を重複なく付けること
+	got, err := repo.ListNewProjects(0, 10)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, projects[0].ID, got[0].ProjectID)
+	assert.Equal(t, "gmail-1", got[0].GmailID)
+	assert.Equal(t, []domain.Keyword{{Name: "Go"}, {Name: "SQL"}}, got[0].Languages)
+	assert.Equal(t, []string{"東京都", "神奈川県"}, got[0].Prefectures)
+	assert.Equal(t, projects[2].ID, got[1].ProjectID)
+
+	// 評価済みの案件IDより後だけを返すこと
+	require.NoError(t, repo.SaveRun(projects[0].ID, 1, 0, now, now))
+	last, err = repo.LastEvaluatedProjectID()
+	require.NoError(t, err)
+	assert.Equal(t, projects[0].ID, last)
+	got, err = repo.ListNewProjects(last, 10)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, projects[2].ID, got[0].ProjectID)
+
+	// ミュートした営業会社の案件は返さないこと
+	require.NoError(t, db.DB.Create(&model.Agency{Domain: "agency.example.com", Rule: "mute"}).Error)
+	got, err = repo.ListNewProjects(0, 10)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+func TestRepository_Notifications(t *testing.T) {
+	t.Parallel()
+	// テスト用DBの準備
+	db, cleanup, err := mysql.CreateNewTestDB()
+	require.NoError(t, err)
+	defer cleanup()
+
+	err = db.DB.AutoMigrate(
+		model.SavedSearch{},
+		model.Notification{},
+	)
+	require.NoError(t, err)
+
+	repo := New(db.DB)
+	search, err := repo.CreateSearch(domain.SavedSearch{UserID: "a@example.com", Name: "Go案件", Languages: []string{"Go"}, LanguageMatch: domain.MatchAny, Enabled: true})
+	require.NoError(t, err)
+
+	// 同じ検索条件・案件の通知は1件だけ作成すること
+	n := domain.Notification{SavedSearchID: search.ID, UserID: "a@example.com", EmailProjectID: 1, GmailID: "gmail-1", ProjectKey: "p1", Title: "[Go案件] Go開発"}
+	created, err := repo.SaveNotifications([]domain.Notification{n})
+	require.NoError(t, err)
+	assert.Equal(t, 1, created)
+	n2 := n
+	n2.EmailProjectID, n2.ProjectKey = 2, "p2"
+	created, err = repo.SaveNotifications([]domain.Notification{n, n2})
+	require.NoError(t, err)
+	assert.Equal(t, 1, created)
+
+	// 送信に成功した通知は未送信から外れ、失敗した通知は再送回数の上限まで残ること
+	pending, err := repo.ListPendingNotifications(10)
+	require.NoError(t, err)
+	require.Len(t, pending, 2)
+	assert.Equal(t, "Go案件", pending[0].SearchName)
+	now := time.Now().Truncate(time.Second)
+	require.NoError(t, repo.SaveDelivery(pending[0].ID, nil, now))
+	for i := 0; i < domain.MaxAttempts; i++ {
+		require.NoError(t, repo.SaveDelivery(pending[1].ID, errors.New("webhook error"), now))
+		remaining, err := repo.ListPendingNotifications(10)
+		require.NoError(t, err)
+		if i < domain.MaxAttempts-1 {
+			assert.Len(t, remaining, 1)
+		} else {
+			assert.Empty(t, remaining)
+		}
+	}
+
+	// 送信状況で絞り込み、新しい順に返すこと
+	failed, err := repo.ListNotifications(domain.NotificationFilter{UserID: "a@example.com", Status: domain.StatusFailed, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, failed, 1)
+	assert.Equal(t, domain.MaxAttempts, failed[0].Attempts)
+	assert.Equal(t, "webhook error", failed[0].LastError)
+	all, err := repo.ListNotifications(domain.NotificationFilter{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+	assert.Greater(t, all[0].ID, all[1].ID)
+	assert.NotNil(t, all[1].SentAt)
+
+	// 検索条件を削除すると通知も削除すること
+	require.NoError(t, repo.DeleteSearch(search.ID))
+	all, err = repo.ListNotifications(domain.NotificationFilter{Limit: 10})
+	require.NoError(t, err)
+	assert.Empty(t, all)
+}
@@ -0,0 +1,29 @@
+// Package application は案件の市場動向の集計機能のアプリケーション層を提供します。
+// このファイルは市場動向の集計のユースケースインターフェースを定義します。
+package application
+
+import (
+	"business/internal/analytics/domain"
+	"time"
+)
+
+// UseCaseInterface は案件の市場動向の集計のユースケースインターフェースです
+type UseCaseInterface interface {
+	// Refresh は前回の更新以降に保存した案件の受信日の週の集計を作り直します（full の場合はすべての週）
+	Refresh(full bool) (domain.RefreshResult, error)
+
+	// AfterSave はメール解析結果の保存後に集計を更新します
+	AfterSave() error
+
+	// SkillTrends はスキルごとの週の案件数の推移を返します
+	SkillTrends(f domain.Filter, now time.Time) ([]domain.SkillSeries, error)
+
+	// PriceTrends は全体とスキルごとの週の単価の分布の推移を返します
+	PriceTrends(f domain.Filter, now time.Time) ([]domain.PriceSeries, error)
+
+	// RemoteTrends は週のリモート区分の内訳と比率の推移を返します
+	RemoteTrends(f domain.Filter, now time.Time) ([]domain.RemotePoint, error)
+
+	// RisingSkills は直近の期間に案件数が伸びているスキルを返します
+	RisingSkills(f domain.Filter, now time.Time) ([]domain.RisingSkill, error)
+}
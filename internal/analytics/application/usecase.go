@@ -0,0 +1,168 @@
+// Package application は案件の市場動向の集計機能のアプリケーション層を提供します。
+// このファイルは週ごとの集計の更新と、スキルの需要・単価・リモート比率の推移の参照のユースケースを実装します。
+package application
+
+import (
+	"business/internal/analytics/domain"
+	r "business/internal/analytics/infrastructure"
+	"fmt"
+	"time"
+
+	"github.com/samber/lo"
+)
+
+// UseCase は案件の市場動向の集計のユースケースの具象です
+type UseCase struct {
+	r r.RepositoryInterface
+}
+
+// New は案件の市場動向の集計のユースケースを作成します
+func New(r r.RepositoryInterface) *UseCase {
+	return &UseCase{
+		r: r,
+	}
+}
+
+// Refresh は前回の更新以降に保存した案件の受信日の週の集計を作り直します
+// 週の集計はその週のすべての案件から作り直すため、過去の日付の案件が後から取り込まれても正しい値になります。
+// 週は domain.RefreshWeekBatch 週ずつ作り直し、集計済みの案件IDを実行履歴に記録します。
+// full の場合はすべての集計を削除して、保存されているすべての案件から作り直します（辞書の見直し後など）。
+func (u *UseCase) Refresh(full bool) (domain.RefreshResult, error) {
+	startedAt := time.Now()
+	result := domain.RefreshResult{Full: full}
+
+	maxID, err := u.r.MaxProjectID()
+	if err != nil {
+		return result, err
+	}
+	var last uint
+	if !full {
+		if last, err = u.r.LastRefreshedProjectID(); err != nil {
+			return result, err
+		}
+		if maxID <= last {
+			result.LastProjectID = last
+			return result, nil
+		}
+	}
+
+	weeks, err := u.r.ListProjectWeeks(last, maxID)
+	if err != nil {
+		return result, err
+	}
+	if full {
+		if err := u.r.DeleteAll(); err != nil {
+			return result, err
+		}
+	}
+	for _, batch := range lo.Chunk(weeks, domain.RefreshWeekBatch) {
+		projects, err := u.r.ListProjects(batch[0], batch[len(batch)-1].AddDate(0, 0, 7))
+		if err != nil {
+			return result, err
+		}
+		// 作り直す週の間の週の案件は集計しない（差分の週が連続しているとは限らない）
+		target := lo.SliceToMap(batch, func(w time.Time) (int64, struct{}) { return w.Unix(), struct{}{} })
+		projects = lo.Filter(projects, func(p domain.Project, _ int) bool {
+			_, ok := target[domain.WeekStart(p.ReceivedDate).Unix()]
+			return ok
+		})
+		totals, skills := domain.Aggregate(projects)
+		if err := u.r.ReplaceWeeks(batch, totals, skills); err != nil {
+			return result, err
+		}
+		result.Weeks += len(batch)
+		result.Projects += len(projects)
+	}
+
+	result.LastProjectID = maxID
+	if err := u.r.SaveRun(result, startedAt, time.Now()); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+// AfterSave はメール解析結果の保存後に集計を更新します
+func (u *UseCase) AfterSave() error {
+	result, err := u.Refresh(false)
+	if err != nil {
+		return fmt.Errorf("市場動向の集計エラー: %w", err)
+	}
+	if result.Weeks > 0 {
+		fmt.Printf("市場動向の集計: %d週 / 案件 %d件\n", result.Weeks, result.Projects)
+	}
+	return nil
+}
+
+// SkillTrends はスキルごとの週の案件数の推移を返します
+func (u *UseCase) SkillTrends(f domain.Filter, now time.Time) ([]domain.SkillSeries, error) {
+	f, err := f.Normalize(now)
+	if err != nil {
+		return nil, err
+	}
+	from, to := f.Range()
+	totals, rows, err := u.listRange(f.Kind, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return domain.SkillTrends(domain.WeekStarts(from, to), totals, rows, f.Kind, f.Names, f.Limit), nil
+}
+
+// PriceTrends は全体とスキルごとの週の単価の分布の推移を返します
+func (u *UseCase) PriceTrends(f domain.Filter, now time.Time) ([]domain.PriceSeries, error) {
+	f, err := f.Normalize(now)
+	if err != nil {
+		return nil, err
+	}
+	from, to := f.Range()
+	totals, rows, err := u.listRange(f.Kind, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return domain.PriceTrends(domain.WeekStarts(from, to), totals, rows, f.Kind, f.Names, f.Limit), nil
+}
+
+// RemoteTrends は週のリモート区分の内訳と比率の推移を返します
+func (u *UseCase) RemoteTrends(f domain.Filter, now time.Time) ([]domain.RemotePoint, error) {
+	f, err := f.Normalize(now)
+	if err != nil {
+		return nil, err
+	}
+	from, to := f.Range()
+	totals, err := u.r.ListWeeks(from, to)
+	if err != nil {
+		return nil, err
+	}
+	return domain.RemoteTrends(domain.WeekStarts(from, to), totals), nil
+}
+
+// RisingSkills は直近の期間に案件数が伸びているスキルを返します
+// 直近 f.Window 週とその前の f.Window 週の案件数を比べます。
+func (u *UseCase) RisingSkills(f domain.Filter, now time.Time) ([]domain.RisingSkill, error) {
+	f, err := f.Normalize(now)
+	if err != nil {
+		return nil, err
+	}
+	recentFrom, recentTo, previousFrom := f.RisingRanges()
+	recent, err := u.r.ListSkillWeeks(f.Kind, recentFrom, recentTo)
+	if err != nil {
+		return nil, err
+	}
+	previous, err := u.r.ListSkillWeeks(f.Kind, previousFrom, recentFrom)
+	if err != nil {
+		return nil, err
+	}
+	return domain.Rising(recent, previous, f.MinCount, f.Limit), nil
+}
+
+// listRange は期間の週の集計と種類のスキルの集計を返します
+func (u *UseCase) listRange(kind string, from, to time.Time) ([]domain.Week, []domain.SkillWeek, error) {
+	totals, err := u.r.ListWeeks(from, to)
+	if err != nil {
+		return nil, nil, err
+	}
+	rows, err := u.r.ListSkillWeeks(kind, from, to)
+	if err != nil {
+		return nil, nil, err
+	}
+	return totals, rows, nil
+}
@@ -0,0 +1,174 @@
+package application
+
+import (
+	"business/internal/analytics/domain"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockRepository は案件の市場動向の集計のリポジトリのモックです
+type MockRepository struct {
+	mock.Mock
+}
+
+func (m *MockRepository) LastRefreshedProjectID() (uint, error) {
+	args := m.Called()
+	return args.Get(0).(uint), args.Error(1)
+}
+
+func (m *MockRepository) MaxProjectID() (uint, error) {
+	args := m.Called()
+	return args.Get(0).(uint), args.Error(1)
+}
+
+func (m *MockRepository) ListProjectWeeks(afterID, upToID uint) ([]time.Time, error) {
+	args := m.Called(afterID, upToID)
+	return args.Get(0).([]time.Time), args.Error(1)
+}
+
+func (m *MockRepository) ListProjects(from, to time.Time) ([]domain.Project, error) {
+	args := m.Called(from, to)
+	return args.Get(0).([]domain.Project), args.Error(1)
+}
+
+func (m *MockRepository) ReplaceWeeks(weeks []time.Time, totals []domain.Week, skills []domain.SkillWeek) error {
+	args := m.Called(weeks, totals, skills)
+	return args.Error(0)
+}
+
+func (m *MockRepository) DeleteAll() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+func (m *MockRepository) SaveRun(result domain.RefreshResult, startedAt, finishedAt time.Time) error {
+	args := m.Called(result, startedAt, finishedAt)
+	return args.Error(0)
+}
+
+func (m *MockRepository) ListWeeks(from, to time.Time) ([]domain.Week, error) {
+	args := m.Called(from, to)
+	return args.Get(0).([]domain.Week), args.Error(1)
+}
+
+func (m *MockRepository) ListSkillWeeks(kind string, from, to time.Time) ([]domain.SkillWeek, error) {
+	args := m.Called(kind, from, to)
+	return args.Get(0).([]domain.SkillWeek), args.Error(1)
+}
+
+func TestUseCase_Refresh(t *testing.T) {
+	w1 := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+	w3 := w1.AddDate(0, 0, 14)
+
+	t.Run("差分の週だけを作り直すこと", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("MaxProjectID").Return(uint(30), nil)
+		repo.On("LastRefreshedProjectID").Return(uint(20), nil)
+		repo.On("ListProjectWeeks", uint(20), uint(30)).Return([]time.Time{w1, w3}, nil)
+		// 間の週（w2）の案件は集計しない
+		repo.On("ListProjects", w1, w3.AddDate(0, 0, 7)).Return([]domain.Project{
+			{ID: 5, ReceivedDate: w1.Add(time.Hour), Skills: []domain.SkillRef{{Kind: domain.KindLanguage, Name: "Go"}}},
+			{ID: 12, ReceivedDate: w1.AddDate(0, 0, 8)},
+			{ID: 25, ReceivedDate: w3.Add(time.Hour)},
+		}, nil)
+		repo.On("ReplaceWeeks", []time.Time{w1, w3}, mock.MatchedBy(func(totals []domain.Week) bool {
+			return len(totals) == 2 && totals[0].WeekStart.Equal(w1) && totals[1].WeekStart.Equal(w3)
+		}), mock.MatchedBy(func(skills []domain.SkillWeek) bool {
+			return len(skills) == 1 && skills[0].Name == "Go"
+		})).Return(nil)
+		repo.On("SaveRun", domain.RefreshResult{Weeks: 2, Projects: 2, LastProjectID: 30}, mock.Anything, mock.Anything).Return(nil)
+
+		result, err := New(repo).Refresh(false)
+		require.NoError(t, err)
+		assert.Equal(t, domain.RefreshResult{Weeks: 2, Projects: 2, LastProjectID: 30}, result)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("新しい案件が無い場合は何もしないこと", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("MaxProjectID").Return(uint(30), nil)
+		repo.On("LastRefreshedProjectID").Return(uint(30), nil)
+
+		result, err := New(repo).Refresh(false)
+		require.NoError(t, err)
+		assert.Equal(t, domain.RefreshResult{LastProjectID: 30}, result)
+		repo.AssertNotCalled(t, "ListProjectWeeks", mock.Anything, mock.Anything)
+		repo.AssertNotCalled(t, "SaveRun", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("full の場合はすべての集計を作り直すこと", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("MaxProjectID").Return(uint(30), nil)
+		repo.On("ListProjectWeeks", uint(0), uint(30)).Return([]time.Time{w1}, nil)
+		repo.On("DeleteAll").Return(nil)
+		repo.On("ListProjects", w1, w1.AddDate(0, 0, 7)).Return([]domain.Project{{ID: 1, ReceivedDate: w1}}, nil)
+		repo.On("ReplaceWeeks", []time.Time{w1}, mock.Anything, mock.Anything).Return(nil)
+		repo.On("SaveRun", domain.RefreshResult{Full: true, Weeks: 1, Projects: 1, LastProjectID: 30}, mock.Anything, mock.Anything).Return(nil)
+
+		result, err := New(repo).Refresh(true)
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Weeks)
+		repo.AssertExpectations(t)
+		repo.AssertNotCalled(t, "LastRefreshedProjectID")
+	})
+
+	t.Run("保存に失敗した場合は実行履歴を記録しないこと", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("MaxProjectID").Return(uint(30), nil)
+		repo.On("LastRefreshedProjectID").Return(uint(20), nil)
+		repo.On("ListProjectWeeks", uint(20), uint(30)).Return([]time.Time{w1}, nil)
+		repo.On("ListProjects", w1, w1.AddDate(0, 0, 7)).Return([]domain.Project{}, nil)
+		repo.On("ReplaceWeeks", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("db error"))
+
+		err := New(repo).AfterSave()
+		assert.ErrorContains(t, err, "db error")
+		repo.AssertNotCalled(t, "SaveRun", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestUseCase_Trends(t *testing.T) {
+	now := time.Date(2025, 6, 11, 9, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 6, 16, 0, 0, 0, 0, time.UTC)
+	from := to.AddDate(0, 0, -14)
+
+	repo := new(MockRepository)
+	repo.On("ListWeeks", from, to).Return([]domain.Week{
+		{WeekStart: from, Projects: 4, RemoteFull: 1, RemoteNone: 1, RemoteUnknown: 2},
+	}, nil)
+	repo.On("ListSkillWeeks", domain.KindFramework, from, to).Return([]domain.SkillWeek{
+		{WeekStart: from, Kind: domain.KindFramework, Name: "Gin", Projects: 2},
+	}, nil)
+	u := New(repo)
+	f := domain.Filter{Kind: domain.KindFramework, Weeks: 2}
+
+	skills, err := u.SkillTrends(f, now)
+	require.NoError(t, err)
+	require.Len(t, skills, 1)
+	assert.Equal(t, "Gin", skills[0].Name)
+	assert.Equal(t, 0.5, skills[0].Points[0].Share)
+
+	prices, err := u.PriceTrends(f, now)
+	require.NoError(t, err)
+	require.Len(t, prices, 2)
+	assert.Equal(t, domain.OverallName, prices[0].Name)
+
+	remote, err := u.RemoteTrends(f, now)
+	require.NoError(t, err)
+	require.Len(t, remote, 2)
+	assert.Equal(t, 0.5, remote[0].RemoteRatio)
+
+	// 直近2週とその前の2週を比べること
+	repo.On("ListSkillWeeks", domain.KindFramework, from.AddDate(0, 0, -14), from).Return([]domain.SkillWeek{}, nil)
+	rising, err := u.RisingSkills(domain.Filter{Kind: domain.KindFramework, Window: 2, MinCount: 1}, now)
+	require.NoError(t, err)
+	require.Len(t, rising, 1)
+	assert.Equal(t, "Gin", rising[0].Name)
+
+	_, err = u.SkillTrends(domain.Filter{Kind: "tool"}, now)
+	assert.ErrorIs(t, err, domain.ErrInvalidFilter)
+}
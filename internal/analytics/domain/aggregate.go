@@ -0,0 +1,248 @@
+// Package domain は案件の市場動向（スキルの需要・単価・リモート比率の推移）の集計機能のドメインモデルを提供します。
+// このファイルは案件を受信日の週ごと・スキルごとに集計する処理を定義します。
+package domain
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	KindLanguage  = "language"  // 言語のキーワードグループ
+	KindFramework = "framework" // フレームワークのキーワードグループ
+	KindPosition  = "position"  // ポジショングループ
+
+	RefreshWeekBatch = 8 // 集計を更新する際に1回で作り直す週の数
+)
+
+// IsValidKind はスキルの種類が有効かを返します
+func IsValidKind(kind string) bool {
+	switch kind {
+	case KindLanguage, KindFramework, KindPosition:
+		return true
+	}
+	return false
+}
+
+// WeekStart は日時を含む週の開始日（月曜日の0時）を返します
+func WeekStart(t time.Time) time.Time {
+	y, m, d := t.Date()
+	day := time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+	return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+}
+
+// SkillRef は案件に紐づくスキル（キーワードグループ・ポジショングループ）です
+type SkillRef struct {
+	Kind string
+	Name string
+}
+
+// Project は集計する案件です
+type Project struct {
+	ID               uint
+	ReceivedDate     time.Time
+	MonthlyPriceFrom *int
+	MonthlyPriceTo   *int
+	RemoteType       string
+	FullRemote       bool // 勤務地にフルリモートの記載がある
+	Skills           []SkillRef
+}
+
+// Remote はリモート区分の種類です
+type Remote string
+
+const (
+	RemoteFull    Remote = "full"    // フルリモート
+	RemotePartial Remote = "partial" // 一部リモート・リモート併用
+	RemoteNone    Remote = "none"    // リモート不可（常駐）
+	RemoteUnknown Remote = "unknown" // 記載なし
+)
+
+// ClassifyRemote は案件のリモート区分を種類に分けます（マッチングの採点と同じ分類）
+func ClassifyRemote(remoteType string, fullRemote bool) Remote {
+	switch {
+	case strings.Contains(remoteType, "フルリモート"), fullRemote:
+		return RemoteFull
+	case strings.Contains(remoteType, "不可"):
+		return RemoteNone
+	case strings.Contains(remoteType, "可"), strings.Contains(remoteType, "併用"), strings.Contains(remoteType, "一部"):
+		return RemotePartial
+	}
+	return RemoteUnknown
+}
+
+// MonthlyPrice は案件の代表の単価（税別の月額）を返します
+// 下限と上限がある場合は中間、片方のみの場合はその値です。記載が無い場合は nil を返します。
+func MonthlyPrice(from, to *int) *int {
+	valid := func(v *int) bool { return v != nil && *v > 0 }
+	switch {
+	case valid(from) && valid(to):
+		v := (*from + *to) / 2
+		return &v
+	case valid(from):
+		return from
+	case valid(to):
+		return to
+	}
+	return nil
+}
+
+// PriceStats は単価の分布です（単価の記載がある案件が無い場合は各値が nil）
+type PriceStats struct {
+	Count  int  `json:"priced_projects"` // 単価の記載がある案件数
+	P25    *int `json:"p25"`
+	Median *int `json:"median"`
+	P75    *int `json:"p75"`
+	P90    *int `json:"p90"`
+}
+
+// NewPriceStats は単価の一覧から分布を計算します
+func NewPriceStats(prices []int) PriceStats {
+	s := PriceStats{Count: len(prices)}
+	if len(prices) == 0 {
+		return s
+	}
+	sorted := append([]int{}, prices...)
+	sort.Ints(sorted)
+	s.P25 = percentile(sorted, 0.25)
+	s.Median = percentile(sorted, 0.5)
+	s.P75 = percentile(sorted, 0.75)
+	s.P90 = percentile(sorted, 0.9)
+	return s
+}
+
+// percentile は昇順の値の p 分位点を線形補間で求め、円単位に丸めて返します
+func percentile(sorted []int, p float64) *int {
+	pos := p * float64(len(sorted)-1)
+	lower := int(math.Floor(pos))
+	upper := int(math.Ceil(pos))
+	v := float64(sorted[lower]) + (float64(sorted[upper])-float64(sorted[lower]))*(pos-float64(lower))
+	rounded := int(math.Round(v))
+	return &rounded
+}
+
+// Week は週ごとの案件数・単価・リモート区分の集計です
+type Week struct {
+	WeekStart     time.Time
+	Projects      int
+	Price         PriceStats
+	RemoteFull    int
+	RemotePartial int
+	RemoteNone    int
+	RemoteUnknown int
+}
+
+// SkillWeek は週ごと・スキルごとの案件数・単価・リモート区分の集計です
+type SkillWeek struct {
+	WeekStart     time.Time
+	Kind          string
+	Name          string
+	Projects      int
+	Price         PriceStats
+	RemoteFull    int
+	RemotePartial int
+}
+
+// Aggregate は案件を受信日の週ごと・スキルごとに集計し、週・種類・名前の順に返します
+// 同じ案件に同じスキルが重複して紐づく場合は1件と数えます。
+func Aggregate(projects []Project) ([]Week, []SkillWeek) {
+	type bucket struct {
+		week                                   time.Time
+		projects, full, partial, none, unknown int
+		prices                                 []int
+	}
+	// 週は開始日時の UNIX 秒で区別する（タイムゾーンの表現の違いで別の週にしない）
+	type skillKey struct {
+		week       int64
+		kind, name string
+	}
+	weeks := map[int64]*bucket{}
+	skills := map[skillKey]*bucket{}
+	add := func(b *bucket, price *int, remote Remote) {
+		b.projects++
+		if price != nil {
+			b.prices = append(b.prices, *price)
+		}
+		switch remote {
+		case RemoteFull:
+			b.full++
+		case RemotePartial:
+			b.partial++
+		case RemoteNone:
+			b.none++
+		default:
+			b.unknown++
+		}
+	}
+
+	for _, p := range projects {
+		week := WeekStart(p.ReceivedDate)
+		price := MonthlyPrice(p.MonthlyPriceFrom, p.MonthlyPriceTo)
+		remote := ClassifyRemote(p.RemoteType, p.FullRemote)
+		if weeks[week.Unix()] == nil {
+			weeks[week.Unix()] = &bucket{week: week}
+		}
+		add(weeks[week.Unix()], price, remote)
+
+		seen := map[SkillRef]struct{}{}
+		for _, s := range p.Skills {
+			if _, ok := seen[s]; ok || s.Name == "" {
+				continue
+			}
+			seen[s] = struct{}{}
+			key := skillKey{week: week.Unix(), kind: s.Kind, name: s.Name}
+			if skills[key] == nil {
+				skills[key] = &bucket{week: week}
+			}
+			add(skills[key], price, remote)
+		}
+	}
+
+	resultWeeks := make([]Week, 0, len(weeks))
+	for _, b := range weeks {
+		resultWeeks = append(resultWeeks, Week{
+			WeekStart:     b.week,
+			Projects:      b.projects,
+			Price:         NewPriceStats(b.prices),
+			RemoteFull:    b.full,
+			RemotePartial: b.partial,
+			RemoteNone:    b.none,
+			RemoteUnknown: b.unknown,
+		})
+	}
+	sort.Slice(resultWeeks, func(i, j int) bool { return resultWeeks[i].WeekStart.Before(resultWeeks[j].WeekStart) })
+
+	resultSkills := make([]SkillWeek, 0, len(skills))
+	for key, b := range skills {
+		resultSkills = append(resultSkills, SkillWeek{
+			WeekStart:     b.week,
+			Kind:          key.kind,
+			Name:          key.name,
+			Projects:      b.projects,
+			Price:         NewPriceStats(b.prices),
+			RemoteFull:    b.full,
+			RemotePartial: b.partial,
+		})
+	}
+	sort.Slice(resultSkills, func(i, j int) bool {
+		a, b := resultSkills[i], resultSkills[j]
+		if !a.WeekStart.Equal(b.WeekStart) {
+			return a.WeekStart.Before(b.WeekStart)
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.Name < b.Name
+	})
+	return resultWeeks, resultSkills
+}
+
+// RefreshResult は集計の更新結果です
+type RefreshResult struct {
+	Full          bool `json:"full"`            // すべての週を作り直した
+	Weeks         int  `json:"weeks"`           // 更新した週の数
+	Projects      int  `json:"projects"`        // 集計した案件数
+	LastProjectID uint `json:"last_project_id"` // 集計済みの案件IDの最大値
+}
@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWeekStart(t *testing.T) {
+	// 2025-06-04 は水曜日、2025-06-08 は日曜日
+	assert.Equal(t, time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC), WeekStart(time.Date(2025, 6, 4, 15, 0, 0, 0, time.UTC)))
+	assert.Equal(t, time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC), WeekStart(time.Date(2025, 6, 8, 23, 59, 0, 0, time.UTC)))
+	assert.Equal(t, time.Date(2025, 6, 9, 0, 0, 0, 0, time.UTC), WeekStart(time.Date(2025, 6, 9, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestClassifyRemote(t *testing.T) {
+	assert.Equal(t, RemoteFull, ClassifyRemote("フルリモート", false))
+	assert.Equal(t, RemoteFull, ClassifyRemote("", true))
+	assert.Equal(t, RemotePartial, ClassifyRemote("リモート併用", false))
+	assert.Equal(t, RemoteNone, ClassifyRemote("リモート不可", false))
+	assert.Equal(t, RemoteUnknown, ClassifyRemote("", false))
+}
+
+func TestNewPriceStats(t *testing.T) {
+	s := NewPriceStats([]int{800000, 600000, 700000, 900000, 500000})
+	assert.Equal(t, 5, s.Count)
+	assert.Equal(t, 600000, *s.P25)
+	assert.Equal(t, 700000, *s.Median)
+	assert.Equal(t, 800000, *s.P75)
+	assert.Equal(t, 860000, *s.P90)
+
+	// 偶数件の中央値は中間、1件はすべて同じ値
+	s = NewPriceStats([]int{600000, 700000})
+	assert.Equal(t, 650000, *s.Median)
+	s = NewPriceStats([]int{550000})
+	assert.Equal(t, 550000, *s.P25)
+	assert.Equal(t, 550000, *s.P90)
+
+	assert.Nil(t, NewPriceStats(nil).Median)
+}
+
+func TestMonthlyPrice(t *testing.T) {
+	price := func(v int) *int { return &v }
+	assert.Equal(t, 650000, *MonthlyPrice(price(600000), price(700000)))
+	assert.Equal(t, 600000, *MonthlyPrice(price(600000), nil))
+	assert.Equal(t, 700000, *MonthlyPrice(price(0), price(700000)))
+	assert.Nil(t, MonthlyPrice(nil, nil))
+}
+
+func TestAggregate(t *testing.T) {
+	price := func(v int) *int { return &v }
+	mon := time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC)
+	projects := []Project{
+		{ID: 1, ReceivedDate: mon, MonthlyPriceTo: price(800000), RemoteType: "フルリモート",
+			Skills: []SkillRef{{Kind: KindLanguage, Name: "Go"}, {Kind: KindLanguage, Name: "Go"}, {Kind: KindPosition, Name: "SE"}}},
+		{ID: 2, ReceivedDate: mon.AddDate(0, 0, 3), MonthlyPriceFrom: price(600000), RemoteType: "リモート不可",
+			Skills: []SkillRef{{Kind: KindLanguage, Name: "Go"}}},
+		{ID: 3, ReceivedDate: mon.AddDate(0, 0, 7), Skills: []SkillRef{{Kind: KindFramework, Name: "Gin"}}},
+	}
+
+	weeks, skills := Aggregate(projects)
+	require.Len(t, weeks, 2)
+	assert.Equal(t, time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC), weeks[0].WeekStart)
+	assert.Equal(t, 2, weeks[0].Projects)
+	assert.Equal(t, 2, weeks[0].Price.Count)
+	assert.Equal(t, 700000, *weeks[0].Price.Median)
+	assert.Equal(t, 1, weeks[0].RemoteFull)
+	assert.Equal(t, 1, weeks[0].RemoteNone)
+	assert.Equal(t, 1, weeks[1].Projects)
+	assert.Equal(t, 1, weeks[1].RemoteUnknown)
+	assert.Nil(t, weeks[1].Price.Median)
+
+	// 週・種類・名前の順で、同じ案件の重複したスキルは1件と数えること
+	require.Len(t, skills, 3)
+	assert.Equal(t, "Go", skills[0].Name)
+	assert.Equal(t, 2, skills[0].Projects)
+	assert.Equal(t, 1, skills[0].RemoteFull)
+	assert.Equal(t, 700000, *skills[0].Price.Median)
+	assert.Equal(t, KindPosition, skills[1].Kind)
+	assert.Equal(t, "Gin", skills[2].Name)
+	assert.Equal(t, weeks[1].WeekStart, skills[2].WeekStart)
+}
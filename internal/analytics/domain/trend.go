@@ -0,0 +1,400 @@
+// Package domain は案件の市場動向（スキルの需要・単価・リモート比率の推移）の集計機能のドメインモデルを提供します。
+// このファイルは集計から推移（時系列）・伸びているスキルを求める条件と結果、CSV の行を定義します。
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultWeeks    = 12  // 既定で返す週の数
+	MaxWeeks        = 104 // 返す週の数の上限
+	DefaultLimit    = 10  // スキルを指定しない場合に返すスキル数（期間内の案件数の多い順）
+	MaxLimit        = 50  // 返すスキル数の上限
+	DefaultWindow   = 4   // 伸びているスキルで比べる直近の週の数
+	MaxWindow       = 26  // 比べる週の数の上限
+	DefaultMinCount = 3   // 伸びているスキルに含める直近の案件数の下限
+	OverallName     = "全体"
+	KindAll         = "all" // 全体の推移の種類
+)
+
+// ErrInvalidFilter は集計の条件が不正な場合のエラーです
+var ErrInvalidFilter = errors.New("集計の条件が不正です")
+
+// Filter は推移を求める条件です
+type Filter struct {
+	Kind     string    // スキルの種類（language（既定） / framework / position）
+	Names    []string  // スキル名（大文字小文字を区別しない。未指定は期間内の案件数の多い順に Limit 件）
+	Weeks    int       // 返す週の数
+	Until    time.Time // この日時を含む週まで返す（未指定は現在）
+	Limit    int       // スキルを指定しない場合に返すスキル数・伸びているスキルの数
+	Window   int       // 伸びているスキルで比べる週の数（直近 Window 週とその前の Window 週を比べる）
+	MinCount int       // 伸びているスキルに含める直近の案件数の下限
+}
+
+// Normalize は未指定の項目に既定値を設定し、条件を検証します
+func (f Filter) Normalize(now time.Time) (Filter, error) {
+	if f.Kind == "" {
+		f.Kind = KindLanguage
+	}
+	if f.Weeks == 0 {
+		f.Weeks = DefaultWeeks
+	}
+	if f.Until.IsZero() {
+		f.Until = now
+	}
+	if f.Limit == 0 {
+		f.Limit = DefaultLimit
+	}
+	if f.Window == 0 {
+		f.Window = DefaultWindow
+	}
+	if f.MinCount == 0 {
+		f.MinCount = DefaultMinCount
+	}
+	names := make([]string, 0, len(f.Names))
+	for _, name := range f.Names {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	f.Names = names
+
+	if !IsValidKind(f.Kind) {
+		return f, fmt.Errorf("%w: kind は language / framework / position で指定してください", ErrInvalidFilter)
+	}
+	if f.Weeks < 0 || f.Weeks > MaxWeeks {
+		return f, fmt.Errorf("%w: weeks は1〜%dで指定してください", ErrInvalidFilter, MaxWeeks)
+	}
+	if f.Limit < 0 || f.Limit > MaxLimit || len(f.Names) > MaxLimit {
+		return f, fmt.Errorf("%w: limit・スキル名は%d件以内で指定してください", ErrInvalidFilter, MaxLimit)
+	}
+	if f.Window < 0 || f.Window > MaxWindow {
+		return f, fmt.Errorf("%w: window は1〜%dで指定してください", ErrInvalidFilter, MaxWindow)
+	}
+	if f.MinCount < 0 {
+		return f, fmt.Errorf("%w: min_count は1以上で指定してください", ErrInvalidFilter)
+	}
+	return f, nil
+}
+
+// Range は推移を返す期間（最初の週の開始日から、最後の週の翌週の開始日まで）を返します
+func (f Filter) Range() (from, to time.Time) {
+	to = WeekStart(f.Until).AddDate(0, 0, 7)
+	return to.AddDate(0, 0, -7*f.Weeks), to
+}
+
+// RisingRanges は伸びているスキルで比べる直近の期間と、その前の期間を返します
+func (f Filter) RisingRanges() (recentFrom, recentTo, previousFrom time.Time) {
+	recentTo = WeekStart(f.Until).AddDate(0, 0, 7)
+	recentFrom = recentTo.AddDate(0, 0, -7*f.Window)
+	return recentFrom, recentTo, recentFrom.AddDate(0, 0, -7*f.Window)
+}
+
+// WeekStarts は期間内の週の開始日を古い順に返します
+func WeekStarts(from, to time.Time) []time.Time {
+	var weeks []time.Time
+	for w := WeekStart(from); w.Before(to); w = w.AddDate(0, 0, 7) {
+		weeks = append(weeks, w)
+	}
+	return weeks
+}
+
+// SkillPoint は週ごとのスキルの案件数です
+type SkillPoint struct {
+	WeekStart time.Time `json:"week_start"`
+	Projects  int       `json:"projects"`
+	Share     float64   `json:"share"` // その週の案件数に占める割合（0〜1）
+}
+
+// SkillSeries はスキルの案件数の推移です
+type SkillSeries struct {
+	Kind   string       `json:"kind"`
+	Name   string       `json:"name"`
+	Total  int          `json:"total"` // 期間内の案件数
+	Points []SkillPoint `json:"points"`
+}
+
+// PricePoint は週ごとの単価の分布です
+type PricePoint struct {
+	WeekStart time.Time `json:"week_start"`
+	PriceStats
+}
+
+// PriceSeries はスキルの単価の分布の推移です（全体の推移は Kind が all）
+type PriceSeries struct {
+	Kind   string       `json:"kind"`
+	Name   string       `json:"name"`
+	Points []PricePoint `json:"points"`
+}
+
+// RemotePoint は週ごとのリモート区分の内訳です
+type RemotePoint struct {
+	WeekStart       time.Time `json:"week_start"`
+	Projects        int       `json:"projects"`
+	Full            int       `json:"full"`
+	Partial         int       `json:"partial"`
+	None            int       `json:"none"`
+	Unknown         int       `json:"unknown"`
+	RemoteRatio     float64   `json:"remote_ratio"`      // リモート区分の記載がある案件のうち、フルリモート・一部リモートの割合
+	FullRemoteRatio float64   `json:"full_remote_ratio"` // リモート区分の記載がある案件のうち、フルリモートの割合
+}
+
+// RisingSkill は直近で案件が増えているスキルです
+type RisingSkill struct {
+	Kind     string   `json:"kind"`
+	Name     string   `json:"name"`
+	Recent   int      `json:"recent"`   // 直近の期間の案件数
+	Previous int      `json:"previous"` // その前の期間の案件数
+	Delta    int      `json:"delta"`    // 増えた案件数
+	Growth   *float64 `json:"growth"`   // 増加率（前の期間が0件の場合は nil）
+}
+
+// SkillTrends はスキルごとの案件数の推移を返します
+// names が空の場合は期間内の案件数の多い順に limit 件を返します。案件の無い週は0件とします。
+func SkillTrends(weeks []time.Time, totals []Week, rows []SkillWeek, kind string, names []string, limit int) []SkillSeries {
+	weekTotals := map[int64]int{}
+	for _, w := range totals {
+		weekTotals[w.WeekStart.Unix()] = w.Projects
+	}
+	byName := groupByName(rows, kind)
+
+	series := []SkillSeries{}
+	for _, name := range selectNames(byName, names, limit) {
+		counts := map[int64]int{}
+		s := SkillSeries{Kind: kind, Name: name, Points: []SkillPoint{}}
+		for _, r := range byName[strings.ToLower(name)] {
+			counts[r.WeekStart.Unix()] = r.Projects
+			s.Name = r.Name
+			s.Total += r.Projects
+		}
+		for _, w := range weeks {
+			p := SkillPoint{WeekStart: w, Projects: counts[w.Unix()]}
+			if total := weekTotals[w.Unix()]; total > 0 {
+				p.Share = round(float64(p.Projects)/float64(total), 4)
+			}
+			s.Points = append(s.Points, p)
+		}
+		series = append(series, s)
+	}
+	return series
+}
+
+// PriceTrends は全体とスキルごとの単価の分布の推移を返します（先頭が全体）
+// names が空の場合は期間内の案件数の多い順に limit 件のスキルを返します。
+func PriceTrends(weeks []time.Time, totals []Week, rows []SkillWeek, kind string, names []string, limit int) []PriceSeries {
+	overall := map[int64]PriceStats{}
+	for _, w := range totals {
+		overall[w.WeekStart.Unix()] = w.Price
+	}
+	series := []PriceSeries{{Kind: KindAll, Name: OverallName, Points: pricePoints(weeks, overall)}}
+
+	byName := groupByName(rows, kind)
+	for _, name := range selectNames(byName, names, limit) {
+		stats := map[int64]PriceStats{}
+		s := PriceSeries{Kind: kind, Name: name}
+		for _, r := range byName[strings.ToLower(name)] {
+			stats[r.WeekStart.Unix()] = r.Price
+			s.Name = r.Name
+		}
+		s.Points = pricePoints(weeks, stats)
+		series = append(series, s)
+	}
+	return series
+}
+
+// RemoteTrends は週ごとのリモート区分の内訳を返します（案件の無い週は0件）
+func RemoteTrends(weeks []time.Time, totals []Week) []RemotePoint {
+	byWeek := map[int64]Week{}
+	for _, w := range totals {
+		byWeek[w.WeekStart.Unix()] = w
+	}
+	points := make([]RemotePoint, 0, len(weeks))
+	for _, start := range weeks {
+		w := byWeek[start.Unix()]
+		p := RemotePoint{
+			WeekStart: start,
+			Projects:  w.Projects,
+			Full:      w.RemoteFull,
+			Partial:   w.RemotePartial,
+			None:      w.RemoteNone,
+			Unknown:   w.RemoteUnknown,
+		}
+		if known := p.Full + p.Partial + p.None; known > 0 {
+			p.RemoteRatio = round(float64(p.Full+p.Partial)/float64(known), 4)
+			p.FullRemoteRatio = round(float64(p.Full)/float64(known), 4)
+		}
+		points = append(points, p)
+	}
+	return points
+}
+
+// Rising は直近の期間とその前の期間の案件数を比べ、増えているスキルを返します
+// 直近の案件数が minCount 以上で前の期間より多いスキルを、増加率（前の期間に1件を足して平準化）の高い順、
+// 同率は直近の案件数の多い順に limit 件返します。
+func Rising(recent, previous []SkillWeek, minCount, limit int) []RisingSkill {
+	type key struct{ kind, name string }
+	recentCounts := map[key]int{}
+	previousCounts := map[key]int{}
+	for _, r := range recent {
+		recentCounts[key{r.Kind, r.Name}] += r.Projects
+	}
+	for _, r := range previous {
+		previousCounts[key{r.Kind, r.Name}] += r.Projects
+	}
+
+	skills := []RisingSkill{}
+	for k, n := range recentCounts {
+		prev := previousCounts[k]
+		if n < minCount || n <= prev {
+			continue
+		}
+		s := RisingSkill{Kind: k.kind, Name: k.name, Recent: n, Previous: prev, Delta: n - prev}
+		if prev > 0 {
+			g := round(float64(n-prev)/float64(prev), 4)
+			s.Growth = &g
+		}
+		skills = append(skills, s)
+	}
+	score := func(s RisingSkill) float64 { return float64(s.Recent+1) / float64(s.Previous+1) }
+	sort.Slice(skills, func(i, j int) bool {
+		a, b := skills[i], skills[j]
+		if score(a) != score(b) {
+			return score(a) > score(b)
+		}
+		if a.Recent != b.Recent {
+			return a.Recent > b.Recent
+		}
+		return a.Name < b.Name
+	})
+	if len(skills) > limit {
+		skills = skills[:limit]
+	}
+	return skills
+}
+
+// SkillRecords は案件数の推移を CSV の行（見出し付き）にします
+func SkillRecords(series []SkillSeries) [][]string {
+	records := [][]string{{"week_start", "kind", "name", "projects", "share"}}
+	for _, s := range series {
+		for _, p := range s.Points {
+			records = append(records, []string{formatDate(p.WeekStart), s.Kind, s.Name, strconv.Itoa(p.Projects), formatFloat(p.Share)})
+		}
+	}
+	return records
+}
+
+// PriceRecords は単価の分布の推移を CSV の行（見出し付き）にします（単価の記載が無い週は空欄）
+func PriceRecords(series []PriceSeries) [][]string {
+	records := [][]string{{"week_start", "kind", "name", "priced_projects", "p25", "median", "p75", "p90"}}
+	for _, s := range series {
+		for _, p := range s.Points {
+			records = append(records, []string{
+				formatDate(p.WeekStart), s.Kind, s.Name, strconv.Itoa(p.Count),
+				formatInt(p.P25), formatInt(p.Median), formatInt(p.P75), formatInt(p.P90),
+			})
+		}
+	}
+	return records
+}
+
+// RemoteRecords はリモート区分の内訳の推移を CSV の行（見出し付き）にします
+func RemoteRecords(points []RemotePoint) [][]string {
+	records := [][]string{{"week_start", "projects", "full", "partial", "none", "unknown", "remote_ratio", "full_remote_ratio"}}
+	for _, p := range points {
+		records = append(records, []string{
+			formatDate(p.WeekStart), strconv.Itoa(p.Projects), strconv.Itoa(p.Full), strconv.Itoa(p.Partial),
+			strconv.Itoa(p.None), strconv.Itoa(p.Unknown), formatFloat(p.RemoteRatio), formatFloat(p.FullRemoteRatio),
+		})
+	}
+	return records
+}
+
+// RisingRecords は増えているスキルを CSV の行（見出し付き）にします（増加率が無い場合は空欄）
+func RisingRecords(skills []RisingSkill) [][]string {
+	records := [][]string{{"kind", "name", "recent", "previous", "delta", "growth"}}
+	for _, s := range skills {
+		growth := ""
+		if s.Growth != nil {
+			growth = formatFloat(*s.Growth)
+		}
+		records = append(records, []string{s.Kind, s.Name, strconv.Itoa(s.Recent), strconv.Itoa(s.Previous), strconv.Itoa(s.Delta), growth})
+	}
+	return records
+}
+
+// groupByName は種類が一致する集計をスキル名（小文字）ごとにまとめます
+func groupByName(rows []SkillWeek, kind string) map[string][]SkillWeek {
+	byName := map[string][]SkillWeek{}
+	for _, r := range rows {
+		if r.Kind == kind {
+			key := strings.ToLower(r.Name)
+			byName[key] = append(byName[key], r)
+		}
+	}
+	return byName
+}
+
+// selectNames は指定されたスキル名、未指定の場合は案件数の多い順に limit 件のスキル名を返します
+func selectNames(byName map[string][]SkillWeek, names []string, limit int) []string {
+	if len(names) > 0 {
+		return names
+	}
+	type total struct {
+		name     string
+		projects int
+	}
+	totals := make([]total, 0, len(byName))
+	for _, rows := range byName {
+		t := total{name: rows[0].Name}
+		for _, r := range rows {
+			t.projects += r.Projects
+		}
+		totals = append(totals, t)
+	}
+	sort.Slice(totals, func(i, j int) bool {
+		if totals[i].projects != totals[j].projects {
+			return totals[i].projects > totals[j].projects
+		}
+		return totals[i].name < totals[j].name
+	})
+	selected := make([]string, 0, limit)
+	for i := 0; i < len(totals) && i < limit; i++ {
+		selected = append(selected, totals[i].name)
+	}
+	return selected
+}
+
+func pricePoints(weeks []time.Time, stats map[int64]PriceStats) []PricePoint {
+	points := make([]PricePoint, 0, len(weeks))
+	for _, w := range weeks {
+		points = append(points, PricePoint{WeekStart: w, PriceStats: stats[w.Unix()]})
+	}
+	return points
+}
+
+func round(v float64, digits int) float64 {
+	p := math.Pow(10, float64(digits))
+	return math.Round(v*p) / p
+}
+
+func formatDate(t time.Time) string {
+	return t.Format("2006-01-02")
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+func formatInt(v *int) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.Itoa(*v)
+}
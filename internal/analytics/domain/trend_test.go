@@ -0,0 +1,137 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilter_Normalize(t *testing.T) {
+	now := time.Date(2025, 6, 11, 9, 0, 0, 0, time.UTC)
+
+	f, err := Filter{Names: []string{" Go ", ""}}.Normalize(now)
+	require.NoError(t, err)
+	assert.Equal(t, KindLanguage, f.Kind)
+	assert.Equal(t, []string{"Go"}, f.Names)
+	assert.Equal(t, DefaultWeeks, f.Weeks)
+	from, to := f.Range()
+	assert.Equal(t, time.Date(2025, 6, 16, 0, 0, 0, 0, time.UTC), to)
+	assert.Equal(t, time.Date(2025, 3, 24, 0, 0, 0, 0, time.UTC), from)
+	assert.Len(t, WeekStarts(from, to), DefaultWeeks)
+
+	recentFrom, recentTo, previousFrom := f.RisingRanges()
+	assert.Equal(t, to, recentTo)
+	assert.Equal(t, time.Date(2025, 5, 19, 0, 0, 0, 0, time.UTC), recentFrom)
+	assert.Equal(t, time.Date(2025, 4, 21, 0, 0, 0, 0, time.UTC), previousFrom)
+
+	invalid := []Filter{
+		{Kind: "tool"},
+		{Weeks: MaxWeeks + 1},
+		{Limit: MaxLimit + 1},
+		{Window: -1},
+		{MinCount: -1},
+	}
+	for _, f := range invalid {
+		_, err := f.Normalize(now)
+		assert.ErrorIs(t, err, ErrInvalidFilter)
+	}
+}
+
+func TestTrends(t *testing.T) {
+	w1 := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+	w2 := w1.AddDate(0, 0, 7)
+	weeks := []time.Time{w1, w2}
+	price := func(v int) *int { return &v }
+	totals := []Week{
+		{WeekStart: w1, Projects: 10, Price: PriceStats{Count: 8, Median: price(700000)}, RemoteFull: 2, RemotePartial: 3, RemoteNone: 3, RemoteUnknown: 2},
+	}
+	rows := []SkillWeek{
+		{WeekStart: w1, Kind: KindLanguage, Name: "Go", Projects: 4, Price: PriceStats{Count: 4, Median: price(750000)}},
+		{WeekStart: w2, Kind: KindLanguage, Name: "Go", Projects: 1},
+		{WeekStart: w1, Kind: KindLanguage, Name: "Java", Projects: 3},
+		{WeekStart: w1, Kind: KindFramework, Name: "Gin", Projects: 9},
+	}
+
+	// 未指定の場合は種類が一致するスキルを案件数の多い順に返すこと
+	series := SkillTrends(weeks, totals, rows, KindLanguage, nil, 1)
+	require.Len(t, series, 1)
+	assert.Equal(t, "Go", series[0].Name)
+	assert.Equal(t, 5, series[0].Total)
+	assert.Equal(t, []SkillPoint{{WeekStart: w1, Projects: 4, Share: 0.4}, {WeekStart: w2, Projects: 1}}, series[0].Points)
+
+	// 指定したスキルは大文字小文字を区別せず、登録した表記で返すこと
+	series = SkillTrends(weeks, totals, rows, KindLanguage, []string{"java", "Rust"}, DefaultLimit)
+	require.Len(t, series, 2)
+	assert.Equal(t, "Java", series[0].Name)
+	assert.Equal(t, 3, series[0].Total)
+	assert.Equal(t, "Rust", series[1].Name)
+	assert.Zero(t, series[1].Total)
+
+	prices := PriceTrends(weeks, totals, rows, KindLanguage, []string{"Go"}, DefaultLimit)
+	require.Len(t, prices, 2)
+	assert.Equal(t, OverallName, prices[0].Name)
+	assert.Equal(t, 700000, *prices[0].Points[0].Median)
+	assert.Nil(t, prices[0].Points[1].Median)
+	assert.Equal(t, 750000, *prices[1].Points[0].Median)
+
+	remote := RemoteTrends(weeks, totals)
+	require.Len(t, remote, 2)
+	assert.Equal(t, 0.625, remote[0].RemoteRatio)
+	assert.Equal(t, 0.25, remote[0].FullRemoteRatio)
+	assert.Zero(t, remote[1].Projects)
+	assert.Zero(t, remote[1].RemoteRatio)
+}
+
+func TestRising(t *testing.T) {
+	w := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+	recent := []SkillWeek{
+		{WeekStart: w, Kind: KindLanguage, Name: "Go", Projects: 6},
+		{WeekStart: w.AddDate(0, 0, 7), Kind: KindLanguage, Name: "Go", Projects: 4},
+		{WeekStart: w, Kind: KindLanguage, Name: "Rust", Projects: 3},
+		{WeekStart: w, Kind: KindLanguage, Name: "Java", Projects: 5},
+		{WeekStart: w, Kind: KindLanguage, Name: "COBOL", Projects: 2},
+	}
+	previous := []SkillWeek{
+		{WeekStart: w.AddDate(0, 0, -14), Kind: KindLanguage, Name: "Go", Projects: 4},
+		{WeekStart: w.AddDate(0, 0, -14), Kind: KindLanguage, Name: "Java", Projects: 8},
+	}
+
+	// 直近の案件数が下限未満・減っているスキルは除き、平準化した増加率の高い順に返すこと
+	got := Rising(recent, previous, 3, DefaultLimit)
+	require.Len(t, got, 2)
+	assert.Equal(t, "Rust", got[0].Name)
+	assert.Nil(t, got[0].Growth)
+	assert.Equal(t, "Go", got[1].Name)
+	assert.Equal(t, 6, got[1].Delta)
+	assert.Equal(t, 1.5, *got[1].Growth)
+
+	assert.Len(t, Rising(recent, previous, 3, 1), 1)
+}
+
+func TestRecords(t *testing.T) {
+	w := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+	price := func(v int) *int { return &v }
+
+	assert.Equal(t, [][]string{
+		{"week_start", "kind", "name", "projects", "share"},
+		{"2025-06-02", "language", "Go", "4", "0.4"},
+	}, SkillRecords([]SkillSeries{{Kind: KindLanguage, Name: "Go", Points: []SkillPoint{{WeekStart: w, Projects: 4, Share: 0.4}}}}))
+
+	assert.Equal(t, []string{"2025-06-02", "all", OverallName, "1", "", "700000", "", ""},
+		PriceRecords([]PriceSeries{{Kind: KindAll, Name: OverallName, Points: []PricePoint{{WeekStart: w, PriceStats: PriceStats{Count: 1, Median: price(700000)}}}}})[1])
+
+	assert.Equal(t, []string{"2025-06-02", "10", "2", "3", "3", "2", "0.625", "0.25"},
+		RemoteRecords([]RemotePoint{{WeekStart: w, Projects: 10, Full: 2, Partial: 3, None: 3, Unknown: 2, RemoteRatio: 0.625, FullRemoteRatio: 0.25}})[1])
+
+	growth := 1.5
+	assert.Equal(t, [][]string{
+		{"kind", "name", "recent", "previous", "delta", "growth"},
+		{"language", "Go", "10", "4", "6", "1.5"},
+		{"language", "Rust", "3", "0", "3", ""},
+	}, RisingRecords([]RisingSkill{
+		{Kind: KindLanguage, Name: "Go", Recent: 10, Previous: 4, Delta: 6, Growth: &growth},
+		{Kind: KindLanguage, Name: "Rust", Recent: 3, Delta: 3},
+	}))
+}
@@ -0,0 +1,38 @@
+// Package infrastructure は案件の市場動向の集計機能のインフラストラクチャ層を提供します。
+// このファイルは市場動向の集計で使用するリポジトリのインターフェースを定義します。
+package infrastructure
+
+import (
+	"business/internal/analytics/domain"
+	"time"
+)
+
+// RepositoryInterface は案件の市場動向の集計のリポジトリインターフェースです
+type RepositoryInterface interface {
+	// LastRefreshedProjectID は集計済みの案件IDの最大値を返します（未実行の場合は0）
+	LastRefreshedProjectID() (uint, error)
+
+	// MaxProjectID は保存されている案件IDの最大値を返します（案件が無い場合は0）
+	MaxProjectID() (uint, error)
+
+	// ListProjectWeeks は案件IDが afterID より後 upToID 以下の案件の受信日の週の開始日を昇順に返します
+	ListProjectWeeks(afterID, upToID uint) ([]time.Time, error)
+
+	// ListProjects は受信日が from 以降 to より前の案件を、リモート区分とスキル付きで返します
+	ListProjects(from, to time.Time) ([]domain.Project, error)
+
+	// ReplaceWeeks は週の集計を作り直します（weeks の週の既存の集計を削除して保存します）
+	ReplaceWeeks(weeks []time.Time, totals []domain.Week, skills []domain.SkillWeek) error
+
+	// DeleteAll はすべての週の集計を削除します
+	DeleteAll() error
+
+	// SaveRun は集計の更新の実行履歴を保存します
+	SaveRun(result domain.RefreshResult, startedAt, finishedAt time.Time) error
+
+	// ListWeeks は週の開始日が from 以降 to より前の週の集計を週の順に返します
+	ListWeeks(from, to time.Time) ([]domain.Week, error)
+
+	// ListSkillWeeks は週の開始日が from 以降 to より前の種類のスキルの集計を週・名前の順に返します
+	ListSkillWeeks(kind string, from, to time.Time) ([]domain.SkillWeek, error)
+}
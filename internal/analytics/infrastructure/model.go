@@ -0,0 +1,68 @@
+// Package infrastructure は案件の市場動向の集計機能のインフラストラクチャ層を提供します。
+// このファイルは集計で参照・更新するテーブルのモデルとクエリの結果の行を定義します。
+package infrastructure
+
+import (
+	"time"
+)
+
+// AnalyticsWeek は週ごとの案件数・単価・リモート区分の集計を表すモデルです
+type AnalyticsWeek struct {
+	ID             uint      `gorm:"primaryKey;autoIncrement"`
+	WeekStart      time.Time `gorm:"type:date;not null;uniqueIndex"`
+	Projects       int       `gorm:"not null"`
+	PricedProjects int       `gorm:"not null"`
+	PriceP25       *int      `gorm:"type:int"`
+	PriceMedian    *int      `gorm:"type:int"`
+	PriceP75       *int      `gorm:"type:int"`
+	PriceP90       *int      `gorm:"type:int"`
+	RemoteFull     int       `gorm:"not null"`
+	RemotePartial  int       `gorm:"not null"`
+	RemoteNone     int       `gorm:"not null"`
+	RemoteUnknown  int       `gorm:"not null"`
+	UpdatedAt      time.Time
+}
+
+// AnalyticsSkillWeek は週ごと・スキルごとの案件数と単価の集計を表すモデルです
+type AnalyticsSkillWeek struct {
+	ID             uint      `gorm:"primaryKey;autoIncrement"`
+	WeekStart      time.Time `gorm:"type:date;not null;uniqueIndex:idx_analytics_skill_week,priority:1"`
+	Kind           string    `gorm:"size:20;not null;uniqueIndex:idx_analytics_skill_week,priority:2"`
+	Name           string    `gorm:"size:255;not null;uniqueIndex:idx_analytics_skill_week,priority:3"`
+	Projects       int       `gorm:"not null"`
+	PricedProjects int       `gorm:"not null"`
+	PriceP25       *int      `gorm:"type:int"`
+	PriceMedian    *int      `gorm:"type:int"`
+	PriceP75       *int      `gorm:"type:int"`
+	PriceP90       *int      `gorm:"type:int"`
+	RemoteFull     int       `gorm:"not null"`
+	RemotePartial  int       `gorm:"not null"`
+	UpdatedAt      time.Time
+}
+
+// AnalyticsRun は集計の更新の実行履歴を表すモデルです
+type AnalyticsRun struct {
+	ID            uint `gorm:"primaryKey;autoIncrement"`
+	LastProjectID uint `gorm:"not null"`
+	Weeks         int  `gorm:"not null"`
+	Projects      int  `gorm:"not null"`
+	Full          bool `gorm:"not null"`
+	StartedAt     time.Time
+	FinishedAt    time.Time
+}
+
+// projectRow は集計する案件の列です
+type projectRow struct {
+	ProjectID        uint
+	ReceivedDate     time.Time
+	MonthlyPriceFrom *int
+	MonthlyPriceTo   *int
+	RemoteType       *string
+}
+
+// skillRow は案件に紐づくキーワードグループ・ポジショングループの列です
+type skillRow struct {
+	EmailProjectID uint
+	Kind           string
+	Name           string
+}
@@ -0,0 +1,306 @@
+// Package infrastructure は案件の市場動向の集計機能のインフラストラクチャ層を提供します。
+// このファイルは集計する案件の参照と、週ごとの集計の保存・参照を実装します。
+package infrastructure
+
+import (
+	"business/internal/analytics/domain"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/samber/lo"
+	"gorm.io/gorm"
+)
+
+const (
+	queryChunkSize  = 1000 // IN 句にまとめる案件IDの件数
+	insertBatchSize = 500  // 一度に保存する集計の行数
+)
+
+// Repository は案件の市場動向の集計のリポジトリ実装です
+type Repository struct {
+	db *gorm.DB
+}
+
+// New は案件の市場動向の集計のリポジトリを作成します
+func New(db *gorm.DB) *Repository {
+	return &Repository{
+		db: db,
+	}
+}
+
+// LastRefreshedProjectID は集計済みの案件IDの最大値を返します（未実行の場合は0）
+func (r *Repository) LastRefreshedProjectID() (uint, error) {
+	var last *uint
+	if err := r.db.Model(&AnalyticsRun{}).Select("MAX(last_project_id)").Scan(&last).Error; err != nil {
+		return 0, fmt.Errorf("実行履歴取得エラー: %w", err)
+	}
+	if last == nil {
+		return 0, nil
+	}
+	return *last, nil
+}
+
+// MaxProjectID は保存されている案件IDの最大値を返します（案件が無い場合は0）
+func (r *Repository) MaxProjectID() (uint, error) {
+	var maxID *uint
+	if err := r.db.Table("email_projects").Select("MAX(id)").Scan(&maxID).Error; err != nil {
+		return 0, fmt.Errorf("案件取得エラー: %w", err)
+	}
+	if maxID == nil {
+		return 0, nil
+	}
+	return *maxID, nil
+}
+
+// ListProjectWeeks は案件IDが afterID より後 upToID 以下の案件の受信日の週の開始日を昇順に返します
+func (r *Repository) ListProjectWeeks(afterID, upToID uint) ([]time.Time, error) {
+	var days []time.Time
+	err := r.db.Table("email_projects ep").
+		Joins("JOIN emails e ON e.id = ep.email_id").
+		Where("ep.id > ? AND ep.id <= ?", afterID, upToID).
+		Distinct().
+		Pluck("DATE(e.received_date)", &days).Error
+	if err != nil {
+		return nil, fmt.Errorf("案件取得エラー: %w", err)
+	}
+
+	weeks := lo.UniqBy(lo.Map(days, func(d time.Time, _ int) time.Time { return domain.WeekStart(d) }),
+		func(w time.Time) int64 { return w.Unix() })
+	sortTimes(weeks)
+	return weeks, nil
+}
+
+// ListProjects は受信日が from 以降 to より前の案件を、リモート区分とスキル付きで返します
+// アーカイブした案件も含めます（募集を終えた案件も、その週の市場の需要として数える）。
+// スキルは用語辞書で表記ゆれをまとめた言語・フレームワークのキーワードグループと、ポジショングループの名前です。
+func (r *Repository) ListProjects(from, to time.Time) ([]domain.Project, error) {
+	var rows []projectRow
+	err := r.db.Table("email_projects ep").
+		Select("ep.id AS project_id, e.received_date, ep.monthly_price_from, ep.monthly_price_to, ep.remote_type").
+		Joins("JOIN emails e ON e.id = ep.email_id").
+		Where("e.received_date >= ? AND e.received_date < ?", from, to).
+		Order("ep.id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("案件取得エラー: %w", err)
+	}
+
+	projects := make([]domain.Project, 0, len(rows))
+	index := make(map[uint]int, len(rows))
+	for _, row := range rows {
+		index[row.ProjectID] = len(projects)
+		projects = append(projects, domain.Project{
+			ID:               row.ProjectID,
+			ReceivedDate:     row.ReceivedDate,
+			MonthlyPriceFrom: row.MonthlyPriceFrom,
+			MonthlyPriceTo:   row.MonthlyPriceTo,
+			RemoteType:       derefString(row.RemoteType),
+		})
+	}
+
+	for _, ids := range lo.Chunk(lo.Keys(index), queryChunkSize) {
+		var remote []uint
+		err := r.db.Table("project_locations").
+			Where("email_project_id IN ? AND remote = ?", ids, true).
+			Distinct().
+			Pluck("email_project_id", &remote).Error
+		if err != nil {
+			return nil, fmt.Errorf("勤務地取得エラー: %w", err)
+		}
+		for _, id := range remote {
+			projects[index[id]].FullRemote = true
+		}
+
+		var skills []skillRow
+		err = r.db.Table("email_keyword_groups ekg").
+			Select("DISTINCT ekg.email_project_id, kg.type AS kind, kg.name").
+			Joins("JOIN keyword_groups kg ON kg.keyword_group_id = ekg.keyword_group_id").
+			Where("ekg.email_project_id IN ? AND kg.type IN ?", ids, []string{domain.KindLanguage, domain.KindFramework}).
+			Order("ekg.email_project_id, kg.type, kg.name").
+			Scan(&skills).Error
+		if err != nil {
+			return nil, fmt.Errorf("スキル取得エラー: %w", err)
+		}
+		var positions []skillRow
+		err = r.db.Table("email_position_groups epg").
+			Select("DISTINCT epg.email_project_id, ? AS kind, pg.name", domain.KindPosition).
+			Joins("JOIN position_groups pg ON pg.position_group_id = epg.position_group_id").
+			Where("epg.email_project_id IN ?", ids).
+			Order("epg.email_project_id, pg.name").
+			Scan(&positions).Error
+		if err != nil {
+			return nil, fmt.Errorf("ポジション取得エラー: %w", err)
+		}
+		for _, s := range append(skills, positions...) {
+			p := &projects[index[s.EmailProjectID]]
+			p.Skills = append(p.Skills, domain.SkillRef{Kind: s.Kind, Name: s.Name})
+		}
+	}
+	return projects, nil
+}
+
+// ReplaceWeeks は週の集計を作り直します
+// weeks の週の既存の集計を削除し、totals・skills のうち weeks の週の集計を保存します（案件が無くなった週は集計が残りません）。
+func (r *Repository) ReplaceWeeks(weeks []time.Time, totals []domain.Week, skills []domain.SkillWeek) error {
+	if len(weeks) == 0 {
+		return nil
+	}
+	target := lo.SliceToMap(weeks, func(w time.Time) (int64, struct{}) { return w.Unix(), struct{}{} })
+	var weekRows []AnalyticsWeek
+	for _, w := range totals {
+		if _, ok := target[w.WeekStart.Unix()]; ok {
+			weekRows = append(weekRows, toWeekModel(w))
+		}
+	}
+	var skillRows []AnalyticsSkillWeek
+	for _, s := range skills {
+		if _, ok := target[s.WeekStart.Unix()]; ok {
+			skillRows = append(skillRows, toSkillWeekModel(s))
+		}
+	}
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("week_start IN ?", weeks).Delete(&AnalyticsSkillWeek{}).Error; err != nil {
+			return fmt.Errorf("スキル別の集計削除エラー: %w", err)
+		}
+		if err := tx.Where("week_start IN ?", weeks).Delete(&AnalyticsWeek{}).Error; err != nil {
+			return fmt.Errorf("週の集計削除エラー: %w", err)
+		}
+		if len(weekRows) > 0 {
+			if err := tx.CreateInBatches(&weekRows, insertBatchSize).Error; err != nil {
+				return fmt.Errorf("週の集計保存エラー: %w", err)
+			}
+		}
+		if len(skillRows) > 0 {
+			if err := tx.CreateInBatches(&skillRows, insertBatchSize).Error; err != nil {
+				return fmt.Errorf("スキル別の集計保存エラー: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// DeleteAll はすべての週の集計を削除します
+func (r *Repository) DeleteAll() error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("1 = 1").Delete(&AnalyticsSkillWeek{}).Error; err != nil {
+			return fmt.Errorf("スキル別の集計削除エラー: %w", err)
+		}
+		if err := tx.Where("1 = 1").Delete(&AnalyticsWeek{}).Error; err != nil {
+			return fmt.Errorf("週の集計削除エラー: %w", err)
+		}
+		return nil
+	})
+}
+
+// SaveRun は集計の更新の実行履歴を保存します
+func (r *Repository) SaveRun(result domain.RefreshResult, startedAt, finishedAt time.Time) error {
+	run := AnalyticsRun{
+		LastProjectID: result.LastProjectID,
+		Weeks:         result.Weeks,
+		Projects:      result.Projects,
+		Full:          result.Full,
+		StartedAt:     startedAt,
+		FinishedAt:    finishedAt,
+	}
+	if err := r.db.Create(&run).Error; err != nil {
+		return fmt.Errorf("実行履歴保存エラー: %w", err)
+	}
+	return nil
+}
+
+// ListWeeks は週の開始日が from 以降 to より前の週の集計を週の順に返します
+func (r *Repository) ListWeeks(from, to time.Time) ([]domain.Week, error) {
+	var rows []AnalyticsWeek
+	err := r.db.Where("week_start >= ? AND week_start < ?", from, to).
+		Order("week_start").
+		Find(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("週の集計取得エラー: %w", err)
+	}
+	weeks := make([]domain.Week, 0, len(rows))
+	for _, row := range rows {
+		weeks = append(weeks, domain.Week{
+			WeekStart:     row.WeekStart,
+			Projects:      row.Projects,
+			Price:         toPriceStats(row.PricedProjects, row.PriceP25, row.PriceMedian, row.PriceP75, row.PriceP90),
+			RemoteFull:    row.RemoteFull,
+			RemotePartial: row.RemotePartial,
+			RemoteNone:    row.RemoteNone,
+			RemoteUnknown: row.RemoteUnknown,
+		})
+	}
+	return weeks, nil
+}
+
+// ListSkillWeeks は週の開始日が from 以降 to より前の種類のスキルの集計を週・名前の順に返します
+func (r *Repository) ListSkillWeeks(kind string, from, to time.Time) ([]domain.SkillWeek, error) {
+	var rows []AnalyticsSkillWeek
+	err := r.db.Where("kind = ? AND week_start >= ? AND week_start < ?", kind, from, to).
+		Order("week_start, name").
+		Find(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("スキル別の集計取得エラー: %w", err)
+	}
+	skills := make([]domain.SkillWeek, 0, len(rows))
+	for _, row := range rows {
+		skills = append(skills, domain.SkillWeek{
+			WeekStart:     row.WeekStart,
+			Kind:          row.Kind,
+			Name:          row.Name,
+			Projects:      row.Projects,
+			Price:         toPriceStats(row.PricedProjects, row.PriceP25, row.PriceMedian, row.PriceP75, row.PriceP90),
+			RemoteFull:    row.RemoteFull,
+			RemotePartial: row.RemotePartial,
+		})
+	}
+	return skills, nil
+}
+
+func toWeekModel(w domain.Week) AnalyticsWeek {
+	return AnalyticsWeek{
+		WeekStart:      w.WeekStart,
+		Projects:       w.Projects,
+		PricedProjects: w.Price.Count,
+		PriceP25:       w.Price.P25,
+		PriceMedian:    w.Price.Median,
+		PriceP75:       w.Price.P75,
+		PriceP90:       w.Price.P90,
+		RemoteFull:     w.RemoteFull,
+		RemotePartial:  w.RemotePartial,
+		RemoteNone:     w.RemoteNone,
+		RemoteUnknown:  w.RemoteUnknown,
+	}
+}
+
+func toSkillWeekModel(s domain.SkillWeek) AnalyticsSkillWeek {
+	return AnalyticsSkillWeek{
+		WeekStart:      s.WeekStart,
+		Kind:           s.Kind,
+		Name:           s.Name,
+		Projects:       s.Projects,
+		PricedProjects: s.Price.Count,
+		PriceP25:       s.Price.P25,
+		PriceMedian:    s.Price.Median,
+		PriceP75:       s.Price.P75,
+		PriceP90:       s.Price.P90,
+		RemoteFull:     s.RemoteFull,
+		RemotePartial:  s.RemotePartial,
+	}
+}
+
+func toPriceStats(count int, p25, median, p75, p90 *int) domain.PriceStats {
+	return domain.PriceStats{Count: count, P25: p25, Median: median, P75: p75, P90: p90}
+}
+
+func sortTimes(times []time.Time) {
+	sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,163 @@
+package infrastructure
+
+import (
+	"business/internal/analytics/domain"
+	"business/tools/migrations/model"
+	"business/tools/mysql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_ListProjects(t *testing.T) {
+	t.Parallel()
+	// テスト用DBの準備
+	db, cleanup, err := mysql.CreateNewTestDB()
+	require.NoError(t, err)
+	defer cleanup()
+
+	err = db.DB.AutoMigrate(
+		model.Email{},
+		model.EmailProject{},
+		model.KeywordGroup{},
+		model.EmailKeywordGroup{},
+		model.PositionGroup{},
+		model.EmailPositionGroup{},
+		model.ProjectLocation{},
+	)
+	require.NoError(t, err)
+
+	// 2025-06-04 は水曜日
+	wed := time.Date(2025, 6, 4, 10, 0, 0, 0, time.Local)
+	emails := []model.Email{
+		{GmailID: "gmail-1", Subject: "Go案件", SenderEmail: "a@agency.example.com", ReceivedDate: wed, Category: "案件"},
+		{GmailID: "gmail-2", Subject: "翌週の案件", SenderEmail: "b@agency.example.com", ReceivedDate: wed.AddDate(0, 0, 7), Category: "案件"},
+	}
+	require.NoError(t, db.DB.Create(&emails).Error)
+	archivedAt := wed
+	price := 700000
+	remote := "リモート併用"
+	projects := []model.EmailProject{
+		{EmailID: emails[0].ID, ProjectKey: "p1", MonthlyPriceTo: &price, RemoteType: &remote},
+		{EmailID: emails[0].ID, ProjectKey: "p2", ArchivedAt: &archivedAt},
+		{EmailID: emails[1].ID, ProjectKey: "p3"},
+	}
+	require.NoError(t, db.DB.Create(&projects).Error)
+
+	groups := []model.KeywordGroup{{Name: "Go", Type: "language"}, {Name: "Gin", Type: "framework"}, {Name: "AWS", Type: "infra"}}
+	require.NoError(t, db.DB.Create(&groups).Error)
+	links := []model.EmailKeywordGroup{
+		{EmailProjectID: projects[0].ID, KeywordGroupID: groups[0].KeywordGroupID},
+		{EmailProjectID: projects[0].ID, KeywordGroupID: groups[1].KeywordGroupID},
+		{EmailProjectID: projects[0].ID, KeywordGroupID: groups[2].KeywordGroupID},
+	}
+	require.NoError(t, db.DB.Create(&links).Error)
+	position := model.PositionGroup{Name: "SE"}
+	require.NoError(t, db.DB.Create(&position).Error)
+	require.NoError(t, db.DB.Create(&model.EmailPositionGroup{EmailProjectID: projects[0].ID, PositionGroupID: position.PositionGroupID}).Error)
+	require.NoError(t, db.DB.Create(&model.ProjectLocation{EmailProjectID: projects[1].ID, Remote: true}).Error)
+
+	repo := New(db.DB)
+
+	// アーカイブした案件も含め、言語・フレームワーク・ポジションとフルリモートの記載を付けること
+	monday := domain.WeekStart(wed)
+	got, err := repo.ListProjects(monday, monday.AddDate(0, 0, 7))
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, projects[0].ID, got[0].ID)
+	assert.Equal(t, "リモート併用", got[0].RemoteType)
+	assert.Equal(t, 700000, *got[0].MonthlyPriceTo)
+	assert.ElementsMatch(t, []domain.SkillRef{
+		{Kind: domain.KindLanguage, Name: "Go"},
+		{Kind: domain.KindFramework, Name: "Gin"},
+		{Kind: domain.KindPosition, Name: "SE"},
+	}, got[0].Skills)
+	assert.False(t, got[0].FullRemote)
+	assert.True(t, got[1].FullRemote)
+	assert.Empty(t, got[1].Skills)
+
+	// 案件IDの範囲の受信日の週を返すこと
+	maxID, err := repo.MaxProjectID()
+	require.NoError(t, err)
+	assert.Equal(t, projects[2].ID, maxID)
+	weeks, err := repo.ListProjectWeeks(0, maxID)
+	require.NoError(t, err)
+	require.Len(t, weeks, 2)
+	assert.True(t, monday.Equal(weeks[0]))
+	assert.True(t, monday.AddDate(0, 0, 7).Equal(weeks[1]))
+	weeks, err = repo.ListProjectWeeks(projects[1].ID, maxID)
+	require.NoError(t, err)
+	require.Len(t, weeks, 1)
+	assert.True(t, monday.AddDate(0, 0, 7).Equal(weeks[0]))
+}
+
+func TestRepository_ReplaceWeeks(t *testing.T) {
+	t.Parallel()
+	// テスト用DBの準備
+	db, cleanup, err := mysql.CreateNewTestDB()
+	require.NoError(t, err)
+	defer cleanup()
+
+	err = db.DB.AutoMigrate(
+		model.AnalyticsWeek{},
+		model.AnalyticsSkillWeek{},
+		model.AnalyticsRun{},
+	)
+	require.NoError(t, err)
+
+	repo := New(db.DB)
+	w1 := time.Date(2025, 6, 2, 0, 0, 0, 0, time.Local)
+	w2 := w1.AddDate(0, 0, 7)
+	median := 700000
+
+	// 未実行の場合は0を返すこと
+	last, err := repo.LastRefreshedProjectID()
+	require.NoError(t, err)
+	assert.Zero(t, last)
+
+	totals := []domain.Week{
+		{WeekStart: w1, Projects: 3, Price: domain.PriceStats{Count: 1, Median: &median}, RemoteFull: 1, RemoteUnknown: 2},
+		{WeekStart: w2, Projects: 1, RemoteNone: 1},
+	}
+	skills := []domain.SkillWeek{
+		{WeekStart: w1, Kind: domain.KindLanguage, Name: "Go", Projects: 2},
+		{WeekStart: w1, Kind: domain.KindFramework, Name: "Gin", Projects: 1},
+		{WeekStart: w2, Kind: domain.KindLanguage, Name: "Go", Projects: 1},
+	}
+	require.NoError(t, repo.ReplaceWeeks([]time.Time{w1, w2}, totals, skills))
+
+	weeks, err := repo.ListWeeks(w1, w2.AddDate(0, 0, 7))
+	require.NoError(t, err)
+	require.Len(t, weeks, 2)
+	assert.Equal(t, 3, weeks[0].Projects)
+	assert.Equal(t, 700000, *weeks[0].Price.Median)
+	assert.Nil(t, weeks[1].Price.Median)
+	got, err := repo.ListSkillWeeks(domain.KindLanguage, w1, w2.AddDate(0, 0, 7))
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, "Go", got[0].Name)
+	assert.Equal(t, 2, got[0].Projects)
+
+	// 作り直す週の既存の集計は置き換え、対象外の週は残すこと
+	require.NoError(t, repo.ReplaceWeeks([]time.Time{w1}, []domain.Week{{WeekStart: w1, Projects: 5}}, nil))
+	weeks, err = repo.ListWeeks(w1, w2.AddDate(0, 0, 7))
+	require.NoError(t, err)
+	require.Len(t, weeks, 2)
+	assert.Equal(t, 5, weeks[0].Projects)
+	got, err = repo.ListSkillWeeks(domain.KindLanguage, w1, w2.AddDate(0, 0, 7))
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.True(t, w2.Equal(got[0].WeekStart))
+
+	require.NoError(t, repo.SaveRun(domain.RefreshResult{LastProjectID: 42, Weeks: 1, Projects: 5}, w1, w1))
+	last, err = repo.LastRefreshedProjectID()
+	require.NoError(t, err)
+	assert.Equal(t, uint(42), last)
+
+	require.NoError(t, repo.DeleteAll())
+	weeks, err = repo.ListWeeks(w1, w2.AddDate(0, 0, 7))
+	require.NoError(t, err)
+	assert.Empty(t, weeks)
+}
@@ -0,0 +1,122 @@
+package presentation
+
+import (
+	aga "business/internal/agency/application"
+	"business/internal/agency/domain"
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AgencyController は営業会社の台帳（品質の集計と取り込みのルール）のコントローラーです
+type AgencyController struct {
+	agu aga.UseCaseInterface
+}
+
+// NewAgencyController は営業会社の台帳のコントローラーを作成します
+func NewAgencyController(agu aga.UseCaseInterface) *AgencyController {
+	return &AgencyController{
+		agu: agu,
+	}
+}
+
+type agencySettingsRequest struct {
+	Name *string `json:"name"`
+	Rule *string `json:"rule"` // none / block / mute / prioritize
+	Note *string `json:"note"`
+}
+
+// ListAgencies は営業会社を集計付きで返します
+//
+// クエリパラメータ:
+//
+//	q      ドメイン・表示名の部分一致
+//	rule   none / block / mute / prioritize で絞り込む
+//	sort   emails（既定） / projects / good_rate / duplicate_rate / last_received
+//	limit  返す営業会社の数（既定は50、最大500）
+func (n *AgencyController) ListAgencies(c *gin.Context, ctx context.Context) error {
+	f := domain.Filter{
+		Query: c.Query("q"),
+		Rule:  domain.Rule(c.Query("rule")),
+		Sort:  c.Query("sort"),
+	}
+	if v, err := queryInt(c, "limit"); err != nil {
+		return badRequest(err)
+	} else if v != nil {
+		f.Limit = *v
+	}
+
+	agencies, err := n.agu.List(f)
+	if err != nil {
+		return agencyError(err)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"items": agencies})
+	return nil
+}
+
+// GetAgency はパスの営業会社（ドメインまたはメールアドレス）を差出人付きで返します
+func (n *AgencyController) GetAgency(c *gin.Context, ctx context.Context) error {
+	agency, senders, err := n.agu.Get(c.Param("domain"))
+	if err != nil {
+		return agencyError(err)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"agency": agency, "senders": senders})
+	return nil
+}
+
+// UpdateAgency はパスの営業会社の表示名・ルール・メモを保存します（省略した項目は変更しません）
+func (n *AgencyController) UpdateAgency(c *gin.Context, ctx context.Context) error {
+	req := agencySettingsRequest{}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		return badRequest(err)
+	}
+	s := domain.Settings{
+		Domain: c.Param("domain"),
+		Name:   req.Name,
+		Note:   req.Note,
+	}
+	if req.Rule != nil {
+		rule := domain.Rule(*req.Rule)
+		s.Rule = &rule
+	}
+
+	agency, err := n.agu.Update(s)
+	if err != nil {
+		return agencyError(err)
+	}
+
+	c.JSON(http.StatusOK, agency)
+	return nil
+}
+
+// Refresh は前回の更新以降にメール・案件が保存・更新された営業会社の集計を更新します（クエリパラメータ full=true ですべて）
+func (n *AgencyController) Refresh(c *gin.Context, ctx context.Context) error {
+	full, err := queryBool(c, "full")
+	if err != nil {
+		return badRequest(err)
+	}
+
+	result, err := n.agu.Refresh(full != nil && *full)
+	if err != nil {
+		return agencyError(err)
+	}
+
+	c.JSON(http.StatusOK, result)
+	return nil
+}
+
+// agencyError は営業会社の台帳のエラーをステータスコードに対応するエラーに変換します
+func agencyError(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrInvalidAgency):
+		return badRequest(err)
+	case errors.Is(err, domain.ErrAgencyNotFound):
+		return notFound(err)
+	default:
+		return err
+	}
+}
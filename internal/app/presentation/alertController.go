@@ -0,0 +1,177 @@
+package presentation
+
+import (
+	aa "business/internal/alert/application"
+	"business/internal/alert/domain"
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AlertController は保存した検索条件と新着案件の通知のコントローラーです
+type AlertController struct {
+	au aa.UseCaseInterface
+}
+
+// NewAlertController は保存した検索条件と新着案件の通知のコントローラーを作成します
+func NewAlertController(au aa.UseCaseInterface) *AlertController {
+	return &AlertController{
+		au: au,
+	}
+}
+
+type savedSearchRequest struct {
+	UserID        string   `json:"user_id" binding:"required"`
+	Name          string   `json:"name" binding:"required"`
+	Languages     []string `json:"languages"`
+	LanguageMatch string   `json:"language_match"` // any（既定） / all
+	Frameworks    []string `json:"frameworks"`
+	PriceMin      *int     `json:"price_min"`
+	PriceMax      *int     `json:"price_max"`
+	RemoteTypes   []string `json:"remote_types"`
+	Prefectures   []string `json:"prefectures"`
+	Enabled       *bool    `json:"enabled"` // 省略時は true
+}
+
+// toSearch はリクエストボディを検索条件に変換します
+func (r savedSearchRequest) toSearch() domain.SavedSearch {
+	enabled := true
+	if r.Enabled != nil {
+		enabled = *r.Enabled
+	}
+	return domain.SavedSearch{
+		UserID:        r.UserID,
+		Name:          r.Name,
+		Languages:     r.Languages,
+		LanguageMatch: r.LanguageMatch,
+		Frameworks:    r.Frameworks,
+		PriceMin:      r.PriceMin,
+		PriceMax:      r.PriceMax,
+		RemoteTypes:   r.RemoteTypes,
+		Prefectures:   r.Prefectures,
+		Enabled:       enabled,
+	}
+}
+
+// ListSearches は保存した検索条件をID順に返します（クエリパラメータ user で利用者を絞り込み）
+func (n *AlertController) ListSearches(c *gin.Context, ctx context.Context) error {
+	searches, err := n.au.ListSearches(c.Query("user"))
+	if err != nil {
+		return alertError(err)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"items": searches})
+	return nil
+}
+
+// GetSearch はパスの検索条件を返します
+func (n *AlertController) GetSearch(c *gin.Context, ctx context.Context) error {
+	id, err := groupID(c)
+	if err != nil {
+		return badRequest(err)
+	}
+
+	search, err := n.au.GetSearch(id)
+	if err != nil {
+		return alertError(err)
+	}
+
+	c.JSON(http.StatusOK, search)
+	return nil
+}
+
+// CreateSearch はリクエストボディの検索条件を保存します（保存した時点より後に保存した案件が通知の対象）
+func (n *AlertController) CreateSearch(c *gin.Context, ctx context.Context) error {
+	req := savedSearchRequest{}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		return badRequest(err)
+	}
+
+	search, err := n.au.CreateSearch(req.toSearch())
+	if err != nil {
+		return alertError(err)
+	}
+
+	c.JSON(http.StatusCreated, search)
+	return nil
+}
+
+// UpdateSearch はパスの検索条件をリクエストボディの内容に置き換えます
+func (n *AlertController) UpdateSearch(c *gin.Context, ctx context.Context) error {
+	id, err := groupID(c)
+	if err != nil {
+		return badRequest(err)
+	}
+	req := savedSearchRequest{}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		return badRequest(err)
+	}
+	s := req.toSearch()
+	s.ID = id
+
+	search, err := n.au.UpdateSearch(s)
+	if err != nil {
+		return alertError(err)
+	}
+
+	c.JSON(http.StatusOK, search)
+	return nil
+}
+
+// DeleteSearch はパスの検索条件を通知ごと削除します
+func (n *AlertController) DeleteSearch(c *gin.Context, ctx context.Context) error {
+	id, err := groupID(c)
+	if err != nil {
+		return badRequest(err)
+	}
+
+	if err := n.au.DeleteSearch(id); err != nil {
+		return alertError(err)
+	}
+
+	c.Status(http.StatusNoContent)
+	return nil
+}
+
+// ListNotifications は通知を新しい順に返します
+//
+// クエリパラメータ:
+//
+//	user    利用者で絞り込む
+//	status  pending / sent / failed で絞り込む
+//	limit   返す通知数（既定は50、最大200）
+func (n *AlertController) ListNotifications(c *gin.Context, ctx context.Context) error {
+	f := domain.NotificationFilter{
+		UserID: c.Query("user"),
+		Status: domain.NotificationStatus(c.Query("status")),
+	}
+	if v, err := queryInt(c, "limit"); err != nil {
+		return badRequest(err)
+	} else if v != nil {
+		f.Limit = *v
+	}
+
+	notifications, err := n.au.ListNotifications(f)
+	if err != nil {
+		return alertError(err)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"items": notifications})
+	return nil
+}
+
+// alertError は通知のエラーをステータスコードに対応するエラーに変換します
+func alertError(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrInvalidSearch):
+		return badRequest(err)
+	case errors.Is(err, domain.ErrSearchNotFound):
+		return notFound(err)
+	case errors.Is(err, domain.ErrConflict):
+		return conflict(err)
+	default:
+		return err
+	}
+}
@@ -0,0 +1,172 @@
+package presentation
+
+import (
+	ana "business/internal/analytics/application"
+	"business/internal/analytics/domain"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AnalyticsController は案件の市場動向（スキルの需要・単価・リモート比率の推移）のコントローラーです
+type AnalyticsController struct {
+	anu ana.UseCaseInterface
+}
+
+// NewAnalyticsController は案件の市場動向のコントローラーを作成します
+func NewAnalyticsController(anu ana.UseCaseInterface) *AnalyticsController {
+	return &AnalyticsController{
+		anu: anu,
+	}
+}
+
+// Skills はスキルごとの週の案件数の推移を返します
+//
+// クエリパラメータ（/analytics/prices・/analytics/remote・/analytics/rising も同じ）:
+//
+//	kind       language（既定） / framework / position
+//	skills     スキル名（カンマ区切り。未指定は期間内の案件数の多い順に limit 件）
+//	weeks      返す週の数（既定は12、最大104）
+//	until      この日を含む週まで返す（YYYY-MM-DD。未指定は今週）
+//	limit      スキルを指定しない場合に返すスキル数（既定は10、最大50）
+//	window     伸びているスキルで比べる週の数（既定は4、最大26）
+//	min_count  伸びているスキルに含める直近の案件数の下限（既定は3）
+//	format     csv を指定すると CSV で返す
+func (n *AnalyticsController) Skills(c *gin.Context, ctx context.Context) error {
+	f, err := analyticsFilter(c)
+	if err != nil {
+		return badRequest(err)
+	}
+
+	series, err := n.anu.SkillTrends(f, time.Now())
+	if err != nil {
+		return analyticsError(err)
+	}
+
+	if c.Query("format") == "csv" {
+		return writeCSV(c, "analytics_skills.csv", domain.SkillRecords(series))
+	}
+	c.JSON(http.StatusOK, gin.H{"items": series})
+	return nil
+}
+
+// Prices は全体とスキルごとの週の単価（税別の月額）の分布の推移を返します
+func (n *AnalyticsController) Prices(c *gin.Context, ctx context.Context) error {
+	f, err := analyticsFilter(c)
+	if err != nil {
+		return badRequest(err)
+	}
+
+	series, err := n.anu.PriceTrends(f, time.Now())
+	if err != nil {
+		return analyticsError(err)
+	}
+
+	if c.Query("format") == "csv" {
+		return writeCSV(c, "analytics_prices.csv", domain.PriceRecords(series))
+	}
+	c.JSON(http.StatusOK, gin.H{"items": series})
+	return nil
+}
+
+// Remote は週のリモート区分の内訳と比率の推移を返します
+func (n *AnalyticsController) Remote(c *gin.Context, ctx context.Context) error {
+	f, err := analyticsFilter(c)
+	if err != nil {
+		return badRequest(err)
+	}
+
+	points, err := n.anu.RemoteTrends(f, time.Now())
+	if err != nil {
+		return analyticsError(err)
+	}
+
+	if c.Query("format") == "csv" {
+		return writeCSV(c, "analytics_remote.csv", domain.RemoteRecords(points))
+	}
+	c.JSON(http.StatusOK, gin.H{"items": points})
+	return nil
+}
+
+// Rising は直近 window 週にその前の window 週より案件数が伸びているスキルを返します
+func (n *AnalyticsController) Rising(c *gin.Context, ctx context.Context) error {
+	f, err := analyticsFilter(c)
+	if err != nil {
+		return badRequest(err)
+	}
+
+	skills, err := n.anu.RisingSkills(f, time.Now())
+	if err != nil {
+		return analyticsError(err)
+	}
+
+	if c.Query("format") == "csv" {
+		return writeCSV(c, "analytics_rising.csv", domain.RisingRecords(skills))
+	}
+	c.JSON(http.StatusOK, gin.H{"items": skills})
+	return nil
+}
+
+// Refresh は前回の更新以降に保存した案件の週の集計を作り直します（クエリパラメータ full=true ですべての週）
+func (n *AnalyticsController) Refresh(c *gin.Context, ctx context.Context) error {
+	full, err := queryBool(c, "full")
+	if err != nil {
+		return badRequest(err)
+	}
+
+	result, err := n.anu.Refresh(full != nil && *full)
+	if err != nil {
+		return analyticsError(err)
+	}
+
+	c.JSON(http.StatusOK, result)
+	return nil
+}
+
+// analyticsFilter はクエリパラメータを集計の条件に変換します
+func analyticsFilter(c *gin.Context) (domain.Filter, error) {
+	f := domain.Filter{
+		Kind:  c.Query("kind"),
+		Names: splitQuery(c.Query("skills")),
+	}
+	if v, err := queryDate(c, "until"); err != nil {
+		return f, err
+	} else if v != nil {
+		f.Until = *v
+	}
+	for key, dst := range map[string]*int{"weeks": &f.Weeks, "limit": &f.Limit, "window": &f.Window, "min_count": &f.MinCount} {
+		v, err := queryInt(c, key)
+		if err != nil {
+			return f, err
+		}
+		if v != nil {
+			*dst = *v
+		}
+	}
+	return f, nil
+}
+
+// writeCSV は集計の行を CSV のファイルとして返します
+func writeCSV(c *gin.Context, filename string, records [][]string) error {
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	c.Status(http.StatusOK)
+	w := csv.NewWriter(c.Writer)
+	if err := w.WriteAll(records); err != nil {
+		return fmt.Errorf("CSV の書き出しエラー: %w", err)
+	}
+	return nil
+}
+
+// analyticsError は集計のエラーをステータスコードに対応するエラーに変換します
+func analyticsError(err error) error {
+	if errors.Is(err, domain.ErrInvalidFilter) {
+		return badRequest(err)
+	}
+	return err
+}
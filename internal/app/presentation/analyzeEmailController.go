@@ -62,13 +62,11 @@ func (n *AnalyzeEmailController) SaveEmailAnalysisResult(c *gin.Context, ctx con
 	}
 
 	fmt.Printf("DBへの保存処理を開始します。")
-	for _, email := range analysisResults {
-		err = n.ea.SaveEmailAnalysisResult(email)
-		if err != nil {
-			fmt.Printf("メール保存エラー: %v \n", err)
-			return err
-		}
+	saved, err := n.ea.SaveEmailAnalysisResults(analysisResults)
+	if err != nil {
+		fmt.Printf("メール保存エラー: %v （%d通保存済み）\n", err, saved)
+		return err
 	}
-	fmt.Printf("DBへの保存処理が完了しました。 \n")
+	fmt.Printf("DBへの保存処理が完了しました。（%d通） \n", saved)
 	return nil
 }
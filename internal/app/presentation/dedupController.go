@@ -0,0 +1,42 @@
+package presentation
+
+import (
+	da "business/internal/dedup/application"
+	"business/internal/dedup/domain"
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DedupController は案件の重複グループ（複数の営業会社から届いた同じ案件）のコントローラーです
+type DedupController struct {
+	du da.UseCaseInterface
+}
+
+// NewDedupController は案件の重複グループのコントローラーを作成します
+func NewDedupController(du da.UseCaseInterface) *DedupController {
+	return &DedupController{
+		du: du,
+	}
+}
+
+// GetCluster は重複グループに含まれる案件を、営業会社・単価付きで受信日の古い順に返します
+func (n *DedupController) GetCluster(c *gin.Context, ctx context.Context) error {
+	id, err := groupID(c)
+	if err != nil {
+		return badRequest(err)
+	}
+
+	cluster, err := n.du.GetCluster(id)
+	if err != nil {
+		if errors.Is(err, domain.ErrClusterNotFound) {
+			return notFound(err)
+		}
+		return err
+	}
+
+	c.JSON(http.StatusOK, cluster)
+	return nil
+}
@@ -0,0 +1,261 @@
+package presentation
+
+import (
+	da "business/internal/dictionary/application"
+	"business/internal/dictionary/domain"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DictionaryController は用語辞書（キーワード・ポジション・業務種別のグループ）の管理コントローラーです
+type DictionaryController struct {
+	du da.UseCaseInterface
+	cu da.ClusterUseCaseInterface
+}
+
+// NewDictionaryController は用語辞書の管理コントローラーを作成します
+func NewDictionaryController(du da.UseCaseInterface, cu da.ClusterUseCaseInterface) *DictionaryController {
+	return &DictionaryController{
+		du: du,
+		cu: cu,
+	}
+}
+
+type mergeGroupRequest struct {
+	Into uint `json:"into" binding:"required"`
+}
+
+type splitGroupRequest struct {
+	Name    string   `json:"name" binding:"required"`
+	Aliases []string `json:"aliases"`
+}
+
+type aliasRequest struct {
+	Word string `json:"word" binding:"required"`
+}
+
+type renameGroupRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
+// ListGroups は種類ごとのグループを別名・案件数付きで返します
+// クエリパラメータ q で正規名・別名を部分一致で絞り込めます。
+func (n *DictionaryController) ListGroups(c *gin.Context, ctx context.Context) error {
+	groups, err := n.du.ListGroups(domain.Kind(c.Param("kind")), c.Query("q"))
+	if err != nil {
+		return dictionaryError(err)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"items": groups})
+	return nil
+}
+
+// MergeGroup はパスのグループをリクエストボディの into のグループに統合します
+func (n *DictionaryController) MergeGroup(c *gin.Context, ctx context.Context) error {
+	id, err := groupID(c)
+	if err != nil {
+		return badRequest(err)
+	}
+	req := mergeGroupRequest{}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		return badRequest(err)
+	}
+
+	group, err := n.du.MergeGroups(domain.Kind(c.Param("kind")), id, req.Into, actor(c))
+	if err != nil {
+		return dictionaryError(err)
+	}
+
+	c.JSON(http.StatusOK, group)
+	return nil
+}
+
+// SplitGroup はパスのグループから aliases を切り出し、name を正規名とする新しいグループを作成します
+func (n *DictionaryController) SplitGroup(c *gin.Context, ctx context.Context) error {
+	id, err := groupID(c)
+	if err != nil {
+		return badRequest(err)
+	}
+	req := splitGroupRequest{}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		return badRequest(err)
+	}
+
+	group, err := n.du.SplitGroup(domain.Kind(c.Param("kind")), id, req.Name, req.Aliases, actor(c))
+	if err != nil {
+		return dictionaryError(err)
+	}
+
+	c.JSON(http.StatusCreated, group)
+	return nil
+}
+
+// AddAlias はパスのグループに別名を追加します
+func (n *DictionaryController) AddAlias(c *gin.Context, ctx context.Context) error {
+	id, err := groupID(c)
+	if err != nil {
+		return badRequest(err)
+	}
+	req := aliasRequest{}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		return badRequest(err)
+	}
+
+	group, err := n.du.AddAlias(domain.Kind(c.Param("kind")), id, req.Word, actor(c))
+	if err != nil {
+		return dictionaryError(err)
+	}
+
+	c.JSON(http.StatusOK, group)
+	return nil
+}
+
+// RemoveAlias はパスのグループから別名を削除します
+func (n *DictionaryController) RemoveAlias(c *gin.Context, ctx context.Context) error {
+	id, err := groupID(c)
+	if err != nil {
+		return badRequest(err)
+	}
+
+	group, err := n.du.RemoveAlias(domain.Kind(c.Param("kind")), id, c.Param("word"), actor(c))
+	if err != nil {
+		return dictionaryError(err)
+	}
+
+	c.JSON(http.StatusOK, group)
+	return nil
+}
+
+// RenameGroup はパスのグループの正規名を変更します
+func (n *DictionaryController) RenameGroup(c *gin.Context, ctx context.Context) error {
+	id, err := groupID(c)
+	if err != nil {
+		return badRequest(err)
+	}
+	req := renameGroupRequest{}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		return badRequest(err)
+	}
+
+	group, err := n.du.RenameGroup(domain.Kind(c.Param("kind")), id, req.Name, actor(c))
+	if err != nil {
+		return dictionaryError(err)
+	}
+
+	c.JSON(http.StatusOK, group)
+	return nil
+}
+
+// AuditLogs は用語辞書の操作履歴を新しい順に返します
+// クエリパラメータ kind で種類、limit で件数を指定できます。
+func (n *DictionaryController) AuditLogs(c *gin.Context, ctx context.Context) error {
+	limit := 0
+	if v := c.Query("limit"); v != "" {
+		l, err := strconv.Atoi(v)
+		if err != nil || l < 0 {
+			return badRequest(fmt.Errorf("limit は0以上の整数で指定してください"))
+		}
+		limit = l
+	}
+
+	logs, err := n.du.AuditLogs(domain.Kind(c.Query("kind")), limit)
+	if err != nil {
+		return dictionaryError(err)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"items": logs})
+	return nil
+}
+
+// ListProposals はキーワードグループの統合提案（レビューキュー）を返します
+// クエリパラメータ status で状態（既定は pending、all ですべて）、limit で件数を指定できます。
+func (n *DictionaryController) ListProposals(c *gin.Context, ctx context.Context) error {
+	status := domain.ProposalStatus(c.DefaultQuery("status", string(domain.ProposalPending)))
+	if status == "all" {
+		status = ""
+	}
+	limit := 0
+	if v := c.Query("limit"); v != "" {
+		l, err := strconv.Atoi(v)
+		if err != nil || l < 0 {
+			return badRequest(fmt.Errorf("limit は0以上の整数で指定してください"))
+		}
+		limit = l
+	}
+
+	proposals, err := n.cu.ListProposals(status, limit)
+	if err != nil {
+		return dictionaryError(err)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"items": proposals})
+	return nil
+}
+
+// AcceptProposal は統合提案を承認し、グループを統合します
+func (n *DictionaryController) AcceptProposal(c *gin.Context, ctx context.Context) error {
+	id, err := groupID(c)
+	if err != nil {
+		return badRequest(err)
+	}
+
+	proposal, err := n.cu.AcceptProposal(id, actor(c))
+	if err != nil {
+		return dictionaryError(err)
+	}
+
+	c.JSON(http.StatusOK, proposal)
+	return nil
+}
+
+// RejectProposal は統合提案を却下します
+func (n *DictionaryController) RejectProposal(c *gin.Context, ctx context.Context) error {
+	id, err := groupID(c)
+	if err != nil {
+		return badRequest(err)
+	}
+
+	proposal, err := n.cu.RejectProposal(id, actor(c))
+	if err != nil {
+		return dictionaryError(err)
+	}
+
+	c.JSON(http.StatusOK, proposal)
+	return nil
+}
+
+// groupID はパスのグループID（統合提案の場合は提案ID、重複グループの場合は重複グループID、エンジニアの場合はプロフィールID、案件の場合は案件ID）を返します
+func groupID(c *gin.Context) (uint, error) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil || id == 0 {
+		return 0, fmt.Errorf("id は1以上の整数で指定してください")
+	}
+	return uint(id), nil
+}
+
+// actor は操作者を返します（X-Actor ヘッダー、未指定の場合は api）
+func actor(c *gin.Context) string {
+	if v := c.GetHeader("X-Actor"); v != "" {
+		return v
+	}
+	return da.ActorAPI
+}
+
+// dictionaryError は用語辞書のエラーをステータスコードに対応するエラーに変換します
+func dictionaryError(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrInvalidOperation):
+		return badRequest(err)
+	case errors.Is(err, domain.ErrGroupNotFound), errors.Is(err, domain.ErrAliasNotFound), errors.Is(err, domain.ErrProposalNotFound):
+		return notFound(err)
+	case errors.Is(err, domain.ErrConflict), errors.Is(err, domain.ErrProposalReviewed):
+		return conflict(err)
+	default:
+		return err
+	}
+}
@@ -0,0 +1,20 @@
+package presentation
+
+import (
+	"fmt"
+)
+
+// badRequest はリクエスト不正を表すエラーに変換します（ルーターで400に変換されます）
+func badRequest(err error) error {
+	return fmt.Errorf("BadRequest: %w", err)
+}
+
+// notFound は対象が存在しないことを表すエラーに変換します（ルーターで404に変換されます）
+func notFound(err error) error {
+	return fmt.Errorf("NotFound: %w", err)
+}
+
+// conflict は既存のデータと競合することを表すエラーに変換します（ルーターで409に変換されます）
+func conflict(err error) error {
+	return fmt.Errorf("Conflict: %w", err)
+}
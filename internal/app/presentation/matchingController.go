@@ -0,0 +1,238 @@
+package presentation
+
+import (
+	ma "business/internal/matching/application"
+	"business/internal/matching/domain"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MatchingController はエンジニアのスキルプロフィールと案件・人材のマッチングのコントローラーです
+type MatchingController struct {
+	mu ma.UseCaseInterface
+}
+
+// NewMatchingController はエンジニアのプロフィールと案件のマッチングのコントローラーを作成します
+func NewMatchingController(mu ma.UseCaseInterface) *MatchingController {
+	return &MatchingController{
+		mu: mu,
+	}
+}
+
+type profileRequest struct {
+	Name                 string                  `json:"name" binding:"required"`
+	Skills               []domain.Skill          `json:"skills"`
+	DesiredPrice         *int                    `json:"desired_price"`
+	RemotePreference     domain.RemotePreference `json:"remote_preference"`
+	AvailableFrom        string                  `json:"available_from"` // YYYY-MM-DD（空は即日）
+	PreferredPrefectures []string                `json:"preferred_prefectures"`
+	Note                 string                  `json:"note"`
+}
+
+// toProfile はリクエストボディをプロフィールに変換します
+func (r profileRequest) toProfile() (domain.Profile, error) {
+	p := domain.Profile{
+		Name:                 r.Name,
+		Skills:               r.Skills,
+		DesiredPrice:         r.DesiredPrice,
+		RemotePreference:     r.RemotePreference,
+		PreferredPrefectures: r.PreferredPrefectures,
+		Note:                 r.Note,
+	}
+	if r.AvailableFrom != "" {
+		t, err := time.ParseInLocation("2006-01-02", r.AvailableFrom, time.Local)
+		if err != nil {
+			return p, fmt.Errorf("available_from の形式が不正です。YYYY-MM-DD で指定してください")
+		}
+		p.AvailableFrom = &t
+	}
+	return p, nil
+}
+
+// ListProfiles はエンジニアのプロフィールをID順に返します
+func (n *MatchingController) ListProfiles(c *gin.Context, ctx context.Context) error {
+	profiles, err := n.mu.ListProfiles()
+	if err != nil {
+		return matchingError(err)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"items": profiles})
+	return nil
+}
+
+// GetProfile はパスのプロフィールを返します
+func (n *MatchingController) GetProfile(c *gin.Context, ctx context.Context) error {
+	id, err := groupID(c)
+	if err != nil {
+		return badRequest(err)
+	}
+
+	profile, err := n.mu.GetProfile(id)
+	if err != nil {
+		return matchingError(err)
+	}
+
+	c.JSON(http.StatusOK, profile)
+	return nil
+}
+
+// CreateProfile はリクエストボディのプロフィールを登録します
+func (n *MatchingController) CreateProfile(c *gin.Context, ctx context.Context) error {
+	req := profileRequest{}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		return badRequest(err)
+	}
+	p, err := req.toProfile()
+	if err != nil {
+		return badRequest(err)
+	}
+
+	profile, err := n.mu.CreateProfile(p)
+	if err != nil {
+		return matchingError(err)
+	}
+
+	c.JSON(http.StatusCreated, profile)
+	return nil
+}
+
+// UpdateProfile はパスのプロフィールをリクエストボディの内容に置き換えます（スキルも置き換え）
+func (n *MatchingController) UpdateProfile(c *gin.Context, ctx context.Context) error {
+	id, err := groupID(c)
+	if err != nil {
+		return badRequest(err)
+	}
+	req := profileRequest{}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		return badRequest(err)
+	}
+	p, err := req.toProfile()
+	if err != nil {
+		return badRequest(err)
+	}
+	p.ID = id
+
+	profile, err := n.mu.UpdateProfile(p)
+	if err != nil {
+		return matchingError(err)
+	}
+
+	c.JSON(http.StatusOK, profile)
+	return nil
+}
+
+// DeleteProfile はパスのプロフィールを削除します
+func (n *MatchingController) DeleteProfile(c *gin.Context, ctx context.Context) error {
+	id, err := groupID(c)
+	if err != nil {
+		return badRequest(err)
+	}
+
+	if err := n.mu.DeleteProfile(id); err != nil {
+		return matchingError(err)
+	}
+
+	c.Status(http.StatusNoContent)
+	return nil
+}
+
+// MatchProjects はパスのプロフィールに対する案件の適合度を採点し、高い順に採点の内訳付きで返します
+//
+// クエリパラメータ:
+//
+//	days            受信日がこの日数以内の案件を対象にする（既定は14、最大180）
+//	limit           返す案件数（既定は20、最大200）
+//	min_score       適合度（0〜100）がこの値未満の案件は返さない
+//	include_closed  true の場合、募集終了・期限切れの案件も対象にする
+func (n *MatchingController) MatchProjects(c *gin.Context, ctx context.Context) error {
+	id, err := groupID(c)
+	if err != nil {
+		return badRequest(err)
+	}
+	opts, err := matchOptions(c)
+	if err != nil {
+		return badRequest(err)
+	}
+	if v, err := queryBool(c, "include_closed"); err != nil {
+		return badRequest(err)
+	} else if v != nil {
+		opts.IncludeClosed = *v
+	}
+
+	result, err := n.mu.MatchProjects(id, opts, time.Now())
+	if err != nil {
+		return matchingError(err)
+	}
+
+	c.JSON(http.StatusOK, result)
+	return nil
+}
+
+// MatchCandidates はパスの案件（email_projects.id）に対する人材メールの適合度を採点し、高い順に採点の内訳付きで返します
+//
+// クエリパラメータ:
+//
+//	days       受信日がこの日数以内の人材メールを対象にする（既定は30、最大180）
+//	limit      返す人材数（既定は20、最大200）
+//	min_score  適合度（0〜100）がこの値未満の人材は返さない
+func (n *MatchingController) MatchCandidates(c *gin.Context, ctx context.Context) error {
+	id, err := groupID(c)
+	if err != nil {
+		return badRequest(err)
+	}
+	opts, err := matchOptions(c)
+	if err != nil {
+		return badRequest(err)
+	}
+
+	result, err := n.mu.MatchCandidates(id, opts, time.Now())
+	if err != nil {
+		return matchingError(err)
+	}
+
+	c.JSON(http.StatusOK, result)
+	return nil
+}
+
+// matchOptions はクエリパラメータの days・limit・min_score をマッチングの条件に変換します
+func matchOptions(c *gin.Context) (domain.MatchOptions, error) {
+	opts := domain.MatchOptions{}
+	if v, err := queryInt(c, "days"); err != nil {
+		return opts, err
+	} else if v != nil {
+		opts.Days = *v
+	}
+	if v, err := queryInt(c, "limit"); err != nil {
+		return opts, err
+	} else if v != nil {
+		opts.Limit = *v
+	}
+	if v := c.Query("min_score"); v != "" {
+		s, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return opts, fmt.Errorf("min_score は数値で指定してください")
+		}
+		opts.MinScore = s
+	}
+	return opts, nil
+}
+
+// matchingError はマッチングのエラーをステータスコードに対応するエラーに変換します
+func matchingError(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrInvalidProfile), errors.Is(err, domain.ErrInvalidOptions):
+		return badRequest(err)
+	case errors.Is(err, domain.ErrProfileNotFound), errors.Is(err, domain.ErrProjectNotFound):
+		return notFound(err)
+	case errors.Is(err, domain.ErrConflict):
+		return conflict(err)
+	default:
+		return err
+	}
+}
@@ -0,0 +1,219 @@
+package presentation
+
+import (
+	ea "business/internal/emailstore/application"
+	"business/internal/emailstore/domain"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ProjectController は保存済み案件の検索・一覧のコントローラーです
+type ProjectController struct {
+	pq ea.ProjectQueryUseCaseInterface
+}
+
+// NewProjectController は案件一覧コントローラーを作成します
+func NewProjectController(pq ea.ProjectQueryUseCaseInterface) *ProjectController {
+	return &ProjectController{
+		pq: pq,
+	}
+}
+
+// ListProjects はクエリパラメータの条件で案件を検索し、1ページ分を返します
+//
+// クエリパラメータ:
+//
+//	q                              件名・本文・案件名の全文検索（BOOLEAN MODEの検索式）
+//	from, to                       受信日（YYYY-MM-DD。to の日は含まない）
+//	category                       メール区分（案件 / 人材）
+//	price_min, price_max           単価の範囲（円）
+//	languages, frameworks          技術キーワード（カンマ区切り。表記ゆれを含めて検索）
+//	language_match, framework_match any（いずれか） / all（すべて）
+//	positions, work_types          ポジション・業務種別（カンマ区切り）
+//	remote_types                   リモート区分（カンマ区切り）
+//	location, sender               勤務場所・差出人（部分一致）
+//	prefectures                    都道府県（カンマ区切り。略称も可）
+//	station, radius_km             最寄り駅と距離（km。駅から radius_km 以内の拠点がある案件）
+//	is_read, is_good, is_bad       true / false
+//	collapse                       true の場合、重複グループ（他の営業会社から届いた同じ案件）は代表の案件だけを返す
+//	statuses                       応募状況（カンマ区切り。未対応 / 応募済 / 面談 / 見送り / 決定）
+//	lifecycle                      募集状況（カンマ区切り。open / closed / expired / unknown）
+//	include_archived               true の場合、アーカイブした案件も返す（既定では除外）
+//	low_confidence, min_confidence 低信頼度の値の扱い（hide / highlight）と閾値
+//	sort                           received_desc / received_asc / price_desc / price_asc / relevance
+//	cursor, limit                  ページ送り
+func (n *ProjectController) ListProjects(c *gin.Context, ctx context.Context) error {
+	q, err := parseProjectQuery(c)
+	if err != nil {
+		return badRequest(err)
+	}
+
+	page, err := n.pq.SearchProjects(q)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidProjectQuery) {
+			return badRequest(err)
+		}
+		return err
+	}
+
+	c.JSON(http.StatusOK, page)
+	return nil
+}
+
+// SearchProjects は件名・本文・案件名を全文検索し、関連度順に1ページ分を返します
+// q は必須です。その他のクエリパラメータは ListProjects と同じです。
+func (n *ProjectController) SearchProjects(c *gin.Context, ctx context.Context) error {
+	q, err := parseProjectQuery(c)
+	if err != nil {
+		return badRequest(err)
+	}
+
+	page, err := n.pq.SearchText(q)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidProjectQuery) {
+			return badRequest(err)
+		}
+		return err
+	}
+
+	c.JSON(http.StatusOK, page)
+	return nil
+}
+
+// parseProjectQuery はクエリパラメータを検索条件に変換します
+func parseProjectQuery(c *gin.Context) (domain.ProjectQuery, error) {
+	q := domain.ProjectQuery{
+		Text:           c.Query("q"),
+		Category:       c.Query("category"),
+		Languages:      splitQuery(c.Query("languages")),
+		LanguageMatch:  c.Query("language_match"),
+		Frameworks:     splitQuery(c.Query("frameworks")),
+		FrameworkMatch: c.Query("framework_match"),
+		Positions:      splitQuery(c.Query("positions")),
+		WorkTypes:      splitQuery(c.Query("work_types")),
+		RemoteTypes:    splitQuery(c.Query("remote_types")),
+		WorkLocation:   c.Query("location"),
+		Prefectures:    splitQuery(c.Query("prefectures")),
+		Station:        c.Query("station"),
+		Sender:         c.Query("sender"),
+		LowConfidence:  c.Query("low_confidence"),
+		Sort:           c.Query("sort"),
+		Cursor:         c.Query("cursor"),
+	}
+	for _, status := range splitQuery(c.Query("statuses")) {
+		q.Statuses = append(q.Statuses, domain.ApplicationStatus(status))
+	}
+	for _, status := range splitQuery(c.Query("lifecycle")) {
+		q.Lifecycles = append(q.Lifecycles, domain.LifecycleStatus(status))
+	}
+
+	var err error
+	if q.ReceivedFrom, err = queryDate(c, "from"); err != nil {
+		return q, err
+	}
+	if q.ReceivedTo, err = queryDate(c, "to"); err != nil {
+		return q, err
+	}
+	if q.StartFrom, err = queryDate(c, "start_from"); err != nil {
+		return q, err
+	}
+	if q.StartTo, err = queryDate(c, "start_to"); err != nil {
+		return q, err
+	}
+	if q.PriceMin, err = queryInt(c, "price_min"); err != nil {
+		return q, err
+	}
+	if q.PriceMax, err = queryInt(c, "price_max"); err != nil {
+		return q, err
+	}
+	if q.IsRead, err = queryBool(c, "is_read"); err != nil {
+		return q, err
+	}
+	if q.IsGood, err = queryBool(c, "is_good"); err != nil {
+		return q, err
+	}
+	if q.IsBad, err = queryBool(c, "is_bad"); err != nil {
+		return q, err
+	}
+	collapse, err := queryBool(c, "collapse")
+	if err != nil {
+		return q, err
+	}
+	q.Collapse = collapse != nil && *collapse
+	includeArchived, err := queryBool(c, "include_archived")
+	if err != nil {
+		return q, err
+	}
+	q.IncludeArchived = includeArchived != nil && *includeArchived
+
+	limit, err := queryInt(c, "limit")
+	if err != nil {
+		return q, err
+	}
+	if limit != nil {
+		q.Limit = *limit
+	}
+
+	if v := c.Query("radius_km"); v != "" {
+		if q.RadiusKm, err = strconv.ParseFloat(v, 64); err != nil {
+			return q, fmt.Errorf("radius_km は数値で指定してください: %w", err)
+		}
+	}
+	if v := c.Query("min_confidence"); v != "" {
+		if q.MinConfidence, err = strconv.ParseFloat(v, 64); err != nil {
+			return q, fmt.Errorf("min_confidence は数値で指定してください: %w", err)
+		}
+	}
+
+	return q, nil
+}
+
+func splitQuery(v string) []string {
+	if v == "" {
+		return nil
+	}
+	return strings.Split(v, ",")
+}
+
+func queryDate(c *gin.Context, key string) (*time.Time, error) {
+	v := c.Query(key)
+	if v == "" {
+		return nil, nil
+	}
+	t, err := time.ParseInLocation("2006-01-02", v, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("%s の形式が不正です。YYYY-MM-DD で指定してください", key)
+	}
+	return &t, nil
+}
+
+func queryInt(c *gin.Context, key string) (*int, error) {
+	v := c.Query(key)
+	if v == "" {
+		return nil, nil
+	}
+	i, err := strconv.Atoi(v)
+	if err != nil {
+		return nil, fmt.Errorf("%s は整数で指定してください", key)
+	}
+	return &i, nil
+}
+
+func queryBool(c *gin.Context, key string) (*bool, error) {
+	v := c.Query(key)
+	if v == "" {
+		return nil, nil
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return nil, fmt.Errorf("%s は true / false で指定してください", key)
+	}
+	return &b, nil
+}
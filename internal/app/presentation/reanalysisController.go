@@ -0,0 +1,127 @@
+package presentation
+
+import (
+	ra "business/internal/reanalysis/application"
+	"business/internal/reanalysis/domain"
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReanalysisController は保存済みメールの再解析とリビジョン管理のコントローラーです
+type ReanalysisController struct {
+	ra ra.UseCaseInterface
+}
+
+// NewReanalysisController は再解析コントローラーを作成します
+func NewReanalysisController(ra ra.UseCaseInterface) *ReanalysisController {
+	return &ReanalysisController{
+		ra: ra,
+	}
+}
+
+type reanalyzeRequest struct {
+	From            string   `json:"from"` // 受信日FROM（例: 2025-06-01）
+	To              string   `json:"to"`   // 受信日TO（例: 2025-07-01。この日を含まない）
+	Category        string   `json:"category"`
+	AnalysisVersion string   `json:"analysis_version"`
+	GmailIDs        []string `json:"gmail_ids"`
+	Limit           int      `json:"limit"`
+	Promote         bool     `json:"promote"`
+}
+
+type promoteRequest struct {
+	Revision uint `json:"revision" binding:"required"`
+}
+
+// Reanalyze は条件に一致する保存済みメールを再解析します
+func (n *ReanalysisController) Reanalyze(c *gin.Context, ctx context.Context) error {
+	req := reanalyzeRequest{}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		return badRequest(err)
+	}
+
+	cond, err := req.toCondition()
+	if err != nil {
+		return badRequest(err)
+	}
+
+	summary, err := n.ra.Reanalyze(ctx, cond)
+	if err != nil {
+		return err
+	}
+
+	c.JSON(http.StatusOK, summary)
+	return nil
+}
+
+// ListRevisions はGメールIDの解析リビジョン一覧を返します
+func (n *ReanalysisController) ListRevisions(c *gin.Context, ctx context.Context) error {
+	revisions, err := n.ra.ListRevisions(c.Param("gmailId"))
+	if err != nil {
+		return err
+	}
+
+	c.JSON(http.StatusOK, revisions)
+	return nil
+}
+
+// PromoteRevision は指定したリビジョンを採用します
+func (n *ReanalysisController) PromoteRevision(c *gin.Context, ctx context.Context) error {
+	req := promoteRequest{}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		return badRequest(err)
+	}
+
+	if err := n.ra.PromoteRevision(c.Param("gmailId"), req.Revision); err != nil {
+		if ra.IsNotFound(err) {
+			return notFound(err)
+		}
+		return err
+	}
+
+	c.Status(http.StatusNoContent)
+	return nil
+}
+
+// RevertRevision は採用中のひとつ前のリビジョンに戻します
+func (n *ReanalysisController) RevertRevision(c *gin.Context, ctx context.Context) error {
+	revision, err := n.ra.RevertRevision(c.Param("gmailId"))
+	if err != nil {
+		if ra.IsNotFound(err) {
+			return notFound(err)
+		}
+		return err
+	}
+
+	c.JSON(http.StatusOK, revision)
+	return nil
+}
+
+func (r reanalyzeRequest) toCondition() (domain.Condition, error) {
+	cond := domain.Condition{
+		Category:        r.Category,
+		AnalysisVersion: r.AnalysisVersion,
+		GmailIDs:        r.GmailIDs,
+		Limit:           r.Limit,
+		Promote:         r.Promote,
+	}
+	if r.From != "" {
+		from, err := time.ParseInLocation("2006-01-02", r.From, time.Local)
+		if err != nil {
+			return cond, errors.New("from の形式が不正です。YYYY-MM-DD で指定してください")
+		}
+		cond.From = &from
+	}
+	if r.To != "" {
+		to, err := time.ParseInLocation("2006-01-02", r.To, time.Local)
+		if err != nil {
+			return cond, errors.New("to の形式が不正です。YYYY-MM-DD で指定してください")
+		}
+		cond.To = &to
+	}
+	return cond, nil
+}
@@ -0,0 +1,133 @@
+package presentation
+
+import (
+	ea "business/internal/emailstore/application"
+	"business/internal/emailstore/domain"
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TriageController はメールの仕分け（既読・いいね・びみょう・メモ）と案件の応募状況のコントローラーです
+type TriageController struct {
+	tu ea.TriageUseCaseInterface
+}
+
+// NewTriageController はメールの仕分けコントローラーを作成します
+func NewTriageController(tu ea.TriageUseCaseInterface) *TriageController {
+	return &TriageController{
+		tu: tu,
+	}
+}
+
+type bulkTriageRequest struct {
+	GmailIDs []string `json:"gmail_ids" binding:"required"`
+	domain.Triage
+}
+
+type bulkStatusRequest struct {
+	ProjectIDs []uint `json:"project_ids" binding:"required"`
+	domain.StatusUpdate
+}
+
+// TriageEmail はGメールIDで指定した1件のメールを仕分けします
+// リクエストボディは is_read / is_good / is_bad / note のうち更新する項目のみ指定します。
+func (n *TriageController) TriageEmail(c *gin.Context, ctx context.Context) error {
+	t := domain.Triage{}
+	if err := c.ShouldBindJSON(&t); err != nil {
+		return badRequest(err)
+	}
+
+	states, err := n.tu.Triage([]string{c.Param("id")}, t)
+	if err != nil {
+		return triageError(err)
+	}
+
+	c.JSON(http.StatusOK, states[0])
+	return nil
+}
+
+// TriageEmails は gmail_ids で指定した複数のメールをまとめて仕分けします
+func (n *TriageController) TriageEmails(c *gin.Context, ctx context.Context) error {
+	req := bulkTriageRequest{}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		return badRequest(err)
+	}
+
+	states, err := n.tu.Triage(req.GmailIDs, req.Triage)
+	if err != nil {
+		return triageError(err)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"items": states})
+	return nil
+}
+
+// SetProjectStatus はパスの案件ID（email_projects.id）の応募状況を更新します
+// リクエストボディは status と、変更履歴に残す note（任意）を指定します。
+func (n *TriageController) SetProjectStatus(c *gin.Context, ctx context.Context) error {
+	id, err := groupID(c)
+	if err != nil {
+		return badRequest(err)
+	}
+	s := domain.StatusUpdate{}
+	if err := c.ShouldBindJSON(&s); err != nil {
+		return badRequest(err)
+	}
+
+	statuses, err := n.tu.SetStatus([]uint{id}, s)
+	if err != nil {
+		return triageError(err)
+	}
+
+	c.JSON(http.StatusOK, statuses[0])
+	return nil
+}
+
+// SetProjectStatuses は project_ids で指定した複数の案件の応募状況をまとめて更新します
+func (n *TriageController) SetProjectStatuses(c *gin.Context, ctx context.Context) error {
+	req := bulkStatusRequest{}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		return badRequest(err)
+	}
+
+	statuses, err := n.tu.SetStatus(req.ProjectIDs, req.StatusUpdate)
+	if err != nil {
+		return triageError(err)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"items": statuses})
+	return nil
+}
+
+// StatusHistory はパスの案件IDの応募状況の変更履歴を返します
+func (n *TriageController) StatusHistory(c *gin.Context, ctx context.Context) error {
+	id, err := groupID(c)
+	if err != nil {
+		return badRequest(err)
+	}
+
+	history, err := n.tu.StatusHistory(id)
+	if err != nil {
+		return triageError(err)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"items": history})
+	return nil
+}
+
+// triageError は仕分けのエラーをステータスコードに対応するエラーに変換します
+func triageError(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrInvalidTriage):
+		return badRequest(err)
+	case errors.Is(err, domain.ErrEmailNotFound), errors.Is(err, domain.ErrProjectNotFound):
+		return notFound(err)
+	case errors.Is(err, domain.ErrStatusConflict):
+		return conflict(err)
+	default:
+		return err
+	}
+}
@@ -45,5 +45,381 @@ func NewRouter(g *gin.Engine, container *dig.Container) *gin.Engine {
 		c.Status(http.StatusOK)
 	})
 
+	g.POST("/reanalyze", func(c *gin.Context) {
+		var innerErr error
+		err := container.Invoke(func(p *presentation.ReanalysisController) {
+			innerErr = p.Reanalyze(c, ctx)
+		})
+		respond(c, "再解析エラー", err, innerErr)
+	})
+
+	g.GET("/revisions/:gmailId", func(c *gin.Context) {
+		var innerErr error
+		err := container.Invoke(func(p *presentation.ReanalysisController) {
+			innerErr = p.ListRevisions(c, ctx)
+		})
+		respond(c, "リビジョン取得エラー", err, innerErr)
+	})
+
+	g.POST("/revisions/:gmailId/promote", func(c *gin.Context) {
+		var innerErr error
+		err := container.Invoke(func(p *presentation.ReanalysisController) {
+			innerErr = p.PromoteRevision(c, ctx)
+		})
+		respond(c, "リビジョン採用エラー", err, innerErr)
+	})
+
+	g.POST("/revisions/:gmailId/revert", func(c *gin.Context) {
+		var innerErr error
+		err := container.Invoke(func(p *presentation.ReanalysisController) {
+			innerErr = p.RevertRevision(c, ctx)
+		})
+		respond(c, "リビジョン差し戻しエラー", err, innerErr)
+	})
+
+	g.GET("/projects", func(c *gin.Context) {
+		var innerErr error
+		err := container.Invoke(func(p *presentation.ProjectController) {
+			innerErr = p.ListProjects(c, ctx)
+		})
+		respond(c, "案件検索エラー", err, innerErr)
+	})
+
+	g.GET("/projects/search", func(c *gin.Context) {
+		var innerErr error
+		err := container.Invoke(func(p *presentation.ProjectController) {
+			innerErr = p.SearchProjects(c, ctx)
+		})
+		respond(c, "全文検索エラー", err, innerErr)
+	})
+
+	g.GET("/projects/:id/candidates", func(c *gin.Context) {
+		var innerErr error
+		err := container.Invoke(func(p *presentation.MatchingController) {
+			innerErr = p.MatchCandidates(c, ctx)
+		})
+		respond(c, "人材マッチングエラー", err, innerErr)
+	})
+
+	g.GET("/project-clusters/:id", func(c *gin.Context) {
+		var innerErr error
+		err := container.Invoke(func(p *presentation.DedupController) {
+			innerErr = p.GetCluster(c, ctx)
+		})
+		respond(c, "重複グループ取得エラー", err, innerErr)
+	})
+
+	g.GET("/engineers", func(c *gin.Context) {
+		var innerErr error
+		err := container.Invoke(func(p *presentation.MatchingController) {
+			innerErr = p.ListProfiles(c, ctx)
+		})
+		respond(c, "プロフィール一覧取得エラー", err, innerErr)
+	})
+
+	g.POST("/engineers", func(c *gin.Context) {
+		var innerErr error
+		err := container.Invoke(func(p *presentation.MatchingController) {
+			innerErr = p.CreateProfile(c, ctx)
+		})
+		respond(c, "プロフィール登録エラー", err, innerErr)
+	})
+
+	g.GET("/engineers/:id", func(c *gin.Context) {
+		var innerErr error
+		err := container.Invoke(func(p *presentation.MatchingController) {
+			innerErr = p.GetProfile(c, ctx)
+		})
+		respond(c, "プロフィール取得エラー", err, innerErr)
+	})
+
+	g.PUT("/engineers/:id", func(c *gin.Context) {
+		var innerErr error
+		err := container.Invoke(func(p *presentation.MatchingController) {
+			innerErr = p.UpdateProfile(c, ctx)
+		})
+		respond(c, "プロフィール更新エラー", err, innerErr)
+	})
+
+	g.DELETE("/engineers/:id", func(c *gin.Context) {
+		var innerErr error
+		err := container.Invoke(func(p *presentation.MatchingController) {
+			innerErr = p.DeleteProfile(c, ctx)
+		})
+		respond(c, "プロフィール削除エラー", err, innerErr)
+	})
+
+	g.GET("/engineers/:id/matches", func(c *gin.Context) {
+		var innerErr error
+		err := container.Invoke(func(p *presentation.MatchingController) {
+			innerErr = p.MatchProjects(c, ctx)
+		})
+		respond(c, "案件マッチングエラー", err, innerErr)
+	})
+
+	g.GET("/saved-searches", func(c *gin.Context) {
+		var innerErr error
+		err := container.Invoke(func(p *presentation.AlertController) {
+			innerErr = p.ListSearches(c, ctx)
+		})
+		respond(c, "検索条件一覧取得エラー", err, innerErr)
+	})
+
+	g.POST("/saved-searches", func(c *gin.Context) {
+		var innerErr error
+		err := container.Invoke(func(p *presentation.AlertController) {
+			innerErr = p.CreateSearch(c, ctx)
+		})
+		respond(c, "検索条件登録エラー", err, innerErr)
+	})
+
+	g.GET("/saved-searches/:id", func(c *gin.Context) {
+		var innerErr error
+		err := container.Invoke(func(p *presentation.AlertController) {
+			innerErr = p.GetSearch(c, ctx)
+		})
+		respond(c, "検索条件取得エラー", err, innerErr)
+	})
+
+	g.PUT("/saved-searches/:id", func(c *gin.Context) {
+		var innerErr error
+		err := container.Invoke(func(p *presentation.AlertController) {
+			innerErr = p.UpdateSearch(c, ctx)
+		})
+		respond(c, "検索条件更新エラー", err, innerErr)
+	})
+
+	g.DELETE("/saved-searches/:id", func(c *gin.Context) {
+		var innerErr error
+		err := container.Invoke(func(p *presentation.AlertController) {
+			innerErr = p.DeleteSearch(c, ctx)
+		})
+		respond(c, "検索条件削除エラー", err, innerErr)
+	})
+
+	g.GET("/notifications", func(c *gin.Context) {
+		var innerErr error
+		err := container.Invoke(func(p *presentation.AlertController) {
+			innerErr = p.ListNotifications(c, ctx)
+		})
+		respond(c, "通知一覧取得エラー", err, innerErr)
+	})
+
+	g.GET("/analytics/skills", func(c *gin.Context) {
+		var innerErr error
+		err := container.Invoke(func(p *presentation.AnalyticsController) {
+			innerErr = p.Skills(c, ctx)
+		})
+		respond(c, "スキル別の推移取得エラー", err, innerErr)
+	})
+
+	g.GET("/analytics/prices", func(c *gin.Context) {
+		var innerErr error
+		err := container.Invoke(func(p *presentation.AnalyticsController) {
+			innerErr = p.Prices(c, ctx)
+		})
+		respond(c, "単価の推移取得エラー", err, innerErr)
+	})
+
+	g.GET("/analytics/remote", func(c *gin.Context) {
+		var innerErr error
+		err := container.Invoke(func(p *presentation.AnalyticsController) {
+			innerErr = p.Remote(c, ctx)
+		})
+		respond(c, "リモート比率の推移取得エラー", err, innerErr)
+	})
+
+	g.GET("/analytics/rising", func(c *gin.Context) {
+		var innerErr error
+		err := container.Invoke(func(p *presentation.AnalyticsController) {
+			innerErr = p.Rising(c, ctx)
+		})
+		respond(c, "伸びているスキル取得エラー", err, innerErr)
+	})
+
+	g.POST("/analytics/refresh", func(c *gin.Context) {
+		var innerErr error
+		err := container.Invoke(func(p *presentation.AnalyticsController) {
+			innerErr = p.Refresh(c, ctx)
+		})
+		respond(c, "市場動向の集計エラー", err, innerErr)
+	})
+
+	g.GET("/agencies", func(c *gin.Context) {
+		var innerErr error
+		err := container.Invoke(func(p *presentation.AgencyController) {
+			innerErr = p.ListAgencies(c, ctx)
+		})
+		respond(c, "営業会社一覧取得エラー", err, innerErr)
+	})
+
+	g.POST("/agencies/refresh", func(c *gin.Context) {
+		var innerErr error
+		err := container.Invoke(func(p *presentation.AgencyController) {
+			innerErr = p.Refresh(c, ctx)
+		})
+		respond(c, "営業会社の集計エラー", err, innerErr)
+	})
+
+	g.GET("/agencies/:domain", func(c *gin.Context) {
+		var innerErr error
+		err := container.Invoke(func(p *presentation.AgencyController) {
+			innerErr = p.GetAgency(c, ctx)
+		})
+		respond(c, "営業会社取得エラー", err, innerErr)
+	})
+
+	g.PUT("/agencies/:domain", func(c *gin.Context) {
+		var innerErr error
+		err := container.Invoke(func(p *presentation.AgencyController) {
+			innerErr = p.UpdateAgency(c, ctx)
+		})
+		respond(c, "営業会社更新エラー", err, innerErr)
+	})
+
+	g.PATCH("/emails", func(c *gin.Context) {
+		var innerErr error
+		err := container.Invoke(func(p *presentation.TriageController) {
+			innerErr = p.TriageEmails(c, ctx)
+		})
+		respond(c, "仕分けエラー", err, innerErr)
+	})
+
+	g.PATCH("/emails/:id", func(c *gin.Context) {
+		var innerErr error
+		err := container.Invoke(func(p *presentation.TriageController) {
+			innerErr = p.TriageEmail(c, ctx)
+		})
+		respond(c, "仕分けエラー", err, innerErr)
+	})
+
+	g.PATCH("/projects/status", func(c *gin.Context) {
+		var innerErr error
+		err := container.Invoke(func(p *presentation.TriageController) {
+			innerErr = p.SetProjectStatuses(c, ctx)
+		})
+		respond(c, "応募状況更新エラー", err, innerErr)
+	})
+
+	g.PATCH("/projects/:id/status", func(c *gin.Context) {
+		var innerErr error
+		err := container.Invoke(func(p *presentation.TriageController) {
+			innerErr = p.SetProjectStatus(c, ctx)
+		})
+		respond(c, "応募状況更新エラー", err, innerErr)
+	})
+
+	g.GET("/projects/:id/status-history", func(c *gin.Context) {
+		var innerErr error
+		err := container.Invoke(func(p *presentation.TriageController) {
+			innerErr = p.StatusHistory(c, ctx)
+		})
+		respond(c, "応募状況履歴取得エラー", err, innerErr)
+	})
+
+	g.GET("/dictionary/audit-logs", func(c *gin.Context) {
+		var innerErr error
+		err := container.Invoke(func(p *presentation.DictionaryController) {
+			innerErr = p.AuditLogs(c, ctx)
+		})
+		respond(c, "用語辞書操作履歴取得エラー", err, innerErr)
+	})
+
+	g.GET("/dictionary/:kind/groups", func(c *gin.Context) {
+		var innerErr error
+		err := container.Invoke(func(p *presentation.DictionaryController) {
+			innerErr = p.ListGroups(c, ctx)
+		})
+		respond(c, "用語辞書取得エラー", err, innerErr)
+	})
+
+	g.PATCH("/dictionary/:kind/groups/:id", func(c *gin.Context) {
+		var innerErr error
+		err := container.Invoke(func(p *presentation.DictionaryController) {
+			innerErr = p.RenameGroup(c, ctx)
+		})
+		respond(c, "用語辞書正規名変更エラー", err, innerErr)
+	})
+
+	g.POST("/dictionary/:kind/groups/:id/merge", func(c *gin.Context) {
+		var innerErr error
+		err := container.Invoke(func(p *presentation.DictionaryController) {
+			innerErr = p.MergeGroup(c, ctx)
+		})
+		respond(c, "用語辞書統合エラー", err, innerErr)
+	})
+
+	g.POST("/dictionary/:kind/groups/:id/split", func(c *gin.Context) {
+		var innerErr error
+		err := container.Invoke(func(p *presentation.DictionaryController) {
+			innerErr = p.SplitGroup(c, ctx)
+		})
+		respond(c, "用語辞書分割エラー", err, innerErr)
+	})
+
+	g.POST("/dictionary/:kind/groups/:id/aliases", func(c *gin.Context) {
+		var innerErr error
+		err := container.Invoke(func(p *presentation.DictionaryController) {
+			innerErr = p.AddAlias(c, ctx)
+		})
+		respond(c, "用語辞書別名追加エラー", err, innerErr)
+	})
+
+	g.DELETE("/dictionary/:kind/groups/:id/aliases/:word", func(c *gin.Context) {
+		var innerErr error
+		err := container.Invoke(func(p *presentation.DictionaryController) {
+			innerErr = p.RemoveAlias(c, ctx)
+		})
+		respond(c, "用語辞書別名削除エラー", err, innerErr)
+	})
+
+	g.GET("/dictionary/proposals", func(c *gin.Context) {
+		var innerErr error
+		err := container.Invoke(func(p *presentation.DictionaryController) {
+			innerErr = p.ListProposals(c, ctx)
+		})
+		respond(c, "統合提案取得エラー", err, innerErr)
+	})
+
+	g.POST("/dictionary/proposals/:id/accept", func(c *gin.Context) {
+		var innerErr error
+		err := container.Invoke(func(p *presentation.DictionaryController) {
+			innerErr = p.AcceptProposal(c, ctx)
+		})
+		respond(c, "統合提案承認エラー", err, innerErr)
+	})
+
+	g.POST("/dictionary/proposals/:id/reject", func(c *gin.Context) {
+		var innerErr error
+		err := container.Invoke(func(p *presentation.DictionaryController) {
+			innerErr = p.RejectProposal(c, ctx)
+		})
+		respond(c, "統合提案却下エラー", err, innerErr)
+	})
+
 	return g
 }
+
+// respond はコントローラーの実行結果をステータスコードに変換します。
+// 正常時のレスポンスはコントローラー側で書き込みます。
+func respond(c *gin.Context, label string, err error, innerErr error) {
+	if innerErr != nil {
+		switch {
+		case strings.Contains(innerErr.Error(), "BadRequest"):
+			c.JSON(http.StatusBadRequest, gin.H{"error": innerErr.Error()})
+		case strings.Contains(innerErr.Error(), "NotFound"):
+			c.JSON(http.StatusNotFound, gin.H{"error": innerErr.Error()})
+		case strings.Contains(innerErr.Error(), "Conflict"):
+			c.JSON(http.StatusConflict, gin.H{"error": innerErr.Error()})
+		default:
+			fmt.Printf("%s: %v \n", label, innerErr)
+			c.Status(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if err != nil {
+		fmt.Printf("%s: %v \n", label, err)
+		c.Status(http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,21 @@
+// Package application はBatch APIによる一括解析機能のアプリケーション層を提供します。
+// このファイルはバッチ解析のユースケースインターフェースを定義します。
+package application
+
+import (
+	"business/internal/batch/domain"
+	cd "business/internal/common/domain"
+	"context"
+)
+
+// UseCaseInterface はバッチ解析のユースケースインターフェースです
+type UseCaseInterface interface {
+	// Submit はメールの解析リクエストをBatch APIに登録します
+	Submit(ctx context.Context, emails []cd.BasicMessage) (domain.Batch, error)
+
+	// Refresh は取り込み前のバッチの状態をBatch APIから取得して更新します
+	Refresh(ctx context.Context) ([]domain.Batch, error)
+
+	// Collect は完了したバッチの解析結果を取り込み、メールとして保存します
+	Collect(ctx context.Context) (domain.CollectSummary, error)
+}
@@ -0,0 +1,233 @@
+// Package application はBatch APIによる一括解析機能のアプリケーション層を提供します。
+// このファイルはBatch APIへの登録と解析結果の取り込みのユースケースを実装します。
+package application
+
+import (
+	"business/internal/batch/domain"
+	r "business/internal/batch/infrastructure"
+	cd "business/internal/common/domain"
+	ea "business/internal/emailstore/application"
+	aiapp "business/internal/openAi/application"
+	oa "business/tools/openai"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// UseCase はバッチ解析のユースケースの具象です
+type UseCase struct {
+	r     r.RepositoryInterface
+	oa    oa.BatchClientInterface
+	aiapp aiapp.UseCaseInterface
+	ea    ea.UseCaseInterface
+}
+
+// New はバッチ解析ユースケースを作成します
+func New(r r.RepositoryInterface, oa oa.BatchClientInterface, aiapp aiapp.UseCaseInterface, ea ea.UseCaseInterface) *UseCase {
+	return &UseCase{
+		r:     r,
+		oa:    oa,
+		aiapp: aiapp,
+		ea:    ea,
+	}
+}
+
+// Submit はメールの解析リクエストをJSONLにまとめてBatch APIに登録し、バッチIDを保存します
+func (u *UseCase) Submit(ctx context.Context, emails []cd.BasicMessage) (domain.Batch, error) {
+	if len(emails) == 0 {
+		return domain.Batch{}, errors.New("バッチに含めるメールがありません")
+	}
+
+	prompts, err := u.aiapp.BuildBatchPrompts(emails)
+	if err != nil {
+		return domain.Batch{}, fmt.Errorf("バッチ入力の作成エラー: %w", err)
+	}
+
+	requests := make([]oa.BatchRequest, 0, len(prompts))
+	chunkCounts := make(map[string]int, len(emails))
+	for _, p := range prompts {
+		requests = append(requests, oa.BatchRequest{
+			CustomID: domain.CustomID(p.GmailID, p.ChunkIndex),
+			Prompt:   p.Prompt,
+		})
+		chunkCounts[p.GmailID] = p.ChunkCount
+	}
+
+	info, err := u.oa.SubmitBatch(ctx, requests)
+	if err != nil {
+		return domain.Batch{}, err
+	}
+
+	batchEmails := make([]domain.BatchEmail, 0, len(emails))
+	for _, email := range emails {
+		batchEmails = append(batchEmails, domain.BatchEmail{
+			GmailID:      email.ID,
+			ThreadID:     email.ThreadID,
+			Subject:      email.Subject,
+			From:         email.From,
+			ReceivedDate: email.Date,
+			Body:         email.Body,
+			ChunkCount:   chunkCounts[email.ID],
+			Status:       domain.EmailStatusPending,
+		})
+	}
+
+	batch := domain.Batch{
+		BatchID:         info.ID,
+		AnalysisVersion: u.aiapp.AnalysisVersion(),
+		RequestCount:    len(requests),
+	}
+	applyInfo(&batch, info)
+
+	return u.r.CreateBatch(batch, batchEmails)
+}
+
+// Refresh は取り込み前のバッチの状態をBatch APIから取得して更新します
+func (u *UseCase) Refresh(ctx context.Context) ([]domain.Batch, error) {
+	batches, err := u.r.ListUncollectedBatches()
+	if err != nil {
+		return nil, err
+	}
+
+	for i, batch := range batches {
+		if domain.IsTerminal(batch.Status) {
+			continue
+		}
+
+		info, err := u.oa.GetBatch(ctx, batch.BatchID)
+		if err != nil {
+			return nil, err
+		}
+		applyInfo(&batch, info)
+		if err := u.r.UpdateBatchStatus(batch); err != nil {
+			return nil, err
+		}
+		batches[i] = batch
+	}
+	return batches, nil
+}
+
+// Collect は完了したバッチの解析結果を取り込み、通常の解析と同じ形式でメールとして保存します
+// 失敗・期限切れになったバッチは出力がある分だけ取り込み、残りのメールは失敗として記録します。
+func (u *UseCase) Collect(ctx context.Context) (domain.CollectSummary, error) {
+	summary := domain.CollectSummary{Failed: []string{}}
+
+	batches, err := u.Refresh(ctx)
+	if err != nil {
+		return summary, err
+	}
+
+	for _, batch := range batches {
+		if !domain.IsTerminal(batch.Status) {
+			continue
+		}
+		if err := u.collectBatch(ctx, batch, &summary); err != nil {
+			return summary, err
+		}
+		summary.Batches++
+	}
+	return summary, nil
+}
+
+// collectBatch は1バッチ分の出力をメール単位にまとめて保存します
+func (u *UseCase) collectBatch(ctx context.Context, batch domain.Batch, summary *domain.CollectSummary) error {
+	var outputs []oa.BatchOutput
+	if batch.OutputFileID != "" || batch.ErrorFileID != "" {
+		var err error
+		outputs, err = u.oa.GetBatchOutputs(ctx, oa.BatchInfo{
+			ID:           batch.BatchID,
+			Status:       batch.Status,
+			OutputFileID: batch.OutputFileID,
+			ErrorFileID:  batch.ErrorFileID,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// GメールIDごとにチャンクの解析結果をまとめる
+	chunks := map[string]map[int][]cd.AnalysisResult{}
+	failures := map[string]error{}
+	for _, output := range outputs {
+		gmailID, chunkIndex, ok := domain.ParseCustomID(output.CustomID)
+		if !ok {
+			continue
+		}
+		if output.Err != nil {
+			failures[gmailID] = output.Err
+			continue
+		}
+		if chunks[gmailID] == nil {
+			chunks[gmailID] = map[int][]cd.AnalysisResult{}
+		}
+		chunks[gmailID][chunkIndex] = output.Results
+	}
+
+	emails, err := u.r.ListBatchEmails(batch.ID)
+	if err != nil {
+		return err
+	}
+
+	for _, email := range emails {
+		if email.Status == domain.EmailStatusSaved {
+			continue
+		}
+
+		saved, err := u.saveEmail(email, chunks[email.GmailID], failures[email.GmailID])
+		if err != nil {
+			summary.Failed = append(summary.Failed, email.GmailID)
+			if err := u.r.UpdateBatchEmailStatus(email.ID, domain.EmailStatusFailed, err.Error()); err != nil {
+				return err
+			}
+			continue
+		}
+
+		summary.Saved++
+		summary.Projects += saved
+		if err := u.r.UpdateBatchEmailStatus(email.ID, domain.EmailStatusSaved, ""); err != nil {
+			return err
+		}
+	}
+
+	return u.r.MarkCollected(batch.ID, time.Now())
+}
+
+// saveEmail はチャンクがすべて揃ったメールの解析結果を保存し、保存件数を返します
+func (u *UseCase) saveEmail(email domain.BatchEmail, chunks map[int][]cd.AnalysisResult, failure error) (int, error) {
+	if failure != nil {
+		return 0, fmt.Errorf("解析エラー: %w", failure)
+	}
+	if len(chunks) < email.ChunkCount {
+		return 0, fmt.Errorf("解析結果が揃っていません。（%d/%dチャンク）", len(chunks), email.ChunkCount)
+	}
+
+	chunkResults := make([][]cd.AnalysisResult, 0, email.ChunkCount)
+	for i := 0; i < email.ChunkCount; i++ {
+		chunkResults = append(chunkResults, chunks[i])
+	}
+
+	results := u.aiapp.ConvertBatchResults(email.ToBasicMessage(), chunkResults)
+	if len(results) == 0 {
+		return 0, errors.New("解析結果が0件でした。メールを確認してください")
+	}
+
+	// 1通に載った案件は1つのトランザクションで保存する
+	if _, err := u.ea.SaveEmailAnalysisResults(results); err != nil {
+		return 0, fmt.Errorf("メール保存エラー: %w", err)
+	}
+	return len(results), nil
+}
+
+// applyInfo はBatch APIから取得した状態をバッチに反映します
+func applyInfo(batch *domain.Batch, info oa.BatchInfo) {
+	batch.Status = info.Status
+	batch.InputFileID = info.InputFileID
+	batch.OutputFileID = info.OutputFileID
+	batch.ErrorFileID = info.ErrorFileID
+	if info.Total > 0 {
+		batch.RequestCount = info.Total
+	}
+	batch.CompletedCount = info.Completed
+	batch.FailedCount = info.Failed
+}
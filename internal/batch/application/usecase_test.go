@@ -0,0 +1,252 @@
+package application
+
+import (
+	"business/internal/batch/domain"
+	cd "business/internal/common/domain"
+	aiapp "business/internal/openAi/application"
+	oa "business/tools/openai"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockRepository はバッチリポジトリのモックです
+type MockRepository struct {
+	mock.Mock
+}
+
+func (m *MockRepository) CreateBatch(batch domain.Batch, emails []domain.BatchEmail) (domain.Batch, error) {
+	args := m.Called(batch, emails)
+	return args.Get(0).(domain.Batch), args.Error(1)
+}
+
+func (m *MockRepository) ListUncollectedBatches() ([]domain.Batch, error) {
+	args := m.Called()
+	return args.Get(0).([]domain.Batch), args.Error(1)
+}
+
+func (m *MockRepository) UpdateBatchStatus(batch domain.Batch) error {
+	args := m.Called(batch)
+	return args.Error(0)
+}
+
+func (m *MockRepository) ListBatchEmails(batchID uint) ([]domain.BatchEmail, error) {
+	args := m.Called(batchID)
+	return args.Get(0).([]domain.BatchEmail), args.Error(1)
+}
+
+func (m *MockRepository) UpdateBatchEmailStatus(id uint, status string, errorMessage string) error {
+	args := m.Called(id, status, errorMessage)
+	return args.Error(0)
+}
+
+func (m *MockRepository) MarkCollected(batchID uint, collectedAt time.Time) error {
+	args := m.Called(batchID, collectedAt)
+	return args.Error(0)
+}
+
+// MockBatchClient はBatch APIクライアントのモックです
+type MockBatchClient struct {
+	mock.Mock
+}
+
+func (m *MockBatchClient) SubmitBatch(ctx context.Context, requests []oa.BatchRequest) (oa.BatchInfo, error) {
+	args := m.Called(ctx, requests)
+	return args.Get(0).(oa.BatchInfo), args.Error(1)
+}
+
+func (m *MockBatchClient) GetBatch(ctx context.Context, batchID string) (oa.BatchInfo, error) {
+	args := m.Called(ctx, batchID)
+	return args.Get(0).(oa.BatchInfo), args.Error(1)
+}
+
+func (m *MockBatchClient) GetBatchOutputs(ctx context.Context, info oa.BatchInfo) ([]oa.BatchOutput, error) {
+	args := m.Called(ctx, info)
+	return args.Get(0).([]oa.BatchOutput), args.Error(1)
+}
+
+// MockAnalyzer はメール分析ユースケースのモックです
+type MockAnalyzer struct {
+	mock.Mock
+}
+
+func (m *MockAnalyzer) AnalyzeEmailContent(ctx context.Context, emails []cd.BasicMessage) ([]cd.Email, error) {
+	args := m.Called(ctx, emails)
+	return args.Get(0).([]cd.Email), args.Error(1)
+}
+
+func (m *MockAnalyzer) AnalysisVersion() string {
+	args := m.Called()
+	return args.String(0)
+}
+
+func (m *MockAnalyzer) BuildBatchPrompts(emails []cd.BasicMessage) ([]aiapp.BatchPrompt, error) {
+	args := m.Called(emails)
+	return args.Get(0).([]aiapp.BatchPrompt), args.Error(1)
+}
+
+func (m *MockAnalyzer) ConvertBatchResults(email cd.BasicMessage, chunkResults [][]cd.AnalysisResult) []cd.Email {
+	args := m.Called(email, chunkResults)
+	return args.Get(0).([]cd.Email)
+}
+
+// MockEmailStore はメール保存ユースケースのモックです
+type MockEmailStore struct {
+	mock.Mock
+}
+
+func (m *MockEmailStore) SaveEmailAnalysisResult(result cd.Email) error {
+	args := m.Called(result)
+	return args.Error(0)
+}
+
+func (m *MockEmailStore) SaveEmailAnalysisResults(results []cd.Email) (int, error) {
+	args := m.Called(results)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockEmailStore) GetEmailByGmailIds(gmailIds []string) ([]string, error) {
+	args := m.Called(gmailIds)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockEmailStore) ReplaceEmailAnalysisResults(gmailID string, results []cd.Email) error {
+	args := m.Called(gmailID, results)
+	return args.Error(0)
+}
+
+func TestUseCase_Submit(t *testing.T) {
+	ctx := context.Background()
+	received := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+	emails := []cd.BasicMessage{
+		{ID: "gmail-1", Subject: "件名1", From: "sales@example.com", Date: received, Body: "長い本文"},
+		{ID: "gmail-2", Subject: "件名2", From: "sales@example.com", Date: received, Body: "本文"},
+	}
+
+	repo := new(MockRepository)
+	client := new(MockBatchClient)
+	analyzer := new(MockAnalyzer)
+	analyzer.On("BuildBatchPrompts", emails).Return([]aiapp.BatchPrompt{
+		{GmailID: "gmail-1", ChunkIndex: 0, ChunkCount: 2, Prompt: "p1-0"},
+		{GmailID: "gmail-1", ChunkIndex: 1, ChunkCount: 2, Prompt: "p1-1"},
+		{GmailID: "gmail-2", ChunkIndex: 0, ChunkCount: 1, Prompt: "p2-0"},
+	}, nil)
+	analyzer.On("AnalysisVersion").Return("v2")
+	client.On("SubmitBatch", ctx, []oa.BatchRequest{
+		{CustomID: "gmail-1-0", Prompt: "p1-0"},
+		{CustomID: "gmail-1-1", Prompt: "p1-1"},
+		{CustomID: "gmail-2-0", Prompt: "p2-0"},
+	}).Return(oa.BatchInfo{ID: "batch_1", Status: domain.StatusValidating, InputFileID: "file_in"}, nil)
+
+	expectedBatch := domain.Batch{BatchID: "batch_1", InputFileID: "file_in", Status: domain.StatusValidating, RequestCount: 3, AnalysisVersion: "v2"}
+	expectedEmails := []domain.BatchEmail{
+		{GmailID: "gmail-1", Subject: "件名1", From: "sales@example.com", ReceivedDate: received, Body: "長い本文", ChunkCount: 2, Status: domain.EmailStatusPending},
+		{GmailID: "gmail-2", Subject: "件名2", From: "sales@example.com", ReceivedDate: received, Body: "本文", ChunkCount: 1, Status: domain.EmailStatusPending},
+	}
+	saved := expectedBatch
+	saved.ID = 1
+	repo.On("CreateBatch", expectedBatch, expectedEmails).Return(saved, nil)
+
+	usecase := New(repo, client, analyzer, new(MockEmailStore))
+	batch, err := usecase.Submit(ctx, emails)
+
+	require.NoError(t, err)
+	assert.Equal(t, uint(1), batch.ID)
+	repo.AssertExpectations(t)
+	client.AssertExpectations(t)
+	analyzer.AssertExpectations(t)
+}
+
+func TestUseCase_Submit_NoEmails(t *testing.T) {
+	usecase := New(new(MockRepository), new(MockBatchClient), new(MockAnalyzer), new(MockEmailStore))
+	_, err := usecase.Submit(context.Background(), nil)
+	assert.Error(t, err)
+}
+
+func TestUseCase_Collect(t *testing.T) {
+	ctx := context.Background()
+
+	repo := new(MockRepository)
+	client := new(MockBatchClient)
+	analyzer := new(MockAnalyzer)
+	store := new(MockEmailStore)
+
+	inProgress := domain.Batch{ID: 1, BatchID: "batch_1", Status: domain.StatusInProgress}
+	running := domain.Batch{ID: 2, BatchID: "batch_2", Status: domain.StatusInProgress}
+	repo.On("ListUncollectedBatches").Return([]domain.Batch{inProgress, running}, nil)
+
+	// batch_1は完了、batch_2は実行中のまま
+	client.On("GetBatch", ctx, "batch_1").Return(oa.BatchInfo{ID: "batch_1", Status: domain.StatusCompleted, OutputFileID: "file_out", Total: 3, Completed: 2, Failed: 1}, nil)
+	client.On("GetBatch", ctx, "batch_2").Return(oa.BatchInfo{ID: "batch_2", Status: domain.StatusInProgress, Total: 1}, nil)
+	completed := domain.Batch{ID: 1, BatchID: "batch_1", Status: domain.StatusCompleted, OutputFileID: "file_out", RequestCount: 3, CompletedCount: 2, FailedCount: 1}
+	repo.On("UpdateBatchStatus", completed).Return(nil)
+	repo.On("UpdateBatchStatus", domain.Batch{ID: 2, BatchID: "batch_2", Status: domain.StatusInProgress, RequestCount: 1}).Return(nil)
+
+	goProject := cd.AnalysisResult{MailCategory: "案件", ProjectTitle: "Go開発"}
+	phpProject := cd.AnalysisResult{MailCategory: "案件", ProjectTitle: "PHP開発"}
+	client.On("GetBatchOutputs", ctx, oa.BatchInfo{ID: "batch_1", Status: domain.StatusCompleted, OutputFileID: "file_out"}).Return([]oa.BatchOutput{
+		{CustomID: "gmail-1-1", Results: []cd.AnalysisResult{phpProject}},
+		{CustomID: "gmail-1-0", Results: []cd.AnalysisResult{goProject}},
+		{CustomID: "gmail-2-0", Err: errors.New("server_error")},
+	}, nil)
+
+	email1 := domain.BatchEmail{ID: 10, BatchID: 1, GmailID: "gmail-1", Body: "本文1", ChunkCount: 2, Status: domain.EmailStatusPending}
+	email2 := domain.BatchEmail{ID: 11, BatchID: 1, GmailID: "gmail-2", Body: "本文2", ChunkCount: 1, Status: domain.EmailStatusPending}
+	repo.On("ListBatchEmails", uint(1)).Return([]domain.BatchEmail{email1, email2}, nil)
+
+	// チャンク順に並べて保存形式へ詰め替える
+	converted := []cd.Email{{GmailID: "gmail-1", ProjectName: "Go開発"}, {GmailID: "gmail-1", ProjectName: "PHP開発"}}
+	analyzer.On("ConvertBatchResults", email1.ToBasicMessage(), [][]cd.AnalysisResult{{goProject}, {phpProject}}).Return(converted)
+	store.On("SaveEmailAnalysisResults", converted).Return(1, nil)
+
+	repo.On("UpdateBatchEmailStatus", uint(10), domain.EmailStatusSaved, "").Return(nil)
+	repo.On("UpdateBatchEmailStatus", uint(11), domain.EmailStatusFailed, mock.AnythingOfType("string")).Return(nil)
+	repo.On("MarkCollected", uint(1), mock.AnythingOfType("time.Time")).Return(nil)
+
+	usecase := New(repo, client, analyzer, store)
+	summary, err := usecase.Collect(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, summary.Batches)
+	assert.Equal(t, 1, summary.Saved)
+	assert.Equal(t, 2, summary.Projects)
+	assert.Equal(t, []string{"gmail-2"}, summary.Failed)
+	repo.AssertExpectations(t)
+	client.AssertExpectations(t)
+	analyzer.AssertExpectations(t)
+	store.AssertExpectations(t)
+}
+
+func TestUseCase_Collect_MissingChunk(t *testing.T) {
+	ctx := context.Background()
+
+	repo := new(MockRepository)
+	client := new(MockBatchClient)
+
+	// 期限切れで一部のチャンクしか出力されなかった
+	expired := domain.Batch{ID: 1, BatchID: "batch_1", Status: domain.StatusExpired, OutputFileID: "file_out"}
+	repo.On("ListUncollectedBatches").Return([]domain.Batch{expired}, nil)
+	client.On("GetBatchOutputs", ctx, oa.BatchInfo{ID: "batch_1", Status: domain.StatusExpired, OutputFileID: "file_out"}).Return([]oa.BatchOutput{
+		{CustomID: "gmail-1-0", Results: []cd.AnalysisResult{{MailCategory: "案件"}}},
+	}, nil)
+	repo.On("ListBatchEmails", uint(1)).Return([]domain.BatchEmail{
+		{ID: 10, BatchID: 1, GmailID: "gmail-1", ChunkCount: 2, Status: domain.EmailStatusPending},
+		{ID: 11, BatchID: 1, GmailID: "gmail-0", ChunkCount: 1, Status: domain.EmailStatusSaved},
+	}, nil)
+	repo.On("UpdateBatchEmailStatus", uint(10), domain.EmailStatusFailed, "解析結果が揃っていません。（1/2チャンク）").Return(nil)
+	repo.On("MarkCollected", uint(1), mock.AnythingOfType("time.Time")).Return(nil)
+
+	usecase := New(repo, client, new(MockAnalyzer), new(MockEmailStore))
+	summary, err := usecase.Collect(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, summary.Saved)
+	assert.Equal(t, []string{"gmail-1"}, summary.Failed)
+	repo.AssertExpectations(t)
+	client.AssertExpectations(t)
+}
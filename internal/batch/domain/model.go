@@ -0,0 +1,109 @@
+// Package domain はBatch APIによる一括解析機能のドメイン層を提供します。
+// このファイルはバッチと対象メールのドメインモデルを定義します。
+package domain
+
+import (
+	cd "business/internal/common/domain"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// バッチの状態（OpenAI Batch APIの状態に準拠）
+const (
+	StatusValidating = "validating"
+	StatusInProgress = "in_progress"
+	StatusFinalizing = "finalizing"
+	StatusCompleted  = "completed"
+	StatusFailed     = "failed"
+	StatusExpired    = "expired"
+	StatusCancelling = "cancelling"
+	StatusCancelled  = "cancelled"
+)
+
+// 対象メールの保存状態
+const (
+	EmailStatusPending = "pending" // 解析結果の取り込み待ち
+	EmailStatusSaved   = "saved"   // 解析結果を保存済み
+	EmailStatusFailed  = "failed"  // 解析または保存に失敗
+)
+
+// IsTerminal はバッチがこれ以上状態遷移しないかを返します
+func IsTerminal(status string) bool {
+	switch status {
+	case StatusCompleted, StatusFailed, StatusExpired, StatusCancelled:
+		return true
+	}
+	return false
+}
+
+// Batch はBatch APIに登録したバッチです
+type Batch struct {
+	ID              uint       `json:"id"`
+	BatchID         string     `json:"batch_id"` // OpenAIのバッチID
+	InputFileID     string     `json:"input_file_id"`
+	OutputFileID    string     `json:"output_file_id"`
+	ErrorFileID     string     `json:"error_file_id"`
+	Status          string     `json:"status"`
+	RequestCount    int        `json:"request_count"`
+	CompletedCount  int        `json:"completed_count"`
+	FailedCount     int        `json:"failed_count"`
+	AnalysisVersion string     `json:"analysis_version"`
+	CollectedAt     *time.Time `json:"collected_at"` // 解析結果を取り込んだ日時
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+// BatchEmail はバッチに含めたメールです
+// 解析結果の取り込み時に保存形式へ詰め替えるため、メールの内容を保持します。
+type BatchEmail struct {
+	ID           uint
+	BatchID      uint // analysis_batches.id
+	GmailID      string
+	ThreadID     string
+	Subject      string
+	From         string
+	ReceivedDate time.Time
+	Body         string
+	ChunkCount   int // 本文の分割数（リクエスト数）
+	Status       string
+	ErrorMessage string
+}
+
+// ToBasicMessage はバッチに含めたメールを解析の入力形式に変換します
+func (e BatchEmail) ToBasicMessage() cd.BasicMessage {
+	return cd.BasicMessage{
+		ID:       e.GmailID,
+		ThreadID: e.ThreadID,
+		Subject:  e.Subject,
+		From:     e.From,
+		Date:     e.ReceivedDate,
+		Body:     e.Body,
+	}
+}
+
+// CollectSummary はバッチの解析結果を取り込んだ結果です
+type CollectSummary struct {
+	Batches  int      // 取り込んだバッチ数
+	Saved    int      // 保存したメール数
+	Projects int      // 保存した案件・人材の件数
+	Failed   []string // 解析または保存に失敗したGメールID
+}
+
+// CustomID はBatch APIのリクエストと出力を突き合わせるIDを作成します
+func CustomID(gmailID string, chunkIndex int) string {
+	return fmt.Sprintf("%s-%d", gmailID, chunkIndex)
+}
+
+// ParseCustomID はCustomIDからGメールIDとチャンク番号を取り出します
+func ParseCustomID(customID string) (string, int, bool) {
+	idx := strings.LastIndex(customID, "-")
+	if idx <= 0 {
+		return "", 0, false
+	}
+	chunkIndex, err := strconv.Atoi(customID[idx+1:])
+	if err != nil {
+		return "", 0, false
+	}
+	return customID[:idx], chunkIndex, true
+}
@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCustomID(t *testing.T) {
+	customID := CustomID("18c1234567890abc", 2)
+	assert.Equal(t, "18c1234567890abc-2", customID)
+
+	gmailID, chunkIndex, ok := ParseCustomID(customID)
+	assert.True(t, ok)
+	assert.Equal(t, "18c1234567890abc", gmailID)
+	assert.Equal(t, 2, chunkIndex)
+
+	_, _, ok = ParseCustomID("invalid")
+	assert.False(t, ok)
+	_, _, ok = ParseCustomID("gmail-x")
+	assert.False(t, ok)
+}
+
+func TestIsTerminal(t *testing.T) {
+	assert.False(t, IsTerminal(StatusValidating))
+	assert.False(t, IsTerminal(StatusInProgress))
+	assert.True(t, IsTerminal(StatusCompleted))
+	assert.True(t, IsTerminal(StatusExpired))
+}
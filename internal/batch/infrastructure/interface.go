@@ -0,0 +1,29 @@
+// Package infrastructure はBatch APIによる一括解析機能のインフラストラクチャ層を提供します。
+// このファイルはバッチの状態を保存するリポジトリのインターフェースを定義します。
+package infrastructure
+
+import (
+	"business/internal/batch/domain"
+	"time"
+)
+
+// RepositoryInterface はバッチのリポジトリインターフェースです
+type RepositoryInterface interface {
+	// CreateBatch はバッチと対象メールを保存します
+	CreateBatch(batch domain.Batch, emails []domain.BatchEmail) (domain.Batch, error)
+
+	// ListUncollectedBatches は解析結果を取り込んでいないバッチを登録順に取得します
+	ListUncollectedBatches() ([]domain.Batch, error)
+
+	// UpdateBatchStatus はバッチの状態・ファイルID・件数を更新します
+	UpdateBatchStatus(batch domain.Batch) error
+
+	// ListBatchEmails はバッチに含めたメールを取得します
+	ListBatchEmails(batchID uint) ([]domain.BatchEmail, error)
+
+	// UpdateBatchEmailStatus は対象メールの保存状態を更新します
+	UpdateBatchEmailStatus(id uint, status string, errorMessage string) error
+
+	// MarkCollected はバッチの解析結果を取り込み済みにします
+	MarkCollected(batchID uint, collectedAt time.Time) error
+}
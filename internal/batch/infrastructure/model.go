@@ -0,0 +1,51 @@
+// Package infrastructure はBatch APIによる一括解析機能のインフラストラクチャ層を提供します。
+// このファイルはバッチの状態を保存するテーブルのモデルを定義します。
+package infrastructure
+
+import (
+	"time"
+)
+
+// AnalysisBatch はBatch APIに登録したバッチを表すモデルです
+type AnalysisBatch struct {
+	ID              uint `gorm:"primaryKey;autoIncrement"`
+	BatchID         string
+	InputFileID     string
+	OutputFileID    *string
+	ErrorFileID     *string
+	Status          string
+	RequestCount    int
+	CompletedCount  int
+	FailedCount     int
+	AnalysisVersion string
+	CollectedAt     *time.Time
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// AnalysisBatchEmail はバッチに含めたメールを表すモデルです
+type AnalysisBatchEmail struct {
+	ID              uint `gorm:"primaryKey;autoIncrement"`
+	AnalysisBatchID uint
+	GmailID         string
+	ThreadID        string
+	Subject         string
+	Sender          string
+	ReceivedDate    time.Time
+	Body            string
+	ChunkCount      int
+	Status          string
+	ErrorMessage    *string
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// TableName はテーブル名を指定します
+func (AnalysisBatch) TableName() string {
+	return "analysis_batches"
+}
+
+// TableName はテーブル名を指定します
+func (AnalysisBatchEmail) TableName() string {
+	return "analysis_batch_emails"
+}
@@ -0,0 +1,183 @@
+// Package infrastructure はBatch APIによる一括解析機能のインフラストラクチャ層を提供します。
+// このファイルはバッチの状態と対象メールの保存を実装します。
+package infrastructure
+
+import (
+	"business/internal/batch/domain"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Repository はバッチのリポジトリ実装です
+type Repository struct {
+	db *gorm.DB
+}
+
+// New はバッチリポジトリを作成します
+func New(db *gorm.DB) *Repository {
+	return &Repository{
+		db: db,
+	}
+}
+
+// CreateBatch はバッチと対象メールを保存します
+func (r *Repository) CreateBatch(batch domain.Batch, emails []domain.BatchEmail) (domain.Batch, error) {
+	row := AnalysisBatch{
+		BatchID:         batch.BatchID,
+		InputFileID:     batch.InputFileID,
+		OutputFileID:    nullable(batch.OutputFileID),
+		ErrorFileID:     nullable(batch.ErrorFileID),
+		Status:          batch.Status,
+		RequestCount:    batch.RequestCount,
+		CompletedCount:  batch.CompletedCount,
+		FailedCount:     batch.FailedCount,
+		AnalysisVersion: batch.AnalysisVersion,
+	}
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&row).Error; err != nil {
+			return fmt.Errorf("バッチ保存エラー: %w", err)
+		}
+
+		rows := make([]AnalysisBatchEmail, 0, len(emails))
+		for _, email := range emails {
+			status := email.Status
+			if status == "" {
+				status = domain.EmailStatusPending
+			}
+			rows = append(rows, AnalysisBatchEmail{
+				AnalysisBatchID: row.ID,
+				GmailID:         email.GmailID,
+				ThreadID:        email.ThreadID,
+				Subject:         email.Subject,
+				Sender:          email.From,
+				ReceivedDate:    email.ReceivedDate,
+				Body:            email.Body,
+				ChunkCount:      email.ChunkCount,
+				Status:          status,
+			})
+		}
+		if len(rows) > 0 {
+			if err := tx.Create(&rows).Error; err != nil {
+				return fmt.Errorf("バッチ対象メール保存エラー: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return domain.Batch{}, err
+	}
+	return toDomain(row), nil
+}
+
+// ListUncollectedBatches は解析結果を取り込んでいないバッチを登録順に取得します
+func (r *Repository) ListUncollectedBatches() ([]domain.Batch, error) {
+	var rows []AnalysisBatch
+	if err := r.db.Where("collected_at IS NULL").Order("id").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("バッチ一覧取得エラー: %w", err)
+	}
+
+	batches := make([]domain.Batch, 0, len(rows))
+	for _, row := range rows {
+		batches = append(batches, toDomain(row))
+	}
+	return batches, nil
+}
+
+// UpdateBatchStatus はバッチの状態・ファイルID・件数を更新します
+func (r *Repository) UpdateBatchStatus(batch domain.Batch) error {
+	err := r.db.Model(&AnalysisBatch{}).Where("id = ?", batch.ID).Updates(map[string]interface{}{
+		"status":          batch.Status,
+		"output_file_id":  nullable(batch.OutputFileID),
+		"error_file_id":   nullable(batch.ErrorFileID),
+		"request_count":   batch.RequestCount,
+		"completed_count": batch.CompletedCount,
+		"failed_count":    batch.FailedCount,
+	}).Error
+	if err != nil {
+		return fmt.Errorf("バッチ状態更新エラー: %w", err)
+	}
+	return nil
+}
+
+// ListBatchEmails はバッチに含めたメールを取得します
+func (r *Repository) ListBatchEmails(batchID uint) ([]domain.BatchEmail, error) {
+	var rows []AnalysisBatchEmail
+	if err := r.db.Where("analysis_batch_id = ?", batchID).Order("id").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("バッチ対象メール取得エラー: %w", err)
+	}
+
+	emails := make([]domain.BatchEmail, 0, len(rows))
+	for _, row := range rows {
+		errorMessage := ""
+		if row.ErrorMessage != nil {
+			errorMessage = *row.ErrorMessage
+		}
+		emails = append(emails, domain.BatchEmail{
+			ID:           row.ID,
+			BatchID:      row.AnalysisBatchID,
+			GmailID:      row.GmailID,
+			ThreadID:     row.ThreadID,
+			Subject:      row.Subject,
+			From:         row.Sender,
+			ReceivedDate: row.ReceivedDate,
+			Body:         row.Body,
+			ChunkCount:   row.ChunkCount,
+			Status:       row.Status,
+			ErrorMessage: errorMessage,
+		})
+	}
+	return emails, nil
+}
+
+// UpdateBatchEmailStatus は対象メールの保存状態を更新します
+func (r *Repository) UpdateBatchEmailStatus(id uint, status string, errorMessage string) error {
+	err := r.db.Model(&AnalysisBatchEmail{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":        status,
+		"error_message": nullable(errorMessage),
+	}).Error
+	if err != nil {
+		return fmt.Errorf("バッチ対象メール更新エラー: %w", err)
+	}
+	return nil
+}
+
+// MarkCollected はバッチの解析結果を取り込み済みにします
+func (r *Repository) MarkCollected(batchID uint, collectedAt time.Time) error {
+	if err := r.db.Model(&AnalysisBatch{}).Where("id = ?", batchID).Update("collected_at", collectedAt).Error; err != nil {
+		return fmt.Errorf("バッチ取り込み済み更新エラー: %w", err)
+	}
+	return nil
+}
+
+func toDomain(row AnalysisBatch) domain.Batch {
+	return domain.Batch{
+		ID:              row.ID,
+		BatchID:         row.BatchID,
+		InputFileID:     row.InputFileID,
+		OutputFileID:    deref(row.OutputFileID),
+		ErrorFileID:     deref(row.ErrorFileID),
+		Status:          row.Status,
+		RequestCount:    row.RequestCount,
+		CompletedCount:  row.CompletedCount,
+		FailedCount:     row.FailedCount,
+		AnalysisVersion: row.AnalysisVersion,
+		CollectedAt:     row.CollectedAt,
+		CreatedAt:       row.CreatedAt,
+	}
+}
+
+func nullable(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
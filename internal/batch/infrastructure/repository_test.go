@@ -0,0 +1,74 @@
+// Package infrastructure はBatch APIによる一括解析機能のインフラストラクチャ層のテストを提供します。
+package infrastructure
+
+import (
+	"business/internal/batch/domain"
+	"business/tools/migrations/model"
+	"business/tools/mysql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_Batch(t *testing.T) {
+	t.Parallel()
+	// テスト用DBの準備
+	db, cleanup, err := mysql.CreateNewTestDB()
+	require.NoError(t, err)
+	defer cleanup()
+
+	err = db.DB.AutoMigrate(
+		model.AnalysisBatch{},
+		model.AnalysisBatchEmail{},
+	)
+	require.NoError(t, err)
+
+	repo := New(db.DB)
+
+	// バッチと対象メールを保存できること
+	received := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+	batch, err := repo.CreateBatch(
+		domain.Batch{BatchID: "batch_1", InputFileID: "file_in", Status: domain.StatusValidating, RequestCount: 3, AnalysisVersion: "v1"},
+		[]domain.BatchEmail{
+			{GmailID: "gmail-1", Subject: "件名1", From: "営業 <sales@example.com>", ReceivedDate: received, Body: "本文1", ChunkCount: 2},
+			{GmailID: "gmail-2", Subject: "件名2", From: "sales@example.com", ReceivedDate: received, Body: "本文2", ChunkCount: 1},
+		},
+	)
+	require.NoError(t, err)
+	assert.NotZero(t, batch.ID)
+
+	// 状態を更新できること
+	batch.Status = domain.StatusCompleted
+	batch.OutputFileID = "file_out"
+	batch.CompletedCount = 3
+	require.NoError(t, repo.UpdateBatchStatus(batch))
+
+	batches, err := repo.ListUncollectedBatches()
+	require.NoError(t, err)
+	require.Len(t, batches, 1)
+	assert.Equal(t, domain.StatusCompleted, batches[0].Status)
+	assert.Equal(t, "file_out", batches[0].OutputFileID)
+	assert.Equal(t, "", batches[0].ErrorFileID)
+
+	// 対象メールを取得・更新できること
+	emails, err := repo.ListBatchEmails(batch.ID)
+	require.NoError(t, err)
+	require.Len(t, emails, 2)
+	assert.Equal(t, domain.EmailStatusPending, emails[0].Status)
+	assert.Equal(t, 2, emails[0].ChunkCount)
+	assert.Equal(t, "営業 <sales@example.com>", emails[0].From)
+
+	require.NoError(t, repo.UpdateBatchEmailStatus(emails[1].ID, domain.EmailStatusFailed, "解析結果が0件でした"))
+	emails, err = repo.ListBatchEmails(batch.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.EmailStatusFailed, emails[1].Status)
+	assert.Equal(t, "解析結果が0件でした", emails[1].ErrorMessage)
+
+	// 取り込み済みのバッチは一覧に含まれないこと
+	require.NoError(t, repo.MarkCollected(batch.ID, time.Now()))
+	batches, err = repo.ListUncollectedBatches()
+	require.NoError(t, err)
+	assert.Empty(t, batches)
+}
@@ -0,0 +1,51 @@
+package domain
+
+// DefaultLowConfidenceThreshold は低信頼度とみなす信頼度の既定値です
+const DefaultLowConfidenceThreshold = 0.5
+
+// 根拠を記録する主要項目（AnalysisResultのJSONキーと同じ）
+const (
+	EvidenceFieldProjectTitle = "案件名"
+	EvidenceFieldStartPeriod  = "開始時期"
+	EvidenceFieldEndPeriod    = "終了時期"
+	EvidenceFieldWorkLocation = "勤務場所"
+	EvidenceFieldPriceFrom    = "単価FROM"
+	EvidenceFieldPriceTo      = "単価TO"
+	EvidenceFieldLanguages    = "言語"
+	EvidenceFieldFrameworks   = "フレームワーク"
+	EvidenceFieldRemote       = "リモートワーク区分"
+)
+
+// FieldEvidence は抽出項目ごとの信頼度と根拠となる本文の引用を表すドメインモデルです
+type FieldEvidence struct {
+	Field      string  `json:"項目"`  // 項目名（例: "単価FROM"）
+	Confidence float64 `json:"信頼度"` // 0.0〜1.0（本文に明記されていれば1.0に近い）
+	Quote      string  `json:"引用"`  // 根拠となる本文の抜粋（推測の場合は空）
+}
+
+// IsLowConfidence は信頼度が閾値を下回るか、引用が無い場合にtrueを返します
+func (f FieldEvidence) IsLowConfidence(threshold float64) bool {
+	return f.Confidence < threshold || f.Quote == ""
+}
+
+// NormalizedConfidence は信頼度を0.0〜1.0の範囲に丸めて返します
+func (f FieldEvidence) NormalizedConfidence() float64 {
+	switch {
+	case f.Confidence < 0:
+		return 0
+	case f.Confidence > 1:
+		return 1
+	default:
+		return f.Confidence
+	}
+}
+
+// FindEvidence は項目名に一致する根拠を返します。見つからない場合はfalseを返します
+func FindEvidence(evidences []FieldEvidence, field string) (FieldEvidence, bool) {
+	for _, e := range evidences {
+		if e.Field == field {
+			return e, true
+		}
+	}
+	return FieldEvidence{}, false
+}
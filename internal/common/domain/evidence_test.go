@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFieldEvidence_IsLowConfidence(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    FieldEvidence
+		expected bool
+	}{
+		{
+			name:     "信頼度が閾値以上で引用がある場合はfalseを返すこと",
+			input:    FieldEvidence{Field: EvidenceFieldPriceFrom, Confidence: 0.9, Quote: "単価:80万"},
+			expected: false,
+		},
+		{
+			name:     "信頼度が閾値未満の場合はtrueを返すこと",
+			input:    FieldEvidence{Field: EvidenceFieldPriceFrom, Confidence: 0.3, Quote: "単価:スキル見合い"},
+			expected: true,
+		},
+		{
+			name:     "引用が空の場合は推測とみなしtrueを返すこと",
+			input:    FieldEvidence{Field: EvidenceFieldPriceFrom, Confidence: 0.9},
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.input.IsLowConfidence(DefaultLowConfidenceThreshold))
+		})
+	}
+}
+
+func TestFieldEvidence_NormalizedConfidence(t *testing.T) {
+	assert.Equal(t, 0.0, FieldEvidence{Confidence: -0.2}.NormalizedConfidence())
+	assert.Equal(t, 1.0, FieldEvidence{Confidence: 1.5}.NormalizedConfidence())
+	assert.Equal(t, 0.7, FieldEvidence{Confidence: 0.7}.NormalizedConfidence())
+}
+
+func TestFindEvidence(t *testing.T) {
+	evidences := []FieldEvidence{
+		{Field: EvidenceFieldProjectTitle, Confidence: 1, Quote: "■案件名:PHP開発"},
+		{Field: EvidenceFieldPriceFrom, Confidence: 0.4},
+	}
+
+	actual, ok := FindEvidence(evidences, EvidenceFieldPriceFrom)
+	assert.True(t, ok)
+	assert.Equal(t, 0.4, actual.Confidence)
+
+	_, ok = FindEvidence(evidences, EvidenceFieldWorkLocation)
+	assert.False(t, ok)
+}
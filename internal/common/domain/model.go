@@ -7,12 +7,13 @@ import (
 
 // BasicMessage はメッセージの基本モデルです
 type BasicMessage struct {
-	ID      string    `json:"id"`
-	Subject string    `json:"subject"`
-	From    string    `json:"from"`
-	To      []string  `json:"to"`
-	Date    time.Time `json:"date"`
-	Body    string    `json:"body"`
+	ID       string    `json:"id"`
+	ThreadID string    `json:"thread_id"` // Gメールのスレッド（返信・転送で共通）
+	Subject  string    `json:"subject"`
+	From     string    `json:"from"`
+	To       []string  `json:"to"`
+	Date     time.Time `json:"date"`
+	Body     string    `json:"body"`
 }
 
 // ExtractSenderName は From フィールドから送信者名を抽出します
@@ -50,11 +51,14 @@ type AnalysisResult struct {
 	RequiredSkillsWant  []string `json:"求めるスキル WANT"`
 	RemoteWorkCategory  *string  `json:"リモートワーク区分"`
 	RemoteWorkFrequency *string  `json:"リモートワークの頻度"`
+
+	Evidences []FieldEvidence `json:"根拠"` // 主要項目ごとの信頼度と引用
 }
 
 // Email は全メール共通の基本情報を表すドメインモデルです
 type Email struct {
 	GmailID      string    `json:"gmail_id"`
+	ThreadID     string    `json:"thread_id"` // Gメールのスレッド（募集終了の連絡の紐付けに使用）
 	ReceivedDate time.Time `json:"received_date"`
 	Summary      string    `json:"summary"`
 	Subject      string    `json:"subject"`
@@ -66,6 +70,9 @@ type Email struct {
 	IsGood bool `json:"is_good"` // いいね
 	IsBad  bool `json:"is_bad"`  // びみょうかも
 
+	AnalysisVersion  string `json:"analysis_version"`  // 解析に使用したプロンプト等のバージョン
+	AnalysisRevision uint   `json:"analysis_revision"` // 解析リビジョン番号（0の場合は初回解析）
+
 	Category            string   `json:"メール区分"`
 	ProjectName         string   `json:"案件名"`
 	StartPeriod         []string `json:"開始時期"`
@@ -81,6 +88,8 @@ type Email struct {
 	RequiredSkillsWant  []string `json:"求めるスキル WANT"`
 	RemoteWorkCategory  *string  `json:"リモートワーク区分"`
 	RemoteWorkFrequency *string  `json:"リモートワークの頻度"`
+
+	Evidences []FieldEvidence `json:"根拠"` // 主要項目ごとの信頼度と引用
 }
 
 // SenderName は From フィールドから送信者名を抽出します
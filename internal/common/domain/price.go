@@ -0,0 +1,343 @@
+package domain
+
+import (
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/text/width"
+)
+
+// PriceUnit は単価の単位です
+type PriceUnit string
+
+const (
+	PriceUnitMonthly PriceUnit = "monthly" // 月額（人月）
+	PriceUnitDaily   PriceUnit = "daily"   // 日額（人日）
+	PriceUnitHourly  PriceUnit = "hourly"  // 時給
+	PriceUnitYearly  PriceUnit = "yearly"  // 年収・年俸
+)
+
+// 月額への換算に使う値
+const (
+	StandardMonthlyHours = 160  // 精算幅が無い場合の月の稼働時間
+	StandardMonthlyDays  = 20   // 月の稼働日数
+	ConsumptionTaxRate   = 0.10 // 消費税率（税込の単価を税別に換算する）
+)
+
+// 税別の月額に換算した単価の妥当な範囲（外れる金額は桁や単位の読み誤りとみなして捨てる）
+const (
+	MinMonthlyPrice = 100000  // 10万円
+	MaxMonthlyPrice = 3000000 // 300万円
+)
+
+// Price は案件の単価です
+// From・To は Unit あたりの金額（円）です。TaxIncluded は税込なら true、税別なら false、記載が無ければ nil です。
+type Price struct {
+	From           *int      `json:"from,omitempty"`
+	To             *int      `json:"to,omitempty"`
+	Unit           PriceUnit `json:"unit,omitempty"`
+	TaxIncluded    *bool     `json:"tax_included,omitempty"`
+	Negotiable     bool      `json:"negotiable"`                // スキル見合い・応相談
+	SettlementFrom *int      `json:"settlement_from,omitempty"` // 精算幅の下限（時間）
+	SettlementTo   *int      `json:"settlement_to,omitempty"`   // 精算幅の上限（時間）
+}
+
+var (
+	priceSettlementRe = regexp.MustCompile(`(\d{2,3})\s*(?:h|H|時間)?\s*[~\-]\s*(\d{2,3})\s*(?:h|H|時間)`)
+	priceAmountRe     = regexp.MustCompile(`(\d[\d,]*(?:\.\d+)?)\s*(万円|万|千円|千|K円|K|k|円)?`)
+	priceLineRe       = regexp.MustCompile(`単価|金額|報酬|月額|時給|日給|日額|予算|年収|年俸`)
+	// priceBareRe は単位の無い数字だけの表記（"65"、"~70"、"60-70"、"単価：65~"）です
+	priceBareRe = regexp.MustCompile(`^(?:単価|月額|金額|報酬|予算)?\s*[:：]?\s*[~\-]?\s*(\d[\d,]*)\s*(?:[~\-]\s*(\d[\d,]*))?\s*([~\-]|以上|まで|以下)?$`)
+)
+
+// ParsePrice は単価の表記（"60万～"、"時給5,000円"、"～80万（税別）"、"スキル見合い" など）を解釈します
+// 金額も単位・税・スキル見合いの記載も無い場合は ok に false を返します。
+// 単位の無い "60～70" のような表記は "万" が後ろの金額にのみ付いていれば前の金額にも適用し、
+// 数字だけの "65"・"～70" は月額の桁（万円・千円）とみなします。年収・年俸は年額として月額に換算します。
+// 税別の月額に換算して MinMonthlyPrice〜MaxMonthlyPrice を外れる金額は読み誤りとして捨てます。
+func ParsePrice(text string) (Price, bool) {
+	s := width.Fold.String(text)
+	s = strings.NewReplacer("〜", "~", "～", "~", "∼", "~", "ー", "-", "−", "-", "–", "-").Replace(s)
+
+	p := Price{Unit: priceUnitOf(s)}
+	switch {
+	case strings.Contains(s, "税込") || strings.Contains(s, "内税"):
+		p.TaxIncluded = boolPtr(true)
+	case strings.Contains(s, "税別") || strings.Contains(s, "税抜") || strings.Contains(s, "外税") || strings.Contains(s, "+税"):
+		p.TaxIncluded = boolPtr(false)
+	}
+	p.Negotiable = strings.Contains(s, "見合") || strings.Contains(s, "相談")
+
+	// 精算幅（"140-180h"）は金額と混同しないよう先に取り除く
+	if m := priceSettlementRe.FindStringSubmatchIndex(s); m != nil {
+		from, _ := strconv.Atoi(s[m[2]:m[3]])
+		to, _ := strconv.Atoi(s[m[4]:m[5]])
+		if from > 0 && from <= to {
+			p.SettlementFrom, p.SettlementTo = intPtr(from), intPtr(to)
+		}
+		s = s[:m[0]] + " " + s[m[1]:]
+	}
+
+	amounts, first, last := priceAmounts(s)
+	if len(amounts) == 0 && (p.Unit == "" || p.Unit == PriceUnitMonthly) {
+		amounts, first, last = bareMonthlyAmounts(s)
+	}
+	switch {
+	case len(amounts) >= 2:
+		p.From, p.To = intPtr(amounts[0]), intPtr(amounts[len(amounts)-1])
+		if *p.From > *p.To {
+			p.From, p.To = p.To, p.From
+		}
+	case len(amounts) == 1:
+		before := strings.TrimSpace(s[:first])
+		after := strings.TrimSpace(s[last:])
+		switch {
+		case strings.HasSuffix(before, "~") || strings.Contains(before, "上限") || strings.Contains(before, "MAX") || strings.Contains(before, "max") ||
+			strings.HasPrefix(after, "まで") || strings.HasPrefix(after, "以下"):
+			p.To = intPtr(amounts[0])
+		case strings.HasPrefix(after, "~") || strings.HasPrefix(after, "以上") || strings.Contains(before, "下限"):
+			p.From = intPtr(amounts[0])
+		default:
+			p.From, p.To = intPtr(amounts[0]), intPtr(amounts[0])
+		}
+	}
+
+	if p.Unit == "" {
+		p.Unit = PriceUnitMonthly
+	}
+	p.From, p.To = p.plausible(p.From), p.plausible(p.To)
+	if p.From == nil && p.To == nil && !p.Negotiable && p.TaxIncluded == nil {
+		return Price{}, false
+	}
+	return p, true
+}
+
+// ResolvePrice は単価の表記と解析結果の単価FROM/TOから単価を決めます
+// 表記から金額を読み取れない場合は単価FROM/TOを使い、"60"（万円）や "600"（千円）のような桁の誤りを月額の円に直します。
+func ResolvePrice(text string, from, to *int) Price {
+	p, ok := ParsePrice(text)
+	if ok && (p.From != nil || p.To != nil) {
+		return p
+	}
+	if !ok {
+		p = Price{Unit: PriceUnitMonthly}
+	}
+	if p.Unit == PriceUnitMonthly {
+		p.From, p.To = fixMonthlyMagnitude(from), fixMonthlyMagnitude(to)
+	} else {
+		p.From, p.To = positive(from), positive(to)
+	}
+	p.From, p.To = p.plausible(p.From), p.plausible(p.To)
+	if p.From == nil && p.To == nil && !p.Negotiable && p.TaxIncluded == nil {
+		return Price{}
+	}
+	return p
+}
+
+// PriceSourceText は単価を解釈する表記を返します
+// 単価FROM/TOの根拠の引用（重複を除く）を優先し、無ければ本文の単価の行を使います。
+func PriceSourceText(quotes []string, body string) string {
+	var texts []string
+	for _, q := range quotes {
+		if q = strings.TrimSpace(q); q != "" && !slices.Contains(texts, q) {
+			texts = append(texts, q)
+		}
+	}
+	if len(texts) > 0 {
+		return strings.Join(texts, " ")
+	}
+	return PriceText(body)
+}
+
+// PriceText は本文から単価が書かれた行を返します
+// 単価・金額・時給などの語を含み、数字または "見合" を含む最初の行です。見つからない場合は空文字を返します。
+func PriceText(body string) string {
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimSpace(line)
+		if !priceLineRe.MatchString(line) {
+			continue
+		}
+		if strings.ContainsAny(width.Fold.String(line), "0123456789") || strings.Contains(line, "見合") {
+			return line
+		}
+	}
+	return ""
+}
+
+// IsZero は金額・単位などの情報が何も無いかどうかを返します
+func (p Price) IsZero() bool {
+	return p.From == nil && p.To == nil && p.Unit == "" && p.TaxIncluded == nil && !p.Negotiable
+}
+
+// MonthlyFrom は単価FROMを税別の月額に換算して返します
+func (p Price) MonthlyFrom() *int {
+	return p.monthly(p.From)
+}
+
+// MonthlyTo は単価TOを税別の月額に換算して返します
+func (p Price) MonthlyTo() *int {
+	return p.monthly(p.To)
+}
+
+// MonthlyHours は時給を月額に換算するときの稼働時間です（精算幅の中央、無ければ StandardMonthlyHours）
+func (p Price) MonthlyHours() int {
+	if p.SettlementFrom != nil && p.SettlementTo != nil {
+		return (*p.SettlementFrom + *p.SettlementTo) / 2
+	}
+	return StandardMonthlyHours
+}
+
+// monthly は金額を税別の月額に換算します
+func (p Price) monthly(amount *int) *int {
+	if amount == nil {
+		return nil
+	}
+	v := float64(*amount)
+	switch p.Unit {
+	case PriceUnitHourly:
+		v *= float64(p.MonthlyHours())
+	case PriceUnitDaily:
+		v *= StandardMonthlyDays
+	case PriceUnitYearly:
+		v /= 12
+	}
+	if p.TaxIncluded != nil && *p.TaxIncluded {
+		v /= 1 + ConsumptionTaxRate
+	}
+	return intPtr(int(math.Round(v)))
+}
+
+// plausible は税別の月額に換算して妥当な範囲の金額のみを返します
+func (p Price) plausible(amount *int) *int {
+	m := p.monthly(amount)
+	if m == nil || *m < MinMonthlyPrice || *m > MaxMonthlyPrice {
+		return nil
+	}
+	return amount
+}
+
+// priceUnitOf は表記から単価の単位を判定します（判定できない場合は空文字）
+func priceUnitOf(s string) PriceUnit {
+	switch {
+	case strings.Contains(s, "年収") || strings.Contains(s, "年俸") || strings.Contains(s, "年額") || strings.Contains(s, "/年"):
+		return PriceUnitYearly
+	case strings.Contains(s, "時給") || strings.Contains(s, "時間単価") || strings.Contains(s, "/h") || strings.Contains(s, "/時間"):
+		return PriceUnitHourly
+	case strings.Contains(s, "日給") || strings.Contains(s, "日額") || strings.Contains(s, "日単価") || strings.Contains(s, "/日") || strings.Contains(s, "人日"):
+		return PriceUnitDaily
+	case strings.Contains(s, "月額") || strings.Contains(s, "/月") || strings.Contains(s, "人月") || strings.Contains(s, "月単価"):
+		return PriceUnitMonthly
+	}
+	return ""
+}
+
+// priceAmounts は表記から金額（円）を順に取り出し、最初の金額の開始位置と最後の金額の終了位置を返します
+// 単位（万・千・K・円）の無い数字は、"~" または "-" を挟んで単位付きの金額が続く場合のみ金額とみなします。
+func priceAmounts(s string) ([]int, int, int) {
+	matches := priceAmountRe.FindAllStringSubmatchIndex(s, -1)
+	var amounts []int
+	first, last := -1, -1
+	for i, m := range matches {
+		suffix := ""
+		if m[4] >= 0 {
+			suffix = s[m[4]:m[5]]
+		}
+		if suffix == "" && i+1 < len(matches) {
+			next := matches[i+1]
+			sep := strings.TrimSpace(s[m[1]:next[0]])
+			if (sep == "~" || sep == "-") && next[4] >= 0 {
+				suffix = s[next[4]:next[5]]
+			}
+		}
+		if suffix == "" {
+			continue
+		}
+
+		v, err := strconv.ParseFloat(strings.ReplaceAll(s[m[2]:m[3]], ",", ""), 64)
+		if err != nil || v <= 0 {
+			continue
+		}
+		switch suffix {
+		case "万", "万円":
+			v *= 10000
+		case "千", "千円", "K", "K円", "k":
+			v *= 1000
+		}
+		amounts = append(amounts, int(math.Round(v)))
+		if first < 0 {
+			first = m[0]
+		}
+		last = m[1]
+	}
+	return amounts, first, last
+}
+
+// bareMonthlyAmounts は単位の無い数字だけの表記から月額の金額（円）を取り出します
+// 税の記載を除いて数字と範囲の記号しか無い場合のみ読み取り、"面談2回" のような数字は金額とみなしません。
+func bareMonthlyAmounts(s string) ([]int, int, int) {
+	stripped := s
+	for _, w := range []string{"税別", "税込", "税抜", "内税", "外税", "+税", "/月", "人月", "(", ")"} {
+		stripped = strings.ReplaceAll(stripped, w, " ")
+	}
+	m := priceBareRe.FindStringSubmatch(strings.TrimSpace(stripped))
+	if m == nil {
+		return nil, -1, -1
+	}
+
+	var amounts []int
+	for _, g := range []string{m[1], m[2]} {
+		if g == "" {
+			continue
+		}
+		v, err := strconv.Atoi(strings.ReplaceAll(g, ",", ""))
+		if err != nil {
+			continue
+		}
+		if fixed := fixMonthlyMagnitude(&v); fixed != nil {
+			amounts = append(amounts, *fixed)
+		}
+	}
+	if len(amounts) == 0 {
+		return nil, -1, -1
+	}
+	// 前後の "~"・"以上"・"まで" で上限・下限を判定できるよう、元の表記での位置を返す
+	first := strings.Index(s, m[1])
+	last := first + len(m[1])
+	if m[2] != "" {
+		last = strings.LastIndex(s, m[2]) + len(m[2])
+	}
+	return amounts, first, last
+}
+
+// fixMonthlyMagnitude は月額の桁の誤りを直します
+// 300未満は万円（60 → 600,000）、3,000未満は千円（600 → 600,000）とみなします。
+func fixMonthlyMagnitude(v *int) *int {
+	switch {
+	case v == nil || *v <= 0:
+		return nil
+	case *v < 300:
+		return intPtr(*v * 10000)
+	case *v < 3000:
+		return intPtr(*v * 1000)
+	default:
+		return intPtr(*v)
+	}
+}
+
+func positive(v *int) *int {
+	if v == nil || *v <= 0 {
+		return nil
+	}
+	return intPtr(*v)
+}
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func boolPtr(v bool) *bool {
+	return &v
+}
@@ -0,0 +1,167 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePrice(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected Price
+		ok       bool
+	}{
+		{
+			name:     "万円の下限のみを読み取れること",
+			input:    "60万～",
+			expected: Price{From: intPtr(600000), Unit: PriceUnitMonthly},
+			ok:       true,
+		},
+		{
+			name:     "上限のみと税別を読み取れること",
+			input:    "〜80万（税別）",
+			expected: Price{To: intPtr(800000), Unit: PriceUnitMonthly, TaxIncluded: boolPtr(false)},
+			ok:       true,
+		},
+		{
+			name:     "時給を読み取れること",
+			input:    "時給5,000円",
+			expected: Price{From: intPtr(5000), To: intPtr(5000), Unit: PriceUnitHourly},
+			ok:       true,
+		},
+		{
+			name:     "スキル見合いは金額なしで応相談になること",
+			input:    "スキル見合い",
+			expected: Price{Unit: PriceUnitMonthly, Negotiable: true},
+			ok:       true,
+		},
+		{
+			name:     "後ろの単位を前の金額にも適用し、精算幅を読み取れること",
+			input:    "単価：60～70万円/月（精算幅140-180h）",
+			expected: Price{From: intPtr(600000), To: intPtr(700000), Unit: PriceUnitMonthly, SettlementFrom: intPtr(140), SettlementTo: intPtr(180)},
+			ok:       true,
+		},
+		{
+			name:     "K表記と税込を読み取れること",
+			input:    "550K～650K 税込",
+			expected: Price{From: intPtr(550000), To: intPtr(650000), Unit: PriceUnitMonthly, TaxIncluded: boolPtr(true)},
+			ok:       true,
+		},
+		{
+			name:     "日額を読み取れること",
+			input:    "日額3万円",
+			expected: Price{From: intPtr(30000), To: intPtr(30000), Unit: PriceUnitDaily},
+			ok:       true,
+		},
+		{
+			name:  "単位の無い数字のみの場合は読み取らないこと",
+			input: "面談2回",
+			ok:    false,
+		},
+		{
+			name:     "年収を年額として読み取ること",
+			input:    "年収600万",
+			expected: Price{From: intPtr(6000000), To: intPtr(6000000), Unit: PriceUnitYearly},
+			ok:       true,
+		},
+		{
+			name:     "年俸の範囲を年額として読み取ること",
+			input:    "年俸500万～800万円",
+			expected: Price{From: intPtr(5000000), To: intPtr(8000000), Unit: PriceUnitYearly},
+			ok:       true,
+		},
+		{
+			name:  "月額として多すぎる金額は読み誤りとして捨てること",
+			input: "1,000万",
+			ok:    false,
+		},
+		{
+			name:     "妥当な範囲を外れる金額のみを捨てること",
+			input:    "単価：1,000万（税別）",
+			expected: Price{Unit: PriceUnitMonthly, TaxIncluded: boolPtr(false)},
+			ok:       true,
+		},
+		{
+			name:     "数字だけの表記を万円とみなすこと",
+			input:    "65",
+			expected: Price{From: intPtr(650000), To: intPtr(650000), Unit: PriceUnitMonthly},
+			ok:       true,
+		},
+		{
+			name:     "数字だけの上限を万円とみなすこと",
+			input:    "～70",
+			expected: Price{To: intPtr(700000), Unit: PriceUnitMonthly},
+			ok:       true,
+		},
+		{
+			name:     "数字だけの範囲と税別を読み取れること",
+			input:    "単価：60-70（税別）",
+			expected: Price{From: intPtr(600000), To: intPtr(700000), Unit: PriceUnitMonthly, TaxIncluded: boolPtr(false)},
+			ok:       true,
+		},
+		{
+			name:  "数字だけでも月額として少なすぎる金額は読み取らないこと",
+			input: "5",
+			ok:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, ok := ParsePrice(tt.input)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, p)
+		})
+	}
+}
+
+func TestPrice_Monthly(t *testing.T) {
+	hourly := Price{From: intPtr(5000), To: intPtr(6000), Unit: PriceUnitHourly}
+	assert.Equal(t, 800000, *hourly.MonthlyFrom())
+	assert.Equal(t, 960000, *hourly.MonthlyTo())
+
+	// 精算幅がある場合はその中央の時間で換算する
+	hourly.SettlementFrom, hourly.SettlementTo = intPtr(140), intPtr(180)
+	assert.Equal(t, 800000, *hourly.MonthlyFrom())
+	hourly.SettlementFrom, hourly.SettlementTo = intPtr(150), intPtr(170)
+	assert.Equal(t, 800000, *hourly.MonthlyFrom())
+
+	daily := Price{From: intPtr(30000), Unit: PriceUnitDaily}
+	assert.Equal(t, 600000, *daily.MonthlyFrom())
+	assert.Nil(t, daily.MonthlyTo())
+
+	// 年額は12か月で割る
+	yearly := Price{From: intPtr(6000000), Unit: PriceUnitYearly}
+	assert.Equal(t, 500000, *yearly.MonthlyFrom())
+
+	// 税込は税別に換算する
+	taxed := Price{From: intPtr(880000), Unit: PriceUnitMonthly, TaxIncluded: boolPtr(true)}
+	assert.Equal(t, 800000, *taxed.MonthlyFrom())
+}
+
+func TestResolvePrice(t *testing.T) {
+	// 表記から金額を読み取れた場合は表記を優先する
+	p := ResolvePrice("時給5,000円", intPtr(5), nil)
+	assert.Equal(t, PriceUnitHourly, p.Unit)
+	assert.Equal(t, 5000, *p.From)
+
+	// 表記に金額が無い場合は解析結果の桁の誤りを直して使う
+	p = ResolvePrice("スキル見合い（税別）", intPtr(60), intPtr(700))
+	assert.Equal(t, Price{From: intPtr(600000), To: intPtr(700000), Unit: PriceUnitMonthly, TaxIncluded: boolPtr(false), Negotiable: true}, p)
+
+	p = ResolvePrice("", intPtr(650000), nil)
+	assert.Equal(t, Price{From: intPtr(650000), Unit: PriceUnitMonthly}, p)
+
+	// 解析結果も妥当な範囲を外れる金額は捨てる
+	assert.True(t, ResolvePrice("", intPtr(10000000), nil).IsZero())
+
+	assert.True(t, ResolvePrice("", nil, nil).IsZero())
+}
+
+func TestPriceText(t *testing.T) {
+	body := "【案件】Go開発\n【場所】渋谷\n【単価】70万円（精算幅140-180h）\n【面談】2回"
+	assert.Equal(t, "【単価】70万円（精算幅140-180h）", PriceText(body))
+	assert.Equal(t, "", PriceText("【場所】渋谷"))
+}
@@ -0,0 +1,16 @@
+// Package application は案件の重複検出機能のアプリケーション層を提供します。
+// このファイルは重複検出のユースケースインターフェースを定義します。
+package application
+
+import (
+	"business/internal/dedup/domain"
+)
+
+// UseCaseInterface は案件の重複検出のユースケースインターフェースです
+type UseCaseInterface interface {
+	// DedupProjects は受信日が一定日数以内の案件の重複グループを作り直します
+	DedupProjects(opts domain.DedupOptions) (domain.DedupResult, error)
+
+	// GetCluster は重複グループを、含まれる案件の差出人・単価付きで返します
+	GetCluster(id uint) (domain.Cluster, error)
+}
@@ -0,0 +1,201 @@
+// Package application は案件の重複検出機能のアプリケーション層を提供します。
+// このファイルは重複グループの作成と参照のユースケースを実装します。
+package application
+
+import (
+	"business/internal/dedup/domain"
+	r "business/internal/dedup/infrastructure"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// UseCase は案件の重複検出のユースケースの具象です
+type UseCase struct {
+	r r.RepositoryInterface
+}
+
+// New は案件の重複検出のユースケースを作成します
+func New(r r.RepositoryInterface) *UseCase {
+	return &UseCase{
+		r: r,
+	}
+}
+
+// edge は同じ案件とみなした2案件の組です（添字は指紋の配列の位置）
+type edge struct {
+	a, b  int
+	score float64
+}
+
+// DedupProjects は受信日が opts.WindowDays 日以内の案件の重複グループを作り直します
+// 本文の要旨の MinHash を LSH のバンドに分けて候補の組を絞り込み、類似度が opts.Threshold 以上の組を類似度の高い順にまとめます。
+// 同じメールに載っている別案件は同じグループにしません。範囲内の案件を含む既存のグループは、範囲外の案件も含めて作り直します。
+func (u *UseCase) DedupProjects(opts domain.DedupOptions) (domain.DedupResult, error) {
+	if opts.Threshold <= 0 {
+		opts.Threshold = domain.DefaultThreshold
+	}
+	if opts.WindowDays <= 0 {
+		opts.WindowDays = domain.DefaultWindowDays
+	}
+
+	since := time.Now().AddDate(0, 0, -opts.WindowDays)
+	sources, err := u.r.ListProjectSources(since)
+	if err != nil {
+		return domain.DedupResult{}, fmt.Errorf("重複検出エラー: %w", err)
+	}
+
+	result := domain.DedupResult{Projects: len(sources)}
+	fps := make([]domain.Fingerprint, len(sources))
+	for i, s := range sources {
+		fps[i] = domain.NewFingerprint(s)
+	}
+
+	edges, candidates := similarPairs(fps, opts.Threshold)
+	result.Candidates = candidates
+	clusters := groupClusters(sources, edges)
+	for _, c := range clusters {
+		result.Duplicates += c.Size
+	}
+	result.Clusters = len(clusters)
+
+	projectIDs := make([]uint, 0, len(sources))
+	for _, s := range sources {
+		projectIDs = append(projectIDs, s.ProjectID)
+	}
+	if err := u.r.ReplaceClusters(projectIDs, clusters); err != nil {
+		return domain.DedupResult{}, fmt.Errorf("重複検出エラー: %w", err)
+	}
+	return result, nil
+}
+
+// GetCluster は重複グループを、含まれる案件の差出人・単価付きで返します
+func (u *UseCase) GetCluster(id uint) (domain.Cluster, error) {
+	cluster, err := u.r.FindCluster(id)
+	if err != nil {
+		return domain.Cluster{}, fmt.Errorf("重複グループ取得エラー: %w", err)
+	}
+	return cluster, nil
+}
+
+// similarPairs は LSH のバンドが一致する組の類似度を計算し、threshold 以上の組を返します
+// 2つ目の戻り値は類似度を計算した組の数です。
+func similarPairs(fps []domain.Fingerprint, threshold float64) ([]edge, int) {
+	buckets := map[uint64][]int{}
+	for i, fp := range fps {
+		for _, key := range fp.BandKeys() {
+			buckets[key] = append(buckets[key], i)
+		}
+	}
+
+	type pair struct{ a, b int }
+	checked := map[pair]struct{}{}
+	var edges []edge
+	for _, members := range buckets {
+		for x := 0; x < len(members); x++ {
+			for y := x + 1; y < len(members); y++ {
+				p := pair{a: members[x], b: members[y]}
+				if p.a > p.b {
+					p.a, p.b = p.b, p.a
+				}
+				if _, ok := checked[p]; ok {
+					continue
+				}
+				checked[p] = struct{}{}
+				if !domain.Compatible(fps[p.a], fps[p.b]) {
+					continue
+				}
+				if score := domain.Similarity(fps[p.a], fps[p.b]); score >= threshold {
+					edges = append(edges, edge{a: p.a, b: p.b, score: score})
+				}
+			}
+		}
+	}
+	return edges, len(checked)
+}
+
+// groupClusters は組を類似度の高い順に併合し、2件以上の重複グループを返します
+// 併合すると同じメールの案件が同じグループに入る場合は併合しません。
+func groupClusters(sources []domain.ProjectSource, edges []edge) []domain.Cluster {
+	sort.SliceStable(edges, func(i, j int) bool {
+		if edges[i].score != edges[j].score {
+			return edges[i].score > edges[j].score
+		}
+		if edges[i].a != edges[j].a {
+			return edges[i].a < edges[j].a
+		}
+		return edges[i].b < edges[j].b
+	})
+
+	parent := make([]int, len(sources))
+	emails := make([]map[uint]struct{}, len(sources))
+	for i, s := range sources {
+		parent[i] = i
+		emails[i] = map[uint]struct{}{s.EmailID: {}}
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	scores := make([]float64, len(sources))
+	for _, e := range edges {
+		ra, rb := find(e.a), find(e.b)
+		if ra != rb {
+			if sharesEmail(emails[ra], emails[rb]) {
+				continue
+			}
+			parent[rb] = ra
+			for id := range emails[rb] {
+				emails[ra][id] = struct{}{}
+			}
+		}
+		scores[e.a] = max(scores[e.a], e.score)
+		scores[e.b] = max(scores[e.b], e.score)
+	}
+
+	byRoot := map[int][]int{}
+	var roots []int
+	for i := range sources {
+		root := find(i)
+		if _, ok := byRoot[root]; !ok {
+			roots = append(roots, root)
+		}
+		byRoot[root] = append(byRoot[root], i)
+	}
+
+	var clusters []domain.Cluster
+	for _, root := range roots {
+		members := byRoot[root]
+		if len(members) < 2 {
+			continue
+		}
+		c := domain.Cluster{}
+		for _, i := range members {
+			s := sources[i]
+			c.Members = append(c.Members, domain.Member{
+				ProjectID:    s.ProjectID,
+				Score:        scores[i],
+				SenderEmail:  s.SenderEmail,
+				ReceivedDate: s.ReceivedDate,
+				PriceFrom:    s.PriceFrom,
+				PriceTo:      s.PriceTo,
+			})
+		}
+		c.Summarize()
+		clusters = append(clusters, c)
+	}
+	return clusters
+}
+
+func sharesEmail(a, b map[uint]struct{}) bool {
+	for id := range a {
+		if _, ok := b[id]; ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,101 @@
+package application
+
+import (
+	"business/internal/dedup/domain"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockRepository は案件の重複検出リポジトリのモックです
+type MockRepository struct {
+	mock.Mock
+}
+
+func (m *MockRepository) ListProjectSources(since time.Time) ([]domain.ProjectSource, error) {
+	args := m.Called(since)
+	return args.Get(0).([]domain.ProjectSource), args.Error(1)
+}
+
+func (m *MockRepository) ReplaceClusters(projectIDs []uint, clusters []domain.Cluster) error {
+	args := m.Called(projectIDs, clusters)
+	return args.Error(0)
+}
+
+func (m *MockRepository) FindCluster(id uint) (domain.Cluster, error) {
+	args := m.Called(id)
+	return args.Get(0).(domain.Cluster), args.Error(1)
+}
+
+const projectBody = `【案件名】物流会社向け在庫管理システムのリプレイス
+【内容】Goによるバックエンドの設計・開発、AWS上のマイクロサービス化
+【スキル】Go、AWS、Docker、MySQLでの開発経験3年以上
+【場所】東京都港区（田町駅）
+【期間】7月～長期`
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func TestDedupProjects(t *testing.T) {
+	repo := new(MockRepository)
+	usecase := New(repo)
+
+	base := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+	project := func(id, emailID uint, sender string, hours int, price int) domain.ProjectSource {
+		return domain.ProjectSource{
+			ProjectID: id, EmailID: emailID, SenderEmail: sender, ReceivedDate: base.Add(time.Duration(hours) * time.Hour),
+			ProjectTitle: "在庫管理システムのリプレイス", Body: projectBody,
+			SkillIDs: []uint{1, 2}, Prefectures: []string{"東京都"}, Stations: []string{"田町"}, PriceTo: intPtr(price),
+		}
+	}
+	sources := []domain.ProjectSource{
+		project(1, 1, "a@agency-a.example.com", 0, 700000),
+		project(2, 2, "b@agency-b.example.com", 1, 750000),
+		project(3, 3, "c@agency-c.example.com", 2, 800000),
+		// 同じメールに載っている別案件は同じグループにしない
+		project(4, 3, "c@agency-c.example.com", 2, 800000),
+		{
+			ProjectID: 5, EmailID: 5, SenderEmail: "d@agency-d.example.com", ReceivedDate: base,
+			ProjectTitle: "取引画面のフロントエンド開発", Body: strings.Repeat("React・TypeScriptによる画面開発\n", 3),
+		},
+	}
+	repo.On("ListProjectSources", mock.Anything).Return(sources, nil)
+	repo.On("ReplaceClusters", []uint{1, 2, 3, 4, 5}, mock.Anything).Return(nil)
+
+	result, err := usecase.DedupProjects(domain.DedupOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 5, result.Projects)
+	assert.Equal(t, 1, result.Clusters)
+	assert.Equal(t, 3, result.Duplicates)
+
+	clusters := repo.Calls[1].Arguments.Get(1).([]domain.Cluster)
+	require.Len(t, clusters, 1)
+	c := clusters[0]
+	assert.Equal(t, 3, c.Size)
+	assert.Equal(t, 3, c.AgencyCount)
+	assert.Equal(t, 700000, *c.PriceMin)
+	assert.Equal(t, 800000, *c.PriceMax)
+	// 受信日が最も新しい案件（同時刻は案件IDの大きい方）を代表にする
+	assert.Contains(t, []uint{3, 4}, c.RepresentativeProjectID)
+	var emails []uint
+	for _, m := range c.Members {
+		emails = append(emails, sources[m.ProjectID-1].EmailID)
+	}
+	assert.ElementsMatch(t, []uint{1, 2, 3}, emails)
+}
+
+func TestGetCluster_NotFound(t *testing.T) {
+	repo := new(MockRepository)
+	usecase := New(repo)
+
+	repo.On("FindCluster", uint(9)).Return(domain.Cluster{}, domain.ErrClusterNotFound)
+
+	_, err := usecase.GetCluster(9)
+	assert.True(t, errors.Is(err, domain.ErrClusterNotFound))
+}
@@ -0,0 +1,137 @@
+package domain
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+)
+
+// 重複検出の既定値
+const (
+	DefaultThreshold  = 0.7 // 同じ案件とみなす類似度の下限
+	DefaultWindowDays = 60  // 重複を検出する受信日の範囲（日数）
+)
+
+// ErrClusterNotFound は重複グループが存在しない場合のエラーです
+var ErrClusterNotFound = errors.New("重複グループが見つかりません")
+
+// ProjectSource は指紋の作成に使う保存済み案件の情報です
+type ProjectSource struct {
+	ProjectID    uint
+	EmailID      uint
+	ReceivedDate time.Time
+	SenderEmail  string
+	ProjectTitle string
+	Body         string
+	SkillIDs     []uint
+	Prefectures  []string
+	Stations     []string
+	StartFrom    *time.Time // 最も早い入場時期の開始日
+	PriceFrom    *int       // 税別の月額に換算した単価FROM
+	PriceTo      *int       // 税別の月額に換算した単価TO
+}
+
+// monthlyPrice は単価帯の計算に使う月額（TO を優先）を返します
+func (p ProjectSource) monthlyPrice() *int {
+	if p.PriceTo != nil {
+		return p.PriceTo
+	}
+	return p.PriceFrom
+}
+
+// Cluster は同じ案件とみなした案件の重複グループです
+// 代表（RepresentativeProjectID）は最も新しく受信した案件で、一覧をまとめる場合はこの案件を表示します。
+type Cluster struct {
+	ID                      uint      `json:"id"`
+	RepresentativeProjectID uint      `json:"representative_project_id"`
+	Size                    int       `json:"size"`         // 案件数
+	AgencyCount             int       `json:"agency_count"` // 営業会社数（差出人のドメイン数）
+	PriceMin                *int      `json:"price_min"`    // 税別の月額に換算した単価の最小
+	PriceMax                *int      `json:"price_max"`    // 税別の月額に換算した単価の最大
+	FirstReceivedAt         time.Time `json:"first_received_at"`
+	LastReceivedAt          time.Time `json:"last_received_at"`
+	Members                 []Member  `json:"members"`
+}
+
+// Member は重複グループに含まれる案件です
+type Member struct {
+	ProjectID      uint      `json:"project_id"`
+	Score          float64   `json:"score"` // グループ内の他の案件との最大の類似度
+	Representative bool      `json:"representative"`
+	GmailID        string    `json:"gmail_id,omitempty"`
+	Subject        string    `json:"subject,omitempty"`
+	ProjectTitle   string    `json:"project_title,omitempty"`
+	SenderName     string    `json:"sender_name,omitempty"`
+	SenderEmail    string    `json:"sender_email,omitempty"`
+	Agency         string    `json:"agency,omitempty"` // 差出人のドメイン
+	ReceivedDate   time.Time `json:"received_date"`
+	PriceFrom      *int      `json:"price_from"` // 税別の月額に換算した単価FROM
+	PriceTo        *int      `json:"price_to"`   // 税別の月額に換算した単価TO
+}
+
+// DedupOptions は重複検出の設定です
+type DedupOptions struct {
+	Threshold  float64 // 同じ案件とみなす類似度の下限（0以下の場合は DefaultThreshold）
+	WindowDays int     // 受信日がこの日数以内の案件を対象にする（0以下の場合は DefaultWindowDays）
+}
+
+// DedupResult は重複検出の実行結果です
+type DedupResult struct {
+	Projects   int `json:"projects"`   // 対象にした案件数
+	Candidates int `json:"candidates"` // 類似度を計算した組の数
+	Clusters   int `json:"clusters"`   // 作成した重複グループ数
+	Duplicates int `json:"duplicates"` // 重複グループに含めた案件数
+}
+
+// AgencyOf は差出人のメールアドレスから営業会社（ドメイン）を返します
+func AgencyOf(senderEmail string) string {
+	i := strings.LastIndex(senderEmail, "@")
+	if i < 0 {
+		return strings.ToLower(senderEmail)
+	}
+	return strings.ToLower(senderEmail[i+1:])
+}
+
+// Summarize は案件数・営業会社数・単価の範囲・受信日の範囲を Members から計算し、代表を設定します
+func (c *Cluster) Summarize() {
+	sort.SliceStable(c.Members, func(i, j int) bool {
+		if !c.Members[i].ReceivedDate.Equal(c.Members[j].ReceivedDate) {
+			return c.Members[i].ReceivedDate.Before(c.Members[j].ReceivedDate)
+		}
+		return c.Members[i].ProjectID < c.Members[j].ProjectID
+	})
+
+	c.Size = len(c.Members)
+	c.PriceMin, c.PriceMax = nil, nil
+	agencies := map[string]struct{}{}
+	for i := range c.Members {
+		m := &c.Members[i]
+		m.Representative = i == len(c.Members)-1
+		if m.Agency == "" {
+			m.Agency = AgencyOf(m.SenderEmail)
+		}
+		agencies[m.Agency] = struct{}{}
+		for _, price := range []*int{m.PriceFrom, m.PriceTo} {
+			if price == nil {
+				continue
+			}
+			if c.PriceMin == nil || *price < *c.PriceMin {
+				c.PriceMin = intPtr(*price)
+			}
+			if c.PriceMax == nil || *price > *c.PriceMax {
+				c.PriceMax = intPtr(*price)
+			}
+		}
+	}
+	c.AgencyCount = len(agencies)
+	if c.Size > 0 {
+		c.FirstReceivedAt = c.Members[0].ReceivedDate
+		c.LastReceivedAt = c.Members[c.Size-1].ReceivedDate
+		c.RepresentativeProjectID = c.Members[c.Size-1].ProjectID
+	}
+}
+
+func intPtr(v int) *int {
+	return &v
+}
@@ -0,0 +1,333 @@
+// Package domain は案件の重複検出（複数の営業会社から届く同一案件のまとめ）機能のドメイン層を提供します。
+// このファイルは案件の指紋（MinHash・SimHash）と類似度の計算を定義します。
+package domain
+
+import (
+	"hash/fnv"
+	"math/bits"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+
+	"golang.org/x/text/width"
+)
+
+// 指紋の設定
+const (
+	MinHashSize  = 64 // MinHash の署名の長さ
+	LSHBands     = 16 // 候補の絞り込みに使うバンド数（MinHashSize を割り切れる数）
+	ShingleSize  = 3  // 本文のシングル（文字 n-gram）の長さ
+	TitleShingle = 2  // 案件名のシングルの長さ
+	PriceBandYen = 100000
+)
+
+// 類似度の重み（合計 1.0）
+const (
+	weightBody     = 0.45
+	weightTitle    = 0.15
+	weightSkills   = 0.20
+	weightLocation = 0.10
+	weightStart    = 0.05
+	weightPrice    = 0.05
+)
+
+// unknownScore は片方または両方の値が無い項目の類似度です（一致とも不一致ともみなさない）
+const unknownScore = 0.5
+
+// Fingerprint は案件の重複検出に使う指紋です
+type Fingerprint struct {
+	ProjectID   uint
+	EmailID     uint
+	SkillIDs    []uint   // 正規化した技術キーワード（キーワードグループID）
+	Prefectures []string // 勤務地の都道府県
+	Stations    []string // 勤務地の最寄り駅
+	StartMonth  string   // 最も早い入場時期の月（"2025-07"。不明は空）
+	PriceBand   int      // 税別の月額を PriceBandYen 単位にした値（不明は -1）
+	BodyHash    []uint64 // 本文の要旨の MinHash 署名
+	TitleHash   uint64   // 案件名の SimHash
+}
+
+var (
+	reNoiseLine = regexp.MustCompile(`@|https?://|tel|電話|〒|お世話|お疲れ|よろしく|宜しく|ご確認|ご提案|ご紹介|配信|署名|株式会社|単価|金額|報酬|月額|時給|精算`)
+	reRule      = regexp.MustCompile(`^[-=_*#━─＝■□◆◇●○・\s]+$`)
+)
+
+// NewFingerprint は案件の情報から指紋を作成します
+func NewFingerprint(p ProjectSource) Fingerprint {
+	fp := Fingerprint{
+		ProjectID:   p.ProjectID,
+		EmailID:     p.EmailID,
+		SkillIDs:    p.SkillIDs,
+		Prefectures: p.Prefectures,
+		Stations:    p.Stations,
+		PriceBand:   -1,
+		BodyHash:    MinHash(Shingles(KeyPhrases(p.Body), ShingleSize)),
+		TitleHash:   SimHash(Shingles(foldText(p.ProjectTitle), TitleShingle)),
+	}
+	if p.StartFrom != nil {
+		fp.StartMonth = p.StartFrom.Format("2006-01")
+	}
+	if price := p.monthlyPrice(); price != nil {
+		fp.PriceBand = *price / PriceBandYen
+	}
+	return fp
+}
+
+// KeyPhrases は本文から営業会社ごとに異なる部分（挨拶・署名・連絡先・単価の行）を除いた要旨を返します
+// 記号と空白も取り除くため、罫線や箇条書きの記号の違いは類似度に影響しません。
+func KeyPhrases(body string) string {
+	var b strings.Builder
+	for _, line := range strings.Split(foldText(body), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || reRule.MatchString(line) || reNoiseLine.MatchString(line) {
+			continue
+		}
+		for _, r := range line {
+			if unicode.IsLetter(r) || unicode.IsNumber(r) {
+				b.WriteRune(r)
+			}
+		}
+	}
+	return b.String()
+}
+
+// Shingles は文字列を n 文字ずつずらした部分文字列（シングル）のハッシュ値を重複なく返します
+// n 文字に満たない場合は文字列全体を1つのシングルとします。
+func Shingles(s string, n int) []uint64 {
+	runes := []rune(s)
+	if len(runes) == 0 {
+		return nil
+	}
+	if len(runes) < n {
+		return []uint64{hashString(s)}
+	}
+	seen := make(map[uint64]struct{}, len(runes))
+	result := make([]uint64, 0, len(runes))
+	for i := 0; i+n <= len(runes); i++ {
+		h := hashString(string(runes[i : i+n]))
+		if _, ok := seen[h]; ok {
+			continue
+		}
+		seen[h] = struct{}{}
+		result = append(result, h)
+	}
+	return result
+}
+
+// MinHash はシングルの集合の MinHash 署名を返します（シングルが無い場合は nil）
+// 2つの署名で値が一致する位置の割合が、元の集合の Jaccard 係数の推定値になります。
+func MinHash(shingles []uint64) []uint64 {
+	if len(shingles) == 0 {
+		return nil
+	}
+	sig := make([]uint64, MinHashSize)
+	for i := range sig {
+		sig[i] = ^uint64(0)
+	}
+	for _, s := range shingles {
+		for i := range sig {
+			if h := mix(s ^ minHashSeeds[i]); h < sig[i] {
+				sig[i] = h
+			}
+		}
+	}
+	return sig
+}
+
+// SimHash はシングルの集合の SimHash を返します（似た集合ほどビットの差が小さくなります）
+func SimHash(shingles []uint64) uint64 {
+	var counts [64]int
+	for _, s := range shingles {
+		h := mix(s)
+		for i := range counts {
+			if h&(1<<uint(i)) != 0 {
+				counts[i]++
+			} else {
+				counts[i]--
+			}
+		}
+	}
+	var result uint64
+	for i, c := range counts {
+		if c > 0 {
+			result |= 1 << uint(i)
+		}
+	}
+	return result
+}
+
+// BandKeys は MinHash 署名を LSHBands 個のバンドに分けたキーを返します
+// 同じキーを1つでも持つ案件同士だけを類似度の計算対象にします。
+func (f Fingerprint) BandKeys() []uint64 {
+	if len(f.BodyHash) == 0 {
+		return nil
+	}
+	rows := MinHashSize / LSHBands
+	keys := make([]uint64, 0, LSHBands)
+	for b := 0; b < LSHBands; b++ {
+		h := mix(uint64(b) + 1)
+		for _, v := range f.BodyHash[b*rows : (b+1)*rows] {
+			h = mix(h ^ v)
+		}
+		keys = append(keys, h)
+	}
+	return keys
+}
+
+// Compatible は2つの案件が同じ案件でありうるかどうかを返します
+// 同じメール内の別案件、都道府県が重ならない案件、入場時期が2か月以上離れた案件は同じ案件とみなしません。
+func Compatible(a, b Fingerprint) bool {
+	if a.EmailID == b.EmailID {
+		return false
+	}
+	if len(a.Prefectures) > 0 && len(b.Prefectures) > 0 && !overlaps(a.Prefectures, b.Prefectures) {
+		return false
+	}
+	if a.StartMonth != "" && b.StartMonth != "" && monthDistance(a.StartMonth, b.StartMonth) > 1 {
+		return false
+	}
+	return true
+}
+
+// Similarity は2つの案件の類似度（0〜1）を返します
+// 本文の要旨（MinHash）・案件名（SimHash）・技術キーワード・勤務地・入場時期・単価帯の類似度を重み付けして合計します。
+func Similarity(a, b Fingerprint) float64 {
+	score := weightBody*minHashSimilarity(a.BodyHash, b.BodyHash) +
+		weightTitle*simHashSimilarity(a.TitleHash, b.TitleHash) +
+		weightSkills*jaccard(a.SkillIDs, b.SkillIDs)
+
+	switch {
+	case len(a.Stations) > 0 && len(b.Stations) > 0 && overlaps(a.Stations, b.Stations):
+		score += weightLocation
+	case len(a.Prefectures) == 0 || len(b.Prefectures) == 0:
+		score += weightLocation * unknownScore
+	case overlaps(a.Prefectures, b.Prefectures):
+		score += weightLocation * 0.5
+	}
+
+	switch {
+	case a.StartMonth == "" || b.StartMonth == "":
+		score += weightStart * unknownScore
+	case a.StartMonth == b.StartMonth:
+		score += weightStart
+	}
+
+	switch {
+	case a.PriceBand < 0 || b.PriceBand < 0:
+		score += weightPrice * unknownScore
+	case abs(a.PriceBand-b.PriceBand) <= 1:
+		// 営業会社ごとの上乗せ（10万円程度）は同じ単価帯とみなす
+		score += weightPrice
+	}
+	return score
+}
+
+func minHashSimilarity(a, b []uint64) float64 {
+	if len(a) == 0 || len(b) == 0 || len(a) != len(b) {
+		return 0
+	}
+	same := 0
+	for i := range a {
+		if a[i] == b[i] {
+			same++
+		}
+	}
+	return float64(same) / float64(len(a))
+}
+
+// simHashSimilarity は SimHash の一致度を返します
+// 無関係な文字列同士でもビットの半分程度は一致するため、差が32ビット以上なら0とします。
+func simHashSimilarity(a, b uint64) float64 {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	d := bits.OnesCount64(a ^ b)
+	if d >= 32 {
+		return 0
+	}
+	return 1 - float64(d)/32
+}
+
+func jaccard(a, b []uint) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return unknownScore
+	}
+	set := make(map[uint]struct{}, len(a))
+	for _, v := range a {
+		set[v] = struct{}{}
+	}
+	union := len(set)
+	inter := 0
+	seen := make(map[uint]struct{}, len(b))
+	for _, v := range b {
+		if _, ok := seen[v]; ok {
+			continue
+		}
+		seen[v] = struct{}{}
+		if _, ok := set[v]; ok {
+			inter++
+		} else {
+			union++
+		}
+	}
+	return float64(inter) / float64(union)
+}
+
+func overlaps(a, b []string) bool {
+	for _, x := range a {
+		for _, y := range b {
+			if x == y {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// monthDistance は "2006-01" 形式の2つの月の差（月数）を返します
+func monthDistance(a, b string) int {
+	ta, errA := time.Parse("2006-01", a)
+	tb, errB := time.Parse("2006-01", b)
+	if errA != nil || errB != nil {
+		return 0
+	}
+	return abs((ta.Year()-tb.Year())*12 + int(ta.Month()) - int(tb.Month()))
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// foldText は全角英数を半角に揃え、小文字にします
+func foldText(s string) string {
+	return strings.ToLower(width.Fold.String(s))
+}
+
+func hashString(s string) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(s))
+	return h.Sum64()
+}
+
+// mix は splitmix64 の最終段でビットを拡散します
+func mix(x uint64) uint64 {
+	x += 0x9e3779b97f4a7c15
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
+}
+
+// minHashSeeds は MinHash の各ハッシュ関数の種です（固定値のため、実行ごとに署名は変わりません）
+var minHashSeeds = func() [MinHashSize]uint64 {
+	var seeds [MinHashSize]uint64
+	s := uint64(42)
+	for i := range seeds {
+		s = mix(s)
+		seeds[i] = s
+	}
+	return seeds
+}()
@@ -0,0 +1,121 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const sampleBody = `お世話になっております。株式会社サンプルの山田です。
+━━━━━━━━━━━━━━━━━━━━
+【案件名】物流会社向け在庫管理システムのリプレイス
+【内容】Goによるバックエンドの設計・開発、AWS上のマイクロサービス化
+【スキル】Go、AWS、Docker、MySQLでの開発経験3年以上
+【場所】東京都港区（田町駅）
+【期間】7月～長期
+【単価】70万円
+━━━━━━━━━━━━━━━━━━━━
+よろしくお願いいたします。`
+
+const otherAgencyBody = `いつもお世話になっております。テスト商事の佐藤です。
+■案件名：物流会社向け在庫管理システムのリプレイス
+■内容：Goによるバックエンドの設計・開発、AWS上のマイクロサービス化
+■スキル：Go、AWS、Docker、MySQLでの開発経験3年以上
+■場所：東京都港区（田町駅）
+■期間：7月～長期
+■単価：80万円
+ご確認のほど宜しくお願いいたします。`
+
+const differentBody = `【案件名】証券会社向け取引画面のフロントエンド開発
+【内容】React・TypeScriptによる画面開発とテスト自動化
+【スキル】React、TypeScript、Jest
+【場所】大阪府大阪市（梅田駅）`
+
+func TestKeyPhrases(t *testing.T) {
+	phrases := KeyPhrases(sampleBody)
+
+	// 挨拶・罫線・単価の行と記号を取り除くこと
+	assert.NotContains(t, phrases, "お世話")
+	assert.NotContains(t, phrases, "70万円")
+	assert.NotContains(t, phrases, "━")
+	assert.NotContains(t, phrases, "【")
+	assert.Contains(t, phrases, "在庫管理システムのリプレイス")
+
+	// 全角英数は半角小文字に揃えること
+	assert.Contains(t, KeyPhrases("ＧＯ開発"), "go開発")
+}
+
+func TestSimilarity(t *testing.T) {
+	start := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	base := ProjectSource{
+		ProjectID: 1, EmailID: 1, ProjectTitle: "在庫管理システムのリプレイス", Body: sampleBody,
+		SkillIDs: []uint{1, 2, 3}, Prefectures: []string{"東京都"}, Stations: []string{"田町"},
+		StartFrom: &start, PriceTo: intPtr(700000),
+	}
+	same := base
+	same.ProjectID, same.EmailID, same.Body, same.PriceTo = 2, 2, otherAgencyBody, intPtr(800000)
+	different := ProjectSource{
+		ProjectID: 3, EmailID: 3, ProjectTitle: "取引画面のフロントエンド開発", Body: differentBody,
+		SkillIDs: []uint{4, 5}, Prefectures: []string{"大阪府"}, Stations: []string{"梅田"},
+		StartFrom: &start, PriceTo: intPtr(650000),
+	}
+
+	a, b, c := NewFingerprint(base), NewFingerprint(same), NewFingerprint(different)
+
+	// 営業会社ごとの挨拶・書式・単価の違いがあっても同じ案件とみなせること
+	assert.True(t, Compatible(a, b))
+	assert.GreaterOrEqual(t, Similarity(a, b), DefaultThreshold)
+	assert.NotEmpty(t, sharedBandKeys(a, b))
+
+	// 内容の異なる案件は類似度が低く、勤務地が重ならなければ対象外になること
+	assert.Less(t, Similarity(a, c), DefaultThreshold)
+	assert.False(t, Compatible(a, c))
+
+	// 同じメール内の別案件は同じ案件とみなさないこと
+	sameEmail := b
+	sameEmail.EmailID = a.EmailID
+	assert.False(t, Compatible(a, sameEmail))
+
+	// 入場時期が2か月以上離れた案件は同じ案件とみなさないこと
+	later := start.AddDate(0, 3, 0)
+	moved := same
+	moved.StartFrom = &later
+	assert.False(t, Compatible(a, NewFingerprint(moved)))
+}
+
+func TestCluster_Summarize(t *testing.T) {
+	base := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+	c := Cluster{Members: []Member{
+		{ProjectID: 3, SenderEmail: "c@Agency-B.example.com", ReceivedDate: base.Add(2 * time.Hour), PriceFrom: intPtr(750000)},
+		{ProjectID: 1, SenderEmail: "a@agency-a.example.com", ReceivedDate: base, PriceFrom: intPtr(600000), PriceTo: intPtr(700000)},
+		{ProjectID: 2, SenderEmail: "b@agency-a.example.com", ReceivedDate: base.Add(time.Hour)},
+	}}
+	c.Summarize()
+
+	assert.Equal(t, 3, c.Size)
+	assert.Equal(t, 2, c.AgencyCount)
+	assert.Equal(t, 600000, *c.PriceMin)
+	assert.Equal(t, 750000, *c.PriceMax)
+	assert.Equal(t, base, c.FirstReceivedAt)
+	assert.Equal(t, base.Add(2*time.Hour), c.LastReceivedAt)
+	// 最も新しく受信した案件を代表にすること
+	assert.Equal(t, uint(3), c.RepresentativeProjectID)
+	assert.Equal(t, []uint{1, 2, 3}, []uint{c.Members[0].ProjectID, c.Members[1].ProjectID, c.Members[2].ProjectID})
+	assert.True(t, c.Members[2].Representative)
+	assert.Equal(t, "agency-b.example.com", c.Members[2].Agency)
+}
+
+func sharedBandKeys(a, b Fingerprint) []uint64 {
+	keys := map[uint64]struct{}{}
+	for _, k := range a.BandKeys() {
+		keys[k] = struct{}{}
+	}
+	var shared []uint64
+	for _, k := range b.BandKeys() {
+		if _, ok := keys[k]; ok {
+			shared = append(shared, k)
+		}
+	}
+	return shared
+}
@@ -0,0 +1,20 @@
+// Package infrastructure は案件の重複検出機能のインフラストラクチャ層を提供します。
+// このファイルは重複検出で使用するリポジトリのインターフェースを定義します。
+package infrastructure
+
+import (
+	"business/internal/dedup/domain"
+	"time"
+)
+
+// RepositoryInterface は案件の重複検出のリポジトリインターフェースです
+type RepositoryInterface interface {
+	// ListProjectSources は since 以降に受信した案件と、それらを含む重複グループの案件を指紋の材料付きで返します
+	ListProjectSources(since time.Time) ([]domain.ProjectSource, error)
+
+	// ReplaceClusters は projectIDs の案件を含む重複グループを削除し、clusters を保存します
+	ReplaceClusters(projectIDs []uint, clusters []domain.Cluster) error
+
+	// FindCluster は重複グループを、含まれる案件の差出人・単価付きで返します
+	FindCluster(id uint) (domain.Cluster, error)
+}
@@ -0,0 +1,64 @@
+// Package infrastructure は案件の重複検出機能のインフラストラクチャ層を提供します。
+// このファイルは重複検出で参照・更新するテーブルのモデルを定義します。
+package infrastructure
+
+import (
+	"time"
+)
+
+// ProjectCluster は同じ案件とみなした案件の重複グループを表すモデルです
+type ProjectCluster struct {
+	ID                      uint      `gorm:"primaryKey;autoIncrement"`
+	RepresentativeProjectID uint      `gorm:"not null"`
+	Size                    int       `gorm:"not null"`
+	AgencyCount             int       `gorm:"not null"`
+	PriceMin                *int      `gorm:"type:int"`
+	PriceMax                *int      `gorm:"type:int"`
+	FirstReceivedAt         time.Time `gorm:"not null"`
+	LastReceivedAt          time.Time `gorm:"not null"`
+	CreatedAt               time.Time
+	UpdatedAt               time.Time
+}
+
+// ProjectClusterMember は重複グループに含まれる案件を表すモデルです
+type ProjectClusterMember struct {
+	ID             uint    `gorm:"primaryKey;autoIncrement"`
+	ClusterID      uint    `gorm:"not null;index"`
+	EmailProjectID uint    `gorm:"not null;uniqueIndex"`
+	Score          float64 `gorm:"type:decimal(4,3);not null;default:0"`
+	CreatedAt      time.Time
+}
+
+// sourceRow は指紋の材料として参照する案件の列です
+type sourceRow struct {
+	ProjectID        uint
+	EmailID          uint
+	ReceivedDate     time.Time
+	SenderEmail      string
+	ProjectTitle     *string
+	Body             *string
+	MonthlyPriceFrom *int
+	MonthlyPriceTo   *int
+}
+
+// memberRow は重複グループの案件の表示に参照する列です
+type memberRow struct {
+	ProjectID        uint
+	Score            float64
+	GmailID          string
+	Subject          string
+	ProjectTitle     *string
+	SenderName       string
+	SenderEmail      string
+	ReceivedDate     time.Time
+	MonthlyPriceFrom *int
+	MonthlyPriceTo   *int
+}
+
+func (ProjectCluster) TableName() string {
+	return "project_clusters"
+}
+
+func (ProjectClusterMember) TableName() string {
+	return "project_cluster_members"
+}
@@ -0,0 +1,242 @@
+// Package infrastructure は案件の重複検出機能のインフラストラクチャ層を提供します。
+// このファイルは指紋の材料の取得と重複グループの保存を実装します。
+package infrastructure
+
+import (
+	"business/internal/dedup/domain"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/samber/lo"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// inChunkSize は IN 句に渡す案件IDの最大数です
+const inChunkSize = 1000
+
+// Repository は案件の重複検出のリポジトリ実装です
+type Repository struct {
+	db *gorm.DB
+}
+
+// New は案件の重複検出リポジトリを作成します
+func New(db *gorm.DB) *Repository {
+	return &Repository{
+		db: db,
+	}
+}
+
+// ListProjectSources は since 以降に受信した案件と、それらを含む重複グループの案件を指紋の材料付きで返します
+// 技術キーワード（キーワードグループID）・勤務地の都道府県と駅・最も早い入場時期は子テーブルから集めます。
+func (r *Repository) ListProjectSources(since time.Time) ([]domain.ProjectSource, error) {
+	touched := r.db.Table("project_cluster_members m").
+		Select("m.cluster_id").
+		Joins("JOIN email_projects ep2 ON ep2.id = m.email_project_id").
+		Joins("JOIN emails e2 ON e2.id = ep2.email_id").
+		Where("e2.received_date >= ?", since)
+
+	var rows []sourceRow
+	err := r.db.Table("email_projects ep").
+		Select(`ep.id AS project_id, ep.email_id, e.received_date, e.sender_email, ep.project_title, e.body,
+			ep.monthly_price_from, ep.monthly_price_to`).
+		Joins("JOIN emails e ON e.id = ep.email_id").
+		Joins("LEFT JOIN project_cluster_members pcm ON pcm.email_project_id = ep.id").
+		Where("e.received_date >= ? OR pcm.cluster_id IN (?)", since, touched).
+		Order("ep.id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("案件取得エラー: %w", err)
+	}
+
+	sources := make([]domain.ProjectSource, 0, len(rows))
+	index := make(map[uint]int, len(rows))
+	ids := make([]uint, 0, len(rows))
+	for _, row := range rows {
+		index[row.ProjectID] = len(sources)
+		ids = append(ids, row.ProjectID)
+		sources = append(sources, domain.ProjectSource{
+			ProjectID:    row.ProjectID,
+			EmailID:      row.EmailID,
+			ReceivedDate: row.ReceivedDate,
+			SenderEmail:  row.SenderEmail,
+			ProjectTitle: derefString(row.ProjectTitle),
+			Body:         derefString(row.Body),
+			PriceFrom:    row.MonthlyPriceFrom,
+			PriceTo:      row.MonthlyPriceTo,
+		})
+	}
+
+	for _, chunk := range lo.Chunk(ids, inChunkSize) {
+		if err := r.attachDetails(chunk, sources, index); err != nil {
+			return nil, err
+		}
+	}
+	return sources, nil
+}
+
+// attachDetails は案件の技術キーワード・勤務地・入場時期を sources に設定します
+func (r *Repository) attachDetails(ids []uint, sources []domain.ProjectSource, index map[uint]int) error {
+	var skills []struct {
+		EmailProjectID uint
+		KeywordGroupID uint
+	}
+	err := r.db.Table("email_keyword_groups").
+		Select("DISTINCT email_project_id, keyword_group_id").
+		Where("email_project_id IN ?", ids).
+		Order("email_project_id, keyword_group_id").
+		Scan(&skills).Error
+	if err != nil {
+		return fmt.Errorf("技術キーワード取得エラー: %w", err)
+	}
+	for _, s := range skills {
+		p := &sources[index[s.EmailProjectID]]
+		p.SkillIDs = append(p.SkillIDs, s.KeywordGroupID)
+	}
+
+	var locations []struct {
+		EmailProjectID uint
+		Prefecture     string
+		Station        string
+	}
+	err = r.db.Table("project_locations").
+		Select("email_project_id, prefecture, station").
+		Where("email_project_id IN ?", ids).
+		Order("email_project_id, site_no").
+		Scan(&locations).Error
+	if err != nil {
+		return fmt.Errorf("勤務地取得エラー: %w", err)
+	}
+	for _, l := range locations {
+		p := &sources[index[l.EmailProjectID]]
+		if l.Prefecture != "" && !lo.Contains(p.Prefectures, l.Prefecture) {
+			p.Prefectures = append(p.Prefectures, l.Prefecture)
+		}
+		if l.Station != "" && !lo.Contains(p.Stations, l.Station) {
+			p.Stations = append(p.Stations, l.Station)
+		}
+	}
+
+	var starts []struct {
+		EmailProjectID uint
+		StartFrom      *time.Time
+	}
+	err = r.db.Table("entry_timings").
+		Select("email_project_id, MIN(start_from) AS start_from").
+		Where("email_project_id IN ? AND start_from IS NOT NULL", ids).
+		Group("email_project_id").
+		Scan(&starts).Error
+	if err != nil {
+		return fmt.Errorf("入場時期取得エラー: %w", err)
+	}
+	for _, s := range starts {
+		sources[index[s.EmailProjectID]].StartFrom = s.StartFrom
+	}
+	return nil
+}
+
+// ReplaceClusters は projectIDs の案件を含む重複グループを削除し、clusters を1つのトランザクションで保存します
+func (r *Repository) ReplaceClusters(projectIDs []uint, clusters []domain.Cluster) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var clusterIDs []uint
+		for _, chunk := range lo.Chunk(projectIDs, inChunkSize) {
+			var ids []uint
+			if err := tx.Model(&ProjectClusterMember{}).Where("email_project_id IN ?", chunk).Distinct().Pluck("cluster_id", &ids).Error; err != nil {
+				return fmt.Errorf("ProjectClusterMember取得エラー: %w", err)
+			}
+			clusterIDs = append(clusterIDs, ids...)
+		}
+		for _, chunk := range lo.Chunk(lo.Uniq(clusterIDs), inChunkSize) {
+			if err := tx.Where("cluster_id IN ?", chunk).Delete(&ProjectClusterMember{}).Error; err != nil {
+				return fmt.Errorf("ProjectClusterMember削除エラー: %w", err)
+			}
+			if err := tx.Where("id IN ?", chunk).Delete(&ProjectCluster{}).Error; err != nil {
+				return fmt.Errorf("ProjectCluster削除エラー: %w", err)
+			}
+		}
+
+		for _, c := range clusters {
+			row := ProjectCluster{
+				RepresentativeProjectID: c.RepresentativeProjectID,
+				Size:                    c.Size,
+				AgencyCount:             c.AgencyCount,
+				PriceMin:                c.PriceMin,
+				PriceMax:                c.PriceMax,
+				FirstReceivedAt:         c.FirstReceivedAt,
+				LastReceivedAt:          c.LastReceivedAt,
+			}
+			if err := tx.Create(&row).Error; err != nil {
+				return fmt.Errorf("ProjectCluster保存エラー: %w", err)
+			}
+			members := make([]ProjectClusterMember, 0, len(c.Members))
+			for _, m := range c.Members {
+				members = append(members, ProjectClusterMember{ClusterID: row.ID, EmailProjectID: m.ProjectID, Score: m.Score})
+			}
+			if err := tx.Omit(clause.Associations).Create(&members).Error; err != nil {
+				return fmt.Errorf("ProjectClusterMember保存エラー: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// FindCluster は重複グループを、含まれる案件の差出人・単価付きで受信日の古い順に返します
+func (r *Repository) FindCluster(id uint) (domain.Cluster, error) {
+	var row ProjectCluster
+	if err := r.db.First(&row, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domain.Cluster{}, fmt.Errorf("%w: %d", domain.ErrClusterNotFound, id)
+		}
+		return domain.Cluster{}, fmt.Errorf("ProjectCluster取得エラー: %w", err)
+	}
+
+	var members []memberRow
+	err := r.db.Table("project_cluster_members m").
+		Select(`m.email_project_id AS project_id, m.score, e.gmail_id, e.subject, ep.project_title,
+			e.sender_name, e.sender_email, e.received_date, ep.monthly_price_from, ep.monthly_price_to`).
+		Joins("JOIN email_projects ep ON ep.id = m.email_project_id").
+		Joins("JOIN emails e ON e.id = ep.email_id").
+		Where("m.cluster_id = ?", id).
+		Order("e.received_date, m.email_project_id").
+		Scan(&members).Error
+	if err != nil {
+		return domain.Cluster{}, fmt.Errorf("ProjectClusterMember取得エラー: %w", err)
+	}
+
+	cluster := domain.Cluster{
+		ID:                      row.ID,
+		RepresentativeProjectID: row.RepresentativeProjectID,
+		Size:                    row.Size,
+		AgencyCount:             row.AgencyCount,
+		PriceMin:                row.PriceMin,
+		PriceMax:                row.PriceMax,
+		FirstReceivedAt:         row.FirstReceivedAt,
+		LastReceivedAt:          row.LastReceivedAt,
+		Members:                 make([]domain.Member, 0, len(members)),
+	}
+	for _, m := range members {
+		cluster.Members = append(cluster.Members, domain.Member{
+			ProjectID:      m.ProjectID,
+			Score:          m.Score,
+			Representative: m.ProjectID == row.RepresentativeProjectID,
+			GmailID:        m.GmailID,
+			Subject:        m.Subject,
+			ProjectTitle:   derefString(m.ProjectTitle),
+			SenderName:     m.SenderName,
+			SenderEmail:    m.SenderEmail,
+			Agency:         domain.AgencyOf(m.SenderEmail),
+			ReceivedDate:   m.ReceivedDate,
+			PriceFrom:      m.MonthlyPriceFrom,
+			PriceTo:        m.MonthlyPriceTo,
+		})
+	}
+	return cluster, nil
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
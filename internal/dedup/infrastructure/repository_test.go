@@ -0,0 +1,115 @@
+package infrastructure
+
+import (
+	"business/internal/dedup/domain"
+	"business/tools/migrations/model"
+	"business/tools/mysql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_Clusters(t *testing.T) {
+	t.Parallel()
+	// テスト用DBの準備
+	db, cleanup, err := mysql.CreateNewTestDB()
+	require.NoError(t, err)
+	defer cleanup()
+
+	err = db.DB.AutoMigrate(
+		model.KeywordGroup{},
+		model.Email{},
+		model.EmailProject{},
+		model.EntryTiming{},
+		model.ProjectLocation{},
+		model.EmailKeywordGroup{},
+		model.ProjectCluster{},
+		model.ProjectClusterMember{},
+	)
+	require.NoError(t, err)
+
+	now := time.Now().Truncate(time.Second)
+	body := "【案件名】在庫管理システムのリプレイス"
+	emails := []model.Email{
+		{GmailID: "gmail-1", Subject: "案件A", SenderEmail: "a@agency-a.example.com", ReceivedDate: now.Add(-2 * time.Hour), Body: &body, Category: "案件"},
+		{GmailID: "gmail-2", Subject: "案件B", SenderEmail: "b@agency-b.example.com", ReceivedDate: now.Add(-time.Hour), Body: &body, Category: "案件"},
+		{GmailID: "gmail-old", Subject: "古い案件", SenderEmail: "c@agency-c.example.com", ReceivedDate: now.AddDate(0, -6, 0), Body: &body, Category: "案件"},
+	}
+	require.NoError(t, db.DB.Create(&emails).Error)
+	title := "在庫管理システムのリプレイス"
+	projects := []model.EmailProject{
+		{EmailID: emails[0].ID, ProjectKey: "p1", ProjectTitle: &title, MonthlyPriceTo: intPtr(700000)},
+		{EmailID: emails[1].ID, ProjectKey: "p2", ProjectTitle: &title, MonthlyPriceTo: intPtr(800000)},
+		{EmailID: emails[2].ID, ProjectKey: "p3", ProjectTitle: &title},
+	}
+	require.NoError(t, db.DB.Create(&projects).Error)
+
+	group := model.KeywordGroup{Name: "Go", Type: "language"}
+	require.NoError(t, db.DB.Create(&group).Error)
+	require.NoError(t, db.DB.Create(&model.EmailKeywordGroup{EmailProjectID: projects[0].ID, KeywordGroupID: group.KeywordGroupID}).Error)
+	require.NoError(t, db.DB.Create(&[]model.ProjectLocation{
+		{EmailProjectID: projects[0].ID, SiteNo: 1, Prefecture: "東京都", Station: "田町"},
+		{EmailProjectID: projects[0].ID, SiteNo: 2, Prefecture: "東京都", Station: "品川"},
+	}).Error)
+	start := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, db.DB.Create(&model.EntryTiming{EmailProjectID: projects[0].ID, StartDate: "7月～", StartFrom: &start}).Error)
+
+	repo := New(db.DB)
+
+	// 範囲内の案件を指紋の材料付きで返すこと
+	sources, err := repo.ListProjectSources(now.AddDate(0, 0, -60))
+	require.NoError(t, err)
+	require.Len(t, sources, 2)
+	assert.Equal(t, []uint{group.KeywordGroupID}, sources[0].SkillIDs)
+	assert.Equal(t, []string{"東京都"}, sources[0].Prefectures)
+	assert.Equal(t, []string{"田町", "品川"}, sources[0].Stations)
+	require.NotNil(t, sources[0].StartFrom)
+	assert.Equal(t, "2025-07-01", sources[0].StartFrom.Format("2006-01-02"))
+	assert.Equal(t, body, sources[1].Body)
+
+	// 範囲外の案件も、範囲内の案件と同じグループにあれば対象にすること
+	cluster := domain.Cluster{Members: []domain.Member{
+		{ProjectID: projects[2].ID, Score: 0.8, SenderEmail: emails[2].SenderEmail, ReceivedDate: emails[2].ReceivedDate},
+		{ProjectID: projects[0].ID, Score: 0.8, SenderEmail: emails[0].SenderEmail, ReceivedDate: emails[0].ReceivedDate, PriceTo: intPtr(700000)},
+	}}
+	cluster.Summarize()
+	require.NoError(t, repo.ReplaceClusters([]uint{projects[0].ID, projects[2].ID}, []domain.Cluster{cluster}))
+	sources, err = repo.ListProjectSources(now.AddDate(0, 0, -60))
+	require.NoError(t, err)
+	assert.Len(t, sources, 3)
+
+	// 作り直すと既存のグループを置き換えること
+	cluster = domain.Cluster{Members: []domain.Member{
+		{ProjectID: projects[0].ID, Score: 0.9, SenderEmail: emails[0].SenderEmail, ReceivedDate: emails[0].ReceivedDate, PriceTo: intPtr(700000)},
+		{ProjectID: projects[1].ID, Score: 0.9, SenderEmail: emails[1].SenderEmail, ReceivedDate: emails[1].ReceivedDate, PriceTo: intPtr(800000)},
+	}}
+	cluster.Summarize()
+	require.NoError(t, repo.ReplaceClusters([]uint{projects[0].ID, projects[1].ID, projects[2].ID}, []domain.Cluster{cluster}))
+
+	var count int64
+	require.NoError(t, db.DB.Model(&ProjectCluster{}).Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+
+	var saved ProjectCluster
+	require.NoError(t, db.DB.First(&saved).Error)
+	found, err := repo.FindCluster(saved.ID)
+	require.NoError(t, err)
+	assert.Equal(t, projects[1].ID, found.RepresentativeProjectID)
+	assert.Equal(t, 2, found.AgencyCount)
+	assert.Equal(t, 700000, *found.PriceMin)
+	assert.Equal(t, 800000, *found.PriceMax)
+	require.Len(t, found.Members, 2)
+	assert.Equal(t, "gmail-1", found.Members[0].GmailID)
+	assert.Equal(t, "agency-b.example.com", found.Members[1].Agency)
+	assert.True(t, found.Members[1].Representative)
+
+	_, err = repo.FindCluster(saved.ID + 100)
+	assert.True(t, errors.Is(err, domain.ErrClusterNotFound))
+}
+
+func intPtr(v int) *int {
+	return &v
+}
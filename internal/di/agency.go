@@ -0,0 +1,21 @@
+package di
+
+import (
+	aga "business/internal/agency/application"
+	agi "business/internal/agency/infrastructure"
+	"business/tools/mysql"
+
+	"go.uber.org/dig"
+)
+
+// ProvideAgencyDependencies 営業会社の台帳（品質の集計と取り込みのルール）の機能群の依存注入設定
+func ProvideAgencyDependencies(container *dig.Container) {
+	// infra
+	mustProvide(container, func(conn *mysql.MySQL) *agi.Repository {
+		return agi.New(conn.DB)
+	})
+	// app
+	mustProvide(container, func(agi *agi.Repository) *aga.UseCase {
+		return aga.New(agi)
+	})
+}
@@ -0,0 +1,30 @@
+package di
+
+import (
+	aa "business/internal/alert/application"
+	ai "business/internal/alert/infrastructure"
+	"business/tools/mysql"
+	"business/tools/notifier"
+	"business/tools/oswrapper"
+
+	"go.uber.org/dig"
+)
+
+// ProvideAlertDependencies 保存した検索条件と新着案件の通知を実行する機能群の依存注入設定
+func ProvideAlertDependencies(container *dig.Container) {
+	// infra
+	mustProvide(container, func(conn *mysql.MySQL) *ai.Repository {
+		return ai.New(conn.DB)
+	})
+	// 送信先は環境変数（NOTIFY_*）で設定する。設定が無い場合は標準出力に書き出す
+	mustProvide(container, func(osw *oswrapper.OsWrapper) aa.Notifier {
+		if ch := notifier.FromEnv(osw); ch != nil {
+			return ai.NewChannelNotifier(ch)
+		}
+		return ai.NewConsoleNotifier()
+	})
+	// app
+	mustProvide(container, func(ai *ai.Repository, n aa.Notifier, osw *oswrapper.OsWrapper) *aa.UseCase {
+		return aa.New(ai, n, osw)
+	})
+}
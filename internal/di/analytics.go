@@ -0,0 +1,21 @@
+package di
+
+import (
+	ana "business/internal/analytics/application"
+	ani "business/internal/analytics/infrastructure"
+	"business/tools/mysql"
+
+	"go.uber.org/dig"
+)
+
+// ProvideAnalyticsDependencies 案件の市場動向（スキルの需要・単価・リモート比率の推移）を集計する機能群の依存注入設定
+func ProvideAnalyticsDependencies(container *dig.Container) {
+	// infra
+	mustProvide(container, func(conn *mysql.MySQL) *ani.Repository {
+		return ani.New(conn.DB)
+	})
+	// app
+	mustProvide(container, func(ani *ani.Repository) *ana.UseCase {
+		return ana.New(ani)
+	})
+}
@@ -0,0 +1,24 @@
+package di
+
+import (
+	ba "business/internal/batch/application"
+	bi "business/internal/batch/infrastructure"
+	ea "business/internal/emailstore/application"
+	aiapp "business/internal/openAi/application"
+	"business/tools/mysql"
+	"business/tools/openai"
+
+	"go.uber.org/dig"
+)
+
+// ProvideBatchDependencies OpenAI Batch APIで一括解析する機能群の依存注入設定
+func ProvideBatchDependencies(container *dig.Container) {
+	// infra
+	mustProvide(container, func(conn *mysql.MySQL) *bi.Repository {
+		return bi.New(conn.DB)
+	})
+	// app
+	mustProvide(container, func(bi *bi.Repository, oa *openai.Client, aiapp *aiapp.UseCase, ea *ea.UseCase) *ba.UseCase {
+		return ba.New(bi, oa, aiapp, ea)
+	})
+}
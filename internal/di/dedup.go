@@ -0,0 +1,21 @@
+package di
+
+import (
+	da "business/internal/dedup/application"
+	dinfra "business/internal/dedup/infrastructure"
+	"business/tools/mysql"
+
+	"go.uber.org/dig"
+)
+
+// ProvideDedupDependencies 案件の重複検出（複数の営業会社から届く同一案件のまとめ）を実行する機能群の依存注入設定
+func ProvideDedupDependencies(container *dig.Container) {
+	// infra
+	mustProvide(container, func(conn *mysql.MySQL) *dinfra.Repository {
+		return dinfra.New(conn.DB)
+	})
+	// app
+	mustProvide(container, func(di *dinfra.Repository) *da.UseCase {
+		return da.New(di)
+	})
+}
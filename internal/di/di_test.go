@@ -1,7 +1,16 @@
 package di
 
 import (
+	aga "business/internal/agency/application"
+	aa "business/internal/alert/application"
+	ana "business/internal/analytics/application"
 	"business/internal/app/presentation"
+	ba "business/internal/batch/application"
+	dda "business/internal/dedup/application"
+	da "business/internal/dictionary/application"
+	dga "business/internal/digest/application"
+	la "business/internal/lifecycle/application"
+	ma "business/internal/matching/application"
 	"business/tools/gmail"
 	"business/tools/gmailService"
 	"business/tools/mysql"
@@ -53,3 +62,202 @@ func TestBuildContainer_WithPresentationLayer(t *testing.T) {
 
 	assert.NoError(t, err)
 }
+
+func TestBuildContainer_WithReanalysisController(t *testing.T) {
+	container := BuildContainer(&mysql.MySQL{}, &openai.Client{}, &gmailService.Client{}, &gmail.Client{}, &oswrapper.OsWrapper{})
+
+	err := container.Invoke(func(controller *presentation.ReanalysisController) {
+		assert.NotNil(t, controller)
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestBuildContainer_WithBatchUseCase(t *testing.T) {
+	container := BuildContainer(&mysql.MySQL{}, &openai.Client{}, &gmailService.Client{}, &gmail.Client{}, &oswrapper.OsWrapper{})
+
+	err := container.Invoke(func(usecase *ba.UseCase) {
+		assert.NotNil(t, usecase)
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestBuildContainer_WithProjectController(t *testing.T) {
+	container := BuildContainer(&mysql.MySQL{}, &openai.Client{}, &gmailService.Client{}, &gmail.Client{}, &oswrapper.OsWrapper{})
+
+	err := container.Invoke(func(controller *presentation.ProjectController) {
+		assert.NotNil(t, controller)
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestBuildContainer_WithTriageController(t *testing.T) {
+	container := BuildContainer(&mysql.MySQL{}, &openai.Client{}, &gmailService.Client{}, &gmail.Client{}, &oswrapper.OsWrapper{})
+
+	err := container.Invoke(func(controller *presentation.TriageController) {
+		assert.NotNil(t, controller)
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestBuildContainer_WithDictionaryUseCase(t *testing.T) {
+	container := BuildContainer(&mysql.MySQL{}, &openai.Client{}, &gmailService.Client{}, &gmail.Client{}, &oswrapper.OsWrapper{})
+
+	err := container.Invoke(func(usecase *da.UseCase) {
+		assert.NotNil(t, usecase)
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestBuildContainer_WithDictionaryController(t *testing.T) {
+	container := BuildContainer(&mysql.MySQL{}, &openai.Client{}, &gmailService.Client{}, &gmail.Client{}, &oswrapper.OsWrapper{})
+
+	err := container.Invoke(func(controller *presentation.DictionaryController) {
+		assert.NotNil(t, controller)
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestBuildContainer_WithClusterUseCase(t *testing.T) {
+	container := BuildContainer(&mysql.MySQL{}, &openai.Client{}, &gmailService.Client{}, &gmail.Client{}, &oswrapper.OsWrapper{})
+
+	err := container.Invoke(func(usecase *da.ClusterUseCase) {
+		assert.NotNil(t, usecase)
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestBuildContainer_WithDedupUseCase(t *testing.T) {
+	container := BuildContainer(&mysql.MySQL{}, &openai.Client{}, &gmailService.Client{}, &gmail.Client{}, &oswrapper.OsWrapper{})
+
+	err := container.Invoke(func(usecase *dda.UseCase) {
+		assert.NotNil(t, usecase)
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestBuildContainer_WithDedupController(t *testing.T) {
+	container := BuildContainer(&mysql.MySQL{}, &openai.Client{}, &gmailService.Client{}, &gmail.Client{}, &oswrapper.OsWrapper{})
+
+	err := container.Invoke(func(controller *presentation.DedupController) {
+		assert.NotNil(t, controller)
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestBuildContainer_WithLifecycleUseCase(t *testing.T) {
+	container := BuildContainer(&mysql.MySQL{}, &openai.Client{}, &gmailService.Client{}, &gmail.Client{}, &oswrapper.OsWrapper{})
+
+	err := container.Invoke(func(usecase *la.UseCase) {
+		assert.NotNil(t, usecase)
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestBuildContainer_WithMatchingUseCase(t *testing.T) {
+	container := BuildContainer(&mysql.MySQL{}, &openai.Client{}, &gmailService.Client{}, &gmail.Client{}, &oswrapper.OsWrapper{})
+
+	err := container.Invoke(func(usecase *ma.UseCase) {
+		assert.NotNil(t, usecase)
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestBuildContainer_WithMatchingController(t *testing.T) {
+	container := BuildContainer(&mysql.MySQL{}, &openai.Client{}, &gmailService.Client{}, &gmail.Client{}, &oswrapper.OsWrapper{})
+
+	err := container.Invoke(func(controller *presentation.MatchingController) {
+		assert.NotNil(t, controller)
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestBuildContainer_WithAlertUseCase(t *testing.T) {
+	container := BuildContainer(&mysql.MySQL{}, &openai.Client{}, &gmailService.Client{}, &gmail.Client{}, &oswrapper.OsWrapper{})
+
+	err := container.Invoke(func(usecase *aa.UseCase) {
+		assert.NotNil(t, usecase)
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestBuildContainer_WithAlertController(t *testing.T) {
+	container := BuildContainer(&mysql.MySQL{}, &openai.Client{}, &gmailService.Client{}, &gmail.Client{}, &oswrapper.OsWrapper{})
+
+	err := container.Invoke(func(controller *presentation.AlertController) {
+		assert.NotNil(t, controller)
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestBuildContainer_WithDigestUseCase(t *testing.T) {
+	container := BuildContainer(&mysql.MySQL{}, &openai.Client{}, &gmailService.Client{}, &gmail.Client{}, &oswrapper.OsWrapper{})
+
+	err := container.Invoke(func(usecase *dga.UseCase) {
+		assert.NotNil(t, usecase)
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestBuildContainer_WithAnalyticsUseCase(t *testing.T) {
+	container := BuildContainer(&mysql.MySQL{}, &openai.Client{}, &gmailService.Client{}, &gmail.Client{}, &oswrapper.OsWrapper{})
+
+	err := container.Invoke(func(usecase *ana.UseCase) {
+		assert.NotNil(t, usecase)
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestBuildContainer_WithAnalyticsController(t *testing.T) {
+	container := BuildContainer(&mysql.MySQL{}, &openai.Client{}, &gmailService.Client{}, &gmail.Client{}, &oswrapper.OsWrapper{})
+
+	err := container.Invoke(func(controller *presentation.AnalyticsController) {
+		assert.NotNil(t, controller)
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestBuildContainer_WithAgencyUseCase(t *testing.T) {
+	container := BuildContainer(&mysql.MySQL{}, &openai.Client{}, &gmailService.Client{}, &gmail.Client{}, &oswrapper.OsWrapper{})
+
+	err := container.Invoke(func(usecase *aga.UseCase) {
+		assert.NotNil(t, usecase)
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestBuildContainer_WithAgencyController(t *testing.T) {
+	container := BuildContainer(&mysql.MySQL{}, &openai.Client{}, &gmailService.Client{}, &gmail.Client{}, &oswrapper.OsWrapper{})
+
+	err := container.Invoke(func(controller *presentation.AgencyController) {
+		assert.NotNil(t, controller)
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestMustProvide_Duplicate(t *testing.T) {
+	container := BuildContainer(&mysql.MySQL{}, &openai.Client{}, &gmailService.Client{}, &gmail.Client{}, &oswrapper.OsWrapper{})
+
+	// 登録済みの型を二重に登録すると panic すること
+	assert.Panics(t, func() {
+		mustProvide(container, func() *aga.UseCase { return nil })
+	})
+}
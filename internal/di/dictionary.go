@@ -0,0 +1,29 @@
+package di
+
+import (
+	da "business/internal/dictionary/application"
+	dinfra "business/internal/dictionary/infrastructure"
+	"business/tools/mysql"
+	"business/tools/openai"
+	"business/tools/oswrapper"
+
+	"go.uber.org/dig"
+)
+
+// ProvideDictionaryDependencies 用語辞書（キーワードの表記ゆれ管理）を実行する機能群の依存注入設定
+func ProvideDictionaryDependencies(container *dig.Container) {
+	// infra
+	mustProvide(container, func(conn *mysql.MySQL) *dinfra.Repository {
+		return dinfra.New(conn.DB)
+	})
+	mustProvide(container, func(oa *openai.Client, osw *oswrapper.OsWrapper) *dinfra.Advisor {
+		return dinfra.NewAdvisor(oa, osw)
+	})
+	// app
+	mustProvide(container, func(di *dinfra.Repository, osw *oswrapper.OsWrapper) *da.UseCase {
+		return da.New(di, osw)
+	})
+	mustProvide(container, func(di *dinfra.Repository, advisor *dinfra.Advisor) *da.ClusterUseCase {
+		return da.NewCluster(di, advisor)
+	})
+}
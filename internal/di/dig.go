@@ -6,34 +6,43 @@ import (
 	"business/tools/mysql"
 	"business/tools/openai"
 	"business/tools/oswrapper"
+	"fmt"
 
 	"go.uber.org/dig"
 )
 
+// mustProvide はコンストラクタをコンテナに登録します
+// 同じ型を二重に登録すると dig は後の登録を捨てるため、登録の誤りは起動時に panic して知らせます。
+func mustProvide(container *dig.Container, constructor interface{}) {
+	if err := container.Provide(constructor); err != nil {
+		panic(fmt.Sprintf("依存性の登録に失敗しました。: %v", err))
+	}
+}
+
 // ProvideCommonDependencies 共通の依存性（例：データベース接続など）を設定する関数
 func ProvideCommonDependencies(container *dig.Container, conn *mysql.MySQL, oa *openai.Client, gs *gmailService.Client, gc *gmail.Client, osw *oswrapper.OsWrapper) {
-	_ = container.Provide(func() *mysql.MySQL {
+	mustProvide(container, func() *mysql.MySQL {
 		return conn
 	})
 
-	_ = container.Provide(func() *openai.Client {
+	mustProvide(container, func() *openai.Client {
 		return oa
 	})
 
-	_ = container.Provide(func() *gmailService.Client {
+	mustProvide(container, func() *gmailService.Client {
 		return gs
 	})
 
-	_ = container.Provide(func() *gmail.Client {
+	mustProvide(container, func() *gmail.Client {
 		return gc
 	})
 
-	_ = container.Provide(func() *oswrapper.OsWrapper {
+	mustProvide(container, func() *oswrapper.OsWrapper {
 		return osw
 	})
 
 	// var wt ct.CustomTime
-	// _ = container.Provide(func() ct.WrapperTime {
+	// mustProvide(container, func() ct.WrapperTime {
 	// 	return wt
 	// })
 }
@@ -49,6 +58,16 @@ func BuildContainer(conn *mysql.MySQL, oa *openai.Client, gs *gmailService.Clien
 	ProvideOpenAiDependencies(container)
 	ProvideGmailDependencies(container)
 	ProvideEmailStoreDependencies(container)
+	ProvideReanalysisDependencies(container)
+	ProvideBatchDependencies(container)
+	ProvideDictionaryDependencies(container)
+	ProvideDedupDependencies(container)
+	ProvideLifecycleDependencies(container)
+	ProvideMatchingDependencies(container)
+	ProvideAlertDependencies(container)
+	ProvideDigestDependencies(container)
+	ProvideAnalyticsDependencies(container)
+	ProvideAgencyDependencies(container)
 	ProvidePresentationDependencies(container)
 
 	return container
@@ -0,0 +1,38 @@
+package di
+
+import (
+	dga "business/internal/digest/application"
+	dgi "business/internal/digest/infrastructure"
+	ma "business/internal/matching/application"
+	"business/tools/mysql"
+	"business/tools/notifier"
+	"business/tools/oswrapper"
+
+	"go.uber.org/dig"
+)
+
+// digestChannels はダイジェストを送信する送信先の名前です（チャットは長い本文に向かないため送らない）
+var digestChannels = map[string]bool{"smtp": true, "webhook": true}
+
+// ProvideDigestDependencies 新着案件のダイジェスト（日次・週次のまとめ）を作成・送信する機能群の依存注入設定
+func ProvideDigestDependencies(container *dig.Container) {
+	// infra
+	mustProvide(container, func(conn *mysql.MySQL) *dgi.Repository {
+		return dgi.New(conn.DB)
+	})
+	// app
+	// 送信先は環境変数（NOTIFY_*）で設定したメール・汎用Webhook。設定が無い場合は送信できない
+	mustProvide(container, func(dgi *dgi.Repository, mu *ma.UseCase, osw *oswrapper.OsWrapper) *dga.UseCase {
+		var channels []notifier.Channel
+		for _, ch := range notifier.ChannelsFromEnv(osw) {
+			if digestChannels[ch.Name()] {
+				channels = append(channels, ch)
+			}
+		}
+		var ch notifier.Channel
+		if len(channels) > 0 {
+			ch = notifier.Multi(channels...)
+		}
+		return dga.New(dgi, mu, ch)
+	})
+}
@@ -1,9 +1,13 @@
 package di
 
 import (
+	aga "business/internal/agency/application"
+	aa "business/internal/alert/application"
+	ana "business/internal/analytics/application"
 	ea "business/internal/emailstore/application"
 	ei "business/internal/emailstore/infrastructure"
 	"business/tools/mysql"
+	"business/tools/oswrapper"
 
 	"go.uber.org/dig"
 )
@@ -11,11 +15,27 @@ import (
 // ProvideEmailStoreDependencies 解析結果保存を実行する機能群の依存注入設定
 func ProvideEmailStoreDependencies(container *dig.Container) {
 	// infra
-	_ = container.Provide(func(conn *mysql.MySQL) *ei.Repository {
+	mustProvide(container, func(conn *mysql.MySQL) *ei.Repository {
 		return ei.New(conn.DB)
 	})
 	// app
-	_ = container.Provide(func(ei *ei.Repository) *ea.UseCase {
-		return ea.New(ei)
+	// 保存後に新着案件を保存した検索条件と照合して通知し、市場動向と営業会社の集計を更新する
+	mustProvide(container, func(ei *ei.Repository, osw *oswrapper.OsWrapper, au *aa.UseCase, anu *ana.UseCase, agu *aga.UseCase) *ea.UseCase {
+		return ea.New(ei, osw, au, anu, agu)
+	})
+	mustProvide(container, func(ei *ei.Repository) *ea.ProjectQueryUseCase {
+		return ea.NewProjectQuery(ei)
+	})
+	mustProvide(container, func(ei *ei.Repository) *ea.TriageUseCase {
+		return ea.NewTriage(ei)
+	})
+	mustProvide(container, func(ei *ei.Repository) *ea.PeriodUseCase {
+		return ea.NewPeriod(ei)
+	})
+	mustProvide(container, func(ei *ei.Repository) *ea.PriceUseCase {
+		return ea.NewPrice(ei)
+	})
+	mustProvide(container, func(ei *ei.Repository) *ea.LocationUseCase {
+		return ea.NewLocation(ei)
 	})
 }
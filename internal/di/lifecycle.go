@@ -0,0 +1,21 @@
+package di
+
+import (
+	la "business/internal/lifecycle/application"
+	linfra "business/internal/lifecycle/infrastructure"
+	"business/tools/mysql"
+
+	"go.uber.org/dig"
+)
+
+// ProvideLifecycleDependencies 案件の募集状況（募集中・募集終了・期限切れ）の判定とアーカイブを実行する機能群の依存注入設定
+func ProvideLifecycleDependencies(container *dig.Container) {
+	// infra
+	mustProvide(container, func(conn *mysql.MySQL) *linfra.Repository {
+		return linfra.New(conn.DB)
+	})
+	// app
+	mustProvide(container, func(li *linfra.Repository) *la.UseCase {
+		return la.New(li)
+	})
+}
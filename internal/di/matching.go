@@ -0,0 +1,22 @@
+package di
+
+import (
+	ma "business/internal/matching/application"
+	minfra "business/internal/matching/infrastructure"
+	"business/tools/mysql"
+	"business/tools/oswrapper"
+
+	"go.uber.org/dig"
+)
+
+// ProvideMatchingDependencies エンジニアのスキルプロフィールと案件のマッチング（適合度の採点）を実行する機能群の依存注入設定
+func ProvideMatchingDependencies(container *dig.Container) {
+	// infra
+	mustProvide(container, func(conn *mysql.MySQL) *minfra.Repository {
+		return minfra.New(conn.DB)
+	})
+	// app
+	mustProvide(container, func(mi *minfra.Repository, osw *oswrapper.OsWrapper) *ma.UseCase {
+		return ma.New(mi, osw)
+	})
+}
@@ -12,11 +12,11 @@ import (
 // ProvideOpenAiDependencies OpenAi APIを実行する機能群の依存注入設定
 func ProvideOpenAiDependencies(container *dig.Container) {
 	// infra
-	_ = container.Provide(func(oa *openai.Client) *aiinfra.Analyzer {
+	mustProvide(container, func(oa *openai.Client) *aiinfra.Analyzer {
 		return aiinfra.New(oa)
 	})
 	// app
-	_ = container.Provide(func(r *aiinfra.Analyzer, osw *oswrapper.OsWrapper) *aiapp.UseCase {
+	mustProvide(container, func(r *aiinfra.Analyzer, osw *oswrapper.OsWrapper) *aiapp.UseCase {
 		return aiapp.New(r, osw)
 	})
 }
@@ -1,10 +1,17 @@
 package di
 
 import (
+	aga "business/internal/agency/application"
+	aa "business/internal/alert/application"
+	ana "business/internal/analytics/application"
 	"business/internal/app/presentation"
+	dda "business/internal/dedup/application"
+	da "business/internal/dictionary/application"
 	ea "business/internal/emailstore/application"
 	ga "business/internal/gmail/application"
+	ma "business/internal/matching/application"
 	aiapp "business/internal/openAi/application"
+	ra "business/internal/reanalysis/application"
 
 	"go.uber.org/dig"
 )
@@ -12,11 +19,56 @@ import (
 // ProvidePresentationDependencies プレゼンテーション層の依存注入設定
 func ProvidePresentationDependencies(container *dig.Container) {
 	// AnalyzeEmailControllerの依存注入
-	_ = container.Provide(func(
+	mustProvide(container, func(
 		ea *ea.UseCase,
 		ga *ga.GmailUseCase,
 		aiapp *aiapp.UseCase,
 	) *presentation.AnalyzeEmailController {
 		return presentation.New(ea, ga, aiapp)
 	})
+
+	// ReanalysisControllerの依存注入
+	mustProvide(container, func(ra *ra.UseCase) *presentation.ReanalysisController {
+		return presentation.NewReanalysisController(ra)
+	})
+
+	// ProjectControllerの依存注入
+	mustProvide(container, func(pq *ea.ProjectQueryUseCase) *presentation.ProjectController {
+		return presentation.NewProjectController(pq)
+	})
+
+	// TriageControllerの依存注入
+	mustProvide(container, func(tu *ea.TriageUseCase) *presentation.TriageController {
+		return presentation.NewTriageController(tu)
+	})
+
+	// DictionaryControllerの依存注入
+	mustProvide(container, func(du *da.UseCase, cu *da.ClusterUseCase) *presentation.DictionaryController {
+		return presentation.NewDictionaryController(du, cu)
+	})
+
+	// DedupControllerの依存注入
+	mustProvide(container, func(du *dda.UseCase) *presentation.DedupController {
+		return presentation.NewDedupController(du)
+	})
+
+	// MatchingControllerの依存注入
+	mustProvide(container, func(mu *ma.UseCase) *presentation.MatchingController {
+		return presentation.NewMatchingController(mu)
+	})
+
+	// AlertControllerの依存注入
+	mustProvide(container, func(au *aa.UseCase) *presentation.AlertController {
+		return presentation.NewAlertController(au)
+	})
+
+	// AnalyticsControllerの依存注入
+	mustProvide(container, func(anu *ana.UseCase) *presentation.AnalyticsController {
+		return presentation.NewAnalyticsController(anu)
+	})
+
+	// AgencyControllerの依存注入
+	mustProvide(container, func(agu *aga.UseCase) *presentation.AgencyController {
+		return presentation.NewAgencyController(agu)
+	})
 }
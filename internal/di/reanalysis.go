@@ -0,0 +1,23 @@
+package di
+
+import (
+	ea "business/internal/emailstore/application"
+	aiapp "business/internal/openAi/application"
+	ra "business/internal/reanalysis/application"
+	ri "business/internal/reanalysis/infrastructure"
+	"business/tools/mysql"
+
+	"go.uber.org/dig"
+)
+
+// ProvideReanalysisDependencies 保存済みメールの再解析を実行する機能群の依存注入設定
+func ProvideReanalysisDependencies(container *dig.Container) {
+	// infra
+	mustProvide(container, func(conn *mysql.MySQL) *ri.Repository {
+		return ri.New(conn.DB)
+	})
+	// app
+	mustProvide(container, func(ri *ri.Repository, aiapp *aiapp.UseCase, ea *ea.UseCase) *ra.UseCase {
+		return ra.New(ri, aiapp, ea)
+	})
+}
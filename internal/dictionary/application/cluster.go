@@ -0,0 +1,235 @@
+// Package application は用語辞書（キーワードの表記ゆれ管理）機能のアプリケーション層を提供します。
+// このファイルは新しいキーワードグループの統合提案（クラスタリング）とレビューのユースケースを実装します。
+package application
+
+import (
+	"business/internal/dictionary/domain"
+	r "business/internal/dictionary/infrastructure"
+	"business/tools/keyword"
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// DefaultClusterThreshold は統合を提案する類似度の既定の下限です
+const DefaultClusterThreshold = 0.8
+
+// llmCandidates はLLMに渡す統合先の候補数です（類似度の高い順）
+const llmCandidates = 10
+
+// ClusterOptions はクラスタリングの設定です
+type ClusterOptions struct {
+	Threshold float64 // 統合を提案する類似度の下限（0以下の場合は DefaultClusterThreshold）
+	UseLLM    bool    // 類似度が下限に満たないキーワードをLLMで判定する
+}
+
+// ClusterUseCase はキーワードのクラスタリングと統合提案のレビューのユースケースです
+type ClusterUseCase struct {
+	r       r.RepositoryInterface
+	advisor r.AdvisorInterface
+}
+
+// NewCluster はクラスタリングのユースケースを作成します
+// advisor が nil の場合、LLMによる判定は行いません。
+func NewCluster(r r.RepositoryInterface, advisor r.AdvisorInterface) *ClusterUseCase {
+	return &ClusterUseCase{
+		r:       r,
+		advisor: advisor,
+	}
+}
+
+// ClusterKeywords は前回の実行以降に作成された、別名が1つ以下のキーワードグループの統合提案を作成します
+// 統合先は自身より前に作成されたグループから、類似度（編集距離・かな/ローマ字の畳み込み）の最も高いものを選びます。
+// 類似度が下限に満たない場合、UseLLM を指定していればLLMに候補から判定させます。
+// 作成した提案はレビュー待ちとして保存し、承認されるまで統合しません。
+func (u *ClusterUseCase) ClusterKeywords(ctx context.Context, opts ClusterOptions) (domain.ClusteringRun, error) {
+	if opts.Threshold <= 0 {
+		opts.Threshold = DefaultClusterThreshold
+	}
+	if opts.UseLLM && u.advisor == nil {
+		return domain.ClusteringRun{}, fmt.Errorf("%w: LLMによる判定は利用できません", domain.ErrInvalidOperation)
+	}
+
+	run := domain.ClusteringRun{UsedLLM: opts.UseLLM, StartedAt: time.Now()}
+	last, err := u.r.LastClusteredGroupID()
+	if err != nil {
+		return domain.ClusteringRun{}, fmt.Errorf("キーワードクラスタリングエラー: %w", err)
+	}
+	run.LastGroupID = last
+
+	targets, err := u.r.ListSingleAliasKeywordGroups(last)
+	if err != nil {
+		return domain.ClusteringRun{}, fmt.Errorf("キーワードクラスタリングエラー: %w", err)
+	}
+	if len(targets) == 0 {
+		return run, nil
+	}
+	groups, err := u.r.ListGroups(domain.KindKeyword, "")
+	if err != nil {
+		return domain.ClusteringRun{}, fmt.Errorf("キーワードクラスタリングエラー: %w", err)
+	}
+	sort.Slice(targets, func(i, j int) bool { return targets[i].ID < targets[j].ID })
+
+	for _, target := range targets {
+		run.Checked++
+		run.LastGroupID = max(run.LastGroupID, target.ID)
+
+		candidates := rankCandidates(target, groups)
+		if len(candidates) == 0 {
+			continue
+		}
+		best := candidates[0]
+		if best.score >= opts.Threshold {
+			run.Proposals = append(run.Proposals, domain.Proposal{
+				SourceGroupID: target.ID,
+				SourceName:    target.Name,
+				TargetGroupID: best.group.ID,
+				TargetName:    best.group.Name,
+				Score:         best.score,
+				Method:        domain.MethodSimilarity,
+				Reason:        fmt.Sprintf("%q と %q の類似度 %.2f", best.word, best.matched, best.score),
+			})
+			continue
+		}
+		if !opts.UseLLM {
+			continue
+		}
+
+		proposal, ok, err := u.suggest(ctx, target, candidates)
+		if err != nil {
+			return domain.ClusteringRun{}, fmt.Errorf("キーワードクラスタリングエラー: %w", err)
+		}
+		if ok {
+			run.Proposals = append(run.Proposals, proposal)
+		}
+	}
+
+	// 確認済みの位置は、別名が2つ以上で対象外だったグループも含めて進める
+	for _, g := range groups {
+		run.LastGroupID = max(run.LastGroupID, g.ID)
+	}
+	run.FinishedAt = time.Now()
+
+	saved, err := u.r.SaveClusteringRun(run)
+	if err != nil {
+		return domain.ClusteringRun{}, fmt.Errorf("キーワードクラスタリングエラー: %w", err)
+	}
+	return saved, nil
+}
+
+// suggest はLLMに統合先を判定させ、統合提案を返します
+func (u *ClusterUseCase) suggest(ctx context.Context, target domain.Group, candidates []candidate) (domain.Proposal, bool, error) {
+	if len(candidates) > llmCandidates {
+		candidates = candidates[:llmCandidates]
+	}
+	names := make([]string, 0, len(candidates))
+	for _, c := range candidates {
+		names = append(names, c.group.Name)
+	}
+
+	name, reason, err := u.advisor.SuggestGroup(ctx, target.Name, names)
+	if err != nil || name == "" {
+		return domain.Proposal{}, false, err
+	}
+	for _, c := range candidates {
+		if c.group.Name == name {
+			return domain.Proposal{
+				SourceGroupID: target.ID,
+				SourceName:    target.Name,
+				TargetGroupID: c.group.ID,
+				TargetName:    c.group.Name,
+				Score:         c.score,
+				Method:        domain.MethodLLM,
+				Reason:        reason,
+			}, true, nil
+		}
+	}
+	return domain.Proposal{}, false, nil
+}
+
+// candidate は統合先の候補と類似度です
+type candidate struct {
+	group   domain.Group
+	score   float64
+	word    string // 類似度が最も高かった統合元の表記
+	matched string // 類似度が最も高かった統合先の表記
+}
+
+// rankCandidates は target より前に作成されたグループを、正規名・別名の類似度の高い順に返します
+// 類似度が同じ場合は案件数の多い順、IDの小さい順とします。
+func rankCandidates(target domain.Group, groups []domain.Group) []candidate {
+	words := append([]string{target.Name}, target.Aliases...)
+
+	var candidates []candidate
+	for _, g := range groups {
+		if g.ID >= target.ID {
+			continue
+		}
+		best := candidate{group: g}
+		for _, w := range words {
+			for _, m := range append([]string{g.Name}, g.Aliases...) {
+				if s := keyword.Similarity(w, m); s > best.score {
+					best.score, best.word, best.matched = s, w, m
+				}
+			}
+		}
+		candidates = append(candidates, best)
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.score != b.score {
+			return a.score > b.score
+		}
+		if a.group.Usage != b.group.Usage {
+			return a.group.Usage > b.group.Usage
+		}
+		return a.group.ID < b.group.ID
+	})
+	return candidates
+}
+
+// ListProposals は統合提案を返します
+// status が空の場合はすべての状態を返します。limit が0以下の場合は件数を制限しません。
+func (u *ClusterUseCase) ListProposals(status domain.ProposalStatus, limit int) ([]domain.Proposal, error) {
+	if status != "" && !status.IsValid() {
+		return nil, fmt.Errorf("%w: 状態 %q は pending / accepted / rejected のいずれかを指定してください", domain.ErrInvalidOperation, status)
+	}
+	proposals, err := u.r.ListProposals(status, limit)
+	if err != nil {
+		return nil, fmt.Errorf("統合提案取得エラー: %w", err)
+	}
+	return proposals, nil
+}
+
+// AcceptProposal は統合提案を承認し、統合元のグループを統合先に統合します
+func (u *ClusterUseCase) AcceptProposal(id uint, actor string) (domain.Proposal, error) {
+	p, err := u.r.GetProposal(id)
+	if err != nil {
+		return domain.Proposal{}, err
+	}
+
+	log := domain.AuditLog{
+		Kind:          domain.KindKeyword,
+		Action:        domain.ActionMerge,
+		GroupID:       p.SourceGroupID,
+		TargetGroupID: &p.TargetGroupID,
+		Detail:        fmt.Sprintf("統合提案 #%d を承認: %s(#%d) を %s(#%d) に統合", p.ID, p.SourceName, p.SourceGroupID, p.TargetName, p.TargetGroupID),
+		Actor:         actorOrDefault(actor),
+	}
+	reviewed, err := u.r.ReviewProposal(id, domain.ProposalAccepted, actorOrDefault(actor), log)
+	if err != nil {
+		return domain.Proposal{}, fmt.Errorf("統合提案承認エラー: %w", err)
+	}
+	return reviewed, nil
+}
+
+// RejectProposal は統合提案を却下します（グループは変更しません）
+func (u *ClusterUseCase) RejectProposal(id uint, actor string) (domain.Proposal, error) {
+	reviewed, err := u.r.ReviewProposal(id, domain.ProposalRejected, actorOrDefault(actor), domain.AuditLog{})
+	if err != nil {
+		return domain.Proposal{}, fmt.Errorf("統合提案却下エラー: %w", err)
+	}
+	return reviewed, nil
+}
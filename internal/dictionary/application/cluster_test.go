@@ -0,0 +1,146 @@
+package application
+
+import (
+	"business/internal/dictionary/domain"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockAdvisor はLLMによる統合先判定のモックです
+type MockAdvisor struct {
+	mock.Mock
+}
+
+func (m *MockAdvisor) SuggestGroup(ctx context.Context, word string, candidates []string) (string, string, error) {
+	args := m.Called(word, candidates)
+	return args.String(0), args.String(1), args.Error(2)
+}
+
+// saveClusteringRunCalls は SaveClusteringRun に渡された実行結果を返します
+func (m *MockRepository) saveClusteringRunCalls() domain.ClusteringRun {
+	for _, c := range m.Calls {
+		if c.Method == "SaveClusteringRun" {
+			return c.Arguments.Get(0).(domain.ClusteringRun)
+		}
+	}
+	return domain.ClusteringRun{}
+}
+
+func keywordGroups() []domain.Group {
+	return []domain.Group{
+		{ID: 1, Kind: domain.KindKeyword, Name: "Next.js", Aliases: []string{"Next.js", "NextJS"}, Usage: 5},
+		{ID: 2, Kind: domain.KindKeyword, Name: "Nuxt.js", Aliases: []string{"Nuxt.js"}, Usage: 3},
+		{ID: 3, Kind: domain.KindKeyword, Name: "Go", Aliases: []string{"Go", "Golang"}, Usage: 10},
+		{ID: 10, Kind: domain.KindKeyword, Name: "Next", Aliases: []string{"Next"}},
+		{ID: 11, Kind: domain.KindKeyword, Name: "Nuxt3", Aliases: []string{"Nuxt3"}},
+		{ID: 12, Kind: domain.KindKeyword, Name: "ゴー言語", Aliases: []string{"ゴー言語"}},
+	}
+}
+
+func TestClusterKeywords_Similarity(t *testing.T) {
+	repo := new(MockRepository)
+	usecase := NewCluster(repo, nil)
+
+	groups := keywordGroups()
+	repo.On("LastClusteredGroupID").Return(uint(9), nil)
+	repo.On("ListSingleAliasKeywordGroups", uint(9)).Return(groups[3:], nil)
+	repo.On("ListGroups", domain.KindKeyword, "").Return(groups, nil)
+	repo.On("SaveClusteringRun", mock.Anything).Return(domain.ClusteringRun{ID: 1}, nil)
+
+	_, err := usecase.ClusterKeywords(context.Background(), ClusterOptions{})
+	require.NoError(t, err)
+
+	run := repo.saveClusteringRunCalls()
+	assert.Equal(t, 3, run.Checked)
+	assert.Equal(t, uint(12), run.LastGroupID)
+	require.Len(t, run.Proposals, 2)
+	assert.Equal(t, uint(10), run.Proposals[0].SourceGroupID)
+	assert.Equal(t, uint(1), run.Proposals[0].TargetGroupID)
+	assert.Equal(t, domain.MethodSimilarity, run.Proposals[0].Method)
+	assert.Equal(t, uint(11), run.Proposals[1].SourceGroupID)
+	assert.Equal(t, uint(2), run.Proposals[1].TargetGroupID)
+}
+
+func TestClusterKeywords_LLM(t *testing.T) {
+	repo := new(MockRepository)
+	advisor := new(MockAdvisor)
+	usecase := NewCluster(repo, advisor)
+
+	groups := keywordGroups()
+	repo.On("LastClusteredGroupID").Return(uint(11), nil)
+	repo.On("ListSingleAliasKeywordGroups", uint(11)).Return(groups[5:], nil)
+	repo.On("ListGroups", domain.KindKeyword, "").Return(groups, nil)
+	repo.On("SaveClusteringRun", mock.Anything).Return(domain.ClusteringRun{ID: 1}, nil)
+	advisor.On("SuggestGroup", "ゴー言語", mock.Anything).Return("Go", "Go言語のカタカナ表記", nil)
+
+	_, err := usecase.ClusterKeywords(context.Background(), ClusterOptions{UseLLM: true})
+	require.NoError(t, err)
+
+	run := repo.saveClusteringRunCalls()
+	assert.True(t, run.UsedLLM)
+	require.Len(t, run.Proposals, 1)
+	assert.Equal(t, uint(3), run.Proposals[0].TargetGroupID)
+	assert.Equal(t, domain.MethodLLM, run.Proposals[0].Method)
+	assert.Equal(t, "Go言語のカタカナ表記", run.Proposals[0].Reason)
+
+	// LLMの判定に失敗した場合は保存しない
+	repo = new(MockRepository)
+	advisor = new(MockAdvisor)
+	usecase = NewCluster(repo, advisor)
+	repo.On("LastClusteredGroupID").Return(uint(11), nil)
+	repo.On("ListSingleAliasKeywordGroups", uint(11)).Return(groups[5:], nil)
+	repo.On("ListGroups", domain.KindKeyword, "").Return(groups, nil)
+	advisor.On("SuggestGroup", mock.Anything, mock.Anything).Return("", "", errors.New("api error"))
+
+	_, err = usecase.ClusterKeywords(context.Background(), ClusterOptions{UseLLM: true})
+	assert.Error(t, err)
+	repo.AssertNotCalled(t, "SaveClusteringRun", mock.Anything)
+}
+
+func TestClusterKeywords_NothingNew(t *testing.T) {
+	repo := new(MockRepository)
+	usecase := NewCluster(repo, nil)
+
+	repo.On("LastClusteredGroupID").Return(uint(12), nil)
+	repo.On("ListSingleAliasKeywordGroups", uint(12)).Return([]domain.Group{}, nil)
+
+	run, err := usecase.ClusterKeywords(context.Background(), ClusterOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 0, run.Checked)
+	repo.AssertNotCalled(t, "SaveClusteringRun", mock.Anything)
+
+	// advisor がない場合はLLMを使えない
+	_, err = usecase.ClusterKeywords(context.Background(), ClusterOptions{UseLLM: true})
+	assert.ErrorIs(t, err, domain.ErrInvalidOperation)
+}
+
+func TestReviewProposal(t *testing.T) {
+	repo := new(MockRepository)
+	usecase := NewCluster(repo, nil)
+
+	proposal := domain.Proposal{ID: 4, SourceGroupID: 10, SourceName: "Next", TargetGroupID: 1, TargetName: "Next.js", Status: domain.ProposalPending}
+	accepted := proposal
+	accepted.Status = domain.ProposalAccepted
+	repo.On("GetProposal", uint(4)).Return(proposal, nil)
+	repo.On("ReviewProposal", uint(4), domain.ProposalAccepted, "cli", mock.MatchedBy(func(log domain.AuditLog) bool {
+		return log.Action == domain.ActionMerge && log.GroupID == 10 && *log.TargetGroupID == 1
+	})).Return(accepted, nil)
+
+	reviewed, err := usecase.AcceptProposal(4, ActorCLI)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ProposalAccepted, reviewed.Status)
+
+	// レビュー済みの提案
+	repo.On("ReviewProposal", uint(5), domain.ProposalRejected, "api", domain.AuditLog{}).Return(domain.Proposal{}, domain.ErrProposalReviewed)
+	_, err = usecase.RejectProposal(5, "")
+	assert.ErrorIs(t, err, domain.ErrProposalReviewed)
+
+	// 不正な状態での絞り込み
+	_, err = usecase.ListProposals("done", 0)
+	assert.ErrorIs(t, err, domain.ErrInvalidOperation)
+}
@@ -0,0 +1,219 @@
+// Package application は用語辞書（キーワードの表記ゆれ管理）機能のアプリケーション層を提供します。
+// このファイルはグループの一覧・統合・分割・別名・正規名の操作と操作履歴のユースケースを実装します。
+package application
+
+import (
+	"business/internal/dictionary/domain"
+	"fmt"
+	"strings"
+)
+
+// 操作者
+const (
+	ActorCLI = "cli" // CLIからの操作
+	ActorAPI = "api" // APIからの操作（X-Actor ヘッダー未指定時）
+)
+
+// DefaultAuditLogLimit は操作履歴の既定の取得件数です
+const DefaultAuditLogLimit = 100
+
+// ListGroups は種類ごとのグループを別名・案件数付きで、案件数の多い順に返します
+// q を指定した場合は、正規名または別名に q を含むグループのみ返します。
+func (u *UseCase) ListGroups(kind domain.Kind, q string) ([]domain.Group, error) {
+	if err := validateKind(kind); err != nil {
+		return nil, err
+	}
+	groups, err := u.r.ListGroups(kind, strings.TrimSpace(q))
+	if err != nil {
+		return nil, fmt.Errorf("用語辞書取得エラー: %w", err)
+	}
+	return groups, nil
+}
+
+// MergeGroups はグループ sourceID をグループ targetID に統合し、統合後のグループを返します
+// 案件の紐付けと別名は統合先に付け替え、統合元の正規名は統合先の別名として残します。
+func (u *UseCase) MergeGroups(kind domain.Kind, sourceID, targetID uint, actor string) (domain.Group, error) {
+	if err := validateKind(kind); err != nil {
+		return domain.Group{}, err
+	}
+	if sourceID == targetID {
+		return domain.Group{}, fmt.Errorf("%w: 統合元と統合先が同じグループです", domain.ErrInvalidOperation)
+	}
+
+	source, err := u.r.GetGroup(kind, sourceID)
+	if err != nil {
+		return domain.Group{}, err
+	}
+	target, err := u.r.GetGroup(kind, targetID)
+	if err != nil {
+		return domain.Group{}, err
+	}
+
+	log := domain.AuditLog{
+		Kind:          kind,
+		Action:        domain.ActionMerge,
+		GroupID:       sourceID,
+		TargetGroupID: &targetID,
+		Detail:        fmt.Sprintf("%s(#%d) を %s(#%d) に統合", source.Name, source.ID, target.Name, target.ID),
+		Actor:         actorOrDefault(actor),
+	}
+	if err := u.r.MergeGroups(kind, sourceID, targetID, log); err != nil {
+		return domain.Group{}, fmt.Errorf("用語辞書統合エラー: %w", err)
+	}
+	return u.r.GetGroup(kind, targetID)
+}
+
+// SplitGroup はグループから別名を切り出し、name を正規名とする新しいグループを作成して返します
+// 切り出した別名のみを含む案件は新しいグループに付け替えます。
+func (u *UseCase) SplitGroup(kind domain.Kind, id uint, name string, aliases []string, actor string) (domain.Group, error) {
+	if err := validateKind(kind); err != nil {
+		return domain.Group{}, err
+	}
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return domain.Group{}, fmt.Errorf("%w: 新しいグループの正規名を指定してください", domain.ErrInvalidOperation)
+	}
+	aliases = trimAll(aliases)
+
+	group, err := u.r.GetGroup(kind, id)
+	if err != nil {
+		return domain.Group{}, err
+	}
+	for _, alias := range aliases {
+		if !group.HasAlias(alias) {
+			return domain.Group{}, fmt.Errorf("%w: %q は %s(#%d) の別名ではありません", domain.ErrAliasNotFound, alias, group.Name, group.ID)
+		}
+	}
+
+	log := domain.AuditLog{
+		Kind:    kind,
+		Action:  domain.ActionSplit,
+		GroupID: id,
+		Detail:  fmt.Sprintf("%s(#%d) から %s を分割（別名: %s）", group.Name, group.ID, name, strings.Join(aliases, ", ")),
+		Actor:   actorOrDefault(actor),
+	}
+	newID, err := u.r.SplitGroup(kind, id, name, aliases, log)
+	if err != nil {
+		return domain.Group{}, fmt.Errorf("用語辞書分割エラー: %w", err)
+	}
+	return u.r.GetGroup(kind, newID)
+}
+
+// AddAlias はグループに別名を追加し、追加後のグループを返します
+func (u *UseCase) AddAlias(kind domain.Kind, id uint, word, actor string) (domain.Group, error) {
+	return u.changeAlias(kind, id, word, actor, domain.ActionAddAlias)
+}
+
+// RemoveAlias はグループから別名を削除し、削除後のグループを返します
+func (u *UseCase) RemoveAlias(kind domain.Kind, id uint, word, actor string) (domain.Group, error) {
+	return u.changeAlias(kind, id, word, actor, domain.ActionRemoveAlias)
+}
+
+// changeAlias は別名の追加・削除を行います
+func (u *UseCase) changeAlias(kind domain.Kind, id uint, word, actor string, action domain.Action) (domain.Group, error) {
+	if err := validateKind(kind); err != nil {
+		return domain.Group{}, err
+	}
+	word = strings.TrimSpace(word)
+	if word == "" {
+		return domain.Group{}, fmt.Errorf("%w: 別名を指定してください", domain.ErrInvalidOperation)
+	}
+
+	group, err := u.r.GetGroup(kind, id)
+	if err != nil {
+		return domain.Group{}, err
+	}
+
+	log := domain.AuditLog{Kind: kind, Action: action, GroupID: id, Actor: actorOrDefault(actor)}
+	if action == domain.ActionAddAlias {
+		log.Detail = fmt.Sprintf("%s(#%d) に別名 %q を追加", group.Name, group.ID, word)
+		err = u.r.AddAlias(kind, id, word, log)
+	} else {
+		if strings.EqualFold(word, group.Name) {
+			return domain.Group{}, fmt.Errorf("%w: 正規名と同じ別名は削除できません", domain.ErrInvalidOperation)
+		}
+		log.Detail = fmt.Sprintf("%s(#%d) から別名 %q を削除", group.Name, group.ID, word)
+		err = u.r.RemoveAlias(kind, id, word, log)
+	}
+	if err != nil {
+		return domain.Group{}, fmt.Errorf("用語辞書別名変更エラー: %w", err)
+	}
+	return u.r.GetGroup(kind, id)
+}
+
+// RenameGroup はグループの正規名を変更し、変更後のグループを返します
+// 変更前の正規名は別名として残します。
+func (u *UseCase) RenameGroup(kind domain.Kind, id uint, name, actor string) (domain.Group, error) {
+	if err := validateKind(kind); err != nil {
+		return domain.Group{}, err
+	}
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return domain.Group{}, fmt.Errorf("%w: 新しい正規名を指定してください", domain.ErrInvalidOperation)
+	}
+
+	group, err := u.r.GetGroup(kind, id)
+	if err != nil {
+		return domain.Group{}, err
+	}
+	if group.Name == name {
+		return group, nil
+	}
+
+	log := domain.AuditLog{
+		Kind:    kind,
+		Action:  domain.ActionRename,
+		GroupID: id,
+		Detail:  fmt.Sprintf("#%d の正規名を %q から %q に変更", group.ID, group.Name, name),
+		Actor:   actorOrDefault(actor),
+	}
+	if err := u.r.RenameGroup(kind, id, name, log); err != nil {
+		return domain.Group{}, fmt.Errorf("用語辞書正規名変更エラー: %w", err)
+	}
+	return u.r.GetGroup(kind, id)
+}
+
+// AuditLogs は用語辞書の操作履歴を新しい順に返します
+// kind が空の場合はすべての種類、limit が0以下の場合は DefaultAuditLogLimit 件を返します。
+func (u *UseCase) AuditLogs(kind domain.Kind, limit int) ([]domain.AuditLog, error) {
+	if kind != "" {
+		if err := validateKind(kind); err != nil {
+			return nil, err
+		}
+	}
+	if limit <= 0 {
+		limit = DefaultAuditLogLimit
+	}
+	logs, err := u.r.ListAuditLogs(kind, limit)
+	if err != nil {
+		return nil, fmt.Errorf("用語辞書操作履歴取得エラー: %w", err)
+	}
+	return logs, nil
+}
+
+// validateKind は用語辞書の種類が定義済みかを確認します
+func validateKind(kind domain.Kind) error {
+	if !kind.IsValid() {
+		return fmt.Errorf("%w: 種類 %q は keyword / position / work_type のいずれかを指定してください", domain.ErrInvalidOperation, kind)
+	}
+	return nil
+}
+
+// actorOrDefault は操作者が未指定の場合に ActorAPI を返します
+func actorOrDefault(actor string) string {
+	if actor = strings.TrimSpace(actor); actor == "" {
+		return ActorAPI
+	}
+	return actor
+}
+
+// trimAll は前後の空白を除き、空文字を除いて返します
+func trimAll(words []string) []string {
+	trimmed := make([]string, 0, len(words))
+	for _, w := range words {
+		if w = strings.TrimSpace(w); w != "" {
+			trimmed = append(trimmed, w)
+		}
+	}
+	return trimmed
+}
@@ -0,0 +1,138 @@
+package application
+
+import (
+	"business/internal/dictionary/domain"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListGroups(t *testing.T) {
+	repo := new(MockRepository)
+	usecase := New(repo, newOsWrapper(""))
+
+	groups := []domain.Group{{ID: 1, Kind: domain.KindPosition, Name: "PM", Aliases: []string{"PM", "ＰＭ"}, Usage: 3}}
+	repo.On("ListGroups", domain.KindPosition, "PM").Return(groups, nil)
+
+	listed, err := usecase.ListGroups(domain.KindPosition, " PM ")
+	require.NoError(t, err)
+	assert.Equal(t, groups, listed)
+
+	// 未定義の種類
+	_, err = usecase.ListGroups("skill", "")
+	assert.ErrorIs(t, err, domain.ErrInvalidOperation)
+}
+
+func TestMergeGroups(t *testing.T) {
+	repo := new(MockRepository)
+	usecase := New(repo, newOsWrapper(""))
+
+	source := domain.Group{ID: 2, Kind: domain.KindKeyword, Name: "JS"}
+	target := domain.Group{ID: 1, Kind: domain.KindKeyword, Name: "JavaScript"}
+	merged := domain.Group{ID: 1, Kind: domain.KindKeyword, Name: "JavaScript", Aliases: []string{"JavaScript", "JS"}}
+	repo.On("GetGroup", domain.KindKeyword, uint(2)).Return(source, nil)
+	repo.On("GetGroup", domain.KindKeyword, uint(1)).Return(target, nil).Once()
+	repo.On("GetGroup", domain.KindKeyword, uint(1)).Return(merged, nil).Once()
+	repo.On("MergeGroups", domain.KindKeyword, uint(2), uint(1), mock.MatchedBy(func(log domain.AuditLog) bool {
+		return log.Action == domain.ActionMerge && *log.TargetGroupID == 1 && log.Actor == ActorCLI &&
+			log.Detail == "JS(#2) を JavaScript(#1) に統合"
+	})).Return(nil)
+
+	group, err := usecase.MergeGroups(domain.KindKeyword, 2, 1, ActorCLI)
+	require.NoError(t, err)
+	assert.Equal(t, merged, group)
+	repo.AssertExpectations(t)
+
+	// 同じグループには統合できない
+	_, err = usecase.MergeGroups(domain.KindKeyword, 1, 1, ActorCLI)
+	assert.ErrorIs(t, err, domain.ErrInvalidOperation)
+}
+
+func TestSplitGroup(t *testing.T) {
+	repo := new(MockRepository)
+	usecase := New(repo, newOsWrapper(""))
+
+	group := domain.Group{ID: 1, Kind: domain.KindKeyword, Name: "Java", Aliases: []string{"Java", "JavaScript", "JS"}}
+	created := domain.Group{ID: 9, Kind: domain.KindKeyword, Name: "JavaScript", Aliases: []string{"JavaScript", "JS"}}
+	repo.On("GetGroup", domain.KindKeyword, uint(1)).Return(group, nil)
+	repo.On("GetGroup", domain.KindKeyword, uint(9)).Return(created, nil)
+	repo.On("SplitGroup", domain.KindKeyword, uint(1), "JavaScript", []string{"JavaScript", "JS"}, mock.MatchedBy(func(log domain.AuditLog) bool {
+		// 操作者が未指定の場合は api とする
+		return log.Action == domain.ActionSplit && log.Actor == ActorAPI
+	})).Return(uint(9), nil)
+
+	split, err := usecase.SplitGroup(domain.KindKeyword, 1, "JavaScript", []string{" JavaScript", "JS", ""}, "")
+	require.NoError(t, err)
+	assert.Equal(t, created, split)
+
+	// 登録されていない別名は切り出せない
+	_, err = usecase.SplitGroup(domain.KindKeyword, 1, "TypeScript", []string{"TS"}, ActorCLI)
+	assert.ErrorIs(t, err, domain.ErrAliasNotFound)
+
+	// 正規名は必須
+	_, err = usecase.SplitGroup(domain.KindKeyword, 1, " ", nil, ActorCLI)
+	assert.ErrorIs(t, err, domain.ErrInvalidOperation)
+}
+
+func TestAddAndRemoveAlias(t *testing.T) {
+	repo := new(MockRepository)
+	usecase := New(repo, newOsWrapper(""))
+
+	group := domain.Group{ID: 3, Kind: domain.KindWorkType, Name: "バックエンド開発", Aliases: []string{"バックエンド開発", "サーバーサイド"}}
+	repo.On("GetGroup", domain.KindWorkType, uint(3)).Return(group, nil)
+	repo.On("AddAlias", domain.KindWorkType, uint(3), "API開発", mock.Anything).Return(nil)
+	repo.On("RemoveAlias", domain.KindWorkType, uint(3), "サーバーサイド", mock.Anything).Return(nil)
+
+	_, err := usecase.AddAlias(domain.KindWorkType, 3, " API開発 ", ActorCLI)
+	require.NoError(t, err)
+	_, err = usecase.RemoveAlias(domain.KindWorkType, 3, "サーバーサイド", ActorCLI)
+	require.NoError(t, err)
+
+	// 正規名と同じ別名は削除できない
+	_, err = usecase.RemoveAlias(domain.KindWorkType, 3, "バックエンド開発", ActorCLI)
+	assert.ErrorIs(t, err, domain.ErrInvalidOperation)
+
+	// リポジトリのエラー（重複など）はそのまま判別できる
+	repo.On("AddAlias", domain.KindWorkType, uint(3), "インフラ", mock.Anything).Return(domain.ErrConflict)
+	_, err = usecase.AddAlias(domain.KindWorkType, 3, "インフラ", ActorCLI)
+	assert.ErrorIs(t, err, domain.ErrConflict)
+}
+
+func TestRenameGroup(t *testing.T) {
+	repo := new(MockRepository)
+	usecase := New(repo, newOsWrapper(""))
+
+	group := domain.Group{ID: 5, Kind: domain.KindPosition, Name: "ＰＭ"}
+	repo.On("GetGroup", domain.KindPosition, uint(5)).Return(group, nil)
+	repo.On("RenameGroup", domain.KindPosition, uint(5), "PM", mock.MatchedBy(func(log domain.AuditLog) bool {
+		return log.Detail == `#5 の正規名を "ＰＭ" から "PM" に変更`
+	})).Return(nil)
+
+	_, err := usecase.RenameGroup(domain.KindPosition, 5, "PM", ActorCLI)
+	require.NoError(t, err)
+	repo.AssertExpectations(t)
+
+	// 存在しないグループ
+	repo.On("GetGroup", domain.KindPosition, uint(6)).Return(domain.Group{}, domain.ErrGroupNotFound)
+	_, err = usecase.RenameGroup(domain.KindPosition, 6, "PM", ActorCLI)
+	assert.ErrorIs(t, err, domain.ErrGroupNotFound)
+}
+
+func TestAuditLogs(t *testing.T) {
+	repo := new(MockRepository)
+	usecase := New(repo, newOsWrapper(""))
+
+	logs := []domain.AuditLog{{ID: 1, Kind: domain.KindKeyword, Action: domain.ActionMerge}}
+	repo.On("ListAuditLogs", domain.Kind(""), DefaultAuditLogLimit).Return(logs, nil)
+	repo.On("ListAuditLogs", domain.KindKeyword, 10).Return([]domain.AuditLog{}, errors.New("db error"))
+
+	listed, err := usecase.AuditLogs("", 0)
+	require.NoError(t, err)
+	assert.Equal(t, logs, listed)
+
+	_, err = usecase.AuditLogs(domain.KindKeyword, 10)
+	assert.Error(t, err)
+}
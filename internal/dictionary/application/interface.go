@@ -0,0 +1,50 @@
+// Package application は用語辞書（キーワードの表記ゆれ管理）機能のアプリケーション層を提供します。
+// このファイルはユースケースのインターフェースを定義します。
+package application
+
+import (
+	"business/internal/dictionary/domain"
+	"context"
+)
+
+// UseCaseInterface は用語辞書のユースケースインターフェースです
+type UseCaseInterface interface {
+	// NormalizeKeywords は同じキーワードに正規化されるキーワードグループを統合し、統合内容を返します
+	NormalizeKeywords(dryRun bool) ([]domain.Merge, error)
+
+	// ListGroups は種類ごとのグループを別名・案件数付きで、案件数の多い順に返します
+	ListGroups(kind domain.Kind, q string) ([]domain.Group, error)
+
+	// MergeGroups はグループを統合し、統合後のグループを返します
+	MergeGroups(kind domain.Kind, sourceID, targetID uint, actor string) (domain.Group, error)
+
+	// SplitGroup はグループから別名を切り出し、新しいグループを返します
+	SplitGroup(kind domain.Kind, id uint, name string, aliases []string, actor string) (domain.Group, error)
+
+	// AddAlias はグループに別名を追加し、追加後のグループを返します
+	AddAlias(kind domain.Kind, id uint, word, actor string) (domain.Group, error)
+
+	// RemoveAlias はグループから別名を削除し、削除後のグループを返します
+	RemoveAlias(kind domain.Kind, id uint, word, actor string) (domain.Group, error)
+
+	// RenameGroup はグループの正規名を変更し、変更後のグループを返します
+	RenameGroup(kind domain.Kind, id uint, name, actor string) (domain.Group, error)
+
+	// AuditLogs は用語辞書の操作履歴を新しい順に返します
+	AuditLogs(kind domain.Kind, limit int) ([]domain.AuditLog, error)
+}
+
+// ClusterUseCaseInterface はキーワードのクラスタリングと統合提案のレビューのユースケースインターフェースです
+type ClusterUseCaseInterface interface {
+	// ClusterKeywords は前回の実行以降に作成されたキーワードグループの統合提案を作成します
+	ClusterKeywords(ctx context.Context, opts ClusterOptions) (domain.ClusteringRun, error)
+
+	// ListProposals は統合提案を返します
+	ListProposals(status domain.ProposalStatus, limit int) ([]domain.Proposal, error)
+
+	// AcceptProposal は統合提案を承認し、グループを統合します
+	AcceptProposal(id uint, actor string) (domain.Proposal, error)
+
+	// RejectProposal は統合提案を却下します
+	RejectProposal(id uint, actor string) (domain.Proposal, error)
+}
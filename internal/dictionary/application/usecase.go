@@ -0,0 +1,119 @@
+// Package application は用語辞書（キーワードの表記ゆれ管理）機能のアプリケーション層を提供します。
+// このファイルはキーワードグループの正規化と統合のユースケースを実装します。
+package application
+
+import (
+	"business/internal/dictionary/domain"
+	r "business/internal/dictionary/infrastructure"
+	"business/tools/keyword"
+	"business/tools/oswrapper"
+	"fmt"
+	"strings"
+)
+
+// UseCase は用語辞書のユースケースの具象です
+type UseCase struct {
+	r  r.RepositoryInterface
+	os oswrapper.OsWapperInterface
+}
+
+// New は用語辞書ユースケースを作成します
+func New(r r.RepositoryInterface, os oswrapper.OsWapperInterface) *UseCase {
+	return &UseCase{
+		r:  r,
+		os: os,
+	}
+}
+
+// NormalizeKeywords は同じキーワードに正規化されるキーワードグループを統合し、統合内容を返します
+// 正規化は全角・半角、大文字・小文字、括弧書き、バージョン番号と別名ルールファイルに基づきます。
+// dryRun が true の場合は統合内容のみ返し、DBは変更しません。
+func (u *UseCase) NormalizeKeywords(dryRun bool) ([]domain.Merge, error) {
+	n, err := keyword.Load(u.os)
+	if err != nil {
+		return nil, fmt.Errorf("キーワード正規化エラー: %w", err)
+	}
+
+	groups, err := u.r.ListKeywordGroups()
+	if err != nil {
+		return nil, fmt.Errorf("キーワード正規化エラー: %w", err)
+	}
+
+	merges := planMerges(groups, n)
+	if dryRun || len(merges) == 0 {
+		return merges, nil
+	}
+
+	if err := u.r.MergeKeywordGroups(merges, normalizeLogs(merges)); err != nil {
+		return nil, fmt.Errorf("キーワード正規化エラー: %w", err)
+	}
+	return merges, nil
+}
+
+// normalizeLogs は表記ゆれの自動統合の操作履歴を作成します
+func normalizeLogs(merges []domain.Merge) []domain.AuditLog {
+	logs := make([]domain.AuditLog, 0, len(merges))
+	for _, m := range merges {
+		merged := make([]string, 0, len(m.Merged))
+		for _, g := range m.Merged {
+			merged = append(merged, fmt.Sprintf("%s(#%d)", g.Name, g.ID))
+		}
+		detail := fmt.Sprintf("%s(#%d) に統合: %s", m.Name, m.Survivor.ID, strings.Join(merged, ", "))
+		if m.Renamed() {
+			detail += fmt.Sprintf("（正規名を %q から変更）", m.Survivor.Name)
+		}
+		logs = append(logs, domain.AuditLog{
+			Kind:    domain.KindKeyword,
+			Action:  domain.ActionNormalize,
+			GroupID: m.Survivor.ID,
+			Detail:  detail,
+			Actor:   ActorCLI,
+		})
+	}
+	return logs
+}
+
+// planMerges はキーワードグループを正規化後のキーでまとめ、統合またはグループ名の変更が必要なものを返します
+// 残すグループは、グループ名が正規名と一致するもの、なければIDの最も小さいものとします。
+// groups はID順であることを前提とします。
+func planMerges(groups []domain.KeywordGroup, n *keyword.Normalizer) []domain.Merge {
+	var order []string
+	byKey := make(map[string][]domain.KeywordGroup)
+	names := make(map[string]string)
+	for _, g := range groups {
+		name := n.Normalize(g.Name)
+		if name == "" {
+			continue
+		}
+		key := n.Key(g.Name)
+		if _, ok := byKey[key]; !ok {
+			order = append(order, key)
+			names[key] = name
+		}
+		byKey[key] = append(byKey[key], g)
+	}
+
+	var merges []domain.Merge
+	for _, key := range order {
+		members := byKey[key]
+		survivor := 0
+		for i, g := range members {
+			if g.Name == names[key] {
+				survivor = i
+				break
+			}
+		}
+
+		merge := domain.Merge{Name: names[key], Survivor: members[survivor]}
+		for i, g := range members {
+			if i != survivor {
+				merge.Merged = append(merge.Merged, g)
+			}
+		}
+		if len(merge.Merged) == 0 && !merge.Renamed() {
+			continue
+		}
+		merges = append(merges, merge)
+	}
+	return merges
+}
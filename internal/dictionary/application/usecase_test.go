@@ -0,0 +1,193 @@
+package application
+
+import (
+	"business/internal/dictionary/domain"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockRepository は用語辞書リポジトリのモックです
+type MockRepository struct {
+	mock.Mock
+}
+
+func (m *MockRepository) ListKeywordGroups() ([]domain.KeywordGroup, error) {
+	args := m.Called()
+	return args.Get(0).([]domain.KeywordGroup), args.Error(1)
+}
+
+func (m *MockRepository) MergeKeywordGroups(merges []domain.Merge, logs []domain.AuditLog) error {
+	args := m.Called(merges, logs)
+	return args.Error(0)
+}
+
+func (m *MockRepository) ListGroups(kind domain.Kind, q string) ([]domain.Group, error) {
+	args := m.Called(kind, q)
+	return args.Get(0).([]domain.Group), args.Error(1)
+}
+
+func (m *MockRepository) GetGroup(kind domain.Kind, id uint) (domain.Group, error) {
+	args := m.Called(kind, id)
+	return args.Get(0).(domain.Group), args.Error(1)
+}
+
+func (m *MockRepository) MergeGroups(kind domain.Kind, sourceID, targetID uint, log domain.AuditLog) error {
+	args := m.Called(kind, sourceID, targetID, log)
+	return args.Error(0)
+}
+
+func (m *MockRepository) SplitGroup(kind domain.Kind, id uint, name string, aliases []string, log domain.AuditLog) (uint, error) {
+	args := m.Called(kind, id, name, aliases, log)
+	return args.Get(0).(uint), args.Error(1)
+}
+
+func (m *MockRepository) AddAlias(kind domain.Kind, id uint, word string, log domain.AuditLog) error {
+	args := m.Called(kind, id, word, log)
+	return args.Error(0)
+}
+
+func (m *MockRepository) RemoveAlias(kind domain.Kind, id uint, word string, log domain.AuditLog) error {
+	args := m.Called(kind, id, word, log)
+	return args.Error(0)
+}
+
+func (m *MockRepository) RenameGroup(kind domain.Kind, id uint, name string, log domain.AuditLog) error {
+	args := m.Called(kind, id, name, log)
+	return args.Error(0)
+}
+
+func (m *MockRepository) ListAuditLogs(kind domain.Kind, limit int) ([]domain.AuditLog, error) {
+	args := m.Called(kind, limit)
+	return args.Get(0).([]domain.AuditLog), args.Error(1)
+}
+
+func (m *MockRepository) LastClusteredGroupID() (uint, error) {
+	args := m.Called()
+	return args.Get(0).(uint), args.Error(1)
+}
+
+func (m *MockRepository) ListSingleAliasKeywordGroups(afterID uint) ([]domain.Group, error) {
+	args := m.Called(afterID)
+	return args.Get(0).([]domain.Group), args.Error(1)
+}
+
+func (m *MockRepository) SaveClusteringRun(run domain.ClusteringRun) (domain.ClusteringRun, error) {
+	args := m.Called(run)
+	return args.Get(0).(domain.ClusteringRun), args.Error(1)
+}
+
+func (m *MockRepository) ListProposals(status domain.ProposalStatus, limit int) ([]domain.Proposal, error) {
+	args := m.Called(status, limit)
+	return args.Get(0).([]domain.Proposal), args.Error(1)
+}
+
+func (m *MockRepository) GetProposal(id uint) (domain.Proposal, error) {
+	args := m.Called(id)
+	return args.Get(0).(domain.Proposal), args.Error(1)
+}
+
+func (m *MockRepository) ReviewProposal(id uint, status domain.ProposalStatus, actor string, log domain.AuditLog) (domain.Proposal, error) {
+	args := m.Called(id, status, actor, log)
+	return args.Get(0).(domain.Proposal), args.Error(1)
+}
+
+// モック: oswrapper
+type mockOsWrapper struct {
+	files map[string]string
+	env   map[string]string
+}
+
+func (m *mockOsWrapper) ReadFile(path string) (string, error) {
+	if text, ok := m.files[path]; ok {
+		return text, nil
+	}
+	return "", errors.New("file not found")
+}
+
+func (m *mockOsWrapper) GetEnv(key string) string {
+	return m.env[key]
+}
+
+func newOsWrapper(rules string) *mockOsWrapper {
+	return &mockOsWrapper{files: map[string]string{"/data/dictionary/keyword_aliases.txt": rules}}
+}
+
+func TestNormalizeKeywords_Merge(t *testing.T) {
+	repo := new(MockRepository)
+	usecase := New(repo, newOsWrapper("JavaScript: JS\nJava: Java8"))
+
+	groups := []domain.KeywordGroup{
+		{ID: 1, Name: "java", Type: "language"},
+		{ID: 2, Name: "Java", Type: "language"},
+		{ID: 3, Name: "ＪＡＶＡ", Type: "must"},
+		{ID: 4, Name: "Java(Spring)", Type: "framework"},
+		{ID: 5, Name: "Go", Type: "language"},
+		{ID: 6, Name: "JS", Type: "language"},
+		{ID: 7, Name: "javascript ", Type: "language"},
+		{ID: 8, Name: "PHP 8", Type: "language"},
+	}
+	expected := []domain.Merge{
+		// 正規名と一致するグループを残す
+		{Name: "Java", Survivor: groups[1], Merged: []domain.KeywordGroup{groups[0], groups[2], groups[3]}},
+		// 一致するグループがない場合はIDの小さいグループを残し、正規名に変更する
+		{Name: "JavaScript", Survivor: groups[5], Merged: []domain.KeywordGroup{groups[6]}},
+		// 統合するグループがなくても名前が変わる場合は対象にする
+		{Name: "PHP", Survivor: groups[7]},
+	}
+	repo.On("ListKeywordGroups").Return(groups, nil)
+	repo.On("MergeKeywordGroups", expected, mock.MatchedBy(func(logs []domain.AuditLog) bool {
+		return len(logs) == 3 && logs[0].Action == domain.ActionNormalize && logs[1].GroupID == 6
+	})).Return(nil)
+
+	merges, err := usecase.NormalizeKeywords(false)
+	require.NoError(t, err)
+	assert.Equal(t, expected, merges)
+	assert.False(t, merges[0].Renamed())
+	assert.True(t, merges[1].Renamed())
+	repo.AssertExpectations(t)
+}
+
+func TestNormalizeKeywords_DryRun(t *testing.T) {
+	repo := new(MockRepository)
+	usecase := New(repo, newOsWrapper(""))
+
+	repo.On("ListKeywordGroups").Return([]domain.KeywordGroup{{ID: 1, Name: "Go"}, {ID: 2, Name: "go"}}, nil)
+
+	merges, err := usecase.NormalizeKeywords(true)
+	require.NoError(t, err)
+	assert.Len(t, merges, 1)
+	repo.AssertNotCalled(t, "MergeKeywordGroups", mock.Anything, mock.Anything)
+}
+
+func TestNormalizeKeywords_NothingToMerge(t *testing.T) {
+	repo := new(MockRepository)
+	usecase := New(repo, newOsWrapper(""))
+
+	repo.On("ListKeywordGroups").Return([]domain.KeywordGroup{{ID: 1, Name: "Go"}, {ID: 2, Name: "PHP"}}, nil)
+
+	merges, err := usecase.NormalizeKeywords(false)
+	require.NoError(t, err)
+	assert.Empty(t, merges)
+	repo.AssertNotCalled(t, "MergeKeywordGroups", mock.Anything, mock.Anything)
+}
+
+func TestNormalizeKeywords_Error(t *testing.T) {
+	// 別名ルールファイルの形式が正しくない場合
+	usecase := New(new(MockRepository), newOsWrapper("JavaScript JS"))
+	_, err := usecase.NormalizeKeywords(false)
+	assert.Error(t, err)
+
+	// 統合に失敗した場合
+	repo := new(MockRepository)
+	usecase = New(repo, newOsWrapper(""))
+	repo.On("ListKeywordGroups").Return([]domain.KeywordGroup{{ID: 1, Name: "Go"}, {ID: 2, Name: "go"}}, nil)
+	repo.On("MergeKeywordGroups", mock.Anything, mock.Anything).Return(errors.New("db error"))
+
+	_, err = usecase.NormalizeKeywords(false)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "キーワード正規化エラー")
+}
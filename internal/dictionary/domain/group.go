@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Kind は用語辞書の種類です
+type Kind string
+
+// 用語辞書の種類
+const (
+	KindKeyword  Kind = "keyword"   // 技術キーワード（keyword_groups / key_words）
+	KindPosition Kind = "position"  // ポジション（position_groups / position_words）
+	KindWorkType Kind = "work_type" // 業務種別（work_type_groups / work_type_words）
+)
+
+// Kinds は用語辞書の種類の一覧です
+var Kinds = []Kind{KindKeyword, KindPosition, KindWorkType}
+
+// IsValid は定義済みの種類かどうかを返します
+func (k Kind) IsValid() bool {
+	for _, kind := range Kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	// ErrInvalidOperation は用語辞書の操作内容が不正な場合のエラーです
+	ErrInvalidOperation = errors.New("用語辞書の操作内容が不正です")
+
+	// ErrGroupNotFound は指定したグループが存在しない場合のエラーです
+	ErrGroupNotFound = errors.New("グループが見つかりません")
+
+	// ErrAliasNotFound は指定した別名がグループに登録されていない場合のエラーです
+	ErrAliasNotFound = errors.New("別名が見つかりません")
+
+	// ErrConflict は同じ名前のグループや別名が既に存在する場合のエラーです
+	ErrConflict = errors.New("同じ名前が既に登録されています")
+)
+
+// Group は用語辞書のグループ（正規名と別名の集まり）です
+type Group struct {
+	ID      uint     `json:"id"`             // グループID
+	Kind    Kind     `json:"kind"`           // 種類
+	Name    string   `json:"name"`           // 正規名
+	Type    string   `json:"type,omitempty"` // キーワードの種類（language / framework / must / want / other）
+	Aliases []string `json:"aliases"`        // 別名（表記ゆれ）
+	Usage   int64    `json:"usage"`          // 紐付いている案件数
+}
+
+// HasAlias は別名が登録されているかを返します（大文字・小文字は区別しません）
+func (g Group) HasAlias(word string) bool {
+	for _, alias := range g.Aliases {
+		if strings.EqualFold(alias, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// Action は用語辞書の操作の種類です
+type Action string
+
+// 用語辞書の操作
+const (
+	ActionMerge       Action = "merge"        // グループの統合
+	ActionSplit       Action = "split"        // グループの分割
+	ActionAddAlias    Action = "add_alias"    // 別名の追加
+	ActionRemoveAlias Action = "remove_alias" // 別名の削除
+	ActionRename      Action = "rename"       // 正規名の変更
+	ActionNormalize   Action = "normalize"    // 表記ゆれの自動統合（normalize-keywords）
+)
+
+// AuditLog は用語辞書の操作履歴です
+type AuditLog struct {
+	ID            uint      `json:"id"`
+	Kind          Kind      `json:"kind"`                      // 種類
+	Action        Action    `json:"action"`                    // 操作
+	GroupID       uint      `json:"group_id"`                  // 操作したグループ
+	TargetGroupID *uint     `json:"target_group_id,omitempty"` // 統合先・分割先のグループ
+	Detail        string    `json:"detail"`                    // 操作内容
+	Actor         string    `json:"actor"`                     // 操作者（cli / api など）
+	CreatedAt     time.Time `json:"created_at"`                // 操作日時
+}
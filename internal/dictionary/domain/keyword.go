@@ -0,0 +1,23 @@
+// Package domain は用語辞書（キーワードの表記ゆれ管理）機能のドメイン層を提供します。
+// このファイルはキーワードグループと統合内容のドメインモデルを定義します。
+package domain
+
+// KeywordGroup は正規化された技術キーワードのグループです
+type KeywordGroup struct {
+	ID   uint   `json:"id"`   // キーワードグループID
+	Name string `json:"name"` // グループ名
+	Type string `json:"type"` // 種類（language / framework / must / want / other）
+}
+
+// Merge は同じキーワードに正規化されるキーワードグループの統合内容です
+// Survivor を残し、Merged の案件・表記の紐付けを Survivor に付け替えてから Merged を削除します。
+type Merge struct {
+	Name     string         `json:"name"`     // 統合後のグループ名（正規名）
+	Survivor KeywordGroup   `json:"survivor"` // 残すグループ
+	Merged   []KeywordGroup `json:"merged"`   // 統合して削除するグループ
+}
+
+// Renamed は統合後にグループ名が変わるかを返します
+func (m Merge) Renamed() bool {
+	return m.Survivor.Name != m.Name
+}
@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// ProposalStatus は統合提案の状態です
+type ProposalStatus string
+
+// 統合提案の状態
+const (
+	ProposalPending  ProposalStatus = "pending"  // レビュー待ち
+	ProposalAccepted ProposalStatus = "accepted" // 承認済み（統合を実行）
+	ProposalRejected ProposalStatus = "rejected" // 却下
+)
+
+// IsValid は定義済みの状態かどうかを返します
+func (s ProposalStatus) IsValid() bool {
+	return s == ProposalPending || s == ProposalAccepted || s == ProposalRejected
+}
+
+// ProposalMethod は統合提案を作成した方法です
+type ProposalMethod string
+
+// 統合提案の作成方法
+const (
+	MethodSimilarity ProposalMethod = "similarity" // 文字列の類似度（編集距離・かな/ローマ字の畳み込み）
+	MethodLLM        ProposalMethod = "llm"        // LLMによる判定
+)
+
+var (
+	// ErrProposalNotFound は指定した統合提案が存在しない場合のエラーです
+	ErrProposalNotFound = errors.New("統合提案が見つかりません")
+
+	// ErrProposalReviewed は統合提案が既にレビュー済みの場合のエラーです
+	ErrProposalReviewed = errors.New("統合提案は既にレビュー済みです")
+)
+
+// Proposal はキーワードグループの統合提案です
+// 新しく作成されたグループ（Source）を既存のグループ（Target）に統合する提案で、レビューで承認すると統合します。
+type Proposal struct {
+	ID            uint           `json:"id"`
+	SourceGroupID uint           `json:"source_group_id"`       // 統合元（新しいグループ）
+	SourceName    string         `json:"source_name"`           // 統合元の正規名
+	TargetGroupID uint           `json:"target_group_id"`       // 統合先（既存のグループ）
+	TargetName    string         `json:"target_name"`           // 統合先の正規名
+	Score         float64        `json:"score"`                 // 類似度（0〜1）
+	Method        ProposalMethod `json:"method"`                // 作成方法
+	Reason        string         `json:"reason"`                // 提案理由
+	Status        ProposalStatus `json:"status"`                // 状態
+	ReviewedBy    string         `json:"reviewed_by,omitempty"` // レビューした操作者
+	ReviewedAt    *time.Time     `json:"reviewed_at,omitempty"` // レビュー日時
+	CreatedAt     time.Time      `json:"created_at"`            // 作成日時
+}
+
+// ClusteringRun はキーワードのクラスタリング（統合提案の作成）の実行結果です
+type ClusteringRun struct {
+	ID          uint       `json:"id"`
+	LastGroupID uint       `json:"last_group_id"` // 確認済みのキーワードグループIDの最大値（次回はこれより後のグループを確認）
+	Checked     int        `json:"checked"`       // 確認したグループ数
+	Proposed    int        `json:"proposed"`      // 作成した統合提案の数
+	UsedLLM     bool       `json:"used_llm"`      // LLMを使ったか
+	StartedAt   time.Time  `json:"started_at"`
+	FinishedAt  time.Time  `json:"finished_at"`
+	Proposals   []Proposal `json:"proposals"`
+}
@@ -0,0 +1,74 @@
+package infrastructure
+
+import (
+	oa "business/tools/openai"
+	"business/tools/oswrapper"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// DefaultClusteringPromptPath はキーワードの統合先を判定するプロンプトの既定の配置場所です
+const DefaultClusteringPromptPath = "/data/prompts/keyword_clustering_prompt.txt"
+
+// Advisor はOpenAI APIでキーワードの統合先を判定します
+type Advisor struct {
+	oa oa.CompleterInterface
+	os oswrapper.OsWapperInterface
+}
+
+// NewAdvisor はキーワードの統合先を判定する Advisor を作成します
+func NewAdvisor(oa oa.CompleterInterface, os oswrapper.OsWapperInterface) *Advisor {
+	return &Advisor{
+		oa: oa,
+		os: os,
+	}
+}
+
+// suggestion はモデルの応答です
+type suggestion struct {
+	Group  string `json:"group"`
+	Reason string `json:"reason"`
+}
+
+// SuggestGroup は候補のグループ名から word と同じ技術を指すものを返します
+// 該当するものがない場合や、候補にない名前が返された場合は空文字を返します。
+func (a *Advisor) SuggestGroup(ctx context.Context, word string, candidates []string) (string, string, error) {
+	prompt, err := a.os.ReadFile(DefaultClusteringPromptPath)
+	if err != nil {
+		return "", "", fmt.Errorf("プロンプト読み込みエラー: %w", err)
+	}
+
+	var b strings.Builder
+	b.WriteString(prompt)
+	fmt.Fprintf(&b, "\n\n新しいキーワード: %s\n候補:\n", word)
+	for _, c := range candidates {
+		fmt.Fprintf(&b, "- %s\n", c)
+	}
+
+	content, err := a.oa.Complete(ctx, b.String())
+	if err != nil {
+		return "", "", fmt.Errorf("統合先判定エラー: %w", err)
+	}
+	return parseSuggestion(content, candidates)
+}
+
+// parseSuggestion はモデルの応答を候補のグループ名と理由に変換します
+// コードブロックで囲まれた応答も受け付けます。
+func parseSuggestion(content string, candidates []string) (string, string, error) {
+	content = strings.TrimSpace(content)
+	content = strings.TrimPrefix(content, "```json")
+	content = strings.Trim(content, "` \n")
+
+	var s suggestion
+	if err := json.Unmarshal([]byte(content), &s); err != nil {
+		return "", "", fmt.Errorf("統合先判定の応答を読み込めません: %w", err)
+	}
+	for _, c := range candidates {
+		if strings.EqualFold(c, strings.TrimSpace(s.Group)) {
+			return c, s.Reason, nil
+		}
+	}
+	return "", s.Reason, nil
+}
@@ -0,0 +1,67 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockCompleter はOpenAI APIのモックです
+type mockCompleter struct {
+	prompt  string
+	content string
+	err     error
+}
+
+func (m *mockCompleter) Complete(ctx context.Context, prompt string) (string, error) {
+	m.prompt = prompt
+	return m.content, m.err
+}
+
+// mockOsWrapper は oswrapper のモックです
+type mockOsWrapper struct {
+	files map[string]string
+}
+
+func (m *mockOsWrapper) ReadFile(path string) (string, error) {
+	if text, ok := m.files[path]; ok {
+		return text, nil
+	}
+	return "", errors.New("file not found")
+}
+
+func (m *mockOsWrapper) GetEnv(key string) string {
+	return ""
+}
+
+func TestAdvisor_SuggestGroup(t *testing.T) {
+	osw := &mockOsWrapper{files: map[string]string{DefaultClusteringPromptPath: "判定してください"}}
+	completer := &mockCompleter{content: "```json\n{\"group\": \"go\", \"reason\": \"Go言語のカタカナ表記\"}\n```"}
+	advisor := NewAdvisor(completer, osw)
+
+	name, reason, err := advisor.SuggestGroup(context.Background(), "ゴー言語", []string{"Go", "Rust"})
+	require.NoError(t, err)
+	// 候補の表記で返す
+	assert.Equal(t, "Go", name)
+	assert.Equal(t, "Go言語のカタカナ表記", reason)
+	assert.Contains(t, completer.prompt, "新しいキーワード: ゴー言語")
+	assert.Contains(t, completer.prompt, "- Rust")
+
+	// 候補にない名前は該当なしとして扱う
+	completer.content = `{"group": "Golang", "reason": "同じ"}`
+	name, _, err = advisor.SuggestGroup(context.Background(), "ゴー言語", []string{"Go"})
+	require.NoError(t, err)
+	assert.Empty(t, name)
+
+	// JSONでない応答
+	completer.content = "Goです"
+	_, _, err = advisor.SuggestGroup(context.Background(), "ゴー言語", []string{"Go"})
+	assert.Error(t, err)
+
+	// プロンプトがない場合
+	_, _, err = NewAdvisor(completer, &mockOsWrapper{}).SuggestGroup(context.Background(), "ゴー言語", []string{"Go"})
+	assert.Error(t, err)
+}
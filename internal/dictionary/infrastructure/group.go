@@ -0,0 +1,677 @@
+package infrastructure
+
+import (
+	"business/internal/dictionary/domain"
+	"business/tools/keyword"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// groupTable は種類ごとのグループ関連テーブルです
+type groupTable struct {
+	group string      // グループのテーブル
+	id    string      // グループIDの列
+	links []linkTable // 案件・人材との中間テーブル
+	words string      // 別名のテーブル（キーワードは keyword_group_word_links 経由）
+}
+
+// linkTable は案件・人材とグループの中間テーブルです
+type linkTable struct {
+	link      string   // 中間テーブル
+	owner     string   // 案件・人材のテーブル
+	ownerID   string   // 中間テーブルの案件・人材のIDの列
+	columns   []string // 案件・人材の表示用の列（分割時の付け替えに使う）
+	createdAt bool     // 中間テーブルに created_at の列がある
+}
+
+// groupTables は種類ごとのグループ関連テーブルです
+// キーワードは案件（email_keyword_groups）と人材（email_candidate_keyword_groups）の両方に紐付きます。
+var groupTables = map[domain.Kind]groupTable{
+	domain.KindKeyword: {
+		group: "keyword_groups", id: "keyword_group_id", words: "key_words",
+		links: []linkTable{
+			{
+				link: "email_keyword_groups", owner: "email_projects", ownerID: "email_project_id",
+				columns: []string{"languages", "frameworks", "must_skills", "want_skills"}, createdAt: true,
+			},
+			{
+				link: "email_candidate_keyword_groups", owner: "email_candidates", ownerID: "email_candidate_id",
+				columns: []string{"skills"}, createdAt: true,
+			},
+		},
+	},
+	domain.KindPosition: {
+		group: "position_groups", id: "position_group_id", words: "position_words",
+		links: []linkTable{{link: "email_position_groups", owner: "email_projects", ownerID: "email_project_id", columns: []string{"positions"}}},
+	},
+	domain.KindWorkType: {
+		group: "work_type_groups", id: "work_type_group_id", words: "work_type_words",
+		links: []linkTable{{link: "email_work_type_groups", owner: "email_projects", ownerID: "email_project_id", columns: []string{"work_types"}}},
+	},
+}
+
+// groupRow はグループの取得結果です
+type groupRow struct {
+	ID   uint
+	Name string
+	Type string
+}
+
+// ListGroups は種類ごとのグループを別名・案件数付きで、案件数の多い順に返します
+// q を指定した場合は、正規名または別名に q を含むグループのみ返します。
+func (r *Repository) ListGroups(kind domain.Kind, q string) ([]domain.Group, error) {
+	return loadGroups(r.db, kind, q, nil)
+}
+
+// GetGroup はグループを別名・案件数付きで返します
+func (r *Repository) GetGroup(kind domain.Kind, id uint) (domain.Group, error) {
+	return getGroup(r.db, kind, id)
+}
+
+// MergeGroups は統合元のグループを統合先のグループに統合します
+// 案件の紐付けと別名を統合先に付け替え、統合元の正規名は統合先の別名として残します。
+func (r *Repository) MergeGroups(kind domain.Kind, sourceID, targetID uint, log domain.AuditLog) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return mergeGroups(tx, kind, sourceID, targetID, log)
+	})
+}
+
+// mergeGroups はトランザクション内でグループを統合し、操作履歴を保存します
+func mergeGroups(tx *gorm.DB, kind domain.Kind, sourceID, targetID uint, log domain.AuditLog) error {
+	source, err := getGroup(tx, kind, sourceID)
+	if err != nil {
+		return err
+	}
+	target, err := getGroup(tx, kind, targetID)
+	if err != nil {
+		return err
+	}
+
+	if kind == domain.KindKeyword {
+		merge := domain.Merge{
+			Name:     target.Name,
+			Survivor: domain.KeywordGroup{ID: target.ID, Name: target.Name, Type: target.Type},
+			Merged:   []domain.KeywordGroup{{ID: source.ID, Name: source.Name, Type: source.Type}},
+		}
+		if err := mergeKeywordGroup(tx, merge); err != nil {
+			return err
+		}
+	} else if err := mergeWordGroup(tx, groupTables[kind], sourceID, targetID); err != nil {
+		return err
+	}
+
+	if err := ensureAlias(tx, kind, targetID, source.Name); err != nil {
+		return err
+	}
+	return createAuditLog(tx, log)
+}
+
+// mergeWordGroup はポジション・業務種別のグループを統合します
+func mergeWordGroup(tx *gorm.DB, t groupTable, sourceID, targetID uint) error {
+	if err := moveLinks(tx, t, []uint{sourceID}, targetID); err != nil {
+		return err
+	}
+
+	// 統合先に同じ表記がある別名は削除してから付け替える
+	err := tx.Exec(fmt.Sprintf(`DELETE s FROM %[1]s s JOIN %[1]s d ON d.word = s.word AND d.%[2]s = ?
+		WHERE s.%[2]s = ?`, t.words, t.id), targetID, sourceID).Error
+	if err != nil {
+		return fmt.Errorf("%s削除エラー: %w", t.words, err)
+	}
+	err = tx.Exec(fmt.Sprintf("UPDATE %s SET %s = ?, updated_at = ? WHERE %s = ?", t.words, t.id, t.id),
+		targetID, time.Now(), sourceID).Error
+	if err != nil {
+		return fmt.Errorf("%s付け替えエラー: %w", t.words, err)
+	}
+
+	if err := tx.Exec(fmt.Sprintf("DELETE FROM %s WHERE %s = ?", t.group, t.id), sourceID).Error; err != nil {
+		return fmt.Errorf("%s削除エラー: %w", t.group, err)
+	}
+	return nil
+}
+
+// moveLinks は統合元のグループの案件・人材の紐付けを統合先に付け替えます
+// 統合先に既に紐付いている案件・人材は重複させません。
+func moveLinks(tx *gorm.DB, t groupTable, sourceIDs []uint, targetID uint) error {
+	for _, l := range t.links {
+		columns, values := l.ownerID+", "+t.id, l.ownerID+", ?"
+		if l.createdAt {
+			columns, values = columns+", created_at", values+", created_at"
+		}
+		err := tx.Exec(fmt.Sprintf("INSERT IGNORE INTO %[1]s (%[2]s) SELECT %[3]s FROM %[1]s WHERE %[4]s IN ?",
+			l.link, columns, values, t.id), targetID, sourceIDs).Error
+		if err != nil {
+			return fmt.Errorf("%s付け替えエラー: %w", l.link, err)
+		}
+		if err := tx.Exec(fmt.Sprintf("DELETE FROM %s WHERE %s IN ?", l.link, t.id), sourceIDs).Error; err != nil {
+			return fmt.Errorf("%s削除エラー: %w", l.link, err)
+		}
+	}
+	return nil
+}
+
+// SplitGroup は指定した別名をグループから切り出し、name を正規名とする新しいグループを作成します
+// 切り出した別名を含み、元のグループに残る別名を含まない案件・人材は、新しいグループに付け替えます。
+// 両方を含む案件・人材は両方のグループに紐付けます。作成したグループのIDを返します。
+func (r *Repository) SplitGroup(kind domain.Kind, id uint, name string, aliases []string, log domain.AuditLog) (uint, error) {
+	var newID uint
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		t := groupTables[kind]
+		source, err := getGroup(tx, kind, id)
+		if err != nil {
+			return err
+		}
+		if err := checkNameConflict(tx, kind, name, 0); err != nil {
+			return err
+		}
+
+		newID, err = createGroup(tx, kind, name, source.Type)
+		if err != nil {
+			return err
+		}
+		if err := moveAliases(tx, kind, id, newID, aliases); err != nil {
+			return err
+		}
+		if err := ensureAlias(tx, kind, newID, name); err != nil {
+			return err
+		}
+
+		// 案件・人材の付け替え
+		moved := keySet(append([]string{name}, aliases...))
+		var remaining []string
+		remaining = append(remaining, source.Name)
+		for _, alias := range source.Aliases {
+			if _, ok := moved[keyword.Key(alias)]; !ok {
+				remaining = append(remaining, alias)
+			}
+		}
+		remainingKeys := keySet(remaining)
+
+		for _, l := range t.links {
+			owners, err := linkedOwners(tx, t, l, id)
+			if err != nil {
+				return err
+			}
+			for _, o := range owners {
+				hasMoved, hasRemaining := false, false
+				for _, value := range o.values {
+					k := keyword.Key(value)
+					if _, ok := moved[k]; ok {
+						hasMoved = true
+					}
+					if _, ok := remainingKeys[k]; ok {
+						hasRemaining = true
+					}
+				}
+				switch {
+				case hasMoved && !hasRemaining:
+					err = tx.Exec(fmt.Sprintf("UPDATE %s SET %s = ? WHERE %s = ? AND %s = ?", l.link, t.id, l.ownerID, t.id),
+						newID, o.id, id).Error
+				case hasMoved:
+					err = createLink(tx, t, l, o.id, newID)
+				}
+				if err != nil {
+					return fmt.Errorf("%s付け替えエラー: %w", l.link, err)
+				}
+			}
+		}
+
+		log.TargetGroupID = &newID
+		return createAuditLog(tx, log)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return newID, nil
+}
+
+// AddAlias はグループに別名を追加します
+// ポジション・業務種別では、他のグループの別名として登録済みの表記は追加できません。
+func (r *Repository) AddAlias(kind domain.Kind, id uint, word string, log domain.AuditLog) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		group, err := getGroup(tx, kind, id)
+		if err != nil {
+			return err
+		}
+		if group.HasAlias(word) {
+			return fmt.Errorf("%w: %q は既に %q の別名です", domain.ErrConflict, word, group.Name)
+		}
+
+		if kind != domain.KindKeyword {
+			t := groupTables[kind]
+			var owner groupRow
+			err := tx.Table(t.words+" AS w").
+				Select(fmt.Sprintf("g.%s AS id, g.name", t.id)).
+				Joins(fmt.Sprintf("JOIN %s g ON g.%s = w.%s", t.group, t.id, t.id)).
+				Where("w.word = ?", word).
+				Limit(1).Scan(&owner).Error
+			if err != nil {
+				return fmt.Errorf("%s検索エラー: %w", t.words, err)
+			}
+			if owner.ID != 0 {
+				return fmt.Errorf("%w: %q は既に %q の別名です", domain.ErrConflict, word, owner.Name)
+			}
+		}
+
+		if err := ensureAlias(tx, kind, id, word); err != nil {
+			return err
+		}
+		return createAuditLog(tx, log)
+	})
+}
+
+// RemoveAlias はグループから別名を削除します
+// キーワードの表記は、どのグループにも紐付かなくなった場合に削除します。
+func (r *Repository) RemoveAlias(kind domain.Kind, id uint, word string, log domain.AuditLog) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if _, err := getGroup(tx, kind, id); err != nil {
+			return err
+		}
+
+		t := groupTables[kind]
+		var result *gorm.DB
+		if kind == domain.KindKeyword {
+			result = tx.Exec(`DELETE l FROM keyword_group_word_links l JOIN key_words w ON w.id = l.key_word_id
+				WHERE l.keyword_group_id = ? AND w.word = ?`, id, word)
+		} else {
+			result = tx.Exec(fmt.Sprintf("DELETE FROM %s WHERE %s = ? AND word = ?", t.words, t.id), id, word)
+		}
+		if result.Error != nil {
+			return fmt.Errorf("%s削除エラー: %w", t.words, result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("%w: %q", domain.ErrAliasNotFound, word)
+		}
+
+		if kind == domain.KindKeyword {
+			err := tx.Exec(`DELETE FROM key_words WHERE word = ?
+				AND NOT EXISTS (SELECT 1 FROM keyword_group_word_links l WHERE l.key_word_id = key_words.id)`, word).Error
+			if err != nil {
+				return fmt.Errorf("key_words削除エラー: %w", err)
+			}
+		}
+		return createAuditLog(tx, log)
+	})
+}
+
+// RenameGroup はグループの正規名を変更します
+// 変更前の正規名は別名として残します。同じ種類に同じ名前のグループがある場合は変更できません。
+func (r *Repository) RenameGroup(kind domain.Kind, id uint, name string, log domain.AuditLog) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		group, err := getGroup(tx, kind, id)
+		if err != nil {
+			return err
+		}
+		if err := checkNameConflict(tx, kind, name, id); err != nil {
+			return err
+		}
+
+		t := groupTables[kind]
+		err = tx.Exec(fmt.Sprintf("UPDATE %s SET name = ?, updated_at = ? WHERE %s = ?", t.group, t.id),
+			name, time.Now(), id).Error
+		if err != nil {
+			return fmt.Errorf("%s名変更エラー: %w", t.group, err)
+		}
+		if err := ensureAlias(tx, kind, id, group.Name); err != nil {
+			return err
+		}
+		if err := ensureAlias(tx, kind, id, name); err != nil {
+			return err
+		}
+		return createAuditLog(tx, log)
+	})
+}
+
+// ListAuditLogs は用語辞書の操作履歴を新しい順に返します
+// kind が空の場合はすべての種類を返します。limit が0の場合は件数を制限しません。
+func (r *Repository) ListAuditLogs(kind domain.Kind, limit int) ([]domain.AuditLog, error) {
+	query := r.db.Order("created_at DESC, id DESC")
+	if kind != "" {
+		query = query.Where("kind = ?", string(kind))
+	}
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	var rows []DictionaryAuditLog
+	if err := query.Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("用語辞書操作履歴取得エラー: %w", err)
+	}
+
+	logs := make([]domain.AuditLog, 0, len(rows))
+	for _, row := range rows {
+		logs = append(logs, domain.AuditLog{
+			ID:            row.ID,
+			Kind:          domain.Kind(row.Kind),
+			Action:        domain.Action(row.Action),
+			GroupID:       row.GroupID,
+			TargetGroupID: row.TargetGroupID,
+			Detail:        row.Detail,
+			Actor:         row.Actor,
+			CreatedAt:     row.CreatedAt,
+		})
+	}
+	return logs, nil
+}
+
+// createAuditLog は用語辞書の操作履歴を保存します
+func createAuditLog(tx *gorm.DB, log domain.AuditLog) error {
+	createdAt := log.CreatedAt
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+	row := DictionaryAuditLog{
+		Kind:          string(log.Kind),
+		Action:        string(log.Action),
+		GroupID:       log.GroupID,
+		TargetGroupID: log.TargetGroupID,
+		Detail:        log.Detail,
+		Actor:         log.Actor,
+		CreatedAt:     createdAt,
+	}
+	if err := tx.Create(&row).Error; err != nil {
+		return fmt.Errorf("用語辞書操作履歴保存エラー: %w", err)
+	}
+	return nil
+}
+
+// getGroup はグループを別名・案件数付きで返します
+func getGroup(db *gorm.DB, kind domain.Kind, id uint) (domain.Group, error) {
+	groups, err := loadGroups(db, kind, "", []uint{id})
+	if err != nil {
+		return domain.Group{}, err
+	}
+	if len(groups) == 0 {
+		return domain.Group{}, fmt.Errorf("%w: %s #%d", domain.ErrGroupNotFound, kind, id)
+	}
+	return groups[0], nil
+}
+
+// loadGroups はグループを別名・案件数付きで、案件数の多い順に返します
+func loadGroups(db *gorm.DB, kind domain.Kind, q string, ids []uint) ([]domain.Group, error) {
+	t, ok := groupTables[kind]
+	if !ok {
+		return nil, fmt.Errorf("%w: 種類 %q", domain.ErrInvalidOperation, kind)
+	}
+
+	columns := fmt.Sprintf("g.%s AS id, g.name", t.id)
+	if kind == domain.KindKeyword {
+		columns += ", g.type"
+	}
+	query := db.Table(t.group + " AS g").Select(columns)
+	if ids != nil {
+		query = query.Where(fmt.Sprintf("g.%s IN ?", t.id), ids)
+	}
+	if q != "" {
+		like := "%" + q + "%"
+		query = query.Where(fmt.Sprintf("g.name LIKE ? OR g.%s IN (?)", t.id), like, aliasGroupIDs(db, kind, like))
+	}
+
+	var rows []groupRow
+	if err := query.Order(fmt.Sprintf("g.%s", t.id)).Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("%s取得エラー: %w", t.group, err)
+	}
+	if len(rows) == 0 {
+		return []domain.Group{}, nil
+	}
+
+	groupIDs := make([]uint, 0, len(rows))
+	for _, row := range rows {
+		groupIDs = append(groupIDs, row.ID)
+	}
+	aliases, err := loadAliases(db, kind, groupIDs)
+	if err != nil {
+		return nil, err
+	}
+	usage, err := loadUsage(db, t, groupIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make([]domain.Group, 0, len(rows))
+	for _, row := range rows {
+		groups = append(groups, domain.Group{
+			ID:      row.ID,
+			Kind:    kind,
+			Name:    row.Name,
+			Type:    row.Type,
+			Aliases: append([]string{}, aliases[row.ID]...),
+			Usage:   usage[row.ID],
+		})
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		return groups[i].Usage > groups[j].Usage
+	})
+	return groups, nil
+}
+
+// aliasGroupIDs は別名が条件に一致するグループIDのサブクエリを返します
+func aliasGroupIDs(db *gorm.DB, kind domain.Kind, like string) *gorm.DB {
+	if kind == domain.KindKeyword {
+		return db.Table("keyword_group_word_links AS l").Select("l.keyword_group_id").
+			Joins("JOIN key_words w ON w.id = l.key_word_id").
+			Where("w.word LIKE ?", like)
+	}
+	t := groupTables[kind]
+	return db.Table(t.words).Select(t.id).Where("word LIKE ?", like)
+}
+
+// aliasRow は別名の取得結果です
+type aliasRow struct {
+	GroupID uint
+	Word    string
+}
+
+// loadAliases はグループIDごとの別名を登録順に返します
+func loadAliases(db *gorm.DB, kind domain.Kind, groupIDs []uint) (map[uint][]string, error) {
+	var rows []aliasRow
+	var err error
+	if kind == domain.KindKeyword {
+		err = db.Table("keyword_group_word_links AS l").
+			Select("l.keyword_group_id AS group_id, w.word").
+			Joins("JOIN key_words w ON w.id = l.key_word_id").
+			Where("l.keyword_group_id IN ?", groupIDs).
+			Order("w.id").Scan(&rows).Error
+	} else {
+		t := groupTables[kind]
+		err = db.Table(t.words).
+			Select(fmt.Sprintf("%s AS group_id, word", t.id)).
+			Where(fmt.Sprintf("%s IN ?", t.id), groupIDs).
+			Order("id").Scan(&rows).Error
+	}
+	if err != nil {
+		return nil, fmt.Errorf("別名取得エラー: %w", err)
+	}
+
+	aliases := make(map[uint][]string, len(groupIDs))
+	for _, row := range rows {
+		aliases[row.GroupID] = append(aliases[row.GroupID], row.Word)
+	}
+	return aliases, nil
+}
+
+// usageRow は案件数の取得結果です
+type usageRow struct {
+	GroupID    uint
+	UsageCount int64
+}
+
+// loadUsage はグループIDごとの紐付いている案件数を返します（キーワードは人材の数も含みます）
+func loadUsage(db *gorm.DB, t groupTable, groupIDs []uint) (map[uint]int64, error) {
+	usage := make(map[uint]int64, len(groupIDs))
+	for _, l := range t.links {
+		var rows []usageRow
+		err := db.Table(l.link).
+			Select(fmt.Sprintf("%s AS group_id, COUNT(DISTINCT %s) AS usage_count", t.id, l.ownerID)).
+			Where(fmt.Sprintf("%s IN ?", t.id), groupIDs).
+			Group(t.id).Scan(&rows).Error
+		if err != nil {
+			return nil, fmt.Errorf("案件数取得エラー: %w", err)
+		}
+		for _, row := range rows {
+			usage[row.GroupID] += row.UsageCount
+		}
+	}
+	return usage, nil
+}
+
+// checkNameConflict は同じ種類に同じ名前のグループがあればエラーを返します
+// exceptID のグループは除きます。
+func checkNameConflict(tx *gorm.DB, kind domain.Kind, name string, exceptID uint) error {
+	t := groupTables[kind]
+	var existing groupRow
+	err := tx.Table(t.group).
+		Select(fmt.Sprintf("%s AS id, name", t.id)).
+		Where(fmt.Sprintf("name = ? AND %s <> ?", t.id), name, exceptID).
+		Limit(1).Scan(&existing).Error
+	if err != nil {
+		return fmt.Errorf("%s検索エラー: %w", t.group, err)
+	}
+	if existing.ID != 0 {
+		return fmt.Errorf("%w: %q（#%d）。統合する場合は merge を使ってください", domain.ErrConflict, existing.Name, existing.ID)
+	}
+	return nil
+}
+
+// createGroup はグループを作成し、IDを返します
+func createGroup(tx *gorm.DB, kind domain.Kind, name, keywordType string) (uint, error) {
+	switch kind {
+	case domain.KindKeyword:
+		g := KeywordGroup{Name: name, Type: keywordType}
+		if err := tx.Create(&g).Error; err != nil {
+			return 0, fmt.Errorf("KeywordGroup作成エラー: %w", err)
+		}
+		return g.KeywordGroupID, nil
+	case domain.KindPosition:
+		g := PositionGroup{Name: name}
+		if err := tx.Create(&g).Error; err != nil {
+			return 0, fmt.Errorf("PositionGroup作成エラー: %w", err)
+		}
+		return g.PositionGroupID, nil
+	default:
+		g := WorkTypeGroup{Name: name}
+		if err := tx.Create(&g).Error; err != nil {
+			return 0, fmt.Errorf("WorkTypeGroup作成エラー: %w", err)
+		}
+		return g.WorkTypeGroupID, nil
+	}
+}
+
+// moveAliases は別名を元のグループから新しいグループへ移します
+func moveAliases(tx *gorm.DB, kind domain.Kind, fromID, toID uint, aliases []string) error {
+	if len(aliases) == 0 {
+		return nil
+	}
+
+	t := groupTables[kind]
+	var err error
+	if kind == domain.KindKeyword {
+		err = tx.Exec(`UPDATE keyword_group_word_links l JOIN key_words w ON w.id = l.key_word_id
+			SET l.keyword_group_id = ? WHERE l.keyword_group_id = ? AND w.word IN ?`, toID, fromID, aliases).Error
+	} else {
+		err = tx.Exec(fmt.Sprintf("UPDATE %s SET %s = ?, updated_at = ? WHERE %s = ? AND word IN ?", t.words, t.id, t.id),
+			toID, time.Now(), fromID, aliases).Error
+	}
+	if err != nil {
+		return fmt.Errorf("%s付け替えエラー: %w", t.words, err)
+	}
+	return nil
+}
+
+// ensureAlias はグループに別名がなければ追加します
+func ensureAlias(tx *gorm.DB, kind domain.Kind, id uint, word string) error {
+	word = strings.TrimSpace(word)
+	if word == "" {
+		return nil
+	}
+
+	if kind == domain.KindKeyword {
+		now := time.Now()
+		err := tx.Exec("INSERT IGNORE INTO key_words (word, created_at, updated_at) VALUES (?, ?, ?)", word, now, now).Error
+		if err != nil {
+			return fmt.Errorf("KeyWord作成エラー: %w", err)
+		}
+		err = tx.Exec(`INSERT IGNORE INTO keyword_group_word_links (keyword_group_id, key_word_id, created_at)
+			SELECT ?, id, ? FROM key_words WHERE word = ?`, id, now, word).Error
+		if err != nil {
+			return fmt.Errorf("KeywordGroupWordLink作成エラー: %w", err)
+		}
+		return nil
+	}
+
+	t := groupTables[kind]
+	var count int64
+	err := tx.Table(t.words).Where(fmt.Sprintf("%s = ? AND word = ?", t.id), id, word).Count(&count).Error
+	if err != nil {
+		return fmt.Errorf("%s検索エラー: %w", t.words, err)
+	}
+	if count > 0 {
+		return nil
+	}
+	now := time.Now()
+	err = tx.Exec(fmt.Sprintf("INSERT INTO %s (%s, word, created_at, updated_at) VALUES (?, ?, ?, ?)", t.words, t.id),
+		id, word, now, now).Error
+	if err != nil {
+		return fmt.Errorf("%s作成エラー: %w", t.words, err)
+	}
+	return nil
+}
+
+// createLink は案件・人材とグループを紐付けます
+func createLink(tx *gorm.DB, t groupTable, l linkTable, ownerID, groupID uint) error {
+	if l.createdAt {
+		return tx.Exec(fmt.Sprintf("INSERT IGNORE INTO %s (%s, %s, created_at) VALUES (?, ?, ?)", l.link, l.ownerID, t.id),
+			ownerID, groupID, time.Now()).Error
+	}
+	return tx.Exec(fmt.Sprintf("INSERT IGNORE INTO %s (%s, %s) VALUES (?, ?)", l.link, l.ownerID, t.id), ownerID, groupID).Error
+}
+
+// linkedOwner はグループに紐付いている案件・人材と、表示用の列の値です
+type linkedOwner struct {
+	id     uint
+	values []string
+}
+
+// linkedOwners はグループに紐付いている案件・人材を返します
+func linkedOwners(tx *gorm.DB, t groupTable, l linkTable, id uint) ([]linkedOwner, error) {
+	columns := make([]string, 0, len(l.columns))
+	for _, c := range l.columns {
+		columns = append(columns, fmt.Sprintf("COALESCE(o.%s, '')", c))
+	}
+	rows, err := tx.Table(l.owner+" AS o").
+		Select(fmt.Sprintf("DISTINCT o.id, CONCAT_WS(',', %s) AS value_list", strings.Join(columns, ", "))).
+		Joins(fmt.Sprintf("JOIN %s l ON l.%s = o.id", l.link, l.ownerID)).
+		Where(fmt.Sprintf("l.%s = ?", t.id), id).
+		Rows()
+	if err != nil {
+		return nil, fmt.Errorf("%s取得エラー: %w", l.owner, err)
+	}
+	defer rows.Close()
+
+	var owners []linkedOwner
+	for rows.Next() {
+		var o linkedOwner
+		var valueList string
+		if err := rows.Scan(&o.id, &valueList); err != nil {
+			return nil, fmt.Errorf("%s取得エラー: %w", l.owner, err)
+		}
+		o.values = strings.Split(valueList, ",")
+		owners = append(owners, o)
+	}
+	return owners, rows.Err()
+}
+
+// keySet は表記の照合キーの集合を返します
+func keySet(words []string) map[string]struct{} {
+	keys := make(map[string]struct{}, len(words))
+	for _, w := range words {
+		if k := keyword.Key(w); k != "" {
+			keys[k] = struct{}{}
+		}
+	}
+	return keys
+}
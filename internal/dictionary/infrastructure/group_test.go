@@ -0,0 +1,175 @@
+package infrastructure
+
+import (
+	"business/internal/dictionary/domain"
+	"business/tools/migrations/model"
+	"business/tools/mysql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_GroupOperations(t *testing.T) {
+	t.Parallel()
+	// テスト用DBの準備
+	db, cleanup, err := mysql.CreateNewTestDB()
+	require.NoError(t, err)
+	defer cleanup()
+
+	err = db.DB.AutoMigrate(
+		model.Email{},
+		model.EmailProject{},
+		model.PositionGroup{},
+		model.PositionWord{},
+		model.EmailPositionGroup{},
+		model.DictionaryAuditLog{},
+	)
+	require.NoError(t, err)
+
+	// 「PM」グループに「PMO」が誤って別名登録され、案件1はPM、案件2はPMOとして紐付いている
+	pm := model.PositionGroup{Name: "PM"}
+	pl := model.PositionGroup{Name: "PL"}
+	require.NoError(t, db.DB.Create(&pm).Error)
+	require.NoError(t, db.DB.Create(&pl).Error)
+	require.NoError(t, db.DB.Create(&[]model.PositionWord{
+		{PositionGroupID: pm.PositionGroupID, Word: "PM"},
+		{PositionGroupID: pm.PositionGroupID, Word: "PMO"},
+		{PositionGroupID: pl.PositionGroupID, Word: "PL"},
+	}).Error)
+	email := model.Email{GmailID: "gmail-1", Subject: "案件のご紹介", ReceivedDate: time.Now()}
+	require.NoError(t, db.DB.Create(&email).Error)
+	positions := []string{"PM", "PMO"}
+	for i := range positions {
+		project := model.EmailProject{EmailID: email.ID, ProjectKey: positions[i], Positions: &positions[i]}
+		require.NoError(t, db.DB.Create(&project).Error)
+		require.NoError(t, db.DB.Create(&model.EmailPositionGroup{EmailProjectID: project.ID, PositionGroupID: pm.PositionGroupID}).Error)
+	}
+
+	repo := New(db.DB)
+	kind := domain.KindPosition
+	log := func(action domain.Action) domain.AuditLog {
+		return domain.AuditLog{Kind: kind, Action: action, Detail: string(action), Actor: "test"}
+	}
+
+	groups, err := repo.ListGroups(kind, "PMO")
+	require.NoError(t, err)
+	require.Len(t, groups, 1)
+	assert.Equal(t, int64(2), groups[0].Usage)
+	assert.Equal(t, []string{"PM", "PMO"}, groups[0].Aliases)
+
+	// 分割: PMOのみの案件は新しいグループに付け替わる
+	pmoID, err := repo.SplitGroup(kind, pm.PositionGroupID, "PMO", []string{"PMO"}, log(domain.ActionSplit))
+	require.NoError(t, err)
+	pmo, err := repo.GetGroup(kind, pmoID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), pmo.Usage)
+	assert.Equal(t, []string{"PMO"}, pmo.Aliases)
+
+	// 別名の追加・削除（他のグループの別名は追加できない）
+	require.NoError(t, repo.AddAlias(kind, pl.PositionGroupID, "リーダー", log(domain.ActionAddAlias)))
+	assert.ErrorIs(t, repo.AddAlias(kind, pl.PositionGroupID, "PMO", log(domain.ActionAddAlias)), domain.ErrConflict)
+	require.NoError(t, repo.RemoveAlias(kind, pl.PositionGroupID, "リーダー", log(domain.ActionRemoveAlias)))
+	assert.ErrorIs(t, repo.RemoveAlias(kind, pl.PositionGroupID, "リーダー", log(domain.ActionRemoveAlias)), domain.ErrAliasNotFound)
+
+	// 正規名の変更（同じ名前のグループがあれば競合）
+	assert.ErrorIs(t, repo.RenameGroup(kind, pl.PositionGroupID, "PM", log(domain.ActionRename)), domain.ErrConflict)
+	require.NoError(t, repo.RenameGroup(kind, pl.PositionGroupID, "プロジェクトリーダー", log(domain.ActionRename)))
+	renamed, err := repo.GetGroup(kind, pl.PositionGroupID)
+	require.NoError(t, err)
+	assert.Equal(t, "プロジェクトリーダー", renamed.Name)
+	assert.Equal(t, []string{"PL", "プロジェクトリーダー"}, renamed.Aliases)
+
+	// 統合: 案件と別名が統合先に付け替わり、統合元は削除される
+	require.NoError(t, repo.MergeGroups(kind, pmoID, pm.PositionGroupID, log(domain.ActionMerge)))
+	merged, err := repo.GetGroup(kind, pm.PositionGroupID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), merged.Usage)
+	assert.ElementsMatch(t, []string{"PM", "PMO"}, merged.Aliases)
+	_, err = repo.GetGroup(kind, pmoID)
+	assert.ErrorIs(t, err, domain.ErrGroupNotFound)
+
+	// 操作はすべて履歴に残る（失敗した操作は残らない）
+	logs, err := repo.ListAuditLogs(kind, 0)
+	require.NoError(t, err)
+	require.Len(t, logs, 5)
+	assert.Equal(t, domain.ActionMerge, logs[0].Action)
+	assert.Equal(t, domain.ActionSplit, logs[4].Action)
+	assert.Equal(t, pmoID, *logs[4].TargetGroupID)
+}
+
+func TestRepository_KeywordGroupCandidates(t *testing.T) {
+	t.Parallel()
+	// テスト用DBの準備
+	db, cleanup, err := mysql.CreateNewTestDB()
+	require.NoError(t, err)
+	defer cleanup()
+
+	err = db.DB.AutoMigrate(
+		model.Email{},
+		model.EmailProject{},
+		model.EmailCandidate{},
+		model.KeywordGroup{},
+		model.KeyWord{},
+		model.KeywordGroupWordLink{},
+		model.EmailKeywordGroup{},
+		model.EmailCandidateKeywordGroup{},
+		model.DictionaryAuditLog{},
+	)
+	require.NoError(t, err)
+
+	// 「JavaScript」グループに「TypeScript」が誤って別名登録され、案件はJavaScript、人材はTypeScriptとして紐付いている
+	js := model.KeywordGroup{Name: "JavaScript", Type: "language"}
+	require.NoError(t, db.DB.Create(&js).Error)
+	words := []model.KeyWord{{Word: "JavaScript"}, {Word: "TypeScript"}}
+	require.NoError(t, db.DB.Create(&words).Error)
+	require.NoError(t, db.DB.Create(&[]model.KeywordGroupWordLink{
+		{KeywordGroupID: js.KeywordGroupID, KeyWordID: words[0].ID},
+		{KeywordGroupID: js.KeywordGroupID, KeyWordID: words[1].ID},
+	}).Error)
+	emails := []model.Email{
+		{GmailID: "gmail-1", Subject: "案件のご紹介", ReceivedDate: time.Now()},
+		{GmailID: "gmail-2", Subject: "人材のご紹介", ReceivedDate: time.Now()},
+	}
+	require.NoError(t, db.DB.Create(&emails).Error)
+	languages, skills := "JavaScript", "TypeScript"
+	project := model.EmailProject{EmailID: emails[0].ID, ProjectKey: "p1", Languages: &languages}
+	require.NoError(t, db.DB.Create(&project).Error)
+	candidate := model.EmailCandidate{EmailID: emails[1].ID, Skills: &skills}
+	require.NoError(t, db.DB.Create(&candidate).Error)
+	require.NoError(t, db.DB.Create(&model.EmailKeywordGroup{EmailProjectID: project.ID, KeywordGroupID: js.KeywordGroupID}).Error)
+	require.NoError(t, db.DB.Create(&model.EmailCandidateKeywordGroup{EmailCandidateID: candidate.ID, KeywordGroupID: js.KeywordGroupID}).Error)
+
+	repo := New(db.DB)
+	kind := domain.KindKeyword
+	log := func(action domain.Action) domain.AuditLog {
+		return domain.AuditLog{Kind: kind, Action: action, Detail: string(action), Actor: "test"}
+	}
+	candidateGroupIDs := func() []uint {
+		var ids []uint
+		require.NoError(t, db.DB.Model(&model.EmailCandidateKeywordGroup{}).
+			Where("email_candidate_id = ?", candidate.ID).Order("keyword_group_id").Pluck("keyword_group_id", &ids).Error)
+		return ids
+	}
+
+	// 案件数は人材の紐付けも数える
+	group, err := repo.GetGroup(kind, js.KeywordGroupID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), group.Usage)
+
+	// 分割: TypeScriptのみの人材は新しいグループに付け替わる
+	tsID, err := repo.SplitGroup(kind, js.KeywordGroupID, "TypeScript", []string{"TypeScript"}, log(domain.ActionSplit))
+	require.NoError(t, err)
+	assert.Equal(t, []uint{tsID}, candidateGroupIDs())
+	ts, err := repo.GetGroup(kind, tsID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), ts.Usage)
+
+	// 統合: 人材の紐付けが統合先に付け替わり、削除したグループを指さない
+	require.NoError(t, repo.MergeGroups(kind, tsID, js.KeywordGroupID, log(domain.ActionMerge)))
+	assert.Equal(t, []uint{js.KeywordGroupID}, candidateGroupIDs())
+	group, err = repo.GetGroup(kind, js.KeywordGroupID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), group.Usage)
+}
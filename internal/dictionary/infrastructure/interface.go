@@ -0,0 +1,65 @@
+// Package infrastructure は用語辞書（キーワード・ポジション・業務種別の表記ゆれ管理）機能のインフラストラクチャ層を提供します。
+// このファイルはリポジトリのインターフェースを定義します。
+package infrastructure
+
+import (
+	"business/internal/dictionary/domain"
+	"context"
+)
+
+// RepositoryInterface は用語辞書のリポジトリインターフェースです
+type RepositoryInterface interface {
+	// ListKeywordGroups はすべてのキーワードグループをID順に返します
+	ListKeywordGroups() ([]domain.KeywordGroup, error)
+
+	// MergeKeywordGroups はキーワードグループを統合し、操作履歴を保存します
+	MergeKeywordGroups(merges []domain.Merge, logs []domain.AuditLog) error
+
+	// ListGroups は種類ごとのグループを別名・案件数付きで、案件数の多い順に返します
+	ListGroups(kind domain.Kind, q string) ([]domain.Group, error)
+
+	// GetGroup はグループを別名・案件数付きで返します
+	GetGroup(kind domain.Kind, id uint) (domain.Group, error)
+
+	// MergeGroups は統合元のグループを統合先のグループに統合します
+	MergeGroups(kind domain.Kind, sourceID, targetID uint, log domain.AuditLog) error
+
+	// SplitGroup は別名をグループから切り出して新しいグループを作成し、そのIDを返します
+	SplitGroup(kind domain.Kind, id uint, name string, aliases []string, log domain.AuditLog) (uint, error)
+
+	// AddAlias はグループに別名を追加します
+	AddAlias(kind domain.Kind, id uint, word string, log domain.AuditLog) error
+
+	// RemoveAlias はグループから別名を削除します
+	RemoveAlias(kind domain.Kind, id uint, word string, log domain.AuditLog) error
+
+	// RenameGroup はグループの正規名を変更します
+	RenameGroup(kind domain.Kind, id uint, name string, log domain.AuditLog) error
+
+	// ListAuditLogs は用語辞書の操作履歴を新しい順に返します
+	ListAuditLogs(kind domain.Kind, limit int) ([]domain.AuditLog, error)
+
+	// LastClusteredGroupID は前回までのクラスタリングで確認済みのキーワードグループIDの最大値を返します
+	LastClusteredGroupID() (uint, error)
+
+	// ListSingleAliasKeywordGroups は afterID より後に作成された、別名が1つ以下のキーワードグループを返します
+	ListSingleAliasKeywordGroups(afterID uint) ([]domain.Group, error)
+
+	// SaveClusteringRun はクラスタリングの実行結果と統合提案を保存します
+	SaveClusteringRun(run domain.ClusteringRun) (domain.ClusteringRun, error)
+
+	// ListProposals は統合提案を返します
+	ListProposals(status domain.ProposalStatus, limit int) ([]domain.Proposal, error)
+
+	// GetProposal は統合提案を返します
+	GetProposal(id uint) (domain.Proposal, error)
+
+	// ReviewProposal は統合提案をレビューし、承認の場合はグループを統合します
+	ReviewProposal(id uint, status domain.ProposalStatus, actor string, log domain.AuditLog) (domain.Proposal, error)
+}
+
+// AdvisorInterface はキーワードの統合先をLLMに判定させるインターフェースです
+type AdvisorInterface interface {
+	// SuggestGroup は候補のグループ名から word と同じ技術を指すものを返します（該当なしの場合は空文字）
+	SuggestGroup(ctx context.Context, word string, candidates []string) (name string, reason string, err error)
+}
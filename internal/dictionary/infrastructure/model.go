@@ -0,0 +1,82 @@
+// Package infrastructure は用語辞書（キーワードの表記ゆれ管理）機能のインフラストラクチャ層を提供します。
+// このファイルは用語辞書で扱うテーブルのモデルを定義します。
+package infrastructure
+
+import "time"
+
+// KeywordGroup は正規化された技術キーワードのマスタです
+type KeywordGroup struct {
+	KeywordGroupID uint `gorm:"primaryKey;autoIncrement"`
+	Name           string
+	Type           string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// KeywordGroupWordLink はキーワードグループと表記（key_words）の中間テーブルです
+type KeywordGroupWordLink struct {
+	KeywordGroupID uint `gorm:"primaryKey"`
+	KeyWordID      uint `gorm:"primaryKey"`
+	CreatedAt      time.Time
+}
+
+// EmailKeywordGroup は案件とキーワードグループの中間テーブルです
+type EmailKeywordGroup struct {
+	EmailProjectID uint
+	KeywordGroupID uint
+	CreatedAt      time.Time
+}
+
+// PositionGroup は正規化されたポジション名のマスタです
+type PositionGroup struct {
+	PositionGroupID uint `gorm:"primaryKey"`
+	Name            string
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// WorkTypeGroup は正規化された業務種別のマスタです
+type WorkTypeGroup struct {
+	WorkTypeGroupID uint `gorm:"primaryKey"`
+	Name            string
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// DictionaryAuditLog は用語辞書の操作履歴です
+type DictionaryAuditLog struct {
+	ID            uint `gorm:"primaryKey;autoIncrement"`
+	Kind          string
+	Action        string
+	GroupID       uint
+	TargetGroupID *uint
+	Detail        string
+	Actor         string
+	CreatedAt     time.Time
+}
+
+// KeywordMergeProposal はキーワードグループの統合提案（レビューキュー）です
+type KeywordMergeProposal struct {
+	ID            uint `gorm:"primaryKey;autoIncrement"`
+	SourceGroupID uint
+	TargetGroupID uint
+	Score         float64
+	Method        string
+	Reason        string
+	Status        string
+	ReviewedBy    string
+	ReviewedAt    *time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// KeywordClusteringRun はキーワードのクラスタリングの実行履歴です
+type KeywordClusteringRun struct {
+	ID          uint `gorm:"primaryKey;autoIncrement"`
+	LastGroupID uint
+	Checked     int
+	Proposed    int
+	UsedLLM     bool
+	StartedAt   time.Time
+	FinishedAt  time.Time
+}
@@ -0,0 +1,214 @@
+package infrastructure
+
+import (
+	"business/internal/dictionary/domain"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// LastClusteredGroupID は前回までのクラスタリングで確認済みのキーワードグループIDの最大値を返します
+// 一度も実行していない場合は0を返します。
+func (r *Repository) LastClusteredGroupID() (uint, error) {
+	var last uint
+	err := r.db.Model(&KeywordClusteringRun{}).Select("COALESCE(MAX(last_group_id), 0)").Scan(&last).Error
+	if err != nil {
+		return 0, fmt.Errorf("KeywordClusteringRun取得エラー: %w", err)
+	}
+	return last, nil
+}
+
+// ListSingleAliasKeywordGroups は afterID より後に作成された、別名が1つ以下のキーワードグループをID順に返します
+func (r *Repository) ListSingleAliasKeywordGroups(afterID uint) ([]domain.Group, error) {
+	var ids []uint
+	err := r.db.Table("keyword_groups AS g").
+		Select("g.keyword_group_id").
+		Joins("LEFT JOIN keyword_group_word_links l ON l.keyword_group_id = g.keyword_group_id").
+		Where("g.keyword_group_id > ?", afterID).
+		Group("g.keyword_group_id").
+		Having("COUNT(l.key_word_id) <= 1").
+		Order("g.keyword_group_id").
+		Pluck("g.keyword_group_id", &ids).Error
+	if err != nil {
+		return nil, fmt.Errorf("KeywordGroup取得エラー: %w", err)
+	}
+	if len(ids) == 0 {
+		return []domain.Group{}, nil
+	}
+	return loadGroups(r.db, domain.KindKeyword, "", ids)
+}
+
+// SaveClusteringRun はクラスタリングの実行結果と統合提案を1つのトランザクションで保存します
+// 同じ統合元・統合先の提案が既にある場合は保存せず、保存した提案のみを結果に含めます。
+func (r *Repository) SaveClusteringRun(run domain.ClusteringRun) (domain.ClusteringRun, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		saved := make([]domain.Proposal, 0, len(run.Proposals))
+		for _, p := range run.Proposals {
+			row := KeywordMergeProposal{
+				SourceGroupID: p.SourceGroupID,
+				TargetGroupID: p.TargetGroupID,
+				Score:         p.Score,
+				Method:        string(p.Method),
+				Reason:        p.Reason,
+				Status:        string(domain.ProposalPending),
+			}
+			result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&row)
+			if result.Error != nil {
+				return fmt.Errorf("KeywordMergeProposal作成エラー: %w", result.Error)
+			}
+			if result.RowsAffected == 0 {
+				continue
+			}
+			p.ID = row.ID
+			p.Status = domain.ProposalPending
+			p.CreatedAt = row.CreatedAt
+			saved = append(saved, p)
+		}
+		run.Proposals = saved
+		run.Proposed = len(saved)
+
+		row := KeywordClusteringRun{
+			LastGroupID: run.LastGroupID,
+			Checked:     run.Checked,
+			Proposed:    run.Proposed,
+			UsedLLM:     run.UsedLLM,
+			StartedAt:   run.StartedAt,
+			FinishedAt:  run.FinishedAt,
+		}
+		if err := tx.Create(&row).Error; err != nil {
+			return fmt.Errorf("KeywordClusteringRun作成エラー: %w", err)
+		}
+		run.ID = row.ID
+		return nil
+	})
+	if err != nil {
+		return domain.ClusteringRun{}, err
+	}
+	return run, nil
+}
+
+// proposalRow は統合提案とグループ名の取得結果です
+type proposalRow struct {
+	KeywordMergeProposal
+	SourceName string
+	TargetName string
+}
+
+// proposalQuery は統合提案をグループ名付きで取得するクエリを返します
+// 統合済みなどでグループが削除されている場合、グループ名は空文字になります。
+func proposalQuery(db *gorm.DB) *gorm.DB {
+	return db.Table("keyword_merge_proposals AS p").
+		Select("p.*, COALESCE(s.name, '') AS source_name, COALESCE(t.name, '') AS target_name").
+		Joins("LEFT JOIN keyword_groups s ON s.keyword_group_id = p.source_group_id").
+		Joins("LEFT JOIN keyword_groups t ON t.keyword_group_id = p.target_group_id")
+}
+
+// ListProposals は統合提案を返します
+// status を指定した場合はその状態の提案のみ、レビュー待ちは類似度の高い順、それ以外は新しい順に返します。
+func (r *Repository) ListProposals(status domain.ProposalStatus, limit int) ([]domain.Proposal, error) {
+	query := proposalQuery(r.db)
+	if status != "" {
+		query = query.Where("p.status = ?", string(status))
+	}
+	if status == domain.ProposalPending {
+		query = query.Order("p.score DESC, p.id")
+	} else {
+		query = query.Order("p.id DESC")
+	}
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	var rows []proposalRow
+	if err := query.Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("KeywordMergeProposal取得エラー: %w", err)
+	}
+	proposals := make([]domain.Proposal, 0, len(rows))
+	for _, row := range rows {
+		proposals = append(proposals, toProposal(row))
+	}
+	return proposals, nil
+}
+
+// GetProposal は統合提案をグループ名付きで返します
+func (r *Repository) GetProposal(id uint) (domain.Proposal, error) {
+	return getProposal(r.db, id)
+}
+
+// ReviewProposal は統合提案をレビューします
+// 承認（accepted）の場合は同じトランザクションでグループを統合して操作履歴（log）を保存し、
+// 統合元のグループに関するほかのレビュー待ちの提案を却下します。
+func (r *Repository) ReviewProposal(id uint, status domain.ProposalStatus, actor string, log domain.AuditLog) (domain.Proposal, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var row KeywordMergeProposal
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).Take(&row).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("%w: #%d", domain.ErrProposalNotFound, id)
+		}
+		if err != nil {
+			return fmt.Errorf("KeywordMergeProposal取得エラー: %w", err)
+		}
+		if row.Status != string(domain.ProposalPending) {
+			return fmt.Errorf("%w: #%d（%s）", domain.ErrProposalReviewed, id, row.Status)
+		}
+
+		now := time.Now()
+		if status == domain.ProposalAccepted {
+			if err := mergeGroups(tx, domain.KindKeyword, row.SourceGroupID, row.TargetGroupID, log); err != nil {
+				return err
+			}
+			// 統合元のグループはなくなるため、関係するほかの提案は却下する
+			err := tx.Model(&KeywordMergeProposal{}).
+				Where("status = ? AND id <> ?", string(domain.ProposalPending), id).
+				Where("source_group_id = ? OR target_group_id = ?", row.SourceGroupID, row.SourceGroupID).
+				Updates(map[string]interface{}{"status": string(domain.ProposalRejected), "reviewed_by": actor, "reviewed_at": now}).Error
+			if err != nil {
+				return fmt.Errorf("KeywordMergeProposal更新エラー: %w", err)
+			}
+		}
+
+		err = tx.Model(&KeywordMergeProposal{}).Where("id = ?", id).
+			Updates(map[string]interface{}{"status": string(status), "reviewed_by": actor, "reviewed_at": now}).Error
+		if err != nil {
+			return fmt.Errorf("KeywordMergeProposal更新エラー: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return domain.Proposal{}, err
+	}
+	return getProposal(r.db, id)
+}
+
+// getProposal は統合提案をグループ名付きで返します
+func getProposal(db *gorm.DB, id uint) (domain.Proposal, error) {
+	var rows []proposalRow
+	if err := proposalQuery(db).Where("p.id = ?", id).Scan(&rows).Error; err != nil {
+		return domain.Proposal{}, fmt.Errorf("KeywordMergeProposal取得エラー: %w", err)
+	}
+	if len(rows) == 0 {
+		return domain.Proposal{}, fmt.Errorf("%w: #%d", domain.ErrProposalNotFound, id)
+	}
+	return toProposal(rows[0]), nil
+}
+
+// toProposal は取得結果をドメインの統合提案に変換します
+func toProposal(row proposalRow) domain.Proposal {
+	return domain.Proposal{
+		ID:            row.ID,
+		SourceGroupID: row.SourceGroupID,
+		SourceName:    row.SourceName,
+		TargetGroupID: row.TargetGroupID,
+		TargetName:    row.TargetName,
+		Score:         row.Score,
+		Method:        domain.ProposalMethod(row.Method),
+		Reason:        row.Reason,
+		Status:        domain.ProposalStatus(row.Status),
+		ReviewedBy:    row.ReviewedBy,
+		ReviewedAt:    row.ReviewedAt,
+		CreatedAt:     row.CreatedAt,
+	}
+}
@@ -0,0 +1,94 @@
+package infrastructure
+
+import (
+	"business/internal/dictionary/domain"
+	"business/tools/migrations/model"
+	"business/tools/mysql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_Proposals(t *testing.T) {
+	t.Parallel()
+	// テスト用DBの準備
+	db, cleanup, err := mysql.CreateNewTestDB()
+	require.NoError(t, err)
+	defer cleanup()
+
+	err = db.DB.AutoMigrate(
+		model.KeywordGroup{},
+		model.KeyWord{},
+		model.KeywordGroupWordLink{},
+		model.EmailKeywordGroup{},
+		model.EmailCandidateKeywordGroup{},
+		model.DictionaryAuditLog{},
+		model.KeywordMergeProposal{},
+		model.KeywordClusteringRun{},
+	)
+	require.NoError(t, err)
+
+	// 既存の「Next.js」（別名2つ）と、新しい「Next」「Nuxt3」（別名1つ）
+	groups := []model.KeywordGroup{{Name: "Next.js", Type: "framework"}, {Name: "Next", Type: "framework"}, {Name: "Nuxt3", Type: "framework"}}
+	require.NoError(t, db.DB.Create(&groups).Error)
+	words := []model.KeyWord{{Word: "Next.js"}, {Word: "NextJS"}, {Word: "Next"}, {Word: "Nuxt3"}}
+	require.NoError(t, db.DB.Create(&words).Error)
+	require.NoError(t, db.DB.Create(&[]model.KeywordGroupWordLink{
+		{KeywordGroupID: groups[0].KeywordGroupID, KeyWordID: words[0].ID},
+		{KeywordGroupID: groups[0].KeywordGroupID, KeyWordID: words[1].ID},
+		{KeywordGroupID: groups[1].KeywordGroupID, KeyWordID: words[2].ID},
+		{KeywordGroupID: groups[2].KeywordGroupID, KeyWordID: words[3].ID},
+	}).Error)
+
+	repo := New(db.DB)
+	last, err := repo.LastClusteredGroupID()
+	require.NoError(t, err)
+	assert.Equal(t, uint(0), last)
+
+	single, err := repo.ListSingleAliasKeywordGroups(0)
+	require.NoError(t, err)
+	require.Len(t, single, 2)
+
+	proposal := domain.Proposal{SourceGroupID: groups[1].KeywordGroupID, TargetGroupID: groups[0].KeywordGroupID, Score: 1, Method: domain.MethodSimilarity}
+	run := domain.ClusteringRun{LastGroupID: groups[2].KeywordGroupID, Checked: 2, StartedAt: time.Now(), FinishedAt: time.Now()}
+	run.Proposals = []domain.Proposal{proposal, {SourceGroupID: groups[2].KeywordGroupID, TargetGroupID: groups[1].KeywordGroupID, Score: 0.8, Method: domain.MethodSimilarity}}
+	saved, err := repo.SaveClusteringRun(run)
+	require.NoError(t, err)
+	assert.Equal(t, 2, saved.Proposed)
+
+	// 同じ提案は二重に登録しない
+	saved, err = repo.SaveClusteringRun(domain.ClusteringRun{LastGroupID: groups[2].KeywordGroupID, Proposals: []domain.Proposal{proposal}, StartedAt: time.Now(), FinishedAt: time.Now()})
+	require.NoError(t, err)
+	assert.Equal(t, 0, saved.Proposed)
+	last, err = repo.LastClusteredGroupID()
+	require.NoError(t, err)
+	assert.Equal(t, groups[2].KeywordGroupID, last)
+
+	pending, err := repo.ListProposals(domain.ProposalPending, 0)
+	require.NoError(t, err)
+	require.Len(t, pending, 2)
+	assert.Equal(t, "Next", pending[0].SourceName)
+	assert.Equal(t, "Next.js", pending[0].TargetName)
+
+	// 承認すると統合され、統合元に関するほかの提案は却下される
+	log := domain.AuditLog{Kind: domain.KindKeyword, Action: domain.ActionMerge, GroupID: groups[1].KeywordGroupID, Detail: "承認", Actor: "test"}
+	accepted, err := repo.ReviewProposal(pending[0].ID, domain.ProposalAccepted, "test", log)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ProposalAccepted, accepted.Status)
+	assert.NotNil(t, accepted.ReviewedAt)
+
+	merged, err := repo.GetGroup(domain.KindKeyword, groups[0].KeywordGroupID)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"Next.js", "NextJS", "Next"}, merged.Aliases)
+
+	other, err := repo.GetProposal(pending[1].ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ProposalRejected, other.Status)
+
+	_, err = repo.ReviewProposal(pending[0].ID, domain.ProposalRejected, "test", domain.AuditLog{})
+	assert.ErrorIs(t, err, domain.ErrProposalReviewed)
+	_, err = repo.ReviewProposal(999, domain.ProposalRejected, "test", domain.AuditLog{})
+	assert.ErrorIs(t, err, domain.ErrProposalNotFound)
+}
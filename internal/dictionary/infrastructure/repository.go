@@ -0,0 +1,94 @@
+// Package infrastructure は用語辞書（キーワードの表記ゆれ管理）機能のインフラストラクチャ層を提供します。
+// このファイルはリポジトリの実装と、キーワードグループの統合を提供します。
+// グループの一覧・統合・分割・別名・正規名の操作は group.go にあります。
+package infrastructure
+
+import (
+	"business/internal/dictionary/domain"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// Repository は用語辞書のリポジトリ実装です
+type Repository struct {
+	db *gorm.DB
+}
+
+// New は用語辞書リポジトリを作成します
+func New(db *gorm.DB) *Repository {
+	return &Repository{
+		db: db,
+	}
+}
+
+// ListKeywordGroups はすべてのキーワードグループをID順に返します
+func (r *Repository) ListKeywordGroups() ([]domain.KeywordGroup, error) {
+	var rows []KeywordGroup
+	if err := r.db.Order("keyword_group_id").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("KeywordGroup取得エラー: %w", err)
+	}
+
+	groups := make([]domain.KeywordGroup, 0, len(rows))
+	for _, row := range rows {
+		groups = append(groups, domain.KeywordGroup{ID: row.KeywordGroupID, Name: row.Name, Type: row.Type})
+	}
+	return groups, nil
+}
+
+// MergeKeywordGroups はキーワードグループを1つのトランザクションで統合します
+// 統合するグループの案件・人材（email_keyword_groups・email_candidate_keyword_groups）と表記（keyword_group_word_links）の紐付けを残すグループに付け替え、
+// 統合したグループを削除します。残すグループの名前は正規名に変更します。
+// 操作履歴（logs）も同じトランザクションで保存します。
+func (r *Repository) MergeKeywordGroups(merges []domain.Merge, logs []domain.AuditLog) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for _, m := range merges {
+			if err := mergeKeywordGroup(tx, m); err != nil {
+				return fmt.Errorf("KeywordGroup統合エラー（%s）: %w", m.Name, err)
+			}
+		}
+		for _, log := range logs {
+			if err := createAuditLog(tx, log); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// mergeKeywordGroup は1つの統合内容を反映します
+func mergeKeywordGroup(tx *gorm.DB, m domain.Merge) error {
+	survivorID := m.Survivor.ID
+	mergedIDs := make([]uint, 0, len(m.Merged))
+	for _, g := range m.Merged {
+		mergedIDs = append(mergedIDs, g.ID)
+	}
+
+	if len(mergedIDs) > 0 {
+		if err := moveLinks(tx, groupTables[domain.KindKeyword], mergedIDs, survivorID); err != nil {
+			return err
+		}
+
+		// 残すグループに既に紐付いている表記は重複させない
+		err := tx.Exec(`INSERT IGNORE INTO keyword_group_word_links (keyword_group_id, key_word_id, created_at)
+			SELECT ?, key_word_id, created_at FROM keyword_group_word_links WHERE keyword_group_id IN ?`,
+			survivorID, mergedIDs).Error
+		if err != nil {
+			return fmt.Errorf("KeywordGroupWordLink付け替えエラー: %w", err)
+		}
+		if err := tx.Where("keyword_group_id IN ?", mergedIDs).Delete(&KeywordGroupWordLink{}).Error; err != nil {
+			return fmt.Errorf("KeywordGroupWordLink削除エラー: %w", err)
+		}
+		if err := tx.Where("keyword_group_id IN ?", mergedIDs).Delete(&KeywordGroup{}).Error; err != nil {
+			return fmt.Errorf("KeywordGroup削除エラー: %w", err)
+		}
+	}
+
+	if m.Renamed() {
+		err := tx.Model(&KeywordGroup{}).Where("keyword_group_id = ?", survivorID).Update("name", m.Name).Error
+		if err != nil {
+			return fmt.Errorf("KeywordGroup名変更エラー: %w", err)
+		}
+	}
+	return nil
+}
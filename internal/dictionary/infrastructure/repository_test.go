@@ -0,0 +1,92 @@
+// Package infrastructure は用語辞書機能のインフラストラクチャ層のテストを提供します。
+package infrastructure
+
+import (
+	"business/internal/dictionary/domain"
+	"business/tools/migrations/model"
+	"business/tools/mysql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_MergeKeywordGroups(t *testing.T) {
+	t.Parallel()
+	// テスト用DBの準備
+	db, cleanup, err := mysql.CreateNewTestDB()
+	require.NoError(t, err)
+	defer cleanup()
+
+	err = db.DB.AutoMigrate(
+		model.KeywordGroup{},
+		model.KeyWord{},
+		model.KeywordGroupWordLink{},
+		model.EmailKeywordGroup{},
+		model.EmailCandidateKeywordGroup{},
+	)
+	require.NoError(t, err)
+
+	// 「java」「ＪＡＶＡ」の2グループと、それぞれの表記・案件の紐付け
+	groups := []model.KeywordGroup{{Name: "java", Type: "language"}, {Name: "ＪＡＶＡ", Type: "must"}, {Name: "Go", Type: "language"}}
+	require.NoError(t, db.DB.Create(&groups).Error)
+	words := []model.KeyWord{{Word: "java"}, {Word: "ＪＡＶＡ"}}
+	require.NoError(t, db.DB.Create(&words).Error)
+	require.NoError(t, db.DB.Create(&[]model.KeywordGroupWordLink{
+		{KeywordGroupID: groups[0].KeywordGroupID, KeyWordID: words[0].ID},
+		{KeywordGroupID: groups[1].KeywordGroupID, KeyWordID: words[1].ID},
+		{KeywordGroupID: groups[1].KeywordGroupID, KeyWordID: words[0].ID},
+	}).Error)
+	require.NoError(t, db.DB.Create(&[]model.EmailKeywordGroup{
+		{EmailProjectID: 1, KeywordGroupID: groups[0].KeywordGroupID},
+		{EmailProjectID: 2, KeywordGroupID: groups[1].KeywordGroupID},
+		// 両方のグループに紐付いている案件
+		{EmailProjectID: 3, KeywordGroupID: groups[0].KeywordGroupID},
+		{EmailProjectID: 3, KeywordGroupID: groups[1].KeywordGroupID},
+	}).Error)
+	require.NoError(t, db.DB.Create(&[]model.EmailCandidateKeywordGroup{
+		{EmailCandidateID: 1, KeywordGroupID: groups[1].KeywordGroupID},
+		{EmailCandidateID: 2, KeywordGroupID: groups[0].KeywordGroupID},
+		{EmailCandidateID: 2, KeywordGroupID: groups[1].KeywordGroupID},
+	}).Error)
+
+	repo := New(db.DB)
+	listed, err := repo.ListKeywordGroups()
+	require.NoError(t, err)
+	require.Len(t, listed, 3)
+
+	merge := domain.Merge{Name: "Java", Survivor: listed[0], Merged: []domain.KeywordGroup{listed[1]}}
+	require.NoError(t, repo.MergeKeywordGroups([]domain.Merge{merge}, nil))
+
+	listed, err = repo.ListKeywordGroups()
+	require.NoError(t, err)
+	assert.Equal(t, []domain.KeywordGroup{
+		{ID: groups[0].KeywordGroupID, Name: "Java", Type: "language"},
+		{ID: groups[2].KeywordGroupID, Name: "Go", Type: "language"},
+	}, listed)
+
+	// 案件と表記の紐付けが残したグループに付け替わり、両方に紐付いていた案件は1行になる
+	var projectLinks []EmailKeywordGroup
+	require.NoError(t, db.DB.Order("email_project_id").Find(&projectLinks).Error)
+	require.Len(t, projectLinks, 3)
+	for i, l := range projectLinks {
+		assert.Equal(t, uint(i+1), l.EmailProjectID)
+		assert.Equal(t, groups[0].KeywordGroupID, l.KeywordGroupID)
+	}
+
+	// 人材の紐付けも残したグループに付け替わる
+	var candidateLinks []model.EmailCandidateKeywordGroup
+	require.NoError(t, db.DB.Order("email_candidate_id").Find(&candidateLinks).Error)
+	require.Len(t, candidateLinks, 2)
+	for i, l := range candidateLinks {
+		assert.Equal(t, uint(i+1), l.EmailCandidateID)
+		assert.Equal(t, groups[0].KeywordGroupID, l.KeywordGroupID)
+	}
+
+	var links []KeywordGroupWordLink
+	require.NoError(t, db.DB.Order("key_word_id").Find(&links).Error)
+	require.Len(t, links, 2)
+	for _, l := range links {
+		assert.Equal(t, groups[0].KeywordGroupID, l.KeywordGroupID)
+	}
+}
@@ -0,0 +1,18 @@
+// Package application は新着案件のダイジェスト機能のアプリケーション層を提供します。
+// このファイルはダイジェストのユースケースインターフェースを定義します。
+package application
+
+import (
+	"business/internal/digest/domain"
+	"context"
+	"time"
+)
+
+// UseCaseInterface は新着案件のダイジェストのユースケースインターフェースです
+type UseCaseInterface interface {
+	// Generate は期間内の新着案件を集計し、エンジニアごとの適合度の高い案件を加えたレポートを作成します
+	Generate(opts domain.Options, now time.Time) (domain.Report, error)
+
+	// Send はレポートをメール・Webhook に送信します
+	Send(ctx context.Context, report domain.Report) error
+}
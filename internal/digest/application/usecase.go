@@ -0,0 +1,129 @@
+// Package application は新着案件のダイジェスト機能のアプリケーション層を提供します。
+// このファイルはダイジェストの作成と送信のユースケースを実装します。
+package application
+
+import (
+	"business/internal/digest/domain"
+	r "business/internal/digest/infrastructure"
+	ma "business/internal/matching/application"
+	md "business/internal/matching/domain"
+	"business/tools/notifier"
+	"context"
+	"fmt"
+	"time"
+)
+
+// UseCase は新着案件のダイジェストのユースケースの具象です
+type UseCase struct {
+	r  r.RepositoryInterface
+	ma ma.UseCaseInterface
+	ch notifier.Channel
+}
+
+// New は新着案件のダイジェストのユースケースを作成します（ch が nil の場合は送信できない）
+func New(r r.RepositoryInterface, ma ma.UseCaseInterface, ch notifier.Channel) *UseCase {
+	return &UseCase{
+		r:  r,
+		ma: ma,
+		ch: ch,
+	}
+}
+
+// Generate は期間内の新着案件を集計し、エンジニアごとの適合度の高い案件を加えたレポートを作成します
+// 適合度はマッチングと同じ採点で、期間内に受信した案件のうち opts.MinScore 以上の上位 opts.TopMatches 件を載せます。
+func (u *UseCase) Generate(opts domain.Options, now time.Time) (domain.Report, error) {
+	opts, err := opts.Normalize()
+	if err != nil {
+		return domain.Report{}, err
+	}
+	projects, err := u.r.ListProjects(opts.Since, opts.Until)
+	if err != nil {
+		return domain.Report{}, err
+	}
+
+	report := domain.Build(projects, opts, now)
+	if opts.TopMatches < 0 || len(projects) == 0 {
+		return report, nil
+	}
+	report.TopMatches, err = u.topMatches(projects, opts)
+	if err != nil {
+		return domain.Report{}, err
+	}
+	return report, nil
+}
+
+// Send はレポートをメール・Webhook に送信します
+// 本文はプレーンテキストで、メールには HTML の本文も付けます。
+func (u *UseCase) Send(ctx context.Context, report domain.Report) error {
+	if u.ch == nil {
+		return domain.ErrNoChannel
+	}
+	text, err := domain.Render(report, domain.FormatText)
+	if err != nil {
+		return err
+	}
+	html, err := domain.Render(report, domain.FormatHTML)
+	if err != nil {
+		return err
+	}
+	if err := u.ch.Send(ctx, notifier.Message{Title: report.Title, Text: text, HTML: html}); err != nil {
+		return fmt.Errorf("ダイジェストの送信エラー: %w", err)
+	}
+	return nil
+}
+
+// topMatches はプロフィールを登録したエンジニアごとに、期間内の案件を適合度の高い順に返します
+// 適合する案件が無いエンジニアは含めません。
+func (u *UseCase) topMatches(projects []domain.Project, opts domain.Options) ([]domain.EngineerMatches, error) {
+	inPeriod := make(map[uint]struct{}, len(projects))
+	for _, p := range projects {
+		inPeriod[p.ProjectID] = struct{}{}
+	}
+
+	profiles, err := u.ma.ListProfiles()
+	if err != nil {
+		return nil, err
+	}
+	matches := []domain.EngineerMatches{}
+	for _, profile := range profiles {
+		// 期間の終了日時を基準に採点し、期間外に受信した案件は除く
+		result, err := u.ma.MatchProjects(profile.ID, md.MatchOptions{Days: opts.Days(), Limit: md.MaxMatchLimit, MinScore: opts.MinScore}, opts.Until)
+		if err != nil {
+			return nil, fmt.Errorf("%s さんのマッチングエラー: %w", profile.Name, err)
+		}
+		items := []domain.MatchItem{}
+		for _, m := range result.Items {
+			if _, ok := inPeriod[m.ProjectID]; !ok {
+				continue
+			}
+			items = append(items, toMatchItem(m))
+			if len(items) == opts.TopMatches {
+				break
+			}
+		}
+		if len(items) > 0 {
+			matches = append(matches, domain.EngineerMatches{Engineer: profile.Name, Items: items})
+		}
+	}
+	return matches, nil
+}
+
+// toMatchItem はマッチングの採点結果をダイジェストに載せる案件にします
+func toMatchItem(m md.Match) domain.MatchItem {
+	title := m.ProjectTitle
+	if title == "" {
+		title = m.Subject
+	}
+	return domain.MatchItem{
+		ProjectID:   m.ProjectID,
+		GmailID:     m.GmailID,
+		Title:       title,
+		URL:         notifier.GmailURL(m.GmailID),
+		Score:       m.Score,
+		PriceFrom:   m.MonthlyPriceFrom,
+		PriceTo:     m.MonthlyPriceTo,
+		Agency:      domain.AgencyOf(m.SenderEmail),
+		ReceivedAt:  m.ReceivedDate,
+		MissingMust: m.Missing,
+	}
+}
@@ -0,0 +1,159 @@
+package application
+
+import (
+	"business/internal/digest/domain"
+	md "business/internal/matching/domain"
+	"business/tools/notifier"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockRepository は新着案件のダイジェストのリポジトリのモックです
+type MockRepository struct {
+	mock.Mock
+}
+
+func (m *MockRepository) ListProjects(since, until time.Time) ([]domain.Project, error) {
+	args := m.Called(since, until)
+	return args.Get(0).([]domain.Project), args.Error(1)
+}
+
+// MockMatching はマッチングのユースケースのモックです
+type MockMatching struct {
+	mock.Mock
+}
+
+func (m *MockMatching) ListProfiles() ([]md.Profile, error) {
+	args := m.Called()
+	return args.Get(0).([]md.Profile), args.Error(1)
+}
+
+func (m *MockMatching) GetProfile(id uint) (md.Profile, error) {
+	args := m.Called(id)
+	return args.Get(0).(md.Profile), args.Error(1)
+}
+
+func (m *MockMatching) GetProfileByName(name string) (md.Profile, error) {
+	args := m.Called(name)
+	return args.Get(0).(md.Profile), args.Error(1)
+}
+
+func (m *MockMatching) CreateProfile(p md.Profile) (md.Profile, error) {
+	args := m.Called(p)
+	return args.Get(0).(md.Profile), args.Error(1)
+}
+
+func (m *MockMatching) UpdateProfile(p md.Profile) (md.Profile, error) {
+	args := m.Called(p)
+	return args.Get(0).(md.Profile), args.Error(1)
+}
+
+func (m *MockMatching) DeleteProfile(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockMatching) MatchProjects(profileID uint, opts md.MatchOptions, now time.Time) (md.MatchResult, error) {
+	args := m.Called(profileID, opts, now)
+	return args.Get(0).(md.MatchResult), args.Error(1)
+}
+
+func (m *MockMatching) MatchCandidates(projectID uint, opts md.MatchOptions, now time.Time) (md.CandidateResult, error) {
+	args := m.Called(projectID, opts, now)
+	return args.Get(0).(md.CandidateResult), args.Error(1)
+}
+
+// MockChannel は通知の送信先のモックです
+type MockChannel struct {
+	mock.Mock
+}
+
+func (m *MockChannel) Name() string {
+	return "mock"
+}
+
+func (m *MockChannel) Send(ctx context.Context, msg notifier.Message) error {
+	args := m.Called(msg)
+	return args.Error(0)
+}
+
+func TestGenerate(t *testing.T) {
+	repo := new(MockRepository)
+	matching := new(MockMatching)
+	usecase := New(repo, matching, nil)
+
+	since := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+	until := since.AddDate(0, 0, 1)
+	now := until.Add(7 * time.Hour)
+	repo.On("ListProjects", since, until).Return([]domain.Project{
+		{ProjectID: 1, SenderEmail: "a@agency.example.com", Skills: []string{"Go"}},
+		{ProjectID: 2, SenderEmail: "b@agency.example.com", Skills: []string{"Go"}},
+	}, nil)
+	matching.On("ListProfiles").Return([]md.Profile{{ID: 1, Name: "山田"}, {ID: 2, Name: "佐藤"}}, nil)
+	opts := md.MatchOptions{Days: 1, Limit: md.MaxMatchLimit, MinScore: domain.DefaultMinScore}
+	// 期間外の案件（ID 9）は除き、上位 TopMatches 件までにすること
+	matching.On("MatchProjects", uint(1), opts, until).Return(md.MatchResult{Items: []md.Match{
+		{ProjectID: 9, Score: 95},
+		{ProjectID: 2, GmailID: "gmail-2", Subject: "Go案件", SenderEmail: "b@agency.example.com", Score: 90, Missing: []string{"AWS"}},
+		{ProjectID: 1, GmailID: "gmail-1", ProjectTitle: "決済基盤", SenderEmail: "a@agency.example.com", Score: 80},
+	}}, nil)
+	matching.On("MatchProjects", uint(2), opts, until).Return(md.MatchResult{Items: []md.Match{}}, nil)
+
+	report, err := usecase.Generate(domain.Options{Since: since, Until: until, TopMatches: 1}, now)
+	require.NoError(t, err)
+	assert.Equal(t, 2, report.Total)
+	assert.Equal(t, now, report.GeneratedAt)
+	// 適合する案件が無いエンジニアは載せないこと
+	require.Len(t, report.TopMatches, 1)
+	assert.Equal(t, "山田", report.TopMatches[0].Engineer)
+	assert.Equal(t, []domain.MatchItem{{
+		ProjectID:   2,
+		GmailID:     "gmail-2",
+		Title:       "Go案件",
+		URL:         notifier.GmailURL("gmail-2"),
+		Score:       90,
+		Agency:      "agency.example.com",
+		MissingMust: []string{"AWS"},
+	}}, report.TopMatches[0].Items)
+
+	// 負数の TopMatches ではマッチングしないこと
+	report, err = usecase.Generate(domain.Options{Since: since, Until: until, TopMatches: -1}, now)
+	require.NoError(t, err)
+	assert.Empty(t, report.TopMatches)
+	matching.AssertNumberOfCalls(t, "ListProfiles", 1)
+
+	// 条件が不正な場合は集計しないこと
+	_, err = usecase.Generate(domain.Options{Since: until, Until: since}, now)
+	assert.ErrorIs(t, err, domain.ErrInvalidOptions)
+	repo.AssertNumberOfCalls(t, "ListProjects", 2)
+}
+
+func TestSend(t *testing.T) {
+	since := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+	report := domain.Build(nil, domain.Options{Since: since, Until: since.AddDate(0, 0, 1)}, since)
+
+	// 送信先が無い場合はエラーにすること
+	err := New(new(MockRepository), new(MockMatching), nil).Send(context.Background(), report)
+	assert.ErrorIs(t, err, domain.ErrNoChannel)
+
+	// テキストと HTML の本文を送信すること
+	ch := new(MockChannel)
+	ch.On("Send", mock.MatchedBy(func(m notifier.Message) bool {
+		return m.Title == "案件ダイジェスト 2025/06/02" &&
+			strings.HasPrefix(m.Text, "■ 案件ダイジェスト 2025/06/02\n") &&
+			strings.HasPrefix(m.HTML, "<!DOCTYPE html>")
+	})).Return(nil).Once()
+	require.NoError(t, New(new(MockRepository), new(MockMatching), ch).Send(context.Background(), report))
+
+	ch.On("Send", mock.Anything).Return(errors.New("smtp: 接続エラー")).Once()
+	err = New(new(MockRepository), new(MockMatching), ch).Send(context.Background(), report)
+	assert.ErrorContains(t, err, "smtp: 接続エラー")
+	ch.AssertExpectations(t)
+}
@@ -0,0 +1,327 @@
+// Package domain は新着案件のダイジェスト（日次・週次のまとめ）のドメインモデルを提供します。
+// このファイルはダイジェストの集計期間・条件と、案件をスキル・単価帯・営業会社ごとに集計したレポートを定義します。
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultTopMatches = 3  // 既定でエンジニアごとに載せる適合度の高い案件数
+	MaxTopMatches     = 20 // エンジニアごとに載せる案件数の上限
+	DefaultMinScore   = 60 // 既定で載せる案件の適合度の下限
+	MaxPeriodDays     = 31 // 集計期間の上限（日数）
+	MaxAgencies       = 20 // 件数の多い順に載せる営業会社数（残りは「ほか」にまとめる）
+	UnknownSkill      = "未分類"
+	UnknownPriceBand  = "単価不明"
+)
+
+var (
+	// ErrInvalidOptions はダイジェストの条件が不正な場合のエラーです
+	ErrInvalidOptions = errors.New("ダイジェストの条件が不正です")
+	// ErrNoChannel はダイジェストの送信先が設定されていない場合のエラーです
+	ErrNoChannel = errors.New("ダイジェストの送信先（メール・Webhook）が設定されていません")
+)
+
+// Period は集計期間の種類です
+type Period string
+
+const (
+	PeriodDaily  Period = "daily"  // 前日の0時から当日の0時まで
+	PeriodWeekly Period = "weekly" // 7日前の0時から当日の0時まで
+)
+
+// Range は now を基準にした集計期間を返します
+func (p Period) Range(now time.Time) (since, until time.Time, err error) {
+	until = truncateDay(now)
+	switch p {
+	case PeriodDaily:
+		return until.AddDate(0, 0, -1), until, nil
+	case PeriodWeekly:
+		return until.AddDate(0, 0, -7), until, nil
+	}
+	return time.Time{}, time.Time{}, fmt.Errorf("%w: period は daily / weekly で指定してください", ErrInvalidOptions)
+}
+
+// ParseTime は集計の開始・終了日時を解釈します
+// "2025-06-01"・"2025-06-01 09:00" の日時（now のタイムゾーン）と、"24h" のように now から遡る時間を受け付けます。
+func ParseTime(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	for _, layout := range []string{"2006-01-02", "2006-01-02 15:04", "2006-01-02T15:04"} {
+		if t, err := time.ParseInLocation(layout, value, now.Location()); err == nil {
+			return t, nil
+		}
+	}
+	if d, err := time.ParseDuration(value); err == nil && d > 0 {
+		return now.Add(-d), nil
+	}
+	return time.Time{}, fmt.Errorf("%w: 日時は 2025-06-01 / 2025-06-01 09:00 / 24h の形式で指定してください: %s", ErrInvalidOptions, value)
+}
+
+// Options はダイジェストの条件です
+type Options struct {
+	Since      time.Time // 受信日がこの日時以降の案件を集計する
+	Until      time.Time // 受信日がこの日時より前の案件を集計する
+	TopMatches int       // エンジニアごとに載せる適合度の高い案件数（0で既定、負数で載せない）
+	MinScore   float64   // 載せる案件の適合度の下限（0で既定）
+}
+
+// Normalize は未指定の項目に既定値を設定し、条件を検証します
+func (o Options) Normalize() (Options, error) {
+	if o.TopMatches == 0 {
+		o.TopMatches = DefaultTopMatches
+	}
+	if o.MinScore == 0 {
+		o.MinScore = DefaultMinScore
+	}
+	if o.Since.IsZero() || o.Until.IsZero() || !o.Since.Before(o.Until) {
+		return o, fmt.Errorf("%w: 開始日時は終了日時より前を指定してください", ErrInvalidOptions)
+	}
+	if o.Until.Sub(o.Since) > MaxPeriodDays*24*time.Hour {
+		return o, fmt.Errorf("%w: 集計期間は%d日以内で指定してください", ErrInvalidOptions, MaxPeriodDays)
+	}
+	if o.TopMatches > MaxTopMatches {
+		return o, fmt.Errorf("%w: top は%d以下で指定してください", ErrInvalidOptions, MaxTopMatches)
+	}
+	if o.MinScore < 0 || o.MinScore > 100 {
+		return o, fmt.Errorf("%w: min_score は0〜100で指定してください", ErrInvalidOptions)
+	}
+	return o, nil
+}
+
+// Days は集計期間を含む日数を返します（マッチングの対象にする受信日の範囲に使います）
+func (o Options) Days() int {
+	return int((o.Until.Sub(o.Since) + 24*time.Hour - 1) / (24 * time.Hour))
+}
+
+// Project はダイジェストで集計する案件です
+type Project struct {
+	ProjectID        uint
+	GmailID          string
+	Subject          string
+	ProjectTitle     string
+	SenderEmail      string
+	ReceivedDate     time.Time
+	Skills           []string // 言語のキーワードグループ名（表記ゆれをまとめたスキル）
+	MonthlyPriceFrom *int
+	MonthlyPriceTo   *int
+	RemoteType       string
+	ClusterID        *uint // 重複グループID（複数の営業会社から届いた同じ案件。未分類は nil）
+}
+
+// Count は項目ごとの案件数です
+type Count struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// SkillCluster はスキルごとの案件数と単価帯の内訳です
+type SkillCluster struct {
+	Name       string  `json:"name"`
+	Count      int     `json:"count"`
+	PriceBands []Count `json:"price_bands"` // 案件のある単価帯（単価帯の順）
+}
+
+// MatchItem はエンジニアに適合度の高い案件です
+type MatchItem struct {
+	ProjectID   uint      `json:"project_id"`
+	GmailID     string    `json:"gmail_id"`
+	Title       string    `json:"title"`
+	URL         string    `json:"url"` // Gメールで開くURL
+	Score       float64   `json:"score"`
+	PriceFrom   *int      `json:"price_from"`
+	PriceTo     *int      `json:"price_to"`
+	Agency      string    `json:"agency"`
+	ReceivedAt  time.Time `json:"received_at"`
+	MissingMust []string  `json:"missing_must"` // 満たしていないMUSTスキル
+}
+
+// EngineerMatches はエンジニアごとの適合度の高い案件です
+type EngineerMatches struct {
+	Engineer string      `json:"engineer"`
+	Items    []MatchItem `json:"items"`
+}
+
+// Report はダイジェストの集計結果です
+type Report struct {
+	Title         string            `json:"title"`
+	Since         time.Time         `json:"since"`
+	Until         time.Time         `json:"until"`
+	Total         int               `json:"total"`          // 案件数
+	Unique        int               `json:"unique"`         // 重複グループを1件と数えた案件数
+	Skills        []SkillCluster    `json:"skills"`         // 案件数の多い順
+	PriceBands    []Count           `json:"price_bands"`    // 単価帯の順（案件の無い単価帯も含む）
+	Agencies      []Count           `json:"agencies"`       // 案件数の多い順に MaxAgencies 社まで
+	OtherAgencies int               `json:"other_agencies"` // MaxAgencies 社より後の営業会社の案件数
+	AgencyCount   int               `json:"agency_count"`   // 営業会社数
+	TopMatches    []EngineerMatches `json:"top_matches"`    // 適合する案件があるエンジニアのみ
+	GeneratedAt   time.Time         `json:"generated_at"`
+}
+
+// priceBand は税別の月額の単価帯です
+type priceBand struct {
+	name string
+	max  int // この金額未満（0は上限なし）
+}
+
+// priceBands は単価帯の一覧です（単価帯の順）
+var priceBands = []priceBand{
+	{name: "〜50万円", max: 500000},
+	{name: "50〜60万円", max: 600000},
+	{name: "60〜70万円", max: 700000},
+	{name: "70〜80万円", max: 800000},
+	{name: "80〜100万円", max: 1000000},
+	{name: "100万円〜"},
+}
+
+// PriceBand は案件の単価帯を返します（上限の単価、無い場合は下限の単価で判定）
+func PriceBand(from, to *int) string {
+	price := to
+	if price == nil {
+		price = from
+	}
+	if price == nil || *price <= 0 {
+		return UnknownPriceBand
+	}
+	for _, b := range priceBands {
+		if b.max == 0 || *price < b.max {
+			return b.name
+		}
+	}
+	return UnknownPriceBand
+}
+
+// AgencyOf は差出人のメールアドレスから営業会社（ドメイン）を返します
+func AgencyOf(senderEmail string) string {
+	i := strings.LastIndex(senderEmail, "@")
+	if i < 0 {
+		return strings.ToLower(senderEmail)
+	}
+	return strings.ToLower(senderEmail[i+1:])
+}
+
+// Build は期間内の案件をスキル・単価帯・営業会社ごとに集計したレポートを作成します
+// 複数のスキルを持つ案件はそれぞれのスキルで数えます。適合度の高い案件（TopMatches）は含みません。
+func Build(projects []Project, opts Options, now time.Time) Report {
+	r := Report{
+		Title:       Title(opts.Since, opts.Until),
+		Since:       opts.Since,
+		Until:       opts.Until,
+		Total:       len(projects),
+		Skills:      []SkillCluster{},
+		Agencies:    []Count{},
+		TopMatches:  []EngineerMatches{},
+		GeneratedAt: now,
+	}
+
+	clusters := map[uint]struct{}{}
+	skills := map[string]map[string]int{}
+	bands := map[string]int{}
+	agencies := map[string]int{}
+	for _, p := range projects {
+		if p.ClusterID == nil {
+			r.Unique++
+		} else if _, ok := clusters[*p.ClusterID]; !ok {
+			clusters[*p.ClusterID] = struct{}{}
+			r.Unique++
+		}
+
+		band := PriceBand(p.MonthlyPriceFrom, p.MonthlyPriceTo)
+		bands[band]++
+		agencies[AgencyOf(p.SenderEmail)]++
+
+		names := p.Skills
+		if len(names) == 0 {
+			names = []string{UnknownSkill}
+		}
+		seen := map[string]struct{}{}
+		for _, name := range names {
+			if _, ok := seen[name]; ok {
+				continue
+			}
+			seen[name] = struct{}{}
+			if skills[name] == nil {
+				skills[name] = map[string]int{}
+			}
+			skills[name][band]++
+		}
+	}
+
+	for name, byBand := range skills {
+		c := SkillCluster{Name: name, PriceBands: []Count{}}
+		for _, band := range bandNames() {
+			if n := byBand[band]; n > 0 {
+				c.Count += n
+				c.PriceBands = append(c.PriceBands, Count{Name: band, Count: n})
+			}
+		}
+		r.Skills = append(r.Skills, c)
+	}
+	sort.Slice(r.Skills, func(i, j int) bool {
+		a, b := r.Skills[i], r.Skills[j]
+		// 未分類は最後に載せる
+		if (a.Name == UnknownSkill) != (b.Name == UnknownSkill) {
+			return b.Name == UnknownSkill
+		}
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		return a.Name < b.Name
+	})
+
+	for _, band := range bandNames() {
+		r.PriceBands = append(r.PriceBands, Count{Name: band, Count: bands[band]})
+	}
+
+	all := sortedCounts(agencies)
+	r.AgencyCount = len(all)
+	if len(all) > MaxAgencies {
+		for _, c := range all[MaxAgencies:] {
+			r.OtherAgencies += c.Count
+		}
+		all = all[:MaxAgencies]
+	}
+	r.Agencies = append(r.Agencies, all...)
+	return r
+}
+
+// Title は集計期間の見出しを返します（1日以内の場合は開始日、それより長い場合は期間）
+func Title(since, until time.Time) string {
+	if until.Sub(since) <= 24*time.Hour {
+		return "案件ダイジェスト " + since.Format("2006/01/02")
+	}
+	return "案件ダイジェスト " + since.Format("2006/01/02") + "〜" + until.Add(-time.Second).Format("2006/01/02")
+}
+
+// bandNames は単価帯の名前を単価帯の順に返します（最後は単価不明）
+func bandNames() []string {
+	names := make([]string, 0, len(priceBands)+1)
+	for _, b := range priceBands {
+		names = append(names, b.name)
+	}
+	return append(names, UnknownPriceBand)
+}
+
+// sortedCounts は件数の多い順（同数は名前の順）に並べた件数を返します
+func sortedCounts(m map[string]int) []Count {
+	counts := make([]Count, 0, len(m))
+	for name, n := range m {
+		counts = append(counts, Count{Name: name, Count: n})
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Count != counts[j].Count {
+			return counts[i].Count > counts[j].Count
+		}
+		return counts[i].Name < counts[j].Name
+	})
+	return counts
+}
+
+func truncateDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,170 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPeriod_Range(t *testing.T) {
+	now := time.Date(2025, 6, 3, 7, 30, 0, 0, time.UTC)
+
+	since, until, err := PeriodDaily.Range(now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC), since)
+	assert.Equal(t, time.Date(2025, 6, 3, 0, 0, 0, 0, time.UTC), until)
+
+	since, _, err = PeriodWeekly.Range(now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 5, 27, 0, 0, 0, 0, time.UTC), since)
+
+	_, _, err = Period("monthly").Range(now)
+	assert.ErrorIs(t, err, ErrInvalidOptions)
+}
+
+func TestParseTime(t *testing.T) {
+	now := time.Date(2025, 6, 3, 7, 30, 0, 0, time.UTC)
+	tests := []struct {
+		value string
+		want  time.Time
+	}{
+		{value: "2025-06-01", want: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)},
+		{value: "2025-06-01 09:00", want: time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)},
+		{value: "24h", want: time.Date(2025, 6, 2, 7, 30, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		got, err := ParseTime(tt.value, now)
+		require.NoError(t, err, tt.value)
+		assert.Equal(t, tt.want, got, tt.value)
+	}
+
+	for _, value := range []string{"", "yesterday", "-24h", "2025/06/01"} {
+		_, err := ParseTime(value, now)
+		assert.ErrorIs(t, err, ErrInvalidOptions, value)
+	}
+}
+
+func TestOptions_Normalize(t *testing.T) {
+	since := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	opts, err := Options{Since: since, Until: since.AddDate(0, 0, 7)}.Normalize()
+	require.NoError(t, err)
+	assert.Equal(t, DefaultTopMatches, opts.TopMatches)
+	assert.Equal(t, float64(DefaultMinScore), opts.MinScore)
+	assert.Equal(t, 7, opts.Days())
+	assert.Equal(t, 2, Options{Since: since, Until: since.Add(25 * time.Hour)}.Days())
+
+	invalid := []Options{
+		{Since: since, Until: since},
+		{Until: since},
+		{Since: since, Until: since.AddDate(0, 0, MaxPeriodDays+1)},
+		{Since: since, Until: since.AddDate(0, 0, 1), TopMatches: MaxTopMatches + 1},
+		{Since: since, Until: since.AddDate(0, 0, 1), MinScore: 101},
+	}
+	for _, o := range invalid {
+		_, err := o.Normalize()
+		assert.ErrorIs(t, err, ErrInvalidOptions)
+	}
+}
+
+func TestPriceBand(t *testing.T) {
+	price := func(v int) *int { return &v }
+	assert.Equal(t, "〜50万円", PriceBand(nil, price(450000)))
+	assert.Equal(t, "60〜70万円", PriceBand(price(500000), price(600000)))
+	assert.Equal(t, "70〜80万円", PriceBand(price(750000), nil))
+	assert.Equal(t, "100万円〜", PriceBand(nil, price(1200000)))
+	assert.Equal(t, UnknownPriceBand, PriceBand(nil, nil))
+}
+
+func TestBuild(t *testing.T) {
+	since := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+	opts := Options{Since: since, Until: since.AddDate(0, 0, 1)}
+	now := since.AddDate(0, 0, 1).Add(7 * time.Hour)
+	price := func(v int) *int { return &v }
+	cluster := func(v uint) *uint { return &v }
+
+	projects := []Project{
+		{ProjectID: 1, SenderEmail: "a@Agency-A.example.com", Skills: []string{"Go", "Python"}, MonthlyPriceTo: price(750000), ClusterID: cluster(1)},
+		{ProjectID: 2, SenderEmail: "b@agency-b.example.com", Skills: []string{"Go"}, MonthlyPriceTo: price(780000), ClusterID: cluster(1)},
+		{ProjectID: 3, SenderEmail: "c@agency-a.example.com", Skills: []string{"Java", "Java"}, MonthlyPriceFrom: price(600000)},
+		{ProjectID: 4, SenderEmail: "d@agency-c.example.com"},
+	}
+	r := Build(projects, opts, now)
+
+	assert.Equal(t, "案件ダイジェスト 2025/06/02", r.Title)
+	assert.Equal(t, 4, r.Total)
+	assert.Equal(t, 3, r.Unique)
+	// 件数の多い順で、未分類は最後に並ぶこと（同じスキルの重複は1件と数える）
+	assert.Equal(t, []SkillCluster{
+		{Name: "Go", Count: 2, PriceBands: []Count{{Name: "70〜80万円", Count: 2}}},
+		{Name: "Java", Count: 1, PriceBands: []Count{{Name: "60〜70万円", Count: 1}}},
+		{Name: "Python", Count: 1, PriceBands: []Count{{Name: "70〜80万円", Count: 1}}},
+		{Name: UnknownSkill, Count: 1, PriceBands: []Count{{Name: UnknownPriceBand, Count: 1}}},
+	}, r.Skills)
+	assert.Equal(t, []Count{
+		{Name: "〜50万円"}, {Name: "50〜60万円"}, {Name: "60〜70万円", Count: 1}, {Name: "70〜80万円", Count: 2},
+		{Name: "80〜100万円"}, {Name: "100万円〜"}, {Name: UnknownPriceBand, Count: 1},
+	}, r.PriceBands)
+	// 営業会社はドメインを小文字で数えること
+	assert.Equal(t, []Count{{Name: "agency-a.example.com", Count: 2}, {Name: "agency-b.example.com", Count: 1}, {Name: "agency-c.example.com", Count: 1}}, r.Agencies)
+	assert.Equal(t, 3, r.AgencyCount)
+	assert.Zero(t, r.OtherAgencies)
+
+	// 上限を超える営業会社は「ほか」にまとめること
+	var many []Project
+	for i := 0; i < MaxAgencies+2; i++ {
+		many = append(many, Project{ProjectID: uint(i + 1), SenderEmail: "x@agency" + string(rune('a'+i)) + ".example.com"})
+	}
+	r = Build(many, Options{Since: since, Until: since.AddDate(0, 0, 7)}, now)
+	assert.Equal(t, "案件ダイジェスト 2025/06/02〜2025/06/08", r.Title)
+	assert.Len(t, r.Agencies, MaxAgencies)
+	assert.Equal(t, MaxAgencies+2, r.AgencyCount)
+	assert.Equal(t, 2, r.OtherAgencies)
+}
+
+func TestRender(t *testing.T) {
+	since := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+	opts := Options{Since: since, Until: since.AddDate(0, 0, 1)}
+	price := func(v int) *int { return &v }
+	r := Build([]Project{
+		{ProjectID: 1, SenderEmail: "a@agency.example.com", Skills: []string{"C#"}, MonthlyPriceFrom: price(600000), MonthlyPriceTo: price(750000)},
+	}, opts, since)
+	r.TopMatches = []EngineerMatches{{
+		Engineer: "山田",
+		Items: []MatchItem{{
+			ProjectID: 1, Title: "決済基盤 [Go] <急募>", URL: "https://mail.google.com/mail/u/0/#all/gmail-1", Score: 85.5,
+			PriceFrom: price(600000), PriceTo: price(750000), Agency: "agency.example.com", MissingMust: []string{"AWS"},
+		}},
+	}}
+
+	md, err := Render(r, FormatMarkdown)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(md, "# 案件ダイジェスト 2025/06/02\n\n集計期間: 2025/06/02 00:00 〜 2025/06/03 00:00\n新着案件: 1件（重複をまとめて1件） / 営業会社: 1社\n"))
+	assert.Contains(t, md, "\n| C# | 1 | 70〜80万円 1 |\n")
+	assert.Contains(t, md, "\n### 山田\n\n- [決済基盤 \\[Go\\] <急募>](https://mail.google.com/mail/u/0/#all/gmail-1) 適合度85.5 / 60万〜75万円 / agency.example.com（不足: AWS）\n")
+
+	text, err := Render(r, FormatText)
+	require.NoError(t, err)
+	assert.Contains(t, text, "\n[スキル別]\n  C#: 1件（70〜80万円 1）\n")
+	assert.Contains(t, text, "\n  山田\n    - 決済基盤 [Go] <急募> 適合度85.5 / 60万〜75万円 / agency.example.com（不足: AWS）\n      https://mail.google.com/mail/u/0/#all/gmail-1\n")
+
+	// HTML は値をエスケープすること
+	html, err := Render(r, FormatHTML)
+	require.NoError(t, err)
+	assert.Contains(t, html, `<a href="https://mail.google.com/mail/u/0/#all/gmail-1">決済基盤 [Go] &lt;急募&gt;</a>`)
+	assert.Contains(t, html, "<tr><td>C#</td><td align=\"right\">1</td><td>70〜80万円 1</td></tr>")
+
+	// 案件が無い期間は集計表を載せないこと
+	empty, err := Render(Build(nil, opts, since), FormatText)
+	require.NoError(t, err)
+	assert.Equal(t, "■ 案件ダイジェスト 2025/06/02\n集計期間: 2025/06/02 00:00 〜 2025/06/03 00:00\n新着案件: 0件（重複をまとめて0件） / 営業会社: 0社\n\n期間内の新着案件はありません。\n", empty)
+
+	_, err = Render(r, Format("pdf"))
+	assert.ErrorIs(t, err, ErrInvalidOptions)
+	format, err := ParseFormat("md")
+	require.NoError(t, err)
+	assert.Equal(t, FormatMarkdown, format)
+}
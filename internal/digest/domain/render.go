@@ -0,0 +1,249 @@
+// Package domain は新着案件のダイジェスト（日次・週次のまとめ）のドメインモデルを提供します。
+// このファイルはレポートを Markdown・HTML・プレーンテキストに整形するテンプレートを定義します。
+package domain
+
+import (
+	"fmt"
+	htmltemplate "html/template"
+	"strconv"
+	"strings"
+	texttemplate "text/template"
+	"time"
+)
+
+// Format はダイジェストの形式です
+type Format string
+
+const (
+	FormatMarkdown Format = "markdown" // Markdown（チャット・Wiki への貼り付け）
+	FormatHTML     Format = "html"     // HTML（メールの本文）
+	FormatText     Format = "text"     // プレーンテキスト（メールの本文・標準出力）
+)
+
+// ParseFormat は形式の名前を解釈します（md・txt の略称も受け付けます）
+func ParseFormat(name string) (Format, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "markdown", "md":
+		return FormatMarkdown, nil
+	case "html":
+		return FormatHTML, nil
+	case "text", "txt":
+		return FormatText, nil
+	}
+	return "", fmt.Errorf("%w: format は markdown / html / text で指定してください: %s", ErrInvalidOptions, name)
+}
+
+const markdownTemplate = `# {{.Title}}
+
+集計期間: {{datetime .Since}} 〜 {{datetime .Until}}
+新着案件: {{.Total}}件（重複をまとめて{{.Unique}}件） / 営業会社: {{.AgencyCount}}社
+{{- if eq .Total 0}}
+
+期間内の新着案件はありません。
+{{- else}}
+
+## スキル別
+
+| スキル | 件数 | 単価帯の内訳 |
+| --- | ---: | --- |
+{{- range .Skills}}
+| {{md .Name}} | {{.Count}} | {{bands .PriceBands}} |
+{{- end}}
+
+## 単価帯別
+
+| 単価帯 | 件数 |
+| --- | ---: |
+{{- range .PriceBands}}
+| {{.Name}} | {{.Count}} |
+{{- end}}
+
+## 営業会社別
+
+| 営業会社 | 件数 |
+| --- | ---: |
+{{- range .Agencies}}
+| {{md .Name}} | {{.Count}} |
+{{- end}}
+{{- if .OtherAgencies}}
+| ほか{{otherAgencies .}}社 | {{.OtherAgencies}} |
+{{- end}}
+{{- end}}
+{{- if .TopMatches}}
+
+## エンジニア別の注目案件
+{{- range .TopMatches}}
+
+### {{md .Engineer}}
+{{range .Items}}
+- {{if .URL}}[{{md .Title}}]({{.URL}}){{else}}{{md .Title}}{{end}} 適合度{{score .Score}} / {{price .PriceFrom .PriceTo}} / {{md .Agency}}
+{{- with .MissingMust}}（不足: {{md (join .)}}）{{end}}
+{{- end}}
+{{- end}}
+{{- end}}
+`
+
+const textTemplate = `■ {{.Title}}
+集計期間: {{datetime .Since}} 〜 {{datetime .Until}}
+新着案件: {{.Total}}件（重複をまとめて{{.Unique}}件） / 営業会社: {{.AgencyCount}}社
+{{- if eq .Total 0}}
+
+期間内の新着案件はありません。
+{{- else}}
+
+[スキル別]
+{{- range .Skills}}
+  {{.Name}}: {{.Count}}件（{{bands .PriceBands}}）
+{{- end}}
+
+[単価帯別]
+{{- range .PriceBands}}
+  {{.Name}}: {{.Count}}件
+{{- end}}
+
+[営業会社別]
+{{- range .Agencies}}
+  {{.Name}}: {{.Count}}件
+{{- end}}
+{{- if .OtherAgencies}}
+  ほか{{otherAgencies .}}社: {{.OtherAgencies}}件
+{{- end}}
+{{- end}}
+{{- if .TopMatches}}
+
+[エンジニア別の注目案件]
+{{- range .TopMatches}}
+  {{.Engineer}}
+{{- range .Items}}
+    - {{.Title}} 適合度{{score .Score}} / {{price .PriceFrom .PriceTo}} / {{.Agency}}
+{{- with .MissingMust}}（不足: {{join .}}）{{end}}
+{{- with .URL}}
+      {{.}}
+{{- end}}
+{{- end}}
+{{- end}}
+{{- end}}
+`
+
+const htmlTemplate = `<!DOCTYPE html>
+<html lang="ja">
+<head>
+<meta charset="UTF-8">
+<title>{{.Title}}</title>
+</head>
+<body style="font-family: sans-serif; color: #222;">
+<h1 style="font-size: 20px;">{{.Title}}</h1>
+<p>集計期間: {{datetime .Since}} 〜 {{datetime .Until}}<br>
+新着案件: <strong>{{.Total}}件</strong>（重複をまとめて{{.Unique}}件） / 営業会社: {{.AgencyCount}}社</p>
+{{- if eq .Total 0}}
+<p>期間内の新着案件はありません。</p>
+{{- else}}
+<h2 style="font-size: 16px;">スキル別</h2>
+<table border="1" cellpadding="4" cellspacing="0" style="border-collapse: collapse;">
+<tr><th>スキル</th><th>件数</th><th>単価帯の内訳</th></tr>
+{{- range .Skills}}
+<tr><td>{{.Name}}</td><td align="right">{{.Count}}</td><td>{{bands .PriceBands}}</td></tr>
+{{- end}}
+</table>
+<h2 style="font-size: 16px;">単価帯別</h2>
+<table border="1" cellpadding="4" cellspacing="0" style="border-collapse: collapse;">
+<tr><th>単価帯</th><th>件数</th></tr>
+{{- range .PriceBands}}
+<tr><td>{{.Name}}</td><td align="right">{{.Count}}</td></tr>
+{{- end}}
+</table>
+<h2 style="font-size: 16px;">営業会社別</h2>
+<table border="1" cellpadding="4" cellspacing="0" style="border-collapse: collapse;">
+<tr><th>営業会社</th><th>件数</th></tr>
+{{- range .Agencies}}
+<tr><td>{{.Name}}</td><td align="right">{{.Count}}</td></tr>
+{{- end}}
+{{- if .OtherAgencies}}
+<tr><td>ほか{{otherAgencies .}}社</td><td align="right">{{.OtherAgencies}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+{{- if .TopMatches}}
+<h2 style="font-size: 16px;">エンジニア別の注目案件</h2>
+{{- range .TopMatches}}
+<h3 style="font-size: 14px;">{{.Engineer}}</h3>
+<ul>
+{{- range .Items}}
+<li>{{if .URL}}<a href="{{.URL}}">{{.Title}}</a>{{else}}{{.Title}}{{end}} 適合度{{score .Score}} / {{price .PriceFrom .PriceTo}} / {{.Agency}}
+{{- with .MissingMust}}（不足: {{join .}}）{{end}}</li>
+{{- end}}
+</ul>
+{{- end}}
+{{- end}}
+</body>
+</html>
+`
+
+// renderFuncs はテンプレートで使う関数です
+var renderFuncs = map[string]interface{}{
+	"datetime":      func(t time.Time) string { return t.Format("2006/01/02 15:04") },
+	"score":         func(v float64) string { return strconv.FormatFloat(v, 'f', -1, 64) },
+	"price":         formatPrice,
+	"bands":         formatBands,
+	"join":          func(s []string) string { return strings.Join(s, ", ") },
+	"md":            escapeMarkdown,
+	"otherAgencies": func(r Report) int { return r.AgencyCount - len(r.Agencies) },
+}
+
+var (
+	markdown = texttemplate.Must(texttemplate.New("markdown").Funcs(renderFuncs).Parse(markdownTemplate))
+	text     = texttemplate.Must(texttemplate.New("text").Funcs(renderFuncs).Parse(textTemplate))
+	html     = htmltemplate.Must(htmltemplate.New("html").Funcs(renderFuncs).Parse(htmlTemplate))
+)
+
+// Render はレポートを形式のテンプレートで整形します
+func Render(r Report, format Format) (string, error) {
+	var b strings.Builder
+	var err error
+	switch format {
+	case FormatMarkdown:
+		err = markdown.Execute(&b, r)
+	case FormatHTML:
+		err = html.Execute(&b, r)
+	case FormatText:
+		err = text.Execute(&b, r)
+	default:
+		return "", fmt.Errorf("%w: 未対応の形式です: %s", ErrInvalidOptions, format)
+	}
+	if err != nil {
+		return "", fmt.Errorf("ダイジェストの整形エラー: %w", err)
+	}
+	return b.String(), nil
+}
+
+// formatPrice は単価の範囲を万円で表示します（"60万〜75万円"。未記載は "単価不明"）
+func formatPrice(from, to *int) string {
+	man := func(v int) string { return strconv.FormatFloat(float64(v)/10000, 'f', -1, 64) + "万" }
+	switch {
+	case from != nil && to != nil && *from == *to:
+		return man(*from) + "円"
+	case from != nil && to != nil:
+		return man(*from) + "〜" + man(*to) + "円"
+	case from != nil:
+		return man(*from) + "円〜"
+	case to != nil:
+		return "〜" + man(*to) + "円"
+	}
+	return UnknownPriceBand
+}
+
+// formatBands は単価帯の内訳を "60〜70万円 5 / 70〜80万円 10" の形式で表示します
+func formatBands(bands []Count) string {
+	parts := make([]string, 0, len(bands))
+	for _, b := range bands {
+		parts = append(parts, b.Name+" "+strconv.Itoa(b.Count))
+	}
+	return strings.Join(parts, " / ")
+}
+
+// markdownEscaper は Markdown の表・リンクを崩す記号をエスケープします
+var markdownEscaper = strings.NewReplacer(`\`, `\\`, "|", `\|`, "[", `\[`, "]", `\]`, "*", `\*`, "_", `\_`, "`", "\\`", "\n", " ")
+
+func escapeMarkdown(s string) string {
+	return markdownEscaper.Replace(s)
+}
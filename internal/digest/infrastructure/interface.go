@@ -0,0 +1,15 @@
+// Package infrastructure は新着案件のダイジェスト機能のインフラストラクチャ層を提供します。
+// このファイルはダイジェストで使用するリポジトリのインターフェースを定義します。
+package infrastructure
+
+import (
+	"business/internal/digest/domain"
+	"time"
+)
+
+// RepositoryInterface は新着案件のダイジェストのリポジトリインターフェースです
+type RepositoryInterface interface {
+	// ListProjects は受信日が since 以降 until より前のアーカイブしていない案件を、言語のキーワードグループ付きで受信日順に返します
+	// ミュート・ブロックした営業会社（agencies.rule）の案件は含めません。
+	ListProjects(since, until time.Time) ([]domain.Project, error)
+}
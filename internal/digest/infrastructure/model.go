@@ -0,0 +1,27 @@
+// Package infrastructure は新着案件のダイジェスト機能のインフラストラクチャ層を提供します。
+// このファイルはダイジェストで参照するクエリの結果の行を定義します。
+package infrastructure
+
+import (
+	"time"
+)
+
+// projectRow は集計する案件の列です
+type projectRow struct {
+	ProjectID        uint
+	GmailID          string
+	Subject          string
+	ProjectTitle     *string
+	SenderEmail      string
+	ReceivedDate     time.Time
+	MonthlyPriceFrom *int
+	MonthlyPriceTo   *int
+	RemoteType       *string
+	ClusterID        *uint
+}
+
+// skillRow は案件に紐づく言語のキーワードグループの列です
+type skillRow struct {
+	EmailProjectID uint
+	Name           string
+}
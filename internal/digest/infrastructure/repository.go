@@ -0,0 +1,90 @@
+// Package infrastructure は新着案件のダイジェスト機能のインフラストラクチャ層を提供します。
+// このファイルは期間内の案件の参照を実装します。
+package infrastructure
+
+import (
+	"business/internal/digest/domain"
+	"fmt"
+	"time"
+
+	"github.com/samber/lo"
+	"gorm.io/gorm"
+)
+
+// queryChunkSize は IN 句にまとめる案件IDの件数です
+const queryChunkSize = 1000
+
+// Repository は新着案件のダイジェストのリポジトリ実装です
+type Repository struct {
+	db *gorm.DB
+}
+
+// New は新着案件のダイジェストのリポジトリを作成します
+func New(db *gorm.DB) *Repository {
+	return &Repository{
+		db: db,
+	}
+}
+
+// ListProjects は受信日が since 以降 until より前のアーカイブしていない案件を、言語のキーワードグループ付きで受信日順に返します
+// ミュート・ブロックした営業会社（agencies.rule）の案件は含めません。
+// スキルは用語辞書で表記ゆれをまとめたキーワードグループの名前です（"Golang" と "Go" は同じスキルとして数えます）。
+func (r *Repository) ListProjects(since, until time.Time) ([]domain.Project, error) {
+	var rows []projectRow
+	err := r.db.Table("email_projects ep").
+		Select(`ep.id AS project_id, e.gmail_id, e.subject, ep.project_title, e.sender_email, e.received_date,
+			ep.monthly_price_from, ep.monthly_price_to, ep.remote_type, m.cluster_id`).
+		Joins("JOIN emails e ON e.id = ep.email_id").
+		Joins("LEFT JOIN project_cluster_members m ON m.email_project_id = ep.id").
+		Where("e.received_date >= ? AND e.received_date < ? AND ep.archived_at IS NULL", since, until).
+		Where(`NOT EXISTS (SELECT 1 FROM agencies ag
+			WHERE ag.domain = LOWER(SUBSTRING_INDEX(e.sender_email, '@', -1)) AND ag.rule IN ?)`, []string{"mute", "block"}).
+		Order("e.received_date, ep.id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("案件取得エラー: %w", err)
+	}
+
+	projects := make([]domain.Project, 0, len(rows))
+	index := make(map[uint]int, len(rows))
+	for _, row := range rows {
+		index[row.ProjectID] = len(projects)
+		projects = append(projects, domain.Project{
+			ProjectID:        row.ProjectID,
+			GmailID:          row.GmailID,
+			Subject:          row.Subject,
+			ProjectTitle:     derefString(row.ProjectTitle),
+			SenderEmail:      row.SenderEmail,
+			ReceivedDate:     row.ReceivedDate,
+			MonthlyPriceFrom: row.MonthlyPriceFrom,
+			MonthlyPriceTo:   row.MonthlyPriceTo,
+			RemoteType:       derefString(row.RemoteType),
+			ClusterID:        row.ClusterID,
+		})
+	}
+
+	for _, ids := range lo.Chunk(lo.Keys(index), queryChunkSize) {
+		var skills []skillRow
+		err := r.db.Table("email_keyword_groups ekg").
+			Select("DISTINCT ekg.email_project_id, kg.name").
+			Joins("JOIN keyword_groups kg ON kg.keyword_group_id = ekg.keyword_group_id").
+			Where("ekg.email_project_id IN ? AND kg.type = ?", ids, "language").
+			Order("ekg.email_project_id, kg.name").
+			Scan(&skills).Error
+		if err != nil {
+			return nil, fmt.Errorf("スキル取得エラー: %w", err)
+		}
+		for _, s := range skills {
+			p := &projects[index[s.EmailProjectID]]
+			p.Skills = append(p.Skills, s.Name)
+		}
+	}
+	return projects, nil
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
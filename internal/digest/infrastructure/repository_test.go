@@ -0,0 +1,84 @@
+package infrastructure
+
+import (
+	"business/tools/migrations/model"
+	"business/tools/mysql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_ListProjects(t *testing.T) {
+	t.Parallel()
+	// テスト用DBの準備
+	db, cleanup, err := mysql.CreateNewTestDB()
+	require.NoError(t, err)
+	defer cleanup()
+
+	err = db.DB.AutoMigrate(
+		model.Email{},
+		model.EmailProject{},
+		model.KeywordGroup{},
+		model.EmailKeywordGroup{},
+		model.ProjectCluster{},
+		model.ProjectClusterMember{},
+		model.Agency{},
+	)
+	require.NoError(t, err)
+
+	since := time.Date(2025, 6, 2, 0, 0, 0, 0, time.Local)
+	emails := []model.Email{
+		{GmailID: "gmail-1", Subject: "Go案件", SenderEmail: "a@agency.example.com", ReceivedDate: since.Add(9 * time.Hour), Category: "案件"},
+		{GmailID: "gmail-2", Subject: "前日の案件", SenderEmail: "b@agency.example.com", ReceivedDate: since.Add(-time.Hour), Category: "案件"},
+	}
+	require.NoError(t, db.DB.Create(&emails).Error)
+	archivedAt := since
+	price := 700000
+	projects := []model.EmailProject{
+		{EmailID: emails[0].ID, ProjectKey: "p1", MonthlyPriceTo: &price},
+		{EmailID: emails[0].ID, ProjectKey: "p2", ArchivedAt: &archivedAt},
+		{EmailID: emails[1].ID, ProjectKey: "p3"},
+	}
+	require.NoError(t, db.DB.Create(&projects).Error)
+
+	groups := []model.KeywordGroup{{Name: "Go", Type: "language"}, {Name: "Gin", Type: "framework"}, {Name: "Python", Type: "language"}}
+	require.NoError(t, db.DB.Create(&groups).Error)
+	links := []model.EmailKeywordGroup{
+		{EmailProjectID: projects[0].ID, KeywordGroupID: groups[2].KeywordGroupID},
+		{EmailProjectID: projects[0].ID, KeywordGroupID: groups[0].KeywordGroupID},
+		{EmailProjectID: projects[0].ID, KeywordGroupID: groups[1].KeywordGroupID},
+	}
+	require.NoError(t, db.DB.Create(&links).Error)
+	cluster := model.ProjectCluster{RepresentativeProjectID: projects[0].ID, Size: 2, AgencyCount: 2, FirstReceivedAt: since, LastReceivedAt: since}
+	require.NoError(t, db.DB.Create(&cluster).Error)
+	require.NoError(t, db.DB.Create(&model.ProjectClusterMember{ClusterID: cluster.ID, EmailProjectID: projects[0].ID}).Error)
+
+	repo := New(db.DB)
+
+	// 期間内のアーカイブしていない案件に、言語のキーワードグループと重複グループを付けること
+	got, err := repo.ListProjects(since, since.AddDate(0, 0, 1))
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, projects[0].ID, got[0].ProjectID)
+	assert.Equal(t, "gmail-1", got[0].GmailID)
+	assert.Equal(t, []string{"Go", "Python"}, got[0].Skills)
+	assert.Equal(t, 700000, *got[0].MonthlyPriceTo)
+	require.NotNil(t, got[0].ClusterID)
+	assert.Equal(t, cluster.ID, *got[0].ClusterID)
+
+	// 期間を広げると受信日順に返すこと
+	got, err = repo.ListProjects(since.AddDate(0, 0, -1), since.AddDate(0, 0, 1))
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, projects[2].ID, got[0].ProjectID)
+	assert.Nil(t, got[0].ClusterID)
+	assert.Empty(t, got[0].Skills)
+
+	// ブロックした営業会社の案件は含めないこと
+	require.NoError(t, db.DB.Create(&model.Agency{Domain: "agency.example.com", Rule: "block"}).Error)
+	got, err = repo.ListProjects(since.AddDate(0, 0, -1), since.AddDate(0, 0, 1))
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
@@ -5,34 +5,131 @@ package application
 import (
 	cd "business/internal/common/domain"
 	r "business/internal/emailstore/infrastructure"
+	"business/tools/keyword"
+	"business/tools/oswrapper"
 	"errors"
 	"fmt"
+	"strconv"
 
 	"gorm.io/gorm"
 )
 
+// DefaultEmailsPerTransaction は1トランザクションで保存するメール数の既定値です
+const DefaultEmailsPerTransaction = 50
+
+// AfterSaveHook はメール分析結果の保存後に実行する処理です（新着案件の通知など）
+type AfterSaveHook interface {
+	// AfterSave はメール分析結果の保存後に実行されます
+	AfterSave() error
+}
+
 // UseCase はメール保存のユースケースの具象です
 type UseCase struct {
-	r r.RepositoryInterface
+	r     r.RepositoryInterface
+	os    oswrapper.OsWapperInterface
+	hooks []AfterSaveHook
 }
 
 // New はメール保存ユースケースを作成します
-func New(r r.RepositoryInterface) *UseCase {
+// hooks はメール分析結果を保存した後に順に実行します。
+func New(r r.RepositoryInterface, os oswrapper.OsWapperInterface, hooks ...AfterSaveHook) *UseCase {
 	return &UseCase{
-		r: r,
+		r:     r,
+		os:    os,
+		hooks: hooks,
 	}
 }
 
 // SaveEmailAnalysisResult はメール分析結果を保存します
 func (u *UseCase) SaveEmailAnalysisResult(result cd.Email) error {
+	normalized, err := u.normalizeKeywords([]cd.Email{result})
+	if err != nil {
+		return fmt.Errorf("メール保存エラー: %w", err)
+	}
+
 	// リポジトリを使用してメールを保存
-	if err := u.r.SaveEmail(result); err != nil {
+	if err := u.r.SaveEmail(normalized[0]); err != nil {
 		return fmt.Errorf("メール保存エラー: %w", err)
 	}
 
+	u.afterSave()
 	return nil
 }
 
+// SaveEmailAnalysisResults は複数のメール分析結果を、一定のメール数ごとのトランザクションで保存します
+// 同じGメールIDの解析結果（1通に載った複数の案件）は同じトランザクションで保存します。
+// 途中のトランザクションで失敗した場合、それまでに保存したメールはそのまま残り、保存したメール数とエラーを返します。
+func (u *UseCase) SaveEmailAnalysisResults(results []cd.Email) (int, error) {
+	normalized, err := u.normalizeKeywords(results)
+	if err != nil {
+		return 0, fmt.Errorf("メール保存エラー: %w", err)
+	}
+
+	saved := 0
+	for _, chunk := range chunkByEmail(normalized, u.EmailsPerTransaction()) {
+		if err := u.r.SaveEmails(chunk.results); err != nil {
+			if saved > 0 {
+				u.afterSave()
+			}
+			return saved, fmt.Errorf("メール保存エラー: %w", err)
+		}
+		saved += chunk.emails
+	}
+
+	if saved > 0 {
+		u.afterSave()
+	}
+	return saved, nil
+}
+
+// afterSave は保存後の処理を実行します
+// 保存後の処理の失敗で保存を失敗扱いにしないため、エラーは出力するだけにします。
+func (u *UseCase) afterSave() {
+	for _, hook := range u.hooks {
+		if err := hook.AfterSave(); err != nil {
+			fmt.Printf("保存後の処理エラー: %v\n", err)
+		}
+	}
+}
+
+// EmailsPerTransaction は1トランザクションで保存するメール数を返します
+// 環境変数 EMAILS_PER_TRANSACTION で変更できます。
+func (u *UseCase) EmailsPerTransaction() int {
+	if v, err := strconv.Atoi(u.os.GetEnv("EMAILS_PER_TRANSACTION")); err == nil && v > 0 {
+		return v
+	}
+	return DefaultEmailsPerTransaction
+}
+
+// emailChunk は1トランザクションで保存する解析結果とメール数です
+type emailChunk struct {
+	results []cd.Email
+	emails  int
+}
+
+// chunkByEmail は解析結果をGメールIDの出現順にまとめ、size 通ごとに分割します
+func chunkByEmail(results []cd.Email, size int) []emailChunk {
+	var order []string
+	byGmailID := make(map[string][]cd.Email)
+	for _, result := range results {
+		if _, ok := byGmailID[result.GmailID]; !ok {
+			order = append(order, result.GmailID)
+		}
+		byGmailID[result.GmailID] = append(byGmailID[result.GmailID], result)
+	}
+
+	var chunks []emailChunk
+	for start := 0; start < len(order); start += size {
+		end := min(start+size, len(order))
+		chunk := emailChunk{emails: end - start}
+		for _, gmailID := range order[start:end] {
+			chunk.results = append(chunk.results, byGmailID[gmailID]...)
+		}
+		chunks = append(chunks, chunk)
+	}
+	return chunks
+}
+
 // GetEmailByGmailIds はメールIDリストを返却します
 func (u *UseCase) GetEmailByGmailIds(emailIdList []string) ([]string, error) {
 	if len(emailIdList) == 0 {
@@ -46,3 +143,42 @@ func (u *UseCase) GetEmailByGmailIds(emailIdList []string) ([]string, error) {
 
 	return exists, nil
 }
+
+// ReplaceEmailAnalysisResults はGメールIDに紐づく解析結果を置き換えます
+// 置き換えで追加された案件の通知や集計の更新のため、保存後の処理も実行します。
+func (u *UseCase) ReplaceEmailAnalysisResults(gmailID string, results []cd.Email) error {
+	if gmailID == "" {
+		return fmt.Errorf("メール置換エラー: %w", r.ErrInvalidEmailData)
+	}
+
+	normalized, err := u.normalizeKeywords(results)
+	if err != nil {
+		return fmt.Errorf("メール置換エラー: %w", err)
+	}
+
+	if err := u.r.ReplaceEmails(gmailID, normalized); err != nil {
+		return fmt.Errorf("メール置換エラー: %w", err)
+	}
+
+	u.afterSave()
+	return nil
+}
+
+// normalizeKeywords は解析結果の言語・フレームワーク・スキルを別名ルールで正規化したコピーを返します
+// 表記ゆれ（例: "ＪＡＶＡ", "java ", "Java(Spring)"）を同じキーワードグループに保存するため、保存前に適用します。
+func (u *UseCase) normalizeKeywords(results []cd.Email) ([]cd.Email, error) {
+	n, err := keyword.Load(u.os)
+	if err != nil {
+		return nil, err
+	}
+
+	normalized := make([]cd.Email, len(results))
+	for i, result := range results {
+		result.Languages = n.NormalizeAll(result.Languages)
+		result.Frameworks = n.NormalizeAll(result.Frameworks)
+		result.RequiredSkillsMust = n.NormalizeAll(result.RequiredSkillsMust)
+		result.RequiredSkillsWant = n.NormalizeAll(result.RequiredSkillsWant)
+		normalized[i] = result
+	}
+	return normalized, nil
+}
@@ -19,15 +19,42 @@ func (m *MockEmailStoreRepository) SaveEmail(result cd.Email) error {
 	return args.Error(0)
 }
 
+func (m *MockEmailStoreRepository) SaveEmails(results []cd.Email) error {
+	args := m.Called(results)
+	return args.Error(0)
+}
+
 func (m *MockEmailStoreRepository) GetEmailByGmailIds(gmailIds []string) ([]string, error) {
 	args := m.Called(gmailIds)
 	return args.Get(0).([]string), args.Error(1)
 }
 
+func (m *MockEmailStoreRepository) ReplaceEmails(gmailID string, results []cd.Email) error {
+	args := m.Called(gmailID, results)
+	return args.Error(0)
+}
+
+// モック: oswrapper
+type mockOsWrapper struct {
+	env   map[string]string
+	files map[string]string
+}
+
+func (m *mockOsWrapper) ReadFile(path string) (string, error) {
+	if text, ok := m.files[path]; ok {
+		return text, nil
+	}
+	return "", errors.New("file not found")
+}
+
+func (m *mockOsWrapper) GetEnv(key string) string {
+	return m.env[key]
+}
+
 // テスト: SaveEmailAnalysisResult 成功時
 func TestSaveEmailAnalysisResult_Success(t *testing.T) {
 	mockRepo := new(MockEmailStoreRepository)
-	usecase := New(mockRepo)
+	usecase := New(mockRepo, &mockOsWrapper{})
 
 	email := cd.Email{GmailID: "test@gmail.com"}
 	mockRepo.On("SaveEmail", email).Return(nil)
@@ -40,7 +67,7 @@ func TestSaveEmailAnalysisResult_Success(t *testing.T) {
 // テスト: SaveEmailAnalysisResult エラー時
 func TestSaveEmailAnalysisResult_Error(t *testing.T) {
 	mockRepo := new(MockEmailStoreRepository)
-	usecase := New(mockRepo)
+	usecase := New(mockRepo, &mockOsWrapper{})
 
 	email := cd.Email{GmailID: "test@gmail.com"}
 	mockRepo.On("SaveEmail", email).Return(errors.New("db error"))
@@ -51,10 +78,40 @@ func TestSaveEmailAnalysisResult_Error(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+// モック: 保存後の処理
+type mockAfterSaveHook struct {
+	calls int
+	err   error
+}
+
+func (m *mockAfterSaveHook) AfterSave() error {
+	m.calls++
+	return m.err
+}
+
+// テスト: SaveEmailAnalysisResult 保存後の処理（失敗しても保存は成功扱い、保存に失敗した場合は実行しない）
+func TestSaveEmailAnalysisResult_AfterSaveHook(t *testing.T) {
+	mockRepo := new(MockEmailStoreRepository)
+	hook := &mockAfterSaveHook{err: errors.New("notify error")}
+	usecase := New(mockRepo, &mockOsWrapper{}, hook)
+
+	saved := cd.Email{GmailID: "saved"}
+	failed := cd.Email{GmailID: "failed"}
+	mockRepo.On("SaveEmail", saved).Return(nil)
+	mockRepo.On("SaveEmail", failed).Return(errors.New("db error"))
+
+	assert.NoError(t, usecase.SaveEmailAnalysisResult(saved))
+	assert.Equal(t, 1, hook.calls)
+
+	assert.Error(t, usecase.SaveEmailAnalysisResult(failed))
+	assert.Equal(t, 1, hook.calls)
+	mockRepo.AssertExpectations(t)
+}
+
 // テスト: GetEmailByGmailIds 成功時
 func TestGetEmailByGmailIds_Success(t *testing.T) {
 	mockRepo := new(MockEmailStoreRepository)
-	usecase := New(mockRepo)
+	usecase := New(mockRepo, &mockOsWrapper{})
 
 	emailIds := []string{"gmail-id-1", "gmail-id-2"}
 	expectedResult := []string{"gmail-id-1"}
@@ -69,7 +126,7 @@ func TestGetEmailByGmailIds_Success(t *testing.T) {
 // テスト: GetEmailByGmailIds エラー時
 func TestGetEmailByGmailIds_Error(t *testing.T) {
 	mockRepo := new(MockEmailStoreRepository)
-	usecase := New(mockRepo)
+	usecase := New(mockRepo, &mockOsWrapper{})
 
 	emailIds := []string{"gmail-id-1"}
 	mockRepo.On("GetEmailByGmailIds", emailIds).Return([]string{}, errors.New("db error"))
@@ -84,9 +141,139 @@ func TestGetEmailByGmailIds_Error(t *testing.T) {
 // テスト: GetEmailByGmailIds 空のリスト
 func TestGetEmailByGmailIds_EmptyList(t *testing.T) {
 	mockRepo := new(MockEmailStoreRepository)
-	usecase := New(mockRepo)
+	usecase := New(mockRepo, &mockOsWrapper{})
 
 	result, err := usecase.GetEmailByGmailIds([]string{})
 	assert.Empty(t, err)
 	assert.Empty(t, result)
 }
+
+// テスト: ReplaceEmailAnalysisResults 成功時（保存後の処理も実行する）
+func TestReplaceEmailAnalysisResults_Success(t *testing.T) {
+	mockRepo := new(MockEmailStoreRepository)
+	hook := &mockAfterSaveHook{}
+	usecase := New(mockRepo, &mockOsWrapper{}, hook)
+
+	results := []cd.Email{{GmailID: "gmail-id-1", AnalysisRevision: 2}}
+	mockRepo.On("ReplaceEmails", "gmail-id-1", results).Return(nil)
+
+	err := usecase.ReplaceEmailAnalysisResults("gmail-id-1", results)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, hook.calls)
+	mockRepo.AssertExpectations(t)
+}
+
+// テスト: ReplaceEmailAnalysisResults GメールIDが空の場合
+func TestReplaceEmailAnalysisResults_EmptyGmailID(t *testing.T) {
+	mockRepo := new(MockEmailStoreRepository)
+	usecase := New(mockRepo, &mockOsWrapper{})
+
+	err := usecase.ReplaceEmailAnalysisResults("", []cd.Email{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "メール置換エラー")
+	mockRepo.AssertNotCalled(t, "ReplaceEmails")
+}
+
+// テスト: ReplaceEmailAnalysisResults エラー時
+func TestReplaceEmailAnalysisResults_Error(t *testing.T) {
+	mockRepo := new(MockEmailStoreRepository)
+	hook := &mockAfterSaveHook{}
+	usecase := New(mockRepo, &mockOsWrapper{}, hook)
+
+	mockRepo.On("ReplaceEmails", "gmail-id-1", []cd.Email{}).Return(errors.New("db error"))
+
+	err := usecase.ReplaceEmailAnalysisResults("gmail-id-1", []cd.Email{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "メール置換エラー")
+	assert.Zero(t, hook.calls)
+	mockRepo.AssertExpectations(t)
+}
+
+// テスト: SaveEmailAnalysisResults 指定したメール数ごとにトランザクションを分ける
+func TestSaveEmailAnalysisResults_Chunked(t *testing.T) {
+	mockRepo := new(MockEmailStoreRepository)
+	usecase := New(mockRepo, &mockOsWrapper{env: map[string]string{"EMAILS_PER_TRANSACTION": "2"}})
+
+	// gmail-1 は2件の案件が載ったメール
+	results := []cd.Email{
+		{GmailID: "gmail-1", ProjectName: "案件A"},
+		{GmailID: "gmail-2"},
+		{GmailID: "gmail-1", ProjectName: "案件B"},
+		{GmailID: "gmail-3"},
+	}
+	mockRepo.On("SaveEmails", []cd.Email{results[0], results[2], results[1]}).Return(nil).Once()
+	mockRepo.On("SaveEmails", []cd.Email{results[3]}).Return(nil).Once()
+
+	saved, err := usecase.SaveEmailAnalysisResults(results)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, saved)
+	mockRepo.AssertExpectations(t)
+}
+
+// テスト: SaveEmailAnalysisResults 途中で失敗した場合は保存済みのメール数を返す
+func TestSaveEmailAnalysisResults_Error(t *testing.T) {
+	mockRepo := new(MockEmailStoreRepository)
+	usecase := New(mockRepo, &mockOsWrapper{env: map[string]string{"EMAILS_PER_TRANSACTION": "1"}})
+
+	results := []cd.Email{{GmailID: "gmail-1"}, {GmailID: "gmail-2"}, {GmailID: "gmail-3"}}
+	mockRepo.On("SaveEmails", results[:1]).Return(nil).Once()
+	mockRepo.On("SaveEmails", results[1:2]).Return(errors.New("db error")).Once()
+
+	saved, err := usecase.SaveEmailAnalysisResults(results)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "メール保存エラー")
+	assert.Equal(t, 1, saved)
+	mockRepo.AssertExpectations(t)
+}
+
+// テスト: EmailsPerTransaction 未設定や不正な値の場合は既定値
+func TestEmailsPerTransaction(t *testing.T) {
+	for _, v := range []string{"", "0", "-1", "abc"} {
+		usecase := New(new(MockEmailStoreRepository), &mockOsWrapper{env: map[string]string{"EMAILS_PER_TRANSACTION": v}})
+		assert.Equal(t, DefaultEmailsPerTransaction, usecase.EmailsPerTransaction(), v)
+	}
+
+	usecase := New(new(MockEmailStoreRepository), &mockOsWrapper{env: map[string]string{"EMAILS_PER_TRANSACTION": "10"}})
+	assert.Equal(t, 10, usecase.EmailsPerTransaction())
+}
+
+// テスト: SaveEmailAnalysisResults キーワードを正規化してから保存する
+func TestSaveEmailAnalysisResults_NormalizeKeywords(t *testing.T) {
+	mockRepo := new(MockEmailStoreRepository)
+	usecase := New(mockRepo, &mockOsWrapper{files: map[string]string{
+		"/data/dictionary/keyword_aliases.txt": "JavaScript: JS\nGo: Golang",
+	}})
+
+	results := []cd.Email{{
+		GmailID:            "gmail-1",
+		Languages:          []string{"ＪＡＶＡ", "java ", "JS"},
+		Frameworks:         []string{"Spring Boot 3.x系"},
+		RequiredSkillsMust: []string{"golang", "Java(Spring)"},
+		RequiredSkillsWant: []string{" AWS "},
+	}}
+	expected := []cd.Email{{
+		GmailID:            "gmail-1",
+		Languages:          []string{"JAVA", "JavaScript"},
+		Frameworks:         []string{"Spring Boot"},
+		RequiredSkillsMust: []string{"Go", "Java"},
+		RequiredSkillsWant: []string{"AWS"},
+	}}
+	mockRepo.On("SaveEmails", expected).Return(nil).Once()
+
+	saved, err := usecase.SaveEmailAnalysisResults(results)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, saved)
+	// 引数の解析結果は変更しない
+	assert.Equal(t, []string{"ＪＡＶＡ", "java ", "JS"}, results[0].Languages)
+	mockRepo.AssertExpectations(t)
+}
+
+// テスト: SaveEmailAnalysisResults 指定した別名ルールファイルが読めない場合は保存しない
+func TestSaveEmailAnalysisResults_RulesError(t *testing.T) {
+	mockRepo := new(MockEmailStoreRepository)
+	usecase := New(mockRepo, &mockOsWrapper{env: map[string]string{"KEYWORD_ALIASES_PATH": "/tmp/none.txt"}})
+
+	_, err := usecase.SaveEmailAnalysisResults([]cd.Email{{GmailID: "gmail-1"}})
+	assert.Error(t, err)
+	mockRepo.AssertNotCalled(t, "SaveEmails", mock.Anything)
+}
@@ -4,6 +4,7 @@ package application
 
 import (
 	cd "business/internal/common/domain"
+	"business/internal/emailstore/domain"
 )
 
 // UseCaseInterface はメール保存のユースケースインターフェースです
@@ -11,6 +12,51 @@ type UseCaseInterface interface {
 	// SaveEmailAnalysisResult はメール分析結果を保存します
 	SaveEmailAnalysisResult(result cd.Email) error
 
+	// SaveEmailAnalysisResults は複数のメール分析結果を一定のメール数ごとのトランザクションで保存し、保存したメール数を返します
+	SaveEmailAnalysisResults(results []cd.Email) (int, error)
+
 	// GetEmailByGmailIds はGメールIDリストを返却します。
 	GetEmailByGmailIds(gmailId []string) ([]string, error)
+
+	// ReplaceEmailAnalysisResults はGメールIDに紐づく解析結果を置き換えます
+	ReplaceEmailAnalysisResults(gmailID string, results []cd.Email) error
+}
+
+// ProjectQueryUseCaseInterface は保存済み案件の検索ユースケースインターフェースです
+type ProjectQueryUseCaseInterface interface {
+	// SearchProjects は条件に一致する案件を1ページ分返します
+	SearchProjects(q domain.ProjectQuery) (domain.ProjectPage, error)
+
+	// SearchText は件名・本文・案件名を全文検索し、関連度順に1ページ分返します
+	SearchText(q domain.ProjectQuery) (domain.ProjectPage, error)
+}
+
+// TriageUseCaseInterface はメールの仕分けユースケースインターフェースです
+type TriageUseCaseInterface interface {
+	// Triage は指定したメールに仕分けの更新内容を反映し、更新後の値を返します
+	Triage(gmailIDs []string, t domain.Triage) ([]domain.TriageState, error)
+
+	// SetStatus は指定した案件の応募状況を更新し、更新後の値を返します
+	SetStatus(projectIDs []uint, s domain.StatusUpdate) ([]domain.ProjectStatus, error)
+
+	// StatusHistory は案件の応募状況の変更履歴を古い順に返します
+	StatusHistory(projectID uint) ([]domain.StatusChange, error)
+}
+
+// PeriodUseCaseInterface は入場時期・終了時期の変換ユースケースインターフェースです
+type PeriodUseCaseInterface interface {
+	// BackfillPeriods は保存済みの入場時期・終了時期を受信日を基準に日付の範囲へ変換します
+	BackfillPeriods(batchSize int) (domain.PeriodBackfillResult, error)
+}
+
+// PriceUseCaseInterface は単価の変換ユースケースインターフェースです
+type PriceUseCaseInterface interface {
+	// BackfillPrices は保存済みの単価を単位・税・精算幅付きで解釈し、税別の月額に換算します
+	BackfillPrices(batchSize int) (domain.PriceBackfillResult, error)
+}
+
+// LocationUseCaseInterface は勤務地の変換ユースケースインターフェースです
+type LocationUseCaseInterface interface {
+	// BackfillLocations は保存済みの勤務場所を都道府県・市区町村・最寄り駅の拠点に変換します
+	BackfillLocations(batchSize int) (domain.LocationBackfillResult, error)
 }
@@ -0,0 +1,77 @@
+package application
+
+import (
+	"business/internal/emailstore/domain"
+	r "business/internal/emailstore/infrastructure"
+	"business/tools/location"
+	"fmt"
+	"strings"
+)
+
+// LocationUseCase は勤務地の変換ユースケースの具象です
+type LocationUseCase struct {
+	r r.LocationRepositoryInterface
+}
+
+// NewLocation は勤務地の変換ユースケースを作成します
+func NewLocation(r r.LocationRepositoryInterface) *LocationUseCase {
+	return &LocationUseCase{
+		r: r,
+	}
+}
+
+// BackfillLocations は保存済みの勤務場所を都道府県・市区町村・最寄り駅の拠点に変換します
+// 案件をID順に batchSize 件ずつ読み込み、1バッチの拠点を1トランザクションで置き換えます。
+// 辞書で解釈できない勤務場所は拠点を空にするため、何度実行しても同じ結果になります。
+func (u *LocationUseCase) BackfillLocations(batchSize int) (domain.LocationBackfillResult, error) {
+	if batchSize <= 0 {
+		batchSize = domain.DefaultLocationBatchSize
+	}
+
+	result := domain.LocationBackfillResult{}
+	var afterID uint
+	for {
+		projects, err := u.r.ListProjectLocations(afterID, batchSize)
+		if err != nil {
+			return result, fmt.Errorf("勤務地の変換エラー: %w", err)
+		}
+		if len(projects) == 0 {
+			return result, nil
+		}
+
+		for i := range projects {
+			p := &projects[i]
+			p.Locations = normalizeLocation(p.WorkLocation)
+			if len(p.Locations) == 0 && strings.TrimSpace(p.WorkLocation) != "" {
+				result.Unresolved++
+			}
+			result.Locations += len(p.Locations)
+			afterID = p.ProjectID
+		}
+		if err := u.r.SaveProjectLocations(projects); err != nil {
+			return result, fmt.Errorf("勤務地の変換エラー: %w", err)
+		}
+		result.Projects += len(projects)
+
+		if len(projects) < batchSize {
+			return result, nil
+		}
+	}
+}
+
+// normalizeLocation は勤務場所の表記を拠点に変換します
+func normalizeLocation(text string) []domain.Location {
+	locs := location.Normalize(text)
+	result := make([]domain.Location, 0, len(locs))
+	for _, l := range locs {
+		result = append(result, domain.Location{
+			Prefecture: l.Prefecture,
+			City:       l.City,
+			Station:    l.Station,
+			Lat:        l.Lat,
+			Lng:        l.Lng,
+			Remote:     l.Remote,
+		})
+	}
+	return result
+}
@@ -0,0 +1,53 @@
+package application
+
+import (
+	"business/internal/emailstore/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockLocationRepository の定義
+type MockLocationRepository struct {
+	mock.Mock
+}
+
+func (m *MockLocationRepository) ListProjectLocations(afterID uint, limit int) ([]domain.ProjectLocations, error) {
+	args := m.Called(afterID, limit)
+	return args.Get(0).([]domain.ProjectLocations), args.Error(1)
+}
+
+func (m *MockLocationRepository) SaveProjectLocations(projects []domain.ProjectLocations) error {
+	args := m.Called(projects)
+	return args.Error(0)
+}
+
+// テスト: 勤務場所を拠点に変換し、バッチごとに保存すること
+func TestBackfillLocations(t *testing.T) {
+	mockRepo := new(MockLocationRepository)
+	usecase := NewLocation(mockRepo)
+
+	mockRepo.On("ListProjectLocations", uint(0), 2).Return([]domain.ProjectLocations{
+		{ProjectID: 1, WorkLocation: "渋谷 or 新宿"},
+		{ProjectID: 2, WorkLocation: "要相談"},
+	}, nil)
+	mockRepo.On("ListProjectLocations", uint(2), 2).Return([]domain.ProjectLocations{
+		{ProjectID: 5, WorkLocation: ""},
+	}, nil)
+	mockRepo.On("SaveProjectLocations", mock.Anything).Return(nil)
+
+	result, err := usecase.BackfillLocations(2)
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.LocationBackfillResult{Projects: 3, Locations: 2, Unresolved: 1}, result)
+	mockRepo.AssertNumberOfCalls(t, "SaveProjectLocations", 2)
+
+	saved := mockRepo.Calls[1].Arguments.Get(0).([]domain.ProjectLocations)
+	require.Len(t, saved[0].Locations, 2)
+	assert.Equal(t, "東京都", saved[0].Locations[0].Prefecture)
+	assert.Equal(t, "渋谷区", saved[0].Locations[0].City)
+	assert.Equal(t, "新宿", saved[0].Locations[1].Station)
+	assert.Empty(t, saved[1].Locations)
+}
@@ -0,0 +1,74 @@
+package application
+
+import (
+	"business/internal/emailstore/domain"
+	r "business/internal/emailstore/infrastructure"
+	"business/tools/jpdate"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// PeriodUseCase は入場時期・終了時期の変換ユースケースの具象です
+type PeriodUseCase struct {
+	r r.PeriodRepositoryInterface
+}
+
+// NewPeriod は入場時期・終了時期の変換ユースケースを作成します
+func NewPeriod(r r.PeriodRepositoryInterface) *PeriodUseCase {
+	return &PeriodUseCase{
+		r: r,
+	}
+}
+
+// BackfillPeriods は保存済みの入場時期・終了時期を受信日を基準に日付の範囲へ変換します
+// 案件をID順に batchSize 件ずつ読み込み、1バッチを1トランザクションで保存します。
+// 解釈できない値は範囲を空にして保存するため、何度実行しても同じ結果になります。
+func (u *PeriodUseCase) BackfillPeriods(batchSize int) (domain.PeriodBackfillResult, error) {
+	if batchSize <= 0 {
+		batchSize = domain.DefaultPeriodBatchSize
+	}
+
+	result := domain.PeriodBackfillResult{}
+	var afterID uint
+	for {
+		periods, err := u.r.ListProjectPeriods(afterID, batchSize)
+		if err != nil {
+			return result, fmt.Errorf("入場時期・終了時期の変換エラー: %w", err)
+		}
+		if len(periods) == 0 {
+			return result, nil
+		}
+
+		for i := range periods {
+			p := &periods[i]
+			var ok bool
+			p.End, ok = parsePeriod(p.EndTiming, p.ReceivedDate)
+			if !ok && strings.TrimSpace(p.EndTiming) != "" {
+				result.UnparsedEnd++
+			}
+			for j := range p.Starts {
+				s := &p.Starts[j]
+				if s.Range, ok = parsePeriod(s.StartDate, p.ReceivedDate); !ok {
+					result.UnparsedStart++
+				}
+			}
+			result.EntryTimings += len(p.Starts)
+			afterID = p.ProjectID
+		}
+		if err := u.r.SaveProjectPeriods(periods); err != nil {
+			return result, fmt.Errorf("入場時期・終了時期の変換エラー: %w", err)
+		}
+		result.Projects += len(periods)
+
+		if len(periods) < batchSize {
+			return result, nil
+		}
+	}
+}
+
+// parsePeriod は日付表現を受信日を基準に日付の範囲へ解釈します
+func parsePeriod(text string, received time.Time) (domain.DateRange, bool) {
+	parsed, ok := jpdate.Parse(text, received)
+	return domain.DateRange{From: parsed.From, To: parsed.To, Flag: string(parsed.Flag)}, ok
+}
@@ -0,0 +1,57 @@
+package application
+
+import (
+	"business/internal/emailstore/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockPeriodRepository の定義
+type MockPeriodRepository struct {
+	mock.Mock
+}
+
+func (m *MockPeriodRepository) ListProjectPeriods(afterID uint, limit int) ([]domain.ProjectPeriod, error) {
+	args := m.Called(afterID, limit)
+	return args.Get(0).([]domain.ProjectPeriod), args.Error(1)
+}
+
+func (m *MockPeriodRepository) SaveProjectPeriods(periods []domain.ProjectPeriod) error {
+	args := m.Called(periods)
+	return args.Error(0)
+}
+
+// テスト: 受信日を基準に入場時期・終了時期を変換し、バッチごとに保存すること
+func TestBackfillPeriods(t *testing.T) {
+	mockRepo := new(MockPeriodRepository)
+	usecase := NewPeriod(mockRepo)
+
+	received := time.Date(2025, 6, 20, 9, 0, 0, 0, time.Local)
+	mockRepo.On("ListProjectPeriods", uint(0), 2).Return([]domain.ProjectPeriod{
+		{ProjectID: 1, ReceivedDate: received, EndTiming: "～長期", Starts: []domain.StartPeriod{{StartDate: "即日"}, {StartDate: "7月～"}}},
+		{ProjectID: 3, ReceivedDate: received, EndTiming: "要確認", Starts: []domain.StartPeriod{{StartDate: "要確認"}}},
+	}, nil)
+	mockRepo.On("ListProjectPeriods", uint(3), 2).Return([]domain.ProjectPeriod{
+		{ProjectID: 4, ReceivedDate: received},
+	}, nil)
+	mockRepo.On("SaveProjectPeriods", mock.Anything).Return(nil)
+
+	result, err := usecase.BackfillPeriods(2)
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.PeriodBackfillResult{Projects: 3, EntryTimings: 3, UnparsedStart: 1, UnparsedEnd: 1}, result)
+	mockRepo.AssertNumberOfCalls(t, "SaveProjectPeriods", 2)
+
+	saved := mockRepo.Calls[1].Arguments.Get(0).([]domain.ProjectPeriod)
+	assert.Equal(t, "ongoing", saved[0].End.Flag)
+	assert.Nil(t, saved[0].End.To)
+	assert.Equal(t, "immediate", saved[0].Starts[0].Range.Flag)
+	assert.Equal(t, "2025-06-20", saved[0].Starts[0].Range.From.Format("2006-01-02"))
+	assert.Equal(t, "2025-07-01", saved[0].Starts[1].Range.From.Format("2006-01-02"))
+	assert.Equal(t, "2025-07-31", saved[0].Starts[1].Range.To.Format("2006-01-02"))
+	assert.Equal(t, domain.DateRange{}, saved[1].Starts[0].Range)
+}
@@ -0,0 +1,61 @@
+package application
+
+import (
+	cd "business/internal/common/domain"
+	"business/internal/emailstore/domain"
+	r "business/internal/emailstore/infrastructure"
+	"fmt"
+)
+
+// PriceUseCase は単価の変換ユースケースの具象です
+type PriceUseCase struct {
+	r r.PriceRepositoryInterface
+}
+
+// NewPrice は単価の変換ユースケースを作成します
+func NewPrice(r r.PriceRepositoryInterface) *PriceUseCase {
+	return &PriceUseCase{
+		r: r,
+	}
+}
+
+// BackfillPrices は保存済みの単価を単位・税・精算幅付きで解釈し、税別の月額に換算します
+// 単価FROM/TOの根拠の引用（無ければ本文の単価の行）を解釈し、金額を読み取れない場合は保存済みの単価の桁を直して使います。
+// 案件をID順に batchSize 件ずつ読み込み、1バッチを1トランザクションで保存します。何度実行しても同じ結果になります。
+func (u *PriceUseCase) BackfillPrices(batchSize int) (domain.PriceBackfillResult, error) {
+	if batchSize <= 0 {
+		batchSize = domain.DefaultPriceBatchSize
+	}
+
+	result := domain.PriceBackfillResult{}
+	var afterID uint
+	for {
+		prices, err := u.r.ListProjectPrices(afterID, batchSize)
+		if err != nil {
+			return result, fmt.Errorf("単価の変換エラー: %w", err)
+		}
+		if len(prices) == 0 {
+			return result, nil
+		}
+
+		for i := range prices {
+			p := &prices[i]
+			p.Price = cd.ResolvePrice(cd.PriceSourceText(p.Quotes, p.Body), p.PriceFrom, p.PriceTo)
+			switch {
+			case p.Price.MonthlyFrom() != nil || p.Price.MonthlyTo() != nil:
+				result.Monthly++
+			case p.Price.IsZero():
+				result.Empty++
+			}
+			afterID = p.ProjectID
+		}
+		if err := u.r.SaveProjectPrices(prices); err != nil {
+			return result, fmt.Errorf("単価の変換エラー: %w", err)
+		}
+		result.Projects += len(prices)
+
+		if len(prices) < batchSize {
+			return result, nil
+		}
+	}
+}
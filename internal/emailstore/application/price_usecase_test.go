@@ -0,0 +1,54 @@
+package application
+
+import (
+	"business/internal/emailstore/domain"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockPriceRepository の定義
+type MockPriceRepository struct {
+	mock.Mock
+}
+
+func (m *MockPriceRepository) ListProjectPrices(afterID uint, limit int) ([]domain.ProjectPrice, error) {
+	args := m.Called(afterID, limit)
+	return args.Get(0).([]domain.ProjectPrice), args.Error(1)
+}
+
+func (m *MockPriceRepository) SaveProjectPrices(prices []domain.ProjectPrice) error {
+	args := m.Called(prices)
+	return args.Error(0)
+}
+
+// テスト: 根拠の引用・本文・保存済みの単価の順に単価を解釈し、月額に換算して保存すること
+func TestBackfillPrices(t *testing.T) {
+	mockRepo := new(MockPriceRepository)
+	usecase := NewPrice(mockRepo)
+
+	mockRepo.On("ListProjectPrices", uint(0), domain.DefaultPriceBatchSize).Return([]domain.ProjectPrice{
+		{ProjectID: 1, PriceFrom: lo.ToPtr(5), Quotes: []string{"時給5,000円（精算140-180h）"}},
+		{ProjectID: 2, Body: "【単価】〜80万（税別）"},
+		{ProjectID: 3, PriceFrom: lo.ToPtr(60), PriceTo: lo.ToPtr(70)},
+		{ProjectID: 4, Body: "【場所】渋谷"},
+	}, nil)
+	mockRepo.On("SaveProjectPrices", mock.Anything).Return(nil)
+
+	result, err := usecase.BackfillPrices(0)
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.PriceBackfillResult{Projects: 4, Monthly: 3, Empty: 1}, result)
+
+	saved := mockRepo.Calls[1].Arguments.Get(0).([]domain.ProjectPrice)
+	assert.Equal(t, 800000, *saved[0].Price.MonthlyFrom())
+	assert.Equal(t, 180, *saved[0].Price.SettlementTo)
+	assert.Equal(t, 800000, *saved[1].Price.MonthlyTo())
+	assert.False(t, *saved[1].Price.TaxIncluded)
+	assert.Equal(t, 600000, *saved[2].Price.From)
+	assert.Equal(t, 700000, *saved[2].Price.To)
+	assert.True(t, saved[3].Price.IsZero())
+}
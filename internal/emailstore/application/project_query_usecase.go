@@ -0,0 +1,117 @@
+package application
+
+import (
+	"business/internal/emailstore/domain"
+	r "business/internal/emailstore/infrastructure"
+	"business/tools/fulltext"
+	"business/tools/location"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ProjectQueryUseCase は保存済み案件の検索ユースケースの具象です
+type ProjectQueryUseCase struct {
+	r r.QueryRepositoryInterface
+}
+
+// NewProjectQuery は保存済み案件の検索ユースケースを作成します
+func NewProjectQuery(r r.QueryRepositoryInterface) *ProjectQueryUseCase {
+	return &ProjectQueryUseCase{
+		r: r,
+	}
+}
+
+// SearchProjects は条件に一致する案件を1ページ分返します
+// 次ページがある場合は NextCursor に続きを取得するためのカーソルを設定します。
+func (u *ProjectQueryUseCase) SearchProjects(q domain.ProjectQuery) (domain.ProjectPage, error) {
+	q, err := q.Normalize()
+	if err != nil {
+		return domain.ProjectPage{}, err
+	}
+	if q, err = resolveLocation(q); err != nil {
+		return domain.ProjectPage{}, err
+	}
+
+	items, err := u.r.SearchProjects(q)
+	if err != nil {
+		return domain.ProjectPage{}, fmt.Errorf("案件検索エラー: %w", err)
+	}
+
+	page := domain.ProjectPage{Items: []domain.ProjectListItem{}}
+	if len(items) > q.Limit {
+		items = items[:q.Limit]
+		page.NextCursor = domain.CursorOf(q.Sort, items[len(items)-1]).Encode()
+	}
+
+	terms := fulltext.Terms(q.Text)
+	for _, item := range items {
+		if q.Text != "" {
+			item.Snippet = snippetOf(item, terms)
+			item.Body = ""
+		}
+		page.Items = append(page.Items, item.ApplyConfidence(q.LowConfidence, q.MinConfidence))
+	}
+
+	return page, nil
+}
+
+// SearchText は件名・本文・案件名を全文検索し、関連度順に1ページ分返します
+// 一覧と同じ絞り込み条件を組み合わせられます。
+func (u *ProjectQueryUseCase) SearchText(q domain.ProjectQuery) (domain.ProjectPage, error) {
+	q, err := q.Normalize()
+	if err != nil {
+		return domain.ProjectPage{}, err
+	}
+	if q.Text == "" {
+		return domain.ProjectPage{}, errors.Join(domain.ErrInvalidProjectQuery, errors.New("q を指定してください"))
+	}
+
+	return u.SearchProjects(q)
+}
+
+// resolveLocation は都道府県の略称を正式名に変換し、最寄り駅を辞書の座標に解決します
+func resolveLocation(q domain.ProjectQuery) (domain.ProjectQuery, error) {
+	prefectures := make([]string, 0, len(q.Prefectures))
+	for _, p := range q.Prefectures {
+		if p = strings.TrimSpace(p); p == "" {
+			continue
+		}
+		name, ok := location.NormalizePrefecture(p)
+		if !ok {
+			return q, errors.Join(domain.ErrInvalidProjectQuery, fmt.Errorf("prefectures が不正です: %s", p))
+		}
+		prefectures = append(prefectures, name)
+	}
+	q.Prefectures = prefectures
+
+	if station := strings.TrimSpace(q.Station); station != "" {
+		s, ok := location.FindStation(station)
+		if !ok {
+			return q, errors.Join(domain.ErrInvalidProjectQuery, fmt.Errorf("station が見つかりません: %s", station))
+		}
+		q.Station = s.Name
+		q.NearLat, q.NearLng = &s.Lat, &s.Lng
+	}
+	return q, nil
+}
+
+// snippetOf は本文の一致箇所から抜粋を作成します。本文に一致しない場合は案件名・件名から作成します
+func snippetOf(item domain.ProjectListItem, terms []string) string {
+	for _, text := range []string{item.Body, item.ProjectTitle, item.Subject} {
+		if containsAny(text, terms) {
+			return fulltext.Snippet(text, terms, fulltext.DefaultSnippetWidth)
+		}
+	}
+	return fulltext.Snippet(item.Body, terms, fulltext.DefaultSnippetWidth)
+}
+
+func containsAny(text string, terms []string) bool {
+	lower := strings.ToLower(text)
+	for _, term := range terms {
+		if strings.Contains(lower, strings.ToLower(term)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,171 @@
+package application
+
+import (
+	cd "business/internal/common/domain"
+	"business/internal/emailstore/domain"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockQueryRepository の定義
+type MockQueryRepository struct {
+	mock.Mock
+}
+
+func (m *MockQueryRepository) SearchProjects(q domain.ProjectQuery) ([]domain.ProjectListItem, error) {
+	args := m.Called(q)
+	return args.Get(0).([]domain.ProjectListItem), args.Error(1)
+}
+
+// テスト: 取得件数を超える行がある場合に次ページのカーソルを返すこと
+func TestSearchProjects_NextCursor(t *testing.T) {
+	mockRepo := new(MockQueryRepository)
+	usecase := NewProjectQuery(mockRepo)
+
+	received := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+	items := []domain.ProjectListItem{
+		{ProjectID: 3, ReceivedDate: received},
+		{ProjectID: 2, ReceivedDate: received},
+		{ProjectID: 1, ReceivedDate: received},
+	}
+	mockRepo.On("SearchProjects", mock.MatchedBy(func(q domain.ProjectQuery) bool {
+		return q.Limit == 2 && q.Sort == domain.SortReceivedDesc && q.LanguageMatch == domain.MatchAny
+	})).Return(items, nil)
+
+	page, err := usecase.SearchProjects(domain.ProjectQuery{Limit: 2})
+
+	require.NoError(t, err)
+	assert.Len(t, page.Items, 2)
+	cursor, err := domain.DecodeCursor(page.NextCursor)
+	require.NoError(t, err)
+	assert.Equal(t, uint(2), cursor.ID)
+	assert.True(t, received.Equal(cursor.Received))
+	mockRepo.AssertExpectations(t)
+}
+
+// テスト: 最終ページではカーソルを返さないこと
+func TestSearchProjects_LastPage(t *testing.T) {
+	mockRepo := new(MockQueryRepository)
+	usecase := NewProjectQuery(mockRepo)
+
+	mockRepo.On("SearchProjects", mock.Anything).Return([]domain.ProjectListItem{{ProjectID: 1}}, nil)
+
+	page, err := usecase.SearchProjects(domain.ProjectQuery{})
+
+	require.NoError(t, err)
+	assert.Len(t, page.Items, 1)
+	assert.Empty(t, page.NextCursor)
+}
+
+// テスト: 低信頼度の値を隠すこと
+func TestSearchProjects_HideLowConfidence(t *testing.T) {
+	mockRepo := new(MockQueryRepository)
+	usecase := NewProjectQuery(mockRepo)
+
+	mockRepo.On("SearchProjects", mock.Anything).Return([]domain.ProjectListItem{
+		{
+			ProjectID: 1,
+			PriceFrom: lo.ToPtr(600000),
+			Evidences: []cd.FieldEvidence{{Field: cd.EvidenceFieldPriceFrom, Confidence: 0.2}},
+		},
+	}, nil)
+
+	page, err := usecase.SearchProjects(domain.ProjectQuery{LowConfidence: domain.LowConfidenceHide})
+
+	require.NoError(t, err)
+	assert.Nil(t, page.Items[0].PriceFrom)
+}
+
+// テスト: 検索条件が不正な場合にリポジトリを呼ばないこと
+func TestSearchProjects_InvalidQuery(t *testing.T) {
+	mockRepo := new(MockQueryRepository)
+	usecase := NewProjectQuery(mockRepo)
+
+	_, err := usecase.SearchProjects(domain.ProjectQuery{Sort: "unknown"})
+
+	assert.ErrorIs(t, err, domain.ErrInvalidProjectQuery)
+	mockRepo.AssertNotCalled(t, "SearchProjects", mock.Anything)
+}
+
+// テスト: 都道府県の略称を正式名に、最寄り駅を座標に解決してリポジトリに渡すこと
+func TestSearchProjects_ResolveLocation(t *testing.T) {
+	mockRepo := new(MockQueryRepository)
+	usecase := NewProjectQuery(mockRepo)
+
+	mockRepo.On("SearchProjects", mock.MatchedBy(func(q domain.ProjectQuery) bool {
+		return assert.ObjectsAreEqual([]string{"東京都", "神奈川県"}, q.Prefectures) &&
+			q.Station == "渋谷" && q.NearLat != nil && q.NearLng != nil && q.RadiusKm == domain.DefaultRadiusKm
+	})).Return([]domain.ProjectListItem{}, nil)
+
+	_, err := usecase.SearchProjects(domain.ProjectQuery{Prefectures: []string{"東京", " 神奈川県"}, Station: "渋谷駅"})
+
+	require.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+// テスト: 辞書に無い駅・都道府県は検索条件の不正とすること
+func TestSearchProjects_UnknownLocation(t *testing.T) {
+	mockRepo := new(MockQueryRepository)
+	usecase := NewProjectQuery(mockRepo)
+
+	_, err := usecase.SearchProjects(domain.ProjectQuery{Station: "存在しない駅"})
+	assert.ErrorIs(t, err, domain.ErrInvalidProjectQuery)
+
+	_, err = usecase.SearchProjects(domain.ProjectQuery{Prefectures: []string{"関東"}})
+	assert.ErrorIs(t, err, domain.ErrInvalidProjectQuery)
+
+	mockRepo.AssertNotCalled(t, "SearchProjects", mock.Anything)
+}
+
+// テスト: リポジトリのエラーを返すこと
+func TestSearchProjects_Error(t *testing.T) {
+	mockRepo := new(MockQueryRepository)
+	usecase := NewProjectQuery(mockRepo)
+
+	mockRepo.On("SearchProjects", mock.Anything).Return([]domain.ProjectListItem{}, errors.New("db error"))
+
+	_, err := usecase.SearchProjects(domain.ProjectQuery{})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "案件検索エラー")
+}
+
+// テスト: 全文検索で一致箇所の抜粋を返すこと
+func TestSearchText_Snippet(t *testing.T) {
+	mockRepo := new(MockQueryRepository)
+	usecase := NewProjectQuery(mockRepo)
+
+	mockRepo.On("SearchProjects", mock.MatchedBy(func(q domain.ProjectQuery) bool {
+		return q.Text == "金融系 -保守" && q.Sort == domain.SortRelevance
+	})).Return([]domain.ProjectListItem{
+		{ProjectID: 1, Relevance: 1.5, Body: "■案件名:金融系システム更改"},
+		{ProjectID: 2, Relevance: 0.8, ProjectTitle: "金融系PMO", Body: "本文には含まれない"},
+	}, nil)
+
+	page, err := usecase.SearchText(domain.ProjectQuery{Text: "金融系 -保守"})
+
+	require.NoError(t, err)
+	require.Len(t, page.Items, 2)
+	assert.Equal(t, "■案件名:<mark>金融系</mark>システム更改", page.Items[0].Snippet)
+	assert.Equal(t, "<mark>金融系</mark>PMO", page.Items[1].Snippet)
+	// 本文はレスポンスに含めない
+	assert.Empty(t, page.Items[0].Body)
+	mockRepo.AssertExpectations(t)
+}
+
+// テスト: 全文検索で検索語が無い場合にエラーを返すこと
+func TestSearchText_TextRequired(t *testing.T) {
+	mockRepo := new(MockQueryRepository)
+	usecase := NewProjectQuery(mockRepo)
+
+	_, err := usecase.SearchText(domain.ProjectQuery{Text: "  "})
+
+	assert.ErrorIs(t, err, domain.ErrInvalidProjectQuery)
+	mockRepo.AssertNotCalled(t, "SearchProjects", mock.Anything)
+}
@@ -0,0 +1,162 @@
+package application
+
+import (
+	"business/internal/emailstore/domain"
+	r "business/internal/emailstore/infrastructure"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TriageUseCase はメールの仕分けユースケースの具象です
+type TriageUseCase struct {
+	r r.TriageRepositoryInterface
+}
+
+// NewTriage はメールの仕分けユースケースを作成します
+func NewTriage(r r.TriageRepositoryInterface) *TriageUseCase {
+	return &TriageUseCase{
+		r: r,
+	}
+}
+
+// Triage は指定したメールに仕分けの更新内容を反映し、更新後の値を返します
+// 1件でも保存されていないGメールIDがあれば何も更新せずにエラーを返します。
+func (u *TriageUseCase) Triage(gmailIDs []string, t domain.Triage) ([]domain.TriageState, error) {
+	if err := t.Validate(); err != nil {
+		return nil, err
+	}
+	gmailIDs = uniqueIDs(gmailIDs)
+	if len(gmailIDs) == 0 {
+		return nil, fmt.Errorf("%w: GメールIDを指定してください", domain.ErrInvalidTriage)
+	}
+
+	current, err := u.r.LoadTriageStates(gmailIDs)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]domain.TriageState, len(current))
+	for _, state := range current {
+		byID[state.GmailID] = state
+	}
+
+	var missing []string
+	for _, id := range gmailIDs {
+		if _, ok := byID[id]; !ok {
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%w: %s", domain.ErrEmailNotFound, strings.Join(missing, ","))
+	}
+
+	states := make([]domain.TriageState, 0, len(gmailIDs))
+	for _, id := range gmailIDs {
+		states = append(states, t.Apply(byID[id]))
+	}
+
+	if err := u.r.SaveTriage(states); err != nil {
+		return nil, fmt.Errorf("仕分け保存エラー: %w", err)
+	}
+	return states, nil
+}
+
+// SetStatus は指定した案件（email_projects.id）の応募状況を更新し、更新後の値を返します
+// 1通に複数の案件が載る場合も案件ごとに更新します。1件でも保存されていない案件IDがあれば何も更新せずにエラーを返します。
+// 応募状況が変わった案件は変更履歴を残します。読み込んだ後にほかの操作で応募状況が変わっていた場合は、
+// 何も更新せずに domain.ErrStatusConflict を返します。
+func (u *TriageUseCase) SetStatus(projectIDs []uint, s domain.StatusUpdate) ([]domain.ProjectStatus, error) {
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	projectIDs = uniqueProjectIDs(projectIDs)
+	if len(projectIDs) == 0 {
+		return nil, fmt.Errorf("%w: 案件IDを指定してください", domain.ErrInvalidTriage)
+	}
+
+	current, err := u.r.LoadProjectStatuses(projectIDs)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]domain.ProjectStatus, len(current))
+	for _, status := range current {
+		byID[status.ProjectID] = status
+	}
+
+	var missing []string
+	for _, id := range projectIDs {
+		if _, ok := byID[id]; !ok {
+			missing = append(missing, fmt.Sprint(id))
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%w: %s", domain.ErrProjectNotFound, strings.Join(missing, ","))
+	}
+
+	now := time.Now()
+	statuses := make([]domain.ProjectStatus, 0, len(projectIDs))
+	var changes []domain.StatusChange
+	for _, id := range projectIDs {
+		before := byID[id]
+		after := before
+		after.Status = s.Status
+		statuses = append(statuses, after)
+
+		if after.Status != before.Status {
+			changes = append(changes, domain.StatusChange{
+				ProjectID: id,
+				From:      before.Status,
+				To:        after.Status,
+				Note:      s.Note,
+				ChangedAt: now,
+			})
+		}
+	}
+
+	if err := u.r.SaveStatusChanges(changes); err != nil {
+		return nil, fmt.Errorf("応募状況保存エラー: %w", err)
+	}
+	return statuses, nil
+}
+
+// StatusHistory は案件の応募状況の変更履歴を古い順に返します
+func (u *TriageUseCase) StatusHistory(projectID uint) ([]domain.StatusChange, error) {
+	statuses, err := u.r.LoadProjectStatuses([]uint{projectID})
+	if err != nil {
+		return nil, err
+	}
+	if len(statuses) == 0 {
+		return nil, fmt.Errorf("%w: %d", domain.ErrProjectNotFound, projectID)
+	}
+
+	return u.r.ListStatusHistory(projectID)
+}
+
+// uniqueIDs は空白を除いたGメールIDを重複なく入力順に返します
+func uniqueIDs(ids []string) []string {
+	seen := make(map[string]bool, len(ids))
+	result := make([]string, 0, len(ids))
+	for _, id := range ids {
+		id = strings.TrimSpace(id)
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		result = append(result, id)
+	}
+	return result
+}
+
+// uniqueProjectIDs は0を除いた案件IDを重複なく入力順に返します
+func uniqueProjectIDs(ids []uint) []uint {
+	seen := make(map[uint]bool, len(ids))
+	result := make([]uint, 0, len(ids))
+	for _, id := range ids {
+		if id == 0 || seen[id] {
+			continue
+		}
+		seen[id] = true
+		result = append(result, id)
+	}
+	return result
+}
@@ -0,0 +1,175 @@
+package application
+
+import (
+	"business/internal/emailstore/domain"
+	"fmt"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockTriageRepository の定義
+type MockTriageRepository struct {
+	mock.Mock
+}
+
+func (m *MockTriageRepository) LoadTriageStates(gmailIDs []string) ([]domain.TriageState, error) {
+	args := m.Called(gmailIDs)
+	return args.Get(0).([]domain.TriageState), args.Error(1)
+}
+
+func (m *MockTriageRepository) SaveTriage(states []domain.TriageState) error {
+	args := m.Called(states)
+	return args.Error(0)
+}
+
+func (m *MockTriageRepository) LoadProjectStatuses(projectIDs []uint) ([]domain.ProjectStatus, error) {
+	args := m.Called(projectIDs)
+	return args.Get(0).([]domain.ProjectStatus), args.Error(1)
+}
+
+func (m *MockTriageRepository) SaveStatusChanges(changes []domain.StatusChange) error {
+	args := m.Called(changes)
+	return args.Error(0)
+}
+
+func (m *MockTriageRepository) ListStatusHistory(projectID uint) ([]domain.StatusChange, error) {
+	args := m.Called(projectID)
+	return args.Get(0).([]domain.StatusChange), args.Error(1)
+}
+
+// テスト: 複数メールをまとめて仕分けすること
+func TestTriage_Bulk(t *testing.T) {
+	mockRepo := new(MockTriageRepository)
+	usecase := NewTriage(mockRepo)
+
+	mockRepo.On("LoadTriageStates", []string{"gmail-1", "gmail-2"}).Return([]domain.TriageState{
+		{GmailID: "gmail-1"},
+		{GmailID: "gmail-2", IsBad: true, Note: "書類選考中"},
+	}, nil)
+	want := []domain.TriageState{
+		{GmailID: "gmail-1", IsRead: true, IsGood: true},
+		{GmailID: "gmail-2", IsRead: true, IsGood: true, Note: "書類選考中"},
+	}
+	mockRepo.On("SaveTriage", want).Return(nil)
+
+	states, err := usecase.Triage([]string{"gmail-1", " gmail-2 ", "gmail-1"}, domain.Triage{
+		IsRead: lo.ToPtr(true),
+		IsGood: lo.ToPtr(true),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, want, states)
+	mockRepo.AssertExpectations(t)
+}
+
+// テスト: 保存されていないメールが含まれる場合は何も更新しないこと
+func TestTriage_NotFound(t *testing.T) {
+	mockRepo := new(MockTriageRepository)
+	usecase := NewTriage(mockRepo)
+
+	mockRepo.On("LoadTriageStates", []string{"gmail-1", "gmail-x"}).Return([]domain.TriageState{
+		{GmailID: "gmail-1"},
+	}, nil)
+
+	_, err := usecase.Triage([]string{"gmail-1", "gmail-x"}, domain.Triage{IsGood: lo.ToPtr(true)})
+
+	assert.ErrorIs(t, err, domain.ErrEmailNotFound)
+	assert.Contains(t, err.Error(), "gmail-x")
+	mockRepo.AssertNotCalled(t, "SaveTriage", mock.Anything)
+}
+
+// テスト: 更新内容が不正な場合はリポジトリを呼ばないこと
+func TestTriage_Invalid(t *testing.T) {
+	mockRepo := new(MockTriageRepository)
+	usecase := NewTriage(mockRepo)
+
+	_, err := usecase.Triage([]string{"gmail-1"}, domain.Triage{})
+	assert.ErrorIs(t, err, domain.ErrInvalidTriage)
+
+	_, err = usecase.Triage(nil, domain.Triage{IsRead: lo.ToPtr(true)})
+	assert.ErrorIs(t, err, domain.ErrInvalidTriage)
+
+	mockRepo.AssertNotCalled(t, "LoadTriageStates", mock.Anything)
+}
+
+// テスト: 同じメールに載った案件ごとに応募状況を更新し、変わった案件だけ履歴を残すこと
+func TestSetStatus_PerProject(t *testing.T) {
+	mockRepo := new(MockTriageRepository)
+	usecase := NewTriage(mockRepo)
+
+	// 案件11と12は同じメール（gmail-1）に載った別の案件
+	mockRepo.On("LoadProjectStatuses", []uint{11, 13}).Return([]domain.ProjectStatus{
+		{ProjectID: 11, GmailID: "gmail-1", Status: domain.StatusUntouched},
+		{ProjectID: 13, GmailID: "gmail-2", Status: domain.StatusApplied},
+	}, nil)
+	mockRepo.On("SaveStatusChanges", mock.Anything).Return(nil)
+
+	statuses, err := usecase.SetStatus([]uint{11, 13, 11, 0}, domain.StatusUpdate{Status: domain.StatusApplied, Note: "単価交渉可"})
+
+	require.NoError(t, err)
+	assert.Equal(t, []domain.ProjectStatus{
+		{ProjectID: 11, GmailID: "gmail-1", Status: domain.StatusApplied},
+		{ProjectID: 13, GmailID: "gmail-2", Status: domain.StatusApplied},
+	}, statuses)
+
+	changes := mockRepo.Calls[1].Arguments.Get(0).([]domain.StatusChange)
+	require.Len(t, changes, 1)
+	assert.Equal(t, uint(11), changes[0].ProjectID)
+	assert.Equal(t, domain.StatusUntouched, changes[0].From)
+	assert.Equal(t, domain.StatusApplied, changes[0].To)
+	assert.Equal(t, "単価交渉可", changes[0].Note)
+	assert.False(t, changes[0].ChangedAt.IsZero())
+	mockRepo.AssertExpectations(t)
+}
+
+// テスト: 保存されていない案件が含まれる場合や応募状況が不正な場合は何も更新しないこと
+func TestSetStatus_Invalid(t *testing.T) {
+	mockRepo := new(MockTriageRepository)
+	usecase := NewTriage(mockRepo)
+
+	_, err := usecase.SetStatus([]uint{11}, domain.StatusUpdate{Status: "辞退"})
+	assert.ErrorIs(t, err, domain.ErrInvalidTriage)
+	_, err = usecase.SetStatus(nil, domain.StatusUpdate{Status: domain.StatusApplied})
+	assert.ErrorIs(t, err, domain.ErrInvalidTriage)
+
+	mockRepo.On("LoadProjectStatuses", []uint{11, 99}).Return([]domain.ProjectStatus{
+		{ProjectID: 11, GmailID: "gmail-1", Status: domain.StatusUntouched},
+	}, nil)
+	_, err = usecase.SetStatus([]uint{11, 99}, domain.StatusUpdate{Status: domain.StatusApplied})
+	assert.ErrorIs(t, err, domain.ErrProjectNotFound)
+	assert.Contains(t, err.Error(), "99")
+
+	mockRepo.AssertNotCalled(t, "SaveStatusChanges", mock.Anything)
+}
+
+// テスト: 読み込んだ後にほかの操作で応募状況が変わっていた場合は競合のエラーを返すこと
+func TestSetStatus_Conflict(t *testing.T) {
+	mockRepo := new(MockTriageRepository)
+	usecase := NewTriage(mockRepo)
+
+	mockRepo.On("LoadProjectStatuses", []uint{11}).Return([]domain.ProjectStatus{
+		{ProjectID: 11, GmailID: "gmail-1", Status: domain.StatusUntouched},
+	}, nil)
+	mockRepo.On("SaveStatusChanges", mock.Anything).Return(fmt.Errorf("%w: 案件 11", domain.ErrStatusConflict))
+
+	_, err := usecase.SetStatus([]uint{11}, domain.StatusUpdate{Status: domain.StatusApplied})
+
+	assert.ErrorIs(t, err, domain.ErrStatusConflict)
+}
+
+// テスト: 保存されていない案件の履歴はエラーになること
+func TestStatusHistory_NotFound(t *testing.T) {
+	mockRepo := new(MockTriageRepository)
+	usecase := NewTriage(mockRepo)
+
+	mockRepo.On("LoadProjectStatuses", []uint{99}).Return([]domain.ProjectStatus{}, nil)
+
+	_, err := usecase.StatusHistory(99)
+
+	assert.ErrorIs(t, err, domain.ErrProjectNotFound)
+	mockRepo.AssertNotCalled(t, "ListStatusHistory", mock.Anything)
+}
@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// Cursor は一覧のページ位置を表すカーソルです
+// 直前のページ末尾の並び替えキーと案件IDを保持します。
+type Cursor struct {
+	Sort     string    `json:"s"`
+	Received time.Time `json:"r,omitempty"`
+	Price    int       `json:"p,omitempty"`
+	Score    float64   `json:"v,omitempty"` // 全文検索の関連度
+	ID       uint      `json:"i"`
+}
+
+// Encode はカーソルをURLに埋め込める文字列に変換します
+func (c Cursor) Encode() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// DecodeCursor は文字列からカーソルを復元します
+func DecodeCursor(s string) (Cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return Cursor{}, errors.New("cursor の形式が不正です")
+	}
+	var c Cursor
+	if err := json.Unmarshal(b, &c); err != nil || c.ID == 0 {
+		return Cursor{}, errors.New("cursor の形式が不正です")
+	}
+	return c, nil
+}
+
+// CursorOf は一覧の行から次ページのカーソルを作成します
+func CursorOf(sort string, item ProjectListItem) Cursor {
+	return Cursor{
+		Sort:     sort,
+		Received: item.ReceivedDate,
+		Price:    item.SortPrice(),
+		Score:    item.Relevance,
+		ID:       item.ProjectID,
+	}
+}
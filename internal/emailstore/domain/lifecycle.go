@@ -0,0 +1,30 @@
+package domain
+
+// LifecycleStatus は案件の募集状況です（lifecycle コマンドで判定）
+type LifecycleStatus string
+
+// 募集状況
+const (
+	LifecycleOpen    LifecycleStatus = "open"    // 募集中
+	LifecycleClosed  LifecycleStatus = "closed"  // 募集終了の連絡があった
+	LifecycleExpired LifecycleStatus = "expired" // 入場時期を過ぎた、または受信から日数が経った
+	LifecycleUnknown LifecycleStatus = "unknown" // 入場時期が不明（未判定を含む）
+)
+
+// LifecycleStatuses は募集状況の一覧です
+var LifecycleStatuses = []LifecycleStatus{
+	LifecycleOpen,
+	LifecycleClosed,
+	LifecycleExpired,
+	LifecycleUnknown,
+}
+
+// IsValid は定義済みの募集状況かどうかを返します
+func (s LifecycleStatus) IsValid() bool {
+	for _, status := range LifecycleStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,35 @@
+package domain
+
+// 一度に変換する案件数
+const DefaultLocationBatchSize = 500
+
+// 最寄り駅からの距離の絞り込み（km）
+const (
+	DefaultRadiusKm = 5.0
+	MaxRadiusKm     = 100.0
+)
+
+// Location は勤務地を正規化した1拠点です
+// Lat・Lng は駅または市区町村まで分かった場合のみ設定されます。
+type Location struct {
+	Prefecture string   `json:"prefecture"`
+	City       string   `json:"city"`
+	Station    string   `json:"station"`
+	Lat        *float64 `json:"lat,omitempty"`
+	Lng        *float64 `json:"lng,omitempty"`
+	Remote     bool     `json:"remote"` // フルリモート
+}
+
+// ProjectLocations は案件の勤務地の表記と、正規化した拠点です
+type ProjectLocations struct {
+	ProjectID    uint
+	WorkLocation string // 保存済みの勤務場所（例: "渋谷 or 新宿"）
+	Locations    []Location
+}
+
+// LocationBackfillResult は勤務地の変換結果です
+type LocationBackfillResult struct {
+	Projects   int `json:"projects"`   // 変換した案件数
+	Locations  int `json:"locations"`  // 保存した拠点の件数
+	Unresolved int `json:"unresolved"` // 拠点に変換できなかった案件数（勤務場所が空の案件を除く）
+}
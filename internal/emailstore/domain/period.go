@@ -0,0 +1,37 @@
+package domain
+
+import "time"
+
+// 一度に変換する案件数
+const DefaultPeriodBatchSize = 500
+
+// DateRange は入場時期・終了時期を日付の範囲に解釈した結果です
+// From・To は両端を含む日付で、nil は下限・上限が無いことを表します。
+type DateRange struct {
+	From *time.Time
+	To   *time.Time
+	Flag string // immediate（即日） / ongoing（随時・長期） / undecided（未定）
+}
+
+// StartPeriod は入場時期1件の保存値と解釈した範囲です
+type StartPeriod struct {
+	StartDate string // 保存済みの入場日（例: "7月～"）
+	Range     DateRange
+}
+
+// ProjectPeriod は案件の入場時期・終了時期と、解釈の基準となる受信日です
+type ProjectPeriod struct {
+	ProjectID    uint
+	ReceivedDate time.Time
+	EndTiming    string // 保存済みの終了時期（例: "～長期"）
+	End          DateRange
+	Starts       []StartPeriod
+}
+
+// PeriodBackfillResult は入場時期・終了時期の変換結果です
+type PeriodBackfillResult struct {
+	Projects      int `json:"projects"`       // 変換した案件数
+	EntryTimings  int `json:"entry_timings"`  // 変換した入場時期の件数
+	UnparsedStart int `json:"unparsed_start"` // 解釈できなかった入場時期の件数
+	UnparsedEnd   int `json:"unparsed_end"`   // 解釈できなかった終了時期の件数（空を除く）
+}
@@ -0,0 +1,23 @@
+package domain
+
+import cd "business/internal/common/domain"
+
+// 一度に変換する案件数
+const DefaultPriceBatchSize = 500
+
+// ProjectPrice は案件の保存済みの単価と、単価を解釈するための表記です
+type ProjectPrice struct {
+	ProjectID uint
+	PriceFrom *int     // 保存済みの単価FROM
+	PriceTo   *int     // 保存済みの単価TO
+	Quotes    []string // 単価FROM/TOの根拠の引用
+	Body      string   // 引用が無い場合に単価の行を探す本文
+	Price     cd.Price // 解釈した単価
+}
+
+// PriceBackfillResult は単価の変換結果です
+type PriceBackfillResult struct {
+	Projects int `json:"projects"` // 変換した案件数
+	Monthly  int `json:"monthly"`  // 月額に換算できた案件数
+	Empty    int `json:"empty"`    // 単価の記載が無かった案件数
+}
@@ -0,0 +1,134 @@
+package domain
+
+import (
+	cd "business/internal/common/domain"
+	"time"
+)
+
+// ProjectListItem は案件一覧の1行です
+type ProjectListItem struct {
+	ProjectID         uint              `json:"project_id"`
+	EmailID           uint              `json:"email_id"`
+	GmailID           string            `json:"gmail_id"`
+	ReceivedDate      time.Time         `json:"received_date"`
+	Subject           string            `json:"subject"`
+	SenderName        string            `json:"sender_name"`
+	SenderEmail       string            `json:"sender_email"`
+	Category          string            `json:"category"`
+	ProjectTitle      string            `json:"project_title"`
+	EntryTimings      []string          `json:"entry_timings"`
+	EndTiming         string            `json:"end_timing"`
+	WorkLocation      string            `json:"work_location"`
+	PriceFrom         *int              `json:"price_from"`
+	PriceTo           *int              `json:"price_to"`
+	PriceUnit         string            `json:"price_unit"`         // 単価FROM/TOの単位（monthly / daily / hourly / yearly）
+	PriceTaxIncluded  *bool             `json:"price_tax_included"` // 税込なら true、税別なら false
+	PriceNegotiable   bool              `json:"price_negotiable"`   // スキル見合い・応相談
+	SettlementFrom    *int              `json:"settlement_from"`    // 精算幅の下限（時間）
+	SettlementTo      *int              `json:"settlement_to"`      // 精算幅の上限（時間）
+	MonthlyPriceFrom  *int              `json:"monthly_price_from"` // 税別の月額に換算した単価FROM
+	MonthlyPriceTo    *int              `json:"monthly_price_to"`   // 税別の月額に換算した単価TO
+	Languages         []string          `json:"languages"`
+	Frameworks        []string          `json:"frameworks"`
+	Positions         []string          `json:"positions"`
+	WorkTypes         []string          `json:"work_types"`
+	MustSkills        []string          `json:"must_skills"`
+	WantSkills        []string          `json:"want_skills"`
+	RemoteType        *string           `json:"remote_type"`
+	RemoteFrequency   *string           `json:"remote_frequency"`
+	IsRead            bool              `json:"is_read"`
+	IsGood            bool              `json:"is_good"`
+	IsBad             bool              `json:"is_bad"`
+	Note              string            `json:"note"`
+	ApplicationStatus ApplicationStatus `json:"application_status"`
+	LifecycleStatus   LifecycleStatus   `json:"lifecycle_status"`      // 募集状況
+	ArchivedAt        *time.Time        `json:"archived_at,omitempty"` // アーカイブした日時
+
+	Locations           []Location         `json:"locations"`                       // 勤務地を正規化した拠点
+	Cluster             *ClusterSummary    `json:"cluster,omitempty"`               // 重複グループ（同じ案件が他の営業会社からも届いている場合）
+	Evidences           []cd.FieldEvidence `json:"evidences"`                       // 項目ごとの信頼度と根拠
+	LowConfidenceFields []string           `json:"low_confidence_fields,omitempty"` // 信頼度の低い項目（highlight 指定時）
+
+	// 全文検索（q 指定時）
+	Relevance float64 `json:"relevance,omitempty"` // 関連度
+	Snippet   string  `json:"snippet,omitempty"`   // 一致箇所の抜粋（一致した語を<mark>で囲む）
+	Body      string  `json:"-"`                   // スニペット生成用の本文
+}
+
+// ProjectPage は案件一覧の1ページ分です
+type ProjectPage struct {
+	Items      []ProjectListItem `json:"items"`
+	NextCursor string            `json:"next_cursor"` // 次ページが無い場合は空
+}
+
+// SortPrice は単価順の並び替えに使う値を返します
+// 税別の月額に換算した単価TO・単価FROMを優先し、換算前の行は単価TO・単価FROMを使います。
+func (p ProjectListItem) SortPrice() int {
+	switch {
+	case p.MonthlyPriceTo != nil:
+		return *p.MonthlyPriceTo
+	case p.MonthlyPriceFrom != nil:
+		return *p.MonthlyPriceFrom
+	case p.PriceTo != nil:
+		return *p.PriceTo
+	case p.PriceFrom != nil:
+		return *p.PriceFrom
+	default:
+		return 0
+	}
+}
+
+// ApplyConfidence は低信頼度の値の扱いに従って行を加工します
+// 根拠が記録されていない項目は判定の対象外です。
+func (p ProjectListItem) ApplyConfidence(mode string, threshold float64) ProjectListItem {
+	if mode == LowConfidenceShow {
+		return p
+	}
+
+	var lowFields []string
+	for _, evidence := range p.Evidences {
+		if !evidence.IsLowConfidence(threshold) {
+			continue
+		}
+		lowFields = append(lowFields, evidence.Field)
+		if mode != LowConfidenceHide {
+			continue
+		}
+		switch evidence.Field {
+		case cd.EvidenceFieldProjectTitle:
+			p.ProjectTitle = ""
+		case cd.EvidenceFieldStartPeriod:
+			p.EntryTimings = []string{}
+		case cd.EvidenceFieldEndPeriod:
+			p.EndTiming = ""
+		case cd.EvidenceFieldWorkLocation:
+			p.WorkLocation = ""
+		case cd.EvidenceFieldPriceFrom:
+			p.PriceFrom = nil
+			p.MonthlyPriceFrom = nil
+		case cd.EvidenceFieldPriceTo:
+			p.PriceTo = nil
+			p.MonthlyPriceTo = nil
+		case cd.EvidenceFieldLanguages:
+			p.Languages = []string{}
+		case cd.EvidenceFieldFrameworks:
+			p.Frameworks = []string{}
+		case cd.EvidenceFieldRemote:
+			p.RemoteType = nil
+			p.RemoteFrequency = nil
+		}
+	}
+	if mode == LowConfidenceHighlight {
+		p.LowConfidenceFields = lowFields
+	}
+	return p
+}
+
+// ClusterSummary は案件が含まれる重複グループの概要です
+type ClusterSummary struct {
+	ID          uint `json:"id"`
+	Size        int  `json:"size"`         // 案件数
+	AgencyCount int  `json:"agency_count"` // 営業会社数（差出人のドメイン数）
+	PriceMin    *int `json:"price_min"`    // 税別の月額に換算した単価の最小
+	PriceMax    *int `json:"price_max"`    // 税別の月額に換算した単価の最大
+}
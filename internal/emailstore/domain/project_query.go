@@ -0,0 +1,186 @@
+// Package domain はメール保存機能のドメイン層を提供します。
+// このファイルは保存済み案件の検索条件を定義します。
+package domain
+
+import (
+	cd "business/internal/common/domain"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// 一覧の並び順
+const (
+	SortReceivedDesc = "received_desc" // 受信日の新しい順（既定）
+	SortReceivedAsc  = "received_asc"  // 受信日の古い順
+	SortPriceDesc    = "price_desc"    // 単価の高い順
+	SortPriceAsc     = "price_asc"     // 単価の低い順
+	SortRelevance    = "relevance"     // 全文検索の関連度順（Text 指定時の既定）
+)
+
+// 複数指定した値の一致条件
+const (
+	MatchAny = "any" // いずれかに一致
+	MatchAll = "all" // すべてに一致
+)
+
+// 低信頼度の値の扱い
+const (
+	LowConfidenceShow      = ""          // そのまま返す（既定）
+	LowConfidenceHide      = "hide"      // 値を空にして返す
+	LowConfidenceHighlight = "highlight" // 値はそのままで low_confidence_fields に項目名を列挙する
+)
+
+// 一覧の取得件数
+const (
+	DefaultProjectLimit = 50
+	MaxProjectLimit     = 200
+)
+
+// ErrInvalidProjectQuery は検索条件が不正な場合のエラーです
+var ErrInvalidProjectQuery = errors.New("検索条件が不正です")
+
+// ProjectQuery は保存済み案件の検索条件です
+type ProjectQuery struct {
+	Text string // 件名・本文・案件名の全文検索（MySQLのBOOLEAN MODEの検索式）
+
+	ReceivedFrom *time.Time // 受信日FROM（この日時以降）
+	ReceivedTo   *time.Time // 受信日TO（この日時より前）
+	Category     string     // メール区分（案件 / 人材）
+
+	// 入場時期は日付の範囲に解釈した値で絞り込みます（範囲が重なる入場時期があれば一致）
+	StartFrom *time.Time // 入場日FROM（この日以降に入場できる）
+	StartTo   *time.Time // 入場日TO（この日以前に入場できる）
+
+	PriceMin *int // 単価の下限（税別の月額に換算した単価TOがこの値以上）
+	PriceMax *int // 単価の上限（税別の月額に換算した単価FROMがこの値以下）
+
+	// 技術キーワードは表記ゆれを含めてキーワードグループに展開して検索します
+	Languages      []string
+	LanguageMatch  string // any / all
+	Frameworks     []string
+	FrameworkMatch string // any / all
+
+	Positions    []string // ポジション（いずれかに一致）
+	WorkTypes    []string // 業務種別（いずれかに一致）
+	RemoteTypes  []string // リモート区分（いずれかに一致）
+	WorkLocation string   // 勤務場所（部分一致）
+	Sender       string   // 差出人名・メールアドレス（部分一致）
+
+	// 勤務地は正規化した拠点（project_locations）で絞り込みます
+	Prefectures []string // 都道府県（いずれかに一致。略称はユースケースで正式名に変換）
+	Station     string   // 最寄り駅（この駅から RadiusKm 以内の拠点がある案件）
+	RadiusKm    float64  // 最寄り駅からの距離（km。0の場合は既定値）
+	NearLat     *float64 // Station の緯度（ユースケースで駅の辞書から設定）
+	NearLng     *float64 // Station の経度
+
+	Collapse bool // 重複グループ（複数の営業会社から届いた同じ案件）は代表の案件だけを返す
+
+	IsRead *bool
+	IsGood *bool
+	IsBad  *bool
+
+	Statuses []ApplicationStatus // 応募状況（いずれかに一致）
+
+	Lifecycles      []LifecycleStatus // 募集状況（いずれかに一致）
+	IncludeArchived bool              // アーカイブした案件も返す（既定では除外）
+
+	LowConfidence string  // 低信頼度の値の扱い（hide / highlight）
+	MinConfidence float64 // 低信頼度とみなす閾値（0の場合は既定値）
+
+	Sort   string // 並び順
+	Cursor string // 前ページの next_cursor
+	Limit  int    // 取得件数
+}
+
+// Normalize は未指定の項目に既定値を設定し、検索条件を検証します
+func (q ProjectQuery) Normalize() (ProjectQuery, error) {
+	q.Text = strings.TrimSpace(q.Text)
+	if q.Sort == "" {
+		q.Sort = SortReceivedDesc
+		if q.Text != "" {
+			q.Sort = SortRelevance
+		}
+	}
+	switch q.Sort {
+	case SortReceivedDesc, SortReceivedAsc, SortPriceDesc, SortPriceAsc:
+	case SortRelevance:
+		if q.Text == "" {
+			return q, errors.Join(ErrInvalidProjectQuery, errors.New("sort=relevance は q と合わせて指定してください"))
+		}
+	default:
+		return q, errors.Join(ErrInvalidProjectQuery, errors.New("sort は received_desc / received_asc / price_desc / price_asc / relevance のいずれかを指定してください"))
+	}
+
+	for _, match := range []*string{&q.LanguageMatch, &q.FrameworkMatch} {
+		if *match == "" {
+			*match = MatchAny
+		}
+		if *match != MatchAny && *match != MatchAll {
+			return q, errors.Join(ErrInvalidProjectQuery, errors.New("一致条件は any / all のいずれかを指定してください"))
+		}
+	}
+
+	switch q.LowConfidence {
+	case LowConfidenceShow, LowConfidenceHide, LowConfidenceHighlight:
+	default:
+		return q, errors.Join(ErrInvalidProjectQuery, errors.New("low_confidence は hide / highlight のいずれかを指定してください"))
+	}
+	if q.MinConfidence < 0 || q.MinConfidence > 1 {
+		return q, errors.Join(ErrInvalidProjectQuery, errors.New("min_confidence は0〜1で指定してください"))
+	}
+	if q.MinConfidence == 0 {
+		q.MinConfidence = cd.DefaultLowConfidenceThreshold
+	}
+
+	if q.PriceMin != nil && q.PriceMax != nil && *q.PriceMin > *q.PriceMax {
+		return q, errors.Join(ErrInvalidProjectQuery, errors.New("price_min は price_max 以下で指定してください"))
+	}
+	if q.ReceivedFrom != nil && q.ReceivedTo != nil && !q.ReceivedFrom.Before(*q.ReceivedTo) {
+		return q, errors.Join(ErrInvalidProjectQuery, errors.New("from は to より前の日付を指定してください"))
+	}
+	if q.StartFrom != nil && q.StartTo != nil && q.StartFrom.After(*q.StartTo) {
+		return q, errors.Join(ErrInvalidProjectQuery, errors.New("start_from は start_to 以前の日付を指定してください"))
+	}
+
+	if q.RadiusKm < 0 || q.RadiusKm > MaxRadiusKm {
+		return q, errors.Join(ErrInvalidProjectQuery, fmt.Errorf("radius_km は0〜%gで指定してください", MaxRadiusKm))
+	}
+	if q.RadiusKm != 0 && strings.TrimSpace(q.Station) == "" {
+		return q, errors.Join(ErrInvalidProjectQuery, errors.New("radius_km は station と合わせて指定してください"))
+	}
+	if q.RadiusKm == 0 && strings.TrimSpace(q.Station) != "" {
+		q.RadiusKm = DefaultRadiusKm
+	}
+
+	for _, status := range q.Statuses {
+		if !status.IsValid() {
+			return q, errors.Join(ErrInvalidProjectQuery, fmt.Errorf("statuses が不正です: %s", status))
+		}
+	}
+	for _, status := range q.Lifecycles {
+		if !status.IsValid() {
+			return q, errors.Join(ErrInvalidProjectQuery, fmt.Errorf("lifecycle は open / closed / expired / unknown のいずれかを指定してください: %s", status))
+		}
+	}
+
+	if q.Limit <= 0 {
+		q.Limit = DefaultProjectLimit
+	}
+	if q.Limit > MaxProjectLimit {
+		q.Limit = MaxProjectLimit
+	}
+
+	if q.Cursor != "" {
+		cursor, err := DecodeCursor(q.Cursor)
+		if err != nil {
+			return q, errors.Join(ErrInvalidProjectQuery, err)
+		}
+		if cursor.Sort != q.Sort {
+			return q, errors.Join(ErrInvalidProjectQuery, errors.New("cursor と sort の組み合わせが不正です"))
+		}
+	}
+
+	return q, nil
+}
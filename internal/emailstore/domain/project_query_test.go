@@ -0,0 +1,86 @@
+package domain
+
+import (
+	cd "business/internal/common/domain"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectQuery_Normalize(t *testing.T) {
+	q, err := ProjectQuery{}.Normalize()
+	require.NoError(t, err)
+	assert.Equal(t, SortReceivedDesc, q.Sort)
+	assert.Equal(t, MatchAny, q.LanguageMatch)
+	assert.Equal(t, MatchAny, q.FrameworkMatch)
+	assert.Equal(t, DefaultProjectLimit, q.Limit)
+	assert.Equal(t, cd.DefaultLowConfidenceThreshold, q.MinConfidence)
+
+	q, err = ProjectQuery{Text: " 金融系 "}.Normalize()
+	require.NoError(t, err)
+	assert.Equal(t, "金融系", q.Text)
+	assert.Equal(t, SortRelevance, q.Sort)
+
+	q, err = ProjectQuery{Limit: 1000}.Normalize()
+	require.NoError(t, err)
+	assert.Equal(t, MaxProjectLimit, q.Limit)
+
+	from := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	cursor := Cursor{Sort: SortPriceDesc, ID: 1}.Encode()
+	invalids := []ProjectQuery{
+		{Sort: "unknown"},
+		{Sort: SortRelevance},
+		{LanguageMatch: "some"},
+		{LowConfidence: "remove"},
+		{MinConfidence: 1.5},
+		{PriceMin: lo.ToPtr(800000), PriceMax: lo.ToPtr(600000)},
+		{ReceivedFrom: &from, ReceivedTo: &to},
+		{StartFrom: &from, StartTo: &to},
+		{Cursor: "!!"},
+		{Cursor: cursor, Sort: SortReceivedDesc},
+	}
+	for _, invalid := range invalids {
+		_, err := invalid.Normalize()
+		assert.ErrorIs(t, err, ErrInvalidProjectQuery)
+	}
+}
+
+func TestCursor_EncodeDecode(t *testing.T) {
+	received := time.Date(2025, 6, 1, 9, 30, 0, 0, time.UTC)
+	item := ProjectListItem{ProjectID: 10, ReceivedDate: received, PriceFrom: lo.ToPtr(600000)}
+
+	decoded, err := DecodeCursor(CursorOf(SortPriceDesc, item).Encode())
+
+	require.NoError(t, err)
+	assert.Equal(t, SortPriceDesc, decoded.Sort)
+	assert.Equal(t, uint(10), decoded.ID)
+	assert.Equal(t, 600000, decoded.Price)
+	assert.True(t, received.Equal(decoded.Received))
+}
+
+func TestProjectListItem_ApplyConfidence(t *testing.T) {
+	item := ProjectListItem{
+		WorkLocation: "東京",
+		PriceTo:      lo.ToPtr(700000),
+		Evidences: []cd.FieldEvidence{
+			{Field: cd.EvidenceFieldWorkLocation, Confidence: 0.9, Quote: "■場所:東京"},
+			{Field: cd.EvidenceFieldPriceTo, Confidence: 0.3},
+		},
+	}
+
+	shown := item.ApplyConfidence(LowConfidenceShow, 0.5)
+	assert.Equal(t, item, shown)
+
+	hidden := item.ApplyConfidence(LowConfidenceHide, 0.5)
+	assert.Equal(t, "東京", hidden.WorkLocation)
+	assert.Nil(t, hidden.PriceTo)
+	assert.Empty(t, hidden.LowConfidenceFields)
+
+	highlighted := item.ApplyConfidence(LowConfidenceHighlight, 0.5)
+	assert.Equal(t, 700000, *highlighted.PriceTo)
+	assert.Equal(t, []string{cd.EvidenceFieldPriceTo}, highlighted.LowConfidenceFields)
+}
@@ -0,0 +1,139 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ApplicationStatus は案件への応募状況です
+type ApplicationStatus string
+
+// 応募状況
+const (
+	StatusUntouched ApplicationStatus = "未対応"
+	StatusApplied   ApplicationStatus = "応募済"
+	StatusInterview ApplicationStatus = "面談"
+	StatusDeclined  ApplicationStatus = "見送り"
+	StatusDecided   ApplicationStatus = "決定"
+)
+
+// ApplicationStatuses は応募状況の一覧です（表示順）
+var ApplicationStatuses = []ApplicationStatus{
+	StatusUntouched,
+	StatusApplied,
+	StatusInterview,
+	StatusDeclined,
+	StatusDecided,
+}
+
+var (
+	// ErrInvalidTriage はメールの仕分け内容が不正な場合のエラーです
+	ErrInvalidTriage = errors.New("仕分け内容が不正です")
+
+	// ErrEmailNotFound は指定したGメールIDのメールが保存されていない場合のエラーです
+	ErrEmailNotFound = errors.New("メールが見つかりません")
+
+	// ErrProjectNotFound は指定した案件ID（email_projects.id）の案件が保存されていない場合のエラーです
+	ErrProjectNotFound = errors.New("案件が見つかりません")
+
+	// ErrStatusConflict は応募状況を読み込んだ後に、ほかの更新で応募状況が変わっていた場合のエラーです
+	ErrStatusConflict = errors.New("応募状況がほかの操作で更新されています")
+)
+
+// IsValid は定義済みの応募状況かどうかを返します
+func (s ApplicationStatus) IsValid() bool {
+	for _, status := range ApplicationStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// Triage はメールの仕分け（既読・いいね・びみょう・メモ）の更新内容です
+// nil の項目は更新しません。応募状況は1通に載った案件ごとに StatusUpdate で更新します。
+type Triage struct {
+	IsRead *bool   `json:"is_read"`
+	IsGood *bool   `json:"is_good"`
+	IsBad  *bool   `json:"is_bad"`
+	Note   *string `json:"note"`
+}
+
+// TriageState はメールの仕分けの現在値です
+type TriageState struct {
+	GmailID string `json:"gmail_id"`
+	IsRead  bool   `json:"is_read"`
+	IsGood  bool   `json:"is_good"`
+	IsBad   bool   `json:"is_bad"`
+	Note    string `json:"note"`
+}
+
+// StatusUpdate は案件の応募状況の更新内容です
+type StatusUpdate struct {
+	Status ApplicationStatus `json:"status"`
+	Note   string            `json:"note"` // 変更時点のメモ（変更履歴に残ります）
+}
+
+// ProjectStatus は案件の応募状況の現在値です
+type ProjectStatus struct {
+	ProjectID uint              `json:"project_id"`
+	GmailID   string            `json:"gmail_id"`
+	Status    ApplicationStatus `json:"status"`
+}
+
+// StatusChange は応募状況の変更履歴の1件です
+type StatusChange struct {
+	ProjectID uint              `json:"project_id"`
+	From      ApplicationStatus `json:"from"`
+	To        ApplicationStatus `json:"to"`
+	Note      string            `json:"note"` // 変更時点のメモ
+	ChangedAt time.Time         `json:"changed_at"`
+}
+
+// Validate は更新内容を検証します
+func (t Triage) Validate() error {
+	var errs []error
+	if t.IsRead == nil && t.IsGood == nil && t.IsBad == nil && t.Note == nil {
+		errs = append(errs, errors.New("更新する項目を指定してください"))
+	}
+	if t.IsGood != nil && t.IsBad != nil && *t.IsGood && *t.IsBad {
+		errs = append(errs, errors.New("is_good と is_bad は同時に true にできません"))
+	}
+	if len(errs) > 0 {
+		return errors.Join(append([]error{ErrInvalidTriage}, errs...)...)
+	}
+	return nil
+}
+
+// Apply は現在値に更新内容を反映した値を返します
+// いいねとびみょうは排他で、一方を true にするともう一方は false になります。
+func (t Triage) Apply(state TriageState) TriageState {
+	if t.IsRead != nil {
+		state.IsRead = *t.IsRead
+	}
+	if t.IsGood != nil {
+		state.IsGood = *t.IsGood
+		if state.IsGood {
+			state.IsBad = false
+		}
+	}
+	if t.IsBad != nil {
+		state.IsBad = *t.IsBad
+		if state.IsBad {
+			state.IsGood = false
+		}
+	}
+	if t.Note != nil {
+		state.Note = *t.Note
+	}
+	return state
+}
+
+// Validate は応募状況の更新内容を検証します
+func (u StatusUpdate) Validate() error {
+	if !u.Status.IsValid() {
+		return fmt.Errorf("%w: status が不正です: %s", ErrInvalidTriage, u.Status)
+	}
+	return nil
+}
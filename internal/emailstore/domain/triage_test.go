@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTriage_Validate(t *testing.T) {
+	require.NoError(t, Triage{IsRead: lo.ToPtr(true)}.Validate())
+	require.NoError(t, Triage{Note: lo.ToPtr("")}.Validate())
+
+	invalids := []Triage{
+		{},
+		{IsGood: lo.ToPtr(true), IsBad: lo.ToPtr(true)},
+	}
+	for _, triage := range invalids {
+		assert.ErrorIs(t, triage.Validate(), ErrInvalidTriage, "%+v", triage)
+	}
+}
+
+func TestTriage_Apply(t *testing.T) {
+	state := TriageState{GmailID: "gmail-1", IsBad: true, Note: "単価低め"}
+
+	// いいねにするとびみょうは外れる
+	got := Triage{IsRead: lo.ToPtr(true), IsGood: lo.ToPtr(true)}.Apply(state)
+	assert.Equal(t, TriageState{GmailID: "gmail-1", IsRead: true, IsGood: true, Note: "単価低め"}, got)
+
+	got = Triage{Note: lo.ToPtr("面談日調整中")}.Apply(state)
+	assert.Equal(t, "面談日調整中", got.Note)
+	assert.True(t, got.IsBad)
+}
+
+func TestStatusUpdate_Validate(t *testing.T) {
+	require.NoError(t, StatusUpdate{Status: StatusInterview}.Validate())
+
+	assert.ErrorIs(t, StatusUpdate{}.Validate(), ErrInvalidTriage)
+	assert.ErrorIs(t, StatusUpdate{Status: "辞退"}.Validate(), ErrInvalidTriage)
+}
@@ -0,0 +1,107 @@
+package infrastructure
+
+import (
+	cd "business/internal/common/domain"
+	"business/tools/jpdate"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// saveCandidateDetails は人材メールの詳細情報とキーワードの紐付けをまとめて保存します
+// 人材の詳細は1メール1行のため、同じメールの解析結果が複数ある場合は最初の解析結果を使い、保存済みのメールは保存しません。
+// キーワードは案件と同じキーワードグループに解決し、案件との照合に使います。
+func (r *Repository) saveCandidateDetails(tx *gorm.DB, results []cd.Email, emailIDs map[string]uint) error {
+	ids := make([]uint, 0, len(results))
+	for _, result := range results {
+		ids = append(ids, emailIDs[result.GmailID])
+	}
+
+	var existing []uint
+	err := tx.Model(&EmailCandidate{}).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("email_id IN ?", ids).
+		Pluck("email_id", &existing).Error
+	if err != nil {
+		return fmt.Errorf("EmailCandidate存在チェックエラー: %w", err)
+	}
+	saved := make(map[uint]struct{}, len(existing)+len(results))
+	for _, id := range existing {
+		saved[id] = struct{}{}
+	}
+
+	candidates := make([]EmailCandidate, 0, len(results))
+	targets := make([]cd.Email, 0, len(results))
+	for _, result := range results {
+		emailID := emailIDs[result.GmailID]
+		if _, ok := saved[emailID]; ok {
+			continue
+		}
+		saved[emailID] = struct{}{}
+		candidates = append(candidates, setEmailCandidate(result, emailID))
+		targets = append(targets, result)
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	keywordGroupIDs, err := resolveKeywordGroups(tx, targets)
+	if err != nil {
+		return fmt.Errorf("KeywordGroup取得/作成エラー: %w", err)
+	}
+	if err := tx.Omit(clause.Associations).CreateInBatches(&candidates, insertBatchSize).Error; err != nil {
+		return fmt.Errorf("EmailCandidate保存エラー: %w", err)
+	}
+
+	var links []EmailCandidateKeywordGroup
+	for i, result := range targets {
+		var names []string
+		for _, kw := range keywordsOf(result) {
+			names = append(names, kw.name)
+		}
+		for _, groupID := range uniqueGroupIDs(names, keywordGroupIDs) {
+			links = append(links, EmailCandidateKeywordGroup{EmailCandidateID: candidates[i].ID, KeywordGroupID: groupID})
+		}
+	}
+	if len(links) == 0 {
+		return nil
+	}
+	if err := tx.CreateInBatches(&links, insertBatchSize).Error; err != nil {
+		return fmt.Errorf("EmailCandidateKeywordGroup保存エラー: %w", err)
+	}
+	return nil
+}
+
+// setEmailCandidate は解析結果から保存するEmailCandidateを作成します
+// 人材メールでは案件名を人材名、開始時期を参画可能日、単価を希望単価、勤務場所を希望勤務地として扱います。
+func setEmailCandidate(result cd.Email, emailID uint) EmailCandidate {
+	var name *string
+	if result.ProjectName != "" {
+		name = &result.ProjectName
+	}
+	availability := strings.Join(result.StartPeriod, ",")
+	var available jpdate.Range
+	if len(result.StartPeriod) > 0 {
+		available, _ = jpdate.Parse(result.StartPeriod[0], result.ReceivedDate)
+	}
+	skills := strings.Join(collectNames([]cd.Email{result}, func(e cd.Email) []string {
+		return append(append(append([]string{}, e.Languages...), e.Frameworks...), e.RequiredSkillsMust...)
+	}), ",")
+	price := cd.ResolvePrice(priceText(result), result.PriceFrom, result.PriceTo)
+
+	return EmailCandidate{
+		EmailID:          emailID,
+		CandidateName:    name,
+		SkillsSummary:    &result.Summary,
+		AvailabilityDate: &availability,
+		AvailableFrom:    available.From,
+		AvailableFlag:    string(available.Flag),
+		Skills:           &skills,
+		MonthlyPriceFrom: price.MonthlyFrom(),
+		MonthlyPriceTo:   price.MonthlyTo(),
+		WorkLocation:     &result.WorkLocation,
+		RemoteType:       result.RemoteWorkCategory,
+	}
+}
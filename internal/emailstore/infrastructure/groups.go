@@ -0,0 +1,336 @@
+package infrastructure
+
+import (
+	cd "business/internal/common/domain"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// insertBatchSize は CreateInBatches で1回のINSERTにまとめる行数です
+const insertBatchSize = 200
+
+// keyword は解析結果のキーワードと種類です
+type keyword struct {
+	name        string
+	keywordType string
+}
+
+// keywordsOf は解析結果の言語・フレームワーク・必須スキル・希望スキルを出現順に返します
+// 空文字は除きます。
+func keywordsOf(result cd.Email) []keyword {
+	var keywords []keyword
+	for _, kind := range []struct {
+		words       []string
+		keywordType string
+	}{
+		{result.Languages, "language"},
+		{result.Frameworks, "framework"},
+		{result.RequiredSkillsMust, "must"},
+		{result.RequiredSkillsWant, "want"},
+	} {
+		for _, word := range kind.words {
+			if word == "" {
+				continue
+			}
+			keywords = append(keywords, keyword{name: word, keywordType: kind.keywordType})
+		}
+	}
+	return keywords
+}
+
+// foldName はマスタの名前を照合するキーを返します
+// DBの照合順序は大文字・小文字を区別しないため、IN検索の結果と突き合わせる際も区別しません。
+func foldName(name string) string {
+	return strings.ToLower(name)
+}
+
+// collectNames は解析結果から取り出した名前を、空文字と重複を除いて出現順に返します
+func collectNames(results []cd.Email, names func(cd.Email) []string) []string {
+	var collected []string
+	seen := make(map[string]struct{})
+	for _, result := range results {
+		for _, name := range names(result) {
+			if name == "" {
+				continue
+			}
+			if _, ok := seen[foldName(name)]; ok {
+				continue
+			}
+			seen[foldName(name)] = struct{}{}
+			collected = append(collected, name)
+		}
+	}
+	return collected
+}
+
+// resolveKeywordGroups は解析結果のキーワードに対応するKeywordGroupのIDを返します
+// グループは名前、別名（KeyWord）の順に一括検索し、どちらにもないものはキーワードの最初の種類でまとめて作成します。
+// 同じ名前の表記（KeyWord）とグループへの紐付けもなければ作成します。
+func resolveKeywordGroups(tx *gorm.DB, results []cd.Email) (map[string]uint, error) {
+	types := make(map[string]string)
+	var names []string
+	for _, result := range results {
+		for _, kw := range keywordsOf(result) {
+			if _, ok := types[foldName(kw.name)]; ok {
+				continue
+			}
+			types[foldName(kw.name)] = kw.keywordType
+			names = append(names, kw.name)
+		}
+	}
+	groupIDs := make(map[string]uint, len(names))
+	if len(names) == 0 {
+		return groupIDs, nil
+	}
+
+	// 同じ名前のグループが複数ある場合はIDの小さいものを使う
+	var groups []KeywordGroup
+	if err := tx.Where("name IN ?", names).Order("keyword_group_id").Find(&groups).Error; err != nil {
+		return nil, fmt.Errorf("KeywordGroup検索エラー: %w", err)
+	}
+	for _, g := range groups {
+		if _, ok := groupIDs[foldName(g.Name)]; !ok {
+			groupIDs[foldName(g.Name)] = g.KeywordGroupID
+		}
+	}
+
+	// 別名（表記）として登録済みの名前は、紐付いているグループを使う
+	if unresolved := unresolvedNames(names, groupIDs); len(unresolved) > 0 {
+		var aliases []struct {
+			KeywordGroupID uint
+			Word           string
+		}
+		err := tx.Table("key_words AS w").
+			Select("l.keyword_group_id, w.word").
+			Joins("JOIN keyword_group_word_links l ON l.key_word_id = w.id").
+			Where("w.word IN ?", unresolved).
+			Order("l.keyword_group_id").
+			Scan(&aliases).Error
+		if err != nil {
+			return nil, fmt.Errorf("KeyWord検索エラー: %w", err)
+		}
+		for _, a := range aliases {
+			if _, ok := groupIDs[foldName(a.Word)]; !ok {
+				groupIDs[foldName(a.Word)] = a.KeywordGroupID
+			}
+		}
+	}
+
+	var newGroups []KeywordGroup
+	for _, name := range names {
+		if _, ok := groupIDs[foldName(name)]; !ok {
+			newGroups = append(newGroups, KeywordGroup{Name: name, Type: types[foldName(name)]})
+		}
+	}
+	if len(newGroups) > 0 {
+		if err := tx.Omit(clause.Associations).CreateInBatches(&newGroups, insertBatchSize).Error; err != nil {
+			return nil, fmt.Errorf("KeywordGroup作成エラー: %w", err)
+		}
+		for _, g := range newGroups {
+			groupIDs[foldName(g.Name)] = g.KeywordGroupID
+		}
+	}
+
+	wordIDs, err := resolveKeyWords(tx, names)
+	if err != nil {
+		return nil, err
+	}
+
+	// グループと表記の紐付けのうち、未登録のものを作成
+	ids := make([]uint, 0, len(wordIDs))
+	for _, id := range wordIDs {
+		ids = append(ids, id)
+	}
+	var links []KeywordGroupWordLink
+	if err := tx.Select("keyword_group_id", "key_word_id").Where("key_word_id IN ?", ids).Find(&links).Error; err != nil {
+		return nil, fmt.Errorf("KeywordGroupWordLink確認エラー: %w", err)
+	}
+	linked := make(map[[2]uint]struct{}, len(links))
+	for _, l := range links {
+		linked[[2]uint{l.KeywordGroupID, l.KeyWordID}] = struct{}{}
+	}
+	var newLinks []KeywordGroupWordLink
+	for _, name := range names {
+		pair := [2]uint{groupIDs[foldName(name)], wordIDs[foldName(name)]}
+		if _, ok := linked[pair]; ok {
+			continue
+		}
+		linked[pair] = struct{}{}
+		newLinks = append(newLinks, KeywordGroupWordLink{KeywordGroupID: pair[0], KeyWordID: pair[1]})
+	}
+	if len(newLinks) > 0 {
+		err := tx.Omit(clause.Associations).Clauses(clause.OnConflict{DoNothing: true}).
+			CreateInBatches(&newLinks, insertBatchSize).Error
+		if err != nil {
+			return nil, fmt.Errorf("KeywordGroupWordLink作成エラー: %w", err)
+		}
+	}
+
+	return groupIDs, nil
+}
+
+// resolveKeyWords は表記（KeyWord）のIDを返します
+// 未登録の表記はまとめて作成します。同時に作成された表記は作成せず、取得し直したIDを使います。
+func resolveKeyWords(tx *gorm.DB, names []string) (map[string]uint, error) {
+	wordIDs := make(map[string]uint, len(names))
+	find := func(words []string) error {
+		var found []KeyWord
+		if err := tx.Where("word IN ?", words).Find(&found).Error; err != nil {
+			return fmt.Errorf("KeyWord検索エラー: %w", err)
+		}
+		for _, w := range found {
+			wordIDs[foldName(w.Word)] = w.ID
+		}
+		return nil
+	}
+
+	if err := find(names); err != nil {
+		return nil, err
+	}
+	var missing []string
+	var newWords []KeyWord
+	for _, name := range names {
+		if _, ok := wordIDs[foldName(name)]; !ok {
+			missing = append(missing, name)
+			newWords = append(newWords, KeyWord{Word: name})
+		}
+	}
+	if len(newWords) == 0 {
+		return wordIDs, nil
+	}
+
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(&newWords, insertBatchSize).Error; err != nil {
+		return nil, fmt.Errorf("KeyWord作成エラー: %w", err)
+	}
+	if err := find(missing); err != nil {
+		return nil, err
+	}
+	for _, name := range missing {
+		if _, ok := wordIDs[foldName(name)]; !ok {
+			return nil, fmt.Errorf("KeyWord作成エラー: %s が見つかりません", name)
+		}
+	}
+	return wordIDs, nil
+}
+
+// resolvePositionGroups はポジション名に対応するPositionGroupのIDを返します
+// グループ名、表記（PositionWord）の順に一括検索し、どちらにもないものはグループと表記をまとめて作成します。
+func resolvePositionGroups(tx *gorm.DB, names []string) (map[string]uint, error) {
+	groupIDs := make(map[string]uint, len(names))
+	if len(names) == 0 {
+		return groupIDs, nil
+	}
+
+	var groups []PositionGroup
+	if err := tx.Where("name IN ?", names).Find(&groups).Error; err != nil {
+		return nil, fmt.Errorf("PositionGroup検索エラー: %w", err)
+	}
+	for _, g := range groups {
+		groupIDs[foldName(g.Name)] = g.PositionGroupID
+	}
+
+	// 表記ゆれとして既に存在するかチェック（同じ表記が複数ある場合は先に登録されたものを使う）
+	unresolved := unresolvedNames(names, groupIDs)
+	if len(unresolved) > 0 {
+		var words []PositionWord
+		if err := tx.Where("word IN ?", unresolved).Order("id").Find(&words).Error; err != nil {
+			return nil, fmt.Errorf("PositionWord検索エラー: %w", err)
+		}
+		for _, w := range words {
+			if _, ok := groupIDs[foldName(w.Word)]; !ok {
+				groupIDs[foldName(w.Word)] = w.PositionGroupID
+			}
+		}
+	}
+
+	// 新規作成（表記ゆれとして同じ名前も登録）
+	unresolved = unresolvedNames(names, groupIDs)
+	if len(unresolved) == 0 {
+		return groupIDs, nil
+	}
+	newGroups := make([]PositionGroup, 0, len(unresolved))
+	for _, name := range unresolved {
+		newGroups = append(newGroups, PositionGroup{Name: name})
+	}
+	if err := tx.Omit(clause.Associations).CreateInBatches(&newGroups, insertBatchSize).Error; err != nil {
+		return nil, fmt.Errorf("PositionGroup作成エラー: %w", err)
+	}
+	newWords := make([]PositionWord, 0, len(newGroups))
+	for _, g := range newGroups {
+		groupIDs[foldName(g.Name)] = g.PositionGroupID
+		newWords = append(newWords, PositionWord{PositionGroupID: g.PositionGroupID, Word: g.Name})
+	}
+	if err := tx.CreateInBatches(&newWords, insertBatchSize).Error; err != nil {
+		return nil, fmt.Errorf("PositionWord作成エラー: %w", err)
+	}
+
+	return groupIDs, nil
+}
+
+// resolveWorkTypeGroups は業務種別名に対応するWorkTypeGroupのIDを返します
+// グループ名、表記（WorkTypeWord）の順に一括検索し、どちらにもないものはグループと表記をまとめて作成します。
+func resolveWorkTypeGroups(tx *gorm.DB, names []string) (map[string]uint, error) {
+	groupIDs := make(map[string]uint, len(names))
+	if len(names) == 0 {
+		return groupIDs, nil
+	}
+
+	var groups []WorkTypeGroup
+	if err := tx.Where("name IN ?", names).Find(&groups).Error; err != nil {
+		return nil, fmt.Errorf("WorkTypeGroup検索エラー: %w", err)
+	}
+	for _, g := range groups {
+		groupIDs[foldName(g.Name)] = g.WorkTypeGroupID
+	}
+
+	// 表記ゆれとして既に存在するかチェック（同じ表記が複数ある場合は先に登録されたものを使う）
+	unresolved := unresolvedNames(names, groupIDs)
+	if len(unresolved) > 0 {
+		var words []WorkTypeWord
+		if err := tx.Where("word IN ?", unresolved).Order("id").Find(&words).Error; err != nil {
+			return nil, fmt.Errorf("WorkTypeWord検索エラー: %w", err)
+		}
+		for _, w := range words {
+			if _, ok := groupIDs[foldName(w.Word)]; !ok {
+				groupIDs[foldName(w.Word)] = w.WorkTypeGroupID
+			}
+		}
+	}
+
+	// 新規作成（表記ゆれとして同じ名前も登録）
+	unresolved = unresolvedNames(names, groupIDs)
+	if len(unresolved) == 0 {
+		return groupIDs, nil
+	}
+	newGroups := make([]WorkTypeGroup, 0, len(unresolved))
+	for _, name := range unresolved {
+		newGroups = append(newGroups, WorkTypeGroup{Name: name})
+	}
+	if err := tx.Omit(clause.Associations).CreateInBatches(&newGroups, insertBatchSize).Error; err != nil {
+		return nil, fmt.Errorf("WorkTypeGroup作成エラー: %w", err)
+	}
+	newWords := make([]WorkTypeWord, 0, len(newGroups))
+	for _, g := range newGroups {
+		groupIDs[foldName(g.Name)] = g.WorkTypeGroupID
+		newWords = append(newWords, WorkTypeWord{WorkTypeGroupID: g.WorkTypeGroupID, Word: g.Name})
+	}
+	if err := tx.CreateInBatches(&newWords, insertBatchSize).Error; err != nil {
+		return nil, fmt.Errorf("WorkTypeWord作成エラー: %w", err)
+	}
+
+	return groupIDs, nil
+}
+
+// unresolvedNames はグループIDが決まっていない名前を返します
+func unresolvedNames(names []string, groupIDs map[string]uint) []string {
+	var unresolved []string
+	for _, name := range names {
+		if _, ok := groupIDs[foldName(name)]; !ok {
+			unresolved = append(unresolved, name)
+		}
+	}
+	return unresolved
+}
@@ -0,0 +1,194 @@
+package infrastructure
+
+import (
+	cd "business/internal/common/domain"
+	"business/tools/migrations/model"
+	"business/tools/mysql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestKeywordsOf(t *testing.T) {
+	result := cd.Email{
+		Languages:          []string{"Go", ""},
+		Frameworks:         []string{"Gin"},
+		RequiredSkillsMust: []string{"Go"},
+		RequiredSkillsWant: []string{"AWS"},
+	}
+	assert.Equal(t, []keyword{
+		{name: "Go", keywordType: "language"},
+		{name: "Gin", keywordType: "framework"},
+		{name: "Go", keywordType: "must"},
+		{name: "AWS", keywordType: "want"},
+	}, keywordsOf(result))
+}
+
+func TestCollectNames(t *testing.T) {
+	results := []cd.Email{
+		{Positions: []string{"PM", "", "SE"}},
+		{Positions: []string{"pm", "PG"}},
+	}
+	names := collectNames(results, func(e cd.Email) []string { return e.Positions })
+	assert.Equal(t, []string{"PM", "SE", "PG"}, names)
+}
+
+func TestUniqueGroupIDs(t *testing.T) {
+	// 表記ゆれが同じグループに解決された場合は1件にまとめる
+	groupIDs := map[string]uint{"pm": 1, "project manager": 1, "se": 2}
+	assert.Equal(t, []uint{1, 2}, uniqueGroupIDs([]string{"PM", "Project Manager", "", "SE"}, groupIDs))
+}
+
+func TestEntryTimingRows(t *testing.T) {
+	received := time.Date(2025, 6, 20, 10, 0, 0, 0, time.Local)
+	rows := entryTimingRows(10, []string{"2025/07/01", "2025/07/01", "即日", "要確認"}, received)
+
+	july := time.Date(2025, 7, 1, 0, 0, 0, 0, time.Local)
+	today := time.Date(2025, 6, 20, 0, 0, 0, 0, time.Local)
+	assert.Equal(t, []EntryTiming{
+		{EmailProjectID: 10, StartDate: "2025/07/01", StartFrom: &july, StartTo: &july},
+		{EmailProjectID: 10, StartDate: "即日", StartFrom: &today, StartTo: &today, StartFlag: "immediate"},
+		{EmailProjectID: 10, StartDate: "要確認"},
+	}, rows)
+}
+
+func TestLocationRows(t *testing.T) {
+	rows := locationRows(10, "渋谷 or 東京都港区")
+	assert.Len(t, rows, 2)
+	assert.Equal(t, 0, rows[0].SiteNo)
+	assert.Equal(t, "渋谷", rows[0].Station)
+	assert.Equal(t, 1, rows[1].SiteNo)
+	assert.Equal(t, "港区", rows[1].City)
+	assert.NotNil(t, rows[1].Lat)
+
+	assert.Empty(t, locationRows(10, "要相談"))
+}
+
+// migrateEmailTables はメール保存に必要なテーブルを作成します
+func migrateEmailTables(db *gorm.DB) error {
+	return db.AutoMigrate(
+		model.KeywordGroup{},
+		model.KeyWord{},
+		model.KeywordGroupWordLink{},
+		model.PositionGroup{},
+		model.PositionWord{},
+		model.WorkTypeGroup{},
+		model.WorkTypeWord{},
+		model.Email{},
+		model.EmailProject{},
+		model.EmailProjectFieldEvidence{},
+		model.EmailCandidate{},
+		model.EmailCandidateKeywordGroup{},
+		model.EntryTiming{},
+		model.ProjectLocation{},
+		model.ProjectCluster{},
+		model.ProjectClusterMember{},
+		model.EmailKeywordGroup{},
+		model.EmailPositionGroup{},
+		model.EmailWorkTypeGroup{},
+	)
+}
+
+// newBulkResult はまとめて保存するテスト用の解析結果を作成します
+func newBulkResult(gmailID string, project int) cd.Email {
+	return cd.Email{
+		GmailID: gmailID, Subject: "案件のご紹介", From: "a@agency.example.com", FromEmail: "a@agency.example.com",
+		ReceivedDate: time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC), Body: "本文", Category: "案件",
+		ProjectName: fmt.Sprintf("案件%d", project), Summary: fmt.Sprintf("案件%d", project),
+		StartPeriod: []string{"2025/07/01", "2025/08/01"}, PriceFrom: intPtr(600000 + project),
+		Languages: []string{"Go", "TypeScript"}, Frameworks: []string{"Gin"},
+		RequiredSkillsMust: []string{"Go"}, RequiredSkillsWant: []string{"AWS"},
+		Positions: []string{"SE", "PG"}, WorkTypes: []string{"バックエンド開発"},
+		Evidences: []cd.FieldEvidence{{Field: "単価FROM", Confidence: 0.9, Quote: "60万円"}},
+	}
+}
+
+func TestRepository_SaveEmails(t *testing.T) {
+	t.Parallel()
+	// テスト用DBの準備
+	db, cleanup, err := mysql.CreateNewTestDB()
+	require.NoError(t, err)
+	defer cleanup()
+	require.NoError(t, migrateEmailTables(db.DB))
+
+	// 既存の表記ゆれ「ＳＥ」はグループ「SE」に解決される
+	se := PositionGroup{Name: "SE"}
+	require.NoError(t, db.DB.Create(&se).Error)
+	require.NoError(t, db.DB.Create(&PositionWord{PositionGroupID: se.PositionGroupID, Word: "ＳＥ"}).Error)
+	existing := KeywordGroup{Name: "Go", Type: "language"}
+	require.NoError(t, db.DB.Create(&existing).Error)
+
+	repo := New(db.DB)
+	second := newBulkResult("gmail-1", 2)
+	second.Positions = []string{"ＳＥ", "SE"}
+	results := []cd.Email{newBulkResult("gmail-1", 1), second, newBulkResult("gmail-2", 3)}
+	require.NoError(t, repo.SaveEmails(results))
+
+	count := func(m interface{}, query string, args ...interface{}) int64 {
+		var n int64
+		q := db.DB.Model(m)
+		if query != "" {
+			q = q.Where(query, args...)
+		}
+		require.NoError(t, q.Count(&n).Error)
+		return n
+	}
+	assert.Equal(t, int64(2), count(&Email{}, ""))
+	assert.Equal(t, int64(3), count(&EmailProject{}, ""))
+	assert.Equal(t, int64(6), count(&EntryTiming{}, ""))
+	assert.Equal(t, int64(3), count(&EmailProjectFieldEvidence{}, ""))
+
+	// マスタは名前ごとに1件だけ作成され、既存のグループを再利用する（Goは言語と必須スキルにあっても案件ごとに1件）
+	assert.Equal(t, int64(1), count(&KeywordGroup{}, "name = ?", "Go"))
+	assert.Equal(t, int64(4), count(&KeywordGroup{}, ""))
+	assert.Equal(t, int64(4), count(&KeyWord{}, ""))
+	assert.Equal(t, int64(4), count(&KeywordGroupWordLink{}, ""))
+	assert.Equal(t, int64(2), count(&PositionGroup{}, ""))
+	assert.Equal(t, int64(1), count(&WorkTypeGroup{}, ""))
+	assert.Equal(t, int64(3), count(&EmailKeywordGroup{}, "keyword_group_id = ?", existing.KeywordGroupID))
+
+	// 表記ゆれが同じグループに解決された案件は1件だけ紐付ける
+	var secondProject EmailProject
+	require.NoError(t, db.DB.Where("project_title = ?", "案件2").Take(&secondProject).Error)
+	assert.Equal(t, int64(1), count(&EmailPositionGroup{}, "email_project_id = ?", secondProject.ID))
+
+	// 同じ解析結果をもう一度保存しても行は増えない
+	require.NoError(t, repo.SaveEmails(results))
+	assert.Equal(t, int64(3), count(&EmailProject{}, ""))
+	assert.Equal(t, int64(4), count(&KeyWord{}, ""))
+}
+
+func BenchmarkRepository_SaveEmails(b *testing.B) {
+	db, cleanup, err := mysql.CreateNewTestDB()
+	if err != nil {
+		b.Skipf("テスト用DBに接続できません: %v", err)
+	}
+	defer cleanup()
+	if err := migrateEmailTables(db.DB); err != nil {
+		b.Fatal(err)
+	}
+	repo := New(db.DB)
+
+	// 1通に2件の案件が載ったメールを、1トランザクションあたりのメール数を変えて保存する
+	seq := 0
+	for _, perTx := range []int{1, 10, 50} {
+		b.Run(fmt.Sprintf("emails_per_tx=%d", perTx), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				results := make([]cd.Email, 0, perTx*2)
+				for j := 0; j < perTx; j++ {
+					seq++
+					gmailID := fmt.Sprintf("bench-%d", seq)
+					results = append(results, newBulkResult(gmailID, 1), newBulkResult(gmailID, 2))
+				}
+				if err := repo.SaveEmails(results); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ReportMetric(float64(b.N*perTx)/b.Elapsed().Seconds(), "emails/s")
+		})
+	}
+}
@@ -4,6 +4,7 @@ package infrastructure
 
 import (
 	cd "business/internal/common/domain"
+	"business/internal/emailstore/domain"
 )
 
 // RepositoryInterface はメール保存のリポジトリインターフェースです
@@ -11,6 +12,63 @@ type RepositoryInterface interface {
 	// SaveEmail はメール分析結果をデータベースに保存します
 	SaveEmail(result cd.Email) error
 
+	// SaveEmails は複数のメール分析結果を1つのトランザクションで保存します
+	SaveEmails(results []cd.Email) error
+
 	// GetEmailByGmailIds はIDでメールを取得します
 	GetEmailByGmailIds(gmail_ids []string) ([]string, error)
+
+	// ReplaceEmails はGメールIDに紐づく解析結果を置き換えます
+	ReplaceEmails(gmailID string, results []cd.Email) error
+}
+
+// QueryRepositoryInterface は保存済み案件を検索するリポジトリインターフェースです
+type QueryRepositoryInterface interface {
+	// SearchProjects は条件に一致する案件を並び順どおりに q.Limit+1 件まで取得します
+	SearchProjects(q domain.ProjectQuery) ([]domain.ProjectListItem, error)
+}
+
+// TriageRepositoryInterface はメールの仕分けを保存するリポジトリインターフェースです
+type TriageRepositoryInterface interface {
+	// LoadTriageStates はGメールIDごとの仕分けの現在値を取得します
+	LoadTriageStates(gmailIDs []string) ([]domain.TriageState, error)
+
+	// SaveTriage はメールの仕分けの値を保存します
+	SaveTriage(states []domain.TriageState) error
+
+	// LoadProjectStatuses は案件IDごとの応募状況の現在値を取得します
+	LoadProjectStatuses(projectIDs []uint) ([]domain.ProjectStatus, error)
+
+	// SaveStatusChanges は案件の応募状況の変更と変更履歴を保存します（ほかの更新と競合した場合は domain.ErrStatusConflict）
+	SaveStatusChanges(changes []domain.StatusChange) error
+
+	// ListStatusHistory は案件の応募状況の変更履歴を古い順に返します
+	ListStatusHistory(projectID uint) ([]domain.StatusChange, error)
+}
+
+// PeriodRepositoryInterface は入場時期・終了時期の日付の範囲を保存するリポジトリインターフェースです
+type PeriodRepositoryInterface interface {
+	// ListProjectPeriods は afterID より後の案件を、入場時期・終了時期と受信日付きでID順に limit 件まで返します
+	ListProjectPeriods(afterID uint, limit int) ([]domain.ProjectPeriod, error)
+
+	// SaveProjectPeriods は解釈した入場時期・終了時期の範囲を保存します
+	SaveProjectPeriods(periods []domain.ProjectPeriod) error
+}
+
+// PriceRepositoryInterface は解釈した単価を保存するリポジトリインターフェースです
+type PriceRepositoryInterface interface {
+	// ListProjectPrices は afterID より後の案件を、単価・単価の根拠の引用・本文付きでID順に limit 件まで返します
+	ListProjectPrices(afterID uint, limit int) ([]domain.ProjectPrice, error)
+
+	// SaveProjectPrices は解釈した単価を保存します
+	SaveProjectPrices(prices []domain.ProjectPrice) error
+}
+
+// LocationRepositoryInterface は正規化した勤務地の拠点を保存するリポジトリインターフェースです
+type LocationRepositoryInterface interface {
+	// ListProjectLocations は afterID より後の案件を、勤務場所の表記付きでID順に limit 件まで返します
+	ListProjectLocations(afterID uint, limit int) ([]domain.ProjectLocations, error)
+
+	// SaveProjectLocations は案件ごとに勤務地の拠点を置き換えて保存します
+	SaveProjectLocations(projects []domain.ProjectLocations) error
 }
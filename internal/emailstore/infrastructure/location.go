@@ -0,0 +1,76 @@
+package infrastructure
+
+import (
+	"business/internal/emailstore/domain"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// locationSourceRow は案件の勤務場所の取得結果です
+type locationSourceRow struct {
+	ID           uint
+	WorkLocation *string
+}
+
+// ListProjectLocations は afterID より後の案件を、勤務場所の表記付きでID順に limit 件まで返します
+func (r *Repository) ListProjectLocations(afterID uint, limit int) ([]domain.ProjectLocations, error) {
+	var rows []locationSourceRow
+	err := r.db.Model(&EmailProject{}).
+		Select("id, work_location").
+		Where("id > ?", afterID).
+		Order("id").
+		Limit(limit).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("EmailProject取得エラー: %w", err)
+	}
+
+	projects := make([]domain.ProjectLocations, 0, len(rows))
+	for _, row := range rows {
+		projects = append(projects, domain.ProjectLocations{
+			ProjectID:    row.ID,
+			WorkLocation: derefString(row.WorkLocation),
+		})
+	}
+	return projects, nil
+}
+
+// SaveProjectLocations は案件ごとに勤務地の拠点を置き換え、1つのトランザクションで保存します
+func (r *Repository) SaveProjectLocations(projects []domain.ProjectLocations) error {
+	if len(projects) == 0 {
+		return nil
+	}
+
+	ids := make([]uint, 0, len(projects))
+	var rows []ProjectLocation
+	for _, p := range projects {
+		ids = append(ids, p.ProjectID)
+		for i, l := range p.Locations {
+			rows = append(rows, ProjectLocation{
+				EmailProjectID: p.ProjectID,
+				SiteNo:         i,
+				Prefecture:     l.Prefecture,
+				City:           l.City,
+				Station:        l.Station,
+				Lat:            l.Lat,
+				Lng:            l.Lng,
+				Remote:         l.Remote,
+			})
+		}
+	}
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("email_project_id IN ?", ids).Delete(&ProjectLocation{}).Error; err != nil {
+			return fmt.Errorf("ProjectLocation削除エラー: %w", err)
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+		if err := tx.Omit(clause.Associations).CreateInBatches(&rows, insertBatchSize).Error; err != nil {
+			return fmt.Errorf("ProjectLocation保存エラー: %w", err)
+		}
+		return nil
+	})
+}
@@ -9,35 +9,37 @@ import (
 
 // Email は全メール共通の基本情報を表すドメインモデルです
 type Email struct {
-	ID           uint      `gorm:"primaryKey;autoIncrement"`           // オートインクリメントID
-	GmailID      string    `gorm:"size:32;index"`                      // GメールID
-	Subject      string    `gorm:"type:text;not null" json:"subject"`  // 件名
-	SenderName   string    `gorm:"size:255" json:"sender_name"`        // 差出人名
-	SenderEmail  string    `gorm:"size:255;index" json:"sender_email"` // メールアドレス
-	ReceivedDate time.Time `gorm:"index" json:"received_date"`         // 受信日
-	Body         *string   `gorm:"type:longtext" json:"body"`          // 本文
-	Category     string    `gorm:"size:50;index" json:"category"`      // 種別（案件 / 人材提案）
-	CreatedAt    time.Time `json:"created_at"`                         // 作成日時
-	UpdatedAt    time.Time `json:"updated_at"`                         // 更新日時
-
-	IsRead bool `gorm:"not null;default:false"` // 既読
-	IsGood bool `gorm:"not null;default:false"` // いいね
-	IsBad  bool `gorm:"not null;default:false"` // びみょうかも
+	ID           uint      `gorm:"primaryKey;autoIncrement"`                     // オートインクリメントID
+	GmailID      string    `gorm:"size:32;uniqueIndex"`                          // GメールID（1メール1行）
+	ThreadID     string    `gorm:"size:32;not null;default:''" json:"thread_id"` // Gメールのスレッド
+	Subject      string    `gorm:"type:text;not null" json:"subject"`            // 件名
+	SenderName   string    `gorm:"size:255" json:"sender_name"`                  // 差出人名
+	SenderEmail  string    `gorm:"size:255;index" json:"sender_email"`           // メールアドレス
+	ReceivedDate time.Time `gorm:"index" json:"received_date"`                   // 受信日
+	Body         *string   `gorm:"type:longtext" json:"body"`                    // 本文
+	Category     string    `gorm:"size:50;index" json:"category"`                // 種別（案件 / 人材提案）
+
+	AnalysisVersion  string    `gorm:"size:50;index" json:"analysis_version"`       // 解析バージョン
+	AnalysisRevision uint      `gorm:"not null;default:1" json:"analysis_revision"` // 採用中の解析リビジョン番号
+	CreatedAt        time.Time `json:"created_at"`                                  // 作成日時
+	UpdatedAt        time.Time `json:"updated_at"`                                  // 更新日時
+
+	IsRead bool    `gorm:"not null;default:false"` // 既読
+	IsGood bool    `gorm:"not null;default:false"` // いいね
+	IsBad  bool    `gorm:"not null;default:false"` // びみょうかも
+	Note   *string `gorm:"type:text" json:"note"`  // メモ
 
 	// 子テーブル
-	EmailProject        *EmailProject        `gorm:"foreignKey:EmailID;references:ID" json:"email_project"`          // 案件情報（1対1）
-	EmailCandidate      *EmailCandidate      `gorm:"foreignKey:EmailID;references:ID" json:"email_candidate"`        // 人材情報（1対1）
-	EntryTimings        []EntryTiming        `gorm:"foreignKey:EmailID;references:ID" json:"entry_timings"`          // 入場時期（1対多）
-	EmailKeywordGroups  []EmailKeywordGroup  `gorm:"foreignKey:EmailID;references:ID" json:"email_keyword_groups"`   // 技術キーワード（1対多）
-	EmailPositionGroups []EmailPositionGroup `gorm:"foreignKey:EmailID;references:ID" json:"email_position_groups"`  // ポジション（1対多）
-	EmailWorkTypeGroups []EmailWorkTypeGroup `gorm:"foreignKey:EmailID;references:ID" json:"email_work_type_groups"` // 業務内容（1対多）
+	EmailProjects  []EmailProject  `gorm:"foreignKey:EmailID;references:ID" json:"email_projects"`  // 案件情報（1対多）
+	EmailCandidate *EmailCandidate `gorm:"foreignKey:EmailID;references:ID" json:"email_candidate"` // 人材情報（1対1）
 }
 
 // EmailProject は案件メール専用の詳細情報を表すドメインモデルです
 type EmailProject struct {
-	ID           uint    `gorm:"primaryKey;autoIncrement"`      // オートインクリメントID
-	EmailID      uint    `gorm:"index"`                         // メールID（emails.idと同じ）
-	ProjectTitle *string `gorm:"size:255" json:"project_title"` // 案件名
+	ID           uint    `gorm:"primaryKey;autoIncrement"`                                               // オートインクリメントID
+	EmailID      uint    `gorm:"not null;uniqueIndex:idx_email_project_key,priority:1"`                  // メールID（emails.id）
+	ProjectKey   string  `gorm:"size:40;not null;uniqueIndex:idx_email_project_key,priority:2" json:"-"` // メール内で案件を識別するキー
+	ProjectTitle *string `gorm:"size:255" json:"project_title"`                                          // 案件名
 
 	// 表示用（カンマ区切り）
 	EntryTiming *string `gorm:"type:text" json:"entry_timing"` // 入場時期（"2025/06/01,2025/07/01"）
@@ -49,22 +51,67 @@ type EmailProject struct {
 	WantSkills  *string `gorm:"type:text" json:"want_skills"`  // WANTスキル（"MT,Adobe製品経験"）
 
 	// その他項目
-	EndTiming       *string   `gorm:"size:255" json:"end_timing"`          // 終了時期
-	WorkLocation    *string   `gorm:"size:255;index" json:"work_location"` // 勤務場所
-	PriceFrom       *int      `gorm:"type:int" json:"price_from"`          // 単価FROM
-	PriceTo         *int      `gorm:"type:int" json:"price_to"`            // 単価TO
-	RemoteType      *string   `gorm:"size:50" json:"remote_type"`          // リモート区分
-	RemoteFrequency *string   `gorm:"size:255" json:"remote_frequency"`    // リモート頻度
-	CreatedAt       time.Time `json:"created_at"`                          // 作成日時
-	UpdatedAt       time.Time `json:"updated_at"`                          // 更新日時
+	EndTiming         *string    `gorm:"size:255" json:"end_timing"`                               // 終了時期
+	EndFrom           *time.Time `gorm:"type:date" json:"end_from"`                                // 終了時期を解釈した範囲の開始
+	EndTo             *time.Time `gorm:"type:date" json:"end_to"`                                  // 終了時期を解釈した範囲の終了（上限なしは NULL）
+	EndFlag           string     `gorm:"size:20;not null;default:''" json:"end_flag"`              // 終了時期の種類（ongoing など）
+	WorkLocation      *string    `gorm:"size:255;index" json:"work_location"`                      // 勤務場所
+	PriceFrom         *int       `gorm:"type:int" json:"price_from"`                               // 単価FROM
+	PriceTo           *int       `gorm:"type:int" json:"price_to"`                                 // 単価TO
+	PriceUnit         string     `gorm:"size:10;not null;default:''" json:"price_unit"`            // 単価の単位（monthly / daily / hourly / yearly）
+	PriceTaxIncluded  *bool      `json:"price_tax_included"`                                       // 税込なら true、税別なら false
+	PriceNegotiable   bool       `gorm:"not null;default:false" json:"price_negotiable"`           // スキル見合い・応相談
+	SettlementFrom    *int       `gorm:"type:smallint" json:"settlement_from"`                     // 精算幅の下限（時間）
+	SettlementTo      *int       `gorm:"type:smallint" json:"settlement_to"`                       // 精算幅の上限（時間）
+	MonthlyPriceFrom  *int       `gorm:"type:int" json:"monthly_price_from"`                       // 税別の月額に換算した単価FROM
+	MonthlyPriceTo    *int       `gorm:"type:int" json:"monthly_price_to"`                         // 税別の月額に換算した単価TO
+	RemoteType        *string    `gorm:"size:50" json:"remote_type"`                               // リモート区分
+	RemoteFrequency   *string    `gorm:"size:255" json:"remote_frequency"`                         // リモート頻度
+	ApplicationStatus string     `gorm:"size:20;not null;default:'未対応'" json:"application_status"` // 応募状況
+
+	LifecycleStatus    string     `gorm:"size:20;not null;default:'unknown'" json:"lifecycle_status"` // 募集状況（open / closed / expired / unknown）
+	LifecycleReason    string     `gorm:"size:255;not null;default:''" json:"lifecycle_reason"`       // 募集状況の判定理由
+	LifecycleChangedAt *time.Time `json:"lifecycle_changed_at"`                                       // 募集状況を変更した日時
+	ClosedByEmailID    *uint      `json:"closed_by_email_id"`                                         // 募集終了の連絡のメールID
+	ArchivedAt         *time.Time `json:"archived_at"`                                                // アーカイブした日時
+	CreatedAt          time.Time  `json:"created_at"`                                                 // 作成日時
+	UpdatedAt          time.Time  `json:"updated_at"`                                                 // 更新日時
+
+	// 子テーブル
+	EntryTimings        []EntryTiming        `gorm:"foreignKey:EmailProjectID;references:ID" json:"entry_timings"`          // 入場時期（1対多）
+	Locations           []ProjectLocation    `gorm:"foreignKey:EmailProjectID;references:ID" json:"locations"`              // 勤務地（1対多）
+	EmailKeywordGroups  []EmailKeywordGroup  `gorm:"foreignKey:EmailProjectID;references:ID" json:"email_keyword_groups"`   // 技術キーワード（1対多）
+	EmailPositionGroups []EmailPositionGroup `gorm:"foreignKey:EmailProjectID;references:ID" json:"email_position_groups"`  // ポジション（1対多）
+	EmailWorkTypeGroups []EmailWorkTypeGroup `gorm:"foreignKey:EmailProjectID;references:ID" json:"email_work_type_groups"` // 業務内容（1対多）
+}
+
+// EmailProjectFieldEvidence は案件の主要項目ごとの信頼度と根拠を表すドメインモデルです
+type EmailProjectFieldEvidence struct {
+	ID             uint      `gorm:"primaryKey;autoIncrement"`                       // オートインクリメントID
+	EmailProjectID uint      `gorm:"not null;uniqueIndex:idx_project_field"`         // 案件ID（email_projects.id）
+	FieldName      string    `gorm:"size:50;not null;uniqueIndex:idx_project_field"` // 項目名（例: "単価FROM"）
+	Confidence     float64   `gorm:"type:decimal(4,3);not null;default:0"`           // 信頼度（0.000〜1.000）
+	SourceText     *string   `gorm:"type:text" json:"source_text"`                   // 根拠となる本文の引用
+	CreatedAt      time.Time `json:"created_at"`                                     // 作成日時
+	UpdatedAt      time.Time `json:"updated_at"`                                     // 更新日時
 }
 
-// EmailCandidate は人材メール専用の詳細情報を表すドメインモデルです（将来拡張用）
+// EmailCandidate は人材メール専用の詳細情報を表すドメインモデルです
 type EmailCandidate struct {
-	ID        uint      `gorm:"primaryKey;autoIncrement"` // オートインクリメントID
-	EmailID   uint      `gorm:"index"`                    // メールID（emails.idと同じ）
-	CreatedAt time.Time `json:"created_at"`               // 作成日時
-	UpdatedAt time.Time `json:"updated_at"`               // 更新日時
+	ID               uint       `gorm:"primaryKey;autoIncrement" json:"id"`                // オートインクリメントID
+	EmailID          uint       `gorm:"uniqueIndex" json:"email_id"`                       // メールID（emails.id。1メール1行）
+	CandidateName    *string    `gorm:"size:255" json:"candidate_name"`                    // 人材名
+	SkillsSummary    *string    `gorm:"type:text" json:"skills_summary"`                   // スキルまとめ
+	AvailabilityDate *string    `gorm:"size:255" json:"availability_date"`                 // 参画可能日
+	AvailableFrom    *time.Time `gorm:"type:date" json:"available_from"`                   // 参画可能日を解釈した日付
+	AvailableFlag    string     `gorm:"size:20;not null;default:''" json:"available_flag"` // 参画可能日の種類（immediate など）
+	Skills           *string    `gorm:"type:text" json:"skills"`                           // 言語・フレームワーク・スキル（カンマ区切り）
+	MonthlyPriceFrom *int       `gorm:"type:int" json:"monthly_price_from"`                // 税別の月額に換算した希望単価FROM
+	MonthlyPriceTo   *int       `gorm:"type:int" json:"monthly_price_to"`                  // 税別の月額に換算した希望単価TO
+	WorkLocation     *string    `gorm:"size:255" json:"work_location"`                     // 希望勤務地
+	RemoteType       *string    `gorm:"size:50" json:"remote_type"`                        // リモートの希望
+	CreatedAt        time.Time  `json:"created_at"`                                        // 作成日時
+	UpdatedAt        time.Time  `json:"updated_at"`                                        // 更新日時
 
 	// リレーション
 	Email Email `gorm:"foreignKey:EmailID;references:ID" json:"email"`
@@ -72,15 +119,56 @@ type EmailCandidate struct {
 
 // EntryTiming は案件の入場時期を正規化管理するドメインモデルです
 type EntryTiming struct {
-	EmailID   uint      `gorm:"primaryKey" json:"email_id"`                    // ID
-	StartDate string    `gorm:"primaryKey;size:20;not null" json:"start_date"` // 入場日（例: "2025/06/01"）
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at,omitempty"`
+	EmailProjectID uint       `gorm:"primaryKey" json:"email_project_id"`            // 案件ID（email_projects.id）
+	StartDate      string     `gorm:"primaryKey;size:20;not null" json:"start_date"` // 入場日（例: "2025/06/01"）
+	StartFrom      *time.Time `gorm:"type:date" json:"start_from"`                   // 入場日を解釈した範囲の開始
+	StartTo        *time.Time `gorm:"type:date" json:"start_to"`                     // 入場日を解釈した範囲の終了（上限なしは NULL）
+	StartFlag      string     `gorm:"size:20;not null;default:''" json:"start_flag"` // 入場日の種類（immediate / ongoing / undecided）
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at,omitempty"`
 }
 
-// EmailKeywordGroup はEmailとKeywordGroupの多対多中間テーブルを表すドメインモデルです
+// ProjectLocation は案件の勤務地を拠点ごとに正規化管理するドメインモデルです
+type ProjectLocation struct {
+	ID             uint      `gorm:"primaryKey;autoIncrement" json:"id"`            // オートインクリメントID
+	EmailProjectID uint      `gorm:"not null;index" json:"email_project_id"`        // 案件ID（email_projects.id）
+	SiteNo         int       `gorm:"not null;default:0" json:"site_no"`             // 拠点の番号（出現順、0始まり）
+	Prefecture     string    `gorm:"size:10;not null;default:''" json:"prefecture"` // 都道府県
+	City           string    `gorm:"size:50;not null;default:''" json:"city"`       // 市区町村
+	Station        string    `gorm:"size:50;not null;default:''" json:"station"`    // 最寄り駅
+	Lat            *float64  `gorm:"type:decimal(9,6)" json:"lat"`                  // 緯度
+	Lng            *float64  `gorm:"type:decimal(9,6)" json:"lng"`                  // 経度
+	Remote         bool      `gorm:"not null;default:false" json:"remote"`          // フルリモート
+	CreatedAt      time.Time `json:"created_at"`                                    // 作成日時
+	UpdatedAt      time.Time `json:"updated_at"`                                    // 更新日時
+}
+
+// ProjectCluster は複数の営業会社から届いた同じ案件の重複グループを表すドメインモデルです
+type ProjectCluster struct {
+	ID                      uint      `gorm:"primaryKey;autoIncrement" json:"id"`              // オートインクリメントID
+	RepresentativeProjectID uint      `gorm:"not null;index" json:"representative_project_id"` // 代表の案件ID（最も新しく受信した案件）
+	Size                    int       `gorm:"not null" json:"size"`                            // 案件数
+	AgencyCount             int       `gorm:"not null" json:"agency_count"`                    // 営業会社数
+	PriceMin                *int      `gorm:"type:int" json:"price_min"`                       // 税別の月額に換算した単価の最小
+	PriceMax                *int      `gorm:"type:int" json:"price_max"`                       // 税別の月額に換算した単価の最大
+	FirstReceivedAt         time.Time `gorm:"not null" json:"first_received_at"`               // 最初に受信した日時
+	LastReceivedAt          time.Time `gorm:"not null" json:"last_received_at"`                // 最後に受信した日時
+	CreatedAt               time.Time `json:"created_at"`                                      // 作成日時
+	UpdatedAt               time.Time `json:"updated_at"`                                      // 更新日時
+}
+
+// ProjectClusterMember は重複グループに含まれる案件を表すドメインモデルです
+type ProjectClusterMember struct {
+	ID             uint      `gorm:"primaryKey;autoIncrement" json:"id"`                // オートインクリメントID
+	ClusterID      uint      `gorm:"not null;index" json:"cluster_id"`                  // 重複グループID（project_clusters.id）
+	EmailProjectID uint      `gorm:"not null;uniqueIndex" json:"email_project_id"`      // 案件ID（email_projects.id）
+	Score          float64   `gorm:"type:decimal(4,3);not null;default:0" json:"score"` // グループ内の他の案件との最大の類似度
+	CreatedAt      time.Time `json:"created_at"`                                        // 作成日時
+}
+
+// EmailKeywordGroup はEmailProjectとKeywordGroupの多対多中間テーブルを表すドメインモデルです
 type EmailKeywordGroup struct {
-	EmailID        uint      `gorm:"not null;"`
+	EmailProjectID uint      `gorm:"not null;index"` // 案件ID（email_projects.id）
 	KeywordGroupID uint      `gorm:"not null;"`
 	CreatedAt      time.Time // 登録日時
 
@@ -88,6 +176,13 @@ type EmailKeywordGroup struct {
 	KeywordGroup KeywordGroup `gorm:"foreignKey:KeywordGroupID;references:KeywordGroupID" json:"keyword_group"` // 統合テスト時はコメントアウト
 }
 
+// EmailCandidateKeywordGroup はEmailCandidateとKeywordGroupの多対多中間テーブルを表すドメインモデルです
+type EmailCandidateKeywordGroup struct {
+	EmailCandidateID uint      `gorm:"not null;index"` // 人材ID（email_candidates.id）
+	KeywordGroupID   uint      `gorm:"not null;index"`
+	CreatedAt        time.Time // 登録日時
+}
+
 // KeywordGroup は正規化された技術キーワードのマスタを表すドメインモデルです
 type KeywordGroup struct {
 	KeywordGroupID uint   `gorm:"primaryKey;autoIncrement"`
@@ -116,9 +211,9 @@ type KeyWord struct {
 	UpdatedAt time.Time
 }
 
-// EmailPositionGroup はEmailとPositionGroupの多対多中間テーブルを表すドメインモデルです
+// EmailPositionGroup はEmailProjectとPositionGroupの多対多中間テーブルを表すドメインモデルです
 type EmailPositionGroup struct {
-	EmailID         uint `gorm:"primaryKey;size:32" json:"email_id"`  // メールID
+	EmailProjectID  uint `gorm:"primaryKey" json:"email_project_id"`  // 案件ID（email_projects.id）
 	PositionGroupID uint `gorm:"primaryKey" json:"position_group_id"` // ポジショングループID
 
 	// リレーション
@@ -144,9 +239,9 @@ type PositionWord struct {
 	UpdatedAt       time.Time `json:"updated_at"`
 }
 
-// EmailWorkTypeGroup はEmailとWorkTypeGroupの多対多中間テーブルを表すドメインモデルです
+// EmailWorkTypeGroup はEmailProjectとWorkTypeGroupの多対多中間テーブルを表すドメインモデルです
 type EmailWorkTypeGroup struct {
-	EmailID         uint `gorm:"primaryKey;size:32" json:"email_id"`   // メールID
+	EmailProjectID  uint `gorm:"primaryKey" json:"email_project_id"`   // 案件ID（email_projects.id）
 	WorkTypeGroupID uint `gorm:"primaryKey" json:"work_type_group_id"` // 業務グループID
 
 	// リレーション
@@ -172,6 +267,16 @@ type WorkTypeWord struct {
 	UpdatedAt       time.Time `json:"updated_at"`
 }
 
+// ApplicationStatusHistory は案件の応募状況の変更履歴を表すドメインモデルです
+type ApplicationStatusHistory struct {
+	ID             uint      `gorm:"primaryKey;autoIncrement"`               // オートインクリメントID
+	EmailProjectID uint      `gorm:"not null;index" json:"email_project_id"` // 案件ID（email_projects.id）
+	FromStatus     string    `gorm:"size:20;not null" json:"from_status"`    // 変更前の応募状況
+	ToStatus       string    `gorm:"size:20;not null" json:"to_status"`      // 変更後の応募状況
+	Note           *string   `gorm:"type:text" json:"note"`                  // 変更時点のメモ
+	CreatedAt      time.Time `json:"created_at"`                             // 変更日時
+}
+
 // ドメインエラー
 var (
 	ErrEmailNotFound      = errors.New("メールが見つかりません")
@@ -188,6 +293,14 @@ func (EmailProject) TableName() string {
 	return "email_projects"
 }
 
+func (EmailProjectFieldEvidence) TableName() string {
+	return "email_project_field_evidences"
+}
+
+func (ApplicationStatusHistory) TableName() string {
+	return "application_status_histories"
+}
+
 func (EmailCandidate) TableName() string {
 	return "email_candidates"
 }
@@ -196,6 +309,18 @@ func (EntryTiming) TableName() string {
 	return "entry_timings"
 }
 
+func (ProjectLocation) TableName() string {
+	return "project_locations"
+}
+
+func (ProjectCluster) TableName() string {
+	return "project_clusters"
+}
+
+func (ProjectClusterMember) TableName() string {
+	return "project_cluster_members"
+}
+
 func (KeywordGroup) TableName() string {
 	return "keyword_groups"
 }
@@ -208,6 +333,10 @@ func (EmailKeywordGroup) TableName() string {
 	return "email_keyword_groups"
 }
 
+func (EmailCandidateKeywordGroup) TableName() string {
+	return "email_candidate_keyword_groups"
+}
+
 func (PositionGroup) TableName() string {
 	return "position_groups"
 }
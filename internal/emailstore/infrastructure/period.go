@@ -0,0 +1,80 @@
+package infrastructure
+
+import (
+	"business/internal/emailstore/domain"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// periodRow は案件の終了時期と受信日の取得結果です
+type periodRow struct {
+	ID           uint
+	EndTiming    *string
+	ReceivedDate time.Time
+}
+
+// ListProjectPeriods は afterID より後の案件を、入場時期・終了時期と受信日付きでID順に limit 件まで返します
+func (r *Repository) ListProjectPeriods(afterID uint, limit int) ([]domain.ProjectPeriod, error) {
+	var rows []periodRow
+	err := r.db.Table("email_projects AS ep").
+		Select("ep.id, ep.end_timing, e.received_date").
+		Joins("JOIN emails e ON e.id = ep.email_id").
+		Where("ep.id > ?", afterID).
+		Order("ep.id").
+		Limit(limit).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("EmailProject取得エラー: %w", err)
+	}
+	if len(rows) == 0 {
+		return []domain.ProjectPeriod{}, nil
+	}
+
+	ids := make([]uint, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.ID)
+	}
+	var timings []EntryTiming
+	if err := r.db.Where("email_project_id IN ?", ids).Order("email_project_id, start_date").Find(&timings).Error; err != nil {
+		return nil, fmt.Errorf("EntryTiming取得エラー: %w", err)
+	}
+	starts := make(map[uint][]domain.StartPeriod, len(rows))
+	for _, t := range timings {
+		starts[t.EmailProjectID] = append(starts[t.EmailProjectID], domain.StartPeriod{StartDate: t.StartDate})
+	}
+
+	periods := make([]domain.ProjectPeriod, 0, len(rows))
+	for _, row := range rows {
+		periods = append(periods, domain.ProjectPeriod{
+			ProjectID:    row.ID,
+			ReceivedDate: row.ReceivedDate,
+			EndTiming:    derefString(row.EndTiming),
+			Starts:       starts[row.ID],
+		})
+	}
+	return periods, nil
+}
+
+// SaveProjectPeriods は解釈した入場時期・終了時期の範囲を1つのトランザクションで保存します
+func (r *Repository) SaveProjectPeriods(periods []domain.ProjectPeriod) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for _, p := range periods {
+			err := tx.Model(&EmailProject{}).Where("id = ?", p.ProjectID).
+				UpdateColumns(map[string]interface{}{"end_from": p.End.From, "end_to": p.End.To, "end_flag": p.End.Flag}).Error
+			if err != nil {
+				return fmt.Errorf("EmailProject更新エラー: %w", err)
+			}
+			for _, s := range p.Starts {
+				err := tx.Model(&EntryTiming{}).
+					Where("email_project_id = ? AND start_date = ?", p.ProjectID, s.StartDate).
+					UpdateColumns(map[string]interface{}{"start_from": s.Range.From, "start_to": s.Range.To, "start_flag": s.Range.Flag}).Error
+				if err != nil {
+					return fmt.Errorf("EntryTiming更新エラー: %w", err)
+				}
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,93 @@
+package infrastructure
+
+import (
+	cd "business/internal/common/domain"
+	"business/internal/emailstore/domain"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// priceRow は案件の単価と本文の取得結果です
+type priceRow struct {
+	ID        uint
+	PriceFrom *int
+	PriceTo   *int
+	Body      *string
+}
+
+// ListProjectPrices は afterID より後の案件を、単価・単価の根拠の引用・本文付きでID順に limit 件まで返します
+func (r *Repository) ListProjectPrices(afterID uint, limit int) ([]domain.ProjectPrice, error) {
+	var rows []priceRow
+	err := r.db.Table("email_projects AS ep").
+		Select("ep.id, ep.price_from, ep.price_to, e.body").
+		Joins("JOIN emails e ON e.id = ep.email_id").
+		Where("ep.id > ?", afterID).
+		Order("ep.id").
+		Limit(limit).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("EmailProject取得エラー: %w", err)
+	}
+	if len(rows) == 0 {
+		return []domain.ProjectPrice{}, nil
+	}
+
+	ids := make([]uint, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.ID)
+	}
+	var evidences []EmailProjectFieldEvidence
+	err = r.db.Where("email_project_id IN ? AND field_name IN ? AND source_text IS NOT NULL", ids,
+		[]string{cd.EvidenceFieldPriceFrom, cd.EvidenceFieldPriceTo}).
+		Order("email_project_id, id").
+		Find(&evidences).Error
+	if err != nil {
+		return nil, fmt.Errorf("EmailProjectFieldEvidence取得エラー: %w", err)
+	}
+	quotes := make(map[uint][]string, len(rows))
+	for _, e := range evidences {
+		quotes[e.EmailProjectID] = append(quotes[e.EmailProjectID], *e.SourceText)
+	}
+
+	prices := make([]domain.ProjectPrice, 0, len(rows))
+	for _, row := range rows {
+		prices = append(prices, domain.ProjectPrice{
+			ProjectID: row.ID,
+			PriceFrom: row.PriceFrom,
+			PriceTo:   row.PriceTo,
+			Quotes:    quotes[row.ID],
+			Body:      derefString(row.Body),
+		})
+	}
+	return prices, nil
+}
+
+// SaveProjectPrices は解釈した単価を1つのトランザクションで保存します
+func (r *Repository) SaveProjectPrices(prices []domain.ProjectPrice) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for _, p := range prices {
+			err := tx.Model(&EmailProject{}).Where("id = ?", p.ProjectID).
+				UpdateColumns(priceColumns(p.Price)).Error
+			if err != nil {
+				return fmt.Errorf("EmailProject更新エラー: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// priceColumns は単価を email_projects の列に変換します
+func priceColumns(p cd.Price) map[string]interface{} {
+	return map[string]interface{}{
+		"price_from":         p.From,
+		"price_to":           p.To,
+		"price_unit":         string(p.Unit),
+		"price_tax_included": p.TaxIncluded,
+		"price_negotiable":   p.Negotiable,
+		"settlement_from":    p.SettlementFrom,
+		"settlement_to":      p.SettlementTo,
+		"monthly_price_from": p.MonthlyFrom(),
+		"monthly_price_to":   p.MonthlyTo(),
+	}
+}
@@ -0,0 +1,445 @@
+package infrastructure
+
+import (
+	cd "business/internal/common/domain"
+	"business/internal/emailstore/domain"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// sortPriceExpr は単価順の並び替えに使う式です（domain.ProjectListItem.SortPrice と同じ規則）
+const sortPriceExpr = "COALESCE(ep.monthly_price_to, ep.monthly_price_from, ep.price_to, ep.price_from, 0)"
+
+// 全文検索の式（emails.subject/body と email_projects.project_title の ngram FULLTEXT インデックスを使用）
+const (
+	matchEmailExpr   = "MATCH(e.subject, e.body) AGAINST (? IN BOOLEAN MODE)"
+	matchProjectExpr = "MATCH(ep.project_title) AGAINST (? IN BOOLEAN MODE)"
+	relevanceExpr    = "(" + matchEmailExpr + " + " + matchProjectExpr + ")"
+)
+
+// projectListColumns は案件一覧で取得する列です
+const projectListColumns = `ep.id AS project_id, e.id AS email_id, e.gmail_id, e.received_date, e.subject,
+	e.sender_name, e.sender_email, e.category, ep.project_title, ep.entry_timing, ep.end_timing,
+	ep.work_location, ep.price_from, ep.price_to, ep.price_unit, ep.price_tax_included, ep.price_negotiable,
+	ep.settlement_from, ep.settlement_to, ep.monthly_price_from, ep.monthly_price_to, ep.languages, ep.frameworks, ep.positions,
+	ep.work_types, ep.must_skills, ep.want_skills, ep.remote_type, ep.remote_frequency,
+	e.is_read, e.is_good, e.is_bad, e.note, ep.application_status, ep.lifecycle_status, ep.archived_at`
+
+// projectListRow は案件一覧の検索結果の行です
+type projectListRow struct {
+	ProjectID         uint
+	EmailID           uint
+	GmailID           string
+	ReceivedDate      time.Time
+	Subject           string
+	SenderName        string
+	SenderEmail       string
+	Category          string
+	ProjectTitle      *string
+	EntryTiming       *string
+	EndTiming         *string
+	WorkLocation      *string
+	PriceFrom         *int
+	PriceTo           *int
+	PriceUnit         string
+	PriceTaxIncluded  *bool
+	PriceNegotiable   bool
+	SettlementFrom    *int
+	SettlementTo      *int
+	MonthlyPriceFrom  *int
+	MonthlyPriceTo    *int
+	Languages         *string
+	Frameworks        *string
+	Positions         *string
+	WorkTypes         *string
+	MustSkills        *string
+	WantSkills        *string
+	RemoteType        *string
+	RemoteFrequency   *string
+	IsRead            bool
+	IsGood            bool
+	IsBad             bool
+	Note              *string
+	ApplicationStatus string
+	LifecycleStatus   string
+	ArchivedAt        *time.Time
+	Body              *string // 全文検索時のみ取得
+	Relevance         float64 // 全文検索時のみ取得
+}
+
+// SearchProjects は条件に一致する案件を並び順どおりに取得します
+// q.Limit 件より1件多く取得するため、呼び出し側で次ページの有無を判定できます。
+func (r *Repository) SearchProjects(q domain.ProjectQuery) ([]domain.ProjectListItem, error) {
+	query := r.db.Table("email_projects ep").Joins("JOIN emails e ON e.id = ep.email_id")
+	if q.Text != "" {
+		query = query.Select(projectListColumns+", e.body, "+relevanceExpr+" AS relevance", q.Text, q.Text)
+	} else {
+		query = query.Select(projectListColumns)
+	}
+
+	query = applyProjectFilters(query, q)
+
+	query, err := applyProjectCursor(query, q)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []projectListRow
+	if err := applyProjectSort(query, q.Sort).Limit(q.Limit + 1).Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("案件検索エラー: %w", err)
+	}
+
+	items := make([]domain.ProjectListItem, 0, len(rows))
+	projectIDs := make([]uint, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, row.toDomain())
+		projectIDs = append(projectIDs, row.ProjectID)
+	}
+
+	evidences, err := r.findFieldEvidences(projectIDs)
+	if err != nil {
+		return nil, err
+	}
+	locations, err := r.findProjectLocations(projectIDs)
+	if err != nil {
+		return nil, err
+	}
+	clusters, err := r.findClusterSummaries(projectIDs)
+	if err != nil {
+		return nil, err
+	}
+	for i := range items {
+		items[i].Evidences = evidences[items[i].ProjectID]
+		if items[i].Evidences == nil {
+			items[i].Evidences = []cd.FieldEvidence{}
+		}
+		items[i].Locations = locations[items[i].ProjectID]
+		if items[i].Locations == nil {
+			items[i].Locations = []domain.Location{}
+		}
+		items[i].Cluster = clusters[items[i].ProjectID]
+	}
+
+	return items, nil
+}
+
+// applyProjectFilters は検索条件をWHERE句に変換します
+func applyProjectFilters(query *gorm.DB, q domain.ProjectQuery) *gorm.DB {
+	if q.Text != "" {
+		query = query.Where("("+matchEmailExpr+" OR "+matchProjectExpr+")", q.Text, q.Text)
+	}
+	if q.ReceivedFrom != nil {
+		query = query.Where("e.received_date >= ?", *q.ReceivedFrom)
+	}
+	if q.ReceivedTo != nil {
+		query = query.Where("e.received_date < ?", *q.ReceivedTo)
+	}
+	if q.Category != "" {
+		query = query.Where("e.category = ?", q.Category)
+	}
+	if q.StartFrom != nil || q.StartTo != nil {
+		query = applyStartFilter(query, q.StartFrom, q.StartTo)
+	}
+	if q.PriceMin != nil {
+		query = query.Where("COALESCE(ep.monthly_price_to, ep.monthly_price_from, ep.price_to, ep.price_from) >= ?", *q.PriceMin)
+	}
+	if q.PriceMax != nil {
+		query = query.Where("COALESCE(ep.monthly_price_from, ep.monthly_price_to, ep.price_from, ep.price_to) <= ?", *q.PriceMax)
+	}
+
+	query = applyKeywordFilter(query, q.Languages, q.LanguageMatch)
+	query = applyKeywordFilter(query, q.Frameworks, q.FrameworkMatch)
+
+	if terms := compact(q.Positions); len(terms) > 0 {
+		query = query.Where(`EXISTS (SELECT 1 FROM email_position_groups epg WHERE epg.email_project_id = ep.id AND epg.position_group_id IN (
+			SELECT pg.position_group_id FROM position_groups pg WHERE pg.name IN ?
+			UNION SELECT pw.position_group_id FROM position_words pw WHERE pw.word IN ?))`, terms, terms)
+	}
+	if terms := compact(q.WorkTypes); len(terms) > 0 {
+		query = query.Where(`EXISTS (SELECT 1 FROM email_work_type_groups ewtg WHERE ewtg.email_project_id = ep.id AND ewtg.work_type_group_id IN (
+			SELECT wtg.work_type_group_id FROM work_type_groups wtg WHERE wtg.name IN ?
+			UNION SELECT wtw.work_type_group_id FROM work_type_words wtw WHERE wtw.word IN ?))`, terms, terms)
+	}
+	if terms := compact(q.RemoteTypes); len(terms) > 0 {
+		query = query.Where("ep.remote_type IN ?", terms)
+	}
+	if q.WorkLocation != "" {
+		query = query.Where("ep.work_location LIKE ?", "%"+escapeLike(q.WorkLocation)+"%")
+	}
+	if terms := compact(q.Prefectures); len(terms) > 0 {
+		query = query.Where("EXISTS (SELECT 1 FROM project_locations pl WHERE pl.email_project_id = ep.id AND pl.prefecture IN ?)", terms)
+	}
+	if q.NearLat != nil && q.NearLng != nil {
+		query = applyNearFilter(query, *q.NearLat, *q.NearLng, q.RadiusKm)
+	}
+	if q.Collapse {
+		// 重複グループの代表以外の案件を除く（グループに含まれない案件はそのまま返す）
+		query = query.Where(`NOT EXISTS (SELECT 1 FROM project_cluster_members pcm JOIN project_clusters pc ON pc.id = pcm.cluster_id
+			WHERE pcm.email_project_id = ep.id AND pc.representative_project_id <> ep.id)`)
+	}
+	if q.Sender != "" {
+		sender := "%" + escapeLike(q.Sender) + "%"
+		query = query.Where("(e.sender_name LIKE ? OR e.sender_email LIKE ?)", sender, sender)
+	}
+	if q.IsRead != nil {
+		query = query.Where("e.is_read = ?", *q.IsRead)
+	}
+	if q.IsGood != nil {
+		query = query.Where("e.is_good = ?", *q.IsGood)
+	}
+	if q.IsBad != nil {
+		query = query.Where("e.is_bad = ?", *q.IsBad)
+	}
+	if len(q.Statuses) > 0 {
+		query = query.Where("ep.application_status IN ?", q.Statuses)
+	}
+	if len(q.Lifecycles) > 0 {
+		query = query.Where("ep.lifecycle_status IN ?", q.Lifecycles)
+	}
+	if !q.IncludeArchived {
+		query = query.Where("ep.archived_at IS NULL")
+	}
+	return query
+}
+
+// applyStartFilter は日付の範囲に解釈した入場時期が [from, to] と重なる案件に絞り込みます
+// 範囲に解釈できなかった入場時期（未定など）は一致しません。
+func applyStartFilter(query *gorm.DB, from, to *time.Time) *gorm.DB {
+	cond := "EXISTS (SELECT 1 FROM entry_timings et WHERE et.email_project_id = ep.id AND (et.start_from IS NOT NULL OR et.start_to IS NOT NULL)"
+	var args []interface{}
+	if from != nil {
+		cond += " AND (et.start_to IS NULL OR et.start_to >= ?)"
+		args = append(args, from.Format("2006-01-02"))
+	}
+	if to != nil {
+		cond += " AND (et.start_from IS NULL OR et.start_from <= ?)"
+		args = append(args, to.Format("2006-01-02"))
+	}
+	return query.Where(cond+")", args...)
+}
+
+// applyNearFilter は座標 (lat, lng) から radiusKm 以内の拠点がある案件に絞り込みます
+// 座標の無い拠点（都道府県のみ・フルリモートのみ）は一致しません。
+func applyNearFilter(query *gorm.DB, lat, lng, radiusKm float64) *gorm.DB {
+	return query.Where(`EXISTS (SELECT 1 FROM project_locations pl WHERE pl.email_project_id = ep.id AND pl.lat IS NOT NULL AND pl.lng IS NOT NULL
+		AND ST_Distance_Sphere(POINT(pl.lng, pl.lat), POINT(?, ?)) <= ?)`, lng, lat, radiusKm*1000)
+}
+
+// applyKeywordFilter は技術キーワードをキーワードグループに展開して絞り込みます
+// 指定した語がグループ名または key_words の表記ゆれに一致するグループを同一視します。
+// 例: "JS" が JavaScript グループに紐づいていれば JavaScript の案件も一致します。
+func applyKeywordFilter(query *gorm.DB, keywords []string, match string) *gorm.DB {
+	terms := compact(keywords)
+	if len(terms) == 0 {
+		return query
+	}
+
+	const exists = `EXISTS (SELECT 1 FROM email_keyword_groups ekg WHERE ekg.email_project_id = ep.id AND ekg.keyword_group_id IN (
+		SELECT kg.keyword_group_id FROM keyword_groups kg WHERE kg.name IN ?
+		UNION SELECT l.keyword_group_id FROM keyword_group_word_links l JOIN key_words kw ON kw.id = l.key_word_id WHERE kw.word IN ?))`
+
+	if match == domain.MatchAll {
+		for _, term := range terms {
+			query = query.Where(exists, []string{term}, []string{term})
+		}
+		return query
+	}
+	return query.Where(exists, terms, terms)
+}
+
+// applyProjectCursor は前ページ末尾より後ろの行に絞り込みます
+func applyProjectCursor(query *gorm.DB, q domain.ProjectQuery) (*gorm.DB, error) {
+	if q.Cursor == "" {
+		return query, nil
+	}
+	cursor, err := domain.DecodeCursor(q.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	switch q.Sort {
+	case domain.SortReceivedAsc:
+		return query.Where("(e.received_date > ? OR (e.received_date = ? AND ep.id > ?))", cursor.Received, cursor.Received, cursor.ID), nil
+	case domain.SortPriceDesc:
+		return query.Where("("+sortPriceExpr+" < ? OR ("+sortPriceExpr+" = ? AND ep.id < ?))", cursor.Price, cursor.Price, cursor.ID), nil
+	case domain.SortPriceAsc:
+		return query.Where("("+sortPriceExpr+" > ? OR ("+sortPriceExpr+" = ? AND ep.id > ?))", cursor.Price, cursor.Price, cursor.ID), nil
+	case domain.SortRelevance:
+		return query.Where("("+relevanceExpr+" < ? OR ("+relevanceExpr+" = ? AND ep.id < ?))",
+			q.Text, q.Text, cursor.Score, q.Text, q.Text, cursor.Score, cursor.ID), nil
+	default:
+		return query.Where("(e.received_date < ? OR (e.received_date = ? AND ep.id < ?))", cursor.Received, cursor.Received, cursor.ID), nil
+	}
+}
+
+// applyProjectSort は並び順をORDER BY句に変換します（同順位は案件IDで並べます）
+func applyProjectSort(query *gorm.DB, sort string) *gorm.DB {
+	switch sort {
+	case domain.SortReceivedAsc:
+		return query.Order("e.received_date ASC").Order("ep.id ASC")
+	case domain.SortPriceDesc:
+		return query.Order(sortPriceExpr + " DESC").Order("ep.id DESC")
+	case domain.SortPriceAsc:
+		return query.Order(sortPriceExpr + " ASC").Order("ep.id ASC")
+	case domain.SortRelevance:
+		return query.Order("relevance DESC").Order("ep.id DESC")
+	default:
+		return query.Order("e.received_date DESC").Order("ep.id DESC")
+	}
+}
+
+// findFieldEvidences は案件ごとの根拠を取得します
+func (r *Repository) findFieldEvidences(projectIDs []uint) (map[uint][]cd.FieldEvidence, error) {
+	result := map[uint][]cd.FieldEvidence{}
+	if len(projectIDs) == 0 {
+		return result, nil
+	}
+
+	var rows []EmailProjectFieldEvidence
+	if err := r.db.Where("email_project_id IN ?", projectIDs).Order("id").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("根拠取得エラー: %w", err)
+	}
+	for _, row := range rows {
+		quote := ""
+		if row.SourceText != nil {
+			quote = *row.SourceText
+		}
+		result[row.EmailProjectID] = append(result[row.EmailProjectID], cd.FieldEvidence{
+			Field:      row.FieldName,
+			Confidence: row.Confidence,
+			Quote:      quote,
+		})
+	}
+	return result, nil
+}
+
+// findProjectLocations は案件ごとの勤務地の拠点を拠点の番号順に取得します
+func (r *Repository) findProjectLocations(projectIDs []uint) (map[uint][]domain.Location, error) {
+	result := map[uint][]domain.Location{}
+	if len(projectIDs) == 0 {
+		return result, nil
+	}
+
+	var rows []ProjectLocation
+	if err := r.db.Where("email_project_id IN ?", projectIDs).Order("email_project_id, site_no").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("勤務地取得エラー: %w", err)
+	}
+	for _, row := range rows {
+		result[row.EmailProjectID] = append(result[row.EmailProjectID], row.toDomain())
+	}
+	return result, nil
+}
+
+// findClusterSummaries は案件ごとに含まれる重複グループの概要を取得します
+func (r *Repository) findClusterSummaries(projectIDs []uint) (map[uint]*domain.ClusterSummary, error) {
+	result := map[uint]*domain.ClusterSummary{}
+	if len(projectIDs) == 0 {
+		return result, nil
+	}
+
+	var rows []struct {
+		EmailProjectID uint
+		domain.ClusterSummary
+	}
+	err := r.db.Table("project_cluster_members pcm").
+		Select("pcm.email_project_id, pc.id, pc.size, pc.agency_count, pc.price_min, pc.price_max").
+		Joins("JOIN project_clusters pc ON pc.id = pcm.cluster_id").
+		Where("pcm.email_project_id IN ?", projectIDs).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("重複グループ取得エラー: %w", err)
+	}
+	for _, row := range rows {
+		summary := row.ClusterSummary
+		result[row.EmailProjectID] = &summary
+	}
+	return result, nil
+}
+
+func (row ProjectLocation) toDomain() domain.Location {
+	return domain.Location{
+		Prefecture: row.Prefecture,
+		City:       row.City,
+		Station:    row.Station,
+		Lat:        row.Lat,
+		Lng:        row.Lng,
+		Remote:     row.Remote,
+	}
+}
+
+func (row projectListRow) toDomain() domain.ProjectListItem {
+	return domain.ProjectListItem{
+		ProjectID:         row.ProjectID,
+		EmailID:           row.EmailID,
+		GmailID:           row.GmailID,
+		ReceivedDate:      row.ReceivedDate,
+		Subject:           row.Subject,
+		SenderName:        row.SenderName,
+		SenderEmail:       row.SenderEmail,
+		Category:          row.Category,
+		ProjectTitle:      derefString(row.ProjectTitle),
+		EntryTimings:      splitCSV(row.EntryTiming),
+		EndTiming:         derefString(row.EndTiming),
+		WorkLocation:      derefString(row.WorkLocation),
+		PriceFrom:         row.PriceFrom,
+		PriceTo:           row.PriceTo,
+		PriceUnit:         row.PriceUnit,
+		PriceTaxIncluded:  row.PriceTaxIncluded,
+		PriceNegotiable:   row.PriceNegotiable,
+		SettlementFrom:    row.SettlementFrom,
+		SettlementTo:      row.SettlementTo,
+		MonthlyPriceFrom:  row.MonthlyPriceFrom,
+		MonthlyPriceTo:    row.MonthlyPriceTo,
+		Languages:         splitCSV(row.Languages),
+		Frameworks:        splitCSV(row.Frameworks),
+		Positions:         splitCSV(row.Positions),
+		WorkTypes:         splitCSV(row.WorkTypes),
+		MustSkills:        splitCSV(row.MustSkills),
+		WantSkills:        splitCSV(row.WantSkills),
+		RemoteType:        row.RemoteType,
+		RemoteFrequency:   row.RemoteFrequency,
+		IsRead:            row.IsRead,
+		IsGood:            row.IsGood,
+		IsBad:             row.IsBad,
+		Note:              derefString(row.Note),
+		ApplicationStatus: domain.ApplicationStatus(row.ApplicationStatus),
+		LifecycleStatus:   domain.LifecycleStatus(row.LifecycleStatus),
+		ArchivedAt:        row.ArchivedAt,
+		Body:              derefString(row.Body),
+		Relevance:         row.Relevance,
+	}
+}
+
+// compact は前後の空白を除き、空文字を除外します
+func compact(values []string) []string {
+	var result []string
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// escapeLike はLIKE検索のワイルドカードをエスケープします
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// splitCSV は表示用のカンマ区切り文字列を配列に戻します
+func splitCSV(s *string) []string {
+	if s == nil || *s == "" {
+		return []string{}
+	}
+	return strings.Split(*s, ",")
+}
@@ -0,0 +1,192 @@
+package infrastructure
+
+import (
+	cd "business/internal/common/domain"
+	"business/internal/emailstore/domain"
+	"business/tools/migrations/model"
+	"business/tools/mysql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_SearchProjects(t *testing.T) {
+	t.Parallel()
+	// テスト用DBの準備
+	db, cleanup, err := mysql.CreateNewTestDB()
+	require.NoError(t, err)
+	defer cleanup()
+
+	err = db.DB.AutoMigrate(
+		model.KeywordGroup{},
+		model.KeyWord{},
+		model.KeywordGroupWordLink{},
+		model.PositionGroup{},
+		model.PositionWord{},
+		model.WorkTypeGroup{},
+		model.WorkTypeWord{},
+		model.Email{},
+		model.EmailProject{},
+		model.EmailProjectFieldEvidence{},
+		model.EmailCandidate{},
+		model.EmailCandidateKeywordGroup{},
+		model.EntryTiming{},
+		model.ProjectLocation{},
+		model.ProjectCluster{},
+		model.ProjectClusterMember{},
+		model.EmailKeywordGroup{},
+		model.EmailPositionGroup{},
+		model.EmailWorkTypeGroup{},
+	)
+	require.NoError(t, err)
+
+	repo := New(db.DB)
+	base := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+	inputs := []cd.Email{
+		{
+			GmailID: "gmail-1", Subject: "Go案件", From: "営業A <a@agency.example.com>", FromEmail: "a@agency.example.com",
+			ReceivedDate: base, Category: "案件", ProjectName: "Go開発", WorkLocation: "東京都港区",
+			PriceFrom: intPtr(600000), PriceTo: intPtr(700000),
+			Languages: []string{"Go", "JavaScript"}, Positions: []string{"SE"}, RemoteWorkCategory: stringPtr("フルリモート"),
+			StartPeriod: []string{"即日"},
+		},
+		{
+			GmailID: "gmail-2", Subject: "PHP案件", From: "b@other.example.com", FromEmail: "b@other.example.com",
+			ReceivedDate: base.Add(time.Hour), Category: "案件", ProjectName: "PHP開発", WorkLocation: "大阪府",
+			PriceFrom: intPtr(500000), PriceTo: intPtr(550000),
+			Languages: []string{"PHP"}, Positions: []string{"PG"}, RemoteWorkCategory: stringPtr("不可"),
+			StartPeriod: []string{"7月～"},
+		},
+		{
+			GmailID: "gmail-3", Subject: "Go/PHP案件", From: "a@agency.example.com", FromEmail: "a@agency.example.com",
+			ReceivedDate: base.Add(2 * time.Hour), Category: "案件", ProjectName: "Go/PHP開発", WorkLocation: "東京都渋谷区",
+			PriceFrom: intPtr(800000), PriceTo: intPtr(900000),
+			Languages: []string{"Go", "PHP"}, Positions: []string{"SE"},
+			StartPeriod: []string{"未定"},
+		},
+	}
+	for _, input := range inputs {
+		require.NoError(t, repo.SaveEmail(input))
+	}
+
+	// "JS" を JavaScript グループの表記ゆれとして登録
+	var jsGroup KeywordGroup
+	require.NoError(t, db.DB.Where("name = ?", "JavaScript").First(&jsGroup).Error)
+	jsWord := KeyWord{Word: "JS"}
+	require.NoError(t, db.DB.Create(&jsWord).Error)
+	require.NoError(t, db.DB.Create(&KeywordGroupWordLink{KeywordGroupID: jsGroup.KeywordGroupID, KeyWordID: jsWord.ID}).Error)
+
+	search := func(q domain.ProjectQuery) []string {
+		q, err := q.Normalize()
+		require.NoError(t, err)
+		items, err := repo.SearchProjects(q)
+		require.NoError(t, err)
+		var ids []string
+		for _, item := range items {
+			ids = append(ids, item.GmailID)
+		}
+		return ids
+	}
+
+	// 既定は受信日の新しい順
+	assert.Equal(t, []string{"gmail-3", "gmail-2", "gmail-1"}, search(domain.ProjectQuery{}))
+
+	// 表記ゆれからキーワードグループに展開して検索できること
+	assert.Equal(t, []string{"gmail-1"}, search(domain.ProjectQuery{Languages: []string{"JS"}}))
+
+	// any / all
+	assert.Equal(t, []string{"gmail-3", "gmail-2", "gmail-1"}, search(domain.ProjectQuery{Languages: []string{"Go", "PHP"}}))
+	assert.Equal(t, []string{"gmail-3"}, search(domain.ProjectQuery{Languages: []string{"Go", "PHP"}, LanguageMatch: domain.MatchAll}))
+
+	// 単価・勤務場所・リモート・ポジション・差出人
+	assert.Equal(t, []string{"gmail-3", "gmail-1"}, search(domain.ProjectQuery{PriceMin: intPtr(600000)}))
+	assert.Equal(t, []string{"gmail-2"}, search(domain.ProjectQuery{PriceMax: intPtr(550000)}))
+	assert.Equal(t, []string{"gmail-3", "gmail-1"}, search(domain.ProjectQuery{WorkLocation: "東京"}))
+	assert.Equal(t, []string{"gmail-1"}, search(domain.ProjectQuery{RemoteTypes: []string{"フルリモート"}}))
+	assert.Equal(t, []string{"gmail-2"}, search(domain.ProjectQuery{Positions: []string{"PG"}}))
+	assert.Equal(t, []string{"gmail-3", "gmail-1"}, search(domain.ProjectQuery{Sender: "agency"}))
+
+	// 入場時期は受信日を基準に解釈した範囲で絞り込めること（未定は一致しない）
+	june1 := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	june30 := time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC)
+	july15 := time.Date(2025, 7, 15, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, []string{"gmail-1"}, search(domain.ProjectQuery{StartFrom: &june1, StartTo: &june30}))
+	assert.Equal(t, []string{"gmail-2"}, search(domain.ProjectQuery{StartFrom: &july15}))
+	assert.Equal(t, []string{"gmail-2", "gmail-1"}, search(domain.ProjectQuery{StartTo: &july15}))
+
+	// 勤務地は正規化した拠点の都道府県・座標からの距離で絞り込めること
+	assert.Equal(t, []string{"gmail-2"}, search(domain.ProjectQuery{Prefectures: []string{"大阪府"}}))
+	assert.Equal(t, []string{"gmail-3", "gmail-2", "gmail-1"}, search(domain.ProjectQuery{Prefectures: []string{"東京都", "大阪府"}}))
+	lat, lng := 35.6580, 139.7016 // 渋谷駅
+	assert.Equal(t, []string{"gmail-3"}, search(domain.ProjectQuery{Station: "渋谷", NearLat: &lat, NearLng: &lng, RadiusKm: 2}))
+	assert.Equal(t, []string{"gmail-3", "gmail-1"}, search(domain.ProjectQuery{Station: "渋谷", NearLat: &lat, NearLng: &lng, RadiusKm: 10}))
+
+	// 重複グループは代表の案件だけに絞り込め、一覧に重複グループの概要が付くこと
+	var projectIDs []uint
+	require.NoError(t, db.DB.Table("email_projects ep").Joins("JOIN emails e ON e.id = ep.email_id").
+		Where("e.gmail_id IN ?", []string{"gmail-1", "gmail-3"}).Order("e.gmail_id").Pluck("ep.id", &projectIDs).Error)
+	require.Len(t, projectIDs, 2)
+	cluster := ProjectCluster{RepresentativeProjectID: projectIDs[1], Size: 2, AgencyCount: 1, PriceMin: intPtr(600000), PriceMax: intPtr(900000), FirstReceivedAt: base, LastReceivedAt: base}
+	require.NoError(t, db.DB.Create(&cluster).Error)
+	require.NoError(t, db.DB.Create(&[]ProjectClusterMember{
+		{ClusterID: cluster.ID, EmailProjectID: projectIDs[0], Score: 0.8},
+		{ClusterID: cluster.ID, EmailProjectID: projectIDs[1], Score: 0.8},
+	}).Error)
+	assert.Equal(t, []string{"gmail-3", "gmail-2"}, search(domain.ProjectQuery{Collapse: true}))
+	q, err := domain.ProjectQuery{Collapse: true}.Normalize()
+	require.NoError(t, err)
+	items, err := repo.SearchProjects(q)
+	require.NoError(t, err)
+	require.NotNil(t, items[0].Cluster)
+	assert.Equal(t, cluster.ID, items[0].Cluster.ID)
+	assert.Equal(t, 2, items[0].Cluster.Size)
+	assert.Equal(t, 900000, *items[0].Cluster.PriceMax)
+	assert.Nil(t, items[1].Cluster)
+
+	// 募集状況で絞り込め、アーカイブした案件は既定で除外されること
+	archivedAt := base.AddDate(0, 1, 0)
+	require.NoError(t, db.DB.Table("email_projects").Where("id = ?", projectIDs[0]).
+		Updates(map[string]interface{}{"lifecycle_status": "closed", "archived_at": archivedAt}).Error)
+	require.NoError(t, db.DB.Table("email_projects").Where("id = ?", projectIDs[1]).Update("lifecycle_status", "open").Error)
+	assert.Equal(t, []string{"gmail-3", "gmail-2"}, search(domain.ProjectQuery{}))
+	assert.Equal(t, []string{"gmail-3", "gmail-2", "gmail-1"}, search(domain.ProjectQuery{IncludeArchived: true}))
+	assert.Equal(t, []string{"gmail-3"}, search(domain.ProjectQuery{Lifecycles: []domain.LifecycleStatus{domain.LifecycleOpen}}))
+	assert.Equal(t, []string{"gmail-1"}, search(domain.ProjectQuery{Lifecycles: []domain.LifecycleStatus{domain.LifecycleClosed}, IncludeArchived: true}))
+	q, err = domain.ProjectQuery{IncludeArchived: true}.Normalize()
+	require.NoError(t, err)
+	items, err = repo.SearchProjects(q)
+	require.NoError(t, err)
+	assert.Equal(t, domain.LifecycleClosed, items[2].LifecycleStatus)
+	require.NotNil(t, items[2].ArchivedAt)
+	assert.Equal(t, domain.LifecycleUnknown, items[1].LifecycleStatus)
+	require.NoError(t, db.DB.Table("email_projects").Where("id = ?", projectIDs[0]).Update("archived_at", nil).Error)
+
+	// 既読フラグ
+	require.NoError(t, db.DB.Model(&Email{}).Where("gmail_id = ?", "gmail-2").Update("is_read", true).Error)
+	isRead := true
+	assert.Equal(t, []string{"gmail-2"}, search(domain.ProjectQuery{IsRead: &isRead}))
+
+	// 全文検索（件名・本文・案件名）と絞り込みを組み合わせられること
+	require.NoError(t, db.DB.Model(&Email{}).Where("gmail_id = ?", "gmail-1").Update("body", "大手金融系のPMO補佐").Error)
+	assert.Equal(t, []string{"gmail-1"}, search(domain.ProjectQuery{Text: "金融系"}))
+	assert.Equal(t, []string{"gmail-3", "gmail-2"}, search(domain.ProjectQuery{Text: "PHP"}))
+	assert.Equal(t, []string{"gmail-3"}, search(domain.ProjectQuery{Text: "PHP", WorkLocation: "東京"}))
+	assert.Equal(t, []string{"gmail-3"}, search(domain.ProjectQuery{Text: "+PHP +Go"}))
+
+	// 単価順とカーソルによるページ送り
+	q, err = domain.ProjectQuery{Sort: domain.SortPriceDesc, Limit: 1}.Normalize()
+	require.NoError(t, err)
+	var pages []string
+	for {
+		items, err := repo.SearchProjects(q)
+		require.NoError(t, err)
+		pages = append(pages, items[0].GmailID)
+		if len(items) <= q.Limit {
+			break
+		}
+		q.Cursor = domain.CursorOf(q.Sort, items[0]).Encode()
+	}
+	assert.Equal(t, []string{"gmail-3", "gmail-1", "gmail-2"}, pages)
+}
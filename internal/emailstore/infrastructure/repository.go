@@ -4,12 +4,15 @@ package infrastructure
 
 import (
 	cd "business/internal/common/domain"
-	"business/tools/mysql"
+	"business/tools/jpdate"
+	"business/tools/location"
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // Repository はメール保存のリポジトリ実装です
@@ -24,412 +27,675 @@ func New(db *gorm.DB) *Repository {
 	}
 }
 
+// SaveEmail はメール分析結果を保存します
 func (r *Repository) SaveEmail(result cd.Email) error {
-	// トランザクション開始
-	tx, creanUp := mysql.Transactional(r.db)
-	defer creanUp()
+	return r.SaveEmails([]cd.Email{result})
+}
 
-	if tx.Error != nil {
-		return fmt.Errorf("トランザクション開始エラー: %w", tx.Error)
-	}
-	defer func() {
-		if r := recover(); r != nil {
-			tx.Rollback()
+// SaveEmails は複数のメール分析結果を1つのトランザクションで保存します
+// GメールIDでメールを登録（登録済みなら再利用）し、同じ解析結果の案件が既にあれば何もしません。
+// CLIとAPIが同時に同じメールを取り込んでも重複しないよう、デッドロック等の競合時はトランザクションごと再試行します。
+func (r *Repository) SaveEmails(results []cd.Email) error {
+	if len(results) == 0 {
+		return nil
+	}
+
+	var err error
+	for attempt := 1; attempt <= maxSaveAttempts; attempt++ {
+		err = r.db.Transaction(func(tx *gorm.DB) error {
+			return r.saveEmails(tx, results)
+		})
+		if err == nil || !isRetryable(err) {
+			return err
 		}
-	}()
-
-	email := r.setEmail(result)
-
-	if err := tx.Create(&email).Error; err != nil {
-		tx.Rollback()
-		return fmt.Errorf("メール保存エラー: %w", err)
 	}
+	return fmt.Errorf("%d回再試行しましたが保存できませんでした: %w", maxSaveAttempts, err)
+}
 
-	// 案件メールの場合、詳細情報を保存
-	if result.Category == "案件" {
-		if err := r.saveProjectDetails(tx, result, email); err != nil {
-			tx.Rollback()
-			return fmt.Errorf("案件詳細保存エラー: %w", err)
+// ReplaceEmails はGメールIDに紐づく保存済みの解析結果を、引数の解析結果で置き換えます
+// メール・案件の行は削除せずに更新するため、emails.id・email_projects.id と、それを参照する通知・仕分け・募集状況は引き継がれます。
+// 解析結果が空の場合はメールと子テーブルを削除します。1つのトランザクションで行います。
+func (r *Repository) ReplaceEmails(gmailID string, results []cd.Email) error {
+	for _, result := range results {
+		if result.GmailID != gmailID {
+			return fmt.Errorf("%w: GメールIDが一致しません。 %s != %s", ErrInvalidEmailData, result.GmailID, gmailID)
 		}
 	}
 
-	return nil
-}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if len(results) == 0 {
+			return r.deleteEmails(tx, gmailID)
+		}
 
-func (r *Repository) setEmail(result cd.Email) Email {
-	return Email{
-		GmailID:      result.GmailID,
-		Subject:      result.Subject,
-		SenderName:   result.SenderName(),
-		SenderEmail:  result.SenderEmail(),
-		ReceivedDate: result.ReceivedDate,
-		Body:         &result.Body,
-		Category:     result.Category,
-	}
+		var email Email
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").Where("gmail_id = ?", gmailID).Take(&email).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return r.saveEmails(tx, results)
+		}
+		if err != nil {
+			return fmt.Errorf("メール検索エラー: %w", err)
+		}
+		return r.replaceEmail(tx, email.ID, results)
+	})
 }
 
-// GetEmailByGmailIds はIDでメールを取得します
-func (r *Repository) GetEmailByGmailIds(ids []string) ([]string, error) {
-	var resuts []string
-	err := r.db.Select("gmail_id").
-		Model(Email{}).
-		Where("gmail_id IN ?", ids).
-		Find(&resuts).
-		Error
+// replaceEmail は保存済みのメールの解析結果を、行を削除せずに置き換えます
+// 既読・いいね・メモなどの仕分けは解析結果ではないため更新しません。
+func (r *Repository) replaceEmail(tx *gorm.DB, emailID uint, results []cd.Email) error {
+	email := r.setEmail(results[0])
+	err := tx.Model(&Email{ID: emailID}).Updates(map[string]interface{}{
+		"thread_id":         email.ThreadID,
+		"subject":           email.Subject,
+		"sender_name":       email.SenderName,
+		"sender_email":      email.SenderEmail,
+		"received_date":     email.ReceivedDate,
+		"body":              email.Body,
+		"category":          email.Category,
+		"analysis_version":  email.AnalysisVersion,
+		"analysis_revision": email.AnalysisRevision,
+	}).Error
 	if err != nil {
-		return []string{}, fmt.Errorf("メール存在チェックエラー: %w", err)
+		return fmt.Errorf("メール更新エラー: %w", err)
 	}
-	return resuts, nil
-}
-
-// saveProjectDetails は案件メールの詳細情報を保存します
-func (r *Repository) saveProjectDetails(tx *gorm.DB, result cd.Email, email Email) error {
-	// EmailProjectを保存
-	entryTimings := strings.Join(result.StartPeriod, ",")
-	languages := strings.Join(result.Languages, ",")
-	frameworks := strings.Join(result.Frameworks, ",")
-	positions := strings.Join(result.Positions, ",")
-	workTypes := strings.Join(result.WorkTypes, ",")
-	mustSkills := strings.Join(result.RequiredSkillsMust, ",")
-	wantSkills := strings.Join(result.RequiredSkillsWant, ",")
 
-	emailProject := EmailProject{
-		EmailID:         email.ID,
-		ProjectTitle:    &result.Summary,
-		EntryTiming:     &entryTimings,
-		WorkLocation:    &result.WorkLocation,
-		EndTiming:       &result.EndPeriod,
-		PriceFrom:       result.PriceFrom,
-		PriceTo:         result.PriceTo,
-		RemoteType:      result.RemoteWorkCategory,
-		RemoteFrequency: result.RemoteWorkFrequency,
-		Languages:       &languages,
-		Frameworks:      &frameworks,
-		Positions:       &positions,
-		WorkTypes:       &workTypes,
-		MustSkills:      &mustSkills,
-		WantSkills:      &wantSkills,
-	}
-
-	if err := tx.Create(&emailProject).Error; err != nil {
-		return fmt.Errorf("EmailProject保存エラー: %w", err)
+	projects := make([]cd.Email, 0, len(results))
+	candidates := make([]cd.Email, 0, len(results))
+	for _, result := range results {
+		switch result.Category {
+		case "案件":
+			projects = append(projects, result)
+		case "人材":
+			candidates = append(candidates, result)
+		}
 	}
-
-	// EntryTimingを保存
-	if err := r.saveEntryTimings(tx, email.ID, result.StartPeriod); err != nil {
-		return fmt.Errorf("EntryTiming保存エラー: %w", err)
+	if err := r.replaceProjectDetails(tx, emailID, projects); err != nil {
+		return fmt.Errorf("案件詳細保存エラー: %w", err)
 	}
 
-	// キーワード関連を保存
-	if err := r.saveKeywords(tx, result, email.ID); err != nil {
-		return fmt.Errorf("キーワード保存エラー: %w", err)
+	// 人材の詳細は他のテーブルから参照されないため、削除して保存し直す
+	if err := deleteCandidateDetails(tx, []uint{emailID}); err != nil {
+		return err
 	}
+	if len(candidates) > 0 {
+		if err := r.saveCandidateDetails(tx, candidates, map[string]uint{email.GmailID: emailID}); err != nil {
+			return fmt.Errorf("人材詳細保存エラー: %w", err)
+		}
+	}
+	return nil
+}
 
-	// ポジション関連を保存
-	if err := r.savePositions(tx, result, email.ID); err != nil {
-		return fmt.Errorf("ポジション保存エラー: %w", err)
+// saveEmails はトランザクション内でメールと案件・人材の詳細をまとめて保存します
+func (r *Repository) saveEmails(tx *gorm.DB, results []cd.Email) error {
+	emailIDs, err := r.upsertEmails(tx, results)
+	if err != nil {
+		return err
 	}
 
-	// 業務種別関連を保存
-	if err := r.saveWorkTypes(tx, result, email.ID); err != nil {
-		return fmt.Errorf("業務種別保存エラー: %w", err)
+	// 案件メール・人材メールの場合、詳細情報を保存
+	projects := make([]cd.Email, 0, len(results))
+	candidates := make([]cd.Email, 0, len(results))
+	for _, result := range results {
+		switch result.Category {
+		case "案件":
+			projects = append(projects, result)
+		case "人材":
+			candidates = append(candidates, result)
+		}
+	}
+	if len(projects) > 0 {
+		if err := r.saveProjectDetails(tx, projects, emailIDs); err != nil {
+			return fmt.Errorf("案件詳細保存エラー: %w", err)
+		}
+	}
+	if len(candidates) > 0 {
+		if err := r.saveCandidateDetails(tx, candidates, emailIDs); err != nil {
+			return fmt.Errorf("人材詳細保存エラー: %w", err)
+		}
 	}
 
 	return nil
 }
 
-// saveEntryTimings は入場時期を保存します
-func (r *Repository) saveEntryTimings(tx *gorm.DB, emailId uint, startPeriods []string) error {
-	for _, period := range startPeriods {
-		entryTiming := EntryTiming{
-			EmailID:   emailId,
-			StartDate: period,
+// upsertEmails はGメールIDでメールをまとめて登録し、GメールIDごとの emails.id を返します
+// 登録済みの場合は既存の行をそのまま使います（INSERT ... ON DUPLICATE KEY UPDATE）。
+// 同じGメールIDの解析結果が複数ある場合、メールの項目は最初の解析結果の値を使います。
+// 既存の行はロックされるため、同じメールを保存するトランザクションはここで直列化されます。
+func (r *Repository) upsertEmails(tx *gorm.DB, results []cd.Email) (map[string]uint, error) {
+	emails := make([]Email, 0, len(results))
+	gmailIDs := make([]string, 0, len(results))
+	seen := make(map[string]struct{}, len(results))
+	for _, result := range results {
+		if _, ok := seen[result.GmailID]; ok {
+			continue
 		}
-		if err := tx.Create(&entryTiming).Error; err != nil {
-			return fmt.Errorf("EntryTiming保存エラー: %w", err)
+		seen[result.GmailID] = struct{}{}
+		emails = append(emails, r.setEmail(result))
+		gmailIDs = append(gmailIDs, result.GmailID)
+	}
+
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(&emails, insertBatchSize).Error; err != nil {
+		return nil, fmt.Errorf("メール保存エラー: %w", err)
+	}
+
+	// 登録済みの行はIDが採番されないため、GメールIDで取得し直す
+	var saved []Email
+	if err := tx.Select("id", "gmail_id").Where("gmail_id IN ?", gmailIDs).Find(&saved).Error; err != nil {
+		return nil, fmt.Errorf("メール取得エラー: %w", err)
+	}
+	emailIDs := make(map[string]uint, len(saved))
+	for _, email := range saved {
+		emailIDs[email.GmailID] = email.ID
+	}
+	for _, gmailID := range gmailIDs {
+		if _, ok := emailIDs[gmailID]; !ok {
+			return nil, fmt.Errorf("メール取得エラー: %s が見つかりません", gmailID)
 		}
 	}
-	return nil
+	return emailIDs, nil
 }
 
-// saveKeywords はキーワード関連のデータを保存します
-func (r *Repository) saveKeywords(tx *gorm.DB, result cd.Email, emailId uint) error {
-	// 言語
-	if err := r.saveKeywordsByType(tx, emailId, result.Languages, "language"); err != nil {
-		return err
+// deleteEmails はGメールIDに紐づくメールと子テーブルを削除します
+func (r *Repository) deleteEmails(tx *gorm.DB, gmailID string) error {
+	var emailIDs []uint
+	if err := tx.Model(Email{}).Where("gmail_id = ?", gmailID).Pluck("id", &emailIDs).Error; err != nil {
+		return fmt.Errorf("メール検索エラー: %w", err)
+	}
+	if len(emailIDs) == 0 {
+		return nil
 	}
 
-	// フレームワーク
-	if err := r.saveKeywordsByType(tx, emailId, result.Frameworks, "framework"); err != nil {
+	var projectIDs []uint
+	if err := tx.Model(EmailProject{}).Where("email_id IN ?", emailIDs).Pluck("id", &projectIDs).Error; err != nil {
+		return fmt.Errorf("案件検索エラー: %w", err)
+	}
+	if err := deleteProjects(tx, projectIDs); err != nil {
 		return err
 	}
-
-	// 必須スキル
-	if err := r.saveKeywordsByType(tx, emailId, result.RequiredSkillsMust, "must"); err != nil {
+	if err := deleteCandidateDetails(tx, emailIDs); err != nil {
 		return err
 	}
 
-	// 希望スキル
-	if err := r.saveKeywordsByType(tx, emailId, result.RequiredSkillsWant, "want"); err != nil {
-		return err
+	if err := tx.Where("id IN ?", emailIDs).Delete(&Email{}).Error; err != nil {
+		return fmt.Errorf("メール削除エラー: %w", err)
 	}
 
 	return nil
 }
 
-// saveKeywordsByType は指定されたタイプのキーワードを保存します
-func (r *Repository) saveKeywordsByType(tx *gorm.DB, emailID uint, keywords []string, keywordType string) error {
-	for _, keyword := range keywords {
-		if keyword == "" {
-			continue
-		}
+// deleteProjects は案件と、案件を参照する子テーブル・応募状況の変更履歴を削除します
+// 新着案件の通知は送信の履歴として残します（通知は保存した本文で表示します）。
+func deleteProjects(tx *gorm.DB, projectIDs []uint) error {
+	if len(projectIDs) == 0 {
+		return nil
+	}
+	if err := deleteProjectChildren(tx, projectIDs); err != nil {
+		return err
+	}
+	if err := tx.Where("email_project_id IN ?", projectIDs).Delete(&ApplicationStatusHistory{}).Error; err != nil {
+		return fmt.Errorf("%T削除エラー: %w", &ApplicationStatusHistory{}, err)
+	}
+	if err := tx.Where("id IN ?", projectIDs).Delete(&EmailProject{}).Error; err != nil {
+		return fmt.Errorf("%T削除エラー: %w", &EmailProject{}, err)
+	}
+	return nil
+}
 
-		// KeywordGroupを取得または作成
-		keywordGroup, err := r.getOrCreateKeywordGroup(tx, keyword, keywordType)
-		if err != nil {
-			return fmt.Errorf("KeywordGroup取得/作成エラー: %w", err)
-		}
+// deleteProjectChildren は案件の解析結果から作成した子テーブルと、案件を含む重複グループを削除します
+func deleteProjectChildren(tx *gorm.DB, projectIDs []uint) error {
+	if len(projectIDs) == 0 {
+		return nil
+	}
 
-		// EmailKeywordGroupを作成
-		emailKeywordGroup := EmailKeywordGroup{
-			EmailID:        emailID,
-			KeywordGroupID: keywordGroup.KeywordGroupID,
+	// 削除する案件を含む重複グループは、代表や件数が変わるためグループごと削除する（次回の重複検出で作り直す）
+	var clusterIDs []uint
+	if err := tx.Model(ProjectClusterMember{}).Where("email_project_id IN ?", projectIDs).Distinct().Pluck("cluster_id", &clusterIDs).Error; err != nil {
+		return fmt.Errorf("重複グループ検索エラー: %w", err)
+	}
+	if len(clusterIDs) > 0 {
+		if err := tx.Where("cluster_id IN ?", clusterIDs).Delete(&ProjectClusterMember{}).Error; err != nil {
+			return fmt.Errorf("%T削除エラー: %w", &ProjectClusterMember{}, err)
+		}
+		if err := tx.Where("id IN ?", clusterIDs).Delete(&ProjectCluster{}).Error; err != nil {
+			return fmt.Errorf("%T削除エラー: %w", &ProjectCluster{}, err)
 		}
+	}
 
-		if err := tx.Create(&emailKeywordGroup).Error; err != nil {
-			return fmt.Errorf("EmailKeywordGroup保存エラー: %w", err)
+	projectChildren := []interface{}{
+		&EmailProjectFieldEvidence{},
+		&EntryTiming{},
+		&ProjectLocation{},
+		&EmailKeywordGroup{},
+		&EmailPositionGroup{},
+		&EmailWorkTypeGroup{},
+	}
+	for _, child := range projectChildren {
+		if err := tx.Where("email_project_id IN ?", projectIDs).Delete(child).Error; err != nil {
+			return fmt.Errorf("%T削除エラー: %w", child, err)
 		}
 	}
 	return nil
 }
 
-func (r *Repository) getOrCreateKeywordGroup(tx *gorm.DB, name string, keywordType string) (KeywordGroup, error) {
-	var keywordGroup KeywordGroup
-	var keyWord KeyWord
-
-	// 単語を先に取得（存在確認）
-	err := tx.Where("word = ?", name).First(&keyWord).Error
-	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
-		return KeywordGroup{}, fmt.Errorf("KeyWord検索エラー: %w", err)
-	}
-
-	// グループ存在確認
-	err = tx.Where("name = ?", name).First(&keywordGroup).Error
-	if err == nil {
-		// 存在する場合 → Linkの存在確認
-		if keyWord.ID == 0 {
-			// 単語がなければ作成
-			keyWord = KeyWord{Word: name}
-			if err := tx.Create(&keyWord).Error; err != nil {
-				return KeywordGroup{}, fmt.Errorf("KeyWord作成エラー: %w", err)
-			}
-		}
-
-		// 中間テーブルが存在するかチェック
-		var count int64
-		err = tx.Model(KeywordGroupWordLink{}).
-			Where("keyword_group_id = ? AND key_word_id = ?", keywordGroup.KeywordGroupID, keyWord.ID).
-			Count(&count).Error
-		if err != nil {
-			return KeywordGroup{}, fmt.Errorf("KeywordGroupWordLink確認エラー: %w", err)
-		}
+// deleteCandidateDetails はメールの人材の詳細とキーワードの紐付けを削除します
+func deleteCandidateDetails(tx *gorm.DB, emailIDs []uint) error {
+	candidateIDs := tx.Model(EmailCandidate{}).Select("id").Where("email_id IN ?", emailIDs)
+	if err := tx.Where("email_candidate_id IN (?)", candidateIDs).Delete(&EmailCandidateKeywordGroup{}).Error; err != nil {
+		return fmt.Errorf("%T削除エラー: %w", &EmailCandidateKeywordGroup{}, err)
+	}
+	if err := tx.Where("email_id IN ?", emailIDs).Delete(&EmailCandidate{}).Error; err != nil {
+		return fmt.Errorf("%T削除エラー: %w", &EmailCandidate{}, err)
+	}
+	return nil
+}
 
-		if count == 0 {
-			link := KeywordGroupWordLink{
-				KeywordGroupID: keywordGroup.KeywordGroupID,
-				KeyWordID:      keyWord.ID,
-			}
-			if err := tx.Create(&link).Error; err != nil {
-				return KeywordGroup{}, fmt.Errorf("KeywordGroupWordLink作成エラー: %w", err)
-			}
-		}
+func (r *Repository) setEmail(result cd.Email) Email {
+	revision := result.AnalysisRevision
+	if revision == 0 {
+		revision = 1
+	}
+	return Email{
+		GmailID:          result.GmailID,
+		ThreadID:         result.ThreadID,
+		Subject:          result.Subject,
+		SenderName:       result.SenderName(),
+		SenderEmail:      result.SenderEmail(),
+		ReceivedDate:     result.ReceivedDate,
+		Body:             &result.Body,
+		Category:         result.Category,
+		AnalysisVersion:  result.AnalysisVersion,
+		AnalysisRevision: revision,
+		IsRead:           result.IsRead,
+		IsGood:           result.IsGood,
+		IsBad:            result.IsBad,
+	}
+}
 
-		return keywordGroup, nil
+// GetEmailByGmailIds はIDでメールを取得します
+func (r *Repository) GetEmailByGmailIds(ids []string) ([]string, error) {
+	var resuts []string
+	err := r.db.Select("gmail_id").
+		Model(Email{}).
+		Where("gmail_id IN ?", ids).
+		Find(&resuts).
+		Error
+	if err != nil {
+		return []string{}, fmt.Errorf("メール存在チェックエラー: %w", err)
 	}
+	return resuts, nil
+}
 
-	if !errors.Is(err, gorm.ErrRecordNotFound) {
-		return KeywordGroup{}, fmt.Errorf("KeywordGroup検索エラー: %w", err)
+// projectRef はメール内の案件を識別する emails.id と案件キーの組です
+type projectRef struct {
+	emailID uint
+	key     string
+}
+
+// saveProjectDetails は案件メールの詳細情報をまとめて保存します
+// 同じメールに同じ解析結果の案件が保存済みの場合、その案件は保存しません。
+// キーワード・ポジション・業務種別のマスタは種類ごとに一括で解決し、子テーブルは CreateInBatches で登録します。
+func (r *Repository) saveProjectDetails(tx *gorm.DB, results []cd.Email, emailIDs map[string]uint) error {
+	ids := make([]uint, 0, len(emailIDs))
+	for _, id := range emailIDs {
+		ids = append(ids, id)
 	}
 
-	// グループも単語も存在しない → 新規作成
-	keywordGroup = KeywordGroup{
-		Name: name,
-		Type: keywordType,
+	var existing []EmailProject
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("email_id", "project_key").
+		Where("email_id IN ?", ids).
+		Find(&existing).Error
+	if err != nil {
+		return fmt.Errorf("EmailProject存在チェックエラー: %w", err)
 	}
-	if err := tx.Create(&keywordGroup).Error; err != nil {
-		return KeywordGroup{}, fmt.Errorf("KeywordGroup作成エラー: %w", err)
+	saved := make(map[projectRef]struct{}, len(existing)+len(results))
+	for _, p := range existing {
+		saved[projectRef{emailID: p.EmailID, key: p.ProjectKey}] = struct{}{}
 	}
 
-	if keyWord.ID == 0 {
-		keyWord = KeyWord{Word: name}
-		if err := tx.Create(&keyWord).Error; err != nil {
-			return KeywordGroup{}, fmt.Errorf("KeyWord作成エラー: %w", err)
+	// 保存済みの案件と、引数内で重複する案件を除く
+	projects := make([]EmailProject, 0, len(results))
+	targets := make([]cd.Email, 0, len(results))
+	for _, result := range results {
+		ref := projectRef{emailID: emailIDs[result.GmailID], key: projectKey(result)}
+		if _, ok := saved[ref]; ok {
+			continue
 		}
+		saved[ref] = struct{}{}
+		projects = append(projects, r.setEmailProject(result, ref))
+		targets = append(targets, result)
 	}
-
-	link := KeywordGroupWordLink{
-		KeywordGroupID: keywordGroup.KeywordGroupID,
-		KeyWordID:      keyWord.ID,
+	if len(projects) == 0 {
+		return nil
 	}
-	if err := tx.Create(&link).Error; err != nil {
-		return KeywordGroup{}, fmt.Errorf("KeywordGroupWordLink作成エラー: %w", err)
+
+	// EmailProjectを保存（IDは一括INSERT後に設定される）
+	if err := tx.CreateInBatches(&projects, insertBatchSize).Error; err != nil {
+		return fmt.Errorf("EmailProject保存エラー: %w", err)
 	}
 
-	return keywordGroup, nil
+	projectIDs := make([]uint, len(projects))
+	for i, project := range projects {
+		projectIDs[i] = project.ID
+	}
+	return saveProjectChildren(tx, projectIDs, targets)
 }
 
-// savePositions はポジション関連のデータを保存します
-func (r *Repository) savePositions(tx *gorm.DB, result cd.Email, emailId uint) error {
-	for _, position := range result.Positions {
-		if position == "" {
+// replaceProjectDetails はメールの保存済みの案件を解析結果で置き換えます
+// 案件キーが同じ案件、残りは保存順に解析結果と対応付けて行を更新し（IDを引き継ぐ）、
+// 対応する行が無い解析結果は追加、対応する解析結果が無い案件は削除します。
+func (r *Repository) replaceProjectDetails(tx *gorm.DB, emailID uint, results []cd.Email) error {
+	var existing []EmailProject
+	if err := tx.Select("id", "project_key").Where("email_id = ?", emailID).Order("id").Find(&existing).Error; err != nil {
+		return fmt.Errorf("EmailProject検索エラー: %w", err)
+	}
+	existingIDs := make([]uint, len(existing))
+	byKey := make(map[string]uint, len(existing))
+	for i, p := range existing {
+		existingIDs[i] = p.ID
+		byKey[p.ProjectKey] = p.ID
+	}
+	if err := deleteProjectChildren(tx, existingIDs); err != nil {
+		return err
+	}
+
+	// 引数内で重複する案件を除き、案件キーが同じ行を対応付ける
+	targets := make([]cd.Email, 0, len(results))
+	assigned := make([]uint, 0, len(results))
+	used := make(map[uint]bool, len(existing))
+	seen := make(map[string]struct{}, len(results))
+	for _, result := range results {
+		key := projectKey(result)
+		if _, ok := seen[key]; ok {
 			continue
 		}
+		seen[key] = struct{}{}
+		targets = append(targets, result)
+		id := byKey[key]
+		if id != 0 {
+			used[id] = true
+		}
+		assigned = append(assigned, id)
+	}
+	// 案件キーが変わった解析結果は、残りの行に保存順に対応付ける
+	var rest []uint
+	for _, id := range existingIDs {
+		if !used[id] {
+			rest = append(rest, id)
+		}
+	}
+	for i := range assigned {
+		if assigned[i] == 0 && len(rest) > 0 {
+			assigned[i], rest = rest[0], rest[1:]
+		}
+	}
+	if err := deleteProjects(tx, rest); err != nil {
+		return err
+	}
 
-		// PositionGroupを取得または作成
-		positionGroup, err := r.getOrCreatePositionGroup(tx, position)
+	var updatedIDs []uint
+	var updated, added []cd.Email
+	for i, result := range targets {
+		if assigned[i] == 0 {
+			added = append(added, result)
+			continue
+		}
+		project := r.setEmailProject(result, projectRef{emailID: emailID, key: projectKey(result)})
+		project.ID = assigned[i]
+		// 応募状況・募集状況などの解析結果ではない列は更新しない
+		err := tx.Model(&project).Select("*").Omit(append([]string{clause.Associations}, projectStateColumns...)...).Updates(&project).Error
 		if err != nil {
-			return fmt.Errorf("PositionGroup取得/作成エラー: %w", err)
+			return fmt.Errorf("EmailProject更新エラー: %w", err)
 		}
-
-		// EmailPositionGroupを作成
-		emailPositionGroup := EmailPositionGroup{
-			EmailID:         emailId,
-			PositionGroupID: positionGroup.PositionGroupID,
+		updatedIDs = append(updatedIDs, project.ID)
+		updated = append(updated, result)
+	}
+	if len(updated) > 0 {
+		if err := saveProjectChildren(tx, updatedIDs, updated); err != nil {
+			return err
 		}
-
-		if err := tx.Create(&emailPositionGroup).Error; err != nil {
-			return fmt.Errorf("EmailPositionGroup保存エラー: %w", err)
+	}
+	if len(added) > 0 {
+		var email Email
+		if err := tx.Select("gmail_id").Where("id = ?", emailID).Take(&email).Error; err != nil {
+			return fmt.Errorf("メール取得エラー: %w", err)
 		}
+		return r.saveProjectDetails(tx, added, map[string]uint{email.GmailID: emailID})
 	}
 	return nil
 }
 
-// getOrCreatePositionGroup はPositionGroupを取得または作成します
-func (r *Repository) getOrCreatePositionGroup(tx *gorm.DB, name string) (PositionGroup, error) {
-	var positionGroup PositionGroup
+// projectStateColumns は案件の行のうち解析結果から作らない列です（再解析で置き換えても引き継ぐ）
+var projectStateColumns = []string{
+	"id",
+	"email_id",
+	"application_status",
+	"lifecycle_status",
+	"lifecycle_reason",
+	"lifecycle_changed_at",
+	"closed_by_email_id",
+	"archived_at",
+	"created_at",
+}
 
-	// 既存のPositionGroupを検索
-	err := tx.Where("name = ?", name).First(&positionGroup).Error
-	if err == nil {
-		return positionGroup, nil
+// saveProjectChildren は案件の子テーブル（根拠・入場時期・勤務地・キーワード等の紐付け）をまとめて保存します
+// projectIDs と targets は同じ順に対応します。
+func saveProjectChildren(tx *gorm.DB, projectIDs []uint, targets []cd.Email) error {
+	// マスタを一括で取得または作成
+	keywordGroupIDs, err := resolveKeywordGroups(tx, targets)
+	if err != nil {
+		return fmt.Errorf("KeywordGroup取得/作成エラー: %w", err)
 	}
-
-	if !errors.Is(err, gorm.ErrRecordNotFound) {
-		return PositionGroup{}, fmt.Errorf("PositionGroup検索エラー: %w", err)
+	positionGroupIDs, err := resolvePositionGroups(tx, collectNames(targets, func(e cd.Email) []string { return e.Positions }))
+	if err != nil {
+		return fmt.Errorf("PositionGroup取得/作成エラー: %w", err)
 	}
-
-	// 表記ゆれとして既に存在するかチェック
-	var existingPositionWord PositionWord
-	err = tx.Where("word = ?", name).First(&existingPositionWord).Error
-	if err == nil {
-		// 既存の表記ゆれが見つかった場合、対応するPositionGroupを取得
-		err = tx.Where("position_group_id = ?", existingPositionWord.PositionGroupID).First(&positionGroup).Error
-		if err != nil {
-			return PositionGroup{}, fmt.Errorf("既存PositionGroup取得エラー: %w", err)
+	workTypeGroupIDs, err := resolveWorkTypeGroups(tx, collectNames(targets, func(e cd.Email) []string { return e.WorkTypes }))
+	if err != nil {
+		return fmt.Errorf("WorkTypeGroup取得/作成エラー: %w", err)
+	}
+
+	var (
+		evidences      []EmailProjectFieldEvidence
+		entryTimings   []EntryTiming
+		locations      []ProjectLocation
+		keywordGroups  []EmailKeywordGroup
+		positionGroups []EmailPositionGroup
+		workTypeGroups []EmailWorkTypeGroup
+	)
+	for i, result := range targets {
+		projectID := projectIDs[i]
+		evidences = append(evidences, fieldEvidences(projectID, result.Evidences)...)
+		entryTimings = append(entryTimings, entryTimingRows(projectID, result.StartPeriod, result.ReceivedDate)...)
+		locations = append(locations, locationRows(projectID, result.WorkLocation)...)
+		keywordGroups = append(keywordGroups, keywordGroupRows(projectID, result, keywordGroupIDs)...)
+		for _, groupID := range uniqueGroupIDs(result.Positions, positionGroupIDs) {
+			positionGroups = append(positionGroups, EmailPositionGroup{EmailProjectID: projectID, PositionGroupID: groupID})
+		}
+		for _, groupID := range uniqueGroupIDs(result.WorkTypes, workTypeGroupIDs) {
+			workTypeGroups = append(workTypeGroups, EmailWorkTypeGroup{EmailProjectID: projectID, WorkTypeGroupID: groupID})
 		}
-		return positionGroup, nil
-	}
-
-	if !errors.Is(err, gorm.ErrRecordNotFound) {
-		return PositionGroup{}, fmt.Errorf("PositionWord検索エラー: %w", err)
 	}
 
-	// 新規作成
-	positionGroup = PositionGroup{
-		Name: name,
+	children := []struct {
+		name string
+		rows interface{}
+		n    int
+	}{
+		{"EmailProjectFieldEvidence", &evidences, len(evidences)},
+		{"EntryTiming", &entryTimings, len(entryTimings)},
+		{"ProjectLocation", &locations, len(locations)},
+		{"EmailKeywordGroup", &keywordGroups, len(keywordGroups)},
+		{"EmailPositionGroup", &positionGroups, len(positionGroups)},
+		{"EmailWorkTypeGroup", &workTypeGroups, len(workTypeGroups)},
+	}
+	for _, child := range children {
+		if child.n == 0 {
+			continue
+		}
+		if err := tx.Omit(clause.Associations).CreateInBatches(child.rows, insertBatchSize).Error; err != nil {
+			return fmt.Errorf("%s保存エラー: %w", child.name, err)
+		}
 	}
 
-	if err := tx.Create(&positionGroup).Error; err != nil {
-		return PositionGroup{}, fmt.Errorf("PositionGroup作成エラー: %w", err)
-	}
+	return nil
+}
 
-	// PositionWordも作成（表記ゆれとして同じ名前を登録）
-	positionWord := PositionWord{
-		PositionGroupID: positionGroup.PositionGroupID,
-		Word:            name,
+// setEmailProject は解析結果から保存するEmailProjectを作成します
+func (r *Repository) setEmailProject(result cd.Email, ref projectRef) EmailProject {
+	entryTimings := strings.Join(result.StartPeriod, ",")
+	languages := strings.Join(result.Languages, ",")
+	frameworks := strings.Join(result.Frameworks, ",")
+	positions := strings.Join(result.Positions, ",")
+	workTypes := strings.Join(result.WorkTypes, ",")
+	mustSkills := strings.Join(result.RequiredSkillsMust, ",")
+	wantSkills := strings.Join(result.RequiredSkillsWant, ",")
+	end, _ := jpdate.Parse(result.EndPeriod, result.ReceivedDate)
+	price := cd.ResolvePrice(priceText(result), result.PriceFrom, result.PriceTo)
+
+	return EmailProject{
+		EmailID:          ref.emailID,
+		ProjectKey:       ref.key,
+		ProjectTitle:     &result.Summary,
+		EntryTiming:      &entryTimings,
+		WorkLocation:     &result.WorkLocation,
+		EndTiming:        &result.EndPeriod,
+		EndFrom:          end.From,
+		EndTo:            end.To,
+		EndFlag:          string(end.Flag),
+		PriceFrom:        price.From,
+		PriceTo:          price.To,
+		PriceUnit:        string(price.Unit),
+		PriceTaxIncluded: price.TaxIncluded,
+		PriceNegotiable:  price.Negotiable,
+		SettlementFrom:   price.SettlementFrom,
+		SettlementTo:     price.SettlementTo,
+		MonthlyPriceFrom: price.MonthlyFrom(),
+		MonthlyPriceTo:   price.MonthlyTo(),
+		RemoteType:       result.RemoteWorkCategory,
+		RemoteFrequency:  result.RemoteWorkFrequency,
+		Languages:        &languages,
+		Frameworks:       &frameworks,
+		Positions:        &positions,
+		WorkTypes:        &workTypes,
+		MustSkills:       &mustSkills,
+		WantSkills:       &wantSkills,
 	}
+}
 
-	if err := tx.Create(&positionWord).Error; err != nil {
-		return PositionGroup{}, fmt.Errorf("PositionWord作成エラー: %w", err)
+// priceText は単価の表記を返します（単価FROM/TOの根拠の引用、無ければ本文の単価の行）
+func priceText(result cd.Email) string {
+	var quotes []string
+	for _, field := range []string{cd.EvidenceFieldPriceFrom, cd.EvidenceFieldPriceTo} {
+		if evidence, ok := cd.FindEvidence(result.Evidences, field); ok {
+			quotes = append(quotes, evidence.Quote)
+		}
 	}
-
-	return positionGroup, nil
+	return cd.PriceSourceText(quotes, result.Body)
 }
 
-// saveWorkTypes は業務種別関連のデータを保存します
-func (r *Repository) saveWorkTypes(tx *gorm.DB, result cd.Email, emailId uint) error {
-	for _, workType := range result.WorkTypes {
-		if workType == "" {
+// fieldEvidences は案件の項目ごとの信頼度と引用の行を作成します
+// 同じ項目が複数返却された場合は先勝ちとします。
+func fieldEvidences(emailProjectID uint, evidences []cd.FieldEvidence) []EmailProjectFieldEvidence {
+	rows := make([]EmailProjectFieldEvidence, 0, len(evidences))
+	saved := make(map[string]struct{}, len(evidences))
+	for _, evidence := range evidences {
+		if evidence.Field == "" {
 			continue
 		}
-
-		// WorkTypeGroupを取得または作成
-		workTypeGroup, err := r.getOrCreateWorkTypeGroup(tx, workType)
-		if err != nil {
-			return fmt.Errorf("WorkTypeGroup取得/作成エラー: %w", err)
+		if _, ok := saved[evidence.Field]; ok {
+			continue
 		}
+		saved[evidence.Field] = struct{}{}
 
-		// EmailWorkTypeGroupを作成
-		emailWorkTypeGroup := EmailWorkTypeGroup{
-			EmailID:         emailId,
-			WorkTypeGroupID: workTypeGroup.WorkTypeGroupID,
+		var sourceText *string
+		if evidence.Quote != "" {
+			quote := evidence.Quote
+			sourceText = &quote
 		}
+		rows = append(rows, EmailProjectFieldEvidence{
+			EmailProjectID: emailProjectID,
+			FieldName:      evidence.Field,
+			Confidence:     evidence.NormalizedConfidence(),
+			SourceText:     sourceText,
+		})
+	}
+	return rows
+}
 
-		if err := tx.Create(&emailWorkTypeGroup).Error; err != nil {
-			return fmt.Errorf("EmailWorkTypeGroup保存エラー: %w", err)
+// entryTimingRows は入場時期の行を作成します
+// 同じ入場日が複数ある場合は1行にまとめます。入場日は受信日を基準に日付の範囲へ解釈して保存します。
+func entryTimingRows(emailProjectID uint, startPeriods []string, received time.Time) []EntryTiming {
+	rows := make([]EntryTiming, 0, len(startPeriods))
+	saved := make(map[string]struct{}, len(startPeriods))
+	for _, period := range startPeriods {
+		if _, ok := saved[period]; ok {
+			continue
 		}
-	}
-	return nil
+		saved[period] = struct{}{}
+		start, _ := jpdate.Parse(period, received)
+		rows = append(rows, EntryTiming{
+			EmailProjectID: emailProjectID,
+			StartDate:      period,
+			StartFrom:      start.From,
+			StartTo:        start.To,
+			StartFlag:      string(start.Flag),
+		})
+	}
+	return rows
 }
 
-// getOrCreateWorkTypeGroup はWorkTypeGroupを取得または作成します
-func (r *Repository) getOrCreateWorkTypeGroup(tx *gorm.DB, name string) (WorkTypeGroup, error) {
-	var workTypeGroup WorkTypeGroup
-
-	// 既存のWorkTypeGroupを検索
-	err := tx.Where("name = ?", name).First(&workTypeGroup).Error
-	if err == nil {
-		return workTypeGroup, nil
-	}
+// locationRows は勤務地の表記を拠点ごとの都道府県・市区町村・最寄り駅の行にします
+// 辞書で解釈できない表記の場合は行を作りません。
+func locationRows(emailProjectID uint, workLocation string) []ProjectLocation {
+	locs := location.Normalize(workLocation)
+	rows := make([]ProjectLocation, 0, len(locs))
+	for i, l := range locs {
+		rows = append(rows, ProjectLocation{
+			EmailProjectID: emailProjectID,
+			SiteNo:         i,
+			Prefecture:     l.Prefecture,
+			City:           l.City,
+			Station:        l.Station,
+			Lat:            l.Lat,
+			Lng:            l.Lng,
+			Remote:         l.Remote,
+		})
+	}
+	return rows
+}
 
-	if !errors.Is(err, gorm.ErrRecordNotFound) {
-		return WorkTypeGroup{}, fmt.Errorf("WorkTypeGroup検索エラー: %w", err)
-	}
+// keywordGroupRows は言語・フレームワーク・必須スキル・希望スキルのキーワードの紐付け行を作成します
+// 複数の項目に同じキーワードがある場合は1行にまとめます。
+func keywordGroupRows(emailProjectID uint, result cd.Email, groupIDs map[string]uint) []EmailKeywordGroup {
+	keywords := keywordsOf(result)
+	names := make([]string, 0, len(keywords))
+	for _, kw := range keywords {
+		names = append(names, kw.name)
+	}
+	var rows []EmailKeywordGroup
+	for _, id := range uniqueGroupIDs(names, groupIDs) {
+		rows = append(rows, EmailKeywordGroup{
+			EmailProjectID: emailProjectID,
+			KeywordGroupID: id,
+		})
+	}
+	return rows
+}
 
-	// 表記ゆれとして既に存在するかチェック
-	var existingWorkTypeWord WorkTypeWord
-	err = tx.Where("word = ?", name).First(&existingWorkTypeWord).Error
-	if err == nil {
-		// 既存の表記ゆれが見つかった場合、対応するWorkTypeGroupを取得
-		err = tx.Where("work_type_group_id = ?", existingWorkTypeWord.WorkTypeGroupID).First(&workTypeGroup).Error
-		if err != nil {
-			return WorkTypeGroup{}, fmt.Errorf("既存WorkTypeGroup取得エラー: %w", err)
+// uniqueGroupIDs は名前に対応するグループIDを重複を除いて返します
+func uniqueGroupIDs(names []string, groupIDs map[string]uint) []uint {
+	ids := make([]uint, 0, len(names))
+	seen := make(map[uint]struct{}, len(names))
+	for _, name := range names {
+		if name == "" {
+			continue
 		}
-		return workTypeGroup, nil
-	}
-
-	if !errors.Is(err, gorm.ErrRecordNotFound) {
-		return WorkTypeGroup{}, fmt.Errorf("WorkTypeWord検索エラー: %w", err)
-	}
-
-	// 新規作成
-	workTypeGroup = WorkTypeGroup{
-		Name: name,
-	}
-
-	if err := tx.Create(&workTypeGroup).Error; err != nil {
-		return WorkTypeGroup{}, fmt.Errorf("WorkTypeGroup作成エラー: %w", err)
-	}
-
-	// WorkTypeWordも作成（表記ゆれとして同じ名前を登録）
-	workTypeWord := WorkTypeWord{
-		WorkTypeGroupID: workTypeGroup.WorkTypeGroupID,
-		Word:            name,
-	}
-
-	if err := tx.Create(&workTypeWord).Error; err != nil {
-		return WorkTypeGroup{}, fmt.Errorf("WorkTypeWord作成エラー: %w", err)
+		id := groupIDs[foldName(name)]
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		ids = append(ids, id)
 	}
-
-	return workTypeGroup, nil
+	return ids
 }
@@ -2,9 +2,14 @@
 package infrastructure
 
 import (
+	aa "business/internal/alert/application"
+	alertdomain "business/internal/alert/domain"
+	ai "business/internal/alert/infrastructure"
 	cd "business/internal/common/domain"
 	"business/tools/migrations/model"
 	"business/tools/mysql"
+	"business/tools/oswrapper"
+	"context"
 	"testing"
 	"time"
 
@@ -30,8 +35,13 @@ func TestEmailStoreRepositoryImpl_SaveEmail(t *testing.T) {
 		model.WorkTypeWord{},
 		model.Email{},
 		model.EmailProject{},
+		model.EmailProjectFieldEvidence{},
 		model.EmailCandidate{},
+		model.EmailCandidateKeywordGroup{},
 		model.EntryTiming{},
+		model.ProjectLocation{},
+		model.ProjectCluster{},
+		model.ProjectClusterMember{},
 		model.EmailKeywordGroup{},
 		model.EmailPositionGroup{},
 		model.EmailWorkTypeGroup{},
@@ -69,6 +79,10 @@ func TestEmailStoreRepositoryImpl_SaveEmail(t *testing.T) {
 				RequiredSkillsWant:  []string{"AWS", "Kubernetes"},
 				RemoteWorkCategory:  stringPtr("フルリモート"),
 				RemoteWorkFrequency: stringPtr("週5日"),
+				Evidences: []cd.FieldEvidence{
+					{Field: cd.EvidenceFieldPriceFrom, Confidence: 0.9, Quote: "単価:50～60万"},
+					{Field: cd.EvidenceFieldWorkLocation, Confidence: 0.3},
+				},
 			},
 			expectedError: "",
 			setupData:     func() {},
@@ -115,10 +129,20 @@ func TestEmailStoreRepositoryImpl_SaveEmail(t *testing.T) {
 						assert.Equal(t, *tt.input.PriceTo, *savedProject.PriceTo)
 					}
 
+					// 信頼度と根拠の確認
+					if len(tt.input.Evidences) > 0 {
+						var evidences []EmailProjectFieldEvidence
+						result := db.DB.Where("email_project_id = ?", savedProject.ID).Order("id").Find(&evidences)
+						assert.NoError(t, result.Error)
+						assert.Equal(t, len(tt.input.Evidences), len(evidences))
+						assert.Equal(t, tt.input.Evidences[0].Quote, *evidences[0].SourceText)
+						assert.Nil(t, evidences[1].SourceText)
+					}
+
 					// EntryTimingの確認
 					if len(tt.input.StartPeriod) > 0 {
 						var entryTimings []EntryTiming
-						result := db.DB.Where("email_id = ?", savedEmail.ID).Find(&entryTimings)
+						result := db.DB.Where("email_project_id = ?", savedProject.ID).Find(&entryTimings)
 						assert.NoError(t, result.Error)
 						assert.Equal(t, len(tt.input.StartPeriod), len(entryTimings))
 					}
@@ -126,16 +150,15 @@ func TestEmailStoreRepositoryImpl_SaveEmail(t *testing.T) {
 					// キーワード関連の確認
 					if len(tt.input.Languages) > 0 || len(tt.input.Frameworks) > 0 || len(tt.input.RequiredSkillsMust) > 0 || len(tt.input.RequiredSkillsWant) > 0 {
 						var emailKeywordGroups []EmailKeywordGroup
-						result := db.DB.Where("email_id = ?", savedEmail.ID).Find(&emailKeywordGroups)
+						result := db.DB.Where("email_project_id = ?", savedProject.ID).Find(&emailKeywordGroups)
 						assert.NoError(t, result.Error)
-						expectedKeywordCount := len(tt.input.Languages) + len(tt.input.Frameworks) + len(tt.input.RequiredSkillsMust) + len(tt.input.RequiredSkillsWant)
-						assert.Equal(t, expectedKeywordCount, len(emailKeywordGroups))
+						assert.Equal(t, distinctKeywordCount(tt.input), len(emailKeywordGroups))
 					}
 
 					// ポジション関連の確認
 					if len(tt.input.Positions) > 0 {
 						var emailPositionGroups []EmailPositionGroup
-						result := db.DB.Where("email_id = ?", savedEmail.ID).Find(&emailPositionGroups)
+						result := db.DB.Where("email_project_id = ?", savedProject.ID).Find(&emailPositionGroups)
 						assert.NoError(t, result.Error)
 						assert.Equal(t, len(tt.input.Positions), len(emailPositionGroups))
 
@@ -154,7 +177,7 @@ func TestEmailStoreRepositoryImpl_SaveEmail(t *testing.T) {
 					// 業務種別関連の確認
 					if len(tt.input.WorkTypes) > 0 {
 						var emailWorkTypeGroups []EmailWorkTypeGroup
-						result := db.DB.Where("email_id = ?", savedEmail.ID).Find(&emailWorkTypeGroups)
+						result := db.DB.Where("email_project_id = ?", savedProject.ID).Find(&emailWorkTypeGroups)
 						assert.NoError(t, result.Error)
 						assert.Equal(t, len(tt.input.WorkTypes), len(emailWorkTypeGroups))
 
@@ -195,8 +218,13 @@ func TestEmailStoreRepositoryImpl_SavedDuplicateEmail(t *testing.T) {
 		model.WorkTypeWord{},
 		model.Email{},
 		model.EmailProject{},
+		model.EmailProjectFieldEvidence{},
 		model.EmailCandidate{},
+		model.EmailCandidateKeywordGroup{},
 		model.EntryTiming{},
+		model.ProjectLocation{},
+		model.ProjectCluster{},
+		model.ProjectClusterMember{},
 		model.EmailKeywordGroup{},
 		model.EmailPositionGroup{},
 		model.EmailWorkTypeGroup{},
@@ -310,7 +338,7 @@ func TestEmailStoreRepositoryImpl_SavedDuplicateEmail(t *testing.T) {
 					// EntryTimingの確認
 					if len(tt.input.StartPeriod) > 0 {
 						var entryTimings []EntryTiming
-						result := db.DB.Where("email_id = ?", savedEmail.ID).Find(&entryTimings)
+						result := db.DB.Where("email_project_id = ?", savedProject.ID).Find(&entryTimings)
 						assert.NoError(t, result.Error)
 						assert.Equal(t, len(tt.input.StartPeriod), len(entryTimings))
 					}
@@ -318,16 +346,15 @@ func TestEmailStoreRepositoryImpl_SavedDuplicateEmail(t *testing.T) {
 					// キーワード関連の確認
 					if len(tt.input.Languages) > 0 || len(tt.input.Frameworks) > 0 || len(tt.input.RequiredSkillsMust) > 0 || len(tt.input.RequiredSkillsWant) > 0 {
 						var emailKeywordGroups []EmailKeywordGroup
-						result := db.DB.Where("email_id = ?", savedEmail.ID).Find(&emailKeywordGroups)
+						result := db.DB.Where("email_project_id = ?", savedProject.ID).Find(&emailKeywordGroups)
 						assert.NoError(t, result.Error)
-						expectedKeywordCount := len(tt.input.Languages) + len(tt.input.Frameworks) + len(tt.input.RequiredSkillsMust) + len(tt.input.RequiredSkillsWant)
-						assert.Equal(t, expectedKeywordCount, len(emailKeywordGroups))
+						assert.Equal(t, distinctKeywordCount(tt.input), len(emailKeywordGroups))
 					}
 
 					// ポジション関連の確認
 					if len(tt.input.Positions) > 0 {
 						var emailPositionGroups []EmailPositionGroup
-						result := db.DB.Where("email_id = ?", savedEmail.ID).Find(&emailPositionGroups)
+						result := db.DB.Where("email_project_id = ?", savedProject.ID).Find(&emailPositionGroups)
 						assert.NoError(t, result.Error)
 						assert.Equal(t, len(tt.input.Positions), len(emailPositionGroups))
 
@@ -346,7 +373,7 @@ func TestEmailStoreRepositoryImpl_SavedDuplicateEmail(t *testing.T) {
 					// 業務種別関連の確認
 					if len(tt.input.WorkTypes) > 0 {
 						var emailWorkTypeGroups []EmailWorkTypeGroup
-						result := db.DB.Where("email_id = ?", savedEmail.ID).Find(&emailWorkTypeGroups)
+						result := db.DB.Where("email_project_id = ?", savedProject.ID).Find(&emailWorkTypeGroups)
 						assert.NoError(t, result.Error)
 						assert.Equal(t, len(tt.input.WorkTypes), len(emailWorkTypeGroups))
 
@@ -373,3 +400,198 @@ func TestEmailStoreRepositoryImpl_SavedDuplicateEmail(t *testing.T) {
 func stringPtr(s string) *string { return &s }
 
 func intPtr(i int) *int { return &i }
+
+func TestEmailStoreRepositoryImpl_SaveCandidate(t *testing.T) {
+	t.Parallel()
+	// テスト用DBの準備
+	db, cleanup, err := mysql.CreateNewTestDB()
+	require.NoError(t, err)
+	defer cleanup()
+
+	// テーブル作成
+	err = db.DB.AutoMigrate(
+		model.KeywordGroup{},
+		model.KeyWord{},
+		model.KeywordGroupWordLink{},
+		model.Email{},
+		model.EmailProject{},
+		model.EmailProjectFieldEvidence{},
+		model.EmailCandidate{},
+		model.EmailCandidateKeywordGroup{},
+		model.EntryTiming{},
+		model.ProjectLocation{},
+		model.ProjectCluster{},
+		model.ProjectClusterMember{},
+		model.EmailKeywordGroup{},
+		model.EmailPositionGroup{},
+		model.EmailWorkTypeGroup{},
+	)
+	require.NoError(t, err)
+
+	repo := New(db.DB)
+	input := cd.Email{
+		GmailID:            "candidate-1",
+		Subject:            "人材のご紹介",
+		From:               "sales@agency.example.com",
+		FromEmail:          "sales@agency.example.com",
+		ReceivedDate:       time.Date(2025, 6, 10, 9, 0, 0, 0, time.UTC),
+		Body:               "希望単価: 70万円",
+		Category:           "人材",
+		ProjectName:        "T.Y",
+		Summary:            "Goのバックエンド開発5年",
+		StartPeriod:        []string{"7月"},
+		WorkLocation:       "東京都",
+		PriceFrom:          intPtr(700000),
+		Languages:          []string{"Go", "go"},
+		Frameworks:         []string{"Gin"},
+		RemoteWorkCategory: stringPtr("フルリモート希望"),
+	}
+
+	// 人材メールの詳細とキーワードの紐付けを保存すること
+	require.NoError(t, repo.SaveEmails([]cd.Email{input, input}))
+	var candidates []model.EmailCandidate
+	require.NoError(t, db.DB.Find(&candidates).Error)
+	require.Len(t, candidates, 1)
+	c := candidates[0]
+	assert.Equal(t, "T.Y", *c.CandidateName)
+	assert.Equal(t, "Go,Gin", *c.Skills)
+	assert.Equal(t, 700000, *c.MonthlyPriceFrom)
+	assert.Equal(t, "2025-07-01", c.AvailableFrom.Format("2006-01-02"))
+	assert.Equal(t, "フルリモート希望", *c.RemoteType)
+	var links []model.EmailCandidateKeywordGroup
+	require.NoError(t, db.DB.Where("email_candidate_id = ?", c.ID).Find(&links).Error)
+	assert.Len(t, links, 2)
+
+	// 案件の詳細は保存しないこと
+	var projects int64
+	require.NoError(t, db.DB.Model(&model.EmailProject{}).Count(&projects).Error)
+	assert.Zero(t, projects)
+
+	// 置き換えで紐付けも作り直すこと
+	input.Frameworks = nil
+	require.NoError(t, repo.ReplaceEmails("candidate-1", []cd.Email{input}))
+	require.NoError(t, db.DB.Model(&model.EmailCandidateKeywordGroup{}).Find(&links).Error)
+	assert.Len(t, links, 1)
+}
+
+// stubNotifier は送信した通知を記録する通知先です
+type stubNotifier struct {
+	sent []alertdomain.Notification
+}
+
+func (n *stubNotifier) Notify(ctx context.Context, notification alertdomain.Notification) error {
+	n.sent = append(n.sent, notification)
+	return nil
+}
+
+func TestEmailStoreRepositoryImpl_ReplaceEmails_KeepsProjectIDs(t *testing.T) {
+	t.Parallel()
+	// テスト用DBの準備
+	db, cleanup, err := mysql.CreateNewTestDB()
+	require.NoError(t, err)
+	defer cleanup()
+
+	// テーブル作成
+	err = db.DB.AutoMigrate(
+		model.KeywordGroup{},
+		model.KeyWord{},
+		model.KeywordGroupWordLink{},
+		model.PositionGroup{},
+		model.PositionWord{},
+		model.WorkTypeGroup{},
+		model.WorkTypeWord{},
+		model.Email{},
+		model.EmailProject{},
+		model.EmailProjectFieldEvidence{},
+		model.EmailCandidate{},
+		model.EmailCandidateKeywordGroup{},
+		model.EntryTiming{},
+		model.ProjectLocation{},
+		model.ProjectCluster{},
+		model.ProjectClusterMember{},
+		model.EmailKeywordGroup{},
+		model.EmailPositionGroup{},
+		model.EmailWorkTypeGroup{},
+		model.Agency{},
+		model.SavedSearch{},
+		model.Notification{},
+		model.AlertRun{},
+		model.ApplicationStatusHistory{},
+	)
+	require.NoError(t, err)
+
+	repo := New(db.DB)
+	notifier := &stubNotifier{}
+	alerts := aa.New(ai.New(db.DB), notifier, oswrapper.New())
+	_, err = alerts.CreateSearch(alertdomain.SavedSearch{UserID: "a@example.com", Name: "Go", Languages: []string{"Go"}, Enabled: true})
+	require.NoError(t, err)
+
+	base := cd.Email{
+		GmailID:      "gmail-1",
+		Subject:      "Go案件2件",
+		FromEmail:    "sales@agency.example.com",
+		ReceivedDate: time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC),
+		Category:     "案件",
+		Languages:    []string{"Go"},
+	}
+	first, second := base, base
+	first.ProjectName, first.PriceFrom = "決済API", intPtr(600000)
+	second.ProjectName = "管理画面"
+	require.NoError(t, repo.SaveEmails([]cd.Email{first, second}))
+	var before []model.EmailProject
+	require.NoError(t, db.DB.Order("id").Find(&before).Error)
+	require.Len(t, before, 2)
+	require.NoError(t, db.DB.Model(&model.EmailProject{}).Where("id = ?", before[0].ID).Update("application_status", "応募済").Error)
+
+	// 保存した2件の案件を通知すること
+	result, err := alerts.Run(context.Background(), time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Created)
+
+	// 案件キーが変わる再解析結果（1件目は単価が変わり、2件目は無くなる）で置き換える
+	first.PriceFrom = intPtr(650000)
+	first.AnalysisRevision = 2
+	require.NoError(t, repo.ReplaceEmails("gmail-1", []cd.Email{first}))
+
+	// 1件目は同じIDのまま更新され、応募状況を引き継ぐこと
+	var after []model.EmailProject
+	require.NoError(t, db.DB.Order("id").Find(&after).Error)
+	require.Len(t, after, 1)
+	assert.Equal(t, before[0].ID, after[0].ID)
+	assert.NotEqual(t, before[0].ProjectKey, after[0].ProjectKey)
+	assert.Equal(t, 650000, *after[0].PriceFrom)
+	assert.Equal(t, "応募済", after[0].ApplicationStatus)
+	var email model.Email
+	require.NoError(t, db.DB.Take(&email).Error)
+	assert.Equal(t, uint(2), email.AnalysisRevision)
+
+	// 無くなった案件の通知も送信の履歴として残ること
+	var notifications []model.Notification
+	require.NoError(t, db.DB.Order("id").Find(&notifications).Error)
+	require.Len(t, notifications, 2)
+	assert.Equal(t, before[0].ID, notifications[0].EmailProjectID)
+	assert.Equal(t, before[1].ID, notifications[1].EmailProjectID)
+
+	// 置き換えた案件を新着として通知し直さないこと
+	result, err = alerts.Run(context.Background(), time.Now())
+	require.NoError(t, err)
+	assert.Zero(t, result.Created)
+	assert.Len(t, notifier.sent, 2)
+
+	// 案件キーが変わっても、同じ案件の通知は作り直さないこと
+	created, err := ai.New(db.DB).SaveNotifications([]alertdomain.Notification{{
+		SavedSearchID: notifications[0].SavedSearchID, UserID: "a@example.com", EmailProjectID: after[0].ID,
+		GmailID: "gmail-1", ProjectKey: after[0].ProjectKey, Status: alertdomain.StatusPending,
+	}})
+	require.NoError(t, err)
+	assert.Zero(t, created)
+}
+
+// distinctKeywordCount は解析結果の言語・フレームワーク・必須スキル・希望スキルのキーワードの種類数を返します
+func distinctKeywordCount(result cd.Email) int {
+	names := make(map[string]struct{})
+	for _, kw := range keywordsOf(result) {
+		names[foldName(kw.name)] = struct{}{}
+	}
+	return len(names)
+}
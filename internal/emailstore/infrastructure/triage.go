@@ -0,0 +1,165 @@
+package infrastructure
+
+import (
+	"business/internal/emailstore/domain"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// triageRow はメールの仕分けの現在値を取得する行です
+type triageRow struct {
+	GmailID string
+	IsRead  bool
+	IsGood  bool
+	IsBad   bool
+	Note    *string
+}
+
+// projectStatusRow は案件の応募状況の現在値を取得する行です
+type projectStatusRow struct {
+	ProjectID uint
+	GmailID   string
+	Status    string
+}
+
+// LoadTriageStates はGメールIDごとの仕分けの現在値を取得します
+// 保存されていないGメールIDは結果に含まれません。
+func (r *Repository) LoadTriageStates(gmailIDs []string) ([]domain.TriageState, error) {
+	if len(gmailIDs) == 0 {
+		return nil, nil
+	}
+
+	var rows []triageRow
+	err := r.db.Model(&Email{}).
+		Select("gmail_id, is_read, is_good, is_bad, note").
+		Where("gmail_id IN ?", gmailIDs).
+		Order("id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("仕分け状態取得エラー: %w", err)
+	}
+
+	states := make([]domain.TriageState, 0, len(rows))
+	for _, row := range rows {
+		states = append(states, domain.TriageState{
+			GmailID: row.GmailID,
+			IsRead:  row.IsRead,
+			IsGood:  row.IsGood,
+			IsBad:   row.IsBad,
+			Note:    derefString(row.Note),
+		})
+	}
+	return states, nil
+}
+
+// SaveTriage はメールの仕分けの値を1つのトランザクションで保存します
+func (r *Repository) SaveTriage(states []domain.TriageState) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for _, state := range states {
+			err := tx.Model(&Email{}).Where("gmail_id = ?", state.GmailID).Updates(map[string]interface{}{
+				"is_read": state.IsRead,
+				"is_good": state.IsGood,
+				"is_bad":  state.IsBad,
+				"note":    nilIfEmpty(state.Note),
+			}).Error
+			if err != nil {
+				return fmt.Errorf("仕分け保存エラー: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// LoadProjectStatuses は案件ID（email_projects.id）ごとの応募状況の現在値を取得します
+// 保存されていない案件IDは結果に含まれません。
+func (r *Repository) LoadProjectStatuses(projectIDs []uint) ([]domain.ProjectStatus, error) {
+	if len(projectIDs) == 0 {
+		return nil, nil
+	}
+
+	var rows []projectStatusRow
+	err := r.db.Table("email_projects AS ep").
+		Select("ep.id AS project_id, e.gmail_id, ep.application_status AS status").
+		Joins("JOIN emails e ON e.id = ep.email_id").
+		Where("ep.id IN ?", projectIDs).
+		Order("ep.id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("応募状況取得エラー: %w", err)
+	}
+
+	statuses := make([]domain.ProjectStatus, 0, len(rows))
+	for _, row := range rows {
+		statuses = append(statuses, domain.ProjectStatus{
+			ProjectID: row.ProjectID,
+			GmailID:   row.GmailID,
+			Status:    domain.ApplicationStatus(row.Status),
+		})
+	}
+	return statuses, nil
+}
+
+// SaveStatusChanges は案件の応募状況の変更と変更履歴を1つのトランザクションで保存します
+// 応募状況は変更前の値のままの場合だけ更新し、ほかの更新で変わっていた場合は何も保存せずに domain.ErrStatusConflict を返します。
+func (r *Repository) SaveStatusChanges(changes []domain.StatusChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for _, change := range changes {
+			result := tx.Model(&EmailProject{}).
+				Where("id = ? AND application_status = ?", change.ProjectID, string(change.From)).
+				Update("application_status", string(change.To))
+			if result.Error != nil {
+				return fmt.Errorf("応募状況保存エラー: %w", result.Error)
+			}
+			if result.RowsAffected == 0 {
+				return fmt.Errorf("%w: 案件 %d", domain.ErrStatusConflict, change.ProjectID)
+			}
+		}
+
+		histories := make([]ApplicationStatusHistory, 0, len(changes))
+		for _, change := range changes {
+			histories = append(histories, ApplicationStatusHistory{
+				EmailProjectID: change.ProjectID,
+				FromStatus:     string(change.From),
+				ToStatus:       string(change.To),
+				Note:           nilIfEmpty(change.Note),
+				CreatedAt:      change.ChangedAt,
+			})
+		}
+		if err := tx.CreateInBatches(&histories, insertBatchSize).Error; err != nil {
+			return fmt.Errorf("応募状況履歴保存エラー: %w", err)
+		}
+		return nil
+	})
+}
+
+// ListStatusHistory は案件の応募状況の変更履歴を古い順に返します
+func (r *Repository) ListStatusHistory(projectID uint) ([]domain.StatusChange, error) {
+	var histories []ApplicationStatusHistory
+	if err := r.db.Where("email_project_id = ?", projectID).Order("created_at, id").Find(&histories).Error; err != nil {
+		return nil, fmt.Errorf("応募状況履歴取得エラー: %w", err)
+	}
+
+	changes := make([]domain.StatusChange, 0, len(histories))
+	for _, h := range histories {
+		changes = append(changes, domain.StatusChange{
+			ProjectID: h.EmailProjectID,
+			From:      domain.ApplicationStatus(h.FromStatus),
+			To:        domain.ApplicationStatus(h.ToStatus),
+			Note:      derefString(h.Note),
+			ChangedAt: h.CreatedAt,
+		})
+	}
+	return changes, nil
+}
+
+// nilIfEmpty は空文字を nil に変換します
+func nilIfEmpty(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
@@ -0,0 +1,132 @@
+package infrastructure
+
+import (
+	cd "business/internal/common/domain"
+	"business/internal/emailstore/domain"
+	"business/tools/migrations/model"
+	"business/tools/mysql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_Triage(t *testing.T) {
+	t.Parallel()
+	// テスト用DBの準備
+	db, cleanup, err := mysql.CreateNewTestDB()
+	require.NoError(t, err)
+	defer cleanup()
+
+	err = db.DB.AutoMigrate(
+		model.KeywordGroup{},
+		model.KeyWord{},
+		model.KeywordGroupWordLink{},
+		model.PositionGroup{},
+		model.PositionWord{},
+		model.WorkTypeGroup{},
+		model.WorkTypeWord{},
+		model.Email{},
+		model.EmailProject{},
+		model.EmailProjectFieldEvidence{},
+		model.EmailCandidate{},
+		model.EmailCandidateKeywordGroup{},
+		model.EntryTiming{},
+		model.ProjectLocation{},
+		model.ProjectCluster{},
+		model.ProjectClusterMember{},
+		model.EmailKeywordGroup{},
+		model.EmailPositionGroup{},
+		model.EmailWorkTypeGroup{},
+		model.ApplicationStatusHistory{},
+	)
+	require.NoError(t, err)
+
+	repo := New(db.DB)
+	received := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+	project := cd.Email{
+		GmailID: "gmail-1", Subject: "Go案件2件", From: "a@agency.example.com", FromEmail: "a@agency.example.com",
+		ReceivedDate: received, Category: "案件", ProjectName: "Go開発", Languages: []string{"Go"},
+	}
+	other := project
+	other.ProjectName = "PHP開発"
+	other.Languages = []string{"PHP"}
+	candidate := cd.Email{
+		GmailID: "gmail-2", Subject: "人材のご紹介", From: "b@agency.example.com", FromEmail: "b@agency.example.com",
+		ReceivedDate: received, Category: "人材",
+	}
+	require.NoError(t, repo.SaveEmails([]cd.Email{project, other}))
+	require.NoError(t, repo.SaveEmail(candidate))
+	var projects []model.EmailProject
+	require.NoError(t, db.DB.Order("id").Find(&projects).Error)
+	require.Len(t, projects, 2)
+	first, second := projects[0].ID, projects[1].ID
+
+	// メールの仕分けはGメールIDごと
+	states, err := repo.LoadTriageStates([]string{"gmail-1", "gmail-2", "gmail-x"})
+	require.NoError(t, err)
+	assert.Equal(t, []domain.TriageState{{GmailID: "gmail-1"}, {GmailID: "gmail-2"}}, states)
+
+	require.NoError(t, repo.SaveTriage([]domain.TriageState{
+		{GmailID: "gmail-1", IsRead: true, IsGood: true, Note: "単価交渉可"},
+		{GmailID: "gmail-2", IsRead: true},
+	}))
+	states, err = repo.LoadTriageStates([]string{"gmail-1", "gmail-2"})
+	require.NoError(t, err)
+	assert.Equal(t, []domain.TriageState{
+		{GmailID: "gmail-1", IsRead: true, IsGood: true, Note: "単価交渉可"},
+		{GmailID: "gmail-2", IsRead: true},
+	}, states)
+
+	// 同じメールの2件の案件に別々の応募状況を保存できること
+	changedAt := time.Date(2025, 6, 2, 10, 0, 0, 0, time.UTC)
+	err = repo.SaveStatusChanges([]domain.StatusChange{
+		{ProjectID: first, From: domain.StatusUntouched, To: domain.StatusApplied, Note: "単価交渉可", ChangedAt: changedAt},
+		{ProjectID: second, From: domain.StatusUntouched, To: domain.StatusDeclined, ChangedAt: changedAt},
+	})
+	require.NoError(t, err)
+
+	// 同時に更新した操作が古い応募状況から変更しようとした場合は競合し、応募状況も履歴も変えないこと
+	err = repo.SaveStatusChanges([]domain.StatusChange{
+		{ProjectID: second, From: domain.StatusDeclined, To: domain.StatusInterview, ChangedAt: changedAt},
+		{ProjectID: first, From: domain.StatusUntouched, To: domain.StatusInterview, ChangedAt: changedAt},
+	})
+	assert.ErrorIs(t, err, domain.ErrStatusConflict)
+
+	statuses, err := repo.LoadProjectStatuses([]uint{first, second, 9999})
+	require.NoError(t, err)
+	assert.Equal(t, []domain.ProjectStatus{
+		{ProjectID: first, GmailID: "gmail-1", Status: domain.StatusApplied},
+		{ProjectID: second, GmailID: "gmail-1", Status: domain.StatusDeclined},
+	}, statuses)
+
+	// 履歴も案件ごとに残ること
+	history, err := repo.ListStatusHistory(first)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	assert.Equal(t, domain.StatusApplied, history[0].To)
+	assert.Equal(t, "単価交渉可", history[0].Note)
+	assert.True(t, changedAt.Equal(history[0].ChangedAt))
+	history, err = repo.ListStatusHistory(second)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	assert.Equal(t, domain.StatusDeclined, history[0].To)
+
+	// 再解析で置き換えても案件ごとの応募状況とメールの仕分けは引き継がれる
+	project.Summary = "Go開発（再解析）"
+	other.Summary = "PHP開発（再解析）"
+	require.NoError(t, repo.ReplaceEmails("gmail-1", []cd.Email{project, other}))
+
+	statuses, err = repo.LoadProjectStatuses([]uint{first, second})
+	require.NoError(t, err)
+	assert.Equal(t, []domain.ProjectStatus{
+		{ProjectID: first, GmailID: "gmail-1", Status: domain.StatusApplied},
+		{ProjectID: second, GmailID: "gmail-1", Status: domain.StatusDeclined},
+	}, statuses)
+	states, err = repo.LoadTriageStates([]string{"gmail-1"})
+	require.NoError(t, err)
+	assert.Equal(t, []domain.TriageState{
+		{GmailID: "gmail-1", IsRead: true, IsGood: true, Note: "単価交渉可"},
+	}, states)
+}
@@ -0,0 +1,72 @@
+package infrastructure
+
+import (
+	cd "business/internal/common/domain"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+
+	driver "github.com/go-sql-driver/mysql"
+)
+
+// maxSaveAttempts はメール保存が競合した場合の最大試行回数です
+const maxSaveAttempts = 3
+
+// 再試行するMySQLのエラー番号
+const (
+	errDuplicateEntry = 1062 // 一意制約違反（同時に同じマスタを作成した場合など）
+	errDeadlock       = 1213 // デッドロック
+)
+
+// isRetryable はトランザクションをやり直せば成功しうる競合エラーかどうかを返します
+func isRetryable(err error) bool {
+	var mysqlErr *driver.MySQLError
+	if !errors.As(err, &mysqlErr) {
+		return false
+	}
+	return mysqlErr.Number == errDuplicateEntry || mysqlErr.Number == errDeadlock
+}
+
+// projectKeySource は案件を識別するキーの元になる解析結果の項目です
+type projectKeySource struct {
+	ProjectName         string   `json:"project_name"`
+	Summary             string   `json:"summary"`
+	StartPeriod         []string `json:"start_period"`
+	EndPeriod           string   `json:"end_period"`
+	WorkLocation        string   `json:"work_location"`
+	PriceFrom           *int     `json:"price_from"`
+	PriceTo             *int     `json:"price_to"`
+	Languages           []string `json:"languages"`
+	Frameworks          []string `json:"frameworks"`
+	Positions           []string `json:"positions"`
+	WorkTypes           []string `json:"work_types"`
+	RequiredSkillsMust  []string `json:"must"`
+	RequiredSkillsWant  []string `json:"want"`
+	RemoteWorkCategory  *string  `json:"remote_type"`
+	RemoteWorkFrequency *string  `json:"remote_frequency"`
+}
+
+// projectKey はメール内で案件を識別するキー（案件項目のSHA-1）を返します
+// 同じ解析結果を再度保存した場合に同じ案件と判定するために使います。
+func projectKey(result cd.Email) string {
+	b, _ := json.Marshal(projectKeySource{
+		ProjectName:         result.ProjectName,
+		Summary:             result.Summary,
+		StartPeriod:         result.StartPeriod,
+		EndPeriod:           result.EndPeriod,
+		WorkLocation:        result.WorkLocation,
+		PriceFrom:           result.PriceFrom,
+		PriceTo:             result.PriceTo,
+		Languages:           result.Languages,
+		Frameworks:          result.Frameworks,
+		Positions:           result.Positions,
+		WorkTypes:           result.WorkTypes,
+		RequiredSkillsMust:  result.RequiredSkillsMust,
+		RequiredSkillsWant:  result.RequiredSkillsWant,
+		RemoteWorkCategory:  result.RemoteWorkCategory,
+		RemoteWorkFrequency: result.RemoteWorkFrequency,
+	})
+	sum := sha1.Sum(b)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,144 @@
+package infrastructure
+
+import (
+	cd "business/internal/common/domain"
+	"business/tools/migrations/model"
+	"business/tools/mysql"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	driver "github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectKey(t *testing.T) {
+	base := cd.Email{
+		GmailID: "gmail-1", Subject: "件名", Body: "本文", Category: "案件",
+		ProjectName: "Go開発", PriceFrom: intPtr(600000), Languages: []string{"Go"},
+	}
+	key := projectKey(base)
+	assert.Len(t, key, 40)
+
+	// メール共通の項目や既読などは案件の識別に影響しない
+	other := base
+	other.GmailID = "gmail-2"
+	other.Body = "別の本文"
+	other.IsRead = true
+	other.AnalysisVersion = "v2"
+	assert.Equal(t, key, projectKey(other))
+
+	// 案件の項目が異なれば別の案件
+	other = base
+	other.PriceFrom = intPtr(700000)
+	assert.NotEqual(t, key, projectKey(other))
+}
+
+func TestIsRetryable(t *testing.T) {
+	assert.True(t, isRetryable(fmt.Errorf("保存エラー: %w", &driver.MySQLError{Number: errDeadlock})))
+	assert.True(t, isRetryable(&driver.MySQLError{Number: errDuplicateEntry}))
+	assert.False(t, isRetryable(&driver.MySQLError{Number: 1146}))
+	assert.False(t, isRetryable(errors.New("その他のエラー")))
+}
+
+func TestRepository_SaveEmail_Upsert(t *testing.T) {
+	t.Parallel()
+	// テスト用DBの準備
+	db, cleanup, err := mysql.CreateNewTestDB()
+	require.NoError(t, err)
+	defer cleanup()
+
+	err = db.DB.AutoMigrate(
+		model.KeywordGroup{},
+		model.KeyWord{},
+		model.KeywordGroupWordLink{},
+		model.PositionGroup{},
+		model.PositionWord{},
+		model.WorkTypeGroup{},
+		model.WorkTypeWord{},
+		model.Email{},
+		model.EmailProject{},
+		model.EmailProjectFieldEvidence{},
+		model.EmailCandidate{},
+		model.EmailCandidateKeywordGroup{},
+		model.EntryTiming{},
+		model.ProjectLocation{},
+		model.ProjectCluster{},
+		model.ProjectClusterMember{},
+		model.EmailKeywordGroup{},
+		model.EmailPositionGroup{},
+		model.EmailWorkTypeGroup{},
+	)
+	require.NoError(t, err)
+
+	repo := New(db.DB)
+	received := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+	newResult := func(gmailID, projectName string, price int) cd.Email {
+		return cd.Email{
+			GmailID: gmailID, Subject: "案件2件のご紹介", From: "a@agency.example.com", FromEmail: "a@agency.example.com",
+			ReceivedDate: received, Body: "本文", Category: "案件", ProjectName: projectName, Summary: projectName,
+			StartPeriod: []string{"2025/07/01"}, PriceFrom: intPtr(price),
+			Languages: []string{"Go"}, Positions: []string{"SE"}, WorkTypes: []string{"バックエンド開発"},
+		}
+	}
+	count := func(table string, gmailID string) int64 {
+		var n int64
+		q := db.DB.Table(table)
+		switch table {
+		case "emails":
+			q = q.Where("gmail_id = ?", gmailID)
+		case "email_projects":
+			q = q.Where("email_id IN (?)", db.DB.Table("emails").Select("id").Where("gmail_id = ?", gmailID))
+		default:
+			q = q.Where("email_project_id IN (?)", db.DB.Table("email_projects ep").Select("ep.id").
+				Joins("JOIN emails e ON e.id = ep.email_id").Where("e.gmail_id = ?", gmailID))
+		}
+		require.NoError(t, q.Count(&n).Error)
+		return n
+	}
+
+	// 同じ解析結果を2回保存しても行は増えない
+	first := newResult("gmail-1", "Go開発A", 600000)
+	require.NoError(t, repo.SaveEmail(first))
+	require.NoError(t, repo.SaveEmail(first))
+	assert.Equal(t, int64(1), count("emails", "gmail-1"))
+	assert.Equal(t, int64(1), count("email_projects", "gmail-1"))
+	assert.Equal(t, int64(1), count("entry_timings", "gmail-1"))
+
+	// 1通に載った2件目の案件は同じメール行に追加される
+	require.NoError(t, repo.SaveEmail(newResult("gmail-1", "Go開発B", 700000)))
+	assert.Equal(t, int64(1), count("emails", "gmail-1"))
+	assert.Equal(t, int64(2), count("email_projects", "gmail-1"))
+	assert.Equal(t, int64(2), count("email_keyword_groups", "gmail-1"))
+
+	// CLIとAPIが同時に同じメールを取り込んでも重複しない
+	results := []cd.Email{newResult("gmail-race", "並行案件A", 600000), newResult("gmail-race", "並行案件B", 650000)}
+	const savers = 2
+	var wg sync.WaitGroup
+	errs := make(chan error, savers*len(results))
+	for i := 0; i < savers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, result := range results {
+				errs <- repo.SaveEmail(result)
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, int64(1), count("emails", "gmail-race"))
+	assert.Equal(t, int64(2), count("email_projects", "gmail-race"))
+	assert.Equal(t, int64(2), count("entry_timings", "gmail-race"))
+	assert.Equal(t, int64(2), count("email_position_groups", "gmail-race"))
+
+	// 一意制約により同じGメールIDの行は直接でも作成できない
+	err = db.DB.Create(&Email{GmailID: "gmail-1", Subject: "重複"}).Error
+	assert.Error(t, err)
+}
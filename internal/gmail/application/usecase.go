@@ -12,17 +12,26 @@ import (
 	"github.com/samber/lo"
 )
 
+// MessageFilter は解析する前のメールを絞り込む処理です（営業会社のルールの適用など）
+type MessageFilter interface {
+	// FilterMessages は解析するメールを返します（除いたメール・並べ替えたメールを返せます）
+	FilterMessages(messages []cd.BasicMessage) ([]cd.BasicMessage, error)
+}
+
 // GmailUseCase はGメール機能群の具象です
 type GmailUseCase struct {
-	r  gi.ConnectInterface
-	ea ea.UseCaseInterface
+	r       gi.ConnectInterface
+	ea      ea.UseCaseInterface
+	filters []MessageFilter
 }
 
 // New は新しいメール機能群のユースケースを作成します
-func New(r gi.ConnectInterface, ea ea.UseCaseInterface) *GmailUseCase {
+// filters は取得したメールに順に適用します。
+func New(r gi.ConnectInterface, ea ea.UseCaseInterface, filters ...MessageFilter) *GmailUseCase {
 	return &GmailUseCase{
-		r:  r,
-		ea: ea,
+		r:       r,
+		ea:      ea,
+		filters: filters,
 	}
 }
 
@@ -69,5 +78,11 @@ func (g *GmailUseCase) GetMessages(ctx context.Context, labelName string, sinceD
 		existMessages = append(existMessages, msg)
 	}
 
+	for _, filter := range g.filters {
+		if existMessages, err = filter.FilterMessages(existMessages); err != nil {
+			return nil, fmt.Errorf("GetMessages: %w", err)
+		}
+	}
+
 	return existMessages, nil
 }
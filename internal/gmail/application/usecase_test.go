@@ -35,11 +35,31 @@ func (m *MockEmailStoreUseCase) SaveEmailAnalysisResult(result cd.Email) error {
 	return args.Error(0)
 }
 
+func (m *MockEmailStoreUseCase) SaveEmailAnalysisResults(results []cd.Email) (int, error) {
+	args := m.Called(results)
+	return args.Int(0), args.Error(1)
+}
+
 func (m *MockEmailStoreUseCase) GetEmailByGmailIds(gmailIds []string) ([]string, error) {
 	args := m.Called(gmailIds)
 	return args.Get(0).([]string), args.Error(1)
 }
 
+func (m *MockEmailStoreUseCase) ReplaceEmailAnalysisResults(gmailID string, results []cd.Email) error {
+	args := m.Called(gmailID, results)
+	return args.Error(0)
+}
+
+// MockMessageFilter はMessageFilterのモック実装です
+type MockMessageFilter struct {
+	mock.Mock
+}
+
+func (m *MockMessageFilter) FilterMessages(messages []cd.BasicMessage) ([]cd.BasicMessage, error) {
+	args := m.Called(messages)
+	return args.Get(0).([]cd.BasicMessage), args.Error(1)
+}
+
 func TestGmailUseCase_GetMessages(t *testing.T) {
 	ctx := context.Background()
 
@@ -147,3 +167,32 @@ func TestGmailUseCase_GetMessages_ErrorOnGetEmailByGmailIds(t *testing.T) {
 	mockGmailConnect.AssertExpectations(t)
 	mockEmailStore.AssertExpectations(t)
 }
+
+func TestGmailUseCase_GetMessages_WithFilter(t *testing.T) {
+	ctx := context.Background()
+
+	// テストデータの準備
+	blocked := cd.BasicMessage{ID: "msg1", From: "a@blocked.example.com"}
+	kept := cd.BasicMessage{ID: "msg2", From: "b@example.com"}
+
+	// モックの設定
+	mockGmailConnect := &MockGmailConnect{}
+	mockEmailStore := &MockEmailStoreUseCase{}
+	mockFilter := &MockMessageFilter{}
+	mockGmailConnect.On("GetMessageIds", ctx, "INBOX", 7).Return([]string{"msg1"}, nil)
+	mockEmailStore.On("GetEmailByGmailIds", []string{"msg1"}).Return([]string{}, nil)
+	mockGmailConnect.On("GetGmailDetail", "msg1").Return(blocked, nil)
+
+	// 絞り込んだメールだけを返すこと
+	mockFilter.On("FilterMessages", []cd.BasicMessage{blocked}).Return([]cd.BasicMessage{kept}, nil).Once()
+	result, err := New(mockGmailConnect, mockEmailStore, mockFilter).GetMessages(ctx, "INBOX", 7)
+	assert.NoError(t, err)
+	assert.Equal(t, []cd.BasicMessage{kept}, result)
+
+	// 絞り込みに失敗した場合はエラーを返すこと
+	mockFilter.On("FilterMessages", []cd.BasicMessage{blocked}).Return([]cd.BasicMessage{}, assert.AnError).Once()
+	result, err = New(mockGmailConnect, mockEmailStore, mockFilter).GetMessages(ctx, "INBOX", 7)
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	mockFilter.AssertExpectations(t)
+}
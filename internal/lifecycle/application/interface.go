@@ -0,0 +1,14 @@
+// Package application は案件の募集状況の判定とアーカイブ機能のアプリケーション層を提供します。
+// このファイルは募集状況のユースケースインターフェースを定義します。
+package application
+
+import (
+	"business/internal/lifecycle/domain"
+	"time"
+)
+
+// UseCaseInterface は案件の募集状況のユースケースインターフェースです
+type UseCaseInterface interface {
+	// RunLifecycle は募集終了の連絡の検出、募集状況の判定、古い案件のアーカイブを行います
+	RunLifecycle(rules domain.Rules, now time.Time) (domain.LifecycleResult, error)
+}
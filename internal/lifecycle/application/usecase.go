@@ -0,0 +1,104 @@
+// Package application は案件の募集状況の判定とアーカイブ機能のアプリケーション層を提供します。
+// このファイルは募集状況の判定とアーカイブのユースケースを実装します。
+package application
+
+import (
+	"business/internal/lifecycle/domain"
+	r "business/internal/lifecycle/infrastructure"
+	"fmt"
+	"time"
+)
+
+// UseCase は案件の募集状況のユースケースの具象です
+type UseCase struct {
+	r r.RepositoryInterface
+}
+
+// New は案件の募集状況のユースケースを作成します
+func New(r r.RepositoryInterface) *UseCase {
+	return &UseCase{
+		r: r,
+	}
+}
+
+// RunLifecycle は募集終了の連絡の検出、募集状況の判定、古い案件のアーカイブを順に行います
+//  1. rules.ClosureWindowDays 日以内に受信した募集終了の連絡から、同じメール・スレッド、または同じ差出人で案件名が一致する案件を closed にする
+//  2. アーカイブしていない案件の募集状況を入場時期・受信日から判定し、変わった案件を保存する
+//  3. closed / expired になってから rules.ArchiveAfterDays 日を過ぎた案件をアーカイブする
+//
+// 何度実行しても同じ結果になります。
+func (u *UseCase) RunLifecycle(rules domain.Rules, now time.Time) (domain.LifecycleResult, error) {
+	rules = rules.WithDefaults()
+	result := domain.LifecycleResult{}
+
+	closed, notices, err := u.closeByNotices(rules, now)
+	if err != nil {
+		return result, fmt.Errorf("募集終了の検出エラー: %w", err)
+	}
+	result.Notices, result.Closed = notices, closed
+
+	var afterID uint
+	for {
+		states, err := u.r.ListProjectStates(afterID, domain.DefaultBatchSize)
+		if err != nil {
+			return result, fmt.Errorf("募集状況の判定エラー: %w", err)
+		}
+		if len(states) == 0 {
+			break
+		}
+
+		var transitions []domain.Transition
+		for _, s := range states {
+			j := domain.Evaluate(s, now, rules)
+			if j.Status != s.Status || j.Reason != s.Reason {
+				transitions = append(transitions, domain.Transition{ProjectID: s.ProjectID, Status: j.Status, Reason: j.Reason})
+			}
+		}
+		if err := u.r.SaveTransitions(transitions, now); err != nil {
+			return result, fmt.Errorf("募集状況の判定エラー: %w", err)
+		}
+		result.Checked += len(states)
+		result.Changed += len(transitions)
+		afterID = states[len(states)-1].ProjectID
+	}
+
+	archived, err := u.r.ArchiveStale(now.AddDate(0, 0, -rules.ArchiveAfterDays), now)
+	if err != nil {
+		return result, fmt.Errorf("アーカイブエラー: %w", err)
+	}
+	result.Archived = archived
+	return result, nil
+}
+
+// closeByNotices は募集終了の連絡の対象の案件を closed にし、closed にした案件数と連絡の件数を返します
+func (u *UseCase) closeByNotices(rules domain.Rules, now time.Time) (int, int, error) {
+	emails, err := u.r.ListEmailsSince(now.AddDate(0, 0, -rules.ClosureWindowDays))
+	if err != nil {
+		return 0, 0, err
+	}
+
+	closed, notices := 0, 0
+	for _, n := range emails {
+		if !domain.IsClosureNotice(n.Subject, n.Body) {
+			continue
+		}
+		notices++
+
+		candidates, err := u.r.ListClosureCandidates(n, n.ReceivedDate.AddDate(0, 0, -rules.ClosureWindowDays))
+		if err != nil {
+			return closed, notices, err
+		}
+		var transitions []domain.Transition
+		for _, c := range candidates {
+			if reason, ok := domain.MatchReason(n, c); ok {
+				emailID := n.EmailID
+				transitions = append(transitions, domain.Transition{ProjectID: c.ProjectID, Status: domain.StatusClosed, Reason: reason, ClosedByEmailID: &emailID})
+			}
+		}
+		if err := u.r.SaveTransitions(transitions, now); err != nil {
+			return closed, notices, err
+		}
+		closed += len(transitions)
+	}
+	return closed, notices, nil
+}
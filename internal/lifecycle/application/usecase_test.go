@@ -0,0 +1,97 @@
+package application
+
+import (
+	"business/internal/lifecycle/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockRepository は案件の募集状況リポジトリのモックです
+type MockRepository struct {
+	mock.Mock
+}
+
+func (m *MockRepository) ListEmailsSince(since time.Time) ([]domain.Notice, error) {
+	args := m.Called(since)
+	return args.Get(0).([]domain.Notice), args.Error(1)
+}
+
+func (m *MockRepository) ListClosureCandidates(notice domain.Notice, since time.Time) ([]domain.Candidate, error) {
+	args := m.Called(notice, since)
+	return args.Get(0).([]domain.Candidate), args.Error(1)
+}
+
+func (m *MockRepository) ListProjectStates(afterID uint, limit int) ([]domain.ProjectState, error) {
+	args := m.Called(afterID, limit)
+	return args.Get(0).([]domain.ProjectState), args.Error(1)
+}
+
+func (m *MockRepository) SaveTransitions(transitions []domain.Transition, at time.Time) error {
+	args := m.Called(transitions, at)
+	return args.Error(0)
+}
+
+func (m *MockRepository) ArchiveStale(before, at time.Time) (int, error) {
+	args := m.Called(before, at)
+	return args.Int(0), args.Error(1)
+}
+
+// savedTransitions は SaveTransitions に渡された変更をまとめて返します
+func (m *MockRepository) savedTransitions() []domain.Transition {
+	var result []domain.Transition
+	for _, c := range m.Calls {
+		if c.Method == "SaveTransitions" {
+			result = append(result, c.Arguments.Get(0).([]domain.Transition)...)
+		}
+	}
+	return result
+}
+
+func TestRunLifecycle(t *testing.T) {
+	repo := new(MockRepository)
+	usecase := New(repo)
+
+	now := time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)
+	received := time.Date(2025, 7, 20, 9, 0, 0, 0, time.UTC)
+	july31 := time.Date(2025, 7, 31, 0, 0, 0, 0, time.UTC)
+	june30 := time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC)
+
+	notice := domain.Notice{EmailID: 20, GmailID: "gmail-close", ThreadID: "thread-1", SenderEmail: "a@agency.example.com", Subject: "Re: 【募集終了】在庫管理システム", ReceivedDate: received.Add(48 * time.Hour)}
+	emails := []domain.Notice{
+		{EmailID: 10, GmailID: "gmail-1", ThreadID: "thread-1", SenderEmail: "a@agency.example.com", Subject: "在庫管理システム", ReceivedDate: received},
+		notice,
+	}
+	repo.On("ListEmailsSince", now.AddDate(0, 0, -60)).Return(emails, nil)
+	repo.On("ListClosureCandidates", notice, mock.Anything).Return([]domain.Candidate{
+		{ProjectID: 1, EmailID: 10, ThreadID: "thread-1", SenderEmail: "a@agency.example.com", ReceivedDate: received},
+		{ProjectID: 2, EmailID: 11, SenderEmail: "a@agency.example.com", ProjectTitle: "証券会社向けフロント開発", ReceivedDate: received},
+	}, nil)
+
+	repo.On("ListProjectStates", uint(0), domain.DefaultBatchSize).Return([]domain.ProjectState{
+		{ProjectID: 1, ReceivedDate: received, Status: domain.StatusClosed, Reason: "同じスレッドの募集終了の連絡（gmail-close）"},
+		{ProjectID: 2, ReceivedDate: received, Starts: []domain.StartRange{{To: &july31}}, Status: domain.StatusUnknown},
+		{ProjectID: 3, ReceivedDate: received, Starts: []domain.StartRange{{To: &june30}}, Status: domain.StatusOpen},
+	}, nil)
+	repo.On("ListProjectStates", uint(3), domain.DefaultBatchSize).Return([]domain.ProjectState{}, nil)
+	repo.On("SaveTransitions", mock.Anything, now).Return(nil)
+	repo.On("ArchiveStale", now.AddDate(0, 0, -14), now).Return(1, nil)
+
+	result, err := usecase.RunLifecycle(domain.Rules{}, now)
+	require.NoError(t, err)
+	assert.Equal(t, domain.LifecycleResult{Notices: 1, Closed: 1, Checked: 3, Changed: 2, Archived: 1}, result)
+
+	transitions := repo.savedTransitions()
+	require.Len(t, transitions, 3)
+	// 同じスレッドの案件だけを募集終了にすること
+	assert.Equal(t, uint(1), transitions[0].ProjectID)
+	assert.Equal(t, domain.StatusClosed, transitions[0].Status)
+	assert.Equal(t, uint(20), *transitions[0].ClosedByEmailID)
+	// 入場時期で募集中・期限切れを判定すること
+	assert.Equal(t, domain.Transition{ProjectID: 2, Status: domain.StatusOpen, Reason: transitions[1].Reason}, transitions[1])
+	assert.Equal(t, uint(3), transitions[2].ProjectID)
+	assert.Equal(t, domain.StatusExpired, transitions[2].Status)
+}
@@ -0,0 +1,125 @@
+package domain
+
+import (
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+
+	"golang.org/x/text/width"
+)
+
+// closureHeadLines は募集終了の文言を探す本文の先頭の行数です
+const closureHeadLines = 5
+
+// minTitleKeyLength は案件名の一致とみなす正規化後の最小の文字数です（短すぎる名前の誤一致を防ぐ）
+const minTitleKeyLength = 4
+
+var (
+	reClosure = regexp.MustCompile(`募集(を)?(終了|締切|締め切|停止|中止)|(募集|案件|本件|枠)は?(充足|クローズ|決定|終了)|充足(しました|いたしました|致しました|のため)|クローズ(しました|いたしました|致しました|となりました)|(決まり|決定し)ました|終了(しました|いたしました|致しました|となりました)|closed`)
+	// reSubjectNoise は件名の返信・転送の接頭辞と、募集終了の文言を含む括弧です
+	reSubjectNoise = regexp.MustCompile(`(?i)^((re|fw|fwd)\s*[:：]\s*)+|[【\[（(][^】\]）)]*(終了|充足|クローズ|締切|決定|closed)[^】\]）)]*[】\]）)]`)
+)
+
+// Notice は募集終了の連絡のメールです
+type Notice struct {
+	EmailID      uint
+	GmailID      string
+	ThreadID     string
+	SenderEmail  string
+	Subject      string
+	Body         string // 本文（先頭のみでもよい）
+	ReceivedDate time.Time
+}
+
+// Candidate は募集終了の連絡の対象になりうる案件です
+type Candidate struct {
+	ProjectID    uint
+	EmailID      uint
+	ThreadID     string
+	SenderEmail  string
+	Subject      string
+	ProjectTitle string
+	ReceivedDate time.Time
+}
+
+// IsClosureNotice は件名または本文の先頭が募集終了の連絡かどうかを返します
+// 例: 「【募集終了】Go案件」「本件は充足いたしました」「Re: 在庫管理案件 クローズのご連絡」
+func IsClosureNotice(subject, body string) bool {
+	if reClosure.MatchString(foldText(subject)) {
+		return true
+	}
+	lines := 0
+	for _, line := range strings.Split(foldText(body), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if reClosure.MatchString(line) {
+			return true
+		}
+		lines++
+		if lines >= closureHeadLines {
+			break
+		}
+	}
+	return false
+}
+
+// MatchReason は募集終了の連絡が案件を対象にしているかどうかを判定し、対象の場合はその理由を返します
+// 同じメール・同じスレッドの案件、または同じ差出人で案件名（件名）が一致する案件を対象とします。
+// 連絡より後に受信した案件は対象にしません。
+func MatchReason(n Notice, c Candidate) (string, bool) {
+	if c.ReceivedDate.After(n.ReceivedDate) {
+		return "", false
+	}
+	switch {
+	case c.EmailID == n.EmailID:
+		return "募集終了の連絡（" + n.GmailID + "）", true
+	case n.ThreadID != "" && c.ThreadID == n.ThreadID:
+		return "同じスレッドの募集終了の連絡（" + n.GmailID + "）", true
+	case strings.EqualFold(c.SenderEmail, n.SenderEmail) && titleMatches(n.Subject, c):
+		return "同じ差出人・案件名の募集終了の連絡（" + n.GmailID + "）", true
+	}
+	return "", false
+}
+
+// titleMatches は連絡の件名が案件の案件名または件名を含む（または含まれる）かどうかを返します
+func titleMatches(subject string, c Candidate) bool {
+	key := TitleKey(subject)
+	for _, title := range []string{c.ProjectTitle, c.Subject} {
+		k := TitleKey(title)
+		if len([]rune(k)) < minTitleKeyLength {
+			continue
+		}
+		if strings.Contains(key, k) || (len([]rune(key)) >= minTitleKeyLength && strings.Contains(k, key)) {
+			return true
+		}
+	}
+	return false
+}
+
+// TitleKey は件名・案件名から返信・転送の接頭辞、募集終了の文言、記号と空白を除いた比較用の文字列を返します
+func TitleKey(s string) string {
+	s = foldText(s)
+	for {
+		next := reSubjectNoise.ReplaceAllString(strings.TrimSpace(s), "")
+		if next == s {
+			break
+		}
+		s = next
+	}
+	s = reClosure.ReplaceAllString(s, "")
+	var b strings.Builder
+	for _, r := range s {
+		if unicode.IsLetter(r) || unicode.IsNumber(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// foldText は全角英数を半角に揃え、小文字にします
+func foldText(s string) string {
+	return strings.ToLower(width.Fold.String(s))
+}
@@ -0,0 +1,153 @@
+// Package domain は案件の募集状況（募集中・募集終了・期限切れ）の判定とアーカイブ機能のドメイン層を提供します。
+// このファイルは募集状況と、入場時期・受信日による期限切れの判定ルールを定義します。
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// Status は案件の募集状況です
+type Status string
+
+const (
+	StatusOpen    Status = "open"    // 募集中（入場時期が先、または受信から日が浅い）
+	StatusClosed  Status = "closed"  // 募集終了の連絡があった
+	StatusExpired Status = "expired" // 入場時期を過ぎた、または受信から日数が経った
+	StatusUnknown Status = "unknown" // 入場時期が不明で、受信から日が浅い
+)
+
+// Statuses は募集状況の一覧です（表示・検証の順）
+var Statuses = []Status{StatusOpen, StatusClosed, StatusExpired, StatusUnknown}
+
+// 入場時期の種類（entry_timings.start_flag）
+const (
+	StartFlagImmediate = "immediate" // 即日
+	StartFlagOngoing   = "ongoing"   // 随時
+	StartFlagUndecided = "undecided" // 未定
+)
+
+// DefaultBatchSize は募集状況を判定する案件の既定の件数です
+const DefaultBatchSize = 500
+
+// Rules は募集状況の判定ルールです
+type Rules struct {
+	ExpireGraceDays   int // 入場時期の範囲の終了からこの日数を過ぎたら期限切れ
+	MaxOpenDays       int // 即日・随時・入場時期不明の案件は受信からこの日数を過ぎたら期限切れ
+	ArchiveAfterDays  int // 募集終了・期限切れになってからこの日数を過ぎたらアーカイブ
+	ClosureWindowDays int // 募集終了の連絡を探す受信日の範囲（日数）。連絡より前のこの日数以内の案件を対象にする
+}
+
+// DefaultRules は既定の判定ルールを返します
+func DefaultRules() Rules {
+	return Rules{
+		ExpireGraceDays:   7,
+		MaxOpenDays:       45,
+		ArchiveAfterDays:  14,
+		ClosureWindowDays: 60,
+	}
+}
+
+// WithDefaults は0以下の項目に既定値を設定したルールを返します
+func (r Rules) WithDefaults() Rules {
+	d := DefaultRules()
+	if r.ExpireGraceDays <= 0 {
+		r.ExpireGraceDays = d.ExpireGraceDays
+	}
+	if r.MaxOpenDays <= 0 {
+		r.MaxOpenDays = d.MaxOpenDays
+	}
+	if r.ArchiveAfterDays <= 0 {
+		r.ArchiveAfterDays = d.ArchiveAfterDays
+	}
+	if r.ClosureWindowDays <= 0 {
+		r.ClosureWindowDays = d.ClosureWindowDays
+	}
+	return r
+}
+
+// StartRange は入場時期を日付の範囲に解釈した値です（entry_timings の1行）
+type StartRange struct {
+	From *time.Time
+	To   *time.Time
+	Flag string
+}
+
+// ProjectState は募集状況の判定に使う案件の情報です
+type ProjectState struct {
+	ProjectID    uint
+	ReceivedDate time.Time
+	Starts       []StartRange
+	Status       Status
+	Reason       string
+}
+
+// Judgement は募集状況の判定結果です
+type Judgement struct {
+	Status Status
+	Reason string
+}
+
+// Evaluate は案件の募集状況を判定します
+// 募集終了の連絡があった案件（closed）はそのままです。それ以外は次の順で判定します。
+//  1. 入場時期の範囲に終了がある場合: 最も遅い終了日 + ExpireGraceDays を過ぎたら expired、それまでは open
+//  2. 即日・随時・開始日のみの場合: 受信日（開始日が後ならその日）+ MaxOpenDays を過ぎたら expired、それまでは open
+//  3. 入場時期が不明（未定・記載なし）の場合: 受信日 + MaxOpenDays を過ぎたら expired、それまでは unknown
+func Evaluate(p ProjectState, now time.Time, rules Rules) Judgement {
+	if p.Status == StatusClosed {
+		return Judgement{Status: StatusClosed, Reason: p.Reason}
+	}
+	rules = rules.WithDefaults()
+
+	var latestTo, latestFrom *time.Time
+	known := false
+	for _, s := range p.Starts {
+		if s.To != nil && (latestTo == nil || s.To.After(*latestTo)) {
+			latestTo = s.To
+		}
+		if s.From != nil && (latestFrom == nil || s.From.After(*latestFrom)) {
+			latestFrom = s.From
+		}
+		if s.From != nil || s.To != nil || s.Flag == StartFlagImmediate || s.Flag == StartFlagOngoing {
+			known = true
+		}
+	}
+
+	if latestTo != nil {
+		deadline := latestTo.AddDate(0, 0, rules.ExpireGraceDays)
+		if now.After(deadline) {
+			return Judgement{Status: StatusExpired, Reason: fmt.Sprintf("入場時期（〜%s）を%d日過ぎました", latestTo.Format("2006-01-02"), rules.ExpireGraceDays)}
+		}
+		return Judgement{Status: StatusOpen, Reason: fmt.Sprintf("入場時期（〜%s）の期間内です", latestTo.Format("2006-01-02"))}
+	}
+
+	base := p.ReceivedDate
+	if latestFrom != nil && latestFrom.After(base) {
+		base = *latestFrom
+	}
+	deadline := base.AddDate(0, 0, rules.MaxOpenDays)
+	if now.After(deadline) {
+		return Judgement{Status: StatusExpired, Reason: fmt.Sprintf("%s から%d日経過しました", base.Format("2006-01-02"), rules.MaxOpenDays)}
+	}
+	if known {
+		return Judgement{Status: StatusOpen, Reason: fmt.Sprintf("%s から%d日以内です", base.Format("2006-01-02"), rules.MaxOpenDays)}
+	}
+	return Judgement{Status: StatusUnknown, Reason: "入場時期が不明です"}
+}
+
+// Transition は案件の募集状況の変更です
+type Transition struct {
+	ProjectID       uint
+	Status          Status
+	Reason          string
+	ClosedByEmailID *uint // 募集終了の連絡のメールID（closed の場合）
+}
+
+// LifecycleResult は募集状況の判定とアーカイブの実行結果です
+type LifecycleResult struct {
+	Notices  int `json:"notices"`  // 見つかった募集終了の連絡の件数
+	Closed   int `json:"closed"`   // 募集終了にした案件数
+	Checked  int `json:"checked"`  // 募集状況を判定した案件数
+	Changed  int `json:"changed"`  // 募集状況が変わった案件数
+	Archived int `json:"archived"` // アーカイブした案件数
+}
@@ -0,0 +1,106 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluate(t *testing.T) {
+	now := time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)
+	day := func(m time.Month, d int) *time.Time {
+		v := time.Date(2025, m, d, 0, 0, 0, 0, time.UTC)
+		return &v
+	}
+	received := time.Date(2025, 7, 1, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		state    ProjectState
+		expected Status
+	}{
+		{
+			name:     "入場時期の範囲内は募集中になること",
+			state:    ProjectState{ReceivedDate: received, Starts: []StartRange{{From: day(7, 1), To: day(7, 31)}}},
+			expected: StatusOpen,
+		},
+		{
+			name:     "入場時期の終了から猶予を過ぎたら期限切れになること",
+			state:    ProjectState{ReceivedDate: received, Starts: []StartRange{{From: day(6, 1), To: day(6, 30)}}},
+			expected: StatusExpired,
+		},
+		{
+			name:     "複数の入場時期は最も遅い終了で判定すること",
+			state:    ProjectState{ReceivedDate: received, Starts: []StartRange{{To: day(6, 30)}, {From: day(8, 1), To: day(8, 31)}}},
+			expected: StatusOpen,
+		},
+		{
+			name:     "即日は受信から一定日数は募集中になること",
+			state:    ProjectState{ReceivedDate: received, Starts: []StartRange{{From: day(7, 1), Flag: StartFlagImmediate}}},
+			expected: StatusOpen,
+		},
+		{
+			name:     "即日でも受信から一定日数を過ぎたら期限切れになること",
+			state:    ProjectState{ReceivedDate: received.AddDate(0, -2, 0), Starts: []StartRange{{Flag: StartFlagImmediate}}},
+			expected: StatusExpired,
+		},
+		{
+			name:     "入場時期が不明で受信から日が浅い場合は不明になること",
+			state:    ProjectState{ReceivedDate: received, Starts: []StartRange{{Flag: StartFlagUndecided}}},
+			expected: StatusUnknown,
+		},
+		{
+			name:     "入場時期の記載が無く受信から一定日数を過ぎたら期限切れになること",
+			state:    ProjectState{ReceivedDate: received.AddDate(0, -3, 0)},
+			expected: StatusExpired,
+		},
+		{
+			name:     "募集終了はそのままになること",
+			state:    ProjectState{ReceivedDate: received, Starts: []StartRange{{To: day(8, 31)}}, Status: StatusClosed, Reason: "募集終了の連絡"},
+			expected: StatusClosed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Evaluate(tt.state, now, Rules{})
+			assert.Equal(t, tt.expected, got.Status)
+			assert.NotEmpty(t, got.Reason)
+		})
+	}
+}
+
+func TestIsClosureNotice(t *testing.T) {
+	assert.True(t, IsClosureNotice("【募集終了】Go案件のご紹介", ""))
+	assert.True(t, IsClosureNotice("Re: 在庫管理システム案件", "お世話になっております。\n本件は充足いたしました。"))
+	assert.True(t, IsClosureNotice("【ｸﾛｰｽﾞ】案件", "案件はクローズしました"))
+	assert.False(t, IsClosureNotice("【Go/フルリモート】在庫管理システム", "お世話になっております。\n下記案件のご紹介です。"))
+	// 本文の先頭以外の文言は対象にしない
+	assert.False(t, IsClosureNotice("案件のご紹介", "1\n2\n3\n4\n5\n前任者の契約は終了しました"))
+}
+
+func TestMatchReason(t *testing.T) {
+	base := time.Date(2025, 7, 1, 9, 0, 0, 0, time.UTC)
+	notice := Notice{EmailID: 10, GmailID: "gmail-close", ThreadID: "thread-1", SenderEmail: "a@agency.example.com", Subject: "【募集終了】在庫管理システムのリプレイス", ReceivedDate: base}
+
+	_, ok := MatchReason(notice, Candidate{EmailID: 1, ThreadID: "thread-1", SenderEmail: "other@example.com", ReceivedDate: base.Add(-time.Hour)})
+	assert.True(t, ok, "同じスレッド")
+
+	_, ok = MatchReason(notice, Candidate{EmailID: 2, SenderEmail: "A@agency.example.com", ProjectTitle: "在庫管理システムのリプレイス", ReceivedDate: base.Add(-time.Hour)})
+	assert.True(t, ok, "同じ差出人・案件名")
+
+	_, ok = MatchReason(notice, Candidate{EmailID: 3, SenderEmail: "a@agency.example.com", ProjectTitle: "証券会社向けフロント開発", Subject: "React案件", ReceivedDate: base.Add(-time.Hour)})
+	assert.False(t, ok, "同じ差出人でも案件名が違う")
+
+	_, ok = MatchReason(notice, Candidate{EmailID: 4, SenderEmail: "b@other.example.com", ProjectTitle: "在庫管理システムのリプレイス", ReceivedDate: base.Add(-time.Hour)})
+	assert.False(t, ok, "別の差出人")
+
+	_, ok = MatchReason(notice, Candidate{EmailID: 5, ThreadID: "thread-1", ReceivedDate: base.Add(time.Hour)})
+	assert.False(t, ok, "連絡より後に受信した案件")
+}
+
+func TestTitleKey(t *testing.T) {
+	assert.Equal(t, "在庫管理システムのリプレイス", TitleKey("Re: Fw: 【募集終了】在庫管理システムのリプレイス"))
+	assert.Equal(t, "go案件", TitleKey("【充足】ＧＯ案件"))
+}
@@ -0,0 +1,26 @@
+// Package infrastructure は案件の募集状況の判定とアーカイブ機能のインフラストラクチャ層を提供します。
+// このファイルは募集状況の判定で使用するリポジトリのインターフェースを定義します。
+package infrastructure
+
+import (
+	"business/internal/lifecycle/domain"
+	"time"
+)
+
+// RepositoryInterface は案件の募集状況のリポジトリインターフェースです
+type RepositoryInterface interface {
+	// ListEmailsSince は since 以降に受信したメールを、募集終了の連絡の候補として本文の先頭付きで返します
+	ListEmailsSince(since time.Time) ([]domain.Notice, error)
+
+	// ListClosureCandidates は募集終了の連絡と同じメール・スレッド・差出人の、募集終了になっていない案件を返します
+	ListClosureCandidates(notice domain.Notice, since time.Time) ([]domain.Candidate, error)
+
+	// ListProjectStates は afterID より大きいIDのアーカイブしていない案件を、入場時期付きでID順に最大 limit 件返します
+	ListProjectStates(afterID uint, limit int) ([]domain.ProjectState, error)
+
+	// SaveTransitions は案件の募集状況の変更を保存します
+	SaveTransitions(transitions []domain.Transition, at time.Time) error
+
+	// ArchiveStale は募集終了・期限切れになってから before より前の案件をアーカイブし、件数を返します
+	ArchiveStale(before, at time.Time) (int, error)
+}
@@ -0,0 +1,45 @@
+// Package infrastructure は案件の募集状況の判定とアーカイブ機能のインフラストラクチャ層を提供します。
+// このファイルは募集状況の判定で参照するテーブルの列を定義します。
+package infrastructure
+
+import (
+	"time"
+)
+
+// noticeRow は募集終了の連絡の候補として参照するメールの列です
+type noticeRow struct {
+	ID           uint
+	GmailID      string
+	ThreadID     string
+	SenderEmail  string
+	Subject      string
+	BodyHead     *string
+	ReceivedDate time.Time
+}
+
+// candidateRow は募集終了の連絡の対象になりうる案件の列です
+type candidateRow struct {
+	ProjectID    uint
+	EmailID      uint
+	ThreadID     string
+	SenderEmail  string
+	Subject      string
+	ProjectTitle *string
+	ReceivedDate time.Time
+}
+
+// stateRow は募集状況の判定に参照する案件の列です
+type stateRow struct {
+	ProjectID       uint
+	ReceivedDate    time.Time
+	LifecycleStatus string
+	LifecycleReason string
+}
+
+// startRow は案件の入場時期の列です
+type startRow struct {
+	EmailProjectID uint
+	StartFrom      *time.Time
+	StartTo        *time.Time
+	StartFlag      string
+}
@@ -0,0 +1,174 @@
+// Package infrastructure は案件の募集状況の判定とアーカイブ機能のインフラストラクチャ層を提供します。
+// このファイルは募集状況の参照・更新とアーカイブを実装します。
+package infrastructure
+
+import (
+	"business/internal/lifecycle/domain"
+	"fmt"
+	"time"
+
+	"github.com/samber/lo"
+	"gorm.io/gorm"
+)
+
+// noticeBodyLength は募集終了の文言を探すために取得する本文の先頭の文字数です
+const noticeBodyLength = 1000
+
+// Repository は案件の募集状況のリポジトリ実装です
+type Repository struct {
+	db *gorm.DB
+}
+
+// New は案件の募集状況のリポジトリを作成します
+func New(db *gorm.DB) *Repository {
+	return &Repository{
+		db: db,
+	}
+}
+
+// ListEmailsSince は since 以降に受信したメールを、募集終了の連絡の候補として本文の先頭付きで返します
+func (r *Repository) ListEmailsSince(since time.Time) ([]domain.Notice, error) {
+	var rows []noticeRow
+	err := r.db.Table("emails").
+		Select("id, gmail_id, thread_id, sender_email, subject, LEFT(body, ?) AS body_head, received_date", noticeBodyLength).
+		Where("received_date >= ?", since).
+		Order("received_date, id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("メール取得エラー: %w", err)
+	}
+
+	notices := make([]domain.Notice, 0, len(rows))
+	for _, row := range rows {
+		notices = append(notices, domain.Notice{
+			EmailID:      row.ID,
+			GmailID:      row.GmailID,
+			ThreadID:     row.ThreadID,
+			SenderEmail:  row.SenderEmail,
+			Subject:      row.Subject,
+			Body:         derefString(row.BodyHead),
+			ReceivedDate: row.ReceivedDate,
+		})
+	}
+	return notices, nil
+}
+
+// ListClosureCandidates は募集終了の連絡と同じメール・スレッド・差出人の、募集終了になっていない案件を返します
+// 受信日が since から連絡の受信日までの案件を対象にします。
+func (r *Repository) ListClosureCandidates(notice domain.Notice, since time.Time) ([]domain.Candidate, error) {
+	query := r.db.Table("email_projects ep").
+		Select("ep.id AS project_id, e.id AS email_id, e.thread_id, e.sender_email, e.subject, ep.project_title, e.received_date").
+		Joins("JOIN emails e ON e.id = ep.email_id").
+		Where("ep.lifecycle_status <> ?", domain.StatusClosed).
+		Where("e.received_date BETWEEN ? AND ?", since, notice.ReceivedDate)
+	if notice.ThreadID != "" {
+		query = query.Where("(e.id = ? OR e.thread_id = ? OR e.sender_email = ?)", notice.EmailID, notice.ThreadID, notice.SenderEmail)
+	} else {
+		query = query.Where("(e.id = ? OR e.sender_email = ?)", notice.EmailID, notice.SenderEmail)
+	}
+
+	var rows []candidateRow
+	if err := query.Order("ep.id").Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("募集終了の対象案件取得エラー: %w", err)
+	}
+
+	candidates := make([]domain.Candidate, 0, len(rows))
+	for _, row := range rows {
+		candidates = append(candidates, domain.Candidate{
+			ProjectID:    row.ProjectID,
+			EmailID:      row.EmailID,
+			ThreadID:     row.ThreadID,
+			SenderEmail:  row.SenderEmail,
+			Subject:      row.Subject,
+			ProjectTitle: derefString(row.ProjectTitle),
+			ReceivedDate: row.ReceivedDate,
+		})
+	}
+	return candidates, nil
+}
+
+// ListProjectStates は afterID より大きいIDのアーカイブしていない案件を、入場時期付きでID順に最大 limit 件返します
+func (r *Repository) ListProjectStates(afterID uint, limit int) ([]domain.ProjectState, error) {
+	var rows []stateRow
+	err := r.db.Table("email_projects ep").
+		Select("ep.id AS project_id, e.received_date, ep.lifecycle_status, ep.lifecycle_reason").
+		Joins("JOIN emails e ON e.id = ep.email_id").
+		Where("ep.id > ? AND ep.archived_at IS NULL", afterID).
+		Order("ep.id").
+		Limit(limit).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("案件取得エラー: %w", err)
+	}
+	if len(rows) == 0 {
+		return []domain.ProjectState{}, nil
+	}
+
+	states := make([]domain.ProjectState, 0, len(rows))
+	index := make(map[uint]int, len(rows))
+	for _, row := range rows {
+		index[row.ProjectID] = len(states)
+		states = append(states, domain.ProjectState{
+			ProjectID:    row.ProjectID,
+			ReceivedDate: row.ReceivedDate,
+			Status:       domain.Status(row.LifecycleStatus),
+			Reason:       row.LifecycleReason,
+		})
+	}
+
+	var starts []startRow
+	err = r.db.Table("entry_timings").
+		Select("email_project_id, start_from, start_to, start_flag").
+		Where("email_project_id IN ?", lo.Keys(index)).
+		Order("email_project_id").
+		Scan(&starts).Error
+	if err != nil {
+		return nil, fmt.Errorf("入場時期取得エラー: %w", err)
+	}
+	for _, s := range starts {
+		p := &states[index[s.EmailProjectID]]
+		p.Starts = append(p.Starts, domain.StartRange{From: s.StartFrom, To: s.StartTo, Flag: s.StartFlag})
+	}
+	return states, nil
+}
+
+// SaveTransitions は案件の募集状況の変更を1つのトランザクションで保存します
+func (r *Repository) SaveTransitions(transitions []domain.Transition, at time.Time) error {
+	if len(transitions) == 0 {
+		return nil
+	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for _, t := range transitions {
+			updates := map[string]interface{}{
+				"lifecycle_status":     string(t.Status),
+				"lifecycle_reason":     t.Reason,
+				"lifecycle_changed_at": at,
+			}
+			if t.ClosedByEmailID != nil {
+				updates["closed_by_email_id"] = *t.ClosedByEmailID
+			}
+			if err := tx.Table("email_projects").Where("id = ?", t.ProjectID).Updates(updates).Error; err != nil {
+				return fmt.Errorf("募集状況保存エラー: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// ArchiveStale は募集終了・期限切れになってから before より前の案件をアーカイブし、件数を返します
+func (r *Repository) ArchiveStale(before, at time.Time) (int, error) {
+	result := r.db.Table("email_projects").
+		Where("archived_at IS NULL AND lifecycle_status IN ? AND lifecycle_changed_at < ?", []domain.Status{domain.StatusClosed, domain.StatusExpired}, before).
+		Update("archived_at", at)
+	if result.Error != nil {
+		return 0, fmt.Errorf("アーカイブエラー: %w", result.Error)
+	}
+	return int(result.RowsAffected), nil
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,107 @@
+package infrastructure
+
+import (
+	"business/internal/lifecycle/domain"
+	"business/tools/migrations/model"
+	"business/tools/mysql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_Lifecycle(t *testing.T) {
+	t.Parallel()
+	// テスト用DBの準備
+	db, cleanup, err := mysql.CreateNewTestDB()
+	require.NoError(t, err)
+	defer cleanup()
+
+	err = db.DB.AutoMigrate(
+		model.Email{},
+		model.EmailProject{},
+		model.EntryTiming{},
+	)
+	require.NoError(t, err)
+
+	now := time.Now().Truncate(time.Second)
+	body := "お世話になっております。\n本件は充足いたしました。"
+	emails := []model.Email{
+		{GmailID: "gmail-1", ThreadID: "thread-1", Subject: "在庫管理システム", SenderEmail: "a@agency.example.com", ReceivedDate: now.Add(-48 * time.Hour), Category: "案件"},
+		{GmailID: "gmail-2", ThreadID: "thread-2", Subject: "証券会社向けフロント開発", SenderEmail: "a@agency.example.com", ReceivedDate: now.Add(-24 * time.Hour), Category: "案件"},
+		{GmailID: "gmail-3", ThreadID: "thread-3", Subject: "別会社の案件", SenderEmail: "b@other.example.com", ReceivedDate: now.Add(-24 * time.Hour), Category: "案件"},
+		{GmailID: "gmail-close", ThreadID: "thread-1", Subject: "Re: 在庫管理システム", SenderEmail: "a@agency.example.com", ReceivedDate: now.Add(-time.Hour), Body: &body, Category: "その他"},
+	}
+	require.NoError(t, db.DB.Create(&emails).Error)
+	projects := []model.EmailProject{
+		{EmailID: emails[0].ID, ProjectKey: "p1"},
+		{EmailID: emails[1].ID, ProjectKey: "p2"},
+		{EmailID: emails[2].ID, ProjectKey: "p3"},
+	}
+	require.NoError(t, db.DB.Create(&projects).Error)
+	to := time.Date(2025, 7, 31, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, db.DB.Create(&model.EntryTiming{EmailProjectID: projects[0].ID, StartDate: "7月", StartTo: &to}).Error)
+
+	repo := New(db.DB)
+
+	// 受信日の範囲内のメールを本文の先頭付きで返すこと
+	notices, err := repo.ListEmailsSince(now.AddDate(0, 0, -1).Add(-time.Minute))
+	require.NoError(t, err)
+	require.Len(t, notices, 3)
+	assert.Equal(t, "thread-1", notices[2].ThreadID)
+	assert.Equal(t, body, notices[2].Body)
+
+	// 同じスレッド・差出人の案件を返し、別の差出人の案件は返さないこと
+	candidates, err := repo.ListClosureCandidates(notices[2], now.AddDate(0, 0, -60))
+	require.NoError(t, err)
+	require.Len(t, candidates, 2)
+	assert.Equal(t, projects[0].ID, candidates[0].ProjectID)
+	assert.Equal(t, "thread-1", candidates[0].ThreadID)
+	assert.Equal(t, projects[1].ID, candidates[1].ProjectID)
+
+	// 入場時期付きで案件を返すこと
+	states, err := repo.ListProjectStates(0, domain.DefaultBatchSize)
+	require.NoError(t, err)
+	require.Len(t, states, 3)
+	assert.Equal(t, domain.StatusUnknown, states[0].Status)
+	require.Len(t, states[0].Starts, 1)
+	assert.Equal(t, "2025-07-31", states[0].Starts[0].To.Format("2006-01-02"))
+	assert.Empty(t, states[1].Starts)
+
+	// 募集状況の変更を保存すること
+	closedBy := emails[3].ID
+	changedAt := now.AddDate(0, 0, -20)
+	require.NoError(t, repo.SaveTransitions([]domain.Transition{
+		{ProjectID: projects[0].ID, Status: domain.StatusClosed, Reason: "募集終了の連絡", ClosedByEmailID: &closedBy},
+		{ProjectID: projects[1].ID, Status: domain.StatusOpen, Reason: "募集中"},
+	}, changedAt))
+	require.NoError(t, repo.SaveTransitions([]domain.Transition{
+		{ProjectID: projects[2].ID, Status: domain.StatusExpired, Reason: "期限切れ"},
+	}, now))
+	var saved model.EmailProject
+	require.NoError(t, db.DB.First(&saved, projects[0].ID).Error)
+	assert.Equal(t, "closed", saved.LifecycleStatus)
+	assert.Equal(t, "募集終了の連絡", saved.LifecycleReason)
+	require.NotNil(t, saved.ClosedByEmailID)
+	assert.Equal(t, closedBy, *saved.ClosedByEmailID)
+
+	// 変更から日数が経った募集終了・期限切れだけをアーカイブすること
+	archived, err := repo.ArchiveStale(now.AddDate(0, 0, -14), now)
+	require.NoError(t, err)
+	assert.Equal(t, 1, archived)
+	archived, err = repo.ArchiveStale(now.AddDate(0, 0, -14), now)
+	require.NoError(t, err)
+	assert.Equal(t, 0, archived)
+
+	// アーカイブした案件は判定の対象にしないこと
+	states, err = repo.ListProjectStates(0, domain.DefaultBatchSize)
+	require.NoError(t, err)
+	require.Len(t, states, 2)
+	assert.Equal(t, projects[1].ID, states[0].ProjectID)
+	assert.Equal(t, domain.StatusOpen, states[0].Status)
+	states, err = repo.ListProjectStates(projects[1].ID, domain.DefaultBatchSize)
+	require.NoError(t, err)
+	require.Len(t, states, 1)
+	assert.Equal(t, projects[2].ID, states[0].ProjectID)
+}
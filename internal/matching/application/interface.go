@@ -0,0 +1,35 @@
+// Package application はエンジニアのスキルプロフィールと案件のマッチング機能のアプリケーション層を提供します。
+// このファイルはマッチングのユースケースインターフェースを定義します。
+package application
+
+import (
+	"business/internal/matching/domain"
+	"time"
+)
+
+// UseCaseInterface はエンジニアのプロフィールと案件のマッチングのユースケースインターフェースです
+type UseCaseInterface interface {
+	// ListProfiles はプロフィールをID順に返します
+	ListProfiles() ([]domain.Profile, error)
+
+	// GetProfile はプロフィールを返します
+	GetProfile(id uint) (domain.Profile, error)
+
+	// GetProfileByName は名前が一致するプロフィールを返します
+	GetProfileByName(name string) (domain.Profile, error)
+
+	// CreateProfile はプロフィールを検証して保存します
+	CreateProfile(p domain.Profile) (domain.Profile, error)
+
+	// UpdateProfile はプロフィールを検証して更新します
+	UpdateProfile(p domain.Profile) (domain.Profile, error)
+
+	// DeleteProfile はプロフィールを削除します
+	DeleteProfile(id uint) error
+
+	// MatchProjects はプロフィールに対する案件の適合度を採点し、高い順に返します
+	MatchProjects(profileID uint, opts domain.MatchOptions, now time.Time) (domain.MatchResult, error)
+
+	// MatchCandidates は案件に対する人材メールの適合度を採点し、高い順に返します
+	MatchCandidates(projectID uint, opts domain.MatchOptions, now time.Time) (domain.CandidateResult, error)
+}
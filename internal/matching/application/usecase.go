@@ -0,0 +1,225 @@
+// Package application はエンジニアのスキルプロフィールと案件のマッチング機能のアプリケーション層を提供します。
+// このファイルはプロフィールの管理と、案件・人材の適合度の採点のユースケースを実装します。
+package application
+
+import (
+	"business/internal/matching/domain"
+	r "business/internal/matching/infrastructure"
+	"business/tools/keyword"
+	"business/tools/location"
+	"business/tools/oswrapper"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/samber/lo"
+)
+
+// UseCase はエンジニアのプロフィールと案件のマッチングのユースケースの具象です
+type UseCase struct {
+	r  r.RepositoryInterface
+	os oswrapper.OsWapperInterface
+}
+
+// New はエンジニアのプロフィールと案件のマッチングのユースケースを作成します
+func New(r r.RepositoryInterface, os oswrapper.OsWapperInterface) *UseCase {
+	return &UseCase{
+		r:  r,
+		os: os,
+	}
+}
+
+// ListProfiles はプロフィールをID順に返します
+func (u *UseCase) ListProfiles() ([]domain.Profile, error) {
+	return u.r.ListProfiles()
+}
+
+// GetProfile はプロフィールを返します
+func (u *UseCase) GetProfile(id uint) (domain.Profile, error) {
+	return u.r.FindProfile(id)
+}
+
+// GetProfileByName は名前が一致するプロフィールを返します
+func (u *UseCase) GetProfileByName(name string) (domain.Profile, error) {
+	return u.r.FindProfileByName(name)
+}
+
+// CreateProfile はプロフィールを検証して保存します
+func (u *UseCase) CreateProfile(p domain.Profile) (domain.Profile, error) {
+	p, err := normalizeProfile(p)
+	if err != nil {
+		return domain.Profile{}, err
+	}
+	return u.r.CreateProfile(p)
+}
+
+// UpdateProfile はプロフィールを検証して更新します（スキルは置き換え）
+func (u *UseCase) UpdateProfile(p domain.Profile) (domain.Profile, error) {
+	p, err := normalizeProfile(p)
+	if err != nil {
+		return domain.Profile{}, err
+	}
+	return u.r.UpdateProfile(p)
+}
+
+// DeleteProfile はプロフィールを削除します
+func (u *UseCase) DeleteProfile(id uint) error {
+	return u.r.DeleteProfile(id)
+}
+
+// MatchProjects はプロフィールに対する案件の適合度を採点し、高い順に返します
+// 受信日が opts.Days 日以内のアーカイブしていない案件を対象にします。スキルは用語辞書のキーワードグループで照合するため、
+// 別名（"Golang" と "Go" など）も一致とみなします。
+func (u *UseCase) MatchProjects(profileID uint, opts domain.MatchOptions, now time.Time) (domain.MatchResult, error) {
+	opts, err := opts.Normalize()
+	if err != nil {
+		return domain.MatchResult{}, err
+	}
+	profile, err := u.r.FindProfile(profileID)
+	if err != nil {
+		return domain.MatchResult{}, err
+	}
+	projects, err := u.r.ListMatchTargets(now.AddDate(0, 0, -opts.Days), opts.IncludeClosed)
+	if err != nil {
+		return domain.MatchResult{}, err
+	}
+
+	n, err := u.resolveSkillGroups(projects, profile.Skills)
+	if err != nil {
+		return domain.MatchResult{}, err
+	}
+
+	items := []domain.Match{}
+	for _, p := range projects {
+		m := domain.Score(profile, p, n, now)
+		if m.Score < opts.MinScore {
+			continue
+		}
+		items = append(items, m)
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].Score != items[j].Score {
+			return items[i].Score > items[j].Score
+		}
+		return items[i].ReceivedDate.After(items[j].ReceivedDate)
+	})
+	if len(items) > opts.Limit {
+		items = items[:opts.Limit]
+	}
+
+	return domain.MatchResult{Profile: profile, Checked: len(projects), Items: items}, nil
+}
+
+// MatchCandidates は案件に対する人材メールの適合度を採点し、高い順に返します
+// 受信日が opts.Days 日以内（既定は domain.DefaultCandidateDays 日）の人材メールを対象にします。案件のスキルは用語辞書の
+// キーワードグループに解決し、人材メールに紐付けたキーワードグループと照合します。opts.IncludeClosed は使いません。
+func (u *UseCase) MatchCandidates(projectID uint, opts domain.MatchOptions, now time.Time) (domain.CandidateResult, error) {
+	if opts.Days == 0 {
+		opts.Days = domain.DefaultCandidateDays
+	}
+	opts, err := opts.Normalize()
+	if err != nil {
+		return domain.CandidateResult{}, err
+	}
+	project, err := u.r.FindMatchTarget(projectID)
+	if err != nil {
+		return domain.CandidateResult{}, err
+	}
+	candidates, err := u.r.ListCandidates(now.AddDate(0, 0, -opts.Days))
+	if err != nil {
+		return domain.CandidateResult{}, err
+	}
+
+	n, err := u.resolveSkillGroups([]domain.Project{project}, nil)
+	if err != nil {
+		return domain.CandidateResult{}, err
+	}
+
+	items := []domain.CandidateMatch{}
+	for _, c := range candidates {
+		m := domain.ScoreCandidate(project, c, n, now)
+		if m.Score < opts.MinScore {
+			continue
+		}
+		items = append(items, m)
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].Score != items[j].Score {
+			return items[i].Score > items[j].Score
+		}
+		return items[i].ReceivedDate.After(items[j].ReceivedDate)
+	})
+	if len(items) > opts.Limit {
+		items = items[:opts.Limit]
+	}
+
+	return domain.CandidateResult{
+		ProjectID:    project.ProjectID,
+		GmailID:      project.GmailID,
+		Subject:      project.Subject,
+		ProjectTitle: project.ProjectTitle,
+		Checked:      len(candidates),
+		Items:        items,
+	}, nil
+}
+
+// resolveSkillGroups は案件とプロフィールのスキルを用語辞書のキーワードグループに解決し、GroupID を設定します
+// 別名ルールの正規名でも解決するため、用語辞書に別名が登録されていないスキル（"JS" など）も正規名のグループに解決します。
+// 採点に使う別名ルールの Normalizer を返します。
+func (u *UseCase) resolveSkillGroups(projects []domain.Project, skills []domain.Skill) (*keyword.Normalizer, error) {
+	n, err := keyword.Load(u.os)
+	if err != nil {
+		return nil, fmt.Errorf("スキルの照合エラー: %w", err)
+	}
+
+	var names []string
+	for _, s := range skills {
+		names = append(names, s.Name, n.Normalize(s.Name))
+	}
+	for _, p := range projects {
+		for _, refs := range [][]domain.SkillRef{p.MustSkills, p.WantSkills, p.Languages, p.Frameworks} {
+			for _, ref := range refs {
+				names = append(names, ref.Name, n.Normalize(ref.Name))
+			}
+		}
+	}
+	groups, err := u.r.ResolveSkillGroups(lo.Uniq(names))
+	if err != nil {
+		return nil, fmt.Errorf("スキルの照合エラー: %w", err)
+	}
+	groupOf := func(name string) uint {
+		if id, ok := groups[keyword.Key(name)]; ok {
+			return id
+		}
+		return groups[keyword.Key(n.Normalize(name))]
+	}
+	for i := range skills {
+		skills[i].GroupID = groupOf(skills[i].Name)
+	}
+	for _, p := range projects {
+		for _, refs := range [][]domain.SkillRef{p.MustSkills, p.WantSkills, p.Languages, p.Frameworks} {
+			for i := range refs {
+				refs[i].GroupID = groupOf(refs[i].Name)
+			}
+		}
+	}
+	return n, nil
+}
+
+// normalizeProfile はプロフィールを検証し、希望する都道府県を正式名にします（"東京"、"都内" などの略称も可）
+func normalizeProfile(p domain.Profile) (domain.Profile, error) {
+	p, err := p.Validate()
+	if err != nil {
+		return p, err
+	}
+	prefectures := make([]string, 0, len(p.PreferredPrefectures))
+	for _, name := range p.PreferredPrefectures {
+		pref, ok := location.NormalizePrefecture(name)
+		if !ok {
+			return p, fmt.Errorf("%w: 都道府県 %q を解釈できません", domain.ErrInvalidProfile, name)
+		}
+		prefectures = append(prefectures, pref)
+	}
+	p.PreferredPrefectures = prefectures
+	return p, nil
+}
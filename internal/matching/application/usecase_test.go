@@ -0,0 +1,189 @@
+package application
+
+import (
+	"business/internal/matching/domain"
+	"business/tools/keyword"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockRepository はエンジニアのプロフィールと案件のマッチングのリポジトリのモックです
+type MockRepository struct {
+	mock.Mock
+}
+
+func (m *MockRepository) ListProfiles() ([]domain.Profile, error) {
+	args := m.Called()
+	return args.Get(0).([]domain.Profile), args.Error(1)
+}
+
+func (m *MockRepository) FindProfile(id uint) (domain.Profile, error) {
+	args := m.Called(id)
+	return args.Get(0).(domain.Profile), args.Error(1)
+}
+
+func (m *MockRepository) FindProfileByName(name string) (domain.Profile, error) {
+	args := m.Called(name)
+	return args.Get(0).(domain.Profile), args.Error(1)
+}
+
+func (m *MockRepository) CreateProfile(p domain.Profile) (domain.Profile, error) {
+	args := m.Called(p)
+	return args.Get(0).(domain.Profile), args.Error(1)
+}
+
+func (m *MockRepository) UpdateProfile(p domain.Profile) (domain.Profile, error) {
+	args := m.Called(p)
+	return args.Get(0).(domain.Profile), args.Error(1)
+}
+
+func (m *MockRepository) DeleteProfile(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockRepository) ResolveSkillGroups(names []string) (map[string]uint, error) {
+	args := m.Called(names)
+	return args.Get(0).(map[string]uint), args.Error(1)
+}
+
+func (m *MockRepository) ListMatchTargets(since time.Time, includeClosed bool) ([]domain.Project, error) {
+	args := m.Called(since, includeClosed)
+	return args.Get(0).([]domain.Project), args.Error(1)
+}
+
+func (m *MockRepository) FindMatchTarget(projectID uint) (domain.Project, error) {
+	args := m.Called(projectID)
+	return args.Get(0).(domain.Project), args.Error(1)
+}
+
+func (m *MockRepository) ListCandidates(since time.Time) ([]domain.Candidate, error) {
+	args := m.Called(since)
+	return args.Get(0).([]domain.Candidate), args.Error(1)
+}
+
+// モック: oswrapper
+type mockOsWrapper struct {
+	files map[string]string
+}
+
+func (m *mockOsWrapper) ReadFile(path string) (string, error) {
+	if text, ok := m.files[path]; ok {
+		return text, nil
+	}
+	return "", errors.New("file not found")
+}
+
+func (m *mockOsWrapper) GetEnv(key string) string {
+	return ""
+}
+
+// newOsWrapper は別名ルールファイルを既定の配置場所に置いた oswrapper を作成します
+func newOsWrapper(rules string) *mockOsWrapper {
+	return &mockOsWrapper{files: map[string]string{keyword.DefaultRulesPath: rules}}
+}
+
+func TestMatchProjects(t *testing.T) {
+	repo := new(MockRepository)
+	usecase := New(repo, newOsWrapper(""))
+
+	now := time.Date(2025, 7, 1, 9, 0, 0, 0, time.UTC)
+	price := func(v int) *int { return &v }
+	profile := domain.Profile{ID: 1, Name: "山田", Skills: []domain.Skill{{Name: "Go", Years: 5}}, DesiredPrice: price(700000), RemotePreference: domain.RemoteAny}
+	projects := []domain.Project{
+		{ProjectID: 10, GmailID: "gmail-php", ReceivedDate: now.Add(-time.Hour), MustSkills: []domain.SkillRef{{Name: "PHP"}}, MonthlyPriceTo: price(700000)},
+		{ProjectID: 11, GmailID: "gmail-golang", ReceivedDate: now.Add(-2 * time.Hour), MustSkills: []domain.SkillRef{{Name: "Golang"}}, MonthlyPriceTo: price(700000)},
+		{ProjectID: 12, GmailID: "gmail-go", ReceivedDate: now.Add(-time.Hour), MustSkills: []domain.SkillRef{{Name: "Go"}}, MonthlyPriceTo: price(700000)},
+	}
+	repo.On("FindProfile", uint(1)).Return(profile, nil)
+	repo.On("ListMatchTargets", now.AddDate(0, 0, -domain.DefaultMatchDays), false).Return(projects, nil)
+	// "Golang" は "Go" の別名として同じキーワードグループに解決される
+	repo.On("ResolveSkillGroups", []string{"Go", "PHP", "Golang"}).Return(map[string]uint{"go": 1, "golang": 1, "php": 2}, nil)
+
+	result, err := usecase.MatchProjects(1, domain.MatchOptions{MinScore: 50}, now)
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.Checked)
+	require.Len(t, result.Items, 2)
+	// 同点は受信日の新しい順
+	assert.Equal(t, "gmail-go", result.Items[0].GmailID)
+	assert.Equal(t, "gmail-golang", result.Items[1].GmailID)
+	assert.Equal(t, result.Items[0].Score, result.Items[1].Score)
+	assert.Equal(t, domain.WeightMust, result.Items[1].Components[0].Score)
+
+	_, err = usecase.MatchProjects(1, domain.MatchOptions{Limit: domain.MaxMatchLimit + 1}, now)
+	assert.ErrorIs(t, err, domain.ErrInvalidOptions)
+}
+
+func TestMatchProjects_AliasRules(t *testing.T) {
+	repo := new(MockRepository)
+	usecase := New(repo, newOsWrapper("JavaScript: JS, ECMAScript"))
+
+	now := time.Date(2025, 7, 1, 9, 0, 0, 0, time.UTC)
+	profile := domain.Profile{ID: 1, Name: "山田", Skills: []domain.Skill{{Name: "JS", Years: 3}}, RemotePreference: domain.RemoteAny}
+	projects := []domain.Project{
+		{ProjectID: 10, GmailID: "gmail-js", MustSkills: []domain.SkillRef{{Name: "ECMAScript"}}},
+	}
+	repo.On("FindProfile", uint(1)).Return(profile, nil)
+	repo.On("ListMatchTargets", now.AddDate(0, 0, -domain.DefaultMatchDays), false).Return(projects, nil)
+	// 用語辞書に "JS" の別名が無くても、別名ルールの正規名 "JavaScript" のグループに解決すること
+	repo.On("ResolveSkillGroups", []string{"JS", "JavaScript", "ECMAScript"}).Return(map[string]uint{"javascript": 5}, nil)
+
+	result, err := usecase.MatchProjects(1, domain.MatchOptions{}, now)
+	require.NoError(t, err)
+	require.Len(t, result.Items, 1)
+	assert.Equal(t, domain.WeightMust, result.Items[0].Components[0].Score)
+	assert.Empty(t, result.Items[0].Missing)
+}
+
+func TestMatchCandidates(t *testing.T) {
+	repo := new(MockRepository)
+	usecase := New(repo, newOsWrapper(""))
+
+	now := time.Date(2025, 7, 1, 9, 0, 0, 0, time.UTC)
+	price := func(v int) *int { return &v }
+	project := domain.Project{ProjectID: 10, GmailID: "gmail-project", MustSkills: []domain.SkillRef{{Name: "Golang"}}, MonthlyPriceTo: price(700000)}
+	candidates := []domain.Candidate{
+		{CandidateID: 1, GmailID: "gmail-php", ReceivedDate: now.Add(-time.Hour), Skills: []domain.Skill{{Name: "PHP", GroupID: 2}}, MonthlyPriceFrom: price(600000)},
+		{CandidateID: 2, GmailID: "gmail-go-old", ReceivedDate: now.Add(-2 * time.Hour), Skills: []domain.Skill{{Name: "Go", GroupID: 1}}, MonthlyPriceFrom: price(600000)},
+		{CandidateID: 3, GmailID: "gmail-go", ReceivedDate: now.Add(-time.Hour), Skills: []domain.Skill{{Name: "Go", GroupID: 1}}, MonthlyPriceFrom: price(600000)},
+	}
+	repo.On("FindMatchTarget", uint(10)).Return(project, nil)
+	repo.On("ListCandidates", now.AddDate(0, 0, -domain.DefaultCandidateDays)).Return(candidates, nil)
+	// 案件の "Golang" は人材のスキル "Go" と同じキーワードグループに解決される
+	repo.On("ResolveSkillGroups", []string{"Golang"}).Return(map[string]uint{"golang": 1}, nil)
+
+	result, err := usecase.MatchCandidates(10, domain.MatchOptions{MinScore: 50}, now)
+	require.NoError(t, err)
+	assert.Equal(t, "gmail-project", result.GmailID)
+	assert.Equal(t, 3, result.Checked)
+	require.Len(t, result.Items, 2)
+	// 同点は受信日の新しい順
+	assert.Equal(t, "gmail-go", result.Items[0].GmailID)
+	assert.Equal(t, "gmail-go-old", result.Items[1].GmailID)
+	assert.Equal(t, domain.WeightMust, result.Items[0].Components[0].Score)
+
+	repo.On("FindMatchTarget", uint(99)).Return(domain.Project{}, domain.ErrProjectNotFound)
+	_, err = usecase.MatchCandidates(99, domain.MatchOptions{}, now)
+	assert.ErrorIs(t, err, domain.ErrProjectNotFound)
+}
+
+func TestCreateProfile(t *testing.T) {
+	repo := new(MockRepository)
+	usecase := New(repo, newOsWrapper(""))
+
+	expected := domain.Profile{Name: "山田", RemotePreference: domain.RemoteAny, Skills: []domain.Skill{}, PreferredPrefectures: []string{"東京都", "神奈川県"}}
+	repo.On("CreateProfile", expected).Return(expected, nil)
+
+	// 都道府県の略称を正式名にして保存すること
+	_, err := usecase.CreateProfile(domain.Profile{Name: "山田", PreferredPrefectures: []string{"東京", "神奈川"}})
+	require.NoError(t, err)
+	repo.AssertExpectations(t)
+
+	_, err = usecase.CreateProfile(domain.Profile{Name: "山田", PreferredPrefectures: []string{"火星"}})
+	assert.ErrorIs(t, err, domain.ErrInvalidProfile)
+}
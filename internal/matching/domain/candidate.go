@@ -0,0 +1,132 @@
+package domain
+
+import (
+	"business/tools/keyword"
+	"errors"
+	"math"
+	"strings"
+	"time"
+)
+
+// DefaultCandidateDays は既定で対象にする人材メールの受信日の範囲（日数）です
+const DefaultCandidateDays = 30
+
+// ErrProjectNotFound は指定した案件が見つからない場合のエラーです
+var ErrProjectNotFound = errors.New("案件が見つかりません")
+
+// Candidate は案件に提案する候補にする人材メールです（email_candidates の1行）
+type Candidate struct {
+	CandidateID      uint
+	GmailID          string
+	Subject          string
+	SenderEmail      string
+	ReceivedDate     time.Time
+	CandidateName    string
+	Summary          string
+	Skills           []Skill    // キーワードグループに紐付けたスキル（経験年数は不明）
+	MonthlyPriceFrom *int       // 希望単価の下限
+	MonthlyPriceTo   *int       // 希望単価の上限
+	Availability     string     // 参画可能日の記載
+	AvailableFrom    *time.Time // 参画可能日を解釈した日付
+	AvailableFlag    string     // 参画可能日の種類（immediate / ongoing / undecided）
+	RemoteType       string     // リモートの希望の記載
+	Prefectures      []string   // 希望勤務地の都道府県
+}
+
+// CandidateMatch は案件に対する人材の適合度の採点結果です
+type CandidateMatch struct {
+	CandidateID      uint        `json:"candidate_id"`
+	GmailID          string      `json:"gmail_id"`
+	Subject          string      `json:"subject"`
+	CandidateName    string      `json:"candidate_name"`
+	Summary          string      `json:"summary"`
+	SenderEmail      string      `json:"sender_email"`
+	ReceivedDate     time.Time   `json:"received_date"`
+	Skills           []string    `json:"skills"`
+	MonthlyPriceFrom *int        `json:"monthly_price_from"`
+	MonthlyPriceTo   *int        `json:"monthly_price_to"`
+	Availability     string      `json:"availability"`
+	Score            float64     `json:"score"`      // 適合度（0〜100）
+	Components       []Component `json:"components"` // 採点項目ごとの内訳
+	Missing          []string    `json:"missing"`    // 満たしていない案件のMUSTスキル
+}
+
+// CandidateResult は案件に対する人材のマッチング結果です
+type CandidateResult struct {
+	ProjectID    uint             `json:"project_id"`
+	GmailID      string           `json:"gmail_id"`
+	Subject      string           `json:"subject"`
+	ProjectTitle string           `json:"project_title"`
+	Checked      int              `json:"checked"` // 採点した人材数
+	Items        []CandidateMatch `json:"items"`   // 適合度の高い順（同点は受信日の新しい順）
+}
+
+// ScoreCandidate は案件に対する人材の適合度を採点します
+// 人材を希望単価の下限・参画可能日・リモートの希望・希望勤務地を持つプロフィールとみなし、Score と同じ配点で採点します。
+// スキルの経験年数は人材メールから取り出さないため、案件の必要年数は満たすものとして扱います。
+// 参画可能日の記載が無い・未定の場合、入場時期は半分の点数とします。
+func ScoreCandidate(project Project, c Candidate, n *keyword.Normalizer, now time.Time) CandidateMatch {
+	skills := make([]Skill, 0, len(c.Skills))
+	names := make([]string, 0, len(c.Skills))
+	for _, s := range c.Skills {
+		skills = append(skills, Skill{Name: s.Name, Years: math.MaxFloat64, GroupID: s.GroupID})
+		names = append(names, s.Name)
+	}
+	desired := c.MonthlyPriceFrom
+	if desired == nil {
+		desired = c.MonthlyPriceTo
+	}
+	profile := Profile{
+		Name:                 c.CandidateName,
+		Skills:               skills,
+		DesiredPrice:         desired,
+		RemotePreference:     remotePreferenceOf(c.RemoteType),
+		AvailableFrom:        c.AvailableFrom,
+		PreferredPrefectures: c.Prefectures,
+	}
+
+	m := Score(profile, project, n, now)
+	if c.AvailableFrom == nil && c.AvailableFlag != StartFlagImmediate && c.AvailableFlag != StartFlagOngoing {
+		for i, comp := range m.Components {
+			if comp.Name != ComponentStart {
+				continue
+			}
+			m.Score = round1(m.Score - comp.Score + WeightStart/2)
+			m.Components[i].Score = round1(WeightStart / 2)
+			m.Components[i].Reason = "参画可能日の記載がありません"
+			if c.Availability != "" {
+				m.Components[i].Reason = "参画可能日（" + c.Availability + "）を日付に解釈できません"
+			}
+		}
+	}
+
+	return CandidateMatch{
+		CandidateID:      c.CandidateID,
+		GmailID:          c.GmailID,
+		Subject:          c.Subject,
+		CandidateName:    c.CandidateName,
+		Summary:          c.Summary,
+		SenderEmail:      c.SenderEmail,
+		ReceivedDate:     c.ReceivedDate,
+		Skills:           names,
+		MonthlyPriceFrom: c.MonthlyPriceFrom,
+		MonthlyPriceTo:   c.MonthlyPriceTo,
+		Availability:     c.Availability,
+		Score:            m.Score,
+		Components:       m.Components,
+		Missing:          m.Missing,
+	}
+}
+
+// remotePreferenceOf は人材メールのリモートの希望の記載をリモートの希望に分けます
+func remotePreferenceOf(remoteType string) RemotePreference {
+	switch {
+	case strings.Contains(remoteType, "フルリモート"):
+		return RemoteFull
+	case strings.Contains(remoteType, "不可"), strings.Contains(remoteType, "常駐"):
+		return RemoteOnsite
+	case strings.Contains(remoteType, "可"), strings.Contains(remoteType, "併用"), strings.Contains(remoteType, "一部"):
+		return RemotePartial
+	}
+	return RemoteAny
+}
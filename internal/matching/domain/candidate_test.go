@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"business/tools/keyword"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScoreCandidate(t *testing.T) {
+	now := time.Date(2025, 7, 1, 9, 0, 0, 0, time.UTC)
+	day := func(m time.Month, d int) *time.Time {
+		v := time.Date(2025, m, d, 0, 0, 0, 0, time.UTC)
+		return &v
+	}
+	price := func(v int) *int { return &v }
+
+	project := Project{
+		ProjectID:      1,
+		MustSkills:     []SkillRef{{Name: "Golang", GroupID: 1}, {Name: "AWSでの構築経験3年以上", GroupID: 3}},
+		MonthlyPriceTo: price(700000),
+		RemoteType:     "リモート不可",
+		Prefectures:    []string{"東京都"},
+		Starts:         []StartRange{{From: day(7, 1), To: day(7, 31)}},
+	}
+
+	// スキルはキーワードグループで照合し、経験年数は満たすものとして扱うこと
+	got := ScoreCandidate(project, Candidate{
+		CandidateID:      10,
+		CandidateName:    "T.Y",
+		Skills:           []Skill{{Name: "Go", GroupID: 1}, {Name: "AWS", GroupID: 3}},
+		MonthlyPriceFrom: price(650000),
+		MonthlyPriceTo:   price(750000),
+		AvailableFrom:    day(7, 15),
+		RemoteType:       "フルリモート希望",
+	}, keyword.New(keyword.Rules{}), now)
+	require.Len(t, got.Components, 5)
+	assert.Equal(t, WeightMust, got.Components[0].Score)
+	assert.Empty(t, got.Missing)
+	// 希望単価は下限で比べること
+	assert.Equal(t, WeightPrice, got.Components[2].Score)
+	// フルリモートの希望に対して出社の案件は0点
+	assert.Equal(t, 0.0, got.Components[3].Score)
+	assert.Equal(t, WeightStart, got.Components[4].Score)
+	assert.Equal(t, 75.0, got.Score)
+	assert.Equal(t, []string{"Go", "AWS"}, got.Skills)
+
+	// 参画可能日を解釈できない場合は入場時期を半分にすること
+	got = ScoreCandidate(project, Candidate{
+		Skills:        []Skill{{Name: "Go", GroupID: 1}},
+		Availability:  "要相談",
+		AvailableFlag: StartFlagUndecided,
+	}, keyword.New(keyword.Rules{}), now)
+	assert.Equal(t, 22.5, got.Components[0].Score)
+	assert.Equal(t, []string{"AWSでの構築経験3年以上"}, got.Missing)
+	assert.Equal(t, WeightStart/2, got.Components[4].Score)
+	assert.Contains(t, got.Components[4].Reason, "要相談")
+	assert.Equal(t, 47.5, got.Score)
+}
+
+func TestRemotePreferenceOf(t *testing.T) {
+	tests := []struct {
+		remoteType string
+		expected   RemotePreference
+	}{
+		{"フルリモート希望", RemoteFull},
+		{"リモート併用希望", RemotePartial},
+		{"常駐可", RemoteOnsite},
+		{"", RemoteAny},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, remotePreferenceOf(tt.remoteType), tt.remoteType)
+	}
+}
@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	DefaultMatchDays  = 14  // 既定で対象にする案件の受信日の範囲（日数）
+	DefaultMatchLimit = 20  // 既定で返す案件数
+	MaxMatchDays      = 180 // 対象にする案件の受信日の範囲の上限（日数）
+	MaxMatchLimit     = 200 // 返す案件数の上限
+)
+
+// ErrInvalidOptions はマッチングの条件が不正な場合のエラーです
+var ErrInvalidOptions = errors.New("マッチングの条件が不正です")
+
+// MatchOptions はマッチングの条件です
+type MatchOptions struct {
+	Days          int     // 受信日がこの日数以内の案件を対象にする
+	Limit         int     // 適合度の高い順に返す案件数
+	MinScore      float64 // 適合度がこの値未満の案件は返さない
+	IncludeClosed bool    // 募集終了・期限切れの案件も対象にする（アーカイブした案件は常に除外）
+}
+
+// Normalize は未指定の項目に既定値を設定し、条件を検証します
+func (o MatchOptions) Normalize() (MatchOptions, error) {
+	if o.Days == 0 {
+		o.Days = DefaultMatchDays
+	}
+	if o.Limit == 0 {
+		o.Limit = DefaultMatchLimit
+	}
+	if o.Days < 0 || o.Days > MaxMatchDays {
+		return o, fmt.Errorf("%w: days は1〜%dで指定してください", ErrInvalidOptions, MaxMatchDays)
+	}
+	if o.Limit < 0 || o.Limit > MaxMatchLimit {
+		return o, fmt.Errorf("%w: limit は1〜%dで指定してください", ErrInvalidOptions, MaxMatchLimit)
+	}
+	if o.MinScore < 0 || o.MinScore > 100 {
+		return o, fmt.Errorf("%w: min_score は0〜100で指定してください", ErrInvalidOptions)
+	}
+	return o, nil
+}
+
+// MatchResult はプロフィールに対する案件のマッチング結果です
+type MatchResult struct {
+	Profile Profile `json:"profile"`
+	Checked int     `json:"checked"` // 採点した案件数
+	Items   []Match `json:"items"`   // 適合度の高い順（同点は受信日の新しい順）
+}
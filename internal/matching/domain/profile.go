@@ -0,0 +1,118 @@
+// Package domain はエンジニアのスキルプロフィールと案件のマッチング（適合度の採点）機能のドメイン層を提供します。
+// このファイルはエンジニアのプロフィールとその検証を定義します。
+package domain
+
+import (
+	"business/tools/keyword"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// RemotePreference はエンジニアのリモートの希望です
+type RemotePreference string
+
+const (
+	RemoteFull    RemotePreference = "full"    // フルリモートのみ
+	RemotePartial RemotePreference = "partial" // 一部リモート（出社との併用）を希望
+	RemoteOnsite  RemotePreference = "onsite"  // 出社でもよい
+	RemoteAny     RemotePreference = "any"     // こだわらない（既定）
+)
+
+// RemotePreferences はリモートの希望の一覧です（表示・検証の順）
+var RemotePreferences = []RemotePreference{RemoteFull, RemotePartial, RemoteOnsite, RemoteAny}
+
+// Label はリモートの希望の表示名を返します
+func (r RemotePreference) Label() string {
+	switch r {
+	case RemoteFull:
+		return "フルリモートのみ"
+	case RemotePartial:
+		return "一部リモート"
+	case RemoteOnsite:
+		return "出社可"
+	default:
+		return "こだわらない"
+	}
+}
+
+// IsValid はリモートの希望が定義済みの値かどうかを返します
+func (r RemotePreference) IsValid() bool {
+	for _, v := range RemotePreferences {
+		if r == v {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	// ErrInvalidProfile はプロフィールの内容が不正な場合のエラーです
+	ErrInvalidProfile = errors.New("プロフィールの内容が不正です")
+
+	// ErrProfileNotFound はプロフィールが存在しない場合のエラーです
+	ErrProfileNotFound = errors.New("プロフィールが見つかりません")
+
+	// ErrConflict は同じ名前のプロフィールが既に登録されている場合のエラーです
+	ErrConflict = errors.New("同じ名前のプロフィールが既に登録されています")
+)
+
+// Skill はエンジニアのスキルと経験年数です
+type Skill struct {
+	Name  string  `json:"name"`  // スキル名（キーワードグループの正規名・別名で照合）
+	Years float64 `json:"years"` // 経験年数（0は不明）
+
+	GroupID uint `json:"-"` // 照合用のキーワードグループID（解決できない場合は0）
+}
+
+// Profile はエンジニアのスキルプロフィールです
+type Profile struct {
+	ID                   uint             `json:"id"`
+	Name                 string           `json:"name"`                  // 氏名・イニシャルなど（一意）
+	Skills               []Skill          `json:"skills"`                // スキルと経験年数
+	DesiredPrice         *int             `json:"desired_price"`         // 希望単価（税別の月額、円）
+	RemotePreference     RemotePreference `json:"remote_preference"`     // リモートの希望
+	AvailableFrom        *time.Time       `json:"available_from"`        // 参画可能日（未設定は即日）
+	PreferredPrefectures []string         `json:"preferred_prefectures"` // 希望する勤務地の都道府県（空はこだわらない）
+	Note                 string           `json:"note"`                  // メモ
+	CreatedAt            time.Time        `json:"created_at,omitempty"`  // 作成日時
+	UpdatedAt            time.Time        `json:"updated_at,omitempty"`  // 更新日時
+}
+
+// Validate はプロフィールの必須項目と値の範囲を検証します
+// リモートの希望が未指定の場合は any にします。
+func (p Profile) Validate() (Profile, error) {
+	p.Name = strings.TrimSpace(p.Name)
+	if p.Name == "" {
+		return p, fmt.Errorf("%w: name は必須です", ErrInvalidProfile)
+	}
+	if p.RemotePreference == "" {
+		p.RemotePreference = RemoteAny
+	}
+	if !p.RemotePreference.IsValid() {
+		return p, fmt.Errorf("%w: remote_preference は full / partial / onsite / any のいずれかで指定してください", ErrInvalidProfile)
+	}
+	if p.DesiredPrice != nil && *p.DesiredPrice <= 0 {
+		return p, fmt.Errorf("%w: desired_price は1以上の整数（円）で指定してください", ErrInvalidProfile)
+	}
+
+	seen := make(map[string]struct{}, len(p.Skills))
+	skills := make([]Skill, 0, len(p.Skills))
+	for _, s := range p.Skills {
+		s.Name = strings.TrimSpace(s.Name)
+		if s.Name == "" {
+			return p, fmt.Errorf("%w: スキル名は必須です", ErrInvalidProfile)
+		}
+		if s.Years < 0 {
+			return p, fmt.Errorf("%w: %s の経験年数は0以上で指定してください", ErrInvalidProfile, s.Name)
+		}
+		if _, ok := seen[keyword.Key(s.Name)]; ok {
+			return p, fmt.Errorf("%w: スキル %s が重複しています", ErrInvalidProfile, s.Name)
+		}
+		seen[keyword.Key(s.Name)] = struct{}{}
+		skills = append(skills, s)
+	}
+	p.Skills = skills
+	return p, nil
+}
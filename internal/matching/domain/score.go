@@ -0,0 +1,477 @@
+package domain
+
+import (
+	"business/tools/keyword"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/text/width"
+)
+
+// 採点項目ごとの配点（合計100点）
+const (
+	WeightMust   = 45.0 // MUSTスキルの充足率
+	WeightWant   = 15.0 // WANTスキルの加点
+	WeightPrice  = 20.0 // 希望単価との適合
+	WeightRemote = 10.0 // リモートの希望・勤務地との適合
+	WeightStart  = 10.0 // 参画可能日と入場時期の適合
+)
+
+// 採点項目の名前
+const (
+	ComponentMust   = "must"
+	ComponentWant   = "want"
+	ComponentPrice  = "price"
+	ComponentRemote = "remote"
+	ComponentStart  = "start"
+)
+
+const (
+	// priceToleranceRate は単価の上限が希望単価をこの割合下回ると単価の点数を0にする割合です
+	priceToleranceRate = 0.2
+	// startLateDays は入場時期の終了からこの日数遅れると入場時期の点数を0にする日数です
+	startLateDays = 30
+	// startWaitDays は入場時期の開始までこの日数以内の待機なら満点とする日数です
+	startWaitDays = 30
+	// startMaxWaitDays は入場時期の開始まで startWaitDays を超えてこの日数待機すると入場時期の点数を0にする日数です
+	startMaxWaitDays = 60
+	// minPartialSkillKeyLength はスキル名を部分一致で照合する最小の文字数です（"C" などの誤一致を防ぐ）
+	minPartialSkillKeyLength = 2
+)
+
+// 入場時期の種類（entry_timings.start_flag）
+const (
+	StartFlagImmediate = "immediate" // 即日
+	StartFlagOngoing   = "ongoing"   // 随時
+	StartFlagUndecided = "undecided" // 未定
+)
+
+// reYears はスキルの記載から必要な経験年数（"3年以上" など）を取り出します
+var reYears = regexp.MustCompile(`(\d+(?:\.\d+)?)\s*年`)
+
+// SkillRef は案件に記載されたスキルです
+type SkillRef struct {
+	Name    string
+	GroupID uint // 照合用のキーワードグループID（解決できない場合は0）
+}
+
+// StartRange は入場時期を日付の範囲に解釈した値です（entry_timings の1行）
+type StartRange struct {
+	From *time.Time
+	To   *time.Time
+	Flag string
+}
+
+// Project は採点の対象にする案件です
+type Project struct {
+	ProjectID        uint
+	GmailID          string
+	Subject          string
+	ProjectTitle     string
+	SenderEmail      string
+	ReceivedDate     time.Time
+	MustSkills       []SkillRef
+	WantSkills       []SkillRef
+	Languages        []SkillRef
+	Frameworks       []SkillRef
+	MonthlyPriceFrom *int
+	MonthlyPriceTo   *int
+	PriceNegotiable  bool
+	RemoteType       string   // リモート区分（フルリモート / リモート可 / 不可）
+	Prefectures      []string // 勤務地の都道府県
+	FullRemote       bool     // 勤務地がフルリモート
+	Starts           []StartRange
+	LifecycleStatus  string
+}
+
+// Component は採点項目ごとの点数と説明です
+type Component struct {
+	Name   string  `json:"name"`   // 採点項目（must / want / price / remote / start）
+	Score  float64 `json:"score"`  // 点数
+	Max    float64 `json:"max"`    // 配点
+	Reason string  `json:"reason"` // 点数の説明
+}
+
+// Match は案件の適合度の採点結果です
+type Match struct {
+	ProjectID        uint        `json:"project_id"`
+	GmailID          string      `json:"gmail_id"`
+	Subject          string      `json:"subject"`
+	ProjectTitle     string      `json:"project_title"`
+	SenderEmail      string      `json:"sender_email"`
+	ReceivedDate     time.Time   `json:"received_date"`
+	MonthlyPriceFrom *int        `json:"monthly_price_from"`
+	MonthlyPriceTo   *int        `json:"monthly_price_to"`
+	RemoteType       string      `json:"remote_type"`
+	LifecycleStatus  string      `json:"lifecycle_status"`
+	Score            float64     `json:"score"`      // 適合度（0〜100）
+	Components       []Component `json:"components"` // 採点項目ごとの内訳
+	Missing          []string    `json:"missing"`    // 満たしていないMUSTスキル
+}
+
+// Score はプロフィールに対する案件の適合度を採点します
+// n はスキル名の別名（"JS" と "JavaScript" など）を同じスキルとみなす正規化、now は参画可能日が未設定・過去の場合の基準日です。
+func Score(p Profile, project Project, n *keyword.Normalizer, now time.Time) Match {
+	must, missing := scoreMust(p.Skills, project, n)
+	components := []Component{
+		must,
+		scoreWant(p.Skills, project.WantSkills, n),
+		scorePrice(p.DesiredPrice, project),
+		scoreRemote(p, project),
+		scoreStart(p.AvailableFrom, project.Starts, now),
+	}
+	total := 0.0
+	for _, c := range components {
+		total += c.Score
+	}
+
+	return Match{
+		ProjectID:        project.ProjectID,
+		GmailID:          project.GmailID,
+		Subject:          project.Subject,
+		ProjectTitle:     project.ProjectTitle,
+		SenderEmail:      project.SenderEmail,
+		ReceivedDate:     project.ReceivedDate,
+		MonthlyPriceFrom: project.MonthlyPriceFrom,
+		MonthlyPriceTo:   project.MonthlyPriceTo,
+		RemoteType:       project.RemoteType,
+		LifecycleStatus:  project.LifecycleStatus,
+		Score:            round1(total),
+		Components:       components,
+		Missing:          missing,
+	}
+}
+
+// scoreMust はMUSTスキルの充足率を採点し、満たしていないスキルを返します
+// MUSTスキルの記載が無い場合は言語・フレームワークを必須とみなします。経験年数の記載がある場合に年数が足りないスキルは半分とします。
+func scoreMust(skills []Skill, project Project, n *keyword.Normalizer) (Component, []string) {
+	c := Component{Name: ComponentMust, Max: WeightMust}
+	required := project.MustSkills
+	basis := "MUSTスキル"
+	if len(required) == 0 {
+		required = append(append([]SkillRef{}, project.Languages...), project.Frameworks...)
+		basis = "言語・フレームワーク（MUSTスキルの記載なし）"
+	}
+	if len(required) == 0 {
+		c.Score = round1(WeightMust / 2)
+		c.Reason = "必須スキルの記載がありません"
+		return c, []string{}
+	}
+
+	credit := 0.0
+	var matched, short []string
+	missing := []string{}
+	for _, item := range required {
+		s, ok := findSkill(skills, item, n)
+		if !ok {
+			missing = append(missing, item.Name)
+			continue
+		}
+		if years := requiredYears(item.Name); years > 0 && s.Years < years {
+			credit += 0.5
+			short = append(short, fmt.Sprintf("%s（経験%s年／必要%s年）", s.Name, formatYears(s.Years), formatYears(years)))
+			continue
+		}
+		credit++
+		matched = append(matched, s.Name)
+	}
+
+	c.Score = round1(WeightMust * credit / float64(len(required)))
+	reason := fmt.Sprintf("%s %d件中%d件を満たしています", basis, len(required), len(matched))
+	if len(matched) > 0 {
+		reason += "（一致: " + strings.Join(matched, ", ") + "）"
+	}
+	if len(short) > 0 {
+		reason += "。経験年数が不足: " + strings.Join(short, ", ")
+	}
+	if len(missing) > 0 {
+		reason += "。不足: " + strings.Join(missing, ", ")
+	}
+	c.Reason = reason
+	return c, missing
+}
+
+// scoreWant はWANTスキルのうち持っているスキルの割合で加点します
+func scoreWant(skills []Skill, want []SkillRef, n *keyword.Normalizer) Component {
+	c := Component{Name: ComponentWant, Max: WeightWant}
+	if len(want) == 0 {
+		c.Reason = "WANTスキルの記載がありません"
+		return c
+	}
+	var matched []string
+	for _, item := range want {
+		if s, ok := findSkill(skills, item, n); ok {
+			matched = append(matched, s.Name)
+		}
+	}
+	c.Score = round1(WeightWant * float64(len(matched)) / float64(len(want)))
+	c.Reason = fmt.Sprintf("WANTスキル %d件中%d件に該当します", len(want), len(matched))
+	if len(matched) > 0 {
+		c.Reason += "（" + strings.Join(matched, ", ") + "）"
+	}
+	return c
+}
+
+// scorePrice は案件の単価の上限（無ければ下限）と希望単価を比べて採点します
+// 上限が希望以上なら満点、希望を priceToleranceRate 下回るまで線形に減点します。
+func scorePrice(desired *int, project Project) Component {
+	c := Component{Name: ComponentPrice, Max: WeightPrice}
+	if desired == nil {
+		c.Score = round1(WeightPrice / 2)
+		c.Reason = "希望単価が未設定です"
+		return c
+	}
+	upper := project.MonthlyPriceTo
+	if upper == nil {
+		upper = project.MonthlyPriceFrom
+	}
+	if upper == nil {
+		c.Score = round1(WeightPrice / 2)
+		c.Reason = "単価の記載がありません"
+		if project.PriceNegotiable {
+			c.Reason = "単価はスキル見合いです"
+		}
+		return c
+	}
+
+	if *upper >= *desired {
+		c.Score = WeightPrice
+		c.Reason = fmt.Sprintf("単価の上限 %s が希望 %s 以上です", formatMan(*upper), formatMan(*desired))
+		return c
+	}
+	shortfall := float64(*desired-*upper) / float64(*desired)
+	c.Score = round1(WeightPrice * math.Max(0, 1-shortfall/priceToleranceRate))
+	c.Reason = fmt.Sprintf("単価の上限 %s が希望 %s を %s 下回ります", formatMan(*upper), formatMan(*desired), formatMan(*desired-*upper))
+	if project.PriceNegotiable {
+		c.Reason += "（スキル見合い）"
+	}
+	return c
+}
+
+// remoteKind は案件のリモート区分の種類です
+type remoteKind int
+
+const (
+	remoteUnknown remoteKind = iota
+	remoteFull
+	remotePartial
+	remoteNone
+)
+
+// classifyRemote は案件のリモート区分を種類に分けます
+func classifyRemote(project Project) remoteKind {
+	t := project.RemoteType
+	switch {
+	case strings.Contains(t, "フルリモート"), project.FullRemote:
+		return remoteFull
+	case strings.Contains(t, "不可"):
+		return remoteNone
+	case strings.Contains(t, "可"), strings.Contains(t, "併用"), strings.Contains(t, "一部"):
+		return remotePartial
+	}
+	return remoteUnknown
+}
+
+// scoreRemote はリモートの希望と案件のリモート区分、希望する都道府県と勤務地を比べて採点します
+// フルリモートでない案件の勤務地が希望する都道府県に無い場合は0点とします（勤務地が不明な場合は減点しません）。
+func scoreRemote(p Profile, project Project) Component {
+	c := Component{Name: ComponentRemote, Max: WeightRemote}
+	kind := classifyRemote(project)
+	label := project.RemoteType
+	if label == "" {
+		label = "記載なし"
+	}
+
+	rate := 1.0
+	switch p.RemotePreference {
+	case RemoteFull:
+		switch kind {
+		case remotePartial, remoteUnknown:
+			rate = 0.5
+		case remoteNone:
+			rate = 0
+		}
+	case RemotePartial:
+		switch kind {
+		case remoteUnknown:
+			rate = 0.5
+		case remoteNone:
+			rate = 0.3
+		}
+	}
+	reason := fmt.Sprintf("リモートの希望（%s）に対して案件は%s", p.RemotePreference.Label(), label)
+
+	if kind != remoteFull && len(p.PreferredPrefectures) > 0 && len(project.Prefectures) > 0 {
+		if !overlaps(p.PreferredPrefectures, project.Prefectures) {
+			rate = 0
+			reason += "。勤務地（" + strings.Join(project.Prefectures, ", ") + "）が希望の都道府県外です"
+		} else {
+			reason += "。勤務地が希望の都道府県内です"
+		}
+	}
+	c.Score = round1(WeightRemote * rate)
+	c.Reason = reason
+	return c
+}
+
+// scoreStart は参画可能日と案件の入場時期を比べて採点します
+// 参画可能日がいずれかの入場時期の範囲内なら満点、範囲の終了より遅れる場合と開始まで待機が長い場合は日数に応じて減点します。
+func scoreStart(availableFrom *time.Time, starts []StartRange, now time.Time) Component {
+	c := Component{Name: ComponentStart, Max: WeightStart}
+	available := truncateDay(now)
+	if availableFrom != nil && availableFrom.After(available) {
+		available = truncateDay(*availableFrom)
+	}
+	day := available.Format("2006-01-02")
+
+	best := -1.0
+	reason := ""
+	for _, s := range starts {
+		if s.From == nil && s.To == nil {
+			if s.Flag == StartFlagImmediate || s.Flag == StartFlagOngoing {
+				if best < 1 {
+					best, reason = 1, fmt.Sprintf("%s から参画でき、入場時期は即日・随時です", day)
+				}
+			}
+			continue
+		}
+		rate, r := startRate(available, s)
+		if rate > best {
+			best, reason = rate, r
+		}
+	}
+	if best < 0 {
+		c.Score = round1(WeightStart / 2)
+		c.Reason = "入場時期が不明です"
+		return c
+	}
+	c.Score = round1(WeightStart * best)
+	c.Reason = reason
+	return c
+}
+
+// startRate は参画可能日と入場時期の範囲1つを比べた割合と説明を返します
+func startRate(available time.Time, s StartRange) (float64, string) {
+	day := available.Format("2006-01-02")
+	if s.To != nil && available.After(truncateDay(*s.To)) {
+		late := daysBetween(*s.To, available)
+		return math.Max(0, 1-float64(late)/startLateDays), fmt.Sprintf("参画可能日 %s が入場時期（〜%s）より%d日遅れます", day, s.To.Format("2006-01-02"), late)
+	}
+	if s.From != nil && truncateDay(*s.From).After(available) {
+		wait := daysBetween(available, *s.From)
+		rate := 1.0
+		if wait > startWaitDays {
+			rate = math.Max(0, 1-float64(wait-startWaitDays)/startMaxWaitDays)
+		}
+		return rate, fmt.Sprintf("入場時期（%s〜）まで参画可能日 %s から%d日待機します", s.From.Format("2006-01-02"), day, wait)
+	}
+	return 1, fmt.Sprintf("参画可能日 %s が入場時期の範囲内です", day)
+}
+
+// findSkill は案件のスキルに一致するプロフィールのスキルを返します
+// キーワードグループが同じ場合、別名ルールで同じ正規名になる場合、またはスキル名（正規名も含む）が記載に含まれる場合に
+// 一致とします（"Go" は "Goでの開発経験3年以上" に、"JS" は "JavaScriptの実務経験" に一致）。
+func findSkill(skills []Skill, item SkillRef, n *keyword.Normalizer) (Skill, bool) {
+	itemKey := n.Key(item.Name)
+	text := foldText(item.Name)
+	for _, s := range skills {
+		if s.GroupID != 0 && s.GroupID == item.GroupID {
+			return s, true
+		}
+		key := n.Key(s.Name)
+		if key == "" {
+			continue
+		}
+		if key == itemKey {
+			return s, true
+		}
+		for _, k := range []string{keyword.Key(s.Name), key} {
+			if len([]rune(k)) >= minPartialSkillKeyLength && containsWord(text, k) {
+				return s, true
+			}
+		}
+	}
+	return Skill{}, false
+}
+
+// foldText はスキルの記載を部分一致で照合できるよう、全角英数を半角に揃えて小文字にし、空白を1つにまとめます
+func foldText(text string) string {
+	return strings.Join(strings.Fields(strings.ToLower(width.Fold.String(text))), " ")
+}
+
+// containsWord は text が key を含み、その前後が英数字に続いていないかどうかを返します（"java" は "javascript" に含まれない）
+func containsWord(text, key string) bool {
+	for offset := 0; ; {
+		i := strings.Index(text[offset:], key)
+		if i < 0 {
+			return false
+		}
+		start := offset + i
+		end := start + len(key)
+		if !isASCIIWordBoundary(text, start, end) {
+			offset = start + 1
+			continue
+		}
+		return true
+	}
+}
+
+func isASCIIWordBoundary(text string, start, end int) bool {
+	isWord := func(b byte) bool {
+		return b == '_' || b >= '0' && b <= '9' || b >= 'a' && b <= 'z'
+	}
+	if start > 0 && isWord(text[start-1]) && isWord(text[start]) {
+		return false
+	}
+	if end < len(text) && isWord(text[end]) && isWord(text[end-1]) {
+		return false
+	}
+	return true
+}
+
+// requiredYears はスキルの記載に含まれる必要な経験年数を返します（無ければ0）
+func requiredYears(name string) float64 {
+	m := reYears.FindStringSubmatch(width.Fold.String(name))
+	if m == nil {
+		return 0
+	}
+	years, _ := strconv.ParseFloat(m[1], 64)
+	return years
+}
+
+func overlaps(a, b []string) bool {
+	for _, x := range a {
+		for _, y := range b {
+			if x == y {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// truncateDay は日時を同じ日付の UTC 0時にします（DBの日付とタイムゾーンを揃えて比べるため）
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func daysBetween(from, to time.Time) int {
+	return int(truncateDay(to).Sub(truncateDay(from)).Hours() / 24)
+}
+
+func formatYears(years float64) string {
+	return strconv.FormatFloat(years, 'f', -1, 64)
+}
+
+// formatMan は円を万円の表記にします
+func formatMan(yen int) string {
+	return strconv.FormatFloat(float64(yen)/10000, 'f', -1, 64) + "万円"
+}
+
+func round1(v float64) float64 {
+	return math.Round(v*10) / 10
+}
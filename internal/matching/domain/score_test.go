@@ -0,0 +1,199 @@
+package domain
+
+import (
+	"business/tools/keyword"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScore(t *testing.T) {
+	now := time.Date(2025, 7, 1, 9, 0, 0, 0, time.UTC)
+	day := func(m time.Month, d int) *time.Time {
+		v := time.Date(2025, m, d, 0, 0, 0, 0, time.UTC)
+		return &v
+	}
+	price := func(v int) *int { return &v }
+
+	profile := Profile{
+		Name:                 "山田",
+		Skills:               []Skill{{Name: "Go", Years: 5, GroupID: 1}, {Name: "AWS", Years: 2}, {Name: "Docker", Years: 3}},
+		DesiredPrice:         price(800000),
+		RemotePreference:     RemotePartial,
+		AvailableFrom:        day(7, 1),
+		PreferredPrefectures: []string{"東京都"},
+	}
+	project := Project{
+		ProjectID:      1,
+		MustSkills:     []SkillRef{{Name: "Golang", GroupID: 1}, {Name: "AWSでの構築経験3年以上"}},
+		WantSkills:     []SkillRef{{Name: "Docker"}, {Name: "Kubernetes"}},
+		MonthlyPriceTo: price(800000),
+		RemoteType:     "リモート可",
+		Prefectures:    []string{"東京都"},
+		Starts:         []StartRange{{From: day(7, 1), To: day(7, 31)}},
+	}
+
+	got := Score(profile, project, keyword.New(keyword.Rules{}), now)
+	require.Len(t, got.Components, 5)
+	// MUST: Go は同じキーワードグループ、AWS は経験年数不足で半分
+	assert.Equal(t, 33.8, got.Components[0].Score)
+	assert.Contains(t, got.Components[0].Reason, "AWS（経験2年／必要3年）")
+	assert.Empty(t, got.Missing)
+	assert.Equal(t, 7.5, got.Components[1].Score)
+	assert.Equal(t, WeightPrice, got.Components[2].Score)
+	assert.Equal(t, WeightRemote, got.Components[3].Score)
+	assert.Equal(t, WeightStart, got.Components[4].Score)
+	assert.Equal(t, 81.3, got.Score)
+}
+
+func TestScore_Components(t *testing.T) {
+	now := time.Date(2025, 7, 1, 9, 0, 0, 0, time.UTC)
+	day := func(m time.Month, d int) *time.Time {
+		v := time.Date(2025, m, d, 0, 0, 0, 0, time.UTC)
+		return &v
+	}
+	price := func(v int) *int { return &v }
+	component := func(m Match, name string) Component {
+		for _, c := range m.Components {
+			if c.Name == name {
+				return c
+			}
+		}
+		t.Fatalf("%s がありません", name)
+		return Component{}
+	}
+
+	tests := []struct {
+		name      string
+		profile   Profile
+		project   Project
+		component string
+		expected  float64
+	}{
+		{
+			name:      "MUSTスキルの記載が無い場合は言語・フレームワークで判定すること",
+			profile:   Profile{Skills: []Skill{{Name: "Java"}}},
+			project:   Project{Languages: []SkillRef{{Name: "JavaScript"}, {Name: "Java"}}},
+			component: ComponentMust,
+			expected:  22.5,
+		},
+		{
+			name:      "不足しているMUSTスキルは0点になること",
+			profile:   Profile{Skills: []Skill{{Name: "PHP"}}},
+			project:   Project{MustSkills: []SkillRef{{Name: "Go"}}},
+			component: ComponentMust,
+			expected:  0,
+		},
+		{
+			name:      "単価の上限が希望を下回る場合は減点すること",
+			profile:   Profile{DesiredPrice: price(800000)},
+			project:   Project{MonthlyPriceFrom: price(600000), MonthlyPriceTo: price(720000)},
+			component: ComponentPrice,
+			expected:  10,
+		},
+		{
+			name:      "単価の上限が希望を2割以上下回る場合は0点になること",
+			profile:   Profile{DesiredPrice: price(800000)},
+			project:   Project{MonthlyPriceTo: price(600000)},
+			component: ComponentPrice,
+			expected:  0,
+		},
+		{
+			name:      "フルリモート希望で出社の案件は0点になること",
+			profile:   Profile{RemotePreference: RemoteFull},
+			project:   Project{RemoteType: "不可"},
+			component: ComponentRemote,
+			expected:  0,
+		},
+		{
+			name:      "勤務地が希望の都道府県外の場合は0点になること",
+			profile:   Profile{RemotePreference: RemoteOnsite, PreferredPrefectures: []string{"東京都"}},
+			project:   Project{RemoteType: "不可", Prefectures: []string{"大阪府"}},
+			component: ComponentRemote,
+			expected:  0,
+		},
+		{
+			name:      "フルリモートの案件は勤務地を問わないこと",
+			profile:   Profile{RemotePreference: RemoteFull, PreferredPrefectures: []string{"東京都"}},
+			project:   Project{RemoteType: "フルリモート", Prefectures: []string{"大阪府"}},
+			component: ComponentRemote,
+			expected:  WeightRemote,
+		},
+		{
+			name:      "入場時期の終了より遅れる場合は減点すること",
+			profile:   Profile{AvailableFrom: day(7, 16)},
+			project:   Project{Starts: []StartRange{{From: day(6, 1), To: day(7, 1)}}},
+			component: ComponentStart,
+			expected:  5,
+		},
+		{
+			name:      "入場時期の開始まで待機が長い場合は減点すること",
+			profile:   Profile{},
+			project:   Project{Starts: []StartRange{{From: day(8, 30)}}},
+			component: ComponentStart,
+			expected:  5,
+		},
+		{
+			name:      "即日・随時は満点になること",
+			profile:   Profile{AvailableFrom: day(8, 1)},
+			project:   Project{Starts: []StartRange{{Flag: StartFlagOngoing}}},
+			component: ComponentStart,
+			expected:  WeightStart,
+		},
+		{
+			name:      "入場時期が不明な場合は半分になること",
+			profile:   Profile{},
+			project:   Project{Starts: []StartRange{{Flag: StartFlagUndecided}}},
+			component: ComponentStart,
+			expected:  WeightStart / 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := component(Score(tt.profile, tt.project, keyword.New(keyword.Rules{}), now), tt.component)
+			assert.Equal(t, tt.expected, got.Score)
+			assert.NotEmpty(t, got.Reason)
+		})
+	}
+}
+
+func TestScore_Aliases(t *testing.T) {
+	now := time.Date(2025, 7, 1, 9, 0, 0, 0, time.UTC)
+	rules, err := keyword.ParseRules("JavaScript: JS\nGo: Golang")
+	require.NoError(t, err)
+	n := keyword.New(rules)
+
+	// キーワードグループに解決できなくても、別名ルールで同じ正規名になるスキルは一致とみなすこと
+	profile := Profile{Skills: []Skill{{Name: "JS", Years: 3}, {Name: "Golang", Years: 3}}}
+	project := Project{
+		MustSkills: []SkillRef{{Name: "JavaScript"}, {Name: "Go"}},
+		WantSkills: []SkillRef{{Name: "JavaScriptでの実務経験"}},
+	}
+	got := Score(profile, project, n, now)
+	assert.Equal(t, WeightMust, got.Components[0].Score)
+	assert.Empty(t, got.Missing)
+	assert.Equal(t, WeightWant, got.Components[1].Score)
+
+	// 別名ルールが無い場合は一致しないこと
+	got = Score(profile, project, keyword.New(keyword.Rules{}), now)
+	assert.Equal(t, []string{"JavaScript", "Go"}, got.Missing)
+}
+
+func TestProfile_Validate(t *testing.T) {
+	p, err := Profile{Name: " 山田 ", Skills: []Skill{{Name: "Go", Years: 3}}}.Validate()
+	require.NoError(t, err)
+	assert.Equal(t, "山田", p.Name)
+	assert.Equal(t, RemoteAny, p.RemotePreference)
+
+	_, err = Profile{}.Validate()
+	assert.ErrorIs(t, err, ErrInvalidProfile)
+	_, err = Profile{Name: "山田", RemotePreference: "sometimes"}.Validate()
+	assert.ErrorIs(t, err, ErrInvalidProfile)
+	_, err = Profile{Name: "山田", Skills: []Skill{{Name: "Go"}, {Name: "ＧＯ"}}}.Validate()
+	assert.ErrorIs(t, err, ErrInvalidProfile)
+	_, err = Profile{Name: "山田", Skills: []Skill{{Name: "Go", Years: -1}}}.Validate()
+	assert.ErrorIs(t, err, ErrInvalidProfile)
+}
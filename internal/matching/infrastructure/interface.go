@@ -0,0 +1,41 @@
+// Package infrastructure はエンジニアのスキルプロフィールと案件のマッチング機能のインフラストラクチャ層を提供します。
+// このファイルはマッチングで使用するリポジトリのインターフェースを定義します。
+package infrastructure
+
+import (
+	"business/internal/matching/domain"
+	"time"
+)
+
+// RepositoryInterface はエンジニアのプロフィールと案件のマッチングのリポジトリインターフェースです
+type RepositoryInterface interface {
+	// ListProfiles はプロフィールをID順にスキル付きで返します
+	ListProfiles() ([]domain.Profile, error)
+
+	// FindProfile はプロフィールをスキル付きで返します
+	FindProfile(id uint) (domain.Profile, error)
+
+	// FindProfileByName は名前が一致するプロフィールをスキル付きで返します
+	FindProfileByName(name string) (domain.Profile, error)
+
+	// CreateProfile はプロフィールをスキル付きで保存します
+	CreateProfile(p domain.Profile) (domain.Profile, error)
+
+	// UpdateProfile はプロフィールを更新し、スキルを置き換えます
+	UpdateProfile(p domain.Profile) (domain.Profile, error)
+
+	// DeleteProfile はプロフィールをスキルごと削除します
+	DeleteProfile(id uint) error
+
+	// ResolveSkillGroups はスキル名に対応するキーワードグループのIDを、keyword.Key をキーにして返します
+	ResolveSkillGroups(names []string) (map[string]uint, error)
+
+	// ListMatchTargets は since 以降に受信したアーカイブしていない案件を、入場時期・勤務地付きで返します
+	ListMatchTargets(since time.Time, includeClosed bool) ([]domain.Project, error)
+
+	// FindMatchTarget は案件を入場時期・勤務地付きで返します
+	FindMatchTarget(projectID uint) (domain.Project, error)
+
+	// ListCandidates は since 以降に受信した人材メールの詳細を、キーワードグループに紐付けたスキル付きで返します
+	ListCandidates(since time.Time) ([]domain.Candidate, error)
+}
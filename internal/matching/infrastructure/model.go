@@ -0,0 +1,98 @@
+// Package infrastructure はエンジニアのスキルプロフィールと案件のマッチング機能のインフラストラクチャ層を提供します。
+// このファイルはマッチングで参照・更新するテーブルのモデルを定義します。
+package infrastructure
+
+import (
+	"time"
+)
+
+// EngineerProfile はエンジニアのスキルプロフィールを表すモデルです
+type EngineerProfile struct {
+	ID                   uint       `gorm:"primaryKey;autoIncrement"`
+	Name                 string     `gorm:"size:100;not null;uniqueIndex"`
+	DesiredPrice         *int       `gorm:"type:int"`
+	RemotePreference     string     `gorm:"size:20;not null;default:'any'"`
+	AvailableFrom        *time.Time `gorm:"type:date"`
+	PreferredPrefectures string     `gorm:"size:255;not null;default:''"`
+	Note                 string     `gorm:"type:text"`
+	CreatedAt            time.Time
+	UpdatedAt            time.Time
+
+	Skills []EngineerSkill `gorm:"foreignKey:ProfileID;references:ID"`
+}
+
+// EngineerSkill はエンジニアのスキルと経験年数を表すモデルです
+type EngineerSkill struct {
+	ID        uint    `gorm:"primaryKey;autoIncrement"`
+	ProfileID uint    `gorm:"not null;index"`
+	Name      string  `gorm:"size:255;not null"`
+	Years     float64 `gorm:"type:decimal(4,1);not null;default:0"`
+	CreatedAt time.Time
+}
+
+// projectRow は採点の対象として参照する案件の列です
+type projectRow struct {
+	ProjectID        uint
+	GmailID          string
+	Subject          string
+	ProjectTitle     *string
+	SenderEmail      string
+	ReceivedDate     time.Time
+	MustSkills       *string
+	WantSkills       *string
+	Languages        *string
+	Frameworks       *string
+	MonthlyPriceFrom *int
+	MonthlyPriceTo   *int
+	PriceNegotiable  bool
+	RemoteType       *string
+	LifecycleStatus  string
+}
+
+// candidateRow は採点の対象として参照する人材メールの列です
+type candidateRow struct {
+	CandidateID      uint
+	GmailID          string
+	Subject          string
+	SenderEmail      string
+	ReceivedDate     time.Time
+	CandidateName    *string
+	SkillsSummary    *string
+	AvailabilityDate *string
+	AvailableFrom    *time.Time
+	AvailableFlag    string
+	MonthlyPriceFrom *int
+	MonthlyPriceTo   *int
+	WorkLocation     *string
+	RemoteType       *string
+}
+
+// candidateSkillRow は人材メールに紐付けたキーワードグループの列です
+type candidateSkillRow struct {
+	EmailCandidateID uint
+	KeywordGroupID   uint
+	Name             string
+}
+
+// startRow は案件の入場時期の列です
+type startRow struct {
+	EmailProjectID uint
+	StartFrom      *time.Time
+	StartTo        *time.Time
+	StartFlag      string
+}
+
+// locationRow は案件の勤務地の列です
+type locationRow struct {
+	EmailProjectID uint
+	Prefecture     string
+	Remote         bool
+}
+
+func (EngineerProfile) TableName() string {
+	return "engineer_profiles"
+}
+
+func (EngineerSkill) TableName() string {
+	return "engineer_skills"
+}
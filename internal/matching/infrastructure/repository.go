@@ -0,0 +1,426 @@
+// Package infrastructure はエンジニアのスキルプロフィールと案件のマッチング機能のインフラストラクチャ層を提供します。
+// このファイルはプロフィールの保存と、採点の対象にする案件・人材の参照を実装します。
+package infrastructure
+
+import (
+	"business/internal/matching/domain"
+	"business/tools/keyword"
+	"business/tools/location"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// queryChunkSize は IN 句にまとめる案件IDの件数です
+const queryChunkSize = 1000
+
+// Repository はエンジニアのプロフィールと案件のマッチングのリポジトリ実装です
+type Repository struct {
+	db *gorm.DB
+}
+
+// New はエンジニアのプロフィールと案件のマッチングのリポジトリを作成します
+func New(db *gorm.DB) *Repository {
+	return &Repository{
+		db: db,
+	}
+}
+
+// ListProfiles はプロフィールをID順にスキル付きで返します
+func (r *Repository) ListProfiles() ([]domain.Profile, error) {
+	var rows []EngineerProfile
+	if err := r.db.Preload("Skills", orderByID).Order("id").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("プロフィール取得エラー: %w", err)
+	}
+	profiles := make([]domain.Profile, 0, len(rows))
+	for _, row := range rows {
+		profiles = append(profiles, toDomain(row))
+	}
+	return profiles, nil
+}
+
+// FindProfile はプロフィールをスキル付きで返します
+func (r *Repository) FindProfile(id uint) (domain.Profile, error) {
+	return r.findProfile(r.db, "id = ?", id)
+}
+
+// FindProfileByName は名前が一致するプロフィールをスキル付きで返します
+func (r *Repository) FindProfileByName(name string) (domain.Profile, error) {
+	return r.findProfile(r.db, "name = ?", name)
+}
+
+// CreateProfile はプロフィールをスキル付きで保存します
+// 同じ名前のプロフィールがある場合は domain.ErrConflict を返します。
+func (r *Repository) CreateProfile(p domain.Profile) (domain.Profile, error) {
+	var id uint
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := checkNameConflict(tx, p.Name, 0); err != nil {
+			return err
+		}
+		row := toModel(p)
+		if err := tx.Omit(clause.Associations).Create(&row).Error; err != nil {
+			return fmt.Errorf("プロフィール保存エラー: %w", err)
+		}
+		id = row.ID
+		return saveSkills(tx, row.ID, p.Skills)
+	})
+	if err != nil {
+		return domain.Profile{}, err
+	}
+	return r.FindProfile(id)
+}
+
+// UpdateProfile はプロフィールを更新し、スキルを置き換えます
+// プロフィールが無い場合は domain.ErrProfileNotFound、同じ名前の別のプロフィールがある場合は domain.ErrConflict を返します。
+func (r *Repository) UpdateProfile(p domain.Profile) (domain.Profile, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if _, err := r.findProfile(tx.Clauses(clause.Locking{Strength: "UPDATE"}), "id = ?", p.ID); err != nil {
+			return err
+		}
+		if err := checkNameConflict(tx, p.Name, p.ID); err != nil {
+			return err
+		}
+		row := toModel(p)
+		err := tx.Model(&EngineerProfile{}).Where("id = ?", p.ID).
+			Select("name", "desired_price", "remote_preference", "available_from", "preferred_prefectures", "note").
+			Updates(&row).Error
+		if err != nil {
+			return fmt.Errorf("プロフィール更新エラー: %w", err)
+		}
+		if err := tx.Where("profile_id = ?", p.ID).Delete(&EngineerSkill{}).Error; err != nil {
+			return fmt.Errorf("スキル削除エラー: %w", err)
+		}
+		return saveSkills(tx, p.ID, p.Skills)
+	})
+	if err != nil {
+		return domain.Profile{}, err
+	}
+	return r.FindProfile(p.ID)
+}
+
+// DeleteProfile はプロフィールをスキルごと削除します
+// プロフィールが無い場合は domain.ErrProfileNotFound を返します。
+func (r *Repository) DeleteProfile(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("profile_id = ?", id).Delete(&EngineerSkill{}).Error; err != nil {
+			return fmt.Errorf("スキル削除エラー: %w", err)
+		}
+		result := tx.Delete(&EngineerProfile{}, id)
+		if result.Error != nil {
+			return fmt.Errorf("プロフィール削除エラー: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("%w: #%d", domain.ErrProfileNotFound, id)
+		}
+		return nil
+	})
+}
+
+// ResolveSkillGroups はスキル名に対応するキーワードグループのIDを、keyword.Key をキーにして返します
+// グループの正規名、別名（KeyWord）の順に照合し、同じ名前のグループが複数ある場合はIDの小さいものを使います。
+func (r *Repository) ResolveSkillGroups(names []string) (map[string]uint, error) {
+	groupIDs := make(map[string]uint, len(names))
+	names = lo.Uniq(lo.Compact(names))
+	for _, chunk := range lo.Chunk(names, queryChunkSize) {
+		var groups []struct {
+			KeywordGroupID uint
+			Name           string
+		}
+		err := r.db.Table("keyword_groups").
+			Select("keyword_group_id, name").
+			Where("name IN ?", chunk).
+			Order("keyword_group_id").
+			Scan(&groups).Error
+		if err != nil {
+			return nil, fmt.Errorf("キーワードグループ取得エラー: %w", err)
+		}
+		for _, g := range groups {
+			if _, ok := groupIDs[keyword.Key(g.Name)]; !ok {
+				groupIDs[keyword.Key(g.Name)] = g.KeywordGroupID
+			}
+		}
+
+		var aliases []struct {
+			KeywordGroupID uint
+			Word           string
+		}
+		err = r.db.Table("key_words AS w").
+			Select("l.keyword_group_id, w.word").
+			Joins("JOIN keyword_group_word_links l ON l.key_word_id = w.id").
+			Where("w.word IN ?", chunk).
+			Order("l.keyword_group_id").
+			Scan(&aliases).Error
+		if err != nil {
+			return nil, fmt.Errorf("キーワード取得エラー: %w", err)
+		}
+		for _, a := range aliases {
+			if _, ok := groupIDs[keyword.Key(a.Word)]; !ok {
+				groupIDs[keyword.Key(a.Word)] = a.KeywordGroupID
+			}
+		}
+	}
+	return groupIDs, nil
+}
+
+// ListMatchTargets は since 以降に受信したアーカイブしていない案件を、入場時期・勤務地付きで受信日の新しい順に返します
+// includeClosed が false の場合は募集終了・期限切れの案件を除きます。
+func (r *Repository) ListMatchTargets(since time.Time, includeClosed bool) ([]domain.Project, error) {
+	query := projectQuery(r.db).Where("e.received_date >= ? AND ep.archived_at IS NULL", since)
+	if !includeClosed {
+		query = query.Where("ep.lifecycle_status NOT IN ?", []string{"closed", "expired"})
+	}
+	return r.listProjects(query.Order("e.received_date DESC, ep.id DESC"))
+}
+
+// FindMatchTarget は案件を入場時期・勤務地付きで返します（募集終了・アーカイブした案件も返します）
+// 案件が無い場合は domain.ErrProjectNotFound を返します。
+func (r *Repository) FindMatchTarget(projectID uint) (domain.Project, error) {
+	projects, err := r.listProjects(projectQuery(r.db).Where("ep.id = ?", projectID))
+	if err != nil {
+		return domain.Project{}, err
+	}
+	if len(projects) == 0 {
+		return domain.Project{}, fmt.Errorf("%w: #%d", domain.ErrProjectNotFound, projectID)
+	}
+	return projects[0], nil
+}
+
+// ListCandidates は since 以降に受信した人材メールの詳細を、キーワードグループに紐付けたスキル付きで受信日の新しい順に返します
+// 希望勤務地は都道府県に正規化します。
+func (r *Repository) ListCandidates(since time.Time) ([]domain.Candidate, error) {
+	var rows []candidateRow
+	err := r.db.Table("email_candidates ec").
+		Select(`ec.id AS candidate_id, e.gmail_id, e.subject, e.sender_email, e.received_date,
+			ec.candidate_name, ec.skills_summary, ec.availability_date, ec.available_from, ec.available_flag,
+			ec.monthly_price_from, ec.monthly_price_to, ec.work_location, ec.remote_type`).
+		Joins("JOIN emails e ON e.id = ec.email_id").
+		Where("e.received_date >= ?", since).
+		Order("e.received_date DESC, ec.id DESC").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("人材取得エラー: %w", err)
+	}
+
+	candidates := make([]domain.Candidate, 0, len(rows))
+	index := make(map[uint]int, len(rows))
+	for _, row := range rows {
+		index[row.CandidateID] = len(candidates)
+		var prefectures []string
+		for _, l := range location.Normalize(derefString(row.WorkLocation)) {
+			if l.Prefecture != "" && !lo.Contains(prefectures, l.Prefecture) {
+				prefectures = append(prefectures, l.Prefecture)
+			}
+		}
+		candidates = append(candidates, domain.Candidate{
+			CandidateID:      row.CandidateID,
+			GmailID:          row.GmailID,
+			Subject:          row.Subject,
+			SenderEmail:      row.SenderEmail,
+			ReceivedDate:     row.ReceivedDate,
+			CandidateName:    derefString(row.CandidateName),
+			Summary:          derefString(row.SkillsSummary),
+			MonthlyPriceFrom: row.MonthlyPriceFrom,
+			MonthlyPriceTo:   row.MonthlyPriceTo,
+			Availability:     derefString(row.AvailabilityDate),
+			AvailableFrom:    row.AvailableFrom,
+			AvailableFlag:    row.AvailableFlag,
+			RemoteType:       derefString(row.RemoteType),
+			Prefectures:      prefectures,
+		})
+	}
+
+	for _, ids := range lo.Chunk(lo.Keys(index), queryChunkSize) {
+		var skills []candidateSkillRow
+		err := r.db.Table("email_candidate_keyword_groups l").
+			Select("DISTINCT l.email_candidate_id, g.keyword_group_id, g.name").
+			Joins("JOIN keyword_groups g ON g.keyword_group_id = l.keyword_group_id").
+			Where("l.email_candidate_id IN ?", ids).
+			Order("l.email_candidate_id, g.keyword_group_id").
+			Scan(&skills).Error
+		if err != nil {
+			return nil, fmt.Errorf("人材のスキル取得エラー: %w", err)
+		}
+		for _, s := range skills {
+			c := &candidates[index[s.EmailCandidateID]]
+			c.Skills = append(c.Skills, domain.Skill{Name: s.Name, GroupID: s.KeywordGroupID})
+		}
+	}
+	return candidates, nil
+}
+
+// projectQuery は採点の対象にする案件の列を選ぶクエリを返します
+func projectQuery(db *gorm.DB) *gorm.DB {
+	return db.Table("email_projects ep").
+		Select(`ep.id AS project_id, e.gmail_id, e.subject, ep.project_title, e.sender_email, e.received_date,
+			ep.must_skills, ep.want_skills, ep.languages, ep.frameworks, ep.monthly_price_from, ep.monthly_price_to,
+			ep.price_negotiable, ep.remote_type, ep.lifecycle_status`).
+		Joins("JOIN emails e ON e.id = ep.email_id")
+}
+
+// listProjects はクエリに一致する案件を、入場時期・勤務地付きでクエリの順に返します
+func (r *Repository) listProjects(query *gorm.DB) ([]domain.Project, error) {
+	var rows []projectRow
+	if err := query.Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("案件取得エラー: %w", err)
+	}
+	projects := make([]domain.Project, 0, len(rows))
+	index := make(map[uint]int, len(rows))
+	for _, row := range rows {
+		index[row.ProjectID] = len(projects)
+		projects = append(projects, domain.Project{
+			ProjectID:        row.ProjectID,
+			GmailID:          row.GmailID,
+			Subject:          row.Subject,
+			ProjectTitle:     derefString(row.ProjectTitle),
+			SenderEmail:      row.SenderEmail,
+			ReceivedDate:     row.ReceivedDate,
+			MustSkills:       skillRefs(row.MustSkills),
+			WantSkills:       skillRefs(row.WantSkills),
+			Languages:        skillRefs(row.Languages),
+			Frameworks:       skillRefs(row.Frameworks),
+			MonthlyPriceFrom: row.MonthlyPriceFrom,
+			MonthlyPriceTo:   row.MonthlyPriceTo,
+			PriceNegotiable:  row.PriceNegotiable,
+			RemoteType:       derefString(row.RemoteType),
+			LifecycleStatus:  row.LifecycleStatus,
+		})
+	}
+
+	for _, ids := range lo.Chunk(lo.Keys(index), queryChunkSize) {
+		var starts []startRow
+		err := r.db.Table("entry_timings").
+			Select("email_project_id, start_from, start_to, start_flag").
+			Where("email_project_id IN ?", ids).
+			Order("email_project_id, id").
+			Scan(&starts).Error
+		if err != nil {
+			return nil, fmt.Errorf("入場時期取得エラー: %w", err)
+		}
+		for _, s := range starts {
+			p := &projects[index[s.EmailProjectID]]
+			p.Starts = append(p.Starts, domain.StartRange{From: s.StartFrom, To: s.StartTo, Flag: s.StartFlag})
+		}
+
+		var locations []locationRow
+		err = r.db.Table("project_locations").
+			Select("email_project_id, prefecture, remote").
+			Where("email_project_id IN ?", ids).
+			Order("email_project_id, site_no").
+			Scan(&locations).Error
+		if err != nil {
+			return nil, fmt.Errorf("勤務地取得エラー: %w", err)
+		}
+		for _, l := range locations {
+			p := &projects[index[l.EmailProjectID]]
+			if l.Remote {
+				p.FullRemote = true
+			}
+			if l.Prefecture != "" && !lo.Contains(p.Prefectures, l.Prefecture) {
+				p.Prefectures = append(p.Prefectures, l.Prefecture)
+			}
+		}
+	}
+	return projects, nil
+}
+
+// findProfile は条件に一致するプロフィールをスキル付きで返します
+func (r *Repository) findProfile(db *gorm.DB, query string, args ...interface{}) (domain.Profile, error) {
+	var row EngineerProfile
+	err := db.Preload("Skills", orderByID).Where(query, args...).First(&row).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domain.Profile{}, fmt.Errorf("%w: %v", domain.ErrProfileNotFound, args[0])
+	}
+	if err != nil {
+		return domain.Profile{}, fmt.Errorf("プロフィール取得エラー: %w", err)
+	}
+	return toDomain(row), nil
+}
+
+// checkNameConflict は exceptID 以外に同じ名前のプロフィールがあれば domain.ErrConflict を返します
+func checkNameConflict(tx *gorm.DB, name string, exceptID uint) error {
+	var count int64
+	if err := tx.Model(&EngineerProfile{}).Where("name = ? AND id <> ?", name, exceptID).Count(&count).Error; err != nil {
+		return fmt.Errorf("プロフィール取得エラー: %w", err)
+	}
+	if count > 0 {
+		return fmt.Errorf("%w: %s", domain.ErrConflict, name)
+	}
+	return nil
+}
+
+// saveSkills はプロフィールのスキルを入力順に保存します
+func saveSkills(tx *gorm.DB, profileID uint, skills []domain.Skill) error {
+	if len(skills) == 0 {
+		return nil
+	}
+	rows := make([]EngineerSkill, 0, len(skills))
+	for _, s := range skills {
+		rows = append(rows, EngineerSkill{ProfileID: profileID, Name: s.Name, Years: s.Years})
+	}
+	if err := tx.Create(&rows).Error; err != nil {
+		return fmt.Errorf("スキル保存エラー: %w", err)
+	}
+	return nil
+}
+
+func orderByID(db *gorm.DB) *gorm.DB {
+	return db.Order("id")
+}
+
+func toModel(p domain.Profile) EngineerProfile {
+	return EngineerProfile{
+		ID:                   p.ID,
+		Name:                 p.Name,
+		DesiredPrice:         p.DesiredPrice,
+		RemotePreference:     string(p.RemotePreference),
+		AvailableFrom:        p.AvailableFrom,
+		PreferredPrefectures: strings.Join(p.PreferredPrefectures, ","),
+		Note:                 p.Note,
+	}
+}
+
+func toDomain(row EngineerProfile) domain.Profile {
+	skills := make([]domain.Skill, 0, len(row.Skills))
+	for _, s := range row.Skills {
+		skills = append(skills, domain.Skill{Name: s.Name, Years: s.Years})
+	}
+	prefectures := []string{}
+	if row.PreferredPrefectures != "" {
+		prefectures = strings.Split(row.PreferredPrefectures, ",")
+	}
+	return domain.Profile{
+		ID:                   row.ID,
+		Name:                 row.Name,
+		Skills:               skills,
+		DesiredPrice:         row.DesiredPrice,
+		RemotePreference:     domain.RemotePreference(row.RemotePreference),
+		AvailableFrom:        row.AvailableFrom,
+		PreferredPrefectures: prefectures,
+		Note:                 row.Note,
+		CreatedAt:            row.CreatedAt,
+		UpdatedAt:            row.UpdatedAt,
+	}
+}
+
+// skillRefs はカンマ区切りのスキルを空白と空文字を除いて返します
+func skillRefs(s *string) []domain.SkillRef {
+	var refs []domain.SkillRef
+	for _, name := range strings.Split(derefString(s), ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			refs = append(refs, domain.SkillRef{Name: name})
+		}
+	}
+	return refs
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
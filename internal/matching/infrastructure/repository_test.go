@@ -0,0 +1,217 @@
+package infrastructure
+
+import (
+	"business/internal/matching/domain"
+	"business/tools/migrations/model"
+	"business/tools/mysql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_Profiles(t *testing.T) {
+	t.Parallel()
+	// テスト用DBの準備
+	db, cleanup, err := mysql.CreateNewTestDB()
+	require.NoError(t, err)
+	defer cleanup()
+
+	err = db.DB.AutoMigrate(
+		model.EngineerProfile{},
+		model.EngineerSkill{},
+		model.KeywordGroup{},
+		model.KeyWord{},
+		model.KeywordGroupWordLink{},
+	)
+	require.NoError(t, err)
+
+	repo := New(db.DB)
+	price := 700000
+	available := time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)
+
+	// スキル付きで保存し、入力順に返すこと
+	created, err := repo.CreateProfile(domain.Profile{
+		Name:                 "山田",
+		Skills:               []domain.Skill{{Name: "Go", Years: 5}, {Name: "AWS", Years: 2.5}},
+		DesiredPrice:         &price,
+		RemotePreference:     domain.RemoteFull,
+		AvailableFrom:        &available,
+		PreferredPrefectures: []string{"東京都", "神奈川県"},
+	})
+	require.NoError(t, err)
+	assert.NotZero(t, created.ID)
+	assert.Equal(t, []domain.Skill{{Name: "Go", Years: 5}, {Name: "AWS", Years: 2.5}}, created.Skills)
+	assert.Equal(t, []string{"東京都", "神奈川県"}, created.PreferredPrefectures)
+	assert.Equal(t, "2025-08-01", created.AvailableFrom.Format("2006-01-02"))
+
+	// 同じ名前は競合すること
+	_, err = repo.CreateProfile(domain.Profile{Name: "山田", RemotePreference: domain.RemoteAny})
+	assert.ErrorIs(t, err, domain.ErrConflict)
+
+	// 更新でスキルを置き換え、未設定の項目を消せること
+	created.Skills = []domain.Skill{{Name: "Python", Years: 1}}
+	created.DesiredPrice = nil
+	created.PreferredPrefectures = nil
+	updated, err := repo.UpdateProfile(created)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.Skill{{Name: "Python", Years: 1}}, updated.Skills)
+	assert.Nil(t, updated.DesiredPrice)
+	assert.Empty(t, updated.PreferredPrefectures)
+
+	found, err := repo.FindProfileByName("山田")
+	require.NoError(t, err)
+	assert.Equal(t, created.ID, found.ID)
+	profiles, err := repo.ListProfiles()
+	require.NoError(t, err)
+	require.Len(t, profiles, 1)
+
+	// 削除後は見つからないこと
+	require.NoError(t, repo.DeleteProfile(created.ID))
+	_, err = repo.FindProfile(created.ID)
+	assert.ErrorIs(t, err, domain.ErrProfileNotFound)
+	assert.ErrorIs(t, repo.DeleteProfile(created.ID), domain.ErrProfileNotFound)
+	_, err = repo.UpdateProfile(created)
+	assert.ErrorIs(t, err, domain.ErrProfileNotFound)
+
+	// スキル名をグループの正規名・別名からキーワードグループに解決すること
+	group := model.KeywordGroup{Name: "Go", Type: "language"}
+	require.NoError(t, db.DB.Create(&group).Error)
+	word := model.KeyWord{Word: "Golang"}
+	require.NoError(t, db.DB.Create(&word).Error)
+	require.NoError(t, db.DB.Create(&model.KeywordGroupWordLink{KeywordGroupID: group.KeywordGroupID, KeyWordID: word.ID}).Error)
+	groups, err := repo.ResolveSkillGroups([]string{"go", "Golang", "Rust", "Go"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]uint{"go": group.KeywordGroupID, "golang": group.KeywordGroupID}, groups)
+}
+
+func TestRepository_ListMatchTargets(t *testing.T) {
+	t.Parallel()
+	// テスト用DBの準備
+	db, cleanup, err := mysql.CreateNewTestDB()
+	require.NoError(t, err)
+	defer cleanup()
+
+	err = db.DB.AutoMigrate(
+		model.Email{},
+		model.EmailProject{},
+		model.EntryTiming{},
+		model.ProjectLocation{},
+	)
+	require.NoError(t, err)
+
+	now := time.Now().Truncate(time.Second)
+	emails := []model.Email{
+		{GmailID: "gmail-1", Subject: "Go案件", SenderEmail: "a@agency.example.com", ReceivedDate: now.Add(-2 * time.Hour), Category: "案件"},
+		{GmailID: "gmail-2", Subject: "終了した案件", SenderEmail: "a@agency.example.com", ReceivedDate: now.Add(-time.Hour), Category: "案件"},
+		{GmailID: "gmail-old", Subject: "古い案件", SenderEmail: "a@agency.example.com", ReceivedDate: now.AddDate(0, -2, 0), Category: "案件"},
+	}
+	require.NoError(t, db.DB.Create(&emails).Error)
+	must := "Go実務3年以上, AWS"
+	langs := "Go"
+	remote := "リモート可"
+	priceTo := 800000
+	archivedAt := now
+	projects := []model.EmailProject{
+		{EmailID: emails[0].ID, ProjectKey: "p1", MustSkills: &must, Languages: &langs, RemoteType: &remote, MonthlyPriceTo: &priceTo, LifecycleStatus: "open"},
+		{EmailID: emails[1].ID, ProjectKey: "p2", LifecycleStatus: "closed"},
+		{EmailID: emails[1].ID, ProjectKey: "p3", LifecycleStatus: "expired", ArchivedAt: &archivedAt},
+		{EmailID: emails[2].ID, ProjectKey: "p4", LifecycleStatus: "open"},
+	}
+	require.NoError(t, db.DB.Create(&projects).Error)
+	start := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, db.DB.Create(&model.EntryTiming{EmailProjectID: projects[0].ID, StartDate: "7月", StartFrom: &start, StartTo: &start}).Error)
+	require.NoError(t, db.DB.Create(&[]model.ProjectLocation{
+		{EmailProjectID: projects[0].ID, SiteNo: 0, Prefecture: "東京都", Station: "品川"},
+		{EmailProjectID: projects[0].ID, SiteNo: 1, Prefecture: "東京都", Station: "田町"},
+	}).Error)
+
+	repo := New(db.DB)
+
+	// 受信日の範囲内で、募集終了・期限切れ・アーカイブした案件を除くこと
+	targets, err := repo.ListMatchTargets(now.AddDate(0, 0, -14), false)
+	require.NoError(t, err)
+	require.Len(t, targets, 1)
+	p := targets[0]
+	assert.Equal(t, "gmail-1", p.GmailID)
+	assert.Equal(t, []domain.SkillRef{{Name: "Go実務3年以上"}, {Name: "AWS"}}, p.MustSkills)
+	assert.Equal(t, []domain.SkillRef{{Name: "Go"}}, p.Languages)
+	assert.Equal(t, "リモート可", p.RemoteType)
+	assert.Equal(t, []string{"東京都"}, p.Prefectures)
+	require.Len(t, p.Starts, 1)
+	assert.Equal(t, "2025-07-01", p.Starts[0].From.Format("2006-01-02"))
+
+	// 募集終了も含める場合もアーカイブした案件は除くこと
+	targets, err = repo.ListMatchTargets(now.AddDate(0, 0, -14), true)
+	require.NoError(t, err)
+	require.Len(t, targets, 2)
+	assert.Equal(t, "gmail-2", targets[0].GmailID)
+}
+
+func TestRepository_ListCandidates(t *testing.T) {
+	t.Parallel()
+	// テスト用DBの準備
+	db, cleanup, err := mysql.CreateNewTestDB()
+	require.NoError(t, err)
+	defer cleanup()
+
+	err = db.DB.AutoMigrate(
+		model.Email{},
+		model.EmailProject{},
+		model.EntryTiming{},
+		model.ProjectLocation{},
+		model.EmailCandidate{},
+		model.EmailCandidateKeywordGroup{},
+		model.KeywordGroup{},
+	)
+	require.NoError(t, err)
+
+	now := time.Now().Truncate(time.Second)
+	emails := []model.Email{
+		{GmailID: "gmail-project", Subject: "Go案件", SenderEmail: "a@agency.example.com", ReceivedDate: now.AddDate(0, -3, 0), Category: "案件"},
+		{GmailID: "gmail-candidate", Subject: "人材のご紹介", SenderEmail: "b@agency.example.com", ReceivedDate: now.Add(-time.Hour), Category: "人材"},
+		{GmailID: "gmail-old", Subject: "古い人材", SenderEmail: "b@agency.example.com", ReceivedDate: now.AddDate(0, -2, 0), Category: "人材"},
+	}
+	require.NoError(t, db.DB.Create(&emails).Error)
+	archivedAt := now
+	project := model.EmailProject{EmailID: emails[0].ID, ProjectKey: "p1", LifecycleStatus: "closed", ArchivedAt: &archivedAt}
+	require.NoError(t, db.DB.Create(&project).Error)
+
+	name := "T.Y"
+	location := "東京都港区"
+	priceFrom := 650000
+	available := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	candidates := []model.EmailCandidate{
+		{EmailID: emails[1].ID, CandidateName: &name, WorkLocation: &location, MonthlyPriceFrom: &priceFrom, AvailableFrom: &available},
+		{EmailID: emails[2].ID},
+	}
+	require.NoError(t, db.DB.Create(&candidates).Error)
+	groups := []model.KeywordGroup{{Name: "Go", Type: "language"}, {Name: "AWS", Type: "must"}}
+	require.NoError(t, db.DB.Create(&groups).Error)
+	require.NoError(t, db.DB.Create(&[]model.EmailCandidateKeywordGroup{
+		{EmailCandidateID: candidates[0].ID, KeywordGroupID: groups[0].KeywordGroupID},
+		{EmailCandidateID: candidates[0].ID, KeywordGroupID: groups[1].KeywordGroupID},
+		{EmailCandidateID: candidates[0].ID, KeywordGroupID: groups[0].KeywordGroupID},
+	}).Error)
+
+	repo := New(db.DB)
+
+	// 受信日の範囲内の人材を、キーワードグループのスキルと希望勤務地の都道府県付きで返すこと
+	got, err := repo.ListCandidates(now.AddDate(0, 0, -30))
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	c := got[0]
+	assert.Equal(t, "gmail-candidate", c.GmailID)
+	assert.Equal(t, "T.Y", c.CandidateName)
+	assert.Equal(t, []domain.Skill{{Name: "Go", GroupID: groups[0].KeywordGroupID}, {Name: "AWS", GroupID: groups[1].KeywordGroupID}}, c.Skills)
+	assert.Equal(t, []string{"東京都"}, c.Prefectures)
+	assert.Equal(t, "2025-07-01", c.AvailableFrom.Format("2006-01-02"))
+
+	// 募集終了・アーカイブした案件も案件IDで取得できること
+	p, err := repo.FindMatchTarget(project.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "gmail-project", p.GmailID)
+	_, err = repo.FindMatchTarget(project.ID + 100)
+	assert.ErrorIs(t, err, domain.ErrProjectNotFound)
+}
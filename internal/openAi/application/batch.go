@@ -0,0 +1,59 @@
+package application
+
+import (
+	cd "business/internal/common/domain"
+	"business/tools/textchunk"
+)
+
+// BatchPrompt はBatch APIで送信する1リクエスト分のプロンプトです
+type BatchPrompt struct {
+	GmailID    string
+	ChunkIndex int // 本文を分割した場合のチャンク番号（0始まり）
+	ChunkCount int // 本文の分割数
+	Prompt     string
+}
+
+// BuildBatchPrompts はメールごとにBatch APIへ送信するプロンプトを作成します
+// 同期実行と同じく個人情報を伏せ字にし、トークン上限を超える本文はチャンクに分割します。
+func (u *UseCase) BuildBatchPrompts(emails []cd.BasicMessage) ([]BatchPrompt, error) {
+	prompt, err := u.readPrompt()
+	if err != nil {
+		return nil, err
+	}
+
+	bodyBudget, err := u.bodyTokenBudget(prompt)
+	if err != nil {
+		return nil, err
+	}
+	redactor := u.redactor()
+
+	var prompts []BatchPrompt
+	for _, email := range emails {
+		redactedBody, _ := redactor.Redact(email.Body)
+		chunks := textchunk.Split(redactedBody, bodyBudget)
+		for i, chunk := range chunks {
+			prompts = append(prompts, BatchPrompt{
+				GmailID:    email.ID,
+				ChunkIndex: i,
+				ChunkCount: len(chunks),
+				Prompt:     prompt + "\n\n" + chunk,
+			})
+		}
+	}
+	return prompts, nil
+}
+
+// ConvertBatchResults はBatch APIのチャンクごとの解析結果を統合し、保存形式へ詰め替えます
+// 伏せ字の対応表は送信時と同じ設定で本文を再度伏せ字にして復元します。
+func (u *UseCase) ConvertBatchResults(email cd.BasicMessage, chunkResults [][]cd.AnalysisResult) []cd.Email {
+	var merged []cd.AnalysisResult
+	for _, results := range chunkResults {
+		merged = append(merged, results...)
+	}
+	if len(chunkResults) > 1 {
+		merged = dedupeAnalysisResults(merged)
+	}
+
+	_, mapping := u.redactor().Redact(email.Body)
+	return toEmails(email, mapping, merged, u.AnalysisVersion())
+}
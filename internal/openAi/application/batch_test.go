@@ -0,0 +1,84 @@
+package application
+
+import (
+	cd "business/internal/common/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildBatchPrompts(t *testing.T) {
+	mockOS := &mockOsWrapper{
+		ReadFileFunc: func(path string) (string, error) {
+			return "PROMPT", nil
+		},
+		GetEnvFunc: func(key string) string {
+			if key == "OPENAI_MAX_INPUT_TOKENS" {
+				return "20"
+			}
+			return ""
+		},
+	}
+	usecase := New(new(mockAnalyzer), mockOS)
+
+	prompts, err := usecase.BuildBatchPrompts([]cd.BasicMessage{
+		{ID: "gmail-1", Body: "■案件1\nGo開発の案件です\n■案件2\nPHP開発の案件です"},
+		{ID: "gmail-2", Body: "連絡先：yamada@example.com"},
+	})
+
+	// 長い本文はチャンクごとに、個人情報は伏せ字にしてプロンプトを作成する
+	require.NoError(t, err)
+	assert.Equal(t, []BatchPrompt{
+		{GmailID: "gmail-1", ChunkIndex: 0, ChunkCount: 2, Prompt: "PROMPT\n\n■案件1\nGo開発の案件です"},
+		{GmailID: "gmail-1", ChunkIndex: 1, ChunkCount: 2, Prompt: "PROMPT\n\n■案件2\nPHP開発の案件です"},
+		{GmailID: "gmail-2", ChunkIndex: 0, ChunkCount: 1, Prompt: "PROMPT\n\n連絡先：[EMAIL_1]"},
+	}, prompts)
+}
+
+func TestBuildBatchPrompts_PromptExceedsMaxInputTokens(t *testing.T) {
+	mockOS := &mockOsWrapper{
+		ReadFileFunc: func(path string) (string, error) {
+			return "長い解析プロンプトです", nil
+		},
+		GetEnvFunc: func(key string) string {
+			if key == "OPENAI_MAX_INPUT_TOKENS" {
+				return "5"
+			}
+			return ""
+		},
+	}
+	usecase := New(new(mockAnalyzer), mockOS)
+
+	prompts, err := usecase.BuildBatchPrompts([]cd.BasicMessage{{ID: "gmail-1", Body: "■案件1\nGo開発の案件です"}})
+
+	// プロンプトだけで上限を超える場合はリクエストを作らずにエラーを返すこと
+	assert.Nil(t, prompts)
+	assert.ErrorContains(t, err, "入力トークンの上限 5")
+}
+
+func TestConvertBatchResults(t *testing.T) {
+	mockOS := &mockOsWrapper{
+		GetEnvFunc: func(key string) string {
+			if key == "ANALYSIS_VERSION" {
+				return "v2"
+			}
+			return ""
+		},
+	}
+	usecase := New(new(mockAnalyzer), mockOS)
+
+	email := cd.BasicMessage{ID: "gmail-1", Subject: "件名", Body: "■案件1\nGo開発\n連絡先：yamada@example.com"}
+	actual := usecase.ConvertBatchResults(email, [][]cd.AnalysisResult{
+		{{MailCategory: "案件", ProjectTitle: "Go開発", WorkLocation: "[EMAIL_1]"}},
+		{{MailCategory: "案件", ProjectTitle: "Go 開発", WorkLocation: "[EMAIL_1]"}},
+	})
+
+	// チャンクをまたいだ重複は除外し、伏せ字は元の値に戻す
+	require.Len(t, actual, 1)
+	assert.Equal(t, "gmail-1", actual[0].GmailID)
+	assert.Equal(t, "Go開発", actual[0].ProjectName)
+	assert.Equal(t, "yamada@example.com", actual[0].WorkLocation)
+	assert.Equal(t, "v2", actual[0].AnalysisVersion)
+	assert.Equal(t, email.Body, actual[0].Body)
+}
@@ -10,4 +10,13 @@ import (
 // UseCaseInterface はメール分析のユースケースインターフェースです
 type UseCaseInterface interface {
 	AnalyzeEmailContent(ctx context.Context, emails []cd.BasicMessage) ([]cd.Email, error)
+
+	// AnalysisVersion は解析結果に記録する解析バージョンを返します
+	AnalysisVersion() string
+
+	// BuildBatchPrompts はメールごとにBatch APIへ送信するプロンプトを作成します
+	BuildBatchPrompts(emails []cd.BasicMessage) ([]BatchPrompt, error)
+
+	// ConvertBatchResults はBatch APIのチャンクごとの解析結果を統合し、保存形式へ詰め替えます
+	ConvertBatchResults(email cd.BasicMessage, chunkResults [][]cd.AnalysisResult) []cd.Email
 }
@@ -6,11 +6,24 @@ import (
 	cd "business/internal/common/domain"
 	r "business/internal/openAi/infrastructure"
 	"business/tools/oswrapper"
+	"business/tools/redact"
+	"business/tools/textchunk"
 	"context"
 	"fmt"
+	"strconv"
+	"strings"
 	"sync"
 )
 
+// DefaultMaxInputTokens は1リクエストあたりの入力トークン上限の既定値です
+const DefaultMaxInputTokens = 8000
+
+// DefaultAnalysisVersion は環境変数 ANALYSIS_VERSION が未設定の場合の解析バージョンです
+const DefaultAnalysisVersion = "v1"
+
+// DefaultConcurrency は同時に解析するメール数の既定値です
+const DefaultConcurrency = 5
+
 // UseCase はメール分析のユースケースの具象です
 type UseCase struct {
 	r  r.ConnectInterface
@@ -26,50 +39,222 @@ func New(r r.ConnectInterface, os oswrapper.OsWapperInterface) *UseCase {
 }
 
 // AnalyzeEmailContent はメール内容を分析します
+// メールは先頭から順に、同時に解析するメール数を上限として解析します。優先する営業会社のメールなど先頭に並べたメールほど先に解析され、
+// 解析結果もメールの順に返します。ctxがキャンセルされた場合は未解析のメールをAPIに送らず、ctxのエラーを返します。
 func (u *UseCase) AnalyzeEmailContent(ctx context.Context, emails []cd.BasicMessage) ([]cd.Email, error) {
-	// TODO あとでENVに追加する。
-	prompt, err := u.os.ReadFile("/data/prompts/text_analysis_prompt.txt")
+	prompt, err := u.readPrompt()
 	if err != nil {
 		return nil, err
 	}
 
-	var AnalyzeEmailWg sync.WaitGroup
-	analyzeEmailChan := make(chan cd.Email)
-	for _, email := range emails {
-		AnalyzeEmailWg.Add(1)
+	bodyBudget, err := u.bodyTokenBudget(prompt)
+	if err != nil {
+		return nil, err
+	}
+	version := u.AnalysisVersion()
+	redactor := u.redactor()
 
-		// メール本文の分析を実行
-		go func(email cd.BasicMessage) {
-			defer AnalyzeEmailWg.Done()
+	// 解析待ちのメールを先頭から順に取り出して解析する
+	queue := make(chan int)
+	resultsByEmail := make([][]cd.Email, len(emails))
+	var wg sync.WaitGroup
+	for range min(u.Concurrency(), len(emails)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range queue {
+				// キャンセル後は残りのメールを解析せずに読み捨てる
+				if ctx.Err() != nil {
+					continue
+				}
+				email := emails[i]
 
-			analysisResults, err := u.r.AnalyzeEmailBody(ctx, string(prompt)+"\n\n"+email.Body)
+				// メール本文の分析を実行
+				redactedBody, mapping := redactor.Redact(email.Body)
+				analysisResults, err := u.analyzeBody(ctx, prompt, redactedBody, bodyBudget)
 
-			if err != nil {
-				fmt.Printf("解析時にエラーが発生しました。 GメールID: %s %v \n", email.ID, err)
-				return
-			}
-			if len(analysisResults) == 0 {
-				fmt.Printf("GメールID: %v の解析結果が0件でした。 メールを確認してください。\n", email.ID)
-				return
-			}
+				if err != nil {
+					fmt.Printf("解析時にエラーが発生しました。 GメールID: %s %v \n", email.ID, err)
+					continue
+				}
+				if len(analysisResults) == 0 {
+					fmt.Printf("GメールID: %v の解析結果が0件でした。 メールを確認してください。\n", email.ID)
+					continue
+				}
 
-			// 解析結果を保存形式へ詰め替える。
-			results := convertToStructs(email, analysisResults)
-			for _, result := range results {
-				analyzeEmailChan <- result
+				resultsByEmail[i] = toEmails(email, mapping, analysisResults, version)
 			}
-		}(email)
+		}()
+	}
+	for i := range emails {
+		queue <- i
+	}
+	close(queue)
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("メール分析を中断しました: %w", err)
 	}
-	go func() { AnalyzeEmailWg.Wait(); close(analyzeEmailChan) }()
 
 	var analysisEmail []cd.Email
-	for email := range analyzeEmailChan {
-		analysisEmail = append(analysisEmail, email)
+	for _, results := range resultsByEmail {
+		analysisEmail = append(analysisEmail, results...)
 	}
 
 	return analysisEmail, nil
 }
 
+// Concurrency は同時に解析するメール数を返します
+// 環境変数 OPENAI_CONCURRENCY で変更できます。
+func (u *UseCase) Concurrency() int {
+	if v, err := strconv.Atoi(u.os.GetEnv("OPENAI_CONCURRENCY")); err == nil && v > 0 {
+		return v
+	}
+	return DefaultConcurrency
+}
+
+// readPrompt は解析プロンプトを読み込みます
+func (u *UseCase) readPrompt() (string, error) {
+	// TODO あとでENVに追加する。
+	return u.os.ReadFile("/data/prompts/text_analysis_prompt.txt")
+}
+
+// redactor は送信前に個人情報を伏せ字にするRedactorを返します
+// PII_REDACTION で種類を指定します。"none" で無効になります。
+func (u *UseCase) redactor() *redact.Redactor {
+	return redact.New(redact.ParseConfig(u.os.GetEnv("PII_REDACTION")))
+}
+
+// toEmails は伏せ字を元の値に戻してから保存形式へ詰め替えます
+func toEmails(email cd.BasicMessage, mapping *redact.Mapping, analysisResults []cd.AnalysisResult, version string) []cd.Email {
+	analysisResults = restoreAnalysisResults(mapping, analysisResults)
+	results := convertToStructs(email, analysisResults)
+	for i := range results {
+		results[i].AnalysisVersion = version
+	}
+	return results
+}
+
+// AnalysisVersion は解析結果に記録する解析バージョンを返します
+// プロンプトを変更した場合は環境変数 ANALYSIS_VERSION を更新してください。
+func (u *UseCase) AnalysisVersion() string {
+	if v := u.os.GetEnv("ANALYSIS_VERSION"); v != "" {
+		return v
+	}
+	return DefaultAnalysisVersion
+}
+
+// analyzeBody は本文をトークン上限以下のチャンクに分割して分析し、結果を統合します
+// いずれかのチャンクで失敗した場合はメール単位でエラーとします。
+func (u *UseCase) analyzeBody(ctx context.Context, prompt string, body string, budget int) ([]cd.AnalysisResult, error) {
+	chunks := textchunk.Split(body, budget)
+	if len(chunks) == 1 {
+		return u.r.AnalyzeEmailBody(ctx, prompt+"\n\n"+chunks[0])
+	}
+
+	var merged []cd.AnalysisResult
+	for i, chunk := range chunks {
+		results, err := u.r.AnalyzeEmailBody(ctx, prompt+"\n\n"+chunk)
+		if err != nil {
+			return nil, fmt.Errorf("チャンク%d/%dの解析エラー: %w", i+1, len(chunks), err)
+		}
+		merged = append(merged, results...)
+	}
+
+	return dedupeAnalysisResults(merged), nil
+}
+
+// bodyTokenBudget はプロンプト分を差し引いた本文のトークン上限を返します
+// 上限は環境変数 OPENAI_MAX_INPUT_TOKENS で変更できます。プロンプトだけで上限に達する場合はどの本文も送れないためエラーとします。
+func (u *UseCase) bodyTokenBudget(prompt string) (int, error) {
+	maxTokens := DefaultMaxInputTokens
+	if v, err := strconv.Atoi(u.os.GetEnv("OPENAI_MAX_INPUT_TOKENS")); err == nil && v > 0 {
+		maxTokens = v
+	}
+
+	promptTokens := textchunk.EstimateTokens(prompt)
+	if promptTokens >= maxTokens {
+		return 0, fmt.Errorf("プロンプト（約%dトークン）が入力トークンの上限 %d に収まりません。OPENAI_MAX_INPUT_TOKENS を見直してください", promptTokens, maxTokens)
+	}
+	return maxTokens - promptTokens, nil
+}
+
+// dedupeAnalysisResults はチャンクをまたいで重複した案件を除外します
+// 区分・案件名・単価・勤務場所が一致するものを同一案件とみなします。
+func dedupeAnalysisResults(results []cd.AnalysisResult) []cd.AnalysisResult {
+	seen := make(map[string]struct{}, len(results))
+	var deduped []cd.AnalysisResult
+	for _, result := range results {
+		key := analysisResultKey(result)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		deduped = append(deduped, result)
+	}
+	return deduped
+}
+
+// analysisResultKey は重複判定用のキーを生成します
+func analysisResultKey(result cd.AnalysisResult) string {
+	normalize := func(s string) string {
+		return strings.ToLower(strings.Join(strings.Fields(s), ""))
+	}
+	price := func(p *int) string {
+		if p == nil {
+			return ""
+		}
+		return strconv.Itoa(*p)
+	}
+	return strings.Join([]string{
+		result.MailCategory,
+		normalize(result.ProjectTitle),
+		price(result.PriceFrom),
+		price(result.PriceTo),
+		normalize(result.WorkLocation),
+	}, "|")
+}
+
+// restoreAnalysisResults は解析結果の文字列項目に含まれる伏せ字を元の値に戻します
+func restoreAnalysisResults(mapping *redact.Mapping, results []cd.AnalysisResult) []cd.AnalysisResult {
+	if mapping.Len() == 0 {
+		return results
+	}
+
+	restorePtr := func(s *string) *string {
+		if s == nil {
+			return nil
+		}
+		v := mapping.Restore(*s)
+		return &v
+	}
+
+	restored := make([]cd.AnalysisResult, len(results))
+	for i, result := range results {
+		result.ProjectTitle = mapping.Restore(result.ProjectTitle)
+		result.StartPeriod = mapping.RestoreAll(result.StartPeriod)
+		result.EndPeriod = mapping.Restore(result.EndPeriod)
+		result.WorkLocation = mapping.Restore(result.WorkLocation)
+		result.Languages = mapping.RestoreAll(result.Languages)
+		result.Frameworks = mapping.RestoreAll(result.Frameworks)
+		result.Positions = mapping.RestoreAll(result.Positions)
+		result.WorkTypes = mapping.RestoreAll(result.WorkTypes)
+		result.RequiredSkillsMust = mapping.RestoreAll(result.RequiredSkillsMust)
+		result.RequiredSkillsWant = mapping.RestoreAll(result.RequiredSkillsWant)
+		result.RemoteWorkCategory = restorePtr(result.RemoteWorkCategory)
+		result.RemoteWorkFrequency = restorePtr(result.RemoteWorkFrequency)
+		if result.Evidences != nil {
+			evidences := make([]cd.FieldEvidence, len(result.Evidences))
+			for j, evidence := range result.Evidences {
+				evidence.Quote = mapping.Restore(evidence.Quote)
+				evidences[j] = evidence
+			}
+			result.Evidences = evidences
+		}
+		restored[i] = result
+	}
+	return restored
+}
+
 // convertToStructs は引数を結合して保存する形式へ詰め替えます。
 func convertToStructs(message cd.BasicMessage, analysisResults []cd.AnalysisResult) []cd.Email {
 	var results []cd.Email
@@ -77,6 +262,7 @@ func convertToStructs(message cd.BasicMessage, analysisResults []cd.AnalysisResu
 	for _, analysisResult := range analysisResults {
 		result := cd.Email{
 			GmailID:             message.ID,
+			ThreadID:            message.ThreadID,
 			ReceivedDate:        message.Date,
 			Summary:             analysisResult.ProjectTitle,
 			Subject:             message.Subject,
@@ -98,6 +284,7 @@ func convertToStructs(message cd.BasicMessage, analysisResults []cd.AnalysisResu
 			RequiredSkillsWant:  analysisResult.RequiredSkillsWant,
 			RemoteWorkCategory:  analysisResult.RemoteWorkCategory,
 			RemoteWorkFrequency: analysisResult.RemoteWorkFrequency,
+			Evidences:           analysisResult.Evidences,
 		}
 		results = append(results, result)
 	}
@@ -1,9 +1,11 @@
 package application
 
 import (
+	ad "business/internal/agency/domain"
 	cd "business/internal/common/domain"
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -65,6 +67,10 @@ func TestAnalyzeEmailContent_Success(t *testing.T) {
 			RequiredSkillsWant:  []string{"AWS", "Kubernetes"},
 			RemoteWorkCategory:  lo.ToPtr("フルリモート"),
 			RemoteWorkFrequency: lo.ToPtr("週5日"),
+			Evidences: []cd.FieldEvidence{
+				{Field: cd.EvidenceFieldPriceFrom, Confidence: 0.9, Quote: "単価:50～60万"},
+			},
+			AnalysisVersion: DefaultAnalysisVersion,
 		},
 	}
 
@@ -85,6 +91,9 @@ func TestAnalyzeEmailContent_Success(t *testing.T) {
 			RequiredSkillsWant:  []string{"AWS", "Kubernetes"},
 			RemoteWorkCategory:  lo.ToPtr("フルリモート"),
 			RemoteWorkFrequency: lo.ToPtr("週5日"),
+			Evidences: []cd.FieldEvidence{
+				{Field: cd.EvidenceFieldPriceFrom, Confidence: 0.9, Quote: "単価:50～60万"},
+			},
 		},
 	}
 
@@ -140,3 +149,284 @@ func TestAnalyzeEmailContent_ReadFileError(t *testing.T) {
 	assert.Nil(t, results)
 	assert.EqualError(t, err, "read error")
 }
+
+func TestAnalyzeEmailContent_PromptExceedsMaxInputTokens(t *testing.T) {
+	ctx := context.Background()
+
+	mockOS := &mockOsWrapper{
+		ReadFileFunc: func(path string) (string, error) {
+			return "長い解析プロンプトです", nil
+		},
+		GetEnvFunc: func(key string) string {
+			if key == "OPENAI_MAX_INPUT_TOKENS" {
+				return "5"
+			}
+			return ""
+		},
+	}
+
+	mockAnalyzer := new(mockAnalyzer)
+	usecase := New(mockAnalyzer, mockOS)
+
+	input := []cd.BasicMessage{{ID: "test-email-id-1", Body: "■案件1\nGo開発の案件です"}}
+	results, err := usecase.AnalyzeEmailContent(ctx, input)
+
+	// プロンプトだけで上限を超える場合は送信せずにエラーを返すこと
+	assert.Nil(t, results)
+	assert.ErrorContains(t, err, "入力トークンの上限 5")
+	mockAnalyzer.AssertNotCalled(t, "AnalyzeEmailBody", mock.Anything, mock.Anything)
+}
+
+func TestAnalyzeEmailContent_SplitsLongBody(t *testing.T) {
+	ctx := context.Background()
+
+	mockOS := &mockOsWrapper{
+		ReadFileFunc: func(path string) (string, error) {
+			return "PROMPT", nil
+		},
+		GetEnvFunc: func(key string) string {
+			if key == "OPENAI_MAX_INPUT_TOKENS" {
+				return "20"
+			}
+			return ""
+		},
+	}
+
+	goProject := cd.AnalysisResult{MailCategory: "案件", ProjectTitle: "Go開発", PriceFrom: lo.ToPtr(600000)}
+	phpProject := cd.AnalysisResult{MailCategory: "案件", ProjectTitle: "PHP開発"}
+
+	mockAnalyzer := new(mockAnalyzer)
+	// 1チャンク目と3チャンク目の両方にGo開発が含まれている
+	mockAnalyzer.On("AnalyzeEmailBody", ctx, "PROMPT\n\n■案件1\nGo開発の案件です").
+		Return([]cd.AnalysisResult{goProject}, nil)
+	mockAnalyzer.On("AnalyzeEmailBody", ctx, "PROMPT\n\n■案件2\nPHP開発の案件です").
+		Return([]cd.AnalysisResult{phpProject}, nil)
+	mockAnalyzer.On("AnalyzeEmailBody", ctx, "PROMPT\n\n■案件3\nGo 開発").
+		Return([]cd.AnalysisResult{{MailCategory: "案件", ProjectTitle: "Go 開発", PriceFrom: lo.ToPtr(600000)}}, nil)
+	usecase := New(mockAnalyzer, mockOS)
+
+	input := []cd.BasicMessage{
+		{
+			ID:   "test-email-id-1",
+			Body: "■案件1\nGo開発の案件です\n■案件2\nPHP開発の案件です\n■案件3\nGo 開発",
+		},
+	}
+	actual, err := usecase.AnalyzeEmailContent(ctx, input)
+
+	assert.NoError(t, err)
+	assert.Len(t, actual, 2)
+	assert.Equal(t, "Go開発", actual[0].ProjectName)
+	assert.Equal(t, "PHP開発", actual[1].ProjectName)
+	mockAnalyzer.AssertExpectations(t)
+}
+
+func TestAnalyzeEmailContent_ChunkError(t *testing.T) {
+	ctx := context.Background()
+
+	mockOS := &mockOsWrapper{
+		ReadFileFunc: func(path string) (string, error) {
+			return "PROMPT", nil
+		},
+		GetEnvFunc: func(key string) string {
+			if key == "OPENAI_MAX_INPUT_TOKENS" {
+				return "20"
+			}
+			return ""
+		},
+	}
+
+	mockAnalyzer := new(mockAnalyzer)
+	mockAnalyzer.On("AnalyzeEmailBody", ctx, "PROMPT\n\n■案件1\nGo開発の案件です").
+		Return([]cd.AnalysisResult{{MailCategory: "案件", ProjectTitle: "Go開発"}}, nil)
+	mockAnalyzer.On("AnalyzeEmailBody", ctx, "PROMPT\n\n■案件2\nPHP開発の案件です").
+		Return([]cd.AnalysisResult{}, errors.New("api error"))
+	usecase := New(mockAnalyzer, mockOS)
+
+	input := []cd.BasicMessage{
+		{
+			ID:   "test-email-id-1",
+			Body: "■案件1\nGo開発の案件です\n■案件2\nPHP開発の案件です",
+		},
+	}
+	actual, err := usecase.AnalyzeEmailContent(ctx, input)
+
+	// 一部のチャンクが失敗したメールは保存対象から外す
+	assert.NoError(t, err)
+	assert.Empty(t, actual)
+	mockAnalyzer.AssertExpectations(t)
+}
+
+func TestAnalyzeEmailContent_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	mockOS := &mockOsWrapper{
+		ReadFileFunc: func(path string) (string, error) {
+			return "PROMPT", nil
+		},
+		GetEnvFunc: func(key string) string {
+			return ""
+		},
+	}
+
+	mockAnalyzer := new(mockAnalyzer)
+	usecase := New(mockAnalyzer, mockOS)
+
+	input := []cd.BasicMessage{
+		{ID: "test-email-id-1", Body: "Go開発の案件です"},
+		{ID: "test-email-id-2", Body: "PHP開発の案件です"},
+	}
+	actual, err := usecase.AnalyzeEmailContent(ctx, input)
+
+	// キャンセル後のメールはAPIへ送らない
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, actual)
+	mockAnalyzer.AssertNotCalled(t, "AnalyzeEmailBody", mock.Anything, mock.Anything)
+}
+
+func TestAnalyzeEmailContent_RedactsPII(t *testing.T) {
+	ctx := context.Background()
+
+	mockOS := &mockOsWrapper{
+		ReadFileFunc: func(path string) (string, error) {
+			return "PROMPT", nil
+		},
+		GetEnvFunc: func(key string) string {
+			return ""
+		},
+	}
+
+	mockAnalyzer := new(mockAnalyzer)
+	// OpenAIへは伏せ字にした本文が送信される
+	mockAnalyzer.On("AnalyzeEmailBody", ctx, "PROMPT\n\n要員のご提案です。\n氏名：[NAME_1]\n連絡先：[EMAIL_1] / [PHONE_1]").
+		Return([]cd.AnalysisResult{
+			{
+				MailCategory: "人材",
+				ProjectTitle: "[NAME_1]さんのご提案",
+				Evidences:    []cd.FieldEvidence{{Field: cd.EvidenceFieldProjectTitle, Confidence: 0.8, Quote: "氏名：[NAME_1]"}},
+			},
+		}, nil)
+	usecase := New(mockAnalyzer, mockOS)
+
+	body := "要員のご提案です。\n氏名：山田 太郎\n連絡先：yamada@example.com / 090-1234-5678"
+	input := []cd.BasicMessage{{ID: "test-email-id-1", Body: body}}
+	actual, err := usecase.AnalyzeEmailContent(ctx, input)
+
+	// 解析結果と保存する本文は元の値に戻っている
+	assert.NoError(t, err)
+	assert.Len(t, actual, 1)
+	assert.Equal(t, "山田 太郎さんのご提案", actual[0].ProjectName)
+	assert.Equal(t, "氏名：山田 太郎", actual[0].Evidences[0].Quote)
+	assert.Equal(t, body, actual[0].Body)
+	mockAnalyzer.AssertExpectations(t)
+}
+
+func TestAnalyzeEmailContent_RedactionDisabled(t *testing.T) {
+	ctx := context.Background()
+
+	mockOS := &mockOsWrapper{
+		ReadFileFunc: func(path string) (string, error) {
+			return "PROMPT", nil
+		},
+		GetEnvFunc: func(key string) string {
+			if key == "PII_REDACTION" {
+				return "none"
+			}
+			return ""
+		},
+	}
+
+	mockAnalyzer := new(mockAnalyzer)
+	mockAnalyzer.On("AnalyzeEmailBody", ctx, "PROMPT\n\n連絡先：yamada@example.com").
+		Return([]cd.AnalysisResult{{MailCategory: "案件"}}, nil)
+	usecase := New(mockAnalyzer, mockOS)
+
+	input := []cd.BasicMessage{{ID: "test-email-id-1", Body: "連絡先：yamada@example.com"}}
+	_, err := usecase.AnalyzeEmailContent(ctx, input)
+
+	assert.NoError(t, err)
+	mockAnalyzer.AssertExpectations(t)
+}
+
+func TestAnalyzeEmailContent_PrioritizedFirst(t *testing.T) {
+	ctx := context.Background()
+
+	mockOS := &mockOsWrapper{
+		ReadFileFunc: func(path string) (string, error) {
+			return "PROMPT", nil
+		},
+		GetEnvFunc: func(key string) string {
+			if key == "OPENAI_CONCURRENCY" {
+				return "1"
+			}
+			return ""
+		},
+	}
+
+	var analyzed []string
+	mockAnalyzer := new(mockAnalyzer)
+	mockAnalyzer.On("AnalyzeEmailBody", ctx, mock.Anything).Run(func(args mock.Arguments) {
+		analyzed = append(analyzed, args.String(1))
+	}).Return([]cd.AnalysisResult{{MailCategory: "案件"}}, nil)
+	usecase := New(mockAnalyzer, mockOS)
+
+	// 優先する営業会社のメールは、受信順で後ろでも先に解析される
+	messages := []cd.BasicMessage{
+		{ID: "gmail-1", From: "a@other.example.com", Body: "本文1"},
+		{ID: "gmail-2", From: "b@other.example.com", Body: "本文2"},
+		{ID: "gmail-3", From: "営業 <c@partner.example.com>", Body: "本文3"},
+	}
+	kept, _ := ad.ApplyRules(messages, map[string]ad.Rule{"partner.example.com": ad.RulePrioritize})
+	actual, err := usecase.AnalyzeEmailContent(ctx, kept)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"PROMPT\n\n本文3", "PROMPT\n\n本文1", "PROMPT\n\n本文2"}, analyzed)
+	assert.Equal(t, []string{"gmail-3", "gmail-1", "gmail-2"}, lo.Map(actual, func(e cd.Email, _ int) string { return e.GmailID }))
+}
+
+func TestAnalyzeEmailContent_KeepsOrderConcurrently(t *testing.T) {
+	ctx := context.Background()
+
+	mockOS := &mockOsWrapper{
+		ReadFileFunc: func(path string) (string, error) {
+			return "PROMPT", nil
+		},
+		GetEnvFunc: func(key string) string {
+			return ""
+		},
+	}
+
+	mockAnalyzer := new(mockAnalyzer)
+	mockAnalyzer.On("AnalyzeEmailBody", ctx, mock.Anything).Return([]cd.AnalysisResult{{MailCategory: "案件"}}, nil)
+	usecase := New(mockAnalyzer, mockOS)
+
+	// 同時に解析しても解析結果はメールの順に返す
+	var messages []cd.BasicMessage
+	var want []string
+	for i := range 20 {
+		id := fmt.Sprintf("gmail-%d", i)
+		messages = append(messages, cd.BasicMessage{ID: id, Body: id})
+		want = append(want, id)
+	}
+	actual, err := usecase.AnalyzeEmailContent(ctx, messages)
+
+	assert.NoError(t, err)
+	assert.Equal(t, want, lo.Map(actual, func(e cd.Email, _ int) string { return e.GmailID }))
+	assert.Equal(t, DefaultConcurrency, usecase.Concurrency())
+}
+
+func TestAnalysisVersion(t *testing.T) {
+	env := ""
+	mockOS := &mockOsWrapper{
+		GetEnvFunc: func(key string) string {
+			assert.Equal(t, "ANALYSIS_VERSION", key)
+			return env
+		},
+	}
+	usecase := New(new(mockAnalyzer), mockOS)
+
+	assert.Equal(t, DefaultAnalysisVersion, usecase.AnalysisVersion())
+
+	env = "v2-2025-07"
+	assert.Equal(t, "v2-2025-07", usecase.AnalysisVersion())
+}
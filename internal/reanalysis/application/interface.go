@@ -0,0 +1,23 @@
+// Package application は再解析機能のアプリケーション層を提供します。
+// このファイルは再解析のユースケースインターフェースを定義します。
+package application
+
+import (
+	"business/internal/reanalysis/domain"
+	"context"
+)
+
+// UseCaseInterface は再解析のユースケースインターフェースです
+type UseCaseInterface interface {
+	// Reanalyze は条件に一致する保存済みメールを再解析し、新しいリビジョンとして保存します
+	Reanalyze(ctx context.Context, cond domain.Condition) (domain.Summary, error)
+
+	// ListRevisions はGメールIDの解析リビジョン一覧を返します
+	ListRevisions(gmailID string) ([]domain.Revision, error)
+
+	// PromoteRevision は指定したリビジョンをemails等に反映し採用中にします
+	PromoteRevision(gmailID string, revision uint) error
+
+	// RevertRevision は採用中のひとつ前のリビジョンに戻します
+	RevertRevision(gmailID string) (domain.Revision, error)
+}
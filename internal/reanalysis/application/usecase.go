@@ -0,0 +1,203 @@
+// Package application は再解析機能のアプリケーション層を提供します。
+// このファイルは保存済みメールの再解析とリビジョン管理のユースケースを実装します。
+package application
+
+import (
+	cd "business/internal/common/domain"
+	ea "business/internal/emailstore/application"
+	aiapp "business/internal/openAi/application"
+	"business/internal/reanalysis/domain"
+	r "business/internal/reanalysis/infrastructure"
+	"context"
+	"errors"
+	"fmt"
+)
+
+// UseCase は再解析のユースケースの具象です
+type UseCase struct {
+	r     r.RepositoryInterface
+	aiapp aiapp.UseCaseInterface
+	ea    ea.UseCaseInterface
+}
+
+// New は再解析ユースケースを作成します
+func New(r r.RepositoryInterface, aiapp aiapp.UseCaseInterface, ea ea.UseCaseInterface) *UseCase {
+	return &UseCase{
+		r:     r,
+		aiapp: aiapp,
+		ea:    ea,
+	}
+}
+
+// Reanalyze は条件に一致する保存済みメールを本文から再解析し、新しいリビジョンとして保存します
+// 初めて再解析するメールは、現在の保存内容をリビジョンとして退避してから再解析します。
+// cond.Promote が true の場合は作成したリビジョンを即時に採用します。
+func (u *UseCase) Reanalyze(ctx context.Context, cond domain.Condition) (domain.Summary, error) {
+	summary := domain.Summary{Revisions: []domain.Revision{}, Skipped: []string{}}
+
+	emails, err := u.r.FindEmails(cond)
+	if err != nil {
+		return summary, err
+	}
+	summary.Targets = len(emails)
+	if len(emails) == 0 {
+		return summary, nil
+	}
+
+	messages := make([]cd.BasicMessage, 0, len(emails))
+	for _, email := range emails {
+		if err := u.snapshotCurrent(email); err != nil {
+			return summary, err
+		}
+		messages = append(messages, email.ToBasicMessage())
+	}
+
+	results, err := u.aiapp.AnalyzeEmailContent(ctx, messages)
+	if err != nil {
+		return summary, fmt.Errorf("再解析エラー: %w", err)
+	}
+
+	resultsByGmailID := make(map[string][]cd.Email, len(emails))
+	for _, result := range results {
+		resultsByGmailID[result.GmailID] = append(resultsByGmailID[result.GmailID], result)
+	}
+
+	version := u.aiapp.AnalysisVersion()
+	for _, email := range emails {
+		emailResults, ok := resultsByGmailID[email.GmailID]
+		if !ok {
+			// 解析に失敗したメールは現在の保存内容を維持する
+			summary.Skipped = append(summary.Skipped, email.GmailID)
+			continue
+		}
+
+		revision, err := u.r.CreateRevision(email.GmailID, version, emailResults, false)
+		if err != nil {
+			return summary, err
+		}
+		summary.Revisions = append(summary.Revisions, revision)
+
+		if cond.Promote {
+			if err := u.promote(email, revision); err != nil {
+				return summary, err
+			}
+			summary.Promoted++
+		}
+	}
+
+	return summary, nil
+}
+
+// ListRevisions はGメールIDの解析リビジョン一覧を返します
+func (u *UseCase) ListRevisions(gmailID string) ([]domain.Revision, error) {
+	return u.r.ListRevisions(gmailID)
+}
+
+// PromoteRevision は指定したリビジョンをemails等に反映し採用中にします
+func (u *UseCase) PromoteRevision(gmailID string, revision uint) error {
+	email, err := u.findEmail(gmailID)
+	if err != nil {
+		return err
+	}
+
+	rev, err := u.r.GetRevision(gmailID, revision)
+	if err != nil {
+		return err
+	}
+
+	return u.promote(email, rev)
+}
+
+// RevertRevision は採用中のひとつ前のリビジョンに戻し、戻したリビジョンを返します
+func (u *UseCase) RevertRevision(gmailID string) (domain.Revision, error) {
+	email, err := u.findEmail(gmailID)
+	if err != nil {
+		return domain.Revision{}, err
+	}
+
+	revisions, err := u.r.ListRevisions(gmailID)
+	if err != nil {
+		return domain.Revision{}, err
+	}
+
+	var target *domain.Revision
+	for i := range revisions {
+		if revisions[i].Revision >= email.AnalysisRevision {
+			break
+		}
+		target = &revisions[i]
+	}
+	if target == nil {
+		return domain.Revision{}, domain.ErrNoRevisionToRevert
+	}
+
+	if err := u.promote(email, *target); err != nil {
+		return domain.Revision{}, err
+	}
+	return *target, nil
+}
+
+// snapshotCurrent はリビジョンが未作成のメールの現在の保存内容をリビジョンとして退避します
+func (u *UseCase) snapshotCurrent(email domain.StoredEmail) error {
+	revisions, err := u.r.ListRevisions(email.GmailID)
+	if err != nil {
+		return err
+	}
+	if len(revisions) > 0 {
+		return nil
+	}
+
+	current, err := u.r.LoadCurrentResults(email.GmailID)
+	if err != nil {
+		return err
+	}
+	if _, err := u.r.CreateRevision(email.GmailID, email.AnalysisVersion, current, true); err != nil {
+		return err
+	}
+	return nil
+}
+
+// promote はリビジョンの解析結果でemails等を置き換え、採用中にします
+// 置き換えと採用は別のトランザクションのため、採用に失敗した場合は domain.ActivateAttempts 回まで繰り返します。
+// 採用は何度実行しても同じ結果になるため、失敗した場合も同じリビジョンを採用し直せば揃います。
+func (u *UseCase) promote(email domain.StoredEmail, revision domain.Revision) error {
+	results := make([]cd.Email, len(revision.Results))
+	for i, result := range revision.Results {
+		// 履歴は本文を持たないため保存済みの本文を使う
+		result.Body = email.Body
+		result.AnalysisRevision = revision.Revision
+		results[i] = result
+	}
+
+	if err := u.ea.ReplaceEmailAnalysisResults(email.GmailID, results); err != nil {
+		return fmt.Errorf("リビジョン反映エラー: %w", err)
+	}
+	var err error
+	for i := 0; i < domain.ActivateAttempts; i++ {
+		err = u.r.ActivateRevision(email.GmailID, revision.Revision)
+		if err == nil || errors.Is(err, domain.ErrRevisionNotFound) {
+			break
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("リビジョン採用エラー（emails等はリビジョン%dに置き換え済みのため、再度採用してください）: %w", revision.Revision, err)
+	}
+	return nil
+}
+
+// findEmail はGメールIDの保存済みメールを取得します
+func (u *UseCase) findEmail(gmailID string) (domain.StoredEmail, error) {
+	emails, err := u.r.FindEmails(domain.Condition{GmailIDs: []string{gmailID}, Limit: 1})
+	if err != nil {
+		return domain.StoredEmail{}, err
+	}
+	if len(emails) == 0 {
+		return domain.StoredEmail{}, domain.ErrEmailNotFound
+	}
+	return emails[0], nil
+}
+
+// IsNotFound は対象が存在しないことを表すエラーか判定します
+func IsNotFound(err error) bool {
+	return errors.Is(err, domain.ErrEmailNotFound) || errors.Is(err, domain.ErrRevisionNotFound) || errors.Is(err, domain.ErrNoRevisionToRevert)
+}
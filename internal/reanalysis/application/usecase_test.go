@@ -0,0 +1,308 @@
+package application
+
+import (
+	cd "business/internal/common/domain"
+	aiapp "business/internal/openAi/application"
+	"business/internal/reanalysis/domain"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockRepository は再解析リポジトリのモックです
+type MockRepository struct {
+	mock.Mock
+}
+
+func (m *MockRepository) FindEmails(cond domain.Condition) ([]domain.StoredEmail, error) {
+	args := m.Called(cond)
+	return args.Get(0).([]domain.StoredEmail), args.Error(1)
+}
+
+func (m *MockRepository) LoadCurrentResults(gmailID string) ([]cd.Email, error) {
+	args := m.Called(gmailID)
+	return args.Get(0).([]cd.Email), args.Error(1)
+}
+
+func (m *MockRepository) CreateRevision(gmailID string, version string, results []cd.Email, isActive bool) (domain.Revision, error) {
+	args := m.Called(gmailID, version, results, isActive)
+	return args.Get(0).(domain.Revision), args.Error(1)
+}
+
+func (m *MockRepository) ListRevisions(gmailID string) ([]domain.Revision, error) {
+	args := m.Called(gmailID)
+	return args.Get(0).([]domain.Revision), args.Error(1)
+}
+
+func (m *MockRepository) GetRevision(gmailID string, revision uint) (domain.Revision, error) {
+	args := m.Called(gmailID, revision)
+	return args.Get(0).(domain.Revision), args.Error(1)
+}
+
+func (m *MockRepository) ActivateRevision(gmailID string, revision uint) error {
+	args := m.Called(gmailID, revision)
+	return args.Error(0)
+}
+
+// MockAnalyzer はメール分析ユースケースのモックです
+type MockAnalyzer struct {
+	mock.Mock
+}
+
+func (m *MockAnalyzer) AnalyzeEmailContent(ctx context.Context, emails []cd.BasicMessage) ([]cd.Email, error) {
+	args := m.Called(ctx, emails)
+	return args.Get(0).([]cd.Email), args.Error(1)
+}
+
+func (m *MockAnalyzer) AnalysisVersion() string {
+	args := m.Called()
+	return args.String(0)
+}
+
+func (m *MockAnalyzer) BuildBatchPrompts(emails []cd.BasicMessage) ([]aiapp.BatchPrompt, error) {
+	args := m.Called(emails)
+	return args.Get(0).([]aiapp.BatchPrompt), args.Error(1)
+}
+
+func (m *MockAnalyzer) ConvertBatchResults(email cd.BasicMessage, chunkResults [][]cd.AnalysisResult) []cd.Email {
+	args := m.Called(email, chunkResults)
+	return args.Get(0).([]cd.Email)
+}
+
+// MockEmailStore はメール保存ユースケースのモックです
+type MockEmailStore struct {
+	mock.Mock
+}
+
+func (m *MockEmailStore) SaveEmailAnalysisResult(result cd.Email) error {
+	args := m.Called(result)
+	return args.Error(0)
+}
+
+func (m *MockEmailStore) SaveEmailAnalysisResults(results []cd.Email) (int, error) {
+	args := m.Called(results)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockEmailStore) GetEmailByGmailIds(gmailIds []string) ([]string, error) {
+	args := m.Called(gmailIds)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockEmailStore) ReplaceEmailAnalysisResults(gmailID string, results []cd.Email) error {
+	args := m.Called(gmailID, results)
+	return args.Error(0)
+}
+
+func storedEmail(gmailID string, revision uint) domain.StoredEmail {
+	return domain.StoredEmail{
+		GmailID:          gmailID,
+		Subject:          "件名",
+		SenderName:       "営業 太郎",
+		SenderEmail:      "sales@example.com",
+		ReceivedDate:     time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC),
+		Body:             "本文",
+		AnalysisVersion:  "v1",
+		AnalysisRevision: revision,
+	}
+}
+
+func TestReanalyze_CreatesRevisionAndSnapshot(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(MockRepository)
+	mockAnalyzer := new(MockAnalyzer)
+	mockStore := new(MockEmailStore)
+	usecase := New(mockRepo, mockAnalyzer, mockStore)
+
+	cond := domain.Condition{Category: "案件", AnalysisVersion: "v1"}
+	email := storedEmail("gmail-1", 1)
+	current := []cd.Email{{GmailID: "gmail-1", Category: "案件", Summary: "旧案件名"}}
+	reanalyzed := []cd.Email{{GmailID: "gmail-1", Category: "案件", Summary: "新案件名", AnalysisVersion: "v2"}}
+
+	mockRepo.On("FindEmails", cond).Return([]domain.StoredEmail{email}, nil)
+	// リビジョン未作成のため現在の保存内容を退避する
+	mockRepo.On("ListRevisions", "gmail-1").Return([]domain.Revision{}, nil)
+	mockRepo.On("LoadCurrentResults", "gmail-1").Return(current, nil)
+	mockRepo.On("CreateRevision", "gmail-1", "v1", current, true).Return(domain.Revision{GmailID: "gmail-1", Revision: 1, IsActive: true}, nil)
+	mockAnalyzer.On("AnalyzeEmailContent", ctx, []cd.BasicMessage{email.ToBasicMessage()}).Return(reanalyzed, nil)
+	mockAnalyzer.On("AnalysisVersion").Return("v2")
+	mockRepo.On("CreateRevision", "gmail-1", "v2", reanalyzed, false).Return(domain.Revision{GmailID: "gmail-1", Revision: 2}, nil)
+
+	summary, err := usecase.Reanalyze(ctx, cond)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, summary.Targets)
+	assert.Len(t, summary.Revisions, 1)
+	assert.Equal(t, uint(2), summary.Revisions[0].Revision)
+	assert.Equal(t, 0, summary.Promoted)
+	mockRepo.AssertExpectations(t)
+	mockAnalyzer.AssertExpectations(t)
+	mockStore.AssertNotCalled(t, "ReplaceEmailAnalysisResults", mock.Anything, mock.Anything)
+}
+
+func TestReanalyze_Promote(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(MockRepository)
+	mockAnalyzer := new(MockAnalyzer)
+	mockStore := new(MockEmailStore)
+	usecase := New(mockRepo, mockAnalyzer, mockStore)
+
+	cond := domain.Condition{GmailIDs: []string{"gmail-1", "gmail-2"}, Promote: true}
+	email1 := storedEmail("gmail-1", 2)
+	email2 := storedEmail("gmail-2", 1)
+	reanalyzed := []cd.Email{{GmailID: "gmail-1", Category: "案件", Summary: "新案件名"}}
+
+	mockRepo.On("FindEmails", cond).Return([]domain.StoredEmail{email1, email2}, nil)
+	mockRepo.On("ListRevisions", mock.Anything).Return([]domain.Revision{{Revision: 1}}, nil)
+	mockAnalyzer.On("AnalyzeEmailContent", ctx, mock.Anything).Return(reanalyzed, nil)
+	mockAnalyzer.On("AnalysisVersion").Return("v2")
+	mockRepo.On("CreateRevision", "gmail-1", "v2", reanalyzed, false).Return(domain.Revision{GmailID: "gmail-1", Revision: 3, Results: reanalyzed}, nil)
+	// 採用時は保存済みの本文とリビジョン番号を付与して置き換える
+	mockStore.On("ReplaceEmailAnalysisResults", "gmail-1", []cd.Email{{GmailID: "gmail-1", Category: "案件", Summary: "新案件名", Body: "本文", AnalysisRevision: 3}}).Return(nil)
+	mockRepo.On("ActivateRevision", "gmail-1", uint(3)).Return(nil)
+
+	summary, err := usecase.Reanalyze(ctx, cond)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, summary.Targets)
+	assert.Equal(t, 1, summary.Promoted)
+	// 解析結果が得られなかったメールはスキップする
+	assert.Equal(t, []string{"gmail-2"}, summary.Skipped)
+	mockRepo.AssertExpectations(t)
+	mockStore.AssertExpectations(t)
+}
+
+func TestReanalyze_NoTargets(t *testing.T) {
+	mockRepo := new(MockRepository)
+	usecase := New(mockRepo, new(MockAnalyzer), new(MockEmailStore))
+
+	mockRepo.On("FindEmails", domain.Condition{}).Return([]domain.StoredEmail{}, nil)
+
+	summary, err := usecase.Reanalyze(context.Background(), domain.Condition{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, summary.Targets)
+}
+
+func TestReanalyze_AnalyzeError(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(MockRepository)
+	mockAnalyzer := new(MockAnalyzer)
+	usecase := New(mockRepo, mockAnalyzer, new(MockEmailStore))
+
+	mockRepo.On("FindEmails", domain.Condition{}).Return([]domain.StoredEmail{storedEmail("gmail-1", 1)}, nil)
+	mockRepo.On("ListRevisions", "gmail-1").Return([]domain.Revision{{Revision: 1}}, nil)
+	mockAnalyzer.On("AnalyzeEmailContent", ctx, mock.Anything).Return([]cd.Email{}, errors.New("read error"))
+
+	_, err := usecase.Reanalyze(ctx, domain.Condition{})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "再解析エラー")
+}
+
+func TestPromoteRevision(t *testing.T) {
+	mockRepo := new(MockRepository)
+	mockStore := new(MockEmailStore)
+	usecase := New(mockRepo, new(MockAnalyzer), mockStore)
+
+	results := []cd.Email{{GmailID: "gmail-1", Summary: "案件名"}}
+	mockRepo.On("FindEmails", domain.Condition{GmailIDs: []string{"gmail-1"}, Limit: 1}).Return([]domain.StoredEmail{storedEmail("gmail-1", 1)}, nil)
+	mockRepo.On("GetRevision", "gmail-1", uint(2)).Return(domain.Revision{GmailID: "gmail-1", Revision: 2, Results: results}, nil)
+	mockStore.On("ReplaceEmailAnalysisResults", "gmail-1", []cd.Email{{GmailID: "gmail-1", Summary: "案件名", Body: "本文", AnalysisRevision: 2}}).Return(nil)
+	mockRepo.On("ActivateRevision", "gmail-1", uint(2)).Return(nil)
+
+	err := usecase.PromoteRevision("gmail-1", 2)
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+	mockStore.AssertExpectations(t)
+}
+
+func TestPromoteRevision_RetriesActivate(t *testing.T) {
+	mockRepo := new(MockRepository)
+	mockStore := new(MockEmailStore)
+	usecase := New(mockRepo, new(MockAnalyzer), mockStore)
+
+	results := []cd.Email{{GmailID: "gmail-1", Summary: "案件名"}}
+	mockRepo.On("FindEmails", mock.Anything).Return([]domain.StoredEmail{storedEmail("gmail-1", 1)}, nil)
+	mockRepo.On("GetRevision", "gmail-1", uint(2)).Return(domain.Revision{GmailID: "gmail-1", Revision: 2, Results: results}, nil)
+	mockStore.On("ReplaceEmailAnalysisResults", "gmail-1", mock.Anything).Return(nil).Once()
+	mockRepo.On("ActivateRevision", "gmail-1", uint(2)).Return(errors.New("deadlock")).Once()
+	mockRepo.On("ActivateRevision", "gmail-1", uint(2)).Return(nil).Once()
+
+	err := usecase.PromoteRevision("gmail-1", 2)
+
+	// 採用に一度失敗しても繰り返して採用する
+	assert.NoError(t, err)
+	mockRepo.AssertNumberOfCalls(t, "ActivateRevision", 2)
+	mockStore.AssertExpectations(t)
+}
+
+func TestPromoteRevision_ActivateError(t *testing.T) {
+	mockRepo := new(MockRepository)
+	mockStore := new(MockEmailStore)
+	usecase := New(mockRepo, new(MockAnalyzer), mockStore)
+
+	results := []cd.Email{{GmailID: "gmail-1", Summary: "案件名"}}
+	mockRepo.On("FindEmails", mock.Anything).Return([]domain.StoredEmail{storedEmail("gmail-1", 1)}, nil)
+	mockRepo.On("GetRevision", "gmail-1", uint(2)).Return(domain.Revision{GmailID: "gmail-1", Revision: 2, Results: results}, nil)
+	mockStore.On("ReplaceEmailAnalysisResults", "gmail-1", mock.Anything).Return(nil)
+	mockRepo.On("ActivateRevision", "gmail-1", uint(2)).Return(errors.New("deadlock"))
+
+	err := usecase.PromoteRevision("gmail-1", 2)
+
+	// 繰り返しても採用できない場合は成功として扱わない
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "リビジョン採用エラー")
+	mockRepo.AssertNumberOfCalls(t, "ActivateRevision", domain.ActivateAttempts)
+}
+
+func TestPromoteRevision_EmailNotFound(t *testing.T) {
+	mockRepo := new(MockRepository)
+	usecase := New(mockRepo, new(MockAnalyzer), new(MockEmailStore))
+
+	mockRepo.On("FindEmails", mock.Anything).Return([]domain.StoredEmail{}, nil)
+
+	err := usecase.PromoteRevision("gmail-x", 1)
+
+	assert.ErrorIs(t, err, domain.ErrEmailNotFound)
+	assert.True(t, IsNotFound(err))
+}
+
+func TestRevertRevision(t *testing.T) {
+	mockRepo := new(MockRepository)
+	mockStore := new(MockEmailStore)
+	usecase := New(mockRepo, new(MockAnalyzer), mockStore)
+
+	revisions := []domain.Revision{
+		{GmailID: "gmail-1", Revision: 1, Results: []cd.Email{{GmailID: "gmail-1", Summary: "初回"}}},
+		{GmailID: "gmail-1", Revision: 2, Results: []cd.Email{{GmailID: "gmail-1", Summary: "2回目"}}},
+		{GmailID: "gmail-1", Revision: 3, Results: []cd.Email{{GmailID: "gmail-1", Summary: "3回目"}}, IsActive: true},
+	}
+	mockRepo.On("FindEmails", mock.Anything).Return([]domain.StoredEmail{storedEmail("gmail-1", 3)}, nil)
+	mockRepo.On("ListRevisions", "gmail-1").Return(revisions, nil)
+	mockStore.On("ReplaceEmailAnalysisResults", "gmail-1", []cd.Email{{GmailID: "gmail-1", Summary: "2回目", Body: "本文", AnalysisRevision: 2}}).Return(nil)
+	mockRepo.On("ActivateRevision", "gmail-1", uint(2)).Return(nil)
+
+	actual, err := usecase.RevertRevision("gmail-1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(2), actual.Revision)
+	mockStore.AssertExpectations(t)
+}
+
+func TestRevertRevision_NoPrevious(t *testing.T) {
+	mockRepo := new(MockRepository)
+	usecase := New(mockRepo, new(MockAnalyzer), new(MockEmailStore))
+
+	mockRepo.On("FindEmails", mock.Anything).Return([]domain.StoredEmail{storedEmail("gmail-1", 1)}, nil)
+	mockRepo.On("ListRevisions", "gmail-1").Return([]domain.Revision{{Revision: 1, IsActive: true}}, nil)
+
+	_, err := usecase.RevertRevision("gmail-1")
+
+	assert.ErrorIs(t, err, domain.ErrNoRevisionToRevert)
+}
@@ -0,0 +1,78 @@
+// Package domain は再解析機能のドメイン層を提供します。
+// このファイルは再解析の対象条件と解析リビジョンのドメインモデルを定義します。
+package domain
+
+import (
+	cd "business/internal/common/domain"
+	"errors"
+	"time"
+)
+
+// ActivateAttempts はemails等に反映したリビジョンの採用を試みる回数です
+const ActivateAttempts = 3
+
+// Condition は再解析の対象とする保存済みメールの条件です
+type Condition struct {
+	From            *time.Time // 受信日FROM（この日時以降）
+	To              *time.Time // 受信日TO（この日時より前）
+	Category        string     // メール区分（案件 / 人材）
+	AnalysisVersion string     // 指定した解析バージョンで解析されたメールのみ対象
+	GmailIDs        []string   // 対象のGメールID
+	Limit           int        // 最大件数（0の場合は無制限）
+	Promote         bool       // 再解析結果を即時に採用するか
+}
+
+// StoredEmail は再解析の入力となる保存済みメールです
+type StoredEmail struct {
+	GmailID          string
+	ThreadID         string
+	Subject          string
+	SenderName       string
+	SenderEmail      string
+	ReceivedDate     time.Time
+	Body             string
+	AnalysisVersion  string
+	AnalysisRevision uint
+}
+
+// ToBasicMessage は保存済みメールを解析の入力形式に変換します
+func (s StoredEmail) ToBasicMessage() cd.BasicMessage {
+	from := s.SenderEmail
+	if s.SenderName != "" && s.SenderName != s.SenderEmail {
+		from = s.SenderName + " <" + s.SenderEmail + ">"
+	}
+	return cd.BasicMessage{
+		ID:       s.GmailID,
+		ThreadID: s.ThreadID,
+		Subject:  s.Subject,
+		From:     from,
+		Date:     s.ReceivedDate,
+		Body:     s.Body,
+	}
+}
+
+// Revision はGメールIDごとの解析結果の履歴です
+type Revision struct {
+	ID              uint       `json:"id"`
+	GmailID         string     `json:"gmail_id"`
+	Revision        uint       `json:"revision"`         // GメールID内で1から採番
+	AnalysisVersion string     `json:"analysis_version"` // 解析バージョン
+	Results         []cd.Email `json:"results"`          // 解析結果（本文は保持しない）
+	IsActive        bool       `json:"is_active"`        // emails等に反映中のリビジョンか
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+// Summary は再解析の実行結果です
+type Summary struct {
+	Targets   int        `json:"targets"`   // 対象メール数
+	Revisions []Revision `json:"revisions"` // 作成したリビジョン
+	Promoted  int        `json:"promoted"`  // 採用したリビジョン数
+	Skipped   []string   `json:"skipped"`   // 解析結果が得られなかったGメールID
+}
+
+// ドメインエラー
+var (
+	ErrRevisionNotFound   = errors.New("解析リビジョンが見つかりません")
+	ErrEmailNotFound      = errors.New("再解析対象のメールが見つかりません")
+	ErrNoRevisionToRevert = errors.New("巻き戻し先の解析リビジョンがありません")
+)
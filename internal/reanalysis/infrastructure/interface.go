@@ -0,0 +1,29 @@
+// Package infrastructure は再解析機能のインフラストラクチャ層を提供します。
+// このファイルは再解析で使用するリポジトリのインターフェースを定義します。
+package infrastructure
+
+import (
+	cd "business/internal/common/domain"
+	"business/internal/reanalysis/domain"
+)
+
+// RepositoryInterface は再解析のリポジトリインターフェースです
+type RepositoryInterface interface {
+	// FindEmails は条件に一致する保存済みメールをGメールID単位で取得します
+	FindEmails(cond domain.Condition) ([]domain.StoredEmail, error)
+
+	// LoadCurrentResults は現在emails等に保存されている解析結果を取得します
+	LoadCurrentResults(gmailID string) ([]cd.Email, error)
+
+	// CreateRevision は解析リビジョンを採番して保存します
+	CreateRevision(gmailID string, version string, results []cd.Email, isActive bool) (domain.Revision, error)
+
+	// ListRevisions はGメールIDの解析リビジョンをリビジョン番号順に取得します
+	ListRevisions(gmailID string) ([]domain.Revision, error)
+
+	// GetRevision は指定したリビジョンを取得します
+	GetRevision(gmailID string, revision uint) (domain.Revision, error)
+
+	// ActivateRevision は指定したリビジョンを採用中にし、それ以外を非採用にします
+	ActivateRevision(gmailID string, revision uint) error
+}
@@ -0,0 +1,82 @@
+// Package infrastructure は再解析機能のインフラストラクチャ層を提供します。
+// このファイルは再解析で参照・更新するテーブルのモデルを定義します。
+package infrastructure
+
+import (
+	"time"
+)
+
+// AnalysisRevision はGメールIDごとの解析結果の履歴を表すモデルです
+type AnalysisRevision struct {
+	ID              uint   `gorm:"primaryKey;autoIncrement"`
+	GmailID         string `gorm:"size:255;not null"`
+	Revision        uint   `gorm:"not null"`
+	AnalysisVersion string `gorm:"size:50"`
+	ResultJSON      string `gorm:"type:longtext;not null"`
+	IsActive        bool   `gorm:"not null;default:false"`
+	CreatedAt       time.Time
+}
+
+// emailRow は再解析の入力として参照するemailsの列です
+type emailRow struct {
+	ID               uint
+	GmailID          string
+	ThreadID         string
+	Subject          string
+	SenderName       string
+	SenderEmail      string
+	ReceivedDate     time.Time
+	Body             *string
+	Category         string
+	AnalysisVersion  string
+	AnalysisRevision uint
+	IsRead           bool
+	IsGood           bool
+	IsBad            bool
+}
+
+// projectRow は現在の解析結果を復元するために参照するemail_projectsの列です
+type projectRow struct {
+	ID              uint
+	EmailID         uint
+	ProjectTitle    *string
+	EntryTiming     *string
+	Languages       *string
+	Frameworks      *string
+	Positions       *string
+	WorkTypes       *string
+	MustSkills      *string
+	WantSkills      *string
+	EndTiming       *string
+	WorkLocation    *string
+	PriceFrom       *int
+	PriceTo         *int
+	RemoteType      *string
+	RemoteFrequency *string
+}
+
+// candidateRow は現在の解析結果を復元するために参照するemail_candidatesの列です
+type candidateRow struct {
+	ID               uint
+	CandidateName    *string
+	SkillsSummary    *string
+	AvailabilityDate *string
+	Skills           *string
+	MonthlyPriceFrom *int
+	MonthlyPriceTo   *int
+	WorkLocation     *string
+	RemoteType       *string
+}
+
+// evidenceRow は現在の解析結果を復元するために参照するemail_project_field_evidencesの列です
+type evidenceRow struct {
+	EmailProjectID uint
+	FieldName      string
+	Confidence     float64
+	SourceText     *string
+}
+
+// TableName はテーブル名を指定します
+func (AnalysisRevision) TableName() string {
+	return "analysis_revisions"
+}
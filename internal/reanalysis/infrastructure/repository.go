@@ -0,0 +1,353 @@
+// Package infrastructure は再解析機能のインフラストラクチャ層を提供します。
+// このファイルは解析リビジョンの保存と保存済みメールの取得を実装します。
+package infrastructure
+
+import (
+	cd "business/internal/common/domain"
+	"business/internal/reanalysis/domain"
+	"business/tools/keyword"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Repository は再解析のリポジトリ実装です
+type Repository struct {
+	db *gorm.DB
+}
+
+// New は再解析リポジトリを作成します
+func New(db *gorm.DB) *Repository {
+	return &Repository{
+		db: db,
+	}
+}
+
+// FindEmails は条件に一致する保存済みメールをGメールID単位で取得します
+func (r *Repository) FindEmails(cond domain.Condition) ([]domain.StoredEmail, error) {
+	query := r.db.Table("emails")
+	if cond.From != nil {
+		query = query.Where("received_date >= ?", *cond.From)
+	}
+	if cond.To != nil {
+		query = query.Where("received_date < ?", *cond.To)
+	}
+	if cond.Category != "" {
+		query = query.Where("category = ?", cond.Category)
+	}
+	if cond.AnalysisVersion != "" {
+		query = query.Where("analysis_version = ?", cond.AnalysisVersion)
+	}
+	if len(cond.GmailIDs) > 0 {
+		query = query.Where("gmail_id IN ?", cond.GmailIDs)
+	}
+	query = query.Order("id")
+	if cond.Limit > 0 {
+		query = query.Limit(cond.Limit)
+	}
+
+	var ids []uint
+	if err := query.Pluck("id", &ids).Error; err != nil {
+		return nil, fmt.Errorf("再解析対象メール検索エラー: %w", err)
+	}
+	if len(ids) == 0 {
+		return []domain.StoredEmail{}, nil
+	}
+
+	var rows []emailRow
+	if err := r.db.Table("emails").Where("id IN ?", ids).Order("received_date, id").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("再解析対象メール取得エラー: %w", err)
+	}
+
+	emails := make([]domain.StoredEmail, 0, len(rows))
+	for _, row := range rows {
+		body := ""
+		if row.Body != nil {
+			body = *row.Body
+		}
+		emails = append(emails, domain.StoredEmail{
+			GmailID:          row.GmailID,
+			ThreadID:         row.ThreadID,
+			Subject:          row.Subject,
+			SenderName:       row.SenderName,
+			SenderEmail:      row.SenderEmail,
+			ReceivedDate:     row.ReceivedDate,
+			Body:             body,
+			AnalysisVersion:  row.AnalysisVersion,
+			AnalysisRevision: row.AnalysisRevision,
+		})
+	}
+	return emails, nil
+}
+
+// LoadCurrentResults は現在emails等に保存されている解析結果を復元します
+// 1通に複数の案件がある場合は案件ごとに1件の解析結果を返します。本文は保持しないため空になります。
+// 人材メールは人材の詳細（email_candidates）とキーワードの紐付けを解析結果に戻します。
+func (r *Repository) LoadCurrentResults(gmailID string) ([]cd.Email, error) {
+	var email emailRow
+	err := r.db.Table("emails").Where("gmail_id = ?", gmailID).Take(&email).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrEmailNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("保存済みメール取得エラー: %w", err)
+	}
+
+	var projects []projectRow
+	if err := r.db.Table("email_projects").Where("email_id = ?", email.ID).Order("id").Find(&projects).Error; err != nil {
+		return nil, fmt.Errorf("保存済み案件取得エラー: %w", err)
+	}
+	projectIDs := make([]uint, 0, len(projects))
+	for _, p := range projects {
+		projectIDs = append(projectIDs, p.ID)
+	}
+
+	evidencesByProjectID := map[uint][]cd.FieldEvidence{}
+	if len(projectIDs) > 0 {
+		var evidences []evidenceRow
+		if err := r.db.Table("email_project_field_evidences").Where("email_project_id IN ?", projectIDs).Order("id").Find(&evidences).Error; err != nil {
+			return nil, fmt.Errorf("保存済み根拠取得エラー: %w", err)
+		}
+		for _, e := range evidences {
+			quote := ""
+			if e.SourceText != nil {
+				quote = *e.SourceText
+			}
+			evidencesByProjectID[e.EmailProjectID] = append(evidencesByProjectID[e.EmailProjectID], cd.FieldEvidence{
+				Field:      e.FieldName,
+				Confidence: e.Confidence,
+				Quote:      quote,
+			})
+		}
+	}
+
+	base := cd.Email{
+		GmailID:          email.GmailID,
+		ThreadID:         email.ThreadID,
+		ReceivedDate:     email.ReceivedDate,
+		Subject:          email.Subject,
+		From:             email.SenderName,
+		FromEmail:        email.SenderEmail,
+		Category:         email.Category,
+		IsRead:           email.IsRead,
+		IsGood:           email.IsGood,
+		IsBad:            email.IsBad,
+		AnalysisVersion:  email.AnalysisVersion,
+		AnalysisRevision: email.AnalysisRevision,
+	}
+	if email.SenderName != "" && email.SenderName != email.SenderEmail {
+		base.From = email.SenderName + " <" + email.SenderEmail + ">"
+	}
+	if err := r.loadCandidate(email.ID, &base); err != nil {
+		return nil, err
+	}
+	if len(projects) == 0 {
+		return []cd.Email{base}, nil
+	}
+
+	results := make([]cd.Email, 0, len(projects))
+	for _, p := range projects {
+		result := base
+		result.Summary = deref(p.ProjectTitle)
+		result.ProjectName = deref(p.ProjectTitle)
+		result.StartPeriod = splitList(p.EntryTiming)
+		result.EndPeriod = deref(p.EndTiming)
+		result.WorkLocation = deref(p.WorkLocation)
+		result.PriceFrom = p.PriceFrom
+		result.PriceTo = p.PriceTo
+		result.Languages = splitList(p.Languages)
+		result.Frameworks = splitList(p.Frameworks)
+		result.Positions = splitList(p.Positions)
+		result.WorkTypes = splitList(p.WorkTypes)
+		result.RequiredSkillsMust = splitList(p.MustSkills)
+		result.RequiredSkillsWant = splitList(p.WantSkills)
+		result.RemoteWorkCategory = p.RemoteType
+		result.RemoteWorkFrequency = p.RemoteFrequency
+		result.Evidences = evidencesByProjectID[p.ID]
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// loadCandidate は人材の詳細とキーワードの紐付けを解析結果に戻します（人材の詳細がない場合は何もしません）
+// 人材の詳細は人材名を案件名、参画可能日を開始時期、希望単価を単価として保存しているため、同じ項目に戻します。
+// スキルの列は言語・フレームワーク・必須スキルをまとめているため必須スキルに戻し、
+// スキルの列にないキーワードの紐付け（希望スキル）はキーワードグループの名前で希望スキルに戻します。
+func (r *Repository) loadCandidate(emailID uint, result *cd.Email) error {
+	var candidate candidateRow
+	err := r.db.Table("email_candidates").Where("email_id = ?", emailID).Take(&candidate).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("保存済み人材取得エラー: %w", err)
+	}
+
+	var groupNames []string
+	err = r.db.Table("email_candidate_keyword_groups l").
+		Joins("JOIN keyword_groups g ON g.keyword_group_id = l.keyword_group_id").
+		Where("l.email_candidate_id = ?", candidate.ID).
+		Order("g.keyword_group_id").
+		Pluck("g.name", &groupNames).Error
+	if err != nil {
+		return fmt.Errorf("保存済み人材キーワード取得エラー: %w", err)
+	}
+
+	result.ProjectName = deref(candidate.CandidateName)
+	result.Summary = deref(candidate.SkillsSummary)
+	result.StartPeriod = splitList(candidate.AvailabilityDate)
+	result.WorkLocation = deref(candidate.WorkLocation)
+	result.PriceFrom = candidate.MonthlyPriceFrom
+	result.PriceTo = candidate.MonthlyPriceTo
+	result.RemoteWorkCategory = candidate.RemoteType
+	result.RequiredSkillsMust = splitList(candidate.Skills)
+	skills := make(map[string]struct{}, len(result.RequiredSkillsMust))
+	for _, skill := range result.RequiredSkillsMust {
+		skills[keyword.Key(skill)] = struct{}{}
+	}
+	for _, name := range groupNames {
+		if _, ok := skills[keyword.Key(name)]; !ok {
+			result.RequiredSkillsWant = append(result.RequiredSkillsWant, name)
+		}
+	}
+	return nil
+}
+
+// CreateRevision は解析リビジョンを採番して保存します
+// 同一GメールIDの最大リビジョン番号+1を採番します。
+func (r *Repository) CreateRevision(gmailID string, version string, results []cd.Email, isActive bool) (domain.Revision, error) {
+	stored := make([]cd.Email, len(results))
+	for i, result := range results {
+		// 本文はemailsに保存済みのため履歴には持たない
+		result.Body = ""
+		stored[i] = result
+	}
+	resultJSON, err := json.Marshal(stored)
+	if err != nil {
+		return domain.Revision{}, fmt.Errorf("解析結果のJSON変換エラー: %w", err)
+	}
+
+	var row AnalysisRevision
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		var latest uint
+		err := tx.Model(&AnalysisRevision{}).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("gmail_id = ?", gmailID).
+			Select("COALESCE(MAX(revision), 0)").
+			Scan(&latest).Error
+		if err != nil {
+			return fmt.Errorf("リビジョン採番エラー: %w", err)
+		}
+
+		if isActive {
+			if err := tx.Model(&AnalysisRevision{}).Where("gmail_id = ?", gmailID).Update("is_active", false).Error; err != nil {
+				return fmt.Errorf("リビジョン更新エラー: %w", err)
+			}
+		}
+
+		row = AnalysisRevision{
+			GmailID:         gmailID,
+			Revision:        latest + 1,
+			AnalysisVersion: version,
+			ResultJSON:      string(resultJSON),
+			IsActive:        isActive,
+		}
+		if err := tx.Create(&row).Error; err != nil {
+			return fmt.Errorf("リビジョン保存エラー: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return domain.Revision{}, err
+	}
+	return toDomain(row, stored), nil
+}
+
+// ListRevisions はGメールIDの解析リビジョンをリビジョン番号順に取得します
+func (r *Repository) ListRevisions(gmailID string) ([]domain.Revision, error) {
+	var rows []AnalysisRevision
+	if err := r.db.Where("gmail_id = ?", gmailID).Order("revision").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("リビジョン一覧取得エラー: %w", err)
+	}
+
+	revisions := make([]domain.Revision, 0, len(rows))
+	for _, row := range rows {
+		revision, err := decode(row)
+		if err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, revision)
+	}
+	return revisions, nil
+}
+
+// GetRevision は指定したリビジョンを取得します
+func (r *Repository) GetRevision(gmailID string, revision uint) (domain.Revision, error) {
+	var row AnalysisRevision
+	err := r.db.Where("gmail_id = ? AND revision = ?", gmailID, revision).First(&row).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domain.Revision{}, domain.ErrRevisionNotFound
+	}
+	if err != nil {
+		return domain.Revision{}, fmt.Errorf("リビジョン取得エラー: %w", err)
+	}
+	return decode(row)
+}
+
+// ActivateRevision は指定したリビジョンを採用中にし、それ以外を非採用にします
+func (r *Repository) ActivateRevision(gmailID string, revision uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&AnalysisRevision{}).Where("gmail_id = ?", gmailID).Update("is_active", false).Error; err != nil {
+			return fmt.Errorf("リビジョン更新エラー: %w", err)
+		}
+
+		result := tx.Model(&AnalysisRevision{}).Where("gmail_id = ? AND revision = ?", gmailID, revision).Update("is_active", true)
+		if result.Error != nil {
+			return fmt.Errorf("リビジョン更新エラー: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return domain.ErrRevisionNotFound
+		}
+		return nil
+	})
+}
+
+// decode はリビジョンの行をドメインモデルに変換します
+func decode(row AnalysisRevision) (domain.Revision, error) {
+	var results []cd.Email
+	if err := json.Unmarshal([]byte(row.ResultJSON), &results); err != nil {
+		return domain.Revision{}, fmt.Errorf("解析結果のJSON変換エラー: %w", err)
+	}
+	return toDomain(row, results), nil
+}
+
+func toDomain(row AnalysisRevision, results []cd.Email) domain.Revision {
+	return domain.Revision{
+		ID:              row.ID,
+		GmailID:         row.GmailID,
+		Revision:        row.Revision,
+		AnalysisVersion: row.AnalysisVersion,
+		Results:         results,
+		IsActive:        row.IsActive,
+		CreatedAt:       row.CreatedAt,
+	}
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// splitList は表示用のカンマ区切り文字列を配列に戻します
+func splitList(s *string) []string {
+	if s == nil || *s == "" {
+		return []string{}
+	}
+	return strings.Split(*s, ",")
+}
@@ -0,0 +1,182 @@
+// Package infrastructure は再解析機能のインフラストラクチャ層のテストを提供します。
+package infrastructure
+
+import (
+	cd "business/internal/common/domain"
+	ei "business/internal/emailstore/infrastructure"
+	"business/internal/reanalysis/domain"
+	"business/tools/migrations/model"
+	"business/tools/mysql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_Revisions(t *testing.T) {
+	t.Parallel()
+	// テスト用DBの準備
+	db, cleanup, err := mysql.CreateNewTestDB()
+	require.NoError(t, err)
+	defer cleanup()
+
+	err = db.DB.AutoMigrate(
+		model.Email{},
+		model.EmailProject{},
+		model.EmailProjectFieldEvidence{},
+		model.AnalysisRevision{},
+	)
+	require.NoError(t, err)
+
+	body := "■案件名:Go開発"
+	title := "Go開発"
+	languages := "Go,SQL"
+	received := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+	email := model.Email{
+		GmailID:          "gmail-1",
+		Subject:          "件名",
+		SenderName:       "営業 太郎",
+		SenderEmail:      "sales@example.com",
+		ReceivedDate:     received,
+		Body:             &body,
+		Category:         "案件",
+		AnalysisVersion:  "v1",
+		AnalysisRevision: 1,
+		EmailProjects:    []model.EmailProject{{ProjectKey: "key-1", ProjectTitle: &title, Languages: &languages}},
+	}
+	require.NoError(t, db.DB.Create(&email).Error)
+
+	repo := New(db.DB)
+
+	// 条件に一致するメールを取得できること
+	emails, err := repo.FindEmails(domain.Condition{Category: "案件", AnalysisVersion: "v1"})
+	require.NoError(t, err)
+	require.Len(t, emails, 1)
+	assert.Equal(t, body, emails[0].Body)
+
+	emails, err = repo.FindEmails(domain.Condition{AnalysisVersion: "v2"})
+	require.NoError(t, err)
+	assert.Empty(t, emails)
+
+	// 現在の保存内容を復元できること
+	current, err := repo.LoadCurrentResults("gmail-1")
+	require.NoError(t, err)
+	require.Len(t, current, 1)
+	assert.Equal(t, "Go開発", current[0].Summary)
+	assert.Equal(t, []string{"Go", "SQL"}, current[0].Languages)
+
+	// リビジョンが1から採番されること
+	rev1, err := repo.CreateRevision("gmail-1", "v1", current, true)
+	require.NoError(t, err)
+	assert.Equal(t, uint(1), rev1.Revision)
+
+	rev2, err := repo.CreateRevision("gmail-1", "v2", []cd.Email{{GmailID: "gmail-1", Summary: "Go開発（再解析）", Body: "保存しない"}}, false)
+	require.NoError(t, err)
+	assert.Equal(t, uint(2), rev2.Revision)
+
+	// 採用中のリビジョンを切り替えられること
+	require.NoError(t, repo.ActivateRevision("gmail-1", 2))
+	revisions, err := repo.ListRevisions("gmail-1")
+	require.NoError(t, err)
+	require.Len(t, revisions, 2)
+	assert.False(t, revisions[0].IsActive)
+	assert.True(t, revisions[1].IsActive)
+	assert.Equal(t, "", revisions[1].Results[0].Body)
+
+	_, err = repo.GetRevision("gmail-1", 3)
+	assert.ErrorIs(t, err, domain.ErrRevisionNotFound)
+	assert.ErrorIs(t, repo.ActivateRevision("gmail-1", 3), domain.ErrRevisionNotFound)
+}
+
+func TestRepository_RevertCandidate(t *testing.T) {
+	t.Parallel()
+	// テスト用DBの準備
+	db, cleanup, err := mysql.CreateNewTestDB()
+	require.NoError(t, err)
+	defer cleanup()
+
+	err = db.DB.AutoMigrate(
+		model.KeywordGroup{},
+		model.KeyWord{},
+		model.KeywordGroupWordLink{},
+		model.PositionGroup{},
+		model.PositionWord{},
+		model.WorkTypeGroup{},
+		model.WorkTypeWord{},
+		model.Email{},
+		model.EmailProject{},
+		model.EmailProjectFieldEvidence{},
+		model.EmailCandidate{},
+		model.EmailCandidateKeywordGroup{},
+		model.EntryTiming{},
+		model.ProjectLocation{},
+		model.ProjectCluster{},
+		model.ProjectClusterMember{},
+		model.EmailKeywordGroup{},
+		model.EmailPositionGroup{},
+		model.EmailWorkTypeGroup{},
+		model.AnalysisRevision{},
+	)
+	require.NoError(t, err)
+
+	// 人材メールを保存する
+	price := 700000
+	remote := "フルリモート希望"
+	saved := cd.Email{
+		GmailID: "gmail-1", Subject: "人材のご紹介", From: "sales@example.com", FromEmail: "sales@example.com",
+		ReceivedDate: time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC), Body: "本文", Category: "人材",
+		AnalysisVersion: "v1", ProjectName: "T.K", Summary: "Goのバックエンド5年", StartPeriod: []string{"即日"},
+		PriceFrom: &price, WorkLocation: "東京都", RemoteWorkCategory: &remote,
+		Languages: []string{"Go"}, Frameworks: []string{"Gin"}, RequiredSkillsWant: []string{"AWS"},
+	}
+	store := ei.New(db.DB)
+	require.NoError(t, store.ReplaceEmails("gmail-1", []cd.Email{saved}))
+	candidateGroups := func() []string {
+		var names []string
+		require.NoError(t, db.DB.Table("email_candidate_keyword_groups l").
+			Joins("JOIN keyword_groups g ON g.keyword_group_id = l.keyword_group_id").
+			Order("g.name").Pluck("g.name", &names).Error)
+		return names
+	}
+	require.Equal(t, []string{"AWS", "Gin", "Go"}, candidateGroups())
+
+	repo := New(db.DB)
+
+	// 人材の詳細を解析結果に戻せること
+	current, err := repo.LoadCurrentResults("gmail-1")
+	require.NoError(t, err)
+	require.Len(t, current, 1)
+	assert.Equal(t, "T.K", current[0].ProjectName)
+	assert.Equal(t, []string{"Go", "Gin"}, current[0].RequiredSkillsMust)
+	assert.Equal(t, []string{"AWS"}, current[0].RequiredSkillsWant)
+	rev1, err := repo.CreateRevision("gmail-1", "v1", current, true)
+	require.NoError(t, err)
+
+	// 再解析の結果を採用すると人材の詳細が置き換わる
+	reanalyzed := saved
+	reanalyzed.ProjectName = "別人"
+	reanalyzed.Languages, reanalyzed.Frameworks, reanalyzed.RequiredSkillsWant = []string{"PHP"}, nil, nil
+	reanalyzed.AnalysisRevision = 2
+	_, err = repo.CreateRevision("gmail-1", "v2", []cd.Email{reanalyzed}, false)
+	require.NoError(t, err)
+	require.NoError(t, store.ReplaceEmails("gmail-1", []cd.Email{reanalyzed}))
+	require.Equal(t, []string{"PHP"}, candidateGroups())
+
+	// 退避したリビジョンに戻すと人材の詳細とキーワードの紐付けも元に戻る
+	reverted := make([]cd.Email, len(rev1.Results))
+	for i, result := range rev1.Results {
+		result.Body = "本文"
+		result.AnalysisRevision = rev1.Revision
+		reverted[i] = result
+	}
+	require.NoError(t, store.ReplaceEmails("gmail-1", reverted))
+	var candidate model.EmailCandidate
+	require.NoError(t, db.DB.Take(&candidate).Error)
+	assert.Equal(t, "T.K", *candidate.CandidateName)
+	assert.Equal(t, "Goのバックエンド5年", *candidate.SkillsSummary)
+	assert.Equal(t, "Go,Gin", *candidate.Skills)
+	assert.Equal(t, 700000, *candidate.MonthlyPriceFrom)
+	assert.Equal(t, "東京都", *candidate.WorkLocation)
+	assert.Equal(t, []string{"AWS", "Gin", "Go"}, candidateGroups())
+}
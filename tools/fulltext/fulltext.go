@@ -0,0 +1,152 @@
+// Package fulltext はMySQLの全文検索（BOOLEAN MODE）の検索語の解釈とスニペット生成を提供します。
+package fulltext
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+// DefaultSnippetWidth は一致箇所の前後に含める文字数の既定値です
+const DefaultSnippetWidth = 40
+
+// ハイライトの開始・終了タグ
+const (
+	HighlightStart = "<mark>"
+	HighlightEnd   = "</mark>"
+)
+
+// Terms はBOOLEAN MODEの検索式からハイライト対象の語を取り出します
+// 除外指定（-語）は対象外です。"..." で囲まれたフレーズは1語として扱います。
+// 例: `+PMO "金融系" -保守 設計*` → ["PMO", "金融系", "設計"]
+func Terms(query string) []string {
+	var terms []string
+	seen := map[string]struct{}{}
+	add := func(term string, exclude bool) {
+		term = strings.TrimSpace(term)
+		if term == "" || exclude {
+			return
+		}
+		key := strings.ToLower(term)
+		if _, ok := seen[key]; ok {
+			return
+		}
+		seen[key] = struct{}{}
+		terms = append(terms, term)
+	}
+
+	runes := []rune(query)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r) || strings.ContainsRune("+<>()~*", r):
+			i++
+		case r == '"':
+			end := i + 1
+			for end < len(runes) && runes[end] != '"' {
+				end++
+			}
+			add(string(runes[i+1:min(end, len(runes))]), i > 0 && runes[i-1] == '-')
+			i = end + 1
+		default:
+			exclude := r == '-'
+			if exclude {
+				i++
+			}
+			start := i
+			for i < len(runes) && !unicode.IsSpace(runes[i]) && !strings.ContainsRune(`+-<>()~*"`, runes[i]) {
+				i++
+			}
+			add(string(runes[start:i]), exclude)
+		}
+	}
+	return terms
+}
+
+// Snippet は本文から最初に検索語が現れる箇所の前後を切り出し、検索語をハイライトします
+// 本文はHTMLエスケープしてからハイライトタグを挿入します。一致箇所が無い場合は先頭を返します。
+func Snippet(text string, terms []string, width int) string {
+	if width <= 0 {
+		width = DefaultSnippetWidth
+	}
+	runes := []rune(strings.Join(strings.Fields(text), " "))
+	if len(runes) == 0 {
+		return ""
+	}
+	lower := []rune(strings.ToLower(string(runes)))
+
+	pos, length := -1, 0
+	for _, term := range terms {
+		t := []rune(strings.ToLower(term))
+		if idx := indexRunes(lower, t, 0); idx >= 0 && (pos < 0 || idx < pos) {
+			pos, length = idx, len(t)
+		}
+	}
+
+	start, end := 0, min(len(runes), width*2)
+	if pos >= 0 {
+		start = max(0, pos-width)
+		end = min(len(runes), pos+length+width)
+	}
+
+	snippet := highlight(runes[start:end], lower[start:end], terms)
+	if start > 0 {
+		snippet = "…" + snippet
+	}
+	if end < len(runes) {
+		snippet += "…"
+	}
+	return snippet
+}
+
+// highlight は切り出した範囲の検索語をハイライトタグで囲みます
+func highlight(runes []rune, lower []rune, terms []string) string {
+	marked := make([]bool, len(runes))
+	for _, term := range terms {
+		t := []rune(strings.ToLower(term))
+		if len(t) == 0 {
+			continue
+		}
+		for idx := indexRunes(lower, t, 0); idx >= 0; idx = indexRunes(lower, t, idx+len(t)) {
+			for j := idx; j < idx+len(t); j++ {
+				marked[j] = true
+			}
+		}
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(runes); {
+		j := i
+		for j < len(runes) && marked[j] == marked[i] {
+			j++
+		}
+		segment := html.EscapeString(string(runes[i:j]))
+		if marked[i] {
+			b.WriteString(HighlightStart + segment + HighlightEnd)
+		} else {
+			b.WriteString(segment)
+		}
+		i = j
+	}
+	return b.String()
+}
+
+// indexRunes はfrom以降でsubが最初に現れる位置を返します
+func indexRunes(s []rune, sub []rune, from int) int {
+	if len(sub) == 0 {
+		return -1
+	}
+	for i := from; i+len(sub) <= len(s); i++ {
+		match := true
+		for j := range sub {
+			if s[i+j] != sub[j] {
+				match = false
+				break
+			}
+		}
+		if match {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,104 @@
+package fulltext
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTerms(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{
+			name:     "空文字列の場合にnilを返すこと",
+			input:    "",
+			expected: nil,
+		},
+		{
+			name:     "空白区切りの語を返すこと",
+			input:    "PMO 経験",
+			expected: []string{"PMO", "経験"},
+		},
+		{
+			name:     "演算子を除いた語を返すこと",
+			input:    "+PMO +経験 設計* ~保守",
+			expected: []string{"PMO", "経験", "設計", "保守"},
+		},
+		{
+			name:     "フレーズを1語として返すこと",
+			input:    `"金融系 案件" +Java`,
+			expected: []string{"金融系 案件", "Java"},
+		},
+		{
+			name:     "除外指定の語を含めないこと",
+			input:    `金融系 -保守 -"運用 監視"`,
+			expected: []string{"金融系"},
+		},
+		{
+			name:     "大文字小文字違いの重複を除くこと",
+			input:    "Java java",
+			expected: []string{"Java"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Terms(tt.input))
+		})
+	}
+}
+
+func TestSnippet(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		terms    []string
+		width    int
+		expected string
+	}{
+		{
+			name:     "一致箇所の前後を切り出してハイライトすること",
+			text:     "■案件名:大手金融系システムの更改 ■場所:大手町",
+			terms:    []string{"金融系"},
+			width:    5,
+			expected: "…件名:大手<mark>金融系</mark>システムの…",
+		},
+		{
+			name:     "大文字小文字を区別せずにハイライトすること",
+			text:     "PMO経験者 pmo補佐",
+			terms:    []string{"PMO"},
+			width:    20,
+			expected: "<mark>PMO</mark>経験者 <mark>pmo</mark>補佐",
+		},
+		{
+			name:     "本文をHTMLエスケープすること",
+			text:     "<b>Go</b>開発",
+			terms:    []string{"Go"},
+			width:    20,
+			expected: "&lt;b&gt;<mark>Go</mark>&lt;/b&gt;開発",
+		},
+		{
+			name:     "一致箇所が無い場合に先頭を返すこと",
+			text:     "あいうえおかきくけこ",
+			terms:    []string{"金融"},
+			width:    2,
+			expected: "あいうえ…",
+		},
+		{
+			name:     "改行や連続する空白を1つの空白にまとめること",
+			text:     "金融系\n\n  案件",
+			terms:    []string{"案件"},
+			width:    20,
+			expected: "金融系 <mark>案件</mark>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Snippet(tt.text, tt.terms, tt.width))
+		})
+	}
+}
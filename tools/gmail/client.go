@@ -105,12 +105,13 @@ func (c *Client) GetGmailDetail(id string) (cd.BasicMessage, error) {
 	}
 
 	msg := cd.BasicMessage{
-		ID:      full.Id,
-		Subject: getHeader(full.Payload.Headers, "Subject"),
-		From:    getHeader(full.Payload.Headers, "From"),
-		To:      parseHeaderMulti(getHeader(full.Payload.Headers, "To")),
-		Date:    parseDate(getHeader(full.Payload.Headers, "Date")),
-		Body:    stripHTMLTags(extractBody(full.Payload)), // HTMLタグを削除する。
+		ID:       full.Id,
+		ThreadID: full.ThreadId,
+		Subject:  getHeader(full.Payload.Headers, "Subject"),
+		From:     getHeader(full.Payload.Headers, "From"),
+		To:       parseHeaderMulti(getHeader(full.Payload.Headers, "To")),
+		Date:     parseDate(getHeader(full.Payload.Headers, "Date")),
+		Body:     stripHTMLTags(extractBody(full.Payload)), // HTMLタグを削除する。
 	}
 	return msg, nil
 }
@@ -0,0 +1,356 @@
+// Package jpdate は案件メールの入場時期・終了時期に書かれる日本語の日付表現を日付の範囲に変換する機能を提供します。
+// "来月上旬" のような相対的な表現は、基準日（メールの受信日）から解決します。
+package jpdate
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/text/width"
+)
+
+// Flag は日付だけでは表せない時期の種類です
+type Flag string
+
+const (
+	FlagNone      Flag = ""          // 日付で表せる
+	FlagImmediate Flag = "immediate" // 即日（基準日から開始）
+	FlagOngoing   Flag = "ongoing"   // 随時・長期（期限なし）
+	FlagUndecided Flag = "undecided" // 未定・応相談
+)
+
+// pastMonths は年の無い月がこの月数より前になる場合に翌年とみなす月数です
+// 例: 12月に受信したメールの "1月～" は翌年の1月とします。
+const pastMonths = 3
+
+// Range は日付表現を変換した範囲です
+// From・To は日付（時刻は0時）で、どちらも含みます。nil は下限・上限が無いことを表します。
+type Range struct {
+	From *time.Time `json:"from,omitempty"`
+	To   *time.Time `json:"to,omitempty"`
+	Flag Flag       `json:"flag,omitempty"`
+}
+
+// IsZero は日付もフラグも無いかどうかを返します
+func (r Range) IsZero() bool {
+	return r.From == nil && r.To == nil && r.Flag == FlagNone
+}
+
+var (
+	reParen    = regexp.MustCompile(`\([^)]*\)`)
+	reSpace    = regexp.MustCompile(`\s+`)
+	reAlt      = regexp.MustCompile(`or|OR|または|もしくは|、|,|・`)
+	reFullDate = regexp.MustCompile(`^(\d{4})[/\-.年](\d{1,2})[/\-.月](\d{1,2})日?$`)
+	reYearMon  = regexp.MustCompile(`^(\d{4})[/\-.年](\d{1,2})月?(.*)$`)
+	reMonDay   = regexp.MustCompile(`^(\d{1,2})(?:/|月)(\d{1,2})日?$`)
+	reMonth    = regexp.MustCompile(`^(\d{1,2})月(.*)$`)
+	reDuration = regexp.MustCompile(`^(\d{1,2})(?:ヶ月|ヵ月|カ月|か月|ケ月|箇月)(.*)$`)
+	reRelMonth = regexp.MustCompile(`^(今月|当月|来月|翌月|再来月)(.*)$`)
+	reRelWeek  = regexp.MustCompile(`^(今週|来週|翌週|再来週)(.*)$`)
+)
+
+// noiseSuffixes は時期の後ろに付くことが多く、意味を変えない語です
+var noiseSuffixes = []string{
+	"入場可能", "参画可能", "開始予定", "入場予定", "参画予定", "予定", "見込み", "目途", "めど",
+	"頃", "ごろ", "ころ", "開始", "スタート", "入場", "参画", "可能", "位", "くらい", "程度",
+}
+
+// Parse は日付表現を基準日から解決した範囲に変換します
+// 変換できない場合は ok に false を返します。
+//
+//	"2025/06/01"  → 2025-06-01〜2025-06-01
+//	"7月～"        → 7月1日〜7月31日（入場月）
+//	"7月以降"      → 7月1日〜（上限なし）
+//	"来月上旬"     → 翌月1日〜10日
+//	"即日"         → 基準日〜基準日（FlagImmediate）
+//	"随時"         → 基準日〜（FlagOngoing）
+//	"～長期"       → 上限なし（FlagOngoing）
+//	"～2025年12月末" → 〜2025-12-31
+func Parse(text string, base time.Time) (Range, bool) {
+	base = day(base.Year(), base.Month(), base.Day(), base.Location())
+	s := normalize(text)
+	if s == "" {
+		return Range{}, false
+	}
+
+	// 選択肢（"6月or7月"）は最も早い開始から最も遅い終了までとする
+	alts := reAlt.Split(s, -1)
+	if len(alts) > 1 {
+		var merged Range
+		found := false
+		for _, alt := range alts {
+			r, ok := parseRange(alt, base)
+			if !ok {
+				continue
+			}
+			if !found {
+				merged, found = r, true
+				continue
+			}
+			merged = widen(merged, r)
+		}
+		return merged, found
+	}
+	return parseRange(s, base)
+}
+
+// normalize は全角英数字・記号を半角にし、括弧書き（曜日など）と空白を除きます
+// 範囲を表す記号（～・〜・~・から）は "~" に揃えます。
+func normalize(text string) string {
+	s := width.Fold.String(text)
+	s = strings.NewReplacer("〜", "~", "～", "~", "∼", "~", "から", "~", "より", "~").Replace(s)
+	s = reParen.ReplaceAllString(s, "")
+	s = reSpace.ReplaceAllString(s, "")
+	return s
+}
+
+// parseRange は "A~B" 形式の範囲、または単独の時期を変換します
+func parseRange(s string, base time.Time) (Range, bool) {
+	s = strings.TrimSuffix(s, "まで")
+	if s == "" {
+		return Range{}, false
+	}
+
+	left, right, hasTilde := strings.Cut(s, "~")
+	if !hasTilde {
+		return parseTerm(s, base)
+	}
+	right = strings.Trim(right, "~")
+
+	switch {
+	case left == "" && right == "":
+		return Range{}, false
+	case left == "":
+		// "~6月末" は上限のみ
+		r, ok := parseTerm(right, base)
+		if !ok {
+			return Range{}, false
+		}
+		if r.Flag == FlagOngoing {
+			return Range{Flag: FlagOngoing}, true
+		}
+		return Range{To: r.To, Flag: r.Flag}, true
+	case right == "":
+		// "7月~" は開始時期のみ
+		return parseTerm(left, base)
+	}
+
+	from, okFrom := parseTerm(left, base)
+	to, okTo := parseTerm(right, base)
+	switch {
+	case okFrom && okTo:
+		r := Range{From: from.From, To: to.To, Flag: from.Flag}
+		if to.Flag == FlagOngoing {
+			r.To = nil
+			if r.Flag == FlagNone {
+				r.Flag = FlagOngoing
+			}
+		}
+		return r, true
+	case okFrom:
+		return from, true
+	case okTo:
+		return Range{To: to.To, Flag: to.Flag}, true
+	default:
+		return Range{}, false
+	}
+}
+
+// parseTerm は単独の時期を変換します
+func parseTerm(s string, base time.Time) (Range, bool) {
+	s = trimNoise(s)
+	openEnd := false
+	if t, ok := strings.CutSuffix(s, "以降"); ok {
+		s, openEnd = trimNoise(t), true
+	}
+	if s == "" {
+		return Range{}, false
+	}
+
+	r, ok := parseWord(s, base)
+	if !ok {
+		r, ok = parseDate(s, base)
+	}
+	if ok && openEnd {
+		r.To = nil
+	}
+	return r, ok
+}
+
+// trimNoise は意味を変えない接尾語を取り除きます
+func trimNoise(s string) string {
+	for {
+		trimmed := s
+		for _, suffix := range noiseSuffixes {
+			trimmed = strings.TrimSuffix(trimmed, suffix)
+		}
+		if trimmed == s {
+			return s
+		}
+		s = trimmed
+	}
+}
+
+// parseWord は日付を含まない語（即日・随時・長期・未定など）を変換します
+func parseWord(s string, base time.Time) (Range, bool) {
+	switch {
+	case strings.HasPrefix(s, "即") || strings.Contains(s, "ASAP") || strings.Contains(s, "至急") || s == "今すぐ":
+		return Range{From: ptr(base), To: ptr(base), Flag: FlagImmediate}, true
+	case strings.Contains(s, "随時") || strings.Contains(s, "いつでも"):
+		return Range{From: ptr(base), Flag: FlagOngoing}, true
+	case strings.Contains(s, "長期") || strings.Contains(s, "継続") || strings.Contains(s, "延長"):
+		return Range{Flag: FlagOngoing}, true
+	case strings.Contains(s, "未定") || strings.Contains(s, "相談") || strings.Contains(s, "調整"):
+		return Range{Flag: FlagUndecided}, true
+	case s == "年内":
+		return Range{From: ptr(base), To: ptr(day(base.Year(), time.December, 31, base.Location()))}, true
+	case s == "年明け" || s == "年始":
+		year := base.Year()
+		if base.Month() >= time.October {
+			year++
+		}
+		return month(year, time.January, "", base.Location())
+	case s == "年度末":
+		year := base.Year()
+		if base.Month() > time.March {
+			year++
+		}
+		end := day(year, time.March, 31, base.Location())
+		return Range{From: ptr(end), To: ptr(end)}, true
+	}
+	return Range{}, false
+}
+
+// parseDate は日付・月・相対的な月や週を変換します
+func parseDate(s string, base time.Time) (Range, bool) {
+	loc := base.Location()
+
+	if m := reFullDate.FindStringSubmatch(s); m != nil {
+		d, ok := date(atoi(m[1]), atoi(m[2]), atoi(m[3]), loc)
+		if !ok {
+			return Range{}, false
+		}
+		return Range{From: ptr(d), To: ptr(d)}, true
+	}
+	if m := reYearMon.FindStringSubmatch(s); m != nil {
+		if atoi(m[2]) < 1 || atoi(m[2]) > 12 {
+			return Range{}, false
+		}
+		return month(atoi(m[1]), time.Month(atoi(m[2])), m[3], loc)
+	}
+	if m := reMonDay.FindStringSubmatch(s); m != nil {
+		mon := atoi(m[1])
+		if mon < 1 || mon > 12 {
+			return Range{}, false
+		}
+		d, ok := date(inferYear(time.Month(mon), base), mon, atoi(m[2]), loc)
+		if !ok {
+			return Range{}, false
+		}
+		return Range{From: ptr(d), To: ptr(d)}, true
+	}
+	if m := reMonth.FindStringSubmatch(s); m != nil {
+		mon := atoi(m[1])
+		if mon < 1 || mon > 12 {
+			return Range{}, false
+		}
+		return month(inferYear(time.Month(mon), base), time.Month(mon), m[2], loc)
+	}
+	if m := reRelMonth.FindStringSubmatch(s); m != nil {
+		offset := map[string]int{"今月": 0, "当月": 0, "来月": 1, "翌月": 1, "再来月": 2}[m[1]]
+		first := day(base.Year(), base.Month()+time.Month(offset), 1, loc)
+		return month(first.Year(), first.Month(), m[2], loc)
+	}
+	if m := reRelWeek.FindStringSubmatch(s); m != nil && m[2] == "" {
+		offset := map[string]int{"今週": 0, "来週": 1, "翌週": 1, "再来週": 2}[m[1]]
+		// 週は月曜日から日曜日までとする
+		monday := base.AddDate(0, 0, -((int(base.Weekday())+6)%7)+7*offset)
+		return Range{From: ptr(monday), To: ptr(monday.AddDate(0, 0, 6))}, true
+	}
+	if m := reDuration.FindStringSubmatch(s); m != nil && (m[2] == "" || m[2] == "後") {
+		// "3ヶ月" は基準日から3か月後
+		d := base.AddDate(0, atoi(m[1]), 0)
+		return Range{From: ptr(d), To: ptr(d)}, true
+	}
+	return Range{}, false
+}
+
+// month は月と旬・末などの部分を範囲に変換します
+func month(year int, mon time.Month, part string, loc *time.Location) (Range, bool) {
+	last := day(year, mon+1, 0, loc).Day()
+	from, to := 1, last
+	switch strings.TrimSuffix(part, "日") {
+	case "", "中", "内":
+	case "上旬", "初旬", "頭", "初め", "はじめ", "始め", "明け":
+		from, to = 1, 10
+	case "中旬", "半ば":
+		from, to = 11, 20
+	case "下旬":
+		from, to = 21, last
+	case "前半":
+		from, to = 1, 15
+	case "後半":
+		from, to = 16, last
+	case "末", "末日", "終わり", "いっぱい", "一杯":
+		from, to = last, last
+	default:
+		return Range{}, false
+	}
+	return Range{From: ptr(day(year, mon, from, loc)), To: ptr(day(year, mon, to, loc))}, true
+}
+
+// inferYear は年の無い月の年を基準日から推定します
+// 基準日の月より pastMonths を超えて前の月は翌年とします。
+func inferYear(mon time.Month, base time.Time) int {
+	diff := int(base.Month()) - int(mon)
+	if diff > pastMonths {
+		return base.Year() + 1
+	}
+	if diff < -12+pastMonths {
+		// 1月に受信したメールの "12月" は前年とする
+		return base.Year() - 1
+	}
+	return base.Year()
+}
+
+// date は存在する日付の場合のみ日付を返します
+func date(year, mon, d int, loc *time.Location) (time.Time, bool) {
+	t := day(year, time.Month(mon), d, loc)
+	if t.Year() != year || int(t.Month()) != mon || t.Day() != d {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// widen は2つの範囲を両方を含む範囲に広げます
+func widen(a, b Range) Range {
+	r := Range{From: a.From, To: a.To, Flag: a.Flag}
+	if r.From == nil || (b.From != nil && b.From.Before(*r.From)) {
+		r.From = b.From
+	}
+	if a.To != nil && b.To != nil && b.To.After(*a.To) {
+		r.To = b.To
+	}
+	if a.To == nil || b.To == nil {
+		r.To = nil
+	}
+	if r.Flag == FlagNone {
+		r.Flag = b.Flag
+	}
+	return r
+}
+
+func day(year int, mon time.Month, d int, loc *time.Location) time.Time {
+	return time.Date(year, mon, d, 0, 0, 0, 0, loc)
+}
+
+func atoi(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
+
+func ptr(t time.Time) *time.Time {
+	return &t
+}
@@ -0,0 +1,74 @@
+package jpdate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	base := time.Date(2025, 5, 20, 15, 30, 0, 0, time.Local)
+
+	tests := []struct {
+		name  string
+		input string
+		from  string // 空の場合は下限なし
+		to    string // 空の場合は上限なし
+		flag  Flag
+		ok    bool
+	}{
+		{name: "年月日はその日になること", input: "2025/06/01", from: "2025-06-01", to: "2025-06-01", ok: true},
+		{name: "全角の年月日と曜日を変換できること", input: "２０２５年６月２日（月）", from: "2025-06-02", to: "2025-06-02", ok: true},
+		{name: "即日は基準日になりフラグが付くこと", input: "即日", from: "2025-05-20", to: "2025-05-20", flag: FlagImmediate, ok: true},
+		{name: "即日～も即日として扱うこと", input: "即日～", from: "2025-05-20", to: "2025-05-20", flag: FlagImmediate, ok: true},
+		{name: "随時は基準日から上限なしになること", input: "随時", from: "2025-05-20", flag: FlagOngoing, ok: true},
+		{name: "月～はその月の範囲になること", input: "7月～", from: "2025-07-01", to: "2025-07-31", ok: true},
+		{name: "月以降は上限なしになること", input: "7月以降", from: "2025-07-01", ok: true},
+		{name: "来月上旬は翌月の1日～10日になること", input: "来月上旬", from: "2025-06-01", to: "2025-06-10", ok: true},
+		{name: "下旬は21日から月末までになること", input: "6月下旬", from: "2025-06-21", to: "2025-06-30", ok: true},
+		{name: "年の無い月日を変換できること", input: "6/15", from: "2025-06-15", to: "2025-06-15", ok: true},
+		{name: "基準日より大きく前の月は翌年になること", input: "1月～", from: "2026-01-01", to: "2026-01-31", ok: true},
+		{name: "範囲は開始から終了までになること", input: "6月～8月末", from: "2025-06-01", to: "2025-08-31", ok: true},
+		{name: "上限のみの範囲を変換できること", input: "～2025年12月末", to: "2025-12-31", ok: true},
+		{name: "～長期は期限なしになること", input: "~長期", flag: FlagOngoing, ok: true},
+		{name: "即日～長期は基準日から上限なしになること", input: "即日〜長期", from: "2025-05-20", flag: FlagImmediate, ok: true},
+		{name: "選択肢は最も早い開始から最も遅い終了までになること", input: "6月or7月", from: "2025-06-01", to: "2025-07-31", ok: true},
+		{name: "予定などの接尾語を無視すること", input: "6月中旬頃から参画予定", from: "2025-06-11", to: "2025-06-20", ok: true},
+		{name: "月数は基準日からの日付になること", input: "3ヶ月", from: "2025-08-20", to: "2025-08-20", ok: true},
+		{name: "未定はフラグのみになること", input: "未定", flag: FlagUndecided, ok: true},
+		{name: "存在しない日付は変換できないこと", input: "2025/02/30", ok: false},
+		{name: "解釈できない表現は変換できないこと", input: "要確認", ok: false},
+		{name: "空文字は変換できないこと", input: " ", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, ok := Parse(tt.input, base)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.from, format(r.From))
+			assert.Equal(t, tt.to, format(r.To))
+			assert.Equal(t, tt.flag, r.Flag)
+		})
+	}
+}
+
+func TestParse_YearBoundary(t *testing.T) {
+	base := time.Date(2026, 1, 10, 0, 0, 0, 0, time.Local)
+
+	r, ok := Parse("12月下旬", base)
+	assert.True(t, ok)
+	assert.Equal(t, "2025-12-21", format(r.From))
+
+	r, ok = Parse("来月", base)
+	assert.True(t, ok)
+	assert.Equal(t, "2026-02-01", format(r.From))
+	assert.Equal(t, "2026-02-28", format(r.To))
+}
+
+func format(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format("2006-01-02")
+}
@@ -0,0 +1,87 @@
+package keyword
+
+import "strings"
+
+// kanaDigraphs は拗音など2文字で1音になるカタカナのローマ字です
+var kanaDigraphs = map[string]string{
+	"キャ": "kya", "キュ": "kyu", "キョ": "kyo", "シャ": "sha", "シュ": "shu", "ショ": "sho", "シェ": "she",
+	"チャ": "cha", "チュ": "chu", "チョ": "cho", "チェ": "che", "ニャ": "nya", "ニュ": "nyu", "ニョ": "nyo",
+	"ヒャ": "hya", "ヒュ": "hyu", "ヒョ": "hyo", "ミャ": "mya", "ミュ": "myu", "ミョ": "myo",
+	"リャ": "rya", "リュ": "ryu", "リョ": "ryo", "ギャ": "gya", "ギュ": "gyu", "ギョ": "gyo",
+	"ジャ": "ja", "ジュ": "ju", "ジョ": "jo", "ジェ": "je", "ビャ": "bya", "ビュ": "byu", "ビョ": "byo",
+	"ピャ": "pya", "ピュ": "pyu", "ピョ": "pyo", "ティ": "ti", "ディ": "di", "デュ": "dyu", "トゥ": "tu",
+	"ファ": "fa", "フィ": "fi", "フェ": "fe", "フォ": "fo", "ウィ": "wi", "ウェ": "we", "ウォ": "wo",
+	"ヴァ": "va", "ヴィ": "vi", "ヴェ": "ve", "ヴォ": "vo",
+}
+
+// kanaSingles は1文字のカタカナのローマ字です
+var kanaSingles = map[rune]string{
+	'ア': "a", 'イ': "i", 'ウ': "u", 'エ': "e", 'オ': "o",
+	'カ': "ka", 'キ': "ki", 'ク': "ku", 'ケ': "ke", 'コ': "ko",
+	'サ': "sa", 'シ': "shi", 'ス': "su", 'セ': "se", 'ソ': "so",
+	'タ': "ta", 'チ': "chi", 'ツ': "tsu", 'テ': "te", 'ト': "to",
+	'ナ': "na", 'ニ': "ni", 'ヌ': "nu", 'ネ': "ne", 'ノ': "no",
+	'ハ': "ha", 'ヒ': "hi", 'フ': "fu", 'ヘ': "he", 'ホ': "ho",
+	'マ': "ma", 'ミ': "mi", 'ム': "mu", 'メ': "me", 'モ': "mo",
+	'ヤ': "ya", 'ユ': "yu", 'ヨ': "yo",
+	'ラ': "ra", 'リ': "ri", 'ル': "ru", 'レ': "re", 'ロ': "ro",
+	'ワ': "wa", 'ヲ': "o", 'ン': "n",
+	'ガ': "ga", 'ギ': "gi", 'グ': "gu", 'ゲ': "ge", 'ゴ': "go",
+	'ザ': "za", 'ジ': "ji", 'ズ': "zu", 'ゼ': "ze", 'ゾ': "zo",
+	'ダ': "da", 'ヂ': "ji", 'ヅ': "zu", 'デ': "de", 'ド': "do",
+	'バ': "ba", 'ビ': "bi", 'ブ': "bu", 'ベ': "be", 'ボ': "bo",
+	'パ': "pa", 'ピ': "pi", 'プ': "pu", 'ペ': "pe", 'ポ': "po",
+	'ヴ': "vu", 'ァ': "a", 'ィ': "i", 'ゥ': "u", 'ェ': "e", 'ォ': "o",
+	'ャ': "ya", 'ュ': "yu", 'ョ': "yo",
+}
+
+// Romanize はひらがな・カタカナをヘボン式に近いローマ字に変換します
+// 促音（ッ）は次の子音を重ね、長音（ー）は取り除きます。かな以外の文字はそのまま残します。
+func Romanize(s string) string {
+	runes := []rune(toKatakana(s))
+
+	var b strings.Builder
+	double := false
+	for i := 0; i < len(runes); i++ {
+		var roman string
+		if i+1 < len(runes) {
+			if r, ok := kanaDigraphs[string(runes[i:i+2])]; ok {
+				roman = r
+				i++
+			}
+		}
+		if roman == "" {
+			switch runes[i] {
+			case 'ッ':
+				double = true
+				continue
+			case 'ー':
+				continue
+			}
+			r, ok := kanaSingles[runes[i]]
+			if !ok {
+				b.WriteRune(runes[i])
+				double = false
+				continue
+			}
+			roman = r
+		}
+
+		if double && roman[0] != 'a' && roman[0] != 'i' && roman[0] != 'u' && roman[0] != 'e' && roman[0] != 'o' {
+			b.WriteByte(roman[0])
+		}
+		double = false
+		b.WriteString(roman)
+	}
+	return b.String()
+}
+
+// toKatakana はひらがなをカタカナに変換します
+func toKatakana(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'ぁ' && r <= 'ゖ' {
+			return r + ('ァ' - 'ぁ')
+		}
+		return r
+	}, s)
+}
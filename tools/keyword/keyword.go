@@ -0,0 +1,116 @@
+// Package keyword は技術キーワードの表記ゆれを正規化する機能を提供します。
+// 全角・半角（NFKC）、前後の空白や記号、括弧書き、バージョン番号を整え、既知の別名は別名ルールで正規名に変換します。
+package keyword
+
+import (
+	"regexp"
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+var (
+	// 括弧書き（例: "Java(Spring)" の "(Spring)"）
+	bracketPattern = regexp.MustCompile(`\s*[(\[【〔「『<]([^)\]】〕」』>]*)[)\]】〕」』>]\s*`)
+	// 空白区切りや ver 付きのバージョン（例: "Java 11以上", "Python ver3.10", "Spring Boot 3.x系"）
+	spacedVersionPattern = regexp.MustCompile(`^(.+?)(?:\s+(?:v|ver\.?|version)?\s*|(?:ver\.?|version)\s*)\d+(?:\.(?:\d+|x))*\s*(?:系|以上|以降|\+)?$`)
+	// 名前に続く小数点付きのバージョン（例: "Python3.10", "PHP7.4", "Laravel5.x"）
+	dottedVersionPattern = regexp.MustCompile(`^(.*[A-Za-z])\d+\.(?:\d+|x)(?:\.(?:\d+|x))*(?:系|以上|以降|\+)?$`)
+	// 名前に続く「系」「以上」付きのバージョン（例: "Java8以上"）
+	qualifiedVersionPattern = regexp.MustCompile(`^(.*[A-Za-z])\d+(?:系|以上|以降)$`)
+	// 連続する空白
+	spacePattern = regexp.MustCompile(`\s+`)
+)
+
+// trimSymbols は前後から取り除く記号です
+// "C++" や "C#"、".NET" のように名前の一部になる記号は含めません。
+const trimSymbols = " ・,、。:;/|*•-_~〜"
+
+// Clean はキーワードの表記を整えます
+// NFKCで全角英数字・記号を半角にし、空白を1つにまとめ、括弧書きとバージョン番号、前後の記号を取り除きます。
+// 括弧書きのみのキーワード（例: "(Java)"）は括弧の中身を使います。
+func Clean(word string) string {
+	s := nfkc(word)
+	s = strings.TrimSpace(spacePattern.ReplaceAllString(s, " "))
+
+	if stripped := strings.TrimSpace(bracketPattern.ReplaceAllString(s, " ")); stripped != "" {
+		s = stripped
+	} else if m := bracketPattern.FindStringSubmatch(s); m != nil {
+		s = m[1]
+	}
+	s = strings.Trim(s, trimSymbols)
+
+	for _, pattern := range []*regexp.Regexp{spacedVersionPattern, dottedVersionPattern, qualifiedVersionPattern} {
+		if m := pattern.FindStringSubmatch(s); m != nil {
+			s = strings.Trim(m[1], trimSymbols)
+			break
+		}
+	}
+	return spacePattern.ReplaceAllString(s, " ")
+}
+
+// Key はキーワードを照合するキーを返します
+// 表記を整えたうえで大文字・小文字を区別しない形にします。
+func Key(word string) string {
+	return strings.ToLower(Clean(word))
+}
+
+// Normalizer は別名ルールを使ってキーワードを正規名に変換します
+type Normalizer struct {
+	rules Rules
+}
+
+// New は別名ルールを使う Normalizer を作成します
+func New(rules Rules) *Normalizer {
+	return &Normalizer{rules: rules}
+}
+
+// Normalize はキーワードの正規名を返します
+// 別名ルールに一致する場合はルールの正規名、一致しない場合は表記を整えた値を返します。
+// 別名ルールは整える前の表記（例: "Vue3"）でも照合します。
+func (n *Normalizer) Normalize(word string) string {
+	raw := strings.ToLower(strings.TrimSpace(nfkc(word)))
+	if canonical, ok := n.rules.Canonical(raw); ok {
+		return canonical
+	}
+
+	cleaned := Clean(word)
+	if canonical, ok := n.rules.Canonical(strings.ToLower(cleaned)); ok {
+		return canonical
+	}
+	return cleaned
+}
+
+// Key は正規名を照合するキーを返します
+// 別名同士（例: "JS" と "JavaScript"）は同じキーになります。
+func (n *Normalizer) Key(word string) string {
+	return strings.ToLower(n.Normalize(word))
+}
+
+// NormalizeAll はキーワードを正規化し、空文字と同じキーの重複を除いて出現順に返します
+func (n *Normalizer) NormalizeAll(words []string) []string {
+	if words == nil {
+		return nil
+	}
+
+	normalized := make([]string, 0, len(words))
+	seen := make(map[string]struct{}, len(words))
+	for _, word := range words {
+		w := n.Normalize(word)
+		if w == "" {
+			continue
+		}
+		key := strings.ToLower(w)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		normalized = append(normalized, w)
+	}
+	return normalized
+}
+
+// nfkc は全角英数字・記号を半角にします
+func nfkc(s string) string {
+	return norm.NFKC.String(s)
+}
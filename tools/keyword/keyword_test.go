@@ -0,0 +1,139 @@
+package keyword
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClean(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "全角英数字が半角になること", input: "ＪＡＶＡ", expected: "JAVA"},
+		{name: "前後の空白が除かれること", input: " java　", expected: "java"},
+		{name: "連続する空白が1つになること", input: "Spring　 Boot", expected: "Spring Boot"},
+		{name: "括弧書きが除かれること", input: "Java(Spring)", expected: "Java"},
+		{name: "全角の括弧書きが除かれること", input: "Java（Spring）", expected: "Java"},
+		{name: "隅付き括弧が除かれること", input: "【必須】AWS", expected: "AWS"},
+		{name: "括弧のみの場合は中身を使うこと", input: "(Java)", expected: "Java"},
+		{name: "前後の記号が除かれること", input: "・Python、", expected: "Python"},
+		{name: "空白区切りのバージョンが除かれること", input: "Java 11以上", expected: "Java"},
+		{name: "ver付きのバージョンが除かれること", input: "Python ver3.10", expected: "Python"},
+		{name: "小数点付きのバージョンが除かれること", input: "PHP7.4", expected: "PHP"},
+		{name: "x付きのバージョンが除かれること", input: "Laravel5.x系", expected: "Laravel"},
+		{name: "系・以上付きのバージョンが除かれること", input: "Java8以上", expected: "Java"},
+		{name: "名前の一部の記号は残ること", input: "C++", expected: "C++"},
+		{name: "名前の一部の#は残ること", input: "Ｃ＃", expected: "C#"},
+		{name: "先頭のドットは残ること", input: ".NET", expected: ".NET"},
+		{name: "名前に含まれる数字は残ること", input: "EC2", expected: "EC2"},
+		{name: "ドットを含む名前のバージョンが除かれること", input: "Vue.js 3", expected: "Vue.js"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Clean(tt.input))
+		})
+	}
+}
+
+func TestKey(t *testing.T) {
+	for _, word := range []string{"Java", "ＪＡＶＡ", "java ", "Java(Spring)", "Java 17"} {
+		assert.Equal(t, "java", Key(word), word)
+	}
+}
+
+func TestParseRules(t *testing.T) {
+	rules, err := ParseRules(`
+# コメント
+JavaScript: JS, Java Script   # 行末コメント
+C#: C Sharp
+Kubernetes: k8s
+`)
+	require.NoError(t, err)
+
+	n := New(rules)
+	assert.Equal(t, "JavaScript", n.Normalize("js"))
+	assert.Equal(t, "JavaScript", n.Normalize("ＪＳ"))
+	assert.Equal(t, "JavaScript", n.Normalize("javascript"))
+	assert.Equal(t, "C#", n.Normalize("c sharp"))
+	assert.Equal(t, "C#", n.Normalize("C#"))
+	assert.Equal(t, "Kubernetes", n.Normalize("K8s"))
+	assert.Equal(t, n.Key("JS"), n.Key("Java Script(ES6)"))
+
+	// ルールにない場合は表記を整えた値を返す
+	assert.Equal(t, "Rust", n.Normalize(" Ｒｕｓｔ "))
+}
+
+func TestParseRules_Error(t *testing.T) {
+	_, err := ParseRules("JavaScript JS")
+	assert.Error(t, err)
+
+	_, err = ParseRules("JavaScript: JS\nTypeScript: JS")
+	assert.Error(t, err)
+}
+
+func TestNormalizer_NormalizeAll(t *testing.T) {
+	rules, err := ParseRules("Go: Golang")
+	require.NoError(t, err)
+
+	n := New(rules)
+	assert.Equal(t, []string{"Go", "Java"}, n.NormalizeAll([]string{"golang", "Java", "", "GO", "ＪＡＶＡ"}))
+	assert.Nil(t, n.NormalizeAll(nil))
+}
+
+// モック: oswrapper
+type mockOsWrapper struct {
+	files map[string]string
+	env   map[string]string
+}
+
+func (m *mockOsWrapper) ReadFile(path string) (string, error) {
+	if text, ok := m.files[path]; ok {
+		return text, nil
+	}
+	return "", errors.New("file not found")
+}
+
+func (m *mockOsWrapper) GetEnv(key string) string {
+	return m.env[key]
+}
+
+func TestLoad(t *testing.T) {
+	// 既定のファイル
+	n, err := Load(&mockOsWrapper{files: map[string]string{DefaultRulesPath: "Go: Golang"}})
+	require.NoError(t, err)
+	assert.Equal(t, "Go", n.Normalize("golang"))
+
+	// 環境変数で指定したファイル
+	n, err = Load(&mockOsWrapper{
+		files: map[string]string{"/tmp/aliases.txt": "TypeScript: TS"},
+		env:   map[string]string{"KEYWORD_ALIASES_PATH": "/tmp/aliases.txt"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "TypeScript", n.Normalize("ts"))
+
+	// 既定のファイルがない場合は別名ルールなし
+	n, err = Load(&mockOsWrapper{})
+	require.NoError(t, err)
+	assert.Equal(t, "golang", n.Normalize("golang"))
+
+	// 指定したファイルがない場合はエラー
+	_, err = Load(&mockOsWrapper{env: map[string]string{"KEYWORD_ALIASES_PATH": "/tmp/none.txt"}})
+	assert.Error(t, err)
+}
+
+func TestDefaultRulesFile(t *testing.T) {
+	// リポジトリに同梱した別名ルールファイルが読み込めること
+	text, err := os.ReadFile("../../dictionary/keyword_aliases.txt")
+	require.NoError(t, err)
+
+	rules, err := ParseRules(string(text))
+	require.NoError(t, err)
+	assert.Equal(t, "JavaScript", New(rules).Normalize("JS"))
+}
@@ -0,0 +1,108 @@
+package keyword
+
+import (
+	"business/tools/oswrapper"
+	"fmt"
+	"strings"
+)
+
+// DefaultRulesPath は別名ルールファイルの既定の配置場所です
+const DefaultRulesPath = "/data/dictionary/keyword_aliases.txt"
+
+// Rules は既知の別名から正規名への対応表です
+type Rules struct {
+	canonical map[string]string // 照合キー → 正規名
+}
+
+// ParseRules は別名ルールファイルの内容を読み込みます
+// 1行に「正規名: 別名, 別名, ...」の形式で記述し、行頭または空白に続く # 以降はコメントとして扱います。
+// 照合は表記を整えたうえで大文字・小文字を区別しません。同じ別名を異なる正規名に割り当てた場合はエラーです。
+func ParseRules(text string) (Rules, error) {
+	rules := Rules{canonical: make(map[string]string)}
+	for i, line := range strings.Split(text, "\n") {
+		if idx := commentIndex(line); idx >= 0 {
+			line = line[:idx]
+		}
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		name, aliases, ok := strings.Cut(line, ":")
+		canonical := strings.TrimSpace(nfkc(name))
+		if !ok || canonical == "" {
+			return Rules{}, fmt.Errorf("別名ルールの%d行目の形式が正しくありません。「正規名: 別名, 別名」の形式で記述してください: %q", i+1, line)
+		}
+
+		words := append([]string{canonical}, strings.Split(aliases, ",")...)
+		for _, word := range words {
+			key := strings.ToLower(strings.TrimSpace(nfkc(word)))
+			if key == "" {
+				continue
+			}
+			if existing, ok := rules.canonical[key]; ok && existing != canonical {
+				return Rules{}, fmt.Errorf("別名ルールの%d行目: %q は既に %q の別名です", i+1, word, existing)
+			}
+			rules.canonical[key] = canonical
+			if cleaned := Key(word); cleaned != "" && cleaned != key {
+				if _, ok := rules.canonical[cleaned]; !ok {
+					rules.canonical[cleaned] = canonical
+				}
+			}
+		}
+	}
+	return rules, nil
+}
+
+// Canonical は照合キーに対応する正規名を返します
+func (r Rules) Canonical(key string) (string, bool) {
+	canonical, ok := r.canonical[key]
+	return canonical, ok
+}
+
+// Len は登録されている照合キーの数を返します
+func (r Rules) Len() int {
+	return len(r.canonical)
+}
+
+// Load は別名ルールファイルを読み込み、Normalizer を作成します
+// ファイルは環境変数 KEYWORD_ALIASES_PATH で指定でき、未指定の場合は DefaultRulesPath を使います。
+// 未指定で既定のファイルがない場合は、別名ルールなしで表記のみ整えます。
+func Load(os oswrapper.OsWapperInterface) (*Normalizer, error) {
+	path := os.GetEnv("KEYWORD_ALIASES_PATH")
+	text, err := os.ReadFile(orDefault(path))
+	if err != nil {
+		if path == "" {
+			return New(Rules{}), nil
+		}
+		return nil, fmt.Errorf("別名ルール読み込みエラー: %w", err)
+	}
+
+	rules, err := ParseRules(text)
+	if err != nil {
+		return nil, err
+	}
+	return New(rules), nil
+}
+
+// commentIndex は行内のコメント（行頭または空白に続く #）の位置を返します
+// "C#" や "F#" のように名前の一部になる # はコメントとして扱いません。
+func commentIndex(line string) int {
+	for i, r := range line {
+		if r != '#' {
+			continue
+		}
+		if i == 0 || line[i-1] == ' ' || line[i-1] == '\t' {
+			return i
+		}
+	}
+	return -1
+}
+
+// orDefault は別名ルールファイルのパスが未指定の場合に既定のパスを返します
+func orDefault(path string) string {
+	if path == "" {
+		return DefaultRulesPath
+	}
+	return path
+}
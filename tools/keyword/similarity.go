@@ -0,0 +1,118 @@
+package keyword
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Levenshtein は2つの文字列の編集距離（挿入・削除・置換の回数）を文字単位で返します
+func Levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) == 0 {
+		return len(rb)
+	}
+	if len(rb) == 0 {
+		return len(ra)
+	}
+
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+// Fold はキーワードを類似度の比較用に畳み込みます
+// 表記を整えて小文字にし、かなをローマ字にしたうえで英数字以外を取り除きます。
+// 末尾の数字（例: "Nuxt3"）と ".js" 接尾辞（例: "Next.js"）も取り除きます。
+// "C#" と "C" のように記号で区別される名前は、記号を読みに置き換えて区別します。
+func Fold(word string) string {
+	s := Key(word)
+	s = strings.NewReplacer("#", "sharp", "+", "plus").Replace(s)
+	s = Romanize(s)
+
+	var b strings.Builder
+	for _, r := range s {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || (r > unicode.MaxASCII && unicode.IsLetter(r)) {
+			b.WriteRune(r)
+		}
+	}
+	s = b.String()
+
+	if trimmed := strings.TrimRight(s, "0123456789"); len(trimmed) >= 2 {
+		s = trimmed
+	}
+	if len(s) > 4 && strings.HasSuffix(s, "js") {
+		s = strings.TrimSuffix(s, "js")
+	}
+	return s
+}
+
+// skeletonReplacer はカタカナ表記とつづりで揺れやすい子音をそろえます
+var skeletonReplacer = strings.NewReplacer("ph", "f", "v", "b", "l", "r", "c", "k", "q", "k", "x", "ks")
+
+// Skeleton は畳み込んだキーワードから先頭以外の母音を除き、子音の並びを返します
+// "ネクスト"（nekusuto）と "Next"（next）のように、カタカナ表記で母音が補われた名前を照合するために使います。
+func Skeleton(folded string) string {
+	s := skeletonReplacer.Replace(folded)
+
+	var b strings.Builder
+	var last rune
+	for i, r := range s {
+		if i > 0 && strings.ContainsRune("aiueoy", r) {
+			continue
+		}
+		if r == last {
+			continue
+		}
+		b.WriteRune(r)
+		last = r
+	}
+	return b.String()
+}
+
+// minSkeletonLength は子音の並びで照合する最小の長さです（短い名前の誤一致を避けます）
+const minSkeletonLength = 3
+
+// skeletonWeight は子音の並びで照合した場合の類似度の重みです
+const skeletonWeight = 0.9
+
+// Similarity は2つのキーワードの類似度を0〜1で返します
+// 畳み込んだ表記の編集距離による類似度と、子音の並びによる類似度（重み0.9）の大きい方を返します。
+func Similarity(a, b string) float64 {
+	fa, fb := Fold(a), Fold(b)
+	if fa == "" || fb == "" {
+		return 0
+	}
+	if fa == fb {
+		return 1
+	}
+
+	score := ratio(fa, fb)
+	sa, sb := Skeleton(fa), Skeleton(fb)
+	if len(sa) >= minSkeletonLength && len(sb) >= minSkeletonLength {
+		score = max(score, ratio(sa, sb)*skeletonWeight)
+	}
+	return score
+}
+
+// ratio は編集距離を長い方の文字数で割った類似度を返します
+func ratio(a, b string) float64 {
+	longest := max(len([]rune(a)), len([]rune(b)))
+	if longest == 0 {
+		return 1
+	}
+	return 1 - float64(Levenshtein(a, b))/float64(longest)
+}
@@ -0,0 +1,62 @@
+package keyword
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLevenshtein(t *testing.T) {
+	assert.Equal(t, 0, Levenshtein("go", "go"))
+	assert.Equal(t, 3, Levenshtein("kitten", "sitting"))
+	assert.Equal(t, 4, Levenshtein("", "ruby"))
+	assert.Equal(t, 2, Levenshtein("ジャバ", "ジャヴァ"))
+}
+
+func TestRomanize(t *testing.T) {
+	tests := map[string]string{
+		"ネクスト":     "nekusuto",
+		"じゃば":      "jaba",
+		"リアクト":     "riakuto",
+		"ジャヴァ":     "java",
+		"ティー":      "ti",
+		"ロケット":     "roketto",
+		"Go言語":     "Go言語",
+		"シェルスクリプト": "sherusukuriputo",
+	}
+	for in, want := range tests {
+		assert.Equal(t, want, Romanize(in), in)
+	}
+}
+
+func TestFold(t *testing.T) {
+	tests := map[string]string{
+		"Next.js": "next",
+		"Nuxt3":   "nuxt",
+		"Vue.js":  "vue",
+		"ＭｙＳＱＬ８":  "mysql",
+		"C#":      "csharp",
+		"C++":     "cplusplus",
+		"C":       "c",
+		"JS":      "js",
+		"ネクスト":    "nekusuto",
+	}
+	for in, want := range tests {
+		assert.Equal(t, want, Fold(in), in)
+	}
+}
+
+func TestSimilarity(t *testing.T) {
+	// 表記ゆれは高い類似度になる
+	assert.Equal(t, 1.0, Similarity("Next", "Next.js"))
+	assert.Equal(t, 1.0, Similarity("Nuxt3", "Nuxt.js"))
+	assert.InDelta(t, 0.9, Similarity("ネクスト", "Next"), 0.001)
+	assert.InDelta(t, 0.8, Similarity("Postgres", "PostgreSQL"), 0.001)
+	assert.Equal(t, 1.0, Similarity("ジャヴァ", "Java"))
+
+	// 別の技術は低い類似度になる
+	assert.Less(t, Similarity("Java", "JavaScript"), 0.8)
+	assert.Less(t, Similarity("C", "C#"), 0.8)
+	assert.Less(t, Similarity("React", "Redux"), 0.8)
+	assert.Equal(t, 0.0, Similarity("", "Go"))
+}
@@ -0,0 +1,386 @@
+// Package location は案件メールの勤務地の表記を都道府県・市区町村・最寄り駅に正規化する機能を提供します。
+// 都道府県・主要な市区・主要駅の辞書はオフラインで使えるようバイナリに埋め込んでいます。
+package location
+
+import (
+	"bufio"
+	"bytes"
+	_ "embed"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/width"
+)
+
+// Station は辞書に登録された駅です
+type Station struct {
+	Name       string  `json:"name"`
+	Prefecture string  `json:"prefecture"`
+	City       string  `json:"city"`
+	Lat        float64 `json:"lat"`
+	Lng        float64 `json:"lng"`
+}
+
+// Location は勤務地の表記を正規化した1拠点です
+// Lat・Lng は駅または市区町村まで分かった場合のみ設定します（駅が分かれば駅の座標）。
+// Remote はフルリモートの記載がある場合に true になります。
+type Location struct {
+	Prefecture string   `json:"prefecture,omitempty"`
+	City       string   `json:"city,omitempty"`
+	Station    string   `json:"station,omitempty"`
+	Lat        *float64 `json:"lat,omitempty"`
+	Lng        *float64 `json:"lng,omitempty"`
+	Remote     bool     `json:"remote"`
+}
+
+// earthRadiusKm は距離の計算に使う地球の半径（km）です
+const earthRadiusKm = 6371.0
+
+var (
+	//go:embed data/prefectures.tsv
+	prefectureData []byte
+	//go:embed data/cities.tsv
+	cityData []byte
+	//go:embed data/stations.tsv
+	stationData []byte
+)
+
+// fullRemoteWords は出社が無いことを表す語です
+var fullRemoteWords = []string{"フルリモート", "完全リモート", "フルリモ", "完全在宅", "フル在宅", "リモートのみ", "在宅のみ", "出社なし", "出社無し"}
+
+// partialRemoteWords は一部リモートを表す語です（これらを含む場合は "リモート" だけではフルリモートとみなしません）
+var partialRemoteWords = []string{"一部", "併用", "週", "ハイブリッド", "相談", "可"}
+
+type kind int
+
+const (
+	kindPrefecture kind = iota + 1
+	kindCity
+	kindStation
+)
+
+type city struct {
+	prefecture string
+	name       string
+	lat, lng   float64
+}
+
+type entry struct {
+	kind  kind
+	index int
+}
+
+type dictionary struct {
+	prefectures []string
+	prefAlias   map[string]string
+	cities      []city
+	stations    []Station
+	stationKeys map[string][]int
+	entries     map[string][]entry
+	keys        []string // 長い順
+}
+
+var dict = load()
+
+// Normalize は勤務地の表記を拠点ごとの都道府県・市区町村・駅に正規化します
+// "渋谷 or 新宿" のように複数の拠点が書かれている場合は出現順に複数返します。
+// 辞書に無い表記しか無い場合は空、フルリモートのみの場合は Remote だけの1件を返します。
+func Normalize(text string) []Location {
+	s := normalizeText(text)
+	if s == "" {
+		return nil
+	}
+	remote := isFullRemote(s)
+
+	matches := dict.scan(s)
+	var sites []Location
+	var levels []kind
+	for _, m := range matches {
+		loc, level := dict.resolve(m, sites, matches)
+		if n := len(sites) - 1; n >= 0 && !conflicts(sites[n], loc) {
+			sites[n], levels[n] = merge(sites[n], levels[n], loc, level)
+			continue
+		}
+		sites = append(sites, loc)
+		levels = append(levels, level)
+	}
+
+	var result []Location
+	for _, l := range sites {
+		l.Remote = remote
+		if !containsLocation(result, l) {
+			result = append(result, l)
+		}
+	}
+	if len(result) == 0 && remote {
+		result = append(result, Location{Remote: true})
+	}
+	return result
+}
+
+// FindStation は駅名（"駅" の有無・別名・先頭の都道府県名を問わない）から駅を探します
+// 同名の駅が複数ある場合は都道府県名が先頭に付いていればその都道府県の駅、無ければ辞書の先頭の駅を返します。
+func FindStation(name string) (Station, bool) {
+	s := normalizeText(name)
+	pref := ""
+	for _, p := range dict.prefectures {
+		if strings.HasPrefix(s, p) {
+			pref, s = p, strings.TrimPrefix(s, p)
+			break
+		}
+	}
+	s = strings.TrimSuffix(s, "駅")
+	indexes := dict.stationKeys[s]
+	if len(indexes) == 0 {
+		return Station{}, false
+	}
+	for _, i := range indexes {
+		if dict.stations[i].Prefecture == pref {
+			return dict.stations[i], true
+		}
+	}
+	return dict.stations[indexes[0]], true
+}
+
+// NormalizePrefecture は都道府県名または略称（"東京"、"都内" など）を正式名に変換します
+func NormalizePrefecture(name string) (string, bool) {
+	p, ok := dict.prefAlias[normalizeText(name)]
+	return p, ok
+}
+
+// Distance は2点間の距離（km）を返します
+func Distance(lat1, lng1, lat2, lng2 float64) float64 {
+	rad := func(d float64) float64 { return d * math.Pi / 180 }
+	dLat := rad(lat2 - lat1)
+	dLng := rad(lng2 - lng1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(rad(lat1))*math.Cos(rad(lat2))*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// normalizeText は全角英数を半角に揃え、空白を取り除きます
+func normalizeText(text string) string {
+	s := width.Fold.String(text)
+	return strings.Join(strings.Fields(s), "")
+}
+
+// isFullRemote はフルリモートの記載があるかどうかを返します
+func isFullRemote(s string) bool {
+	for _, w := range fullRemoteWords {
+		if strings.Contains(s, w) {
+			return true
+		}
+	}
+	if !strings.Contains(s, "リモート") && !strings.Contains(s, "在宅") {
+		return false
+	}
+	for _, w := range partialRemoteWords {
+		if strings.Contains(s, w) {
+			return false
+		}
+	}
+	// "リモート" のみの表記（地名を含まない）はフルリモートとみなす
+	return len(dict.scan(s)) == 0
+}
+
+// scan は表記を先頭から最長一致で辞書の語に分割し、一致した語を出現順に返します
+func (d *dictionary) scan(s string) [][]entry {
+	var matches [][]entry
+	for i := 0; i < len(s); {
+		key := ""
+		for _, k := range d.keys {
+			if strings.HasPrefix(s[i:], k) {
+				key = k
+				break
+			}
+		}
+		if key == "" {
+			_, size := utf8.DecodeRuneInString(s[i:])
+			i += size
+			continue
+		}
+		matches = append(matches, d.entries[key])
+		i += len(key)
+	}
+	return matches
+}
+
+// resolve は一致した語を拠点に変換します
+// 同じ表記の候補が複数ある場合（大阪と東京の "京橋" など）は、直前の拠点または他の語の都道府県に合う候補を選びます。
+func (d *dictionary) resolve(candidates []entry, sites []Location, matches [][]entry) (Location, kind) {
+	e := candidates[0]
+	if len(candidates) > 1 {
+		var prefs []string
+		if n := len(sites) - 1; n >= 0 && sites[n].Prefecture != "" {
+			prefs = append(prefs, sites[n].Prefecture)
+		}
+		for _, m := range matches {
+			if len(m) == 1 {
+				prefs = append(prefs, d.location(m[0]).Prefecture)
+			}
+		}
+	found:
+		for _, p := range prefs {
+			for _, c := range candidates {
+				if d.location(c).Prefecture == p {
+					e = c
+					break found
+				}
+			}
+		}
+	}
+	return d.location(e), e.kind
+}
+
+// location は辞書の語を拠点に変換します
+func (d *dictionary) location(e entry) Location {
+	switch e.kind {
+	case kindStation:
+		s := d.stations[e.index]
+		return Location{Prefecture: s.Prefecture, City: s.City, Station: s.Name, Lat: float64Ptr(s.Lat), Lng: float64Ptr(s.Lng)}
+	case kindCity:
+		c := d.cities[e.index]
+		return Location{Prefecture: c.prefecture, City: c.name, Lat: float64Ptr(c.lat), Lng: float64Ptr(c.lng)}
+	default:
+		return Location{Prefecture: d.prefectures[e.index]}
+	}
+}
+
+// conflicts は拠点 l が拠点 site と別の拠点かどうかを返します
+// 駅が2つ並んだ場合、または都道府県・市区町村が食い違う場合は別の拠点とみなします。
+func conflicts(site, l Location) bool {
+	if site.Station != "" && l.Station != "" {
+		return site.Station != l.Station
+	}
+	if site.Prefecture != "" && l.Prefecture != "" && site.Prefecture != l.Prefecture {
+		return true
+	}
+	// "大阪市" と "大阪市北区" は同じ市として扱う
+	return site.City != "" && l.City != "" && !strings.HasPrefix(site.City, l.City) && !strings.HasPrefix(l.City, site.City)
+}
+
+// merge は同じ拠点の語を合わせ、より詳しい語の座標を使います
+func merge(site Location, siteLevel kind, l Location, level kind) (Location, kind) {
+	if site.Prefecture == "" {
+		site.Prefecture = l.Prefecture
+	}
+	if len(l.City) > len(site.City) {
+		site.City = l.City
+	}
+	if site.Station == "" {
+		site.Station = l.Station
+	}
+	if level > siteLevel {
+		site.Lat, site.Lng = l.Lat, l.Lng
+		siteLevel = level
+	}
+	return site, siteLevel
+}
+
+func containsLocation(locs []Location, l Location) bool {
+	for _, x := range locs {
+		if x.Prefecture == l.Prefecture && x.City == l.City && x.Station == l.Station {
+			return true
+		}
+	}
+	return false
+}
+
+func float64Ptr(v float64) *float64 {
+	return &v
+}
+
+// load は埋め込みの辞書を読み込みます
+// 辞書の誤りはビルド時に同梱されるため、読み込めない行は panic します。
+func load() *dictionary {
+	d := &dictionary{
+		prefAlias:   map[string]string{},
+		stationKeys: map[string][]int{},
+		entries:     map[string][]entry{},
+	}
+	add := func(key string, e entry) {
+		if key != "" {
+			d.entries[key] = append(d.entries[key], e)
+		}
+	}
+
+	for _, cols := range readTSV(prefectureData, 2) {
+		i := len(d.prefectures)
+		d.prefectures = append(d.prefectures, cols[0])
+		for _, name := range append([]string{cols[0]}, splitAliases(cols[1])...) {
+			d.prefAlias[name] = cols[0]
+			add(name, entry{kind: kindPrefecture, index: i})
+		}
+	}
+	for _, cols := range readTSV(cityData, 5) {
+		i := len(d.cities)
+		d.cities = append(d.cities, city{prefecture: cols[0], name: cols[1], lat: parseFloat(cols[3]), lng: parseFloat(cols[4])})
+		for _, name := range append([]string{cols[1]}, splitAliases(cols[2])...) {
+			add(name, entry{kind: kindCity, index: i})
+		}
+	}
+	for _, cols := range readTSV(stationData, 6) {
+		i := len(d.stations)
+		d.stations = append(d.stations, Station{Name: cols[0], Prefecture: cols[2], City: cols[3], Lat: parseFloat(cols[4]), Lng: parseFloat(cols[5])})
+		for _, name := range append([]string{cols[0]}, splitAliases(cols[1])...) {
+			d.stationKeys[name] = append(d.stationKeys[name], i)
+			add(name+"駅", entry{kind: kindStation, index: i})
+			// 1文字の駅名（"栄"、"柏" など）は "駅" が付いている場合のみ駅とみなす
+			if utf8.RuneCountInString(name) > 1 {
+				add(name, entry{kind: kindStation, index: i})
+			}
+		}
+	}
+
+	for key, es := range d.entries {
+		// 同じ表記は都道府県 > 市区町村 > 駅の順に優先する（"東京" は東京都、"東京駅" は駅）
+		sort.SliceStable(es, func(a, b int) bool { return es[a].kind < es[b].kind })
+		d.entries[key] = es
+		d.keys = append(d.keys, key)
+	}
+	sort.Slice(d.keys, func(a, b int) bool {
+		if len(d.keys[a]) != len(d.keys[b]) {
+			return len(d.keys[a]) > len(d.keys[b])
+		}
+		return d.keys[a] < d.keys[b]
+	})
+	return d
+}
+
+// readTSV はコメント行（#）と空行を除いたタブ区切りの行を返します
+func readTSV(data []byte, columns int) [][]string {
+	var rows [][]string
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for sc.Scan() {
+		line := sc.Text()
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		cols := strings.Split(line, "\t")
+		if len(cols) != columns {
+			panic("location: 辞書の列数が不正です: " + line)
+		}
+		rows = append(rows, cols)
+	}
+	return rows
+}
+
+func splitAliases(s string) []string {
+	var aliases []string
+	for _, a := range strings.Split(s, ",") {
+		if a = strings.TrimSpace(a); a != "" {
+			aliases = append(aliases, a)
+		}
+	}
+	return aliases
+}
+
+func parseFloat(s string) float64 {
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		panic("location: 辞書の座標が不正です: " + s)
+	}
+	return v
+}
@@ -0,0 +1,112 @@
+package location
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// names は拠点を "都道府県/市区町村/駅" の文字列にします（座標は別に確認する）
+func names(locs []Location) []string {
+	var s []string
+	for _, l := range locs {
+		s = append(s, l.Prefecture+"/"+l.City+"/"+l.Station)
+	}
+	return s
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{name: "駅名から都道府県・区を補うこと", input: "渋谷駅 徒歩5分", expected: []string{"東京都/渋谷区/渋谷"}},
+		{name: "住所と駅を1拠点にまとめること", input: "東京都港区（最寄り：品川駅）", expected: []string{"東京都/港区/品川"}},
+		{name: "複数の拠点を出現順に返すこと", input: "渋谷 or 新宿", expected: []string{"東京都/渋谷区/渋谷", "東京都/新宿区/新宿"}},
+		{name: "都道府県のみを読み取れること", input: "都内（詳細は面談時）", expected: []string{"東京都//"}},
+		{name: "長い駅名を優先すること", input: "新横浜", expected: []string{"神奈川県/横浜市/新横浜"}},
+		{name: "同名の駅は他の語の都道府県に合わせること", input: "大阪府 京橋駅", expected: []string{"大阪府/大阪市/京橋"}},
+		{name: "同名の駅は指定が無ければ辞書の先頭を使うこと", input: "京橋", expected: []string{"東京都/中央区/京橋"}},
+		{name: "1文字の駅名は駅が付いている場合のみ読み取ること", input: "栄駅", expected: []string{"愛知県/名古屋市/栄"}},
+		{name: "全角の英数・空白を無視すること", input: "東京都　２３区内", expected: []string{"東京都//"}},
+		{name: "辞書に無い表記は空になること", input: "要相談", expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, names(Normalize(tt.input)))
+		})
+	}
+}
+
+func TestNormalize_Coordinates(t *testing.T) {
+	locs := Normalize("東京都港区")
+	require.Len(t, locs, 1)
+	require.NotNil(t, locs[0].Lat)
+	assert.InDelta(t, 35.6581, *locs[0].Lat, 0.0001)
+
+	// 駅まで分かれば駅の座標を使う
+	locs = Normalize("港区 品川駅")
+	require.Len(t, locs, 1)
+	assert.InDelta(t, 35.6285, *locs[0].Lat, 0.0001)
+
+	// 都道府県のみの場合は座標を持たない
+	locs = Normalize("神奈川県")
+	require.Len(t, locs, 1)
+	assert.Nil(t, locs[0].Lat)
+	assert.Nil(t, locs[0].Lng)
+}
+
+func TestNormalize_Remote(t *testing.T) {
+	assert.Equal(t, []Location{{Remote: true}}, Normalize("フルリモート"))
+
+	locs := Normalize("完全リモート（初日のみ渋谷）")
+	require.Len(t, locs, 1)
+	assert.True(t, locs[0].Remote)
+	assert.Equal(t, "渋谷", locs[0].Station)
+
+	// 一部リモートはフルリモートとみなさない
+	locs = Normalize("新宿（リモート併用）")
+	require.Len(t, locs, 1)
+	assert.False(t, locs[0].Remote)
+}
+
+func TestFindStation(t *testing.T) {
+	s, ok := FindStation("品川駅")
+	require.True(t, ok)
+	assert.Equal(t, "東京都", s.Prefecture)
+
+	s, ok = FindStation("大阪府京橋")
+	require.True(t, ok)
+	assert.Equal(t, "大阪府", s.Prefecture)
+
+	s, ok = FindStation("お茶の水")
+	require.True(t, ok)
+	assert.Equal(t, "御茶ノ水", s.Name)
+
+	_, ok = FindStation("存在しない駅")
+	assert.False(t, ok)
+}
+
+func TestNormalizePrefecture(t *testing.T) {
+	p, ok := NormalizePrefecture("東京")
+	assert.True(t, ok)
+	assert.Equal(t, "東京都", p)
+
+	p, ok = NormalizePrefecture("大阪府")
+	assert.True(t, ok)
+	assert.Equal(t, "大阪府", p)
+
+	_, ok = NormalizePrefecture("関東")
+	assert.False(t, ok)
+}
+
+func TestDistance(t *testing.T) {
+	// 東京駅〜新宿駅はおよそ6km
+	tokyo, _ := FindStation("東京")
+	shinjuku, _ := FindStation("新宿")
+	assert.InDelta(t, 6.1, Distance(tokyo.Lat, tokyo.Lng, shinjuku.Lat, shinjuku.Lng), 0.3)
+	assert.Equal(t, 0.0, Distance(tokyo.Lat, tokyo.Lng, tokyo.Lat, tokyo.Lng))
+}
@@ -77,10 +77,31 @@ func CreateArrayMigrationSlice() []interface{} {
 		model.WorkTypeWord{},
 		model.Email{},
 		model.EmailProject{},
+		model.EmailProjectFieldEvidence{},
 		model.EmailCandidate{},
 		model.EntryTiming{},
+		model.ProjectLocation{},
+		model.ProjectCluster{},
+		model.ProjectClusterMember{},
 		model.EmailKeywordGroup{},
+		model.EmailCandidateKeywordGroup{},
 		model.EmailPositionGroup{},
 		model.EmailWorkTypeGroup{},
+		model.AnalysisRevision{},
+		model.AnalysisBatch{},
+		model.AnalysisBatchEmail{},
+		model.ApplicationStatusHistory{},
+		model.DictionaryAuditLog{},
+		model.KeywordMergeProposal{},
+		model.KeywordClusteringRun{},
+		model.EngineerProfile{},
+		model.EngineerSkill{},
+		model.SavedSearch{},
+		model.Notification{},
+		model.AlertRun{},
+		model.AnalyticsWeek{},
+		model.AnalyticsSkillWeek{},
+		model.AnalyticsRun{},
+		model.Agency{},
 	}
 }
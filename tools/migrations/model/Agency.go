@@ -0,0 +1,36 @@
+package model
+
+import (
+	"time"
+)
+
+// Agency（営業会社。差出人のメールアドレスのドメインごとの台帳と取り込みのルール、メール・案件の集計）
+type Agency struct {
+	ID                   uint       `gorm:"primaryKey;autoIncrement"`        // オートインクリメントID
+	Domain               string     `gorm:"size:255;not null;uniqueIndex"`   // 差出人のドメイン（小文字）
+	Name                 string     `gorm:"size:255;not null;default:''"`    // 表示名（利用者が設定）
+	Rule                 string     `gorm:"size:20;not null;default:'none'"` // 取り込みのルール（none / block / mute / prioritize）
+	Note                 *string    `gorm:"type:text"`                       // メモ
+	Emails               int        `gorm:"not null;default:0"`              // メール数
+	ProjectEmails        int        `gorm:"not null;default:0"`              // 案件メール数
+	CandidateEmails      int        `gorm:"not null;default:0"`              // 人材メール数
+	Senders              int        `gorm:"not null;default:0"`              // 差出人のメールアドレスの数
+	Projects             int        `gorm:"not null;default:0"`              // 案件数
+	PricedProjects       int        `gorm:"not null;default:0"`              // 単価の記載がある案件数
+	AveragePrice         *int       `gorm:"type:int"`                        // 税別の月額に換算した単価の平均
+	DuplicateProjects    int        `gorm:"not null;default:0"`              // ほかの営業会社からも届いた案件数
+	GoodProjects         int        `gorm:"not null;default:0"`              // いいねを付けた案件数
+	AppliedProjects      int        `gorm:"not null;default:0"`              // 応募した案件数（応募済・面談・決定）
+	InterviewProjects    int        `gorm:"not null;default:0"`              // 面談に進んだ案件数（面談・決定）
+	WonProjects          int        `gorm:"not null;default:0"`              // 決定した案件数
+	FirstReceivedAt      *time.Time // 最初に受信した日時
+	LastReceivedAt       *time.Time // 最後に受信した日時
+	ActiveDays           int        `gorm:"not null;default:0"` // メールが届いた日数
+	ReplyEmails          int        `gorm:"not null;default:0"` // 同じスレッドの2通目以降のメール数
+	ClosedProjects       int        `gorm:"not null;default:0"` // 募集終了の連絡があった案件数
+	RespondedProjects    int        `gorm:"not null;default:0"` // 応募状況を変更した案件数
+	AverageResponseHours *int       `gorm:"type:int"`           // 受信から最初に応募状況を変更するまでの平均時間（時間）
+	MetricsUpdatedAt     *time.Time `gorm:"index"`              // 集計を更新した日時（ルールのみ登録した場合は NULL）
+	CreatedAt            time.Time  // 作成日時
+	UpdatedAt            time.Time  // 更新日時
+}
@@ -0,0 +1,15 @@
+package model
+
+import (
+	"time"
+)
+
+// AlertRun（新着案件の通知の評価の実行履歴）
+type AlertRun struct {
+	ID            uint      `gorm:"primaryKey;autoIncrement"` // オートインクリメントID
+	LastProjectID uint      `gorm:"not null"`                 // 評価済みの案件ID（email_projects.id）の最大値
+	Evaluated     int       `gorm:"not null"`                 // 評価した案件数
+	Created       int       `gorm:"not null"`                 // 作成した通知の数
+	StartedAt     time.Time // 開始日時
+	FinishedAt    time.Time // 終了日時
+}
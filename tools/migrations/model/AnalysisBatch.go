@@ -0,0 +1,40 @@
+package model
+
+import (
+	"time"
+)
+
+// AnalysisBatch（OpenAI Batch APIに登録したバッチ）
+type AnalysisBatch struct {
+	ID              uint                 `gorm:"primaryKey;autoIncrement"`      // オートインクリメントID
+	BatchID         string               `gorm:"size:100;not null;uniqueIndex"` // OpenAIのバッチID
+	InputFileID     string               `gorm:"size:100;not null"`             // 入力ファイルID
+	OutputFileID    *string              `gorm:"size:100"`                      // 出力ファイルID
+	ErrorFileID     *string              `gorm:"size:100"`                      // エラーファイルID
+	Status          string               `gorm:"size:30;not null;index"`        // バッチの状態（validating / in_progress / completed など）
+	RequestCount    int                  `gorm:"not null;default:0"`            // リクエスト数
+	CompletedCount  int                  `gorm:"not null;default:0"`            // 完了リクエスト数
+	FailedCount     int                  `gorm:"not null;default:0"`            // 失敗リクエスト数
+	AnalysisVersion string               `gorm:"size:50"`                       // 解析バージョン
+	CollectedAt     *time.Time           `gorm:"index"`                         // 解析結果を取り込んだ日時
+	CreatedAt       time.Time            // 作成日時
+	UpdatedAt       time.Time            // 更新日時
+	Emails          []AnalysisBatchEmail `gorm:"foreignKey:AnalysisBatchID;constraint:OnDelete:CASCADE"`
+}
+
+// AnalysisBatchEmail（バッチに含めたメール）
+type AnalysisBatchEmail struct {
+	ID              uint      `gorm:"primaryKey;autoIncrement"`         // オートインクリメントID
+	AnalysisBatchID uint      `gorm:"not null;index"`                   // analysis_batches.id
+	GmailID         string    `gorm:"size:255;not null;index"`          // GメールID
+	ThreadID        string    `gorm:"size:255;not null;default:''"`     // Gメールのスレッド
+	Subject         string    `gorm:"type:text;not null"`               // 件名
+	Sender          string    `gorm:"size:255;not null"`                // 送信元（From）
+	ReceivedDate    time.Time `gorm:"not null"`                         // 受信日時
+	Body            string    `gorm:"type:longtext;not null"`           // 本文
+	ChunkCount      int       `gorm:"not null;default:1"`               // 本文の分割数（リクエスト数）
+	Status          string    `gorm:"size:20;not null;default:pending"` // 保存状態（pending / saved / failed）
+	ErrorMessage    *string   `gorm:"type:text"`                        // 失敗理由
+	CreatedAt       time.Time // 作成日時
+	UpdatedAt       time.Time // 更新日時
+}
@@ -0,0 +1,16 @@
+package model
+
+import (
+	"time"
+)
+
+// AnalysisRevision（GメールIDごとの解析結果の履歴）
+type AnalysisRevision struct {
+	ID              uint      `gorm:"primaryKey;autoIncrement"`                         // オートインクリメントID
+	GmailID         string    `gorm:"size:255;not null;uniqueIndex:idx_gmail_revision"` // GメールID
+	Revision        uint      `gorm:"not null;uniqueIndex:idx_gmail_revision"`          // リビジョン番号（GメールID内で1から採番）
+	AnalysisVersion string    `gorm:"size:50;index"`                                    // 解析バージョン
+	ResultJSON      string    `gorm:"type:longtext;not null"`                           // 解析結果（cd.EmailのJSON配列）
+	IsActive        bool      `gorm:"not null;default:false"`                           // 採用中のリビジョンか
+	CreatedAt       time.Time // 作成日時
+}
@@ -0,0 +1,16 @@
+package model
+
+import (
+	"time"
+)
+
+// AnalyticsRun（集計の更新の実行履歴）
+type AnalyticsRun struct {
+	ID            uint      `gorm:"primaryKey;autoIncrement"` // オートインクリメントID
+	LastProjectID uint      `gorm:"not null"`                 // 集計済みの案件ID（email_projects.id）の最大値
+	Weeks         int       `gorm:"not null"`                 // 更新した週の数
+	Projects      int       `gorm:"not null"`                 // 集計した案件数
+	Full          bool      `gorm:"not null"`                 // すべての週を作り直した場合は true
+	StartedAt     time.Time // 開始日時
+	FinishedAt    time.Time // 終了日時
+}
@@ -0,0 +1,22 @@
+package model
+
+import (
+	"time"
+)
+
+// AnalyticsSkillWeek（週ごと・スキルごとの案件数と単価の集計。スキルは言語・フレームワークのキーワードグループとポジショングループ）
+type AnalyticsSkillWeek struct {
+	ID             uint      `gorm:"primaryKey;autoIncrement"`                                           // オートインクリメントID
+	WeekStart      time.Time `gorm:"type:date;not null;uniqueIndex:idx_analytics_skill_week,priority:1"` // 週の開始日（月曜日）
+	Kind           string    `gorm:"size:20;not null;uniqueIndex:idx_analytics_skill_week,priority:2"`   // language / framework / position
+	Name           string    `gorm:"size:255;not null;uniqueIndex:idx_analytics_skill_week,priority:3"`  // キーワードグループ・ポジショングループの名前
+	Projects       int       `gorm:"not null"`                                                           // 案件数
+	PricedProjects int       `gorm:"not null"`                                                           // 単価の記載がある案件数
+	PriceP25       *int      `gorm:"type:int"`                                                           // 税別の月額に換算した単価の25パーセンタイル
+	PriceMedian    *int      `gorm:"type:int"`                                                           // 税別の月額に換算した単価の中央値
+	PriceP75       *int      `gorm:"type:int"`                                                           // 税別の月額に換算した単価の75パーセンタイル
+	PriceP90       *int      `gorm:"type:int"`                                                           // 税別の月額に換算した単価の90パーセンタイル
+	RemoteFull     int       `gorm:"not null"`                                                           // フルリモートの案件数
+	RemotePartial  int       `gorm:"not null"`                                                           // 一部リモートの案件数
+	UpdatedAt      time.Time // 更新日時
+}
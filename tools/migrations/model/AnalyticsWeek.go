@@ -0,0 +1,22 @@
+package model
+
+import (
+	"time"
+)
+
+// AnalyticsWeek（週ごとの案件数・単価・リモート区分の集計。受信日の週（月曜始まり）で集計し、取り込み後に差分を更新する）
+type AnalyticsWeek struct {
+	ID             uint      `gorm:"primaryKey;autoIncrement"`       // オートインクリメントID
+	WeekStart      time.Time `gorm:"type:date;not null;uniqueIndex"` // 週の開始日（月曜日）
+	Projects       int       `gorm:"not null"`                       // 案件数
+	PricedProjects int       `gorm:"not null"`                       // 単価の記載がある案件数
+	PriceP25       *int      `gorm:"type:int"`                       // 税別の月額に換算した単価の25パーセンタイル
+	PriceMedian    *int      `gorm:"type:int"`                       // 税別の月額に換算した単価の中央値
+	PriceP75       *int      `gorm:"type:int"`                       // 税別の月額に換算した単価の75パーセンタイル
+	PriceP90       *int      `gorm:"type:int"`                       // 税別の月額に換算した単価の90パーセンタイル
+	RemoteFull     int       `gorm:"not null"`                       // フルリモートの案件数
+	RemotePartial  int       `gorm:"not null"`                       // 一部リモートの案件数
+	RemoteNone     int       `gorm:"not null"`                       // リモート不可の案件数
+	RemoteUnknown  int       `gorm:"not null"`                       // リモート区分の記載が無い案件数
+	UpdatedAt      time.Time // 更新日時
+}
@@ -0,0 +1,15 @@
+package model
+
+import (
+	"time"
+)
+
+// ApplicationStatusHistory（案件の応募状況の変更履歴）
+type ApplicationStatusHistory struct {
+	ID             uint      `gorm:"primaryKey;autoIncrement"` // オートインクリメントID
+	EmailProjectID uint      `gorm:"not null;index"`           // 案件ID（email_projects.id。1通に載った案件ごとに保持し、再解析でもIDは変わらない）
+	FromStatus     string    `gorm:"size:20;not null"`         // 変更前の応募状況
+	ToStatus       string    `gorm:"size:20;not null"`         // 変更後の応募状況
+	Note           *string   `gorm:"type:text"`                // 変更時点のメモ
+	CreatedAt      time.Time // 変更日時
+}
@@ -0,0 +1,17 @@
+package model
+
+import (
+	"time"
+)
+
+// DictionaryAuditLog（用語辞書の操作履歴）
+type DictionaryAuditLog struct {
+	ID            uint      `gorm:"primaryKey;autoIncrement"` // オートインクリメントID
+	Kind          string    `gorm:"size:20;not null;index"`   // 種類（keyword / position / work_type）
+	Action        string    `gorm:"size:20;not null"`         // 操作（merge / split / add_alias / remove_alias / rename / normalize）
+	GroupID       uint      `gorm:"not null;index"`           // 操作したグループID
+	TargetGroupID *uint     `gorm:"index"`                    // 統合先・分割先のグループID
+	Detail        string    `gorm:"type:text;not null"`       // 操作内容
+	Actor         string    `gorm:"size:100;not null"`        // 操作者（cli / api など）
+	CreatedAt     time.Time `gorm:"index"`                    // 操作日時
+}
@@ -6,27 +6,28 @@ import (
 
 // Email（メール基本情報）
 type Email struct {
-	ID           uint      `gorm:"primaryKey;autoIncrement"` // オートインクリメントID
-	GmailID      string    `gorm:"size:255;index"`           // GメールID
-	Subject      string    `gorm:"type:text;not null"`       // 件名
-	SenderName   string    `gorm:"size:255"`                 // 差出人名
-	SenderEmail  string    `gorm:"size:255;index"`           // メールアドレス
-	ReceivedDate time.Time `gorm:"index"`                    // 受信日
-	Body         *string   `gorm:"type:longtext"`            // 本文
-	Category     string    `gorm:"size:50;index"`            // 種別（案件 / 人材提案）
+	ID           uint      `gorm:"primaryKey;autoIncrement"`                                                             // オートインクリメントID
+	GmailID      string    `gorm:"size:255;not null;uniqueIndex"`                                                        // GメールID（1メール1行）
+	ThreadID     string    `gorm:"size:255;not null;default:'';index"`                                                   // Gメールのスレッド（募集終了の連絡の紐付けに使用）
+	Subject      string    `gorm:"type:text;not null;index:idx_emails_fulltext,class:FULLTEXT,option:WITH PARSER ngram"` // 件名（全文検索対象）
+	SenderName   string    `gorm:"size:255"`                                                                             // 差出人名
+	SenderEmail  string    `gorm:"size:255;index"`                                                                       // メールアドレス
+	ReceivedDate time.Time `gorm:"index"`                                                                                // 受信日
+	Body         *string   `gorm:"type:longtext;index:idx_emails_fulltext,class:FULLTEXT,option:WITH PARSER ngram"`      // 本文（全文検索対象）
+	Category     string    `gorm:"size:50;index"`                                                                        // 種別（案件 / 人材提案）
 
-	IsRead bool `gorm:"not null;default:false"` // 既読
-	IsGood bool `gorm:"not null;default:false"` // いいね
-	IsBad  bool `gorm:"not null;default:false"` // びみょうかも
+	AnalysisVersion  string `gorm:"size:50;index"`      // 解析バージョン
+	AnalysisRevision uint   `gorm:"not null;default:1"` // 採用中の解析リビジョン番号
+
+	IsRead bool    `gorm:"not null;default:false"` // 既読
+	IsGood bool    `gorm:"not null;default:false"` // いいね
+	IsBad  bool    `gorm:"not null;default:false"` // びみょうかも
+	Note   *string `gorm:"type:text"`              // メモ
 
 	CreatedAt time.Time // 作成日時
 	UpdatedAt time.Time // 更新日時
 
 	// 子テーブル
-	EmailProject        *EmailProject        `gorm:"foreignKey:EmailID;references:ID"` // 案件情報（1対1）
-	EmailCandidate      *EmailCandidate      `gorm:"foreignKey:EmailID;references:ID"` // 人材情報（1対1）
-	EntryTimings        []EntryTiming        `gorm:"foreignKey:EmailID;references:ID"` // 入場時期（1対多）
-	EmailKeywordGroups  []EmailKeywordGroup  `gorm:"foreignKey:EmailID;references:ID"` // 技術キーワード（1対多）
-	EmailPositionGroups []EmailPositionGroup `gorm:"foreignKey:EmailID;references:ID"` // ポジション（1対多）
-	EmailWorkTypeGroups []EmailWorkTypeGroup `gorm:"foreignKey:EmailID;references:ID"` // 業務内容（1対多）
+	EmailProjects  []EmailProject  `gorm:"foreignKey:EmailID;references:ID"` // 案件情報（1対多。1通に複数案件が載る場合がある）
+	EmailCandidate *EmailCandidate `gorm:"foreignKey:EmailID;references:ID"` // 人材情報（1対1）
 }
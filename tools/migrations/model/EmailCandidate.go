@@ -6,14 +6,21 @@ import (
 
 // EmailCandidate（人材提案メール専用情報）
 type EmailCandidate struct {
-	ID               uint      `gorm:"primaryKey;autoIncrement"` // オートインクリメントID
-	EmailID          uint      `gorm:"index"`                    // メールID（emails.idと同じ）
-	CandidateName    *string   `gorm:"size:255"`                 // 人材名（仮）
-	ExperienceYears  *int      `gorm:"type:int"`                 // 経験年数
-	SkillsSummary    *string   `gorm:"type:text"`                // 自己紹介・スキルまとめ
-	AvailabilityDate *string   `gorm:"size:255"`                 // 参画可能日
-	CreatedAt        time.Time // 作成日時
-	UpdatedAt        time.Time // 更新日時
+	ID               uint       `gorm:"primaryKey;autoIncrement"`    // オートインクリメントID
+	EmailID          uint       `gorm:"uniqueIndex"`                 // メールID（emails.id。1メール1行）
+	CandidateName    *string    `gorm:"size:255"`                    // 人材名（仮）
+	ExperienceYears  *int       `gorm:"type:int"`                    // 経験年数
+	SkillsSummary    *string    `gorm:"type:text"`                   // 自己紹介・スキルまとめ
+	AvailabilityDate *string    `gorm:"size:255"`                    // 参画可能日
+	AvailableFrom    *time.Time `gorm:"type:date"`                   // 参画可能日を解釈した日付（受信日を基準に解決）
+	AvailableFlag    string     `gorm:"size:20;not null;default:''"` // immediate（即日） / undecided（未定）など
+	Skills           *string    `gorm:"type:text"`                   // 言語・フレームワーク・スキル（カンマ区切り）
+	MonthlyPriceFrom *int       `gorm:"type:int"`                    // 税別の月額に換算した希望単価FROM
+	MonthlyPriceTo   *int       `gorm:"type:int"`                    // 税別の月額に換算した希望単価TO
+	WorkLocation     *string    `gorm:"size:255"`                    // 希望勤務地
+	RemoteType       *string    `gorm:"size:50"`                     // リモートの希望
+	CreatedAt        time.Time  // 作成日時
+	UpdatedAt        time.Time  // 更新日時
 
 	// リレーション
 	Email Email `gorm:"foreignKey:EmailID;references:ID"` // 親メール
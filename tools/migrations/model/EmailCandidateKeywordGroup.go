@@ -0,0 +1,12 @@
+package model
+
+import (
+	"time"
+)
+
+// EmailCandidateKeywordGroup（人材とキーワードの多対多）
+type EmailCandidateKeywordGroup struct {
+	EmailCandidateID uint `gorm:"not null;uniqueIndex:idx_email_candidate_keyword_groups_candidate_group,priority:1"`       // 人材ID（email_candidates.id）
+	KeywordGroupID   uint `gorm:"not null;index;uniqueIndex:idx_email_candidate_keyword_groups_candidate_group,priority:2"` // 同じ人材に同じキーワードは1行
+	CreatedAt        time.Time
+}
@@ -4,10 +4,10 @@ import (
 	"time"
 )
 
-// EmailKeywordGroup（メールとキーワードの多対多）
+// EmailKeywordGroup（案件とキーワードの多対多）
 type EmailKeywordGroup struct {
-	EmailID        uint `gorm:"not null;"`
-	KeywordGroupID uint `gorm:"not null;"`
+	EmailProjectID uint `gorm:"not null;uniqueIndex:idx_email_keyword_groups_project_group,priority:1"` // 案件ID（email_projects.id）
+	KeywordGroupID uint `gorm:"not null;uniqueIndex:idx_email_keyword_groups_project_group,priority:2"` // 同じ案件に同じキーワードは1行
 	CreatedAt      time.Time
 
 	// 循環してて完全に積んでるのでコメントアウト
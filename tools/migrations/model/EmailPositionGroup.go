@@ -1,7 +1,7 @@
 package model
 
-// EmailPositionGroup（メールとポジショングループの中間）
+// EmailPositionGroup（案件とポジショングループの中間）
 type EmailPositionGroup struct {
-	EmailID         uint `gorm:"primaryKey"` // メールID
+	EmailProjectID  uint `gorm:"primaryKey"` // 案件ID（email_projects.id）
 	PositionGroupID uint `gorm:"primaryKey"` // ポジショングループID
 }
@@ -6,9 +6,10 @@ import (
 
 // EmailProject（案件メール専用情報）
 type EmailProject struct {
-	ID           uint    `gorm:"primaryKey;autoIncrement"` // オートインクリメントID
-	EmailID      uint    `gorm:"index"`                    // メールID（emails.idと同じ）
-	ProjectTitle *string `gorm:"size:255"`                 // 案件名
+	ID           uint    `gorm:"primaryKey;autoIncrement"`                                                           // オートインクリメントID
+	EmailID      uint    `gorm:"not null;uniqueIndex:idx_email_project_key,priority:1"`                              // メールID（emails.id）
+	ProjectKey   string  `gorm:"size:40;not null;uniqueIndex:idx_email_project_key,priority:2"`                      // メール内で案件を識別するキー（解析結果のハッシュ）
+	ProjectTitle *string `gorm:"size:255;index:idx_email_projects_fulltext,class:FULLTEXT,option:WITH PARSER ngram"` // 案件名（全文検索対象）
 
 	// 表示用（カンマ区切り）
 	EntryTiming *string `gorm:"type:text"` // 入場時期（"2025/06/01,2025/07/01"）
@@ -20,14 +21,38 @@ type EmailProject struct {
 	WantSkills  *string `gorm:"type:text"` // WANTスキル（"MT,Adobe製品経験"）
 
 	// その他項目
-	EndTiming       *string   `gorm:"size:255"`       // 終了時期
-	WorkLocation    *string   `gorm:"size:255;index"` // 勤務場所
-	PriceFrom       *int      `gorm:"type:int"`       // 単価FROM
-	PriceTo         *int      `gorm:"type:int"`       // 単価TO
-	RemoteType      *string   `gorm:"size:50"`        // リモート区分
-	RemoteFrequency *string   `gorm:"size:255"`       // リモート頻度
-	CreatedAt       time.Time // 作成日時
-	UpdatedAt       time.Time // 更新日時
+	EndTiming         *string    `gorm:"size:255"`                    // 終了時期
+	EndFrom           *time.Time `gorm:"type:date"`                   // 終了時期を解釈した範囲の開始（受信日を基準に解決）
+	EndTo             *time.Time `gorm:"type:date;index"`             // 終了時期を解釈した範囲の終了（上限なしは NULL）
+	EndFlag           string     `gorm:"size:20;not null;default:''"` // ongoing（長期） / undecided（未定）など
+	WorkLocation      *string    `gorm:"size:255;index"`              // 勤務場所
+	PriceFrom         *int       `gorm:"type:int"`                    // 単価FROM
+	PriceTo           *int       `gorm:"type:int"`                    // 単価TO
+	PriceUnit         string     `gorm:"size:10;not null;default:''"` // 単価の単位（monthly / daily / hourly / yearly）。単価FROM/TOはこの単位あたりの円
+	PriceTaxIncluded  *bool      // 税込なら true、税別なら false、記載なしは NULL
+	PriceNegotiable   bool       `gorm:"not null;default:false"`               // スキル見合い・応相談
+	SettlementFrom    *int       `gorm:"type:smallint"`                        // 精算幅の下限（時間）
+	SettlementTo      *int       `gorm:"type:smallint"`                        // 精算幅の上限（時間）
+	MonthlyPriceFrom  *int       `gorm:"type:int;index"`                       // 税別の月額に換算した単価FROM
+	MonthlyPriceTo    *int       `gorm:"type:int;index"`                       // 税別の月額に換算した単価TO
+	RemoteType        *string    `gorm:"size:50"`                              // リモート区分
+	RemoteFrequency   *string    `gorm:"size:255"`                             // リモート頻度
+	ApplicationStatus string     `gorm:"size:20;not null;default:'未対応';index"` // 応募状況（未対応 / 応募済 / 面談 / 見送り / 決定）
+
+	LifecycleStatus    string     `gorm:"size:20;not null;default:'unknown';index"` // 募集状況（open / closed / expired / unknown。lifecycle で判定）
+	LifecycleReason    string     `gorm:"size:255;not null;default:''"`             // 募集状況の判定理由
+	LifecycleChangedAt *time.Time // 募集状況を変更した日時
+	ClosedByEmailID    *uint      // 募集終了の連絡のメールID（emails.id）
+	ArchivedAt         *time.Time `gorm:"index"` // アーカイブした日時（一覧の既定では除外）
+
+	CreatedAt time.Time // 作成日時
+	UpdatedAt time.Time // 更新日時
+
+	// 子テーブル
+	EntryTimings        []EntryTiming        `gorm:"foreignKey:EmailProjectID;references:ID"` // 入場時期（1対多）
+	EmailKeywordGroups  []EmailKeywordGroup  `gorm:"foreignKey:EmailProjectID;references:ID"` // 技術キーワード（1対多）
+	EmailPositionGroups []EmailPositionGroup `gorm:"foreignKey:EmailProjectID;references:ID"` // ポジション（1対多）
+	EmailWorkTypeGroups []EmailWorkTypeGroup `gorm:"foreignKey:EmailProjectID;references:ID"` // 業務内容（1対多）
 
 	// リレーション
 	Email Email `gorm:"foreignKey:EmailID;references:ID"` // 親メール
@@ -0,0 +1,19 @@
+package model
+
+import (
+	"time"
+)
+
+// EmailProjectFieldEvidence（案件の主要項目ごとの信頼度と根拠）
+type EmailProjectFieldEvidence struct {
+	ID             uint      `gorm:"primaryKey;autoIncrement"`                       // オートインクリメントID
+	EmailProjectID uint      `gorm:"not null;uniqueIndex:idx_project_field"`         // 案件ID（email_projects.id）
+	FieldName      string    `gorm:"size:50;not null;uniqueIndex:idx_project_field"` // 項目名（例: "単価FROM"）
+	Confidence     float64   `gorm:"type:decimal(4,3);not null;default:0"`           // 信頼度（0.000〜1.000）
+	SourceText     *string   `gorm:"type:text"`                                      // 根拠となる本文の引用
+	CreatedAt      time.Time // 作成日時
+	UpdatedAt      time.Time // 更新日時
+
+	// リレーション
+	EmailProject EmailProject `gorm:"foreignKey:EmailProjectID;references:ID"` // 親案件
+}
@@ -1,7 +1,7 @@
 package model
 
-// EmailWorkTypeGroup（メールと業務グループの中間）
+// EmailWorkTypeGroup（案件と業務グループの中間）
 type EmailWorkTypeGroup struct {
-	EmailID         uint `gorm:"primaryKey"` // メールID
+	EmailProjectID  uint `gorm:"primaryKey"` // 案件ID（email_projects.id）
 	WorkTypeGroupID uint `gorm:"primaryKey"` // 業務グループID
 }
@@ -0,0 +1,30 @@
+package model
+
+import (
+	"time"
+)
+
+// EngineerProfile（エンジニアのスキルプロフィール。案件との適合度の採点に使用）
+type EngineerProfile struct {
+	ID                   uint       `gorm:"primaryKey;autoIncrement"`       // オートインクリメントID
+	Name                 string     `gorm:"size:100;not null;uniqueIndex"`  // 氏名・イニシャルなど
+	DesiredPrice         *int       `gorm:"type:int"`                       // 希望単価（税別の月額、円）
+	RemotePreference     string     `gorm:"size:20;not null;default:'any'"` // リモートの希望（full / partial / onsite / any）
+	AvailableFrom        *time.Time `gorm:"type:date"`                      // 参画可能日（NULL は即日）
+	PreferredPrefectures string     `gorm:"size:255;not null;default:''"`   // 希望する勤務地の都道府県（カンマ区切り。空はこだわらない）
+	Note                 string     `gorm:"type:text"`                      // メモ
+	CreatedAt            time.Time  // 作成日時
+	UpdatedAt            time.Time  // 更新日時
+
+	// 子テーブル
+	Skills []EngineerSkill `gorm:"foreignKey:ProfileID;references:ID"` // スキル（1対多）
+}
+
+// EngineerSkill（エンジニアのスキルと経験年数）
+type EngineerSkill struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement"`             // オートインクリメントID
+	ProfileID uint      `gorm:"not null;index"`                       // プロフィールID（engineer_profiles.id）
+	Name      string    `gorm:"size:255;not null"`                    // スキル名（キーワードグループの正規名・別名で照合）
+	Years     float64   `gorm:"type:decimal(4,1);not null;default:0"` // 経験年数（0は不明）
+	CreatedAt time.Time // 作成日時
+}
@@ -4,9 +4,13 @@ import (
 	"time"
 )
 
+// EntryTiming（案件の入場時期）
 type EntryTiming struct {
-	EmailID   uint   // ID
-	StartDate string `gorm:";size:20;not null"` // 入場日（例: "2025/06/01"）
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	EmailProjectID uint       `gorm:"not null;index"`              // 案件ID（email_projects.id）
+	StartDate      string     `gorm:";size:20;not null"`           // 入場日（例: "2025/06/01"、"即日"、"7月～"）
+	StartFrom      *time.Time `gorm:"type:date;index"`             // 入場日を解釈した範囲の開始（受信日を基準に解決。下限なしは NULL）
+	StartTo        *time.Time `gorm:"type:date;index"`             // 入場日を解釈した範囲の終了（上限なしは NULL）
+	StartFlag      string     `gorm:"size:20;not null;default:''"` // immediate（即日） / ongoing（随時） / undecided（未定）
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
 }
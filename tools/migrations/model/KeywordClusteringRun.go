@@ -0,0 +1,16 @@
+package model
+
+import (
+	"time"
+)
+
+// KeywordClusteringRun（キーワードのクラスタリングの実行履歴）
+type KeywordClusteringRun struct {
+	ID          uint      `gorm:"primaryKey;autoIncrement"` // オートインクリメントID
+	LastGroupID uint      `gorm:"not null"`                 // 確認済みのキーワードグループIDの最大値
+	Checked     int       `gorm:"not null"`                 // 確認したグループ数
+	Proposed    int       `gorm:"not null"`                 // 作成した統合提案の数
+	UsedLLM     bool      `gorm:"not null;default:false"`   // LLMを使ったか
+	StartedAt   time.Time // 開始日時
+	FinishedAt  time.Time // 終了日時
+}
@@ -0,0 +1,20 @@
+package model
+
+import (
+	"time"
+)
+
+// KeywordMergeProposal（キーワードグループの統合提案。レビューキュー）
+type KeywordMergeProposal struct {
+	ID            uint       `gorm:"primaryKey;autoIncrement"`                                   // オートインクリメントID
+	SourceGroupID uint       `gorm:"not null;uniqueIndex:idx_keyword_merge_proposal,priority:1"` // 統合元（新しいキーワードグループ）
+	TargetGroupID uint       `gorm:"not null;uniqueIndex:idx_keyword_merge_proposal,priority:2"` // 統合先（既存のキーワードグループ）
+	Score         float64    `gorm:"not null"`                                                   // 類似度（0〜1）
+	Method        string     `gorm:"size:20;not null"`                                           // 作成方法（similarity / llm）
+	Reason        string     `gorm:"type:text"`                                                  // 提案理由
+	Status        string     `gorm:"size:20;not null;default:'pending';index"`                   // 状態（pending / accepted / rejected）
+	ReviewedBy    string     `gorm:"size:100"`                                                   // レビューした操作者
+	ReviewedAt    *time.Time // レビュー日時
+	CreatedAt     time.Time  // 作成日時
+	UpdatedAt     time.Time  // 更新日時
+}
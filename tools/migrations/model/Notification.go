@@ -0,0 +1,23 @@
+package model
+
+import (
+	"time"
+)
+
+// Notification（保存した検索条件に一致した新着案件の通知）
+type Notification struct {
+	ID             uint       `gorm:"primaryKey;autoIncrement"`                                              // オートインクリメントID
+	SavedSearchID  uint       `gorm:"not null;uniqueIndex:idx_notification_search_email_project,priority:1"` // 検索条件ID（saved_searches.id）
+	UserID         string     `gorm:"size:100;not null;index"`                                               // 通知先の利用者
+	EmailProjectID uint       `gorm:"not null;uniqueIndex:idx_notification_search_email_project,priority:2"` // 案件ID（email_projects.id。再解析でも変わらない。案件が無くなっても通知は残す）
+	GmailID        string     `gorm:"size:255;not null"`                                                     // GメールID
+	ProjectKey     string     `gorm:"size:40;not null"`                                                      // 通知した時点の案件を識別するキー
+	Title          string     `gorm:"size:255;not null;default:''"`                                          // 通知の件名
+	Message        string     `gorm:"type:text"`                                                             // 通知の本文
+	Status         string     `gorm:"size:20;not null;default:'pending';index"`                              // pending / sent / failed
+	Attempts       int        `gorm:"not null;default:0"`                                                    // 送信を試みた回数
+	LastError      string     `gorm:"type:text"`                                                             // 最後の送信エラー
+	SentAt         *time.Time // 送信日時
+	CreatedAt      time.Time  // 作成日時
+	UpdatedAt      time.Time  // 更新日時
+}
@@ -0,0 +1,32 @@
+package model
+
+import (
+	"time"
+)
+
+// ProjectCluster（案件の重複グループ。複数の営業会社から届いた同じ案件をまとめる）
+type ProjectCluster struct {
+	ID                      uint      `gorm:"primaryKey;autoIncrement"` // オートインクリメントID
+	RepresentativeProjectID uint      `gorm:"not null;index"`           // 代表の案件ID（最も新しく受信した案件。一覧をまとめる場合に表示）
+	Size                    int       `gorm:"not null"`                 // 案件数
+	AgencyCount             int       `gorm:"not null"`                 // 営業会社数（差出人のドメイン数）
+	PriceMin                *int      `gorm:"type:int"`                 // 税別の月額に換算した単価の最小
+	PriceMax                *int      `gorm:"type:int"`                 // 税別の月額に換算した単価の最大
+	FirstReceivedAt         time.Time `gorm:"not null"`                 // 最初に受信した日時
+	LastReceivedAt          time.Time `gorm:"not null"`                 // 最後に受信した日時
+	CreatedAt               time.Time // 作成日時
+	UpdatedAt               time.Time // 更新日時
+}
+
+// ProjectClusterMember（重複グループに含まれる案件。1案件は1グループまで）
+type ProjectClusterMember struct {
+	ID             uint      `gorm:"primaryKey;autoIncrement"`             // オートインクリメントID
+	ClusterID      uint      `gorm:"not null;index"`                       // 重複グループID（project_clusters.id）
+	EmailProjectID uint      `gorm:"not null;uniqueIndex"`                 // 案件ID（email_projects.id）
+	Score          float64   `gorm:"type:decimal(4,3);not null;default:0"` // グループ内の他の案件との最大の類似度
+	CreatedAt      time.Time // 作成日時
+
+	// リレーション
+	Cluster      ProjectCluster `gorm:"foreignKey:ClusterID;references:ID"`      // 重複グループ
+	EmailProject EmailProject   `gorm:"foreignKey:EmailProjectID;references:ID"` // 案件
+}
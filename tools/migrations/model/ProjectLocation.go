@@ -0,0 +1,23 @@
+package model
+
+import (
+	"time"
+)
+
+// ProjectLocation（案件の勤務地。勤務地の表記を都道府県・市区町村・最寄り駅に正規化した拠点ごとの行）
+type ProjectLocation struct {
+	ID             uint      `gorm:"primaryKey;autoIncrement"`          // オートインクリメントID
+	EmailProjectID uint      `gorm:"not null;index"`                    // 案件ID（email_projects.id）
+	SiteNo         int       `gorm:"not null;default:0"`                // 拠点の番号（勤務地の表記に出現した順、0始まり）
+	Prefecture     string    `gorm:"size:10;not null;default:'';index"` // 都道府県（例: "東京都"。フルリモートのみの場合は空）
+	City           string    `gorm:"size:50;not null;default:''"`       // 市区町村（例: "港区"）
+	Station        string    `gorm:"size:50;not null;default:'';index"` // 最寄り駅（"駅" は付けない）
+	Lat            *float64  `gorm:"type:decimal(9,6)"`                 // 緯度（駅が分かれば駅、無ければ市区町村の代表点。都道府県のみは NULL）
+	Lng            *float64  `gorm:"type:decimal(9,6)"`                 // 経度
+	Remote         bool      `gorm:"not null;default:false"`            // フルリモート
+	CreatedAt      time.Time // 作成日時
+	UpdatedAt      time.Time // 更新日時
+
+	// リレーション
+	EmailProject EmailProject `gorm:"foreignKey:EmailProjectID;references:ID"` // 親案件
+}
@@ -0,0 +1,22 @@
+package model
+
+import (
+	"time"
+)
+
+// SavedSearch（利用者ごとに保存した検索条件。新着案件の通知に使用）
+type SavedSearch struct {
+	ID            uint      `gorm:"primaryKey;autoIncrement"`                                 // オートインクリメントID
+	UserID        string    `gorm:"size:100;not null;uniqueIndex:idx_saved_search_user_name"` // 利用者（メールアドレスなど）
+	Name          string    `gorm:"size:100;not null;uniqueIndex:idx_saved_search_user_name"` // 検索条件の名前（利用者ごとに一意）
+	Languages     string    `gorm:"size:255;not null;default:''"`                             // 言語（カンマ区切り）
+	LanguageMatch string    `gorm:"size:10;not null;default:'any'"`                           // 言語の一致条件（any / all）
+	Frameworks    string    `gorm:"size:255;not null;default:''"`                             // フレームワーク（カンマ区切り。いずれかに一致）
+	PriceMin      *int      `gorm:"type:int"`                                                 // 単価の下限（税別の月額に換算した単価TOがこの値以上）
+	PriceMax      *int      `gorm:"type:int"`                                                 // 単価の上限（税別の月額に換算した単価FROMがこの値以下）
+	RemoteTypes   string    `gorm:"size:255;not null;default:''"`                             // リモート区分（カンマ区切り。いずれかに一致）
+	Prefectures   string    `gorm:"size:255;not null;default:''"`                             // 勤務地の都道府県（カンマ区切り。いずれかに一致）
+	Enabled       bool      `gorm:"not null"`                                                 // 通知するか
+	CreatedAt     time.Time // 作成日時（これ以降に保存した案件が通知の対象）
+	UpdatedAt     time.Time // 更新日時
+}
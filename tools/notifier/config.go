@@ -0,0 +1,70 @@
+package notifier
+
+import (
+	"business/tools/oswrapper"
+	"strconv"
+	"strings"
+)
+
+// FromEnv は環境変数で設定した送信先を、再送付きでまとめて返します（設定が無い場合は nil）
+//
+//	NOTIFY_SLACK_WEBHOOK_URL    Slack の Incoming Webhook の URL
+//	NOTIFY_TEAMS_WEBHOOK_URL    Microsoft Teams の Webhook の URL
+//	NOTIFY_DISCORD_WEBHOOK_URL  Discord の Webhook の URL
+//	NOTIFY_WEBHOOK_URL          汎用 JSON Webhook の URL
+//	NOTIFY_WEBHOOK_SECRET       汎用 JSON Webhook の署名の秘密鍵（任意）
+//	NOTIFY_SMTP_ADDR            SMTPサーバー（host:port）
+//	NOTIFY_SMTP_USERNAME        SMTP認証のユーザー名（任意）
+//	NOTIFY_SMTP_PASSWORD        SMTP認証のパスワード（任意）
+//	NOTIFY_SMTP_FROM            差出人
+//	NOTIFY_SMTP_TO              宛先（カンマ区切り）
+//	NOTIFY_RETRY_ATTEMPTS       送信を試みる回数（任意。既定は3）
+func FromEnv(os oswrapper.OsWapperInterface) Channel {
+	channels := ChannelsFromEnv(os)
+	if len(channels) == 0 {
+		return nil
+	}
+	return Multi(channels...)
+}
+
+// ChannelsFromEnv は環境変数で設定した送信先を、再送付きで1つずつ返します（環境変数は FromEnv を参照）
+// 用途に合わせて Name で送信先を選ぶ場合に使います。
+func ChannelsFromEnv(os oswrapper.OsWapperInterface) []Channel {
+	templates := DefaultTemplates()
+	var channels []Channel
+	if url := os.GetEnv("NOTIFY_SLACK_WEBHOOK_URL"); url != "" {
+		channels = append(channels, NewSlack(url, nil, templates))
+	}
+	if url := os.GetEnv("NOTIFY_TEAMS_WEBHOOK_URL"); url != "" {
+		channels = append(channels, NewTeams(url, nil, templates))
+	}
+	if url := os.GetEnv("NOTIFY_DISCORD_WEBHOOK_URL"); url != "" {
+		channels = append(channels, NewDiscord(url, nil, templates))
+	}
+	if url := os.GetEnv("NOTIFY_WEBHOOK_URL"); url != "" {
+		channels = append(channels, NewWebhook(url, os.GetEnv("NOTIFY_WEBHOOK_SECRET"), nil, templates))
+	}
+	if addr := os.GetEnv("NOTIFY_SMTP_ADDR"); addr != "" {
+		var to []string
+		for _, addr := range strings.Split(os.GetEnv("NOTIFY_SMTP_TO"), ",") {
+			if addr = strings.TrimSpace(addr); addr != "" {
+				to = append(to, addr)
+			}
+		}
+		channels = append(channels, NewSMTP(SMTPConfig{
+			Addr:     addr,
+			Username: os.GetEnv("NOTIFY_SMTP_USERNAME"),
+			Password: os.GetEnv("NOTIFY_SMTP_PASSWORD"),
+			From:     os.GetEnv("NOTIFY_SMTP_FROM"),
+			To:       to,
+		}, templates))
+	}
+	policy := DefaultRetryPolicy()
+	if v, err := strconv.Atoi(os.GetEnv("NOTIFY_RETRY_ATTEMPTS")); err == nil && v > 0 {
+		policy.Attempts = v
+	}
+	for i, ch := range channels {
+		channels[i] = WithRetry(ch, policy)
+	}
+	return channels
+}
@@ -0,0 +1,66 @@
+package notifier
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockOsWrapper は環境変数を返すテスト用の oswrapper です
+type mockOsWrapper struct {
+	env map[string]string
+}
+
+func (m *mockOsWrapper) ReadFile(path string) (string, error) {
+	return "", errors.New("file not found")
+}
+
+func (m *mockOsWrapper) GetEnv(key string) string {
+	return m.env[key]
+}
+
+func TestFromEnv(t *testing.T) {
+	assert.Nil(t, FromEnv(&mockOsWrapper{}))
+
+	// 送信先が1つの場合はその送信先を再送付きで返すこと
+	ch := FromEnv(&mockOsWrapper{env: map[string]string{"NOTIFY_SLACK_WEBHOOK_URL": "https://hooks.slack.example.com/x", "NOTIFY_RETRY_ATTEMPTS": "5"}})
+	require.NotNil(t, ch)
+	r, ok := ch.(*retrying)
+	require.True(t, ok)
+	assert.Equal(t, "slack", r.Name())
+	assert.Equal(t, 5, r.policy.Attempts)
+
+	// 複数の送信先をまとめること
+	ch = FromEnv(&mockOsWrapper{env: map[string]string{
+		"NOTIFY_TEAMS_WEBHOOK_URL":   "https://teams.example.com/x",
+		"NOTIFY_DISCORD_WEBHOOK_URL": "https://discord.example.com/x",
+		"NOTIFY_WEBHOOK_URL":         "https://hooks.example.com/x",
+		"NOTIFY_SMTP_ADDR":           "smtp.example.com:587",
+		"NOTIFY_SMTP_FROM":           "alert@example.com",
+		"NOTIFY_SMTP_TO":             "a@example.com, b@example.com",
+	}})
+	channels, ok := ch.(multi)
+	require.True(t, ok)
+	var names []string
+	for _, c := range channels {
+		names = append(names, c.Name())
+	}
+	assert.Equal(t, []string{"teams", "discord", "webhook", "smtp"}, names)
+	assert.Equal(t, []string{"a@example.com", "b@example.com"}, channels[3].(*retrying).ch.(*SMTP).config.To)
+}
+
+func TestChannelsFromEnv(t *testing.T) {
+	assert.Empty(t, ChannelsFromEnv(&mockOsWrapper{}))
+
+	channels := ChannelsFromEnv(&mockOsWrapper{env: map[string]string{
+		"NOTIFY_SLACK_WEBHOOK_URL": "https://hooks.slack.example.com/x",
+		"NOTIFY_WEBHOOK_URL":       "https://hooks.example.com/x",
+	}})
+	require.Len(t, channels, 2)
+	assert.Equal(t, "slack", channels[0].Name())
+	assert.Equal(t, "webhook", channels[1].Name())
+	_, ok := channels[1].(*retrying)
+	assert.True(t, ok)
+}
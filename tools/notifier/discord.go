@@ -0,0 +1,86 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+const (
+	// discordMaxCards は Discord に1回で送る案件カードの上限です（1メッセージの埋め込みは10件まで）
+	discordMaxCards = 10
+	// discordMaxTitle は埋め込みのタイトルの最大文字数です
+	discordMaxTitle = 256
+	// discordMaxContent は本文の最大文字数です
+	discordMaxContent = 2000
+)
+
+// Discord は Discord の Webhook への送信先です
+type Discord struct {
+	url       string
+	client    *http.Client
+	templates *Templates
+}
+
+// NewDiscord は Discord の Webhook への送信先を作成します（client・templates が nil の場合は既定）
+func NewDiscord(webhookURL string, client *http.Client, templates *Templates) *Discord {
+	return &Discord{
+		url:       webhookURL,
+		client:    newHTTPClient(client),
+		templates: templatesOrDefault(templates),
+	}
+}
+
+type discordEmbed struct {
+	Title       string `json:"title"`
+	URL         string `json:"url,omitempty"`
+	Description string `json:"description"`
+}
+
+type discordPayload struct {
+	Content string         `json:"content"`
+	Embeds  []discordEmbed `json:"embeds"`
+}
+
+// Name は送信先の名前を返します
+func (d *Discord) Name() string {
+	return "discord"
+}
+
+// Send は見出しを本文に、案件カードを埋め込みにして通知を送信します
+func (d *Discord) Send(ctx context.Context, m Message) error {
+	content := m.Title
+	if m.Text != "" {
+		content += "\n" + m.Text
+	}
+	cards, omitted := limitCards(m.Cards, discordMaxCards)
+	if omitted > 0 {
+		content += fmt.Sprintf("\n（ほか%d件）", omitted)
+	}
+	payload := discordPayload{Content: truncateRunes(content, discordMaxContent), Embeds: []discordEmbed{}}
+	for _, c := range cards {
+		// タイトルとリンクは埋め込みの見出しに表示するため、本文には含めない
+		title, url := c.Title, c.URL
+		c.Title, c.URL = "", ""
+		text, err := d.templates.Render(FormatMarkdown, c)
+		if err != nil {
+			return &PermanentError{Err: err}
+		}
+		payload.Embeds = append(payload.Embeds, discordEmbed{Title: truncateRunes(title, discordMaxTitle), URL: url, Description: text})
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return &PermanentError{Err: err}
+	}
+	return postJSON(ctx, d.client, d.url, body, nil)
+}
+
+func truncateRunes(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n])
+}
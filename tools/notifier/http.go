@@ -0,0 +1,70 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// DefaultTimeout は Webhook の送信のタイムアウトです
+const DefaultTimeout = 10 * time.Second
+
+// maxErrorBody はエラーに含める応答本文の最大バイト数です
+const maxErrorBody = 512
+
+// HTTPError は Webhook が2xx以外の応答を返した場合のエラーです
+type HTTPError struct {
+	StatusCode int
+	Body       string
+	RetryAfter time.Duration // Retry-After ヘッダーの待ち時間（無い場合は0）
+}
+
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("HTTP %d: %s", e.StatusCode, e.Body)
+}
+
+// Temporary は時間をおいて再送すれば成功する可能性があるかどうかを返します（429 と 5xx）
+func (e *HTTPError) Temporary() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= 500
+}
+
+// newHTTPClient は client が nil の場合に既定のタイムアウトの HTTP クライアントを返します
+func newHTTPClient(client *http.Client) *http.Client {
+	if client == nil {
+		return &http.Client{Timeout: DefaultTimeout}
+	}
+	return client
+}
+
+// postJSON は JSON の本文を POST し、2xx以外の応答は HTTPError を返します
+func postJSON(ctx context.Context, client *http.Client, url string, body []byte, header http.Header) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("リクエスト作成エラー: %w", err)
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("送信エラー: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+
+	b, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+	httpErr := &HTTPError{StatusCode: resp.StatusCode, Body: string(b)}
+	if sec, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && sec > 0 {
+		httpErr.RetryAfter = time.Duration(sec) * time.Second
+	}
+	return httpErr
+}
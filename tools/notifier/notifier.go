@@ -0,0 +1,85 @@
+// Package notifier は Slack・Microsoft Teams・Discord・汎用Webhook・メール（SMTP）への通知の送信を提供します。
+// 通知は案件カード（案件名・単価・スキル・Gメールへのリンクなど）の一覧で、チャネルごとの形式にテンプレートで整形して送信します。
+package notifier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Message は送信する通知です
+type Message struct {
+	Title string `json:"title"`          // 見出し（メールの件名）
+	Text  string `json:"text"`           // 前書き（任意）
+	HTML  string `json:"html,omitempty"` // HTML の本文（任意。メールでは本文のテキストと併せて送信します）
+	Cards []Card `json:"cards"`          // 案件カード
+}
+
+// Card は通知に載せる案件カードです（未設定の項目は表示しません）
+type Card struct {
+	Title      string    `json:"title"`       // 案件名
+	URL        string    `json:"url"`         // Gメールなどへのリンク
+	PriceFrom  *int      `json:"price_from"`  // 単価の下限（税別の月額、円）
+	PriceTo    *int      `json:"price_to"`    // 単価の上限（税別の月額、円）
+	Skills     []string  `json:"skills"`      // 言語・フレームワーク・スキル
+	Location   string    `json:"location"`    // 勤務地
+	Remote     string    `json:"remote"`      // リモート区分
+	Sender     string    `json:"sender"`      // 差出人
+	ReceivedAt time.Time `json:"received_at"` // 受信日時
+	Note       string    `json:"note"`        // 補足
+}
+
+// Channel は通知の送信先です
+type Channel interface {
+	// Name は送信先の名前を返します（エラーの表示に使います）
+	Name() string
+
+	// Send は通知を送信します
+	Send(ctx context.Context, m Message) error
+}
+
+// GmailURL はGメールIDのメールをブラウザで開くURLを返します
+func GmailURL(gmailID string) string {
+	if gmailID == "" {
+		return ""
+	}
+	return "https://mail.google.com/mail/u/0/#all/" + gmailID
+}
+
+// multi は複数の送信先にまとめて送信する送信先です
+type multi []Channel
+
+// Multi は複数の送信先にまとめて送信する送信先を返します
+// すべての送信先に送信し、失敗した送信先のエラーをまとめて返します。送信先が1つの場合はその送信先を返します。
+func Multi(channels ...Channel) Channel {
+	if len(channels) == 1 {
+		return channels[0]
+	}
+	return multi(channels)
+}
+
+// Name は送信先の名前を返します
+func (m multi) Name() string {
+	return "multi"
+}
+
+// Send はすべての送信先に通知を送信します
+func (m multi) Send(ctx context.Context, msg Message) error {
+	var errs []error
+	for _, ch := range m {
+		if err := ch.Send(ctx, msg); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", ch.Name(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// limitCards はカードを max 件までにし、省いた件数を返します
+func limitCards(cards []Card, max int) ([]Card, int) {
+	if len(cards) <= max {
+		return cards, 0
+	}
+	return cards[:max], len(cards) - max
+}
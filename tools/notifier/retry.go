@@ -0,0 +1,108 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+const (
+	// DefaultAttempts は送信を試みる回数の既定値です
+	DefaultAttempts = 3
+	// DefaultBackoff は最初の再送までの待ち時間の既定値です（再送のたびに2倍）
+	DefaultBackoff = time.Second
+	// maxRetryAfter は Retry-After ヘッダーに従って待つ時間の上限です
+	maxRetryAfter = time.Minute
+)
+
+// RetryPolicy は送信に失敗した場合の再送の方針です
+type RetryPolicy struct {
+	Attempts int           // 送信を試みる回数（1以下は再送しない）
+	Backoff  time.Duration // 最初の再送までの待ち時間（再送のたびに2倍）
+}
+
+// DefaultRetryPolicy は既定の再送の方針を返します
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{Attempts: DefaultAttempts, Backoff: DefaultBackoff}
+}
+
+// retrying は送信に失敗した場合に再送する送信先です
+type retrying struct {
+	ch     Channel
+	policy RetryPolicy
+	sleep  func(ctx context.Context, d time.Duration) error
+}
+
+// WithRetry は送信に失敗した場合に方針に従って再送する送信先を返します
+// 429・5xx の応答と通信エラーは再送し、それ以外の応答（4xx）とコンテキストのキャンセルは再送しません。
+// Retry-After ヘッダーがある場合はその時間（最大1分）待ちます。
+func WithRetry(ch Channel, policy RetryPolicy) Channel {
+	return &retrying{ch: ch, policy: policy, sleep: sleepContext}
+}
+
+// Name は送信先の名前を返します
+func (r *retrying) Name() string {
+	return r.ch.Name()
+}
+
+// Send は通知を送信し、失敗した場合は方針に従って再送します
+func (r *retrying) Send(ctx context.Context, m Message) error {
+	backoff := r.policy.Backoff
+	var err error
+	for attempt := 1; ; attempt++ {
+		if err = r.ch.Send(ctx, m); err == nil {
+			return nil
+		}
+		if attempt >= r.policy.Attempts || !retryable(err) {
+			return err
+		}
+
+		wait := backoff
+		var httpErr *HTTPError
+		if errors.As(err, &httpErr) && httpErr.RetryAfter > 0 {
+			wait = min(httpErr.RetryAfter, maxRetryAfter)
+		}
+		if sleepErr := r.sleep(ctx, wait); sleepErr != nil {
+			return err
+		}
+		backoff *= 2
+	}
+}
+
+// retryable は再送すれば成功する可能性があるエラーかどうかを返します
+func retryable(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.Temporary()
+	}
+	var permanent *PermanentError
+	return !errors.As(err, &permanent)
+}
+
+// PermanentError は再送しても成功しないエラーです（設定の誤りなど）
+type PermanentError struct {
+	Err error
+}
+
+func (e *PermanentError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *PermanentError) Unwrap() error {
+	return e.Err
+}
+
+// sleepContext は d の間待ちます（コンテキストがキャンセルされた場合はそのエラーを返します）
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,115 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// statusSequenceServer は呼ばれるたびに statuses を順に返すテスト用のサーバーです
+func statusSequenceServer(t *testing.T, statuses ...int) (*httptest.Server, *int32) {
+	t.Helper()
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		i := atomic.AddInt32(&calls, 1) - 1
+		w.WriteHeader(statuses[min(int(i), len(statuses)-1)])
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+// noSleep は待たずに待ち時間を記録する retrying を返します
+func noSleep(ch Channel, policy RetryPolicy, waits *[]time.Duration) Channel {
+	return &retrying{ch: ch, policy: policy, sleep: func(ctx context.Context, d time.Duration) error {
+		*waits = append(*waits, d)
+		return ctx.Err()
+	}}
+}
+
+func TestWithRetry_RetriesTemporaryErrors(t *testing.T) {
+	server, calls := statusSequenceServer(t, http.StatusServiceUnavailable, http.StatusTooManyRequests, http.StatusOK)
+	var waits []time.Duration
+	ch := noSleep(NewSlack(server.URL, server.Client(), nil), RetryPolicy{Attempts: 3, Backoff: time.Second}, &waits)
+
+	require.NoError(t, ch.Send(context.Background(), Message{Title: "件名"}))
+
+	assert.Equal(t, int32(3), *calls)
+	assert.Equal(t, []time.Duration{time.Second, 2 * time.Second}, waits)
+	assert.Equal(t, "slack", ch.Name())
+}
+
+func TestWithRetry_GivesUp(t *testing.T) {
+	server, calls := statusSequenceServer(t, http.StatusBadGateway)
+	var waits []time.Duration
+	ch := noSleep(NewDiscord(server.URL, server.Client(), nil), RetryPolicy{Attempts: 3, Backoff: time.Millisecond}, &waits)
+
+	err := ch.Send(context.Background(), Message{Title: "件名"})
+
+	var httpErr *HTTPError
+	require.True(t, errors.As(err, &httpErr))
+	assert.Equal(t, http.StatusBadGateway, httpErr.StatusCode)
+	assert.Equal(t, int32(3), *calls)
+}
+
+func TestWithRetry_DoesNotRetryClientErrors(t *testing.T) {
+	server, calls := statusSequenceServer(t, http.StatusNotFound, http.StatusOK)
+	var waits []time.Duration
+	ch := noSleep(NewTeams(server.URL, server.Client(), nil), RetryPolicy{Attempts: 3, Backoff: time.Millisecond}, &waits)
+
+	assert.Error(t, ch.Send(context.Background(), Message{Title: "件名"}))
+	assert.Equal(t, int32(1), *calls)
+	assert.Empty(t, waits)
+}
+
+func TestWithRetry_StopsOnCancel(t *testing.T) {
+	server, calls := statusSequenceServer(t, http.StatusServiceUnavailable)
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := WithRetry(NewSlack(server.URL, server.Client(), nil), RetryPolicy{Attempts: 5, Backoff: time.Hour})
+
+	done := make(chan error)
+	go func() { done <- ch.Send(ctx, Message{Title: "件名"}) }()
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+
+	select {
+	case err := <-done:
+		assert.Error(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("キャンセル後に終了しません")
+	}
+	assert.Equal(t, int32(1), *calls)
+}
+
+// failingChannel は常に err を返すテスト用の送信先です
+type failingChannel struct {
+	name string
+	err  error
+	sent int
+}
+
+func (f *failingChannel) Name() string { return f.name }
+
+func (f *failingChannel) Send(ctx context.Context, m Message) error {
+	f.sent++
+	return f.err
+}
+
+func TestMulti(t *testing.T) {
+	ok := &failingChannel{name: "ok"}
+	ng := &failingChannel{name: "ng", err: errors.New("送信失敗")}
+
+	err := Multi(ok, ng).Send(context.Background(), Message{})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "ng: 送信失敗")
+	assert.Equal(t, 1, ok.sent)
+	assert.Equal(t, 1, ng.sent)
+	assert.Same(t, ok, Multi(ok))
+}
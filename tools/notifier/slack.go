@@ -0,0 +1,78 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// slackMaxCards は Slack に1回で送る案件カードの上限です（1メッセージのブロック数は50まで）
+const slackMaxCards = 20
+
+// Slack は Slack の Incoming Webhook への送信先です
+type Slack struct {
+	url       string
+	client    *http.Client
+	templates *Templates
+}
+
+// NewSlack は Slack の Incoming Webhook への送信先を作成します（client・templates が nil の場合は既定）
+func NewSlack(webhookURL string, client *http.Client, templates *Templates) *Slack {
+	return &Slack{
+		url:       webhookURL,
+		client:    newHTTPClient(client),
+		templates: templatesOrDefault(templates),
+	}
+}
+
+type slackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type slackBlock struct {
+	Type string     `json:"type"`
+	Text *slackText `json:"text,omitempty"`
+}
+
+type slackPayload struct {
+	Text   string       `json:"text"` // 通知のポップアップなどに使う代替テキスト
+	Blocks []slackBlock `json:"blocks"`
+}
+
+// Name は送信先の名前を返します
+func (s *Slack) Name() string {
+	return "slack"
+}
+
+// Send は見出しと案件カードごとのセクションのブロックで通知を送信します
+func (s *Slack) Send(ctx context.Context, m Message) error {
+	payload := slackPayload{Text: m.Title}
+	if m.Title != "" {
+		payload.Blocks = append(payload.Blocks, slackBlock{Type: "header", Text: &slackText{Type: "plain_text", Text: m.Title}})
+	}
+	if m.Text != "" {
+		payload.Blocks = append(payload.Blocks, slackBlock{Type: "section", Text: &slackText{Type: "mrkdwn", Text: escapeSlack(m.Text)}})
+	}
+	cards, omitted := limitCards(m.Cards, slackMaxCards)
+	for _, c := range cards {
+		text, err := s.templates.Render(FormatSlack, c)
+		if err != nil {
+			return &PermanentError{Err: err}
+		}
+		payload.Blocks = append(payload.Blocks,
+			slackBlock{Type: "divider"},
+			slackBlock{Type: "section", Text: &slackText{Type: "mrkdwn", Text: text}},
+		)
+	}
+	if omitted > 0 {
+		payload.Blocks = append(payload.Blocks, slackBlock{Type: "section", Text: &slackText{Type: "mrkdwn", Text: fmt.Sprintf("ほか%d件", omitted)}})
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return &PermanentError{Err: err}
+	}
+	return postJSON(ctx, s.client, s.url, body, nil)
+}
@@ -0,0 +1,148 @@
+package notifier
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// SMTPConfig はメールの送信の設定です
+type SMTPConfig struct {
+	Addr     string   // SMTPサーバー（host:port）
+	Username string   // 認証のユーザー名（空の場合は認証しない）
+	Password string   // 認証のパスワード
+	From     string   // 差出人
+	To       []string // 宛先
+}
+
+// SMTP はメールへの送信先です
+// 通知1件を1通のメールにし、案件カードをテキストで並べた本文で送信します（複数の案件をまとめたダイジェストにも使えます）。
+// 通知に HTML の本文がある場合は、テキストと HTML の multipart/alternative のメールにします。
+// サーバーが STARTTLS に対応している場合は暗号化してから認証・送信します。
+type SMTP struct {
+	config    SMTPConfig
+	templates *Templates
+	now       func() time.Time
+}
+
+// NewSMTP はメールへの送信先を作成します（templates が nil の場合は既定）
+func NewSMTP(config SMTPConfig, templates *Templates) *SMTP {
+	return &SMTP{
+		config:    config,
+		templates: templatesOrDefault(templates),
+		now:       time.Now,
+	}
+}
+
+// Name は送信先の名前を返します
+func (s *SMTP) Name() string {
+	return "smtp"
+}
+
+// Send は通知をメールで送信します
+func (s *SMTP) Send(ctx context.Context, m Message) error {
+	if s.config.Addr == "" || s.config.From == "" || len(s.config.To) == 0 {
+		return &PermanentError{Err: errors.New("SMTPサーバー・差出人・宛先を設定してください")}
+	}
+	body, err := s.templates.renderAll(FormatText, m)
+	if err != nil {
+		return &PermanentError{Err: err}
+	}
+	host, _, err := net.SplitHostPort(s.config.Addr)
+	if err != nil {
+		return &PermanentError{Err: fmt.Errorf("SMTPサーバーの形式が不正です: %w", err)}
+	}
+
+	dialer := &net.Dialer{Timeout: DefaultTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", s.config.Addr)
+	if err != nil {
+		return fmt.Errorf("SMTP接続エラー: %w", err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	} else {
+		_ = conn.SetDeadline(time.Now().Add(DefaultTimeout))
+	}
+	c, err := smtp.NewClient(conn, host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("SMTP接続エラー: %w", err)
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: host}); err != nil {
+			return fmt.Errorf("STARTTLSエラー: %w", err)
+		}
+	}
+	if s.config.Username != "" {
+		if err := c.Auth(smtp.PlainAuth("", s.config.Username, s.config.Password, host)); err != nil {
+			return &PermanentError{Err: fmt.Errorf("SMTP認証エラー: %w", err)}
+		}
+	}
+	if err := c.Mail(s.config.From); err != nil {
+		return fmt.Errorf("SMTP送信エラー: %w", err)
+	}
+	for _, to := range s.config.To {
+		if err := c.Rcpt(to); err != nil {
+			return fmt.Errorf("SMTP送信エラー（%s）: %w", to, err)
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return fmt.Errorf("SMTP送信エラー: %w", err)
+	}
+	if _, err := w.Write(s.buildMail(m.Title, body, m.HTML)); err != nil {
+		return fmt.Errorf("SMTP送信エラー: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("SMTP送信エラー: %w", err)
+	}
+	return c.Quit()
+}
+
+// buildMail はヘッダーと base64 で符号化した UTF-8 の本文のメールを作成します（html が空の場合はテキストのみ）
+func (s *SMTP) buildMail(subject, body, html string) []byte {
+	var b strings.Builder
+	b.WriteString("From: " + s.config.From + "\r\n")
+	b.WriteString("To: " + strings.Join(s.config.To, ", ") + "\r\n")
+	b.WriteString("Subject: " + mime.BEncoding.Encode("UTF-8", subject) + "\r\n")
+	b.WriteString("Date: " + s.now().Format(time.RFC1123Z) + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	if html == "" {
+		writePart(&b, "text/plain", body)
+		return []byte(b.String())
+	}
+
+	b.WriteString("Content-Type: multipart/alternative; boundary=\"" + mailBoundary + "\"\r\n")
+	b.WriteString("\r\n")
+	b.WriteString("--" + mailBoundary + "\r\n")
+	writePart(&b, "text/plain", body)
+	b.WriteString("--" + mailBoundary + "\r\n")
+	writePart(&b, "text/html", html)
+	b.WriteString("--" + mailBoundary + "--\r\n")
+	return []byte(b.String())
+}
+
+// mailBoundary は multipart のパートの区切りです（base64 の本文には現れない文字を含めています）
+const mailBoundary = "=_notifier_alternative_="
+
+// writePart は Content-Type のヘッダーと base64 で符号化した UTF-8 の本文を書き込みます
+func writePart(b *strings.Builder, contentType, body string) {
+	b.WriteString("Content-Type: " + contentType + "; charset=UTF-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: base64\r\n")
+	b.WriteString("\r\n")
+	encoded := base64.StdEncoding.EncodeToString([]byte(strings.ReplaceAll(body, "\n", "\r\n")))
+	for len(encoded) > 76 {
+		b.WriteString(encoded[:76] + "\r\n")
+		encoded = encoded[76:]
+	}
+	b.WriteString(encoded + "\r\n")
+}
@@ -0,0 +1,187 @@
+package notifier
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSMTP は受け取ったコマンドとメールを記録するテスト用の SMTP サーバーです（STARTTLS には対応しない）
+type fakeSMTP struct {
+	addr     string
+	mu       sync.Mutex
+	commands []string
+	data     string
+}
+
+func newFakeSMTP(t *testing.T) *fakeSMTP {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { ln.Close() })
+
+	f := &fakeSMTP{addr: ln.Addr().String()}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go f.serve(conn)
+		}
+	}()
+	return f
+}
+
+func (f *fakeSMTP) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	reply := func(s string) { _, _ = conn.Write([]byte(s + "\r\n")) }
+	reply("220 fake ESMTP")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		f.mu.Lock()
+		f.commands = append(f.commands, line)
+		f.mu.Unlock()
+
+		switch cmd := strings.ToUpper(strings.SplitN(line, " ", 2)[0]); cmd {
+		case "EHLO":
+			reply("250-fake")
+			reply("250 AUTH PLAIN")
+		case "AUTH":
+			reply("235 2.7.0 Authentication successful")
+		case "MAIL", "RCPT":
+			reply("250 OK")
+		case "DATA":
+			reply("354 End data with <CR><LF>.<CR><LF>")
+			var data strings.Builder
+			for {
+				l, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if l == ".\r\n" {
+					break
+				}
+				data.WriteString(l)
+			}
+			f.mu.Lock()
+			f.data = data.String()
+			f.mu.Unlock()
+			reply("250 OK")
+		case "QUIT":
+			reply("221 Bye")
+			return
+		default:
+			reply("502 Command not implemented")
+		}
+	}
+}
+
+func TestSMTP_Send(t *testing.T) {
+	server := newFakeSMTP(t)
+	s := NewSMTP(SMTPConfig{
+		Addr:     server.addr,
+		Username: "user",
+		Password: "pass",
+		From:     "alert@example.com",
+		To:       []string{"a@example.com", "b@example.com"},
+	}, nil)
+	s.now = func() time.Time { return time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC) }
+
+	err := s.Send(context.Background(), Message{Title: "新着案件のお知らせ", Text: "2件の新着案件があります。", Cards: []Card{sampleCard(), {Title: "PHP案件"}}})
+	require.NoError(t, err)
+
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	assert.Contains(t, server.commands, "MAIL FROM:<alert@example.com>")
+	assert.Contains(t, server.commands, "RCPT TO:<a@example.com>")
+	assert.Contains(t, server.commands, "RCPT TO:<b@example.com>")
+	assert.Equal(t, "AUTH PLAIN "+base64.StdEncoding.EncodeToString([]byte("\x00user\x00pass")), server.commands[1])
+
+	msg, err := mail.ReadMessage(strings.NewReader(server.data))
+	require.NoError(t, err)
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	require.NoError(t, err)
+	assert.Equal(t, "新着案件のお知らせ", subject)
+	assert.Equal(t, "a@example.com, b@example.com", msg.Header.Get("To"))
+	assert.Equal(t, "text/plain; charset=UTF-8", msg.Header.Get("Content-Type"))
+
+	body, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(readAll(t, msg), "\r\n", ""))
+	require.NoError(t, err)
+	text := strings.ReplaceAll(string(body), "\r\n", "\n")
+	assert.True(t, strings.HasPrefix(text, "2件の新着案件があります。\n\n■ Go開発 <急募>\n単価: 60万円〜75万円\n"))
+	assert.True(t, strings.HasSuffix(text, "\n\n■ PHP案件"))
+}
+
+func TestSMTP_Send_Config(t *testing.T) {
+	err := NewSMTP(SMTPConfig{Addr: "127.0.0.1:25"}, nil).Send(context.Background(), Message{Title: "件名"})
+
+	var permanent *PermanentError
+	assert.ErrorAs(t, err, &permanent)
+	assert.False(t, retryable(err))
+}
+
+func readAll(t *testing.T, msg *mail.Message) string {
+	t.Helper()
+	var b strings.Builder
+	_, err := bufio.NewReader(msg.Body).WriteTo(&b)
+	require.NoError(t, err)
+	return b.String()
+}
+
+func TestSMTP_Send_HTML(t *testing.T) {
+	server := newFakeSMTP(t)
+	s := NewSMTP(SMTPConfig{Addr: server.addr, From: "digest@example.com", To: []string{"a@example.com"}}, nil)
+
+	err := s.Send(context.Background(), Message{Title: "案件ダイジェスト", Text: "新着案件 3件", HTML: "<h1>新着案件 3件</h1>"})
+	require.NoError(t, err)
+
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	// 認証しないこと
+	for _, c := range server.commands {
+		assert.False(t, strings.HasPrefix(c, "AUTH"))
+	}
+
+	msg, err := mail.ReadMessage(strings.NewReader(server.data))
+	require.NoError(t, err)
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	assert.Equal(t, "multipart/alternative", mediaType)
+
+	mr := multipart.NewReader(msg.Body, params["boundary"])
+	var parts []string
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		data, err := io.ReadAll(part)
+		require.NoError(t, err)
+		body, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(string(data), "\r\n", ""))
+		require.NoError(t, err)
+		parts = append(parts, part.Header.Get("Content-Type")+": "+string(body))
+	}
+	assert.Equal(t, []string{
+		"text/plain; charset=UTF-8: 新着案件 3件",
+		"text/html; charset=UTF-8: <h1>新着案件 3件</h1>",
+	}, parts)
+}
@@ -0,0 +1,101 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// teamsMaxCards は Teams に1回で送る案件カードの上限です（1メッセージは約28KBまで）
+const teamsMaxCards = 20
+
+// Teams は Microsoft Teams の Webhook（Workflows の「Webhook 要求を受信したとき」）への送信先です
+// 通知はアダプティブカードで送信します。
+type Teams struct {
+	url       string
+	client    *http.Client
+	templates *Templates
+}
+
+// NewTeams は Microsoft Teams の Webhook への送信先を作成します（client・templates が nil の場合は既定）
+func NewTeams(webhookURL string, client *http.Client, templates *Templates) *Teams {
+	return &Teams{
+		url:       webhookURL,
+		client:    newHTTPClient(client),
+		templates: templatesOrDefault(templates),
+	}
+}
+
+type teamsElement struct {
+	Type      string         `json:"type"`
+	Text      string         `json:"text,omitempty"`
+	Weight    string         `json:"weight,omitempty"`
+	Size      string         `json:"size,omitempty"`
+	Wrap      bool           `json:"wrap,omitempty"`
+	Separator bool           `json:"separator,omitempty"`
+	Title     string         `json:"title,omitempty"`
+	URL       string         `json:"url,omitempty"`
+	Actions   []teamsElement `json:"actions,omitempty"`
+}
+
+type teamsCard struct {
+	Schema  string         `json:"$schema"`
+	Type    string         `json:"type"`
+	Version string         `json:"version"`
+	Body    []teamsElement `json:"body"`
+}
+
+type teamsAttachment struct {
+	ContentType string    `json:"contentType"`
+	Content     teamsCard `json:"content"`
+}
+
+type teamsPayload struct {
+	Type        string            `json:"type"`
+	Attachments []teamsAttachment `json:"attachments"`
+}
+
+// Name は送信先の名前を返します
+func (t *Teams) Name() string {
+	return "teams"
+}
+
+// Send は見出しと案件カードごとのテキスト・Gメールを開くボタンのアダプティブカードで通知を送信します
+func (t *Teams) Send(ctx context.Context, m Message) error {
+	card := teamsCard{
+		Schema:  "http://adaptivecards.io/schemas/adaptive-card.json",
+		Type:    "AdaptiveCard",
+		Version: "1.4",
+	}
+	if m.Title != "" {
+		card.Body = append(card.Body, teamsElement{Type: "TextBlock", Text: m.Title, Weight: "Bolder", Size: "Medium", Wrap: true})
+	}
+	if m.Text != "" {
+		card.Body = append(card.Body, teamsElement{Type: "TextBlock", Text: m.Text, Wrap: true})
+	}
+	cards, omitted := limitCards(m.Cards, teamsMaxCards)
+	for _, c := range cards {
+		text, err := t.templates.Render(FormatMarkdown, c)
+		if err != nil {
+			return &PermanentError{Err: err}
+		}
+		card.Body = append(card.Body, teamsElement{Type: "TextBlock", Text: text, Wrap: true, Separator: true})
+		if c.URL != "" {
+			card.Body = append(card.Body, teamsElement{Type: "ActionSet", Actions: []teamsElement{{Type: "Action.OpenUrl", Title: "Gメールで開く", URL: c.URL}}})
+		}
+	}
+	if omitted > 0 {
+		card.Body = append(card.Body, teamsElement{Type: "TextBlock", Text: fmt.Sprintf("ほか%d件", omitted), Wrap: true, Separator: true})
+	}
+
+	payload := teamsPayload{
+		Type:        "message",
+		Attachments: []teamsAttachment{{ContentType: "application/vnd.microsoft.card.adaptive", Content: card}},
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return &PermanentError{Err: err}
+	}
+	return postJSON(ctx, t.client, t.url, body, nil)
+}
@@ -0,0 +1,153 @@
+package notifier
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+)
+
+// Format は案件カードを整形する形式です
+type Format string
+
+const (
+	FormatText     Format = "text"     // プレーンテキスト（メール・汎用Webhook）
+	FormatMarkdown Format = "markdown" // Markdown（Teams・Discord）
+	FormatSlack    Format = "slack"    // Slack の mrkdwn
+)
+
+const defaultTextTemplate = `{{with .Title}}■ {{.}}
+{{end}}{{with price .PriceFrom .PriceTo}}単価: {{.}}
+{{end}}{{with .Skills}}スキル: {{join . ", "}}
+{{end}}{{with .Location}}勤務地: {{.}}
+{{end}}{{with .Remote}}リモート: {{.}}
+{{end}}{{with .Sender}}差出人: {{.}}
+{{end}}{{if not .ReceivedAt.IsZero}}受信日: {{date .ReceivedAt}}
+{{end}}{{with .Note}}{{.}}
+{{end}}{{with .URL}}{{.}}
+{{end}}`
+
+const defaultMarkdownTemplate = `{{if .Title}}{{if .URL}}**[{{.Title}}]({{.URL}})**{{else}}**{{.Title}}**{{end}}
+{{end}}{{with price .PriceFrom .PriceTo}}- 単価: {{.}}
+{{end}}{{with .Skills}}- スキル: {{join . ", "}}
+{{end}}{{with .Location}}- 勤務地: {{.}}
+{{end}}{{with .Remote}}- リモート: {{.}}
+{{end}}{{with .Sender}}- 差出人: {{.}}
+{{end}}{{if not .ReceivedAt.IsZero}}- 受信日: {{date .ReceivedAt}}
+{{end}}{{with .Note}}
+{{.}}
+{{end}}`
+
+const defaultSlackTemplate = `{{if .Title}}{{if .URL}}*<{{.URL}}|{{slack .Title}}>*{{else}}*{{slack .Title}}*{{end}}
+{{end}}{{with price .PriceFrom .PriceTo}}• 単価: {{.}}
+{{end}}{{with .Skills}}• スキル: {{slack (join . ", ")}}
+{{end}}{{with .Location}}• 勤務地: {{slack .}}
+{{end}}{{with .Remote}}• リモート: {{slack .}}
+{{end}}{{with .Sender}}• 差出人: {{slack .}}
+{{end}}{{if not .ReceivedAt.IsZero}}• 受信日: {{date .ReceivedAt}}
+{{end}}{{with .Note}}
+{{slack .}}
+{{end}}`
+
+// Templates は形式ごとの案件カードのテンプレートです
+// テンプレートには Card を渡し（見出しを別に表示する送信先では Title・URL を空にして渡します）、price（単価の範囲）・join・date・slack（Slack の特殊文字のエスケープ）を使えます。
+type Templates struct {
+	mu        sync.RWMutex
+	templates map[Format]*template.Template
+}
+
+var funcs = template.FuncMap{
+	"price": FormatPrice,
+	"join":  strings.Join,
+	"date": func(t time.Time) string {
+		return t.Format("2006-01-02 15:04")
+	},
+	"slack": escapeSlack,
+}
+
+// DefaultTemplates は既定のテンプレートを返します
+func DefaultTemplates() *Templates {
+	t := &Templates{templates: map[Format]*template.Template{}}
+	for format, text := range map[Format]string{
+		FormatText:     defaultTextTemplate,
+		FormatMarkdown: defaultMarkdownTemplate,
+		FormatSlack:    defaultSlackTemplate,
+	} {
+		t.templates[format] = template.Must(template.New(string(format)).Funcs(funcs).Parse(text))
+	}
+	return t
+}
+
+// Set は形式のテンプレートを置き換えます
+func (t *Templates) Set(format Format, text string) error {
+	tmpl, err := template.New(string(format)).Funcs(funcs).Parse(text)
+	if err != nil {
+		return fmt.Errorf("テンプレート解析エラー: %w", err)
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.templates[format] = tmpl
+	return nil
+}
+
+// Render は案件カードを形式のテンプレートで整形します
+func (t *Templates) Render(format Format, c Card) (string, error) {
+	t.mu.RLock()
+	tmpl, ok := t.templates[format]
+	t.mu.RUnlock()
+	if !ok {
+		return "", fmt.Errorf("テンプレートがありません: %s", format)
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, c); err != nil {
+		return "", fmt.Errorf("テンプレート実行エラー: %w", err)
+	}
+	return strings.TrimRight(b.String(), "\n"), nil
+}
+
+// renderAll は通知の前書きと案件カードを形式のテンプレートで整形し、空行で区切って返します
+func (t *Templates) renderAll(format Format, m Message) (string, error) {
+	var parts []string
+	if m.Text != "" {
+		parts = append(parts, m.Text)
+	}
+	for _, c := range m.Cards {
+		s, err := t.Render(format, c)
+		if err != nil {
+			return "", err
+		}
+		parts = append(parts, s)
+	}
+	return strings.Join(parts, "\n\n"), nil
+}
+
+// templatesOrDefault は t が nil の場合に既定のテンプレートを返します
+func templatesOrDefault(t *Templates) *Templates {
+	if t == nil {
+		return DefaultTemplates()
+	}
+	return t
+}
+
+// FormatPrice は税別の月額の単価の範囲を万円の表記にします（どちらも不明な場合は空）
+func FormatPrice(from, to *int) string {
+	man := func(yen int) string {
+		return strconv.FormatFloat(float64(yen)/10000, 'f', -1, 64) + "万円"
+	}
+	switch {
+	case from != nil && to != nil && *from != *to:
+		return man(*from) + "〜" + man(*to)
+	case to != nil:
+		return man(*to)
+	case from != nil:
+		return man(*from) + "〜"
+	}
+	return ""
+}
+
+// escapeSlack は Slack の mrkdwn で特別な意味を持つ文字をエスケープします
+func escapeSlack(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
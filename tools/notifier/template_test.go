@@ -0,0 +1,70 @@
+package notifier
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func sampleCard() Card {
+	return Card{
+		Title:      "Go開発 <急募>",
+		URL:        GmailURL("18c1234567890abc"),
+		PriceFrom:  intPtr(600000),
+		PriceTo:    intPtr(750000),
+		Skills:     []string{"Go", "AWS"},
+		Location:   "東京都港区",
+		Remote:     "フルリモート",
+		Sender:     "sales@agency.example.com",
+		ReceivedAt: time.Date(2025, 6, 2, 9, 30, 0, 0, time.UTC),
+	}
+}
+
+func TestTemplates_Render(t *testing.T) {
+	templates := DefaultTemplates()
+
+	text, err := templates.Render(FormatText, sampleCard())
+	require.NoError(t, err)
+	assert.Equal(t, "■ Go開発 <急募>\n単価: 60万円〜75万円\nスキル: Go, AWS\n勤務地: 東京都港区\nリモート: フルリモート\n"+
+		"差出人: sales@agency.example.com\n受信日: 2025-06-02 09:30\nhttps://mail.google.com/mail/u/0/#all/18c1234567890abc", text)
+
+	markdown, err := templates.Render(FormatMarkdown, sampleCard())
+	require.NoError(t, err)
+	assert.Contains(t, markdown, "**[Go開発 <急募>](https://mail.google.com/mail/u/0/#all/18c1234567890abc)**\n- 単価: 60万円〜75万円\n")
+
+	// Slack の特殊文字をエスケープすること
+	slack, err := templates.Render(FormatSlack, sampleCard())
+	require.NoError(t, err)
+	assert.Contains(t, slack, "*<https://mail.google.com/mail/u/0/#all/18c1234567890abc|Go開発 &lt;急募&gt;>*\n• 単価: 60万円〜75万円\n")
+
+	// 未設定の項目は表示しないこと
+	text, err = templates.Render(FormatText, Card{Title: "案件"})
+	require.NoError(t, err)
+	assert.Equal(t, "■ 案件", text)
+}
+
+func TestTemplates_Set(t *testing.T) {
+	templates := DefaultTemplates()
+
+	require.NoError(t, templates.Set(FormatText, "{{.Title}}（{{price .PriceFrom .PriceTo}}）"))
+	text, err := templates.Render(FormatText, sampleCard())
+	require.NoError(t, err)
+	assert.Equal(t, "Go開発 <急募>（60万円〜75万円）", text)
+
+	assert.Error(t, templates.Set(FormatText, "{{.Title"))
+	_, err = templates.Render("unknown", sampleCard())
+	assert.Error(t, err)
+}
+
+func TestFormatPrice(t *testing.T) {
+	assert.Equal(t, "", FormatPrice(nil, nil))
+	assert.Equal(t, "75万円", FormatPrice(nil, intPtr(750000)))
+	assert.Equal(t, "75万円", FormatPrice(intPtr(750000), intPtr(750000)))
+	assert.Equal(t, "62.5万円〜", FormatPrice(intPtr(625000), nil))
+}
@@ -0,0 +1,89 @@
+package notifier
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// SignatureHeader は本文の署名を載せるヘッダーです（"sha256=" に続けて16進数）
+	SignatureHeader = "X-Signature-256"
+	// TimestampHeader は署名した時刻（UNIX秒）を載せるヘッダーです
+	TimestampHeader = "X-Signature-Timestamp"
+)
+
+// Webhook は汎用の JSON Webhook への送信先です
+// 本文は通知（Message）の JSON に、テキスト形式で整形した本文（text_body）を加えたものです。
+// secret を指定した場合、"<タイムスタンプ>.<本文>" の HMAC-SHA256 を SignatureHeader に載せます。
+type Webhook struct {
+	url       string
+	secret    []byte
+	client    *http.Client
+	templates *Templates
+	now       func() time.Time
+}
+
+// NewWebhook は汎用の JSON Webhook への送信先を作成します（secret が空の場合は署名しない。client・templates が nil の場合は既定）
+func NewWebhook(url, secret string, client *http.Client, templates *Templates) *Webhook {
+	return &Webhook{
+		url:       url,
+		secret:    []byte(secret),
+		client:    newHTTPClient(client),
+		templates: templatesOrDefault(templates),
+		now:       time.Now,
+	}
+}
+
+type webhookPayload struct {
+	Message
+	TextBody string `json:"text_body"`
+}
+
+// Name は送信先の名前を返します
+func (w *Webhook) Name() string {
+	return "webhook"
+}
+
+// Send は通知を JSON で送信します
+func (w *Webhook) Send(ctx context.Context, m Message) error {
+	text, err := w.templates.renderAll(FormatText, m)
+	if err != nil {
+		return &PermanentError{Err: err}
+	}
+	if m.Cards == nil {
+		m.Cards = []Card{}
+	}
+	body, err := json.Marshal(webhookPayload{Message: m, TextBody: text})
+	if err != nil {
+		return &PermanentError{Err: err}
+	}
+
+	header := http.Header{}
+	if len(w.secret) > 0 {
+		timestamp := strconv.FormatInt(w.now().Unix(), 10)
+		header.Set(TimestampHeader, timestamp)
+		header.Set(SignatureHeader, "sha256="+Sign(w.secret, timestamp, body))
+	}
+	return postJSON(ctx, w.client, w.url, body, header)
+}
+
+// Sign は "<タイムスタンプ>.<本文>" の HMAC-SHA256 を16進数で返します（受信側の署名の検証にも使えます）
+func Sign(secret []byte, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify は署名ヘッダーの値（"sha256=..."）が本文の署名と一致するかどうかを返します
+func Verify(secret []byte, timestamp string, body []byte, signature string) bool {
+	expected := "sha256=" + Sign(secret, timestamp, body)
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
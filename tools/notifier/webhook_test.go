@@ -0,0 +1,145 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// captureServer は受け取った本文とヘッダーを記録し、status を返すテスト用のサーバーです
+func captureServer(t *testing.T, status int) (*httptest.Server, *[]byte, *http.Header) {
+	t.Helper()
+	var body []byte
+	var header http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		header = r.Header.Clone()
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte("invalid_payload"))
+	}))
+	t.Cleanup(server.Close)
+	return server, &body, &header
+}
+
+func sampleMessage() Message {
+	return Message{Title: "新着案件 2件", Cards: []Card{sampleCard(), {Title: "PHP案件"}}}
+}
+
+func TestSlack_Send(t *testing.T) {
+	server, body, header := captureServer(t, http.StatusOK)
+
+	err := NewSlack(server.URL, server.Client(), nil).Send(context.Background(), sampleMessage())
+	require.NoError(t, err)
+
+	assert.Equal(t, "application/json", header.Get("Content-Type"))
+	var payload slackPayload
+	require.NoError(t, json.Unmarshal(*body, &payload))
+	assert.Equal(t, "新着案件 2件", payload.Text)
+	require.Len(t, payload.Blocks, 5)
+	assert.Equal(t, "header", payload.Blocks[0].Type)
+	assert.Equal(t, "divider", payload.Blocks[1].Type)
+	assert.Contains(t, payload.Blocks[2].Text.Text, "• スキル: Go, AWS")
+	assert.Equal(t, "*PHP案件*", payload.Blocks[4].Text.Text)
+}
+
+func TestSlack_Send_LimitsCards(t *testing.T) {
+	server, body, _ := captureServer(t, http.StatusOK)
+	m := Message{Title: "新着案件"}
+	for i := 0; i < slackMaxCards+3; i++ {
+		m.Cards = append(m.Cards, Card{Title: "案件" + strconv.Itoa(i)})
+	}
+
+	require.NoError(t, NewSlack(server.URL, server.Client(), nil).Send(context.Background(), m))
+
+	var payload slackPayload
+	require.NoError(t, json.Unmarshal(*body, &payload))
+	assert.Len(t, payload.Blocks, 1+2*slackMaxCards+1)
+	assert.Equal(t, "ほか3件", payload.Blocks[len(payload.Blocks)-1].Text.Text)
+}
+
+func TestTeams_Send(t *testing.T) {
+	server, body, _ := captureServer(t, http.StatusAccepted)
+
+	err := NewTeams(server.URL, server.Client(), nil).Send(context.Background(), sampleMessage())
+	require.NoError(t, err)
+
+	var payload teamsPayload
+	require.NoError(t, json.Unmarshal(*body, &payload))
+	assert.Equal(t, "message", payload.Type)
+	require.Len(t, payload.Attachments, 1)
+	card := payload.Attachments[0].Content
+	assert.Equal(t, "AdaptiveCard", card.Type)
+	// 見出し・カード1（テキスト・ボタン）・カード2（テキスト）
+	require.Len(t, card.Body, 4)
+	assert.Equal(t, "新着案件 2件", card.Body[0].Text)
+	assert.Contains(t, card.Body[1].Text, "- 単価: 60万円〜75万円")
+	assert.Equal(t, "Action.OpenUrl", card.Body[2].Actions[0].Type)
+	assert.Equal(t, GmailURL("18c1234567890abc"), card.Body[2].Actions[0].URL)
+}
+
+func TestDiscord_Send(t *testing.T) {
+	server, body, _ := captureServer(t, http.StatusNoContent)
+
+	err := NewDiscord(server.URL, server.Client(), nil).Send(context.Background(), sampleMessage())
+	require.NoError(t, err)
+
+	var payload discordPayload
+	require.NoError(t, json.Unmarshal(*body, &payload))
+	assert.Equal(t, "新着案件 2件", payload.Content)
+	require.Len(t, payload.Embeds, 2)
+	assert.Equal(t, "Go開発 <急募>", payload.Embeds[0].Title)
+	assert.Equal(t, GmailURL("18c1234567890abc"), payload.Embeds[0].URL)
+	// タイトルは埋め込みの見出しに表示し、本文には含めないこと
+	assert.NotContains(t, payload.Embeds[0].Description, "Go開発")
+	assert.Contains(t, payload.Embeds[0].Description, "- スキル: Go, AWS")
+}
+
+func TestWebhook_Send_Signature(t *testing.T) {
+	server, body, header := captureServer(t, http.StatusOK)
+	w := NewWebhook(server.URL, "secret", server.Client(), nil)
+	w.now = func() time.Time { return time.Unix(1750000000, 0) }
+
+	require.NoError(t, w.Send(context.Background(), sampleMessage()))
+
+	assert.Equal(t, "1750000000", header.Get(TimestampHeader))
+	assert.True(t, Verify([]byte("secret"), "1750000000", *body, header.Get(SignatureHeader)))
+	assert.False(t, Verify([]byte("other"), "1750000000", *body, header.Get(SignatureHeader)))
+	var payload webhookPayload
+	require.NoError(t, json.Unmarshal(*body, &payload))
+	assert.Equal(t, "新着案件 2件", payload.Title)
+	assert.Len(t, payload.Cards, 2)
+	assert.Contains(t, payload.TextBody, "■ Go開発 <急募>\n")
+}
+
+func TestWebhook_Send_NoSecret(t *testing.T) {
+	server, _, header := captureServer(t, http.StatusOK)
+
+	require.NoError(t, NewWebhook(server.URL, "", server.Client(), nil).Send(context.Background(), Message{Title: "件名"}))
+
+	assert.Empty(t, header.Get(SignatureHeader))
+}
+
+func TestPostJSON_HTTPError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "2")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	err := NewSlack(server.URL, server.Client(), nil).Send(context.Background(), Message{Title: "件名"})
+
+	var httpErr *HTTPError
+	require.True(t, errors.As(err, &httpErr))
+	assert.Equal(t, http.StatusTooManyRequests, httpErr.StatusCode)
+	assert.Equal(t, 2*time.Second, httpErr.RetryAfter)
+	assert.True(t, httpErr.Temporary())
+}
@@ -0,0 +1,233 @@
+package openai
+
+import (
+	"bufio"
+	cd "business/internal/common/domain"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/openai/openai-go"
+	"github.com/openai/openai-go/option"
+)
+
+// BatchEndpoint はBatch APIで呼び出すエンドポイントです
+const BatchEndpoint = "/v1/chat/completions"
+
+// BatchRequest はBatch APIの入力JSONLの1行分です
+type BatchRequest struct {
+	CustomID string // 出力と突き合わせるためのID
+	Prompt   string
+}
+
+// BatchInfo はBatch APIのバッチの状態です
+type BatchInfo struct {
+	ID           string
+	Status       string // validating / in_progress / finalizing / completed / failed / expired / cancelling / cancelled
+	InputFileID  string
+	OutputFileID string
+	ErrorFileID  string
+	Total        int
+	Completed    int
+	Failed       int
+}
+
+// BatchOutput はBatch APIの出力JSONLの1行分を解析結果に変換したものです
+type BatchOutput struct {
+	CustomID string
+	Results  []cd.AnalysisResult
+	Err      error // リクエスト単位の失敗（APIエラーやJSON変換エラー）
+}
+
+type batchLine struct {
+	CustomID string         `json:"custom_id"`
+	Method   string         `json:"method"`
+	URL      string         `json:"url"`
+	Body     batchChatInput `json:"body"`
+}
+
+type batchChatInput struct {
+	Model    string             `json:"model"`
+	Messages []batchChatMessage `json:"messages"`
+}
+
+type batchChatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type batchOutputLine struct {
+	CustomID string `json:"custom_id"`
+	Response *struct {
+		StatusCode int `json:"status_code"`
+		Body       struct {
+			Choices []struct {
+				Message struct {
+					Content string `json:"content"`
+				} `json:"message"`
+			} `json:"choices"`
+		} `json:"body"`
+	} `json:"response"`
+	Error *struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// NewWithBaseURL は接続先を指定してクライアントを作成します
+// 検証用のサーバーやプロキシを経由する場合に利用します。
+func NewWithBaseURL(apiKey string, baseURL string) *Client {
+	client := openai.NewClient(
+		option.WithAPIKey(apiKey),
+		option.WithBaseURL(baseURL),
+	)
+	return &Client{
+		sdk: &client,
+	}
+}
+
+// BuildBatchJSONL はBatch APIの入力ファイル（JSONL）を作成します
+func BuildBatchJSONL(requests []BatchRequest) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	for _, req := range requests {
+		line := batchLine{
+			CustomID: req.CustomID,
+			Method:   "POST",
+			URL:      BatchEndpoint,
+			Body: batchChatInput{
+				Model:    openai.ChatModelGPT4_1Mini,
+				Messages: []batchChatMessage{{Role: "user", Content: req.Prompt}},
+			},
+		}
+		if err := encoder.Encode(line); err != nil {
+			return nil, fmt.Errorf("バッチ入力の作成エラー: %w", err)
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// SubmitBatch は入力ファイルをアップロードし、バッチを作成します
+func (c *Client) SubmitBatch(ctx context.Context, requests []BatchRequest) (BatchInfo, error) {
+	if len(requests) == 0 {
+		return BatchInfo{}, errors.New("バッチに含めるリクエストがありません")
+	}
+
+	jsonl, err := BuildBatchJSONL(requests)
+	if err != nil {
+		return BatchInfo{}, err
+	}
+
+	file, err := c.sdk.Files.New(ctx, openai.FileNewParams{
+		File:    openai.File(bytes.NewReader(jsonl), "batch_input.jsonl", "application/jsonl"),
+		Purpose: openai.FilePurposeBatch,
+	})
+	if err != nil {
+		return BatchInfo{}, fmt.Errorf("バッチ入力ファイルのアップロードエラー: %w", err)
+	}
+
+	batch, err := c.sdk.Batches.New(ctx, openai.BatchNewParams{
+		CompletionWindow: openai.BatchNewParamsCompletionWindow24h,
+		Endpoint:         openai.BatchNewParamsEndpointV1ChatCompletions,
+		InputFileID:      file.ID,
+	})
+	if err != nil {
+		return BatchInfo{}, fmt.Errorf("バッチ作成エラー: %w", err)
+	}
+
+	return toBatchInfo(batch), nil
+}
+
+// GetBatch はバッチの状態を取得します
+func (c *Client) GetBatch(ctx context.Context, batchID string) (BatchInfo, error) {
+	batch, err := c.sdk.Batches.Get(ctx, batchID)
+	if err != nil {
+		return BatchInfo{}, fmt.Errorf("バッチ状態の取得エラー: %w", err)
+	}
+	return toBatchInfo(batch), nil
+}
+
+// GetBatchOutputs は完了したバッチの出力ファイルとエラーファイルを読み込みます
+func (c *Client) GetBatchOutputs(ctx context.Context, info BatchInfo) ([]BatchOutput, error) {
+	var outputs []BatchOutput
+	for _, fileID := range []string{info.OutputFileID, info.ErrorFileID} {
+		if fileID == "" {
+			continue
+		}
+		lines, err := c.readBatchFile(ctx, fileID)
+		if err != nil {
+			return nil, err
+		}
+		outputs = append(outputs, lines...)
+	}
+	return outputs, nil
+}
+
+// readBatchFile は出力ファイル（JSONL）を1行ずつ解析結果に変換します
+func (c *Client) readBatchFile(ctx context.Context, fileID string) ([]BatchOutput, error) {
+	resp, err := c.sdk.Files.Content(ctx, fileID)
+	if err != nil {
+		return nil, fmt.Errorf("バッチ出力ファイルの取得エラー: %w", err)
+	}
+	defer resp.Body.Close()
+
+	return parseBatchOutputs(resp.Body)
+}
+
+// parseBatchOutputs はBatch APIの出力JSONLを解析結果に変換します
+func parseBatchOutputs(r io.Reader) ([]BatchOutput, error) {
+	scanner := bufio.NewScanner(r)
+	// 1行に解析結果全体が入るため上限を広げる
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+
+	var outputs []BatchOutput
+	for scanner.Scan() {
+		raw := bytes.TrimSpace(scanner.Bytes())
+		if len(raw) == 0 {
+			continue
+		}
+
+		var line batchOutputLine
+		if err := json.Unmarshal(raw, &line); err != nil {
+			return nil, fmt.Errorf("バッチ出力の読み込みエラー: %w", err)
+		}
+
+		output := BatchOutput{CustomID: line.CustomID}
+		switch {
+		case line.Error != nil:
+			output.Err = fmt.Errorf("%s: %s", line.Error.Code, line.Error.Message)
+		case line.Response == nil || line.Response.StatusCode != 200:
+			status := 0
+			if line.Response != nil {
+				status = line.Response.StatusCode
+			}
+			output.Err = fmt.Errorf("バッチリクエストが失敗しました。ステータス: %d", status)
+		case len(line.Response.Body.Choices) == 0:
+			output.Err = errors.New("バッチ出力に解析結果が含まれていません")
+		default:
+			output.Results, output.Err = parseAnalysisResults(line.Response.Body.Choices[0].Message.Content)
+		}
+		outputs = append(outputs, output)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("バッチ出力の読み込みエラー: %w", err)
+	}
+	return outputs, nil
+}
+
+func toBatchInfo(batch *openai.Batch) BatchInfo {
+	return BatchInfo{
+		ID:           batch.ID,
+		Status:       string(batch.Status),
+		InputFileID:  batch.InputFileID,
+		OutputFileID: batch.OutputFileID,
+		ErrorFileID:  batch.ErrorFileID,
+		Total:        int(batch.RequestCounts.Total),
+		Completed:    int(batch.RequestCounts.Completed),
+		Failed:       int(batch.RequestCounts.Failed),
+	}
+}
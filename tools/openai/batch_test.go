@@ -0,0 +1,123 @@
+package openai
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newFakeBatchServer はFiles APIとBatches APIを模したテスト用サーバーを作成します
+func newFakeBatchServer(t *testing.T, output string) (*httptest.Server, *[]string) {
+	t.Helper()
+	var uploaded []string
+
+	batch := func(status string, outputFileID string) string {
+		return fmt.Sprintf(`{"id":"batch_1","object":"batch","endpoint":"/v1/chat/completions","input_file_id":"file_in","completion_window":"24h","created_at":1,"status":%q,"output_file_id":%q,"request_counts":{"total":2,"completed":1,"failed":1}}`, status, outputFileID)
+	}
+
+	mux := http.NewServeMux()
+	withJSON := func(h http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			h(w, r)
+		}
+	}
+	mux.HandleFunc("POST /files", withJSON(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseMultipartForm(1<<20))
+		assert.Equal(t, "batch", r.FormValue("purpose"))
+		file, _, err := r.FormFile("file")
+		require.NoError(t, err)
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			uploaded = append(uploaded, scanner.Text())
+		}
+		fmt.Fprint(w, `{"id":"file_in","object":"file","bytes":1,"created_at":1,"filename":"batch_input.jsonl","purpose":"batch","status":"processed"}`)
+	}))
+	mux.HandleFunc("POST /batches", withJSON(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		assert.Contains(t, string(body), `"input_file_id":"file_in"`)
+		fmt.Fprint(w, batch("validating", ""))
+	}))
+	mux.HandleFunc("GET /batches/batch_1", withJSON(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, batch("completed", "file_out"))
+	}))
+	mux.HandleFunc("GET /files/file_out/content", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, output)
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server, &uploaded
+}
+
+func TestBuildBatchJSONL(t *testing.T) {
+	jsonl, err := BuildBatchJSONL([]BatchRequest{
+		{CustomID: "gmail-1-0", Prompt: "プロンプト<本文>"},
+		{CustomID: "gmail-2-0", Prompt: "プロンプト2"},
+	})
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(string(jsonl)), "\n")
+	require.Len(t, lines, 2)
+
+	var line batchLine
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &line))
+	assert.Equal(t, "gmail-1-0", line.CustomID)
+	assert.Equal(t, "POST", line.Method)
+	assert.Equal(t, BatchEndpoint, line.URL)
+	assert.Equal(t, "プロンプト<本文>", line.Body.Messages[0].Content)
+	// HTMLエスケープされないこと
+	assert.Contains(t, lines[0], "<本文>")
+}
+
+func TestClient_Batch(t *testing.T) {
+	output := strings.Join([]string{
+		`{"id":"req_1","custom_id":"gmail-1-0","response":{"status_code":200,"body":{"choices":[{"message":{"role":"assistant","content":"[{\"メール区分\":\"案件\",\"案件名\":\"Go開発\"}]"}}]}},"error":null}`,
+		`{"id":"req_2","custom_id":"gmail-2-0","response":null,"error":{"code":"server_error","message":"failed"}}`,
+	}, "\n")
+	server, uploaded := newFakeBatchServer(t, output)
+	client := NewWithBaseURL("test-key", server.URL+"/")
+	ctx := t.Context()
+
+	// 入力ファイルをアップロードしてバッチを作成できること
+	info, err := client.SubmitBatch(ctx, []BatchRequest{
+		{CustomID: "gmail-1-0", Prompt: "p1"},
+		{CustomID: "gmail-2-0", Prompt: "p2"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "batch_1", info.ID)
+	assert.Equal(t, "validating", info.Status)
+	assert.Len(t, *uploaded, 2)
+
+	// 状態を取得できること
+	info, err = client.GetBatch(ctx, "batch_1")
+	require.NoError(t, err)
+	assert.Equal(t, "completed", info.Status)
+	assert.Equal(t, "file_out", info.OutputFileID)
+	assert.Equal(t, 2, info.Total)
+
+	// 出力を解析結果に変換できること
+	outputs, err := client.GetBatchOutputs(ctx, info)
+	require.NoError(t, err)
+	require.Len(t, outputs, 2)
+	assert.Equal(t, "gmail-1-0", outputs[0].CustomID)
+	require.NoError(t, outputs[0].Err)
+	require.Len(t, outputs[0].Results, 1)
+	assert.Equal(t, "Go開発", outputs[0].Results[0].ProjectTitle)
+	assert.Equal(t, "gmail-2-0", outputs[1].CustomID)
+	assert.Error(t, outputs[1].Err)
+}
+
+func TestClient_SubmitBatch_Empty(t *testing.T) {
+	client := NewWithBaseURL("test-key", "http://127.0.0.1:0/")
+	_, err := client.SubmitBatch(t.Context(), nil)
+	assert.Error(t, err)
+}
@@ -4,6 +4,7 @@ import (
 	cd "business/internal/common/domain"
 	"context"
 	"encoding/json"
+	"errors"
 	"log"
 
 	"github.com/openai/openai-go"
@@ -46,6 +47,15 @@ func (c *Client) Chat(ctx context.Context, prompt string) ([]cd.AnalysisResult,
 	// 	Strict:      openai.Bool(true),
 	// }
 
+	content, err := c.Complete(ctx, prompt)
+	if err != nil {
+		return nil, err
+	}
+	return parseAnalysisResults(content)
+}
+
+// Complete はプロンプトに対するモデルの応答本文をそのまま返します
+func (c *Client) Complete(ctx context.Context, prompt string) (string, error) {
 	resp, err := c.sdk.Chat.Completions.New(ctx, openai.ChatCompletionNewParams{
 		Model: openai.ChatModelGPT4_1Mini,
 		Messages: []openai.ChatCompletionMessageParamUnion{
@@ -59,10 +69,16 @@ func (c *Client) Chat(ctx context.Context, prompt string) ([]cd.AnalysisResult,
 		// },
 	})
 	if err != nil {
-		return nil, err
+		return "", err
 	}
-	raw := resp.Choices[0].Message.Content
+	if len(resp.Choices) == 0 {
+		return "", errors.New("モデルの応答が空です")
+	}
+	return resp.Choices[0].Message.Content, nil
+}
 
+// parseAnalysisResults はモデルの応答本文を解析結果に変換します
+func parseAnalysisResults(raw string) ([]cd.AnalysisResult, error) {
 	var results []cd.AnalysisResult
 	if err := json.Unmarshal([]byte(raw), &results); err != nil {
 		log.Printf("構造エラー: JSON→構造体変換失敗:\n%s\nエラー: %v", raw, err)
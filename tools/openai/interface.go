@@ -8,3 +8,15 @@ import (
 type UseCaserInterface interface {
 	Chat(ctx context.Context, prompt string) ([]cd.AnalysisResult, error)
 }
+
+// CompleterInterface はプロンプトに対する応答本文をそのまま返すインターフェースです
+type CompleterInterface interface {
+	Complete(ctx context.Context, prompt string) (string, error)
+}
+
+// BatchClientInterface はBatch APIのインターフェースです
+type BatchClientInterface interface {
+	SubmitBatch(ctx context.Context, requests []BatchRequest) (BatchInfo, error)
+	GetBatch(ctx context.Context, batchID string) (BatchInfo, error)
+	GetBatchOutputs(ctx context.Context, info BatchInfo) ([]BatchOutput, error)
+}
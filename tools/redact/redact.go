@@ -0,0 +1,206 @@
+// Package redact はメール本文を外部APIへ送信する前に個人情報を伏せ字にする機能を提供します。
+// 伏せ字と元の値の対応表はローカルにのみ保持し、解析結果の復元に使用します。
+package redact
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Category は伏せ字にする個人情報の種類です
+type Category string
+
+const (
+	CategoryEmail Category = "email" // メールアドレス
+	CategoryPhone Category = "phone" // 電話番号
+	CategoryURL   Category = "url"   // トラッキング用トークン付きURL
+	CategoryName  Category = "name"  // 人材メールの氏名
+)
+
+// Config は種類ごとに伏せ字にするかを表します
+type Config struct {
+	Email      bool
+	Phone      bool
+	URL        bool
+	PersonName bool
+}
+
+// DefaultConfig はすべての種類を伏せ字にする設定を返します
+func DefaultConfig() Config {
+	return Config{Email: true, Phone: true, URL: true, PersonName: true}
+}
+
+// ParseConfig はカンマ区切りの種類（例: "email,phone"）から設定を生成します
+// 空文字の場合はすべて有効、"none" の場合はすべて無効とします。
+func ParseConfig(value string) Config {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return DefaultConfig()
+	}
+
+	var config Config
+	for _, v := range strings.Split(value, ",") {
+		switch Category(strings.ToLower(strings.TrimSpace(v))) {
+		case CategoryEmail:
+			config.Email = true
+		case CategoryPhone:
+			config.Phone = true
+		case CategoryURL:
+			config.URL = true
+		case CategoryName:
+			config.PersonName = true
+		}
+	}
+	return config
+}
+
+var (
+	emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9\-]+(\.[A-Za-z0-9\-]+)*\.[A-Za-z]{2,}`)
+	// 前後が数字でない 0始まり（または+81始まり）の10〜11桁の電話番号
+	phonePattern = regexp.MustCompile(`(^|[^0-9０-９])((?:\+81[-\s]?|0|０)[0-9０-９]{1,4}[-－‐ー−\s(（)）]{0,2}[0-9０-９]{1,4}[-－‐ー−\s(（)）]{0,2}[0-9０-９]{3,4})([^0-9０-９]|$)`)
+	urlPattern   = regexp.MustCompile(`https?://[^\s<>"'）)」】]+`)
+	// 長いランダム文字列（トラッキングトークン）を含むパス
+	tokenPathPattern = regexp.MustCompile(`/[A-Za-z0-9_\-]{24,}`)
+	// 人材メールの氏名欄（例: "氏名：山田 太郎", "要員名: 佐藤花子"）
+	namePattern = regexp.MustCompile(`((?:氏名|お名前|名前|要員名|人材名|候補者名|候補者)\s*[:：]\s*)([^\s\n（(【/／,、]+(?:[ 　][^\s\n（(【/／,、]+)?)`)
+	// 人材メールと判定するための語句
+	candidateMailWords = []string{"人材", "要員", "スキルシート", "ご提案", "ご紹介", "エンジニア紹介"}
+)
+
+// Redactor は個人情報を伏せ字にします
+type Redactor struct {
+	config Config
+}
+
+// New は設定から Redactor を生成します
+func New(config Config) *Redactor {
+	return &Redactor{config: config}
+}
+
+// Mapping は伏せ字と元の値の対応表です
+type Mapping struct {
+	originals map[string]string // 伏せ字 -> 元の値
+	tokens    map[string]string // 元の値 -> 伏せ字
+	counts    map[Category]int
+}
+
+func newMapping() *Mapping {
+	return &Mapping{
+		originals: map[string]string{},
+		tokens:    map[string]string{},
+		counts:    map[Category]int{},
+	}
+}
+
+// Len は伏せ字にした値の件数を返します
+func (m *Mapping) Len() int {
+	return len(m.originals)
+}
+
+// Original は伏せ字に対応する元の値を返します
+func (m *Mapping) Original(token string) (string, bool) {
+	v, ok := m.originals[token]
+	return v, ok
+}
+
+// Restore は文字列中の伏せ字を元の値に戻します
+func (m *Mapping) Restore(text string) string {
+	if m == nil || len(m.originals) == 0 || !strings.Contains(text, "[") {
+		return text
+	}
+	pairs := make([]string, 0, len(m.originals)*2)
+	for token, original := range m.originals {
+		pairs = append(pairs, token, original)
+	}
+	return strings.NewReplacer(pairs...).Replace(text)
+}
+
+// RestoreAll は文字列の配列に含まれる伏せ字を元の値に戻します
+func (m *Mapping) RestoreAll(texts []string) []string {
+	if texts == nil {
+		return nil
+	}
+	restored := make([]string, len(texts))
+	for i, text := range texts {
+		restored[i] = m.Restore(text)
+	}
+	return restored
+}
+
+// token は元の値に対応する伏せ字を返します。同じ値には同じ伏せ字を割り当てます
+func (m *Mapping) token(category Category, original string) string {
+	if t, ok := m.tokens[original]; ok {
+		return t
+	}
+	m.counts[category]++
+	t := fmt.Sprintf("[%s_%d]", strings.ToUpper(string(category)), m.counts[category])
+	m.tokens[original] = t
+	m.originals[t] = original
+	return t
+}
+
+// Redact は設定に従って本文の個人情報を伏せ字にし、対応表とともに返します
+func (r *Redactor) Redact(text string) (string, *Mapping) {
+	m := newMapping()
+
+	// メールアドレスを含むURLがあるため、URL → メールアドレスの順に処理する
+	if r.config.URL {
+		text = urlPattern.ReplaceAllStringFunc(text, func(u string) string {
+			if !hasTrackingToken(u) {
+				return u
+			}
+			return m.token(CategoryURL, u)
+		})
+	}
+	if r.config.Email {
+		text = emailPattern.ReplaceAllStringFunc(text, func(e string) string {
+			return m.token(CategoryEmail, e)
+		})
+	}
+	if r.config.Phone {
+		text = replaceSubmatch(phonePattern, text, 2, func(p string) string {
+			return m.token(CategoryPhone, p)
+		})
+	}
+	if r.config.PersonName && isCandidateMail(text) {
+		text = replaceSubmatch(namePattern, text, 2, func(n string) string {
+			return m.token(CategoryName, n)
+		})
+	}
+
+	return text, m
+}
+
+// hasTrackingToken はURLにクエリ・フラグメント・長いトークンが含まれるか判定します
+func hasTrackingToken(u string) bool {
+	return strings.ContainsAny(u, "?#") || tokenPathPattern.MatchString(u)
+}
+
+// isCandidateMail は本文が人材メールらしいか判定します
+func isCandidateMail(text string) bool {
+	for _, w := range candidateMailWords {
+		if strings.Contains(text, w) {
+			return true
+		}
+	}
+	return false
+}
+
+// replaceSubmatch は正規表現の指定したグループのみを置換します
+// 前後の文字を条件に含む正規表現で、重なった一致も取りこぼさないよう繰り返し適用します。
+func replaceSubmatch(re *regexp.Regexp, text string, group int, replace func(string) string) string {
+	var b strings.Builder
+	rest := text
+	for {
+		loc := re.FindStringSubmatchIndex(rest)
+		if loc == nil || loc[group*2] < 0 {
+			b.WriteString(rest)
+			return b.String()
+		}
+		start, end := loc[group*2], loc[group*2+1]
+		b.WriteString(rest[:start])
+		b.WriteString(replace(rest[start:end]))
+		rest = rest[end:]
+	}
+}
@@ -0,0 +1,131 @@
+package redact
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected Config
+	}{
+		{
+			name:     "空文字の場合はすべて有効になること",
+			input:    "",
+			expected: DefaultConfig(),
+		},
+		{
+			name:     "noneの場合はすべて無効になること",
+			input:    "none",
+			expected: Config{},
+		},
+		{
+			name:     "指定した種類のみ有効になること",
+			input:    "email, PHONE",
+			expected: Config{Email: true, Phone: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ParseConfig(tt.input))
+		})
+	}
+}
+
+func TestRedactor_Redact(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   Config
+		input    string
+		expected string
+	}{
+		{
+			name:     "メールアドレスを伏せ字にすること",
+			config:   Config{Email: true},
+			input:    "ご連絡は tanaka.taro@example.co.jp までお願いします。",
+			expected: "ご連絡は [EMAIL_1] までお願いします。",
+		},
+		{
+			name:     "ハイフン区切りの電話番号を伏せ字にすること",
+			config:   Config{Phone: true},
+			input:    "TEL：03-1234-5678／携帯：090-1234-5678",
+			expected: "TEL：[PHONE_1]／携帯：[PHONE_2]",
+		},
+		{
+			name:     "全角数字や括弧付きの電話番号を伏せ字にすること",
+			config:   Config{Phone: true},
+			input:    "電話 ０３（１２３４）５６７８ まで",
+			expected: "電話 [PHONE_1] まで",
+		},
+		{
+			name:     "単価や精算幅、日付は電話番号とみなさないこと",
+			config:   Config{Phone: true},
+			input:    "■単価:650000円\n■精算:140-180h\n■開始:2025/06/01\n■時間:09:00-18:00",
+			expected: "■単価:650000円\n■精算:140-180h\n■開始:2025/06/01\n■時間:09:00-18:00",
+		},
+		{
+			name:     "トラッキングトークン付きURLのみ伏せ字にすること",
+			config:   Config{URL: true},
+			input:    "詳細: https://example.com/jobs?utm_source=mail&uid=123 会社HP: https://example.com/",
+			expected: "詳細: [URL_1] 会社HP: https://example.com/",
+		},
+		{
+			name:     "長いトークンをパスに含むURLを伏せ字にすること",
+			config:   Config{URL: true},
+			input:    "配信停止: https://mail.example.com/u/AbCdEfGhIjKlMnOpQrStUvWxYz012345",
+			expected: "配信停止: [URL_1]",
+		},
+		{
+			name:     "人材メールの氏名を伏せ字にすること",
+			config:   Config{PersonName: true},
+			input:    "弊社要員のご提案です。\n■氏名：山田 太郎（30歳）\n■要員名: 佐藤花子",
+			expected: "弊社要員のご提案です。\n■氏名：[NAME_1]（30歳）\n■要員名: [NAME_2]",
+		},
+		{
+			name:     "案件メールの氏名欄は伏せ字にしないこと",
+			config:   Config{PersonName: true},
+			input:    "■案件名: Go開発\n■名前: 決済基盤刷新",
+			expected: "■案件名: Go開発\n■名前: 決済基盤刷新",
+		},
+		{
+			name:     "同じ値には同じ伏せ字を割り当てること",
+			config:   Config{Email: true},
+			input:    "a@example.com / b@example.com / a@example.com",
+			expected: "[EMAIL_1] / [EMAIL_2] / [EMAIL_1]",
+		},
+		{
+			name:     "無効な種類は伏せ字にしないこと",
+			config:   Config{},
+			input:    "a@example.com 03-1234-5678",
+			expected: "a@example.com 03-1234-5678",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, _ := New(tt.config).Redact(tt.input)
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestMapping_Restore(t *testing.T) {
+	input := "人材のご紹介です。\n氏名：鈴木 一郎\n連絡先：suzuki@example.com 080-1111-2222"
+	redacted, mapping := New(DefaultConfig()).Redact(input)
+
+	assert.NotContains(t, redacted, "鈴木")
+	assert.NotContains(t, redacted, "suzuki@example.com")
+	assert.NotContains(t, redacted, "080-1111-2222")
+	assert.Equal(t, 3, mapping.Len())
+
+	// 解析結果に伏せ字が残っていても元の値に戻せること
+	assert.Equal(t, input, mapping.Restore(redacted))
+	assert.Equal(t, []string{"鈴木 一郎さん"}, mapping.RestoreAll([]string{"[NAME_1]さん"}))
+	original, ok := mapping.Original("[EMAIL_1]")
+	assert.True(t, ok)
+	assert.Equal(t, "suzuki@example.com", original)
+}
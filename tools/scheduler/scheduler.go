@@ -0,0 +1,34 @@
+// Package scheduler は定期実行するジョブの実行ループを提供します。
+// cron などの外部スケジューラーを使わずに、コマンドを常駐させて一定間隔でジョブを実行する場合に利用します。
+package scheduler
+
+import (
+	"context"
+	"time"
+)
+
+// Job は定期実行するジョブです
+type Job func(ctx context.Context) error
+
+// Every は job を直ちに1回実行し、以降は interval ごとに ctx がキャンセルされるまで実行します
+// job がエラーを返した場合は onError に渡して実行を続けます（onError が nil の場合は無視します）。
+// 前回の実行が interval より長くかかった場合、重ねて実行せず次の間隔まで待ちます。
+func Every(ctx context.Context, interval time.Duration, job Job, onError func(error)) {
+	run := func() {
+		if err := job(ctx); err != nil && onError != nil {
+			onError(err)
+		}
+	}
+
+	run()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			run()
+		}
+	}
+}
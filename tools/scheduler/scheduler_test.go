@@ -0,0 +1,43 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvery(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	runs := 0
+	var errs []error
+	done := make(chan struct{})
+	go func() {
+		Every(ctx, 10*time.Millisecond, func(ctx context.Context) error {
+			runs++
+			if runs == 3 {
+				cancel()
+			}
+			if runs == 2 {
+				return errors.New("失敗")
+			}
+			return nil
+		}, func(err error) {
+			errs = append(errs, err)
+		})
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("キャンセル後に終了しません")
+	}
+	// エラーがあっても実行を続け、キャンセルで終了する
+	assert.Equal(t, 3, runs)
+	assert.Len(t, errs, 1)
+}
@@ -24,28 +24,30 @@ func CreateEmail(tx *gorm.DB) error {
 			ReceivedDate: time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC),
 			Body:         stringPtr("Java Spring Bootを使用したWebアプリケーション開発案件です。リモートワーク可能です。"),
 			Category:     "案件",
-			// 案件情報（EmailProject）
-			EmailProject: &model.EmailProject{
-				EmailID:         1,
-				ProjectTitle:    stringPtr("ECサイト構築プロジェクト"),
-				EntryTiming:     stringPtr("2024/02/01,2024/03/01"),
-				Languages:       stringPtr("Java,JavaScript"),
-				Frameworks:      stringPtr("Spring Boot,React"),
-				Positions:       stringPtr("SE,PG"),
-				WorkTypes:       stringPtr("バックエンド実装,フロントエンド実装"),
-				MustSkills:      stringPtr("Java経験3年以上,Spring Boot経験"),
-				WantSkills:      stringPtr("AWS経験,Docker経験"),
-				EndTiming:       stringPtr("2024年8月"),
-				WorkLocation:    stringPtr("東京都渋谷区（リモート可）"),
-				PriceFrom:       intPtr(600000),
-				PriceTo:         intPtr(800000),
-				RemoteType:      stringPtr("フルリモート可"),
-				RemoteFrequency: stringPtr("週5日"),
-			},
-			// EntryTimingsリレーション
-			EntryTimings: []model.EntryTiming{
-				{EmailID: 1, StartDate: "2024/02/01"},
-				{EmailID: 1, StartDate: "2024/03/01"},
+			// 案件情報（EmailProject。1通に複数案件が載る場合は複数件）
+			EmailProjects: []model.EmailProject{
+				{
+					ProjectKey:      "seed-email001-1",
+					ProjectTitle:    stringPtr("ECサイト構築プロジェクト"),
+					EntryTiming:     stringPtr("2024/02/01,2024/03/01"),
+					Languages:       stringPtr("Java,JavaScript"),
+					Frameworks:      stringPtr("Spring Boot,React"),
+					Positions:       stringPtr("SE,PG"),
+					WorkTypes:       stringPtr("バックエンド実装,フロントエンド実装"),
+					MustSkills:      stringPtr("Java経験3年以上,Spring Boot経験"),
+					WantSkills:      stringPtr("AWS経験,Docker経験"),
+					EndTiming:       stringPtr("2024年8月"),
+					WorkLocation:    stringPtr("東京都渋谷区（リモート可）"),
+					PriceFrom:       intPtr(600000),
+					PriceTo:         intPtr(800000),
+					RemoteType:      stringPtr("フルリモート可"),
+					RemoteFrequency: stringPtr("週5日"),
+					// EntryTimingsリレーション
+					EntryTimings: []model.EntryTiming{
+						{StartDate: "2024/02/01"},
+						{StartDate: "2024/03/01"},
+					},
+				},
 			},
 		},
 		{
@@ -65,28 +67,30 @@ func CreateEmail(tx *gorm.DB) error {
 			ReceivedDate: time.Date(2024, 1, 17, 9, 15, 0, 0, time.UTC),
 			Body:         stringPtr("Python、機械学習ライブラリを使用したAIシステム開発案件です。"),
 			Category:     "案件",
-			// 案件情報（EmailProject）
-			EmailProject: &model.EmailProject{
-				EmailID:         3,
-				ProjectTitle:    stringPtr("AIチャットボット開発"),
-				EntryTiming:     stringPtr("2024/03/01,2024/04/01"),
-				Languages:       stringPtr("Python,SQL"),
-				Frameworks:      stringPtr("TensorFlow,PyTorch,FastAPI"),
-				Positions:       stringPtr("AIエンジニア,データサイエンティスト"),
-				WorkTypes:       stringPtr("機械学習モデル開発,データ分析"),
-				MustSkills:      stringPtr("Python経験3年以上,機械学習ライブラリ経験"),
-				WantSkills:      stringPtr("深層学習経験,クラウド経験"),
-				EndTiming:       stringPtr("2024年12月"),
-				WorkLocation:    stringPtr("東京都港区"),
-				PriceFrom:       intPtr(800000),
-				PriceTo:         intPtr(1200000),
-				RemoteType:      stringPtr("出社必須"),
-				RemoteFrequency: stringPtr("週5日出社"),
-			},
-			// EntryTimingsリレーション
-			EntryTimings: []model.EntryTiming{
-				{EmailID: 3, StartDate: "2024/03/01"},
-				{EmailID: 3, StartDate: "2024/04/01"},
+			// 案件情報（EmailProject。1通に複数案件が載る場合は複数件）
+			EmailProjects: []model.EmailProject{
+				{
+					ProjectKey:      "seed-email003-1",
+					ProjectTitle:    stringPtr("AIチャットボット開発"),
+					EntryTiming:     stringPtr("2024/03/01,2024/04/01"),
+					Languages:       stringPtr("Python,SQL"),
+					Frameworks:      stringPtr("TensorFlow,PyTorch,FastAPI"),
+					Positions:       stringPtr("AIエンジニア,データサイエンティスト"),
+					WorkTypes:       stringPtr("機械学習モデル開発,データ分析"),
+					MustSkills:      stringPtr("Python経験3年以上,機械学習ライブラリ経験"),
+					WantSkills:      stringPtr("深層学習経験,クラウド経験"),
+					EndTiming:       stringPtr("2024年12月"),
+					WorkLocation:    stringPtr("東京都港区"),
+					PriceFrom:       intPtr(800000),
+					PriceTo:         intPtr(1200000),
+					RemoteType:      stringPtr("出社必須"),
+					RemoteFrequency: stringPtr("週5日出社"),
+					// EntryTimingsリレーション
+					EntryTimings: []model.EntryTiming{
+						{StartDate: "2024/03/01"},
+						{StartDate: "2024/04/01"},
+					},
+				},
 			},
 		},
 		{
@@ -97,28 +101,30 @@ func CreateEmail(tx *gorm.DB) error {
 			ReceivedDate: time.Date(2024, 1, 18, 16, 45, 0, 0, time.UTC),
 			Body:         stringPtr("Go言語でのマイクロサービス開発経験者を募集しています。"),
 			Category:     "案件",
-			// 案件情報（EmailProject）
-			EmailProject: &model.EmailProject{
-				EmailID:         4,
-				ProjectTitle:    stringPtr("マイクロサービス基盤構築"),
-				EntryTiming:     stringPtr("2024/04/01,2024/05/01"),
-				Languages:       stringPtr("Go,SQL"),
-				Frameworks:      stringPtr("Gin,gRPC,Docker"),
-				Positions:       stringPtr("バックエンドエンジニア,インフラエンジニア"),
-				WorkTypes:       stringPtr("マイクロサービス開発,API設計"),
-				MustSkills:      stringPtr("Go経験2年以上,Docker経験"),
-				WantSkills:      stringPtr("Kubernetes経験,AWS経験"),
-				EndTiming:       stringPtr("2024年10月"),
-				WorkLocation:    stringPtr("東京都千代田区"),
-				PriceFrom:       intPtr(750000),
-				PriceTo:         intPtr(1000000),
-				RemoteType:      stringPtr("フルリモート可"),
-				RemoteFrequency: stringPtr("完全リモート"),
-			},
-			// EntryTimingsリレーション
-			EntryTimings: []model.EntryTiming{
-				{EmailID: 4, StartDate: "2024/04/01"},
-				{EmailID: 4, StartDate: "2024/05/01"},
+			// 案件情報（EmailProject。1通に複数案件が載る場合は複数件）
+			EmailProjects: []model.EmailProject{
+				{
+					ProjectKey:      "seed-email004-1",
+					ProjectTitle:    stringPtr("マイクロサービス基盤構築"),
+					EntryTiming:     stringPtr("2024/04/01,2024/05/01"),
+					Languages:       stringPtr("Go,SQL"),
+					Frameworks:      stringPtr("Gin,gRPC,Docker"),
+					Positions:       stringPtr("バックエンドエンジニア,インフラエンジニア"),
+					WorkTypes:       stringPtr("マイクロサービス開発,API設計"),
+					MustSkills:      stringPtr("Go経験2年以上,Docker経験"),
+					WantSkills:      stringPtr("Kubernetes経験,AWS経験"),
+					EndTiming:       stringPtr("2024年10月"),
+					WorkLocation:    stringPtr("東京都千代田区"),
+					PriceFrom:       intPtr(750000),
+					PriceTo:         intPtr(1000000),
+					RemoteType:      stringPtr("フルリモート可"),
+					RemoteFrequency: stringPtr("完全リモート"),
+					// EntryTimingsリレーション
+					EntryTimings: []model.EntryTiming{
+						{StartDate: "2024/04/01"},
+						{StartDate: "2024/05/01"},
+					},
+				},
 			},
 		},
 		{
@@ -6,60 +6,38 @@ import (
 	"gorm.io/gorm"
 )
 
-// CreateEmailPositionGroup はメールとポジショングループの関連のサンプルデータを投入する。
+// CreateEmailPositionGroup は案件とポジショングループの関連のサンプルデータを投入する。
 func CreateEmailPositionGroup(tx *gorm.DB) error {
 	var err error
 
 	emailPositionGroups := []model.EmailPositionGroup{
-		// email001 (Java案件) の関連
+		// email001 (Java案件・案件ID 1) の関連
 		{
-			EmailID:         1,
+			EmailProjectID:  1,
 			PositionGroupID: 3, // SE
 		},
 		{
-			EmailID:         1,
+			EmailProjectID:  1,
 			PositionGroupID: 2, // PL
 		},
-		// email002 (React案件) の関連
+		// email003 (Python機械学習案件・案件ID 2) の関連
 		{
-			EmailID:         2,
-			PositionGroupID: 6, // フロントエンドエンジニア
-		},
-		{
-			EmailID:         2,
-			PositionGroupID: 3, // SE
-		},
-		// email003 (Python機械学習案件) の関連
-		{
-			EmailID:         3,
+			EmailProjectID:  2,
 			PositionGroupID: 10, // 機械学習エンジニア
 		},
 		{
-			EmailID:         3,
+			EmailProjectID:  2,
 			PositionGroupID: 9, // データエンジニア
 		},
-		// email004 (Go案件) の関連
+		// email004 (Go案件・案件ID 3) の関連
 		{
-			EmailID:         4,
+			EmailProjectID:  3,
 			PositionGroupID: 7, // バックエンドエンジニア
 		},
 		{
-			EmailID:         4,
+			EmailProjectID:  3,
 			PositionGroupID: 5, // アーキテクト
 		},
-		// email005 (フルスタック案件) の関連
-		{
-			EmailID:         5,
-			PositionGroupID: 8, // フルスタックエンジニア
-		},
-		{
-			EmailID:         5,
-			PositionGroupID: 6, // フロントエンドエンジニア
-		},
-		{
-			EmailID:         5,
-			PositionGroupID: 7, // バックエンドエンジニア
-		},
 	}
 
 	for _, emailPositionGroup := range emailPositionGroups {
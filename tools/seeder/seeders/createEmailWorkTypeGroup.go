@@ -6,94 +6,60 @@ import (
 	"gorm.io/gorm"
 )
 
-// CreateEmailWorkTypeGroup はメールと業務グループの関連のサンプルデータを投入する。
+// CreateEmailWorkTypeGroup は案件と業務グループの関連のサンプルデータを投入する。
 func CreateEmailWorkTypeGroup(tx *gorm.DB) error {
 	var err error
 
 	emailWorkTypeGroups := []model.EmailWorkTypeGroup{
-		// email001 (Java案件) の関連
+		// email001 (Java案件・案件ID 1) の関連
 		{
-			EmailID:         1,
+			EmailProjectID:  1,
 			WorkTypeGroupID: 2, // 基本設計
 		},
 		{
-			EmailID:         1,
+			EmailProjectID:  1,
 			WorkTypeGroupID: 3, // 詳細設計
 		},
 		{
-			EmailID:         1,
+			EmailProjectID:  1,
 			WorkTypeGroupID: 5, // バックエンド開発
 		},
 		{
-			EmailID:         1,
+			EmailProjectID:  1,
 			WorkTypeGroupID: 10, // 単体テスト
 		},
-		// email002 (React案件) の関連
+		// email003 (Python機械学習案件・案件ID 2) の関連
 		{
-			EmailID:         2,
-			WorkTypeGroupID: 3, // 詳細設計
-		},
-		{
-			EmailID:         2,
-			WorkTypeGroupID: 4, // フロントエンド開発
-		},
-		{
-			EmailID:         2,
-			WorkTypeGroupID: 19, // コードレビュー
-		},
-		// email003 (Python機械学習案件) の関連
-		{
-			EmailID:         3,
+			EmailProjectID:  2,
 			WorkTypeGroupID: 1, // 要件定義
 		},
 		{
-			EmailID:         3,
+			EmailProjectID:  2,
 			WorkTypeGroupID: 2, // 基本設計
 		},
 		{
-			EmailID:         3,
+			EmailProjectID:  2,
 			WorkTypeGroupID: 5, // バックエンド開発
 		},
 		{
-			EmailID:         3,
+			EmailProjectID:  2,
 			WorkTypeGroupID: 7, // データベース設計
 		},
-		// email004 (Go案件) の関連
+		// email004 (Go案件・案件ID 3) の関連
 		{
-			EmailID:         4,
+			EmailProjectID:  3,
 			WorkTypeGroupID: 2, // 基本設計
 		},
 		{
-			EmailID:         4,
-			WorkTypeGroupID: 5, // バックエンド開発
-		},
-		{
-			EmailID:         4,
-			WorkTypeGroupID: 6, // API開発
-		},
-		{
-			EmailID:         4,
-			WorkTypeGroupID: 8, // インフラ構築
-		},
-		// email005 (フルスタック案件) の関連
-		{
-			EmailID:         5,
-			WorkTypeGroupID: 3, // 詳細設計
-		},
-		{
-			EmailID:         5,
-			WorkTypeGroupID: 4, // フロントエンド開発
-		},
-		{
-			EmailID:         5,
+			EmailProjectID:  3,
 			WorkTypeGroupID: 5, // バックエンド開発
 		},
 		{
-			EmailID:         5,
+			EmailProjectID:  3,
 			WorkTypeGroupID: 6, // API開発
 		},
 		{
-			EmailID:         5,
+			EmailProjectID:  3,
 			WorkTypeGroupID: 8, // インフラ構築
 		},
 	}
@@ -0,0 +1,148 @@
+// Package textchunk はAIへ送信する本文のトークン見積もりと分割を提供します。
+package textchunk
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// DefaultTokenBudget は1チャンクあたりのトークン上限の既定値です
+const DefaultTokenBudget = 6000
+
+// boundaryPattern は一斉配信メールで案件の区切りとして使われる行を表します
+// 例: "■案件1", "【案件2】", "◆", "━━━━", "────", "====", "案件３"
+var boundaryPattern = regexp.MustCompile(`^\s*(■\s*案件\s*[0-9０-９]|【\s*案件|◆|◇|━━|──|＝＝|==|\[\s*案件|案件\s*[0-9０-９]+\s*[:：.．】)]|No\.?\s*[0-9]+)`)
+
+// EstimateTokens は本文のトークン数を概算します
+// ASCIIは4文字で1トークン、それ以外（日本語など）は1文字1トークンとして数えます。
+func EstimateTokens(text string) int {
+	ascii := 0
+	other := 0
+	for _, r := range text {
+		if r < utf8.RuneSelf {
+			ascii++
+		} else {
+			other++
+		}
+	}
+	return other + (ascii+3)/4
+}
+
+// Split は本文をトークン上限以下のチャンクに分割します
+// 案件の区切り行で分割し、上限に収まる範囲で区切りをまとめます。
+// 区切りが無い、または1案件で上限を超える場合は行単位で分割します。
+func Split(body string, budget int) []string {
+	if budget <= 0 {
+		budget = DefaultTokenBudget
+	}
+	if EstimateTokens(body) <= budget {
+		return []string{body}
+	}
+
+	var chunks []string
+	var current strings.Builder
+	currentTokens := 0
+	flush := func() {
+		if strings.TrimSpace(current.String()) != "" {
+			chunks = append(chunks, strings.TrimRight(current.String(), "\n"))
+		}
+		current.Reset()
+		currentTokens = 0
+	}
+
+	for _, section := range splitSections(body) {
+		sectionTokens := EstimateTokens(section)
+		if sectionTokens > budget {
+			// 1案件で上限を超える場合は行単位で詰める
+			flush()
+			for _, piece := range splitLines(section, budget) {
+				chunks = append(chunks, piece)
+			}
+			continue
+		}
+		if currentTokens+sectionTokens > budget {
+			flush()
+		}
+		current.WriteString(section)
+		currentTokens += sectionTokens
+	}
+	flush()
+
+	return chunks
+}
+
+// splitSections は区切り行の直前で本文を分割します
+func splitSections(body string) []string {
+	lines := strings.SplitAfter(body, "\n")
+	var sections []string
+	var current strings.Builder
+	for _, line := range lines {
+		if boundaryPattern.MatchString(line) && current.Len() > 0 {
+			sections = append(sections, current.String())
+			current.Reset()
+		}
+		current.WriteString(line)
+	}
+	if current.Len() > 0 {
+		sections = append(sections, current.String())
+	}
+	return sections
+}
+
+// splitLines は行単位で上限に収まるように分割します
+// 1行で上限を超える場合は文字単位で分割します。
+func splitLines(section string, budget int) []string {
+	var pieces []string
+	var current strings.Builder
+	currentTokens := 0
+	flush := func() {
+		if strings.TrimSpace(current.String()) != "" {
+			pieces = append(pieces, strings.TrimRight(current.String(), "\n"))
+		}
+		current.Reset()
+		currentTokens = 0
+	}
+
+	for _, line := range strings.SplitAfter(section, "\n") {
+		lineTokens := EstimateTokens(line)
+		if lineTokens > budget {
+			flush()
+			pieces = append(pieces, splitRunes(line, budget)...)
+			continue
+		}
+		if currentTokens+lineTokens > budget {
+			flush()
+		}
+		current.WriteString(line)
+		currentTokens += lineTokens
+	}
+	flush()
+
+	return pieces
+}
+
+// splitRunes は文字単位で上限に収まるように分割します
+// 長い行でも線形時間で済むよう、EstimateTokens と同じ数え方で文字数を積み上げます。
+func splitRunes(line string, budget int) []string {
+	var pieces []string
+	var current strings.Builder
+	ascii, other := 0, 0
+	for _, r := range line {
+		current.WriteRune(r)
+		if r < utf8.RuneSelf {
+			ascii++
+		} else {
+			other++
+		}
+		if other+(ascii+3)/4 >= budget {
+			pieces = append(pieces, current.String())
+			current.Reset()
+			ascii, other = 0, 0
+		}
+	}
+	if strings.TrimSpace(current.String()) != "" {
+		pieces = append(pieces, strings.TrimRight(current.String(), "\n"))
+	}
+	return pieces
+}
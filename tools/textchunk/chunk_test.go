@@ -0,0 +1,100 @@
+package textchunk
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstimateTokens(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected int
+	}{
+		{
+			name:     "空文字列の場合に0を返すこと",
+			input:    "",
+			expected: 0,
+		},
+		{
+			name:     "ASCIIは4文字で1トークンとして数えること",
+			input:    "abcdefgh",
+			expected: 2,
+		},
+		{
+			name:     "日本語は1文字1トークンとして数えること",
+			input:    "案件名",
+			expected: 3,
+		},
+		{
+			name:     "混在している場合は合算すること",
+			input:    "Go案件",
+			expected: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, EstimateTokens(tt.input))
+		})
+	}
+}
+
+func TestSplit(t *testing.T) {
+	t.Run("上限以下の場合は分割しないこと", func(t *testing.T) {
+		body := "■案件1\nGo開発\n■案件2\nPHP開発"
+		assert.Equal(t, []string{body}, Split(body, 100))
+	})
+
+	t.Run("案件の区切りで分割すること", func(t *testing.T) {
+		body := "お世話になっております。\n■案件1\nGo開発の案件です\n■案件2\nPHP開発の案件です\n■案件3\nJava開発の案件です\n"
+		actual := Split(body, 25)
+
+		assert.Equal(t, []string{
+			"お世話になっております。\n■案件1\nGo開発の案件です",
+			"■案件2\nPHP開発の案件です\n■案件3\nJava開発の案件です",
+		}, actual)
+	})
+
+	t.Run("罫線や記号の区切りでも分割すること", func(t *testing.T) {
+		body := "◆Go開発の案件です\n━━━━━━\n◆PHP開発の案件です\n"
+		actual := Split(body, 10)
+
+		assert.Equal(t, []string{"◆Go開発の案件です", "━━━━━━", "◆PHP開発の案件です"}, actual)
+	})
+
+	t.Run("1案件で上限を超える場合は行単位で分割すること", func(t *testing.T) {
+		body := "■案件1\n" + strings.Repeat("あ", 5) + "\n" + strings.Repeat("い", 8) + "\n"
+		actual := Split(body, 10)
+
+		assert.Equal(t, []string{"■案件1\n" + strings.Repeat("あ", 5), strings.Repeat("い", 8)}, actual)
+	})
+
+	t.Run("1行で上限を超える場合は文字単位で分割すること", func(t *testing.T) {
+		body := strings.Repeat("あ", 25)
+		actual := Split(body, 10)
+
+		assert.Equal(t, []string{strings.Repeat("あ", 10), strings.Repeat("あ", 10), strings.Repeat("あ", 5)}, actual)
+		for _, chunk := range actual {
+			assert.LessOrEqual(t, EstimateTokens(chunk), 10)
+		}
+	})
+
+	t.Run("ASCIIを含む長い1行も上限に収まるように分割すること", func(t *testing.T) {
+		body := strings.Repeat("Go案件abcd", 200000)
+		actual := Split(body, 1000)
+
+		assert.Equal(t, body, strings.Join(actual, ""))
+		for _, chunk := range actual[:len(actual)-1] {
+			assert.Equal(t, 1000, EstimateTokens(chunk))
+		}
+		assert.LessOrEqual(t, EstimateTokens(actual[len(actual)-1]), 1000)
+	})
+
+	t.Run("上限が0以下の場合は既定値を使うこと", func(t *testing.T) {
+		body := "■案件1\nGo開発"
+		assert.Equal(t, []string{body}, Split(body, 0))
+	})
+}